@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/loop"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// compareRun is one model's isolated run in a `run --compare` experiment.
+type compareRun struct {
+	model   string
+	branch  string // quasar/compare/<stamp>/<model>, kept after the run
+	dir     string // linked worktree checked out on branch, removed after the run
+	workDir string // where the loop runs: dir, or the same subdirectory of it the run started in
+	result  *loop.TaskResult
+	err     error
+	files   []nebula.FileChange
+}
+
+// runCompare runs task once per model, in parallel, each in its own git
+// worktree branched from HEAD so the runs cannot see or overwrite each
+// other's changes, then prints a comparison table. The worktrees are
+// removed afterwards; their branches stay so the diffs can be inspected.
+func runCompare(ctx context.Context, cfg config.Config, printer *ui.Printer, coderPrompt, reviewerPrompt string, models []string, args []string) error {
+	task := strings.Join(args, " ")
+	if task == "" {
+		scanner := bufio.NewScanner(os.Stdin)
+		if scanner.Scan() {
+			task = scanner.Text()
+		}
+	}
+	if task == "" {
+		return fmt.Errorf("no task provided to compare")
+	}
+
+	repo, err := resolveWorkDir(cfg.WorkDir)
+	if err != nil {
+		return err
+	}
+	git := nebula.NewGitCommitter(ctx, repo)
+	if git == nil {
+		return fmt.Errorf("--compare needs a git repository to create worktrees in")
+	}
+	if err := nebula.CheckCleanWorkTree(ctx, git, ".quasar"); err != nil {
+		return fmt.Errorf("compare runs branch from HEAD: %w", err)
+	}
+	root, err := os.MkdirTemp("", "quasar-compare-")
+	if err != nil {
+		return fmt.Errorf("creating worktree directory: %w", err)
+	}
+	defer os.RemoveAll(root)
+
+	// Run from the same subdirectory of each worktree as of the repository.
+	prefix, err := exec.CommandContext(ctx, "git", "-C", repo, "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return fmt.Errorf("git rev-parse: %w", err)
+	}
+	subdir := filepath.FromSlash(strings.TrimSpace(string(prefix)))
+
+	stamp := time.Now().Format("20060102-150405")
+	runs := make([]*compareRun, 0, len(models))
+	defer func() {
+		for _, r := range runs {
+			if err := removeWorktree(repo, r.dir); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: failed to remove worktree for %s: %v\n", r.model, err)
+			}
+		}
+	}()
+	for _, m := range models {
+		slug := compareSlug(m)
+		r := &compareRun{model: m, branch: "quasar/compare/" + stamp + "/" + slug, dir: filepath.Join(root, slug)}
+		r.workDir = filepath.Join(r.dir, subdir)
+		if err := addWorktree(ctx, repo, r.dir, r.branch); err != nil {
+			return err
+		}
+		runs = append(runs, r)
+	}
+
+	printer.Info(fmt.Sprintf("comparing %d models on one task: %s", len(runs), strings.Join(models, ", ")))
+	var wg sync.WaitGroup
+	for _, r := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.run(ctx, cfg, coderPrompt, reviewerPrompt, task)
+		}()
+	}
+	wg.Wait()
+
+	rows := make([]ui.ModelComparisonRow, 0, len(runs))
+	for _, r := range runs {
+		row := r.row()
+		if row.Outcome == "error" {
+			printer.Error(fmt.Sprintf("%s: %v", r.model, r.err))
+		}
+		rows = append(rows, row)
+	}
+	printer.ModelComparison(rows)
+	return ctx.Err()
+}
+
+// run executes the task with this run's model in its worktree and records
+// what the loop produced.
+func (r *compareRun) run(ctx context.Context, cfg config.Config, coderPrompt, reviewerPrompt, task string) {
+	cfg.Model = r.model
+	cfg.WorkDir = r.workDir
+	taskLoop, err := buildLoop(&cfg, ui.NewQuietLabeled(r.model), coderPrompt, reviewerPrompt)
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.result, r.err = taskLoop.RunTask(ctx, task)
+	if r.result == nil || r.result.BaseCommitSHA == "" {
+		return
+	}
+	git := nebula.NewGitCommitter(ctx, r.workDir)
+	if git == nil {
+		return
+	}
+	final := r.result.FinalCommitSHA
+	if final == "" {
+		final = "HEAD"
+	}
+	if stat, err := git.DiffStatRange(ctx, r.result.BaseCommitSHA, final); err == nil {
+		r.files = nebula.ParseDiffStat(stat)
+	}
+}
+
+// row summarizes the run for the comparison table.
+func (r *compareRun) row() ui.ModelComparisonRow {
+	row := ui.ModelComparisonRow{Model: r.model, Branch: r.branch, FilesChanged: len(r.files)}
+	for _, fc := range r.files {
+		row.LinesAdded += fc.LinesAdded
+		row.LinesRemoved += fc.LinesRemoved
+	}
+	if r.result != nil {
+		row.Cycles = r.result.CyclesUsed
+		row.CostUSD = r.result.TotalCostUSD
+		if r.result.Report != nil {
+			row.Satisfaction = r.result.Report.Satisfaction
+		}
+	}
+	switch {
+	case r.err == nil:
+		row.Approved = true
+		row.Outcome = "approved"
+	case errors.Is(r.err, loop.ErrMaxCycles):
+		row.Outcome = "max cycles"
+	case errors.Is(r.err, loop.ErrBudgetExceeded):
+		row.Outcome = "budget exceeded"
+	case errors.Is(r.err, loop.ErrReviewDisagreement):
+		row.Outcome = "disagreement"
+	case errors.Is(r.err, loop.ErrDegradingReview):
+		row.Outcome = "degrading review"
+	case errors.Is(r.err, loop.ErrNoProgress):
+		row.Outcome = "no progress"
+	case errors.Is(r.err, context.Canceled):
+		row.Outcome = "canceled"
+	default:
+		row.Outcome = "error"
+	}
+	return row
+}
+
+// parseCompareModels splits the --compare value into distinct model names.
+func parseCompareModels(value string) ([]string, error) {
+	var models []string
+	seen := make(map[string]bool)
+	for _, m := range strings.Split(value, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" || seen[compareSlug(m)] {
+			continue
+		}
+		seen[compareSlug(m)] = true
+		models = append(models, m)
+	}
+	if len(models) < 2 {
+		return nil, fmt.Errorf("--compare needs at least two distinct models, got %q", value)
+	}
+	return models, nil
+}
+
+// compareSlug turns a model name into a branch and directory segment.
+func compareSlug(model string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(model) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
+
+// addWorktree checks out a new branch at HEAD of repo into dir.
+func addWorktree(ctx context.Context, repo, dir, branch string) error {
+	out, err := exec.CommandContext(ctx, "git", "-C", repo, "worktree", "add", "-q", "-b", branch, dir, "HEAD").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree add %s: %w: %s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// removeWorktree deletes a worktree created by addWorktree, keeping its
+// branch. It runs even after the run's context is canceled.
+func removeWorktree(repo, dir string) error {
+	out, err := exec.Command("git", "-C", repo, "worktree", "remove", "--force", dir).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
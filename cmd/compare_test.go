@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+	"github.com/papapumpkin/quasar/internal/loop"
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestParseCompareModels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"opus,sonnet", []string{"opus", "sonnet"}, false},
+		{" opus , sonnet,, haiku ", []string{"opus", "sonnet", "haiku"}, false},
+		{"opus,OPUS,sonnet", []string{"opus", "sonnet"}, false},
+		{"opus", nil, true},
+		{"opus,opus", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCompareModels(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("models = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSlug(t *testing.T) {
+	t.Parallel()
+
+	for model, want := range map[string]string{
+		"claude-sonnet-4-5":    "claude-sonnet-4-5",
+		"Opus":                 "opus",
+		"vendor/model:latest/": "vendor-model-latest",
+	} {
+		if got := compareSlug(model); got != want {
+			t.Errorf("compareSlug(%q) = %q, want %q", model, got, want)
+		}
+	}
+}
+
+func TestCompareRunRow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		run          compareRun
+		wantOutcome  string
+		wantApproved bool
+	}{
+		{"approved", compareRun{result: &loop.TaskResult{CyclesUsed: 2, TotalCostUSD: 1.5, Report: &agent.ReviewReport{Satisfaction: "high"}}}, "approved", true},
+		{"max cycles", compareRun{result: &loop.TaskResult{CyclesUsed: 3}, err: loop.ErrMaxCycles}, "max cycles", false},
+		{"budget", compareRun{err: fmt.Errorf("cycle 2: %w", loop.ErrBudgetExceeded)}, "budget exceeded", false},
+		{"other error", compareRun{err: errors.New("claude not available")}, "error", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.run.model = "m"
+			tt.run.files = []nebula.FileChange{{Path: "a.go", LinesAdded: 3, LinesRemoved: 1}, {Path: "b.go", LinesAdded: 2}}
+			row := tt.run.row()
+			if row.Outcome != tt.wantOutcome || row.Approved != tt.wantApproved {
+				t.Errorf("outcome = %q approved=%v, want %q approved=%v", row.Outcome, row.Approved, tt.wantOutcome, tt.wantApproved)
+			}
+			if row.FilesChanged != 2 || row.LinesAdded != 5 || row.LinesRemoved != 1 {
+				t.Errorf("diff summary = %d files +%d -%d, want 2 files +5 -1", row.FilesChanged, row.LinesAdded, row.LinesRemoved)
+			}
+		})
+	}
+}
+
+func TestAddRemoveWorktree(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "opus")
+	ctx := context.Background()
+	if err := addWorktree(ctx, repo, dir, "quasar/compare/test/opus"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "only-here.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "only-here.txt")); !os.IsNotExist(err) {
+		t.Error("a file written in the worktree showed up in the main checkout")
+	}
+	if err := removeWorktree(repo, dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("worktree directory still exists after removal")
+	}
+	out, err := exec.Command("git", "-C", repo, "branch", "--list", "quasar/compare/test/opus").Output()
+	if err != nil || !strings.Contains(string(out), "quasar/compare/test/opus") {
+		t.Errorf("branch should be kept after removing the worktree, got %q (%v)", out, err)
+	}
+}
@@ -22,7 +22,7 @@ affected tasks and surface for human attention in the cockpit.`,
 }
 
 func init() {
-	discoveryCmd.Flags().String("kind", "", "discovery kind: entanglement_dispute, missing_dependency, file_conflict, requirements_ambiguity, budget_alert (required)")
+	discoveryCmd.Flags().String("kind", "", "discovery kind: entanglement_dispute, missing_dependency, file_conflict, requirements_ambiguity, budget_alert, stale_work (required)")
 	discoveryCmd.Flags().String("detail", "", "free-text explanation of the discovery (required)")
 	discoveryCmd.Flags().String("affects", "", "task ID affected by this discovery (optional; omit for broadcast)")
 	discoveryCmd.Flags().String("task", os.Getenv("QUASAR_TASK_ID"), "source task posting the discovery (or QUASAR_TASK_ID env)")
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/tui"
+)
+
+// etaHistory builds the TUI's ETA inputs for wg's nebula from its last
+// recorded run and its phases' estimated_minutes hints. Call it before
+// wg.Run, which starts a new metrics record.
+// An unreadable metrics file leaves every phase on the default duration.
+func etaHistory(wg *nebula.WorkerGroup, fallback time.Duration) tui.MsgETAHistory {
+	durations, err := nebula.PhaseDurations(wg.Nebula.Dir)
+	if err != nil {
+		durations = nil
+	}
+	if fallback <= 0 {
+		fallback = nebula.DefaultETAPhaseDuration
+	}
+	return tui.MsgETAHistory{
+		Durations:  durations,
+		Estimates:  nebula.PhaseEstimates(wg.Nebula.Phases),
+		Default:    fallback,
+		MaxWorkers: wg.MaxWorkers,
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// exitError carries a process exit code from a command to Execute.
+type exitError struct {
+	code int
+	err  error // reported before exiting; nil exits quietly
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// exitStatus makes cmd end with the given exit code (see nebula.ExitCode).
+// A zero code returns err unchanged. Otherwise usage is not printed, and
+// when err is nil the command exits without an error message.
+func exitStatus(cmd *cobra.Command, code int, err error) error {
+	if code == 0 {
+		return err
+	}
+	cmd.SilenceUsage = true
+	if err == nil {
+		cmd.SilenceErrors = true
+	}
+	return &exitError{code: code, err: err}
+}
@@ -24,6 +24,7 @@ var nebulaSubcmds = []nebulaSubcmd{
 		use:   "validate <path>",
 		short: "Validate a nebula directory structure and dependencies",
 		args:  cobra.ExactArgs(1),
+		flags: addNebulaValidateFlags,
 		run:   runNebulaValidate,
 	},
 	{
@@ -44,8 +45,16 @@ var nebulaSubcmds = []nebulaSubcmd{
 		use:   "show <path>",
 		short: "Display current nebula state",
 		args:  cobra.ExactArgs(1),
+		flags: addNebulaShowFlags,
 		run:   runNebulaShow,
 	},
+	{
+		use:   "attach <path>",
+		short: "Open the TUI on a nebula running headless in another terminal",
+		args:  cobra.ExactArgs(1),
+		flags: addNebulaAttachFlags,
+		run:   runNebulaAttach,
+	},
 	{
 		use:   "status <path>",
 		short: "Display metrics summary for a nebula run",
@@ -53,6 +62,40 @@ var nebulaSubcmds = []nebulaSubcmd{
 		flags: addNebulaStatusFlags,
 		run:   runNebulaStatus,
 	},
+	{
+		use:   "tail-logs <path> <phase-id>",
+		short: "Follow a phase's agent output saved by apply --save-output",
+		args:  cobra.ExactArgs(2),
+		flags: addNebulaTailLogsFlags,
+		run:   runNebulaTailLogs,
+	},
+	{
+		use:   "export <path>",
+		short: "Bundle a nebula, its state, and its metrics into a .tar.gz for sharing",
+		args:  cobra.ExactArgs(1),
+		flags: addNebulaExportFlags,
+		run:   runNebulaExport,
+	},
+	{
+		use:   "import <archive> <dir>",
+		short: "Unpack an exported nebula archive into a new directory",
+		args:  cobra.ExactArgs(2),
+		run:   runNebulaImport,
+	},
+	{
+		use:   "restore <path>",
+		short: "Replace the nebula's state file with one of its rotated backups",
+		args:  cobra.ExactArgs(1),
+		flags: addNebulaRestoreFlags,
+		run:   runNebulaRestore,
+	},
+	{
+		use:   "prune <path>",
+		short: "Remove old state backups, phase logs, phase artifacts, and metrics history",
+		args:  cobra.ExactArgs(1),
+		flags: addNebulaPruneFlags,
+		run:   runNebulaPrune,
+	},
 	{
 		use:   "generate <prompt>",
 		short: "Generate a complete nebula from a natural-language description",
@@ -60,6 +103,20 @@ var nebulaSubcmds = []nebulaSubcmd{
 		flags: addNebulaGenerateFlags,
 		run:   runNebulaGenerate,
 	},
+	{
+		use:   "note <path> <phase-id> <text>",
+		short: "Leave a note on the nebula's scratchpad (used by agents to think out loud)",
+		args:  cobra.ExactArgs(3),
+		flags: addNebulaNoteFlags,
+		run:   runNebulaNote,
+	},
+	{
+		use:   "lint-phases <path>",
+		short: "Score phase bodies for clarity with a cheap model (costs API budget)",
+		args:  cobra.ExactArgs(1),
+		flags: addNebulaLintPhasesFlags,
+		run:   runNebulaLintPhases,
+	},
 }
 
 func init() {
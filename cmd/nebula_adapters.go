@@ -8,9 +8,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"text/template"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/beads"
@@ -22,7 +24,12 @@ import (
 
 // loopAdapter wraps *loop.Loop to satisfy nebula.PhaseRunner.
 type loopAdapter struct {
-	loop *loop.Loop
+	loop    *loop.Loop
+	workDir string // nebula working directory; phases may run beneath it
+	// coderPrompt and reviewPrompt are the loop's default prompts, restored
+	// before each phase so one phase's agent profile doesn't leak into the next.
+	coderPrompt  string
+	reviewPrompt string
 }
 
 func (a *loopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID, phaseTitle, phaseDescription string, exec nebula.ResolvedExecution) (*nebula.PhaseRunnerResult, error) {
@@ -36,7 +43,13 @@ func (a *loopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID, pha
 	if exec.Model != "" {
 		a.loop.Model = exec.Model
 	}
+	a.loop.WorkDir = exec.WorkDirUnder(a.workDir)
 	a.loop.CommitSummary = phaseTitle
+	a.loop.OutputDir = exec.OutputDir
+	a.loop.SkipReview = exec.SkipReview
+	a.loop.CoderPrompt = a.coderPrompt
+	a.loop.ReviewPrompt = a.reviewPrompt
+	applyProfile(a.loop, exec)
 
 	// Enable struggle detection when auto-decomposition is active.
 	if exec.AutoDecompose {
@@ -49,6 +62,7 @@ func (a *loopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID, pha
 
 	result, err := a.loop.RunExistingTask(ctx, beadID, phaseDescription)
 	if err != nil {
+		err = phaseRunError(err)
 		if result != nil {
 			return toPhaseRunnerResult(result), err
 		}
@@ -68,6 +82,7 @@ type tuiLoopAdapter struct {
 	program          *tui.Program
 	invoker          agent.Invoker
 	beads            beads.Client
+	beadQueue        *loop.BeadQueue // shared across phases; nil = unbuffered bead hooks
 	git              loop.CycleCommitter
 	linter           loop.Linter
 	maxCycles        int
@@ -76,32 +91,61 @@ type tuiLoopAdapter struct {
 	coderPrompt      string
 	reviewPrompt     string
 	workDir          string
-	fabric           fabric.Fabric // nil when fabric is not configured
-	projectContext   string        // Deterministic project snapshot for prompt caching.
-	maxContextTokens int           // Token budget for context injection. 0 = use default.
+	fabric           fabric.Fabric      // nil when fabric is not configured
+	projectContext   string             // Deterministic project snapshot for prompt caching.
+	maxContextTokens int                // Token budget for context injection. 0 = use default.
+	escalateAfter    int                // Rejected cycles before a second reviewer is consulted. 0 disables.
+	escalationModel  string             // Model for the second reviewer. Empty uses model.
+	stopOnDegrading  bool               // Stop and hail when reviewer satisfaction keeps dropping.
+	stopOnNoProgress bool               // Stop and hail when cycles stop changing the code.
+	tester           loop.Linter        // Runs the project's tests after approval; nil disables.
+	retryOnTestFail  bool               // Run another cycle when tests fail after approval.
+	includeDiff      bool               // Show the reviewer the task's code diff.
+	reviewDiffOnly   bool               // Have the reviewer work from the diff instead of whole files.
+	autoApply        bool               // Apply trivial reviewer suggestions before the coder's next cycle.
+	coderTemplate    *template.Template // Builds the coder's task prompt; nil uses the built-in format.
+	hookQueueSize    int                // Pending bead events before the loop waits. 0 runs hooks inline.
 }
 
 func (a *tuiLoopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID, phaseTitle, phaseDescription string, exec nebula.ResolvedExecution) (*nebula.PhaseRunnerResult, error) {
 	// Create a per-phase UI bridge so messages carry the phase ID.
-	phaseUI := tui.NewPhaseUIBridge(a.program, phaseID, a.workDir)
+	workDir := exec.WorkDirUnder(a.workDir)
+	phaseUI := tui.NewPhaseUIBridge(a.program, phaseID, workDir)
+	ctx = agent.WithActivity(ctx, phaseUI.Heartbeat())
 
 	l := &loop.Loop{
 		Invoker:          a.invoker,
 		UI:               phaseUI,
 		Git:              a.git,
-		Hooks:            []loop.Hook{&loop.BeadHook{Beads: a.beads, UI: phaseUI}},
+		Hooks:            []loop.Hook{&loop.BeadHook{Beads: a.beads, UI: phaseUI, Queue: a.beadQueue}},
 		Linter:           a.linter,
 		MaxCycles:        a.maxCycles,
 		MaxBudgetUSD:     a.maxBudget,
 		Model:            a.model,
 		CoderPrompt:      a.coderPrompt,
 		ReviewPrompt:     a.reviewPrompt,
-		WorkDir:          a.workDir,
+		WorkDir:          workDir,
 		CommitSummary:    phaseTitle,
 		Fabric:           a.fabric,
 		FabricEnabled:    a.fabric != nil,
 		ProjectContext:   a.projectContext,
 		MaxContextTokens: a.maxContextTokens,
+
+		EscalateReviewAfterCycles: a.escalateAfter,
+		EscalationModel:           a.escalationModel,
+		StopOnDegradingReview:     a.stopOnDegrading,
+		StopOnNoProgress:          a.stopOnNoProgress,
+		Tester:                    a.tester,
+		RetryOnTestFailure:        a.retryOnTestFail,
+		IncludeDiffInReview:       a.includeDiff,
+		ReviewDiffOnly:            a.reviewDiffOnly,
+		AutoApplySuggestions:      a.autoApply,
+		CoderTemplate:             a.coderTemplate,
+		OutputDir:                 exec.OutputDir,
+		HookQueueSize:             a.hookQueueSize,
+		SkipReview:                exec.SkipReview,
+		ForceApproveCh:            phaseUI.ForceApproveChannel(),
+		ExtendCyclesCh:            phaseUI.ExtendCyclesChannel(),
 	}
 
 	// Apply per-phase execution overrides.
@@ -114,6 +158,7 @@ func (a *tuiLoopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID,
 	if exec.Model != "" {
 		l.Model = exec.Model
 	}
+	applyProfile(l, exec)
 
 	// Enable struggle detection when auto-decomposition is active.
 	if exec.AutoDecompose {
@@ -128,6 +173,7 @@ func (a *tuiLoopAdapter) RunExistingPhase(ctx context.Context, phaseID, beadID,
 	a.emitFabricEvents(ctx, phaseID, phaseUI)
 
 	if err != nil {
+		err = phaseRunError(err)
 		if result != nil {
 			return toPhaseRunnerResult(result), err
 		}
@@ -160,7 +206,7 @@ func (a *tuiLoopAdapter) GenerateCheckpoint(ctx context.Context, beadID, phaseDe
 		Invoker:          a.invoker,
 		UI:               phaseUI,
 		Git:              a.git,
-		Hooks:            []loop.Hook{&loop.BeadHook{Beads: a.beads, UI: phaseUI}},
+		Hooks:            []loop.Hook{&loop.BeadHook{Beads: a.beads, UI: phaseUI, Queue: a.beadQueue}},
 		Linter:           a.linter,
 		MaxCycles:        a.maxCycles,
 		MaxBudgetUSD:     a.maxBudget,
@@ -174,6 +220,34 @@ func (a *tuiLoopAdapter) GenerateCheckpoint(ctx context.Context, beadID, phaseDe
 	return l.GenerateCheckpoint(ctx, beadID, phaseDescription)
 }
 
+// applyProfile applies the prompt and tool overrides of a phase's agent
+// profile and its own tool lists to l. The profile's model arrives through
+// ResolvedExecution.Model.
+func applyProfile(l *loop.Loop, exec nebula.ResolvedExecution) {
+	p := exec.Profile
+	if p.CoderPrompt != "" {
+		l.CoderPrompt = p.CoderPrompt
+	}
+	if p.ReviewerPrompt != "" {
+		l.ReviewPrompt = p.ReviewerPrompt
+	}
+	l.CoderTools = p.CoderTools
+	if exec.AllowedTools != nil {
+		l.CoderTools = exec.AllowedTools
+	}
+	l.ReviewerTools = p.ReviewerTools
+	l.DeniedTools = exec.DeniedTools
+}
+
+// phaseRunError marks a loop budget exhaustion with nebula.ErrPhaseBudgetExceeded
+// so the worker group can offer a budget extension instead of failing outright.
+func phaseRunError(err error) error {
+	if errors.Is(err, loop.ErrBudgetExceeded) {
+		return fmt.Errorf("%w: %w", nebula.ErrPhaseBudgetExceeded, err)
+	}
+	return err
+}
+
 // toPhaseRunnerResult converts a loop.TaskResult to nebula.PhaseRunnerResult.
 func toPhaseRunnerResult(result *loop.TaskResult) *nebula.PhaseRunnerResult {
 	pr := &nebula.PhaseRunnerResult{
@@ -184,6 +258,7 @@ func toPhaseRunnerResult(result *loop.TaskResult) *nebula.PhaseRunnerResult {
 		FinalCommitSHA: result.FinalCommitSHA,
 		Decompose:      result.Decompose,
 		StruggleReason: result.StruggleReason,
+		ForceApproved:  result.ForceApproved,
 	}
 	// Convert loop.ReviewFinding to nebula.DecomposeFinding to avoid
 	// a circular dependency between the loop and nebula packages.
@@ -230,6 +305,21 @@ func (fc *fabricComponents) WorkerGroupOptions() []nebula.Option {
 	}
 }
 
+// openBeadQueue opens the on-disk buffer for bead operations under
+// workDir's .quasar directory. A run opens it once and shares it between
+// its phases' hooks, so they buffer into one queue instead of overwriting
+// each other's replays; nebulae running at once have their own work
+// directories. It returns nil — leaving bead hooks unbuffered — when a
+// leftover queue file cannot be read.
+func openBeadQueue(workDir string) *loop.BeadQueue {
+	q, err := loop.NewBeadQueue(filepath.Join(workDir, ".quasar", "beads-queue.jsonl"), 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: bead queue unavailable: %v\n", err)
+		return nil
+	}
+	return q
+}
+
 // initFabric creates the fabric infrastructure when the DAG has inter-phase
 // dependencies. When no phases have dependencies, it returns a zero-value
 // fabricComponents (all nil fields). The caller must defer fc.Close().
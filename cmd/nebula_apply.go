@@ -8,19 +8,22 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/beads"
-	"github.com/papapumpkin/quasar/internal/claude"
 	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/dirsnap"
 	"github.com/papapumpkin/quasar/internal/fabric"
 	"github.com/papapumpkin/quasar/internal/loop"
 	"github.com/papapumpkin/quasar/internal/nebula"
 	"github.com/papapumpkin/quasar/internal/snapshot"
 	"github.com/papapumpkin/quasar/internal/tui"
+	"github.com/papapumpkin/quasar/internal/tycho"
 	"github.com/papapumpkin/quasar/internal/ui"
 )
 
@@ -28,10 +31,33 @@ import (
 func addNebulaApplyFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("auto", false, "automatically start workers for ready phases")
 	cmd.Flags().Bool("watch", false, "watch for phase file changes during execution (with --auto)")
+	cmd.Flags().Duration("watch-debounce", nebula.DefaultEditDebounce, "collapse repeated edits to a phase file within this window into one refactor (with --watch)")
 	cmd.Flags().Int("max-workers", 1, "maximum concurrent workers (with --auto)")
 	cmd.Flags().Bool("no-tui", false, "disable TUI even on a TTY (use stderr output)")
 	cmd.Flags().Bool("no-splash", false, "skip the startup splash animation")
 	cmd.Flags().Int("max-context-tokens", 0, "token budget for injected context (0 = use default 10000)")
+	cmd.Flags().Bool("no-commit", false, "do not create git commits for review cycles or phases (with --auto)")
+	cmd.Flags().String("notify-webhook", "", "URL to POST gate, escalation, and completion notifications to (e.g. a Slack webhook)")
+	cmd.Flags().Duration("idle-timeout", 0, "pause the run when a TUI gate prompt goes this long without a keypress (0 = never)")
+	cmd.Flags().Float64("budget-step", nebula.DefaultBudgetStepUSD, "extra USD offered in the TUI when a phase exhausts its budget")
+	cmd.Flags().Int("state-backups", nebula.DefaultStateBackups, "previous state files to keep as nebula.state.toml.N (0 = none)")
+	cmd.Flags().String("audit-log", "", "append a JSONL audit record for every phase, gate, intervention, and cost event to this file")
+	cmd.Flags().Bool("allow-dirty", false, "start even if the working tree has uncommitted changes (with --auto)")
+	cmd.Flags().Bool("trace", false, "print a plain wave-by-wave execution trace instead of the progress dashboard (with --no-tui)")
+	cmd.Flags().Bool("phase-cache", false, "skip phases whose body, settings, and dependency outputs match their last successful run (with --auto)")
+	cmd.Flags().Bool("squash-commits", false, "squash each phase's cycle commits into one commit when it completes (with --auto and one worker)")
+	cmd.Flags().Bool("preserve-failed", false, "keep the uncommitted work of each failed phase on a quasar/failed/<phase> branch")
+	cmd.Flags().String("phase-timeout-action", "", "what a timed-out phase does when it sets no timeout_action: fail, skip, or retry (overrides execution.timeout_action)")
+	cmd.Flags().Duration("dispatch-jitter", 0, "delay each phase's start by a random amount up to this, spreading out API calls when many phases start at once (with --auto)")
+	cmd.Flags().Duration("max-runtime", 0, "stop starting phases after this long, let running ones finish, and leave the rest pending (with --auto)")
+	cmd.Flags().Bool("escalate-low-confidence", false, "raise a hail and ask a human about approvals the reviewer had low confidence in, whatever the gate mode (with --auto)")
+	cmd.Flags().Bool("deterministic", false, "dispatch phases in reproducible batches, waiting for each batch to finish (with --auto)")
+	cmd.Flags().Bool("wizard", false, "step through the plan's changes in a TUI, choosing which to apply (interactive terminals only)")
+	cmd.Flags().StringSlice("rename", nil, "treat a phase removed from the nebula as renamed, keeping its bead (old-id=new-id, repeatable)")
+	cmd.Flags().Bool("gate-stdin", false, "read gate decisions from stdin even when it is not a terminal (with --no-tui)")
+	_ = cmd.Flags().MarkHidden("gate-stdin")
+	addParamFlag(cmd)
+	cmd.Flags().Bool("save-output", false, "write each agent's output to logs/<phase>/cycleN-<role>.txt in the nebula directory, for `nebula tail-logs` (with --auto)")
 }
 
 func runNebulaApply(cmd *cobra.Command, args []string) error {
@@ -52,10 +78,21 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
 		return fmt.Errorf("validation failed")
 	}
+	params, err := launchParams(cmd, n)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
 
 	if v, _ := cmd.Flags().GetBool("verbose"); v {
 		cfg.Verbose = true
 	}
+	if url, _ := cmd.Flags().GetString("notify-webhook"); url != "" {
+		cfg.NotifyWebhook = url
+	}
+	if cmd.Flags().Changed("idle-timeout") {
+		cfg.IdleTimeout, _ = cmd.Flags().GetDuration("idle-timeout")
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -76,6 +113,19 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		workDir = wd
 	}
 
+	// Refuse to start when existing uncommitted changes would be swept into
+	// the first phase commit (or carried onto the nebula branch).
+	auto, _ := cmd.Flags().GetBool("auto")
+	noCommit, _ := cmd.Flags().GetBool("no-commit")
+	allowDirty, _ := cmd.Flags().GetBool("allow-dirty")
+	if auto && !noCommit && !allowDirty {
+		if err := checkCleanWorkTree(ctx, workDir, dir); err != nil {
+			printer.Error(err.Error())
+			printer.Info("commit or stash them first, or pass --allow-dirty (or --no-commit)")
+			return err
+		}
+	}
+
 	// Create nebula branch if in a git repo. Non-fatal if git is unavailable.
 	branchMgr, branchErr := nebula.NewBranchManager(ctx, workDir, n.Manifest.Nebula.Name)
 	if branchErr != nil {
@@ -88,6 +138,11 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 	}
 	branchName := branchMgr.Branch() // "" if branchMgr is nil (nil-safe)
 
+	if errs := nebula.ValidateWorkingDirs(n, workDir); len(errs) > 0 {
+		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
+		return fmt.Errorf("validation failed")
+	}
+
 	state, err := nebula.LoadState(dir)
 	if err != nil {
 		printer.Error(err.Error())
@@ -101,6 +156,30 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		printer.Error(err.Error())
 		return err
 	}
+	renames, _ := cmd.Flags().GetStringSlice("rename")
+	if err := applyRenameFlags(renames, plan, n, state); err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	if isStdinTTY() {
+		if err := promptRenames(os.Stdin, os.Stderr, plan, n, state); err != nil {
+			return fmt.Errorf("reading rename answer: %w", err)
+		}
+	}
+	if wizard, _ := cmd.Flags().GetBool("wizard"); wizard {
+		if !isStdinTTY() {
+			return fmt.Errorf("--wizard needs an interactive terminal")
+		}
+		plan, err = tui.RunResumeWizard(plan, n, state)
+		if errors.Is(err, tui.ErrResumeCanceled) {
+			printer.Info("apply canceled; nothing was changed")
+			return nil
+		}
+		if err != nil {
+			printer.Error(err.Error())
+			return err
+		}
+	}
 
 	printer.NebulaPlan(plan)
 
@@ -115,9 +194,11 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	printer.NebulaApplyDone(plan)
+	if err := recordAppliedPlan(n, dir, workDir); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
 
 	// --auto: start workers.
-	auto, _ := cmd.Flags().GetBool("auto")
 	if !auto {
 		return nil
 	}
@@ -137,6 +218,14 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		maxContextTokens = n.Manifest.Execution.MaxContextTokens
 	}
 
+	budgetStep, _ := cmd.Flags().GetFloat64("budget-step")
+
+	timeoutActionFlag, _ := cmd.Flags().GetString("phase-timeout-action")
+	timeoutAction := nebula.TimeoutAction(timeoutActionFlag)
+	if timeoutAction != "" && !timeoutAction.Valid() {
+		return fmt.Errorf("invalid --phase-timeout-action %q: must be fail, skip, or retry", timeoutActionFlag)
+	}
+
 	// Load custom prompts.
 	coderPrompt := agent.DefaultCoderSystemPrompt
 	if cfg.CoderSystemPrompt != "" {
@@ -146,8 +235,13 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 	if cfg.ReviewerSystemPrompt != "" {
 		reviewerPrompt = cfg.ReviewerSystemPrompt
 	}
+	coderTmpl, err := parseCoderTemplate(&cfg)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
 
-	claudeInv := claude.NewInvoker(cfg.ClaudePath, cfg.Verbose)
+	claudeInv, limiter := newClaudeInvoker(&cfg)
 	if err := claudeInv.Validate(); err != nil {
 		printer.Error(fmt.Sprintf("claude not available: %v", err))
 		return err
@@ -169,11 +263,20 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		projectCtx = scanned
 	}
 
-	git := loop.NewCycleCommitterWithBranch(ctx, workDir, branchName)
-	phaseCommitter := nebula.NewGitCommitterWithBranch(ctx, workDir, branchName)
+	git, phaseCommitter := newCommitters(ctx, workDir, dir, branchName, noCommit)
 
 	noTUI, _ := cmd.Flags().GetBool("no-tui")
 	noSplash, _ := cmd.Flags().GetBool("no-splash")
+	editDebounce, _ := cmd.Flags().GetDuration("watch-debounce")
+	stateBackups, _ := cmd.Flags().GetInt("state-backups")
+	deterministic, _ := cmd.Flags().GetBool("deterministic")
+	dispatchJitter, _ := cmd.Flags().GetDuration("dispatch-jitter")
+	maxRuntime, _ := cmd.Flags().GetDuration("max-runtime")
+	escalateLowConfidence, _ := cmd.Flags().GetBool("escalate-low-confidence")
+	phaseCache, _ := cmd.Flags().GetBool("phase-cache")
+	saveOutput, _ := cmd.Flags().GetBool("save-output")
+	squashCommits, _ := cmd.Flags().GetBool("squash-commits")
+	preserveFailed, _ := cmd.Flags().GetBool("preserve-failed")
 	useTUI := !noTUI && isStderrTTY()
 
 	// Build the runner and WorkerGroup, branching on TUI vs stderr.
@@ -185,6 +288,33 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		nebula.WithGlobalBudget(cfg.MaxBudgetUSD),
 		nebula.WithGlobalModel(cfg.Model),
 		nebula.WithCommitter(phaseCommitter),
+		nebula.WithBudgetStep(budgetStep),
+		nebula.WithNotifier(newNotifier(cfg.NotifyWebhook)),
+		nebula.WithWorkDir(workDir),
+		nebula.WithEditDebounce(editDebounce),
+		nebula.WithStateBackups(stateBackups),
+		nebula.WithDeterministic(deterministic),
+		nebula.WithDispatchJitter(dispatchJitter),
+		nebula.WithMaxRuntime(maxRuntime),
+		nebula.WithEscalateLowConfidence(escalateLowConfidence),
+		nebula.WithPhaseCache(phaseCache),
+		nebula.WithSquashPhaseCommits(squashCommits),
+		nebula.WithPreserveFailedWorktrees(preserveFailed),
+		nebula.WithTimeoutAction(timeoutAction),
+		nebula.WithParams(params),
+	}
+	if saveOutput {
+		wgOpts = append(wgOpts, nebula.WithOutputDir(nebula.OutputLogDir(dir)))
+	}
+	var auditFile *os.File
+	if auditPath, _ := cmd.Flags().GetString("audit-log"); auditPath != "" {
+		auditFile, err = os.OpenFile(auditPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			printer.Error(fmt.Sprintf("opening audit log: %v", err))
+			return err
+		}
+		defer auditFile.Close()
+		wgOpts = append(wgOpts, nebula.WithAuditLog(auditFile))
 	}
 	wgOpts = append(wgOpts, fc.WorkerGroupOptions()...)
 	wg := nebula.NewWorkerGroup(n, state, wgOpts...)
@@ -194,22 +324,27 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		phases := make([]tui.PhaseInfo, 0, len(n.Phases))
 		for _, p := range n.Phases {
 			pi := tui.PhaseInfo{
-				ID:        p.ID,
-				Title:     p.Title,
-				DependsOn: p.DependsOn,
-				PlanBody:  p.Body,
+				ID:         p.ID,
+				Title:      p.Title,
+				DependsOn:  p.DependsOn,
+				PlanBody:   p.Body,
+				SourceFile: p.SourcePath(dir),
+				Manual:     p.IsManual(),
 			}
 			if ps := state.Phases[p.ID]; ps != nil {
 				pi.Status = tui.PhaseStatusFromString(string(ps.Status))
+				pi.SkipReason = ps.SkipReason
+				pi.Attempts = ps.AttemptLog
 			}
 			phases = append(phases, pi)
 		}
-		tuiProgram = tui.NewNebulaProgram(n.Manifest.Nebula.Name, phases, dir, noSplash)
+		tuiProgram = tui.NewNebulaProgram(n.Manifest.Nebula, phases, dir, noSplash)
 		// Per-phase loops with PhaseUIBridge for hierarchical TUI tracking.
 		wg.Runner = &tuiLoopAdapter{
 			program:          tuiProgram,
 			invoker:          claudeInv,
 			beads:            client,
+			beadQueue:        openBeadQueue(workDir),
 			git:              git,
 			linter:           loop.NewLinter(cfg.LintCommands, workDir),
 			maxCycles:        cfg.MaxReviewCycles,
@@ -221,9 +356,22 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 			fabric:           wg.Fabric, // nil-safe — emitFabricEvents checks for nil
 			projectContext:   projectCtx,
 			maxContextTokens: maxContextTokens,
+			escalateAfter:    cfg.EscalateReviewAfterCycles,
+			escalationModel:  cfg.EscalationModel,
+			stopOnDegrading:  cfg.StopOnDegradingReview,
+			stopOnNoProgress: cfg.StopOnNoProgress,
+			tester:           loop.NewLinter(cfg.TestCommands, workDir),
+			retryOnTestFail:  cfg.RetryOnTestFailure,
+			includeDiff:      cfg.IncludeDiffInReview,
+			reviewDiffOnly:   cfg.ReviewDiffOnly,
+			autoApply:        cfg.AutoApplySuggestions,
+			coderTemplate:    coderTmpl,
+			hookQueueSize:    cfg.HookQueueSize,
 		}
 		wg.Logger = io.Discard
-		wg.Prompter = tui.NewGater(tuiProgram)
+		gater := tui.NewGater(tuiProgram)
+		wg.Prompter = gater
+		wg.Budget = gater
 		wg.OnProgress = func(completed, total, openBeads, closedBeads int, totalCostUSD float64) {
 			tuiProgram.Send(tui.MsgNebulaProgress{
 				Completed:    completed,
@@ -247,6 +395,27 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		wg.OnScanning = func(phaseID string) {
 			tuiProgram.Send(tui.MsgPhaseScanning{PhaseID: phaseID})
 		}
+		// Show why phases were skipped when the run stops early.
+		wg.OnSkip = func(phaseID, reason string) {
+			tuiProgram.Send(tui.MsgPhaseStatus{PhaseID: phaseID, Status: tui.PhaseSkipped, Reason: reason})
+		}
+		wg.OnPreserved = func(phaseID, location string) {
+			tuiProgram.Send(tui.MsgPhasePreserved{PhaseID: phaseID, Location: location})
+		}
+		wg.OnAttempt = func(phaseID string, rec nebula.AttemptRecord) {
+			tuiProgram.Send(tui.MsgPhaseAttempt{PhaseID: phaseID, Attempt: rec})
+		}
+		wg.OnPhaseReady = func(phaseID string) {
+			tuiProgram.Send(tui.MsgPhaseReady{PhaseID: phaseID})
+		}
+		// Surface file-level conflicts between parallel phases.
+		wg.OnConflict = func(c fabric.FileConflict) {
+			tuiProgram.Send(tui.MsgConflict{PhaseID: c.Phase, OtherPhaseID: c.Owner, File: c.File})
+		}
+		// Warn about stuck claims and blocked phases, and what was done about them.
+		wg.OnStale = func(items []tycho.StaleItem) {
+			tuiProgram.Send(tui.MsgStaleWarning{Items: items})
+		}
 		// Start telemetry bridge if a telemetry file exists.
 		telemetryPath := filepath.Join(".quasar", "telemetry", "current.jsonl")
 		if _, statErr := os.Stat(telemetryPath); statErr == nil {
@@ -255,13 +424,18 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 				defer tb.Stop()
 			}
 		}
+		// Show the notes agents leave on the scratchpad, earlier runs' included.
+		sb := tui.NewScratchpadBridge(tuiProgram, dir)
+		if startErr := sb.Start(); startErr == nil {
+			defer sb.Stop()
+		}
 	} else {
 		// Stderr path: single shared loop with Printer UI.
 		taskLoop := &loop.Loop{
 			Invoker:          claudeInv,
 			UI:               printer,
 			Git:              git,
-			Hooks:            []loop.Hook{&loop.BeadHook{Beads: client, UI: printer}},
+			Hooks:            []loop.Hook{&loop.BeadHook{Beads: client, UI: printer, Queue: openBeadQueue(workDir)}},
 			Linter:           loop.NewLinter(cfg.LintCommands, workDir),
 			MaxCycles:        cfg.MaxReviewCycles,
 			MaxBudgetUSD:     cfg.MaxBudgetUSD,
@@ -273,16 +447,36 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 			FabricEnabled:    wg.Fabric != nil,
 			ProjectContext:   projectCtx,
 			MaxContextTokens: maxContextTokens,
+
+			EscalateReviewAfterCycles: cfg.EscalateReviewAfterCycles,
+			EscalationModel:           cfg.EscalationModel,
+			StopOnDegradingReview:     cfg.StopOnDegradingReview,
+			StopOnNoProgress:          cfg.StopOnNoProgress,
+			Tester:                    loop.NewLinter(cfg.TestCommands, workDir),
+			RetryOnTestFailure:        cfg.RetryOnTestFailure,
+			IncludeDiffInReview:       cfg.IncludeDiffInReview,
+			ReviewDiffOnly:            cfg.ReviewDiffOnly,
+			AutoApplySuggestions:      cfg.AutoApplySuggestions,
+			HookQueueSize:             cfg.HookQueueSize,
+			CoderTemplate:             coderTmpl,
 		}
-		wg.Runner = &loopAdapter{loop: taskLoop}
+		wg.Runner = &loopAdapter{loop: taskLoop, workDir: workDir, coderPrompt: coderPrompt, reviewPrompt: reviewerPrompt}
 		// Stderr path: use dashboard and terminal gater.
 		isTTY := isStderrTTY()
 		dashboard := nebula.NewDashboard(os.Stderr, n, state, cfg.MaxBudgetUSD, isTTY)
 		if n.Manifest.Execution.Gate == nebula.GateModeWatch {
 			dashboard.AppendOnly = true
 		}
+		dashboard.Trace, _ = cmd.Flags().GetBool("trace")
+		dashboard.MaxWorkers = maxWorkers
 		wg.Dashboard = dashboard
 		wg.OnProgress = dashboard.ProgressCallback()
+		// Expose the run on the event socket so `quasar nebula attach` can follow it.
+		wg.EventSocket = nebula.EventSocketPath(dir)
+		// quasar serve drives headless runs it starts over stdin.
+		if gateStdin, _ := cmd.Flags().GetBool("gate-stdin"); gateStdin {
+			wg.Prompter = nebula.NewPipeGater(os.Stdin, os.Stderr)
+		}
 	}
 
 	// Always create a watcher for intervention file detection (PAUSE/STOP).
@@ -312,11 +506,19 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 			prog := tuiProgram
 			br := branchName
 			wd := workDir
+			reportRateLimit(ctx, prog, limiter)
 			go func() {
+				prog.Send(tui.MsgRefactorerReady{Refactorer: wg})
+				prog.Send(tui.MsgIdlePauserReady{Pauser: wg, Timeout: cfg.IdleTimeout})
+				prog.Send(tui.MsgSilenceLimit{Limit: cfg.SilentPhaseWarning})
+				prog.Send(etaHistory(wg, cfg.ETADefaultPhase))
+				if wg.MaxRuntime > 0 {
+					prog.Send(tui.MsgRuntimeLimit{Deadline: time.Now().Add(wg.MaxRuntime)})
+				}
 				results, runErr := wg.Run(ctx)
-				prog.Send(tui.MsgNebulaDone{Results: results, Err: runErr})
+				prog.Send(tui.MsgNebulaDone{Results: results, Err: runErr, Canceled: errors.Is(runErr, nebula.ErrCanceled)})
 				// Post-completion git workflow: commit+push, checkout main only on success.
-				if br != "" {
+				if br != "" && !noCommit {
 					allSucceeded := runErr == nil
 					gitResult := nebula.PostCompletion(context.Background(), wd, br, allSucceeded)
 					prog.Send(tui.MsgGitPostCompletion{Result: gitResult})
@@ -344,6 +546,11 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 					printer.Error(fmt.Sprintf("failed to load nebula: %v", loadErr))
 					return loadErr
 				}
+				// Launch params were given for the first nebula only.
+				if loadErr = nebula.CheckParams(nextN.Phases, nil); loadErr != nil {
+					printer.Error(loadErr.Error())
+					return loadErr
+				}
 				nextState, loadErr := nebula.LoadState(nextDir)
 				if loadErr != nil {
 					printer.Error(fmt.Sprintf("failed to load state: %v", loadErr))
@@ -361,18 +568,28 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 					printer.Error(fmt.Sprintf("failed to build plan: %v", planErr))
 					return planErr
 				}
+				// Determine work dir for next nebula.
+				nextWorkDir := workDir
+				if nextN.Manifest.Context.WorkingDir != "" {
+					nextWorkDir = nextN.Manifest.Context.WorkingDir
+				}
 				if nextPlan.HasChanges() {
 					if applyErr := nebula.Apply(ctx, nextPlan, nextN, nextState, client); applyErr != nil {
 						cancel()
 						printer.Error(fmt.Sprintf("failed to apply: %v", applyErr))
 						return applyErr
 					}
+					if recErr := recordAppliedPlan(nextN, nextDir, nextWorkDir); recErr != nil {
+						fmt.Fprintf(os.Stderr, "warning: %v\n", recErr)
+					}
 				}
-
-				// Determine work dir for next nebula.
-				nextWorkDir := workDir
-				if nextN.Manifest.Context.WorkingDir != "" {
-					nextWorkDir = nextN.Manifest.Context.WorkingDir
+				if !noCommit && !allowDirty {
+					if dirtyErr := checkCleanWorkTree(ctx, nextWorkDir, nextDir); dirtyErr != nil {
+						cancel()
+						printer.Error(dirtyErr.Error())
+						printer.Info("commit or stash them first, or pass --allow-dirty (or --no-commit)")
+						return dirtyErr
+					}
 				}
 
 				// Create/checkout branch for the next nebula.
@@ -387,6 +604,11 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 					}
 				}
 				nextBranchName := nextBranchMgr.Branch()
+				if errs := nebula.ValidateWorkingDirs(nextN, nextWorkDir); len(errs) > 0 {
+					cancel()
+					printer.NebulaValidateResult(nextN.Manifest.Nebula.Name, len(nextN.Phases), errs)
+					return fmt.Errorf("validation failed")
+				}
 
 				// Close previous fabric before creating a new one.
 				fc.Close()
@@ -400,19 +622,23 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 				phases := make([]tui.PhaseInfo, 0, len(nextN.Phases))
 				for _, p := range nextN.Phases {
 					pi := tui.PhaseInfo{
-						ID:        p.ID,
-						Title:     p.Title,
-						DependsOn: p.DependsOn,
-						PlanBody:  p.Body,
+						ID:         p.ID,
+						Title:      p.Title,
+						DependsOn:  p.DependsOn,
+						PlanBody:   p.Body,
+						SourceFile: p.SourcePath(nextDir),
+						Manual:     p.IsManual(),
 					}
 					if ps := nextState.Phases[p.ID]; ps != nil {
 						pi.Status = tui.PhaseStatusFromString(string(ps.Status))
+						pi.SkipReason = ps.SkipReason
+						pi.Attempts = ps.AttemptLog
 					}
 					phases = append(phases, pi)
 				}
 				// Create WorkerGroup first. The Runner is set after the
 				// TUI program is created (it depends on the program).
-				nextPhaseCommitter := nebula.NewGitCommitterWithBranch(ctx, nextWorkDir, nextBranchName)
+				nextGit, nextPhaseCommitter := newCommitters(ctx, nextWorkDir, nextDir, nextBranchName, noCommit)
 				nextWgOpts := []nebula.Option{
 					nebula.WithMaxWorkers(maxWorkers),
 					nebula.WithBeadsClient(client),
@@ -421,15 +647,35 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 					nebula.WithGlobalModel(cfg.Model),
 					nebula.WithLogger(io.Discard),
 					nebula.WithCommitter(nextPhaseCommitter),
+					nebula.WithBudgetStep(budgetStep),
+					nebula.WithNotifier(newNotifier(cfg.NotifyWebhook)),
+					nebula.WithWorkDir(nextWorkDir),
+					nebula.WithEditDebounce(editDebounce),
+					nebula.WithStateBackups(stateBackups),
+					nebula.WithDeterministic(deterministic),
+					nebula.WithDispatchJitter(dispatchJitter),
+					nebula.WithMaxRuntime(maxRuntime),
+					nebula.WithEscalateLowConfidence(escalateLowConfidence),
+					nebula.WithPhaseCache(phaseCache),
+					nebula.WithSquashPhaseCommits(squashCommits),
+					nebula.WithPreserveFailedWorktrees(preserveFailed),
+					nebula.WithTimeoutAction(timeoutAction),
+				}
+				if saveOutput {
+					nextWgOpts = append(nextWgOpts, nebula.WithOutputDir(nebula.OutputLogDir(nextDir)))
+				}
+				if auditFile != nil {
+					nextWgOpts = append(nextWgOpts, nebula.WithAuditLog(auditFile))
 				}
 				nextWgOpts = append(nextWgOpts, fc.WorkerGroupOptions()...)
 				wg = nebula.NewWorkerGroup(nextN, nextState, nextWgOpts...)
-				tuiProgram = tui.NewNebulaProgram(nextN.Manifest.Nebula.Name, phases, nextDir, noSplash)
+				tuiProgram = tui.NewNebulaProgram(nextN.Manifest.Nebula, phases, nextDir, noSplash)
 				wg.Runner = &tuiLoopAdapter{
 					program:          tuiProgram,
 					invoker:          claudeInv,
 					beads:            client,
-					git:              loop.NewCycleCommitterWithBranch(ctx, nextWorkDir, nextBranchName),
+					beadQueue:        openBeadQueue(nextWorkDir),
+					git:              nextGit,
 					linter:           loop.NewLinter(cfg.LintCommands, nextWorkDir),
 					maxCycles:        cfg.MaxReviewCycles,
 					maxBudget:        cfg.MaxBudgetUSD,
@@ -440,8 +686,21 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 					fabric:           wg.Fabric, // nil-safe
 					projectContext:   projectCtx,
 					maxContextTokens: maxContextTokens,
+					escalateAfter:    cfg.EscalateReviewAfterCycles,
+					escalationModel:  cfg.EscalationModel,
+					stopOnDegrading:  cfg.StopOnDegradingReview,
+					stopOnNoProgress: cfg.StopOnNoProgress,
+					tester:           loop.NewLinter(cfg.TestCommands, nextWorkDir),
+					retryOnTestFail:  cfg.RetryOnTestFailure,
+					includeDiff:      cfg.IncludeDiffInReview,
+					reviewDiffOnly:   cfg.ReviewDiffOnly,
+					autoApply:        cfg.AutoApplySuggestions,
+					coderTemplate:    coderTmpl,
+					hookQueueSize:    cfg.HookQueueSize,
 				}
-				wg.Prompter = tui.NewGater(tuiProgram)
+				gater := tui.NewGater(tuiProgram)
+				wg.Prompter = gater
+				wg.Budget = gater
 				// Re-wire OnHail for the next nebula's TUI program.
 				wg.OnHail = func(phaseID string, d fabric.Discovery) {
 					tuiProgram.Send(tui.MsgHail{PhaseID: phaseID, Discovery: d})
@@ -449,6 +708,24 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 				wg.OnScanning = func(phaseID string) {
 					tuiProgram.Send(tui.MsgPhaseScanning{PhaseID: phaseID})
 				}
+				wg.OnConflict = func(c fabric.FileConflict) {
+					tuiProgram.Send(tui.MsgConflict{PhaseID: c.Phase, OtherPhaseID: c.Owner, File: c.File})
+				}
+				wg.OnStale = func(items []tycho.StaleItem) {
+					tuiProgram.Send(tui.MsgStaleWarning{Items: items})
+				}
+				wg.OnSkip = func(phaseID, reason string) {
+					tuiProgram.Send(tui.MsgPhaseStatus{PhaseID: phaseID, Status: tui.PhaseSkipped, Reason: reason})
+				}
+				wg.OnPreserved = func(phaseID, location string) {
+					tuiProgram.Send(tui.MsgPhasePreserved{PhaseID: phaseID, Location: location})
+				}
+				wg.OnAttempt = func(phaseID string, rec nebula.AttemptRecord) {
+					tuiProgram.Send(tui.MsgPhaseAttempt{PhaseID: phaseID, Attempt: rec})
+				}
+				wg.OnPhaseReady = func(phaseID string) {
+					tuiProgram.Send(tui.MsgPhaseReady{PhaseID: phaseID})
+				}
 				wg.OnProgress = func(completed, total, openBeads, closedBeads int, totalCostUSD float64) {
 					tuiProgram.Send(tui.MsgNebulaProgress{
 						Completed:    completed,
@@ -476,13 +753,12 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 				continue
 			}
 
-			if appModel.DoneErr != nil {
-				if !errors.Is(appModel.DoneErr, nebula.ErrManualStop) {
-					printer.Error(appModel.DoneErr.Error())
-				}
-				return appModel.DoneErr
+			code := nebula.ExitCode(appModel.DoneErr, appModel.DoneResults)
+			if appModel.DoneErr != nil && !errors.Is(appModel.DoneErr, nebula.ErrManualStop) {
+				printer.Error(appModel.DoneErr.Error())
+				return exitStatus(cmd, code, appModel.DoneErr)
 			}
-			return nil
+			return exitStatus(cmd, code, nil)
 		}
 	}
 
@@ -497,19 +773,23 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 	printer.Info(fmt.Sprintf("starting workers (max %d)...", maxWorkers))
 	results, err := wg.Run(ctx)
 	printer.NebulaProgressBarDone()
-	if errors.Is(err, nebula.ErrManualStop) {
+	if errors.Is(err, nebula.ErrManualStop) || errors.Is(err, nebula.ErrCanceled) {
 		printer.NebulaWorkerResults(results)
-		return nil
+		return exitStatus(cmd, nebula.ExitManualStop, nil)
+	}
+	if errors.Is(err, nebula.ErrMaxRuntime) {
+		printer.NebulaWorkerResults(results)
+		return exitStatus(cmd, nebula.ExitMaxRuntime, nil)
 	}
 	if err != nil {
 		printer.Error(err.Error())
-		return err
+		return exitStatus(cmd, nebula.ExitCode(err, results), err)
 	}
 
 	printer.NebulaWorkerResults(results)
 
 	// Post-completion git workflow for stderr path (only reached on success).
-	if branchName != "" {
+	if branchName != "" && !noCommit {
 		gitResult := nebula.PostCompletion(context.Background(), workDir, branchName, true)
 		if gitResult.CommitErr != nil {
 			printer.Error(fmt.Sprintf("git commit failed: %v", gitResult.CommitErr))
@@ -526,5 +806,61 @@ func runNebulaApply(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return nil
+	return exitStatus(cmd, nebula.ExitCode(nil, results), nil)
+}
+
+// newCommitters builds the per-cycle and per-phase committers for workDir.
+// Outside a git repository both fall back to snapshots of the working
+// directory kept under .quasar, which leave out nebulaDir (when set) so the
+// run's own state and logs are never diffed or rolled back. Both are nil
+// when noCommit is set or the snapshot store cannot be opened; the loop and
+// worker group treat nil as "don't commit".
+func newCommitters(ctx context.Context, workDir, nebulaDir, branch string, noCommit bool) (loop.CycleCommitter, nebula.GitCommitter) {
+	if noCommit {
+		return nil, nil
+	}
+	if cycle := loop.NewCycleCommitterWithBranch(ctx, workDir, branch); cycle != nil {
+		return cycle, nebula.NewGitCommitterWithBranch(ctx, workDir, branch)
+	}
+	store, err := dirsnap.Open(workDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: not a git repository and snapshots unavailable: %v\n", err)
+		return nil, nil
+	}
+	if nebulaDir != "" {
+		store.Exclude(dirtyCheckExcludes(workDir, nebulaDir)...)
+	}
+	return loop.NewSnapshotCycleCommitter(store), nebula.NewSnapshotCommitter(store)
+}
+
+// newNotifier returns a webhook notifier for url, or nil when url is empty.
+func newNotifier(url string) nebula.Notifier {
+	if url == "" {
+		return nil
+	}
+	return nebula.NewWebhookNotifier(url)
+}
+
+// checkCleanWorkTree refuses a run that commits per phase when workDir has
+// uncommitted changes outside the paths the run writes itself. Outside a
+// git repository it always passes.
+func checkCleanWorkTree(ctx context.Context, workDir, nebulaDir string) error {
+	return nebula.CheckCleanWorkTree(ctx, nebula.NewGitCommitter(ctx, workDir), dirtyCheckExcludes(workDir, nebulaDir)...)
+}
+
+// dirtyCheckExcludes returns the paths, relative to workDir, that quasar
+// itself writes to during a run — the nebula directory (state file), when
+// it lies inside workDir, and workDir's .quasar runtime directory — so they
+// don't trip the dirty-tree check.
+func dirtyCheckExcludes(workDir, nebulaDir string) []string {
+	var excludes []string
+	absWork, errWork := filepath.Abs(workDir)
+	absNebula, errNebula := filepath.Abs(nebulaDir)
+	if errWork == nil && errNebula == nil {
+		rel, err := filepath.Rel(absWork, absNebula)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			excludes = append(excludes, filepath.ToSlash(rel))
+		}
+	}
+	return append(excludes, ".quasar")
 }
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/tui"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+func addNebulaAttachFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-splash", false, "skip the startup splash animation")
+}
+
+// runNebulaAttach connects to the event socket of a nebula running headless
+// (`nebula apply --no-tui`) and follows it in the TUI. Quitting the TUI only
+// detaches; the run keeps going.
+func runNebulaAttach(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+	dir := args[0]
+	noSplash, _ := cmd.Flags().GetBool("no-splash")
+
+	conn, err := net.Dial("unix", nebula.EventSocketPath(dir))
+	if err != nil {
+		err = fmt.Errorf("no running nebula to attach to in %s: %w", dir, err)
+		printer.Error(err.Error())
+		return err
+	}
+	defer conn.Close()
+
+	// The first event on every connection is a snapshot of the run.
+	r := bufio.NewReader(conn)
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("reading nebula snapshot: %w", err)
+	}
+	var ev nebula.StreamEvent
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return fmt.Errorf("decoding nebula snapshot: %w", err)
+	}
+	if ev.Kind != nebula.StreamEventSnapshot || ev.Snapshot == nil {
+		return fmt.Errorf("unexpected first event %q from nebula", ev.Kind)
+	}
+
+	p := tui.NewNebulaProgram(nebula.Info{Name: ev.Snapshot.Name, Icon: ev.Snapshot.Icon, Color: ev.Snapshot.Color}, tui.SnapshotPhases(ev.Snapshot), dir, noSplash)
+	progress := ev.Snapshot.Progress
+	go func() {
+		// Send blocks until the program runs, so seed progress from here.
+		p.Send(tui.MsgNebulaProgress{
+			Completed:    progress.Completed,
+			Total:        progress.Total,
+			OpenBeads:    progress.OpenBeads,
+			ClosedBeads:  progress.ClosedBeads,
+			TotalCostUSD: progress.TotalCostUSD,
+		})
+		tui.FollowEvents(r, p)
+	}()
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// addNebulaExportFlags registers flags specific to the export subcommand.
+func addNebulaExportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("out", "", "archive path to write (default <nebula-dir-name>.tar.gz)")
+}
+
+func runNebulaExport(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+	dir := args[0]
+
+	out, _ := cmd.Flags().GetString("out")
+	if out == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("resolving nebula directory: %w", err)
+		}
+		out = filepath.Base(abs) + ".tar.gz"
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	if err := nebula.Export(dir, f); err != nil {
+		f.Close()
+		os.Remove(out)
+		printer.Error(err.Error())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	printer.Info(fmt.Sprintf("exported %s to %s", dir, out))
+	return nil
+}
+
+func runNebulaImport(_ *cobra.Command, args []string) error {
+	printer := ui.New()
+	archive, dest := args[0], args[1]
+
+	f, err := os.Open(archive)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	defer f.Close()
+
+	if err := nebula.Import(f, dest); err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	printer.Info(fmt.Sprintf("imported %s into %s — try `quasar nebula status %s`", archive, dest, dest))
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/claude"
+	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// addNebulaLintPhasesFlags registers CLI flags for the lint-phases subcommand.
+func addNebulaLintPhasesFlags(cmd *cobra.Command) {
+	cmd.Flags().String("model", nebula.DefaultPhaseLintModel, "Model used to score phase bodies")
+	cmd.Flags().Float64("budget", nebula.DefaultPhaseLintBudgetUSD, "Max budget in USD for scoring uncached phases")
+}
+
+// runNebulaLintPhases implements the `quasar nebula lint-phases` command.
+// Unlike validate, it judges phase content rather than structure, scoring
+// each body with a cheap model. Unchanged phases are served from a cache.
+func runNebulaLintPhases(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	n, err := nebula.Load(args[0])
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+
+	model, _ := cmd.Flags().GetString("model")
+	budget, _ := cmd.Flags().GetFloat64("budget")
+
+	claudeInv := claude.NewInvoker(cfg.ClaudePath, cfg.Verbose)
+	if err := claudeInv.Validate(); err != nil {
+		printer.Error(fmt.Sprintf("claude CLI not available: %v", err))
+		return fmt.Errorf("claude CLI not available: %w", err)
+	}
+
+	report, err := nebula.LintPhases(cmd.Context(), claudeInv, n, nebula.PhaseLintOptions{
+		Model:        model,
+		MaxBudgetUSD: budget,
+	})
+	if err != nil {
+		printer.Error(fmt.Sprintf("phase lint failed: %v", err))
+		return fmt.Errorf("phase lint failed: %w", err)
+	}
+
+	printer.NebulaPhaseLint(n.Manifest.Nebula.Name, report)
+	return nil
+}
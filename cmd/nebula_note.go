@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// addNebulaNoteFlags registers flags specific to the note subcommand.
+func addNebulaNoteFlags(cmd *cobra.Command) {
+	cmd.Flags().String("role", "", "who is writing the note: coder or reviewer")
+}
+
+func runNebulaNote(cmd *cobra.Command, args []string) error {
+	dir, phaseID, text := args[0], args[1], args[2]
+	role, _ := cmd.Flags().GetString("role")
+
+	n, err := nebula.Load(dir)
+	if err != nil {
+		return fmt.Errorf("nebula note: %w", err)
+	}
+	if nebula.PhasesByID(n.Phases)[phaseID] == nil {
+		return fmt.Errorf("nebula note: %w: %s", nebula.ErrUnknownPhase, phaseID)
+	}
+	note := nebula.ScratchpadNote{PhaseID: phaseID, Role: role, Text: text}
+	if err := nebula.AppendScratchpadNote(dir, note); err != nil {
+		return fmt.Errorf("nebula note: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// addParamFlag registers the repeatable --param flag shared by the nebula
+// subcommands that resolve phase params.
+func addParamFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("param", nil, "set a phase param for ${params.NAME} in phase bodies (key=value, repeatable)")
+}
+
+// launchParams parses the --param flags and checks that every phase of n
+// has a value for each param it requires or references.
+func launchParams(cmd *cobra.Command, n *nebula.Nebula) (map[string]string, error) {
+	flags, _ := cmd.Flags().GetStringArray("param")
+	params, err := nebula.ParseParams(flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := nebula.CheckParams(n.Phases, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
@@ -20,6 +20,7 @@ func addNebulaPlanFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("save", false, "save the plan to <nebula-dir>/<name>.plan.json")
 	cmd.Flags().Bool("diff", false, "diff against a previously saved plan")
 	cmd.Flags().Bool("no-color", false, "disable ANSI colors in output")
+	addParamFlag(cmd)
 }
 
 func runNebulaPlan(cmd *cobra.Command, args []string) error {
@@ -36,6 +37,10 @@ func runNebulaPlan(cmd *cobra.Command, args []string) error {
 		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
 		return fmt.Errorf("validation failed")
 	}
+	if _, err := launchParams(cmd, n); err != nil {
+		printer.Error(err.Error())
+		return err
+	}
 
 	// Resolve working directory for the static scanner.
 	workDir := n.Manifest.Context.WorkingDir
@@ -127,3 +132,20 @@ func hasErrorRisks(risks []nebula.PlanRisk) bool {
 	}
 	return false
 }
+
+// recordAppliedPlan saves n's execution plan to <dir>/<name>.plan.json once
+// an apply has succeeded, so the cockpit's plan preview and `nebula plan
+// --diff` show what changed since this apply.
+func recordAppliedPlan(n *nebula.Nebula, dir, workDir string) error {
+	pe := &nebula.PlanEngine{
+		Scanner: &fabric.StaticScanner{WorkDir: workDir},
+	}
+	ep, err := pe.Plan(n)
+	if err != nil {
+		return fmt.Errorf("recording applied plan: %w", err)
+	}
+	if err := ep.Save(filepath.Join(dir, ep.Name+".plan.json")); err != nil {
+		return fmt.Errorf("recording applied plan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// defaultPruneAge is how old a run artifact must be before prune removes it
+// when no --older-than flag is given.
+const defaultPruneAge = 30 * 24 * time.Hour
+
+// addNebulaPruneFlags registers flags specific to the prune subcommand.
+func addNebulaPruneFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("older-than", defaultPruneAge, "remove artifacts last written longer ago than this (0 = no age limit)")
+	cmd.Flags().Int("keep", 0, "keep only the newest N of each kind of artifact (0 = no count limit)")
+	cmd.Flags().Bool("dry-run", false, "list what would be removed without removing it")
+}
+
+func runNebulaPrune(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+	dir := args[0]
+
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+	keep, _ := cmd.Flags().GetInt("keep")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if olderThan < 0 || keep < 0 {
+		err := fmt.Errorf("--older-than and --keep must not be negative")
+		printer.Error(err.Error())
+		return err
+	}
+
+	now := time.Now()
+	items, err := nebula.Prune(dir, nebula.PruneOptions{OlderThan: olderThan, Keep: keep, DryRun: dryRun, Now: now})
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	if len(items) == 0 {
+		printer.Info("nothing to prune")
+		return nil
+	}
+
+	verb := "removed"
+	if dryRun {
+		verb = "would remove"
+	}
+	var total int64
+	out := cmd.OutOrStdout()
+	for _, item := range items {
+		total += item.Size
+		fmt.Fprintf(out, "%s %-12s %s  (%s old, %s)\n", verb, item.Kind, item.Path, formatAge(now.Sub(item.ModTime)), formatSize(item.Size))
+	}
+	printer.Info(fmt.Sprintf("%s %d item(s), %s", verb, len(items), formatSize(total)))
+	return nil
+}
+
+// formatAge formats d in whole days, or in hours below a day.
+func formatAge(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// formatSize formats n bytes as "512 B", "3.2 KB", or "1.5 MB".
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
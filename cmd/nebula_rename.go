@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// applyRenameFlags turns each --rename old=new into an ActionRename in plan.
+func applyRenameFlags(specs []string, plan *nebula.Plan, n *nebula.Nebula, state *nebula.State) error {
+	for _, spec := range specs {
+		oldID, newID, ok := strings.Cut(spec, "=")
+		if !ok || oldID == "" || newID == "" {
+			return fmt.Errorf("invalid --rename %q: want old-id=new-id", spec)
+		}
+		if err := plan.Rename(n, state, oldID, newID); err != nil {
+			return fmt.Errorf("--rename %s: %w", spec, err)
+		}
+	}
+	return nil
+}
+
+// promptRenames asks, for each phase removed from the nebula whose bead is
+// still tracked, whether it was renamed to one of the phases the plan would
+// create. An answer maps old to new; an empty answer keeps the close.
+func promptRenames(in io.Reader, out io.Writer, plan *nebula.Plan, n *nebula.Nebula, state *nebula.State) error {
+	scanner := bufio.NewScanner(in)
+	removed, added := plan.RenameCandidates(n, state)
+	for _, oldID := range removed {
+		if len(added) == 0 {
+			return nil
+		}
+		for {
+			fmt.Fprintf(out, "phase %q was removed from the nebula (bead %s).\n", oldID, state.Phases[oldID].BeadID)
+			fmt.Fprintf(out, "  renamed to [%s]? Enter the new ID, or press Enter to close its bead: ", strings.Join(added, ", "))
+			if !scanner.Scan() {
+				return scanner.Err()
+			}
+			newID := strings.TrimSpace(scanner.Text())
+			if newID == "" {
+				break
+			}
+			if err := plan.Rename(n, state, oldID, newID); err != nil {
+				fmt.Fprintf(out, "  %v\n", err)
+				continue
+			}
+			_, added = plan.RenameCandidates(n, state)
+			break
+		}
+	}
+	return nil
+}
+
+// isStdinTTY reports whether stdin is connected to a terminal.
+func isStdinTTY() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return (fi.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestPromptRenames(t *testing.T) {
+	t.Parallel()
+
+	n := &nebula.Nebula{Phases: []nebula.PhaseSpec{{ID: "auth-v2"}, {ID: "docs"}}}
+	newState := func() *nebula.State {
+		return &nebula.State{Phases: map[string]*nebula.PhaseState{
+			"auth":   {BeadID: "b-1", Status: nebula.PhaseStatusCreated},
+			"readme": {BeadID: "b-2", Status: nebula.PhaseStatusCreated},
+		}}
+	}
+	newPlan := func() *nebula.Plan {
+		return &nebula.Plan{Actions: []nebula.Action{
+			{PhaseID: "auth-v2", Type: nebula.ActionCreate},
+			{PhaseID: "docs", Type: nebula.ActionCreate},
+			{PhaseID: "auth", Type: nebula.ActionClose},
+			{PhaseID: "readme", Type: nebula.ActionClose},
+		}}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []nebula.ActionType // per action after prompting
+	}{
+		{"keep closes", "\n\n", []nebula.ActionType{nebula.ActionCreate, nebula.ActionCreate, nebula.ActionClose, nebula.ActionClose}},
+		{"map one", "auth-v2\n\n", []nebula.ActionType{nebula.ActionRename, nebula.ActionCreate, nebula.ActionClose}},
+		{"retry bad answer", "nope\nauth-v2\ndocs\n", []nebula.ActionType{nebula.ActionRename, nebula.ActionRename}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, state := newPlan(), newState()
+			var out bytes.Buffer
+			if err := promptRenames(strings.NewReader(tt.input), &out, plan, n, state); err != nil {
+				t.Fatal(err)
+			}
+			var got []nebula.ActionType
+			for _, a := range plan.Actions {
+				got = append(got, a.Type)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("actions = %v, want %v\n%s", got, tt.want, out.String())
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("actions = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRenameFlags(t *testing.T) {
+	t.Parallel()
+
+	n := &nebula.Nebula{Phases: []nebula.PhaseSpec{{ID: "new"}}}
+	state := &nebula.State{Phases: map[string]*nebula.PhaseState{"old": {BeadID: "b-1"}}}
+	plan := &nebula.Plan{Actions: []nebula.Action{
+		{PhaseID: "new", Type: nebula.ActionCreate},
+		{PhaseID: "old", Type: nebula.ActionClose},
+	}}
+	if err := applyRenameFlags([]string{"old"}, plan, n, state); err == nil {
+		t.Error("a rename without = was accepted")
+	}
+	if err := applyRenameFlags([]string{"old=new"}, plan, n, state); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Actions) != 1 || plan.Actions[0].Type != nebula.ActionRename {
+		t.Errorf("actions = %+v", plan.Actions)
+	}
+}
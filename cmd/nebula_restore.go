@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// addNebulaRestoreFlags registers flags specific to the restore subcommand.
+func addNebulaRestoreFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("backup", 1, "which backup to restore (1 = the state before the latest save)")
+}
+
+func runNebulaRestore(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+	dir := args[0]
+
+	n, _ := cmd.Flags().GetInt("backup")
+	if n < 1 {
+		err := fmt.Errorf("--backup must be at least 1, got %d", n)
+		printer.Error(err.Error())
+		return err
+	}
+	if err := nebula.RestoreStateBackup(dir, n); err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	printer.Info(fmt.Sprintf("restored %s over the current state — try `quasar nebula status %s`", nebula.StateBackupPath(dir, n), dir))
+	return nil
+}
@@ -1,16 +1,34 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
 
 	"github.com/papapumpkin/quasar/internal/nebula"
 	"github.com/papapumpkin/quasar/internal/ui"
 )
 
-func runNebulaShow(_ *cobra.Command, args []string) error {
+// addNebulaShowFlags registers flags specific to the show subcommand.
+func addNebulaShowFlags(cmd *cobra.Command) {
+	addParamFlag(cmd)
+	cmd.Flags().String("format", "text", "output format: text, or json or yaml to stdout")
+}
+
+func runNebulaShow(cmd *cobra.Command, args []string) error {
 	printer := ui.New()
 	dir := args[0]
 
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" && format != "yaml" {
+		err := fmt.Errorf("invalid --format %q: must be text, json, or yaml", format)
+		printer.Error(err.Error())
+		return err
+	}
+
 	n, err := nebula.Load(dir)
 	if err != nil {
 		printer.Error(err.Error())
@@ -23,6 +41,35 @@ func runNebulaShow(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	printer.NebulaShow(n, state)
+	// Unlike plan and apply, show lists missing params instead of failing.
+	flags, _ := cmd.Flags().GetStringArray("param")
+	params, err := nebula.ParseParams(flags)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+
+	if format != "text" {
+		return writeShowDoc(cmd.OutOrStdout(), nebula.NewShowDoc(n, state, params), format)
+	}
+	printer.NebulaShow(n, state, params)
+	return nil
+}
+
+// writeShowDoc encodes doc to w as JSON or YAML.
+func writeShowDoc(w io.Writer, doc nebula.ShowDoc, format string) error {
+	if format == "yaml" {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("encoding nebula YAML: %w", err)
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding nebula JSON: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestWriteShowDoc(t *testing.T) {
+	t.Parallel()
+
+	doc := nebula.NewShowDoc(&nebula.Nebula{
+		Manifest: nebula.Manifest{Nebula: nebula.Info{Name: "auth"}},
+		Phases:   []nebula.PhaseSpec{{ID: "api", DependsOn: []string{"schema"}}},
+	}, &nebula.State{}, nil)
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"json", []string{`"name": "auth"`, `"id": "api"`, `"depends_on": [`, `"status": "pending"`}},
+		{"yaml", []string{"name: auth", "- id: api", "depends_on:\n      - schema", "status: pending"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := writeShowDoc(&buf, doc, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("%s output missing %q:\n%s", tt.format, want, buf.String())
+				}
+			}
+		})
+	}
+}
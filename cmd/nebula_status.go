@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -15,36 +19,39 @@ import (
 // addNebulaStatusFlags registers flags specific to the status subcommand.
 func addNebulaStatusFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("json", false, "output metrics as JSON to stdout")
+	cmd.Flags().Bool("watch", false, "redraw the summary in place until every phase is resolved")
+	cmd.Flags().Duration("interval", defaultStatusInterval, "refresh interval for --watch")
 }
 
 func runNebulaStatus(cmd *cobra.Command, args []string) error {
 	printer := ui.New()
 	dir := args[0]
 
-	n, err := nebula.Load(dir)
-	if err != nil {
-		printer.Error(err.Error())
-		return err
-	}
-
-	state, err := nebula.LoadState(dir)
-	if err != nil {
+	jsonFlag, _ := cmd.Flags().GetBool("json")
+	watchFlag, _ := cmd.Flags().GetBool("watch")
+	if jsonFlag && watchFlag {
+		err := errors.New("--json and --watch cannot be combined")
 		printer.Error(err.Error())
 		return err
 	}
 
-	metrics, history, err := nebula.LoadMetricsWithHistory(dir)
+	snap, err := loadStatusSnapshot(dir)
 	if err != nil {
 		printer.Error(err.Error())
 		return err
 	}
 
-	jsonFlag, _ := cmd.Flags().GetBool("json")
 	if jsonFlag {
-		return writeStatusJSON(os.Stdout, n, state, metrics, history)
+		return writeStatusJSON(os.Stdout, snap.nebula, snap.state, snap.metrics, snap.history)
+	}
+	if watchFlag {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watchNebulaStatus(ctx, os.Stderr, printer, dir, snap, interval)
 	}
 
-	printer.NebulaStatus(n, state, metrics, history)
+	printer.NebulaStatus(snap.nebula, snap.state, snap.metrics, snap.history)
 	return nil
 }
 
@@ -63,6 +70,9 @@ type statusJSON struct {
 	Waves       []statusWaveJSON  `json:"waves,omitempty"`
 	Phases      []statusPhaseJSON `json:"phases,omitempty"`
 	History     []statusRunJSON   `json:"history,omitempty"`
+
+	SuggestedMaxWorkers int    `json:"suggested_max_workers,omitempty"`
+	Suggestion          string `json:"suggestion,omitempty"`
 }
 
 type statusWaveJSON struct {
@@ -166,6 +176,8 @@ func writeStatusJSON(w io.Writer, n *nebula.Nebula, state *nebula.State, m *nebu
 		}
 	}
 
+	out.SuggestedMaxWorkers, out.Suggestion = nebula.SuggestMaxWorkers(history, []*nebula.Metrics{m})
+
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(out)
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/ansi"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// defaultStatusInterval is how often status --watch re-reads the nebula.
+const defaultStatusInterval = 2 * time.Second
+
+// statusSnapshot is one read of everything status renders.
+type statusSnapshot struct {
+	nebula  *nebula.Nebula
+	state   *nebula.State
+	metrics *nebula.Metrics
+	history []nebula.HistorySummary
+}
+
+// loadStatusSnapshot reads the nebula, its state and its metrics from dir.
+func loadStatusSnapshot(dir string) (*statusSnapshot, error) {
+	n, err := nebula.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	state, err := nebula.LoadState(dir)
+	if err != nil {
+		return nil, err
+	}
+	metrics, history, err := nebula.LoadMetricsWithHistory(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &statusSnapshot{nebula: n, state: state, metrics: metrics, history: history}, nil
+}
+
+// statusResolved reports whether every phase of n has reached a final
+// status, meaning the run has nothing left to do.
+func statusResolved(n *nebula.Nebula, state *nebula.State) bool {
+	if len(n.Phases) == 0 {
+		return false
+	}
+	for _, p := range n.Phases {
+		ps, ok := state.Phases[p.ID]
+		if !ok {
+			return false
+		}
+		switch ps.Status {
+		case nebula.PhaseStatusDone, nebula.PhaseStatusFailed,
+			nebula.PhaseStatusSkipped, nebula.PhaseStatusDecomposed:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// statusRunning reports whether any phase in state is in progress.
+func statusRunning(state *nebula.State) bool {
+	for _, ps := range state.Phases {
+		if ps.Status == nebula.PhaseStatusInProgress {
+			return true
+		}
+	}
+	return false
+}
+
+// runIdleReads is how many reads in a row must show no phase in progress,
+// after one was seen, before status --watch takes the run as finished. One
+// read is not enough: the state between a phase finishing and the next one
+// starting looks the same.
+const runIdleReads = 2
+
+// frameRedrawer draws multi-line frames over the previous one using
+// cursor movement, clearing any lines a shorter frame leaves behind.
+type frameRedrawer struct {
+	w     io.Writer
+	lines int // lines currently on screen from the previous frame
+}
+
+func (r *frameRedrawer) draw(frame string) {
+	var buf strings.Builder
+	if r.lines > 0 {
+		buf.WriteString(ansi.CursorUp(r.lines))
+	}
+	lines := strings.Split(strings.TrimSuffix(frame, "\n"), "\n")
+	for _, line := range lines {
+		buf.WriteString(ansi.ClearLine + line + "\n")
+	}
+	for i := len(lines); i < r.lines; i++ {
+		buf.WriteString(ansi.ClearLine + "\n")
+	}
+	r.lines = max(r.lines, len(lines))
+	fmt.Fprint(r.w, buf.String())
+}
+
+// watchNebulaStatus redraws the status summary for dir in place every
+// interval, starting from snap, until every phase is resolved, the run it
+// was following has finished with phases left over, or ctx is canceled. The
+// run counts as finished once a phase was seen in progress and then none was
+// for runIdleReads reads in a row. A read that fails — typically a state or metrics file caught
+// mid-write — keeps the last good frame and is retried on the next tick.
+func watchNebulaStatus(ctx context.Context, w io.Writer, printer *ui.Printer, dir string, snap *statusSnapshot, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultStatusInterval
+	}
+	r := &frameRedrawer{w: w}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var readErr error
+	sawRun, idle := false, 0
+	for {
+		done := statusResolved(snap.nebula, snap.state)
+		if statusRunning(snap.state) {
+			sawRun, idle = true, 0
+		} else if sawRun && readErr == nil {
+			idle++
+		}
+		finished := sawRun && idle >= runIdleReads
+
+		var frame bytes.Buffer
+		printer.WriteNebulaStatus(&frame, snap.nebula, snap.state, snap.metrics, snap.history)
+		switch {
+		case done:
+			frame.WriteString("  all phases resolved\n")
+		case finished:
+			frame.WriteString("  run finished with phases unresolved\n")
+		case readErr != nil:
+			fmt.Fprintf(&frame, "  read failed, retrying in %s: %v\n", interval, readErr)
+		default:
+			fmt.Fprintf(&frame, "  watching — refreshing every %s (Ctrl+C to stop)\n", interval)
+		}
+		r.draw(frame.String())
+		if done || finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		next, err := loadStatusSnapshot(dir)
+		readErr = err
+		if err == nil {
+			snap = next
+		}
+	}
+}
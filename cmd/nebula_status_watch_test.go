@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/ansi"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+func TestStatusResolved(t *testing.T) {
+	t.Parallel()
+
+	neb := &nebula.Nebula{Phases: []nebula.PhaseSpec{{ID: "a"}, {ID: "b"}}}
+	tests := []struct {
+		name   string
+		neb    *nebula.Nebula
+		phases map[string]*nebula.PhaseState
+		want   bool
+	}{
+		{"no phases", &nebula.Nebula{}, nil, false},
+		{"not started", neb, nil, false},
+		{"one in progress", neb, map[string]*nebula.PhaseState{
+			"a": {Status: nebula.PhaseStatusDone},
+			"b": {Status: nebula.PhaseStatusInProgress},
+		}, false},
+		{"one missing", neb, map[string]*nebula.PhaseState{
+			"a": {Status: nebula.PhaseStatusDone},
+		}, false},
+		{"done and failed", neb, map[string]*nebula.PhaseState{
+			"a": {Status: nebula.PhaseStatusDone},
+			"b": {Status: nebula.PhaseStatusFailed},
+		}, true},
+		{"skipped and decomposed", neb, map[string]*nebula.PhaseState{
+			"a": {Status: nebula.PhaseStatusSkipped},
+			"b": {Status: nebula.PhaseStatusDecomposed},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := statusResolved(tt.neb, &nebula.State{Phases: tt.phases}); got != tt.want {
+				t.Errorf("statusResolved = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameRedrawer(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r := &frameRedrawer{w: &buf}
+
+	r.draw("one\ntwo\nthree\n")
+	want := ansi.ClearLine + "one\n" + ansi.ClearLine + "two\n" + ansi.ClearLine + "three\n"
+	if buf.String() != want || r.lines != 3 {
+		t.Fatalf("first frame: lines = %d, output %q, want %q", r.lines, buf.String(), want)
+	}
+
+	buf.Reset()
+	r.draw("only\n")
+	want = ansi.CursorUp(3) + ansi.ClearLine + "only\n" + ansi.ClearLine + "\n" + ansi.ClearLine + "\n"
+	if buf.String() != want {
+		t.Errorf("shorter frame output = %q, want %q", buf.String(), want)
+	}
+	if r.lines != 3 {
+		t.Errorf("lines = %d, want 3 (cleared lines stay on screen)", r.lines)
+	}
+}
+
+func TestWatchNebulaStatus(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := "[nebula]\nname = \"watch\"\n"
+	phase := "+++\nid = \"a\"\ntitle = \"A\"\n+++\nDo stuff.\n"
+	if err := os.WriteFile(filepath.Join(dir, "nebula.toml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte(phase), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := loadStatusSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watchNebulaStatus(context.Background(), &out, ui.New(), dir, snap, 5*time.Millisecond)
+	}()
+
+	// A state file caught mid-write must not end the watch.
+	if err := os.WriteFile(filepath.Join(dir, "nebula.state.toml"), []byte("version = 1\n[phases.a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	state := &nebula.State{Version: 1, Phases: map[string]*nebula.PhaseState{"a": {Status: nebula.PhaseStatusDone}}}
+	if err := nebula.SaveState(dir, state); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("watchNebulaStatus: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not exit after every phase resolved")
+	}
+	got := out.String()
+	for _, want := range []string{"read failed, retrying", "all phases resolved", ansi.ClearLine} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestWatchNebulaStatusRunFinished(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := "[nebula]\nname = \"watch\"\n"
+	for name, body := range map[string]string{
+		"nebula.toml": manifest,
+		"a.md":        "+++\nid = \"a\"\ntitle = \"A\"\n+++\nDo stuff.\n",
+		"b.md":        "+++\nid = \"b\"\ntitle = \"B\"\ndepends_on = [\"a\"]\n+++\nDo more.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	running := &nebula.State{Version: 1, Phases: map[string]*nebula.PhaseState{"a": {Status: nebula.PhaseStatusInProgress}}}
+	if err := nebula.SaveState(dir, running); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := loadStatusSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watchNebulaStatus(context.Background(), &out, ui.New(), dir, snap, 5*time.Millisecond)
+	}()
+
+	// The run stops after a leaves b pending, e.g. at a STOP file.
+	time.Sleep(20 * time.Millisecond)
+	stopped := &nebula.State{Version: 1, Phases: map[string]*nebula.PhaseState{"a": {Status: nebula.PhaseStatusDone}}}
+	if err := nebula.SaveState(dir, stopped); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("watchNebulaStatus: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not exit after the run finished with a phase pending")
+	}
+	if !strings.Contains(out.String(), "run finished with phases unresolved") {
+		t.Errorf("output missing the finished notice:\n%s", out.String())
+	}
+}
+
+func TestWatchNebulaStatusCanceled(t *testing.T) {
+	t.Parallel()
+
+	snap := &statusSnapshot{
+		nebula: &nebula.Nebula{Phases: []nebula.PhaseSpec{{ID: "a"}}},
+		state:  &nebula.State{Phases: map[string]*nebula.PhaseState{}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := watchNebulaStatus(ctx, &bytes.Buffer{}, ui.New(), t.TempDir(), snap, time.Hour); err != nil {
+		t.Errorf("watchNebulaStatus after cancel = %v, want nil", err)
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// defaultTailInterval is how often tail-logs checks for new output.
+const defaultTailInterval = 500 * time.Millisecond
+
+func addNebulaTailLogsFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-follow", false, "print the phase's logs so far and exit")
+	cmd.Flags().Duration("interval", defaultTailInterval, "how often to check for new output")
+}
+
+// runNebulaTailLogs prints a phase's agent output logs, written by
+// `nebula apply --save-output`, and follows them until the phase reaches a
+// final status or the user interrupts.
+func runNebulaTailLogs(cmd *cobra.Command, args []string) error {
+	printer := ui.New()
+	dir, phaseID := args[0], args[1]
+
+	n, err := nebula.Load(dir)
+	if err != nil {
+		printer.Error(err.Error())
+		return err
+	}
+	if !hasPhase(n, phaseID) {
+		err := fmt.Errorf("%w: %s", nebula.ErrUnknownPhase, phaseID)
+		printer.Error(err.Error())
+		return err
+	}
+
+	t := &logTailer{w: os.Stdout, dir: dir, phaseID: phaseID, offsets: make(map[string]int64)}
+	if err := t.poll(); err != nil {
+		return err
+	}
+	if noFollow, _ := cmd.Flags().GetBool("no-follow"); noFollow {
+		return nil
+	}
+	if t.current == "" {
+		fmt.Fprintf(os.Stderr, "waiting for output from %s in %s\n", phaseID, filepath.Join(nebula.OutputLogDir(dir), phaseID))
+	}
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return t.follow(ctx, interval)
+}
+
+// hasPhase reports whether n declares a phase with the given ID.
+func hasPhase(n *nebula.Nebula, phaseID string) bool {
+	for _, p := range n.Phases {
+		if p.ID == phaseID {
+			return true
+		}
+	}
+	return false
+}
+
+// logTailer copies a phase's output logs to w, remembering how much of each
+// file it has already printed.
+type logTailer struct {
+	w       io.Writer
+	dir     string
+	phaseID string
+	offsets map[string]int64
+	current string // log file the last output came from
+}
+
+// poll prints whatever has been appended to the phase's logs since the last
+// call, with a header each time the output switches files.
+func (t *logTailer) poll() error {
+	paths, err := nebula.PhaseOutputLogs(t.dir, t.phaseID)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := t.copyNew(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyNew prints the part of path past its recorded offset.
+func (t *logTailer) copyNew(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening output log: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading output log: %w", err)
+	}
+	offset := t.offsets[path]
+	if info.Size() <= offset {
+		return nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("reading output log: %w", err)
+	}
+	if path != t.current {
+		fmt.Fprintf(t.w, "\n==> %s <==\n", filepath.Base(path))
+		t.current = path
+	}
+	n, err := io.Copy(t.w, f)
+	t.offsets[path] = offset + n
+	if err != nil {
+		return fmt.Errorf("reading output log: %w", err)
+	}
+	return nil
+}
+
+// follow polls every interval until ctx is canceled or the phase reaches a
+// final status, draining any output written before it finished.
+func (t *logTailer) follow(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultTailInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		finished := t.phaseFinished()
+		if err := t.poll(); err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+	}
+}
+
+// phaseFinished reports whether the phase has a final status in the
+// nebula's state file. A state file that cannot be read counts as running.
+func (t *logTailer) phaseFinished() bool {
+	state, err := nebula.LoadState(t.dir)
+	if err != nil {
+		return false
+	}
+	ps, ok := state.Phases[t.phaseID]
+	if !ok {
+		return false
+	}
+	switch ps.Status {
+	case nebula.PhaseStatusDone, nebula.PhaseStatusFailed,
+		nebula.PhaseStatusSkipped, nebula.PhaseStatusDecomposed:
+		return true
+	}
+	return false
+}
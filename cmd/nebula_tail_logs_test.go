@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestLogTailerPrintsOnlyNewOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	phaseDir := filepath.Join(nebula.OutputLogDir(dir), "api")
+	if err := os.MkdirAll(phaseDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	appendTo := func(name, text string) {
+		t.Helper()
+		f, err := os.OpenFile(filepath.Join(phaseDir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	tl := &logTailer{w: &buf, dir: dir, phaseID: "api", offsets: make(map[string]int64)}
+	if err := tl.poll(); err != nil || buf.Len() != 0 {
+		t.Fatalf("poll with no logs: %q, %v", buf.String(), err)
+	}
+
+	appendTo("cycle1-coder.txt", "coder started\n")
+	if err := tl.poll(); err != nil {
+		t.Fatal(err)
+	}
+	appendTo("cycle1-coder.txt", "coder output\n")
+	appendTo("cycle1-reviewer.txt", "reviewer output\n")
+	if err := tl.poll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "\n==> cycle1-coder.txt <==\ncoder started\ncoder output\n\n==> cycle1-reviewer.txt <==\nreviewer output\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if strings.Count(buf.String(), "coder started") != 1 {
+		t.Error("output repeated after a poll with nothing new")
+	}
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -90,6 +93,36 @@ func TestWriteStatusJSON_WritesToWriter(t *testing.T) {
 	}
 }
 
+func TestWriteStatusJSON_SuggestsMaxWorkers(t *testing.T) {
+	t.Parallel()
+
+	neb := &nebula.Nebula{Manifest: nebula.Manifest{Nebula: nebula.Info{Name: "tuning"}}}
+	state := &nebula.State{Phases: map[string]*nebula.PhaseState{}}
+	m := &nebula.Metrics{
+		MaxWorkers: 8,
+		Waves: []nebula.WaveMetrics{
+			{EffectiveParallelism: 2, PhaseCount: 2, TotalDuration: 4 * time.Minute},
+		},
+	}
+	history := make([]nebula.HistorySummary, 3)
+
+	var buf bytes.Buffer
+	if err := writeStatusJSON(&buf, neb, state, m, history); err != nil {
+		t.Fatalf("writeStatusJSON: %v", err)
+	}
+
+	var result statusJSON
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if result.SuggestedMaxWorkers != 3 {
+		t.Errorf("SuggestedMaxWorkers = %d, want 3", result.SuggestedMaxWorkers)
+	}
+	if result.Suggestion == "" {
+		t.Error("Suggestion should be set")
+	}
+}
+
 func TestWriteStatusJSON_NilMetrics(t *testing.T) {
 	t.Parallel()
 
@@ -126,3 +159,84 @@ func TestWriteStatusJSON_NilMetrics(t *testing.T) {
 		t.Errorf("TotalCost = %f, want 1.00", result.TotalCost)
 	}
 }
+
+func TestDirtyCheckExcludes(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	outside := t.TempDir()
+	tests := []struct {
+		name      string
+		nebulaDir string
+		want      []string
+	}{
+		{"nebula inside work dir", filepath.Join(work, ".nebulas", "auth"), []string{".nebulas/auth", ".quasar"}},
+		{"nebula outside work dir", outside, []string{".quasar"}},
+		{"nebula is the work dir", work, []string{".quasar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// .quasar is always the work dir's own, whatever the process cwd.
+			got := dirtyCheckExcludes(work, tt.nebulaDir)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dirtyCheckExcludes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenBeadQueue(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if q := openBeadQueue(dir); q == nil || q.Len() != 0 {
+		t.Fatalf("openBeadQueue = %v, want an empty queue", q)
+	}
+
+	// A corrupt leftover file leaves hooks unbuffered, and a later open
+	// reads the file again rather than reusing the failure.
+	path := filepath.Join(dir, ".quasar", "beads-queue.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("not json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if q := openBeadQueue(dir); q != nil {
+		t.Fatalf("openBeadQueue with a corrupt file = %v, want nil", q)
+	}
+	if err := os.WriteFile(path, []byte(`{"kind":"comment","bead_id":"b1","body":"c1"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if q := openBeadQueue(dir); q == nil || q.Len() != 1 {
+		t.Errorf("openBeadQueue after repair = %v, want the leftover operation", q)
+	}
+}
+
+func TestRecordAppliedPlan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "nebula.toml"), []byte("[nebula]\nname = \"rec\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("+++\nid = \"a\"\ntitle = \"A\"\n+++\nDo stuff.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n, err := nebula.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recordAppliedPlan(n, dir, dir); err != nil {
+		t.Fatalf("recordAppliedPlan: %v", err)
+	}
+	plan, err := nebula.LoadPlan(filepath.Join(dir, "rec.plan.json"))
+	if err != nil {
+		t.Fatalf("applied plan not recorded: %v", err)
+	}
+	if plan.Name != "rec" {
+		t.Errorf("recorded plan name = %q, want rec", plan.Name)
+	}
+}
@@ -5,14 +5,24 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/papapumpkin/quasar/internal/config"
 	"github.com/papapumpkin/quasar/internal/nebula"
 	"github.com/papapumpkin/quasar/internal/ui"
 )
 
-func runNebulaValidate(_ *cobra.Command, args []string) error {
+func addNebulaValidateFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("strict", false, "also flag phase bodies that mention a phase missing from depends_on")
+}
+
+func runNebulaValidate(cmd *cobra.Command, args []string) error {
 	printer := ui.New()
 	dir := args[0]
 
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	n, err := nebula.Load(dir)
 	if err != nil {
 		printer.Error(err.Error())
@@ -20,6 +30,17 @@ func runNebulaValidate(_ *cobra.Command, args []string) error {
 	}
 
 	errs := nebula.Validate(n)
+	if len(errs) == 0 {
+		// Phase working dirs are checked where the nebula would run.
+		workDir, err := nebulaWorkDir(cfg, n)
+		if err != nil {
+			return err
+		}
+		errs = nebula.ValidateWorkingDirs(n, workDir)
+		if strict, _ := cmd.Flags().GetBool("strict"); strict {
+			errs = append(errs, nebula.UndeclaredDependencies(n)...)
+		}
+	}
 	if len(errs) > 0 {
 		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
 		return fmt.Errorf("validation failed with %d error(s)", len(errs))
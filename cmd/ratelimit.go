@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+	"github.com/papapumpkin/quasar/internal/claude"
+	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/tui"
+)
+
+// newClaudeInvoker returns the Claude CLI invoker, wrapped in a shared rate
+// limiter when rate_limit_rpm is configured. The limiter is nil when
+// invocations are unlimited.
+func newClaudeInvoker(cfg *config.Config) (agent.Invoker, *agent.RateLimiter) {
+	limiter := agent.NewRateLimiter(cfg.RateLimitRPM, cfg.RateLimitTPM)
+	return agent.WithRateLimiter(claude.NewInvoker(cfg.ClaudePath, cfg.Verbose), limiter), limiter
+}
+
+// reportRateLimit sends the limiter's utilization to the TUI every second
+// until ctx is done. A nil limiter makes this a no-op.
+func reportRateLimit(ctx context.Context, p *tui.Program, limiter *agent.RateLimiter) {
+	if limiter == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Send(tui.MsgRateLimit{Utilization: limiter.Utilization(), Throttled: limiter.Throttled()})
+			}
+		}
+	}()
+}
@@ -9,12 +9,12 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/beads"
-	"github.com/papapumpkin/quasar/internal/claude"
 	"github.com/papapumpkin/quasar/internal/config"
 	"github.com/papapumpkin/quasar/internal/loop"
 	"github.com/papapumpkin/quasar/internal/snapshot"
@@ -36,8 +36,10 @@ func init() {
 	runCmd.Flags().Bool("auto", false, "run a single task from stdin and exit (non-interactive)")
 	runCmd.Flags().Bool("no-tui", false, "disable TUI even on a TTY (use stderr printer)")
 	runCmd.Flags().Bool("no-splash", false, "skip the startup splash animation")
+	runCmd.Flags().Bool("quiet", false, "print only periodic progress until an error, then the recent context (stderr printer)")
 	runCmd.Flags().Bool("project-context", false, "scan and inject project context into agent prompts for caching")
 	runCmd.Flags().Int("max-context-tokens", 0, "token budget for injected context (0 = use default 10000)")
+	runCmd.Flags().String("compare", "", "run one task with each of these comma-separated models in isolated git worktrees and compare the results")
 
 	rootCmd.AddCommand(runCmd)
 }
@@ -61,13 +63,28 @@ func runRun(cmd *cobra.Command, args []string) error {
 	noSplash, _ := cmd.Flags().GetBool("no-splash")
 	useProjectCtx, _ := cmd.Flags().GetBool("project-context")
 	maxContextTokens, _ := cmd.Flags().GetInt("max-context-tokens")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	if v, _ := cmd.Flags().GetString("compare"); v != "" {
+		models, err := parseCompareModels(v)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := setupSignalContext(printer)
+		defer cancel()
+		return runCompare(ctx, cfg, printer, coderPrompt, reviewerPrompt, models, args)
+	}
 
 	// TUI path: auto mode on a TTY without --no-tui.
 	if auto && !noTUI && isStderrTTY() {
 		return runAutoTUI(cfg, printer, coderPrompt, reviewerPrompt, noSplash, useProjectCtx, maxContextTokens, args)
 	}
 
-	taskLoop, err := buildLoop(&cfg, printer, coderPrompt, reviewerPrompt)
+	var loopUI ui.UI = printer
+	if quiet {
+		loopUI = ui.NewQuiet()
+	}
+	taskLoop, err := buildLoop(&cfg, loopUI, coderPrompt, reviewerPrompt)
 	if err != nil {
 		return err
 	}
@@ -145,7 +162,7 @@ func runAutoTUI(cfg config.Config, printer *ui.Printer, coderPrompt, reviewerPro
 
 	// After TUI exits, report result to stderr.
 	if m, ok := finalModel.(tui.AppModel); ok && m.DoneErr != nil {
-		if !errors.Is(m.DoneErr, loop.ErrMaxCycles) && !errors.Is(m.DoneErr, loop.ErrBudgetExceeded) {
+		if !errors.Is(m.DoneErr, loop.ErrMaxCycles) && !errors.Is(m.DoneErr, loop.ErrBudgetExceeded) && !errors.Is(m.DoneErr, loop.ErrReviewDisagreement) && !errors.Is(m.DoneErr, loop.ErrDegradingReview) && !errors.Is(m.DoneErr, loop.ErrNoProgress) {
 			printer.Error(m.DoneErr.Error())
 		}
 		return m.DoneErr
@@ -194,10 +211,25 @@ func loadPrompts(cmd *cobra.Command, cfg *config.Config) (coder, reviewer string
 	return coder, reviewer, nil
 }
 
+// parseCoderTemplate parses the coder_template setting, so a bad template
+// stops the command before any agent runs.
+func parseCoderTemplate(cfg *config.Config) (*template.Template, error) {
+	tmpl, err := loop.ParseCoderTemplate(cfg.CoderTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coder_template: %w", err)
+	}
+	return tmpl, nil
+}
+
 // buildLoop validates dependencies, resolves the working directory, and
 // constructs a Loop ready to execute tasks.
 func buildLoop(cfg *config.Config, uiHandler ui.UI, coderPrompt, reviewerPrompt string) (*loop.Loop, error) {
-	claudeInv := claude.NewInvoker(cfg.ClaudePath, cfg.Verbose)
+	coderTmpl, err := parseCoderTemplate(cfg)
+	if err != nil {
+		uiHandler.Error(err.Error())
+		return nil, err
+	}
+	claudeInv, _ := newClaudeInvoker(cfg)
 	if err := claudeInv.Validate(); err != nil {
 		uiHandler.Error(fmt.Sprintf("claude not available: %v", err))
 		return nil, err
@@ -214,9 +246,9 @@ func buildLoop(cfg *config.Config, uiHandler ui.UI, coderPrompt, reviewerPrompt
 		return nil, err
 	}
 
-	git := loop.NewCycleCommitter(context.Background(), workDir)
+	git, _ := newCommitters(context.Background(), workDir, "", "", false)
 
-	beadHook := &loop.BeadHook{Beads: beadsClient, UI: uiHandler}
+	beadHook := &loop.BeadHook{Beads: beadsClient, UI: uiHandler, Queue: openBeadQueue(workDir)}
 
 	return &loop.Loop{
 		Invoker:      claudeInv,
@@ -230,6 +262,18 @@ func buildLoop(cfg *config.Config, uiHandler ui.UI, coderPrompt, reviewerPrompt
 		CoderPrompt:  coderPrompt,
 		ReviewPrompt: reviewerPrompt,
 		WorkDir:      workDir,
+
+		EscalateReviewAfterCycles: cfg.EscalateReviewAfterCycles,
+		EscalationModel:           cfg.EscalationModel,
+		StopOnDegradingReview:     cfg.StopOnDegradingReview,
+		StopOnNoProgress:          cfg.StopOnNoProgress,
+		Tester:                    loop.NewLinter(cfg.TestCommands, workDir),
+		RetryOnTestFailure:        cfg.RetryOnTestFailure,
+		IncludeDiffInReview:       cfg.IncludeDiffInReview,
+		ReviewDiffOnly:            cfg.ReviewDiffOnly,
+		AutoApplySuggestions:      cfg.AutoApplySuggestions,
+		HookQueueSize:             cfg.HookQueueSize,
+		CoderTemplate:             coderTmpl,
 	}, nil
 }
 
@@ -330,7 +374,7 @@ func runTask(ctx context.Context, taskLoop *loop.Loop, printer *ui.Printer, task
 		return nil
 	}
 
-	if errors.Is(err, loop.ErrMaxCycles) || errors.Is(err, loop.ErrBudgetExceeded) {
+	if errors.Is(err, loop.ErrMaxCycles) || errors.Is(err, loop.ErrBudgetExceeded) || errors.Is(err, loop.ErrReviewDisagreement) || errors.Is(err, loop.ErrDegradingReview) || errors.Is(err, loop.ErrNoProgress) {
 		// These are expected termination conditions, not fatal.
 		return err
 	}
@@ -0,0 +1,473 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// serveCmd exposes the nebulas in .nebulas/ over HTTP for dashboards.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a REST API to list, run, and steer the nebulas in .nebulas/",
+	Long: `Serve a small REST API over the nebulas in the .nebulas/ directory of the
+current (or specified) directory: list them, start a headless run, stream
+its events as server-sent events, and post interventions and gate
+decisions. Runs are started as "quasar nebula apply --auto --no-tui"
+processes, so they keep going if the server stops.
+
+The API has no authentication unless --token is set, so it listens on
+localhost by default.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "127.0.0.1:8787", "address to listen on")
+	serveCmd.Flags().String("dir", "", "directory to scan for .nebulas/ (default: cwd)")
+	serveCmd.Flags().String("token", "", "require this bearer token on every request")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// serveShutdownTimeout bounds how long the server waits for open requests,
+// including event streams, when it is stopped.
+const serveShutdownTimeout = 5 * time.Second
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	baseDir, _ := cmd.Flags().GetString("dir")
+	if baseDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+		baseDir = wd
+	}
+	nebulaeDir := filepath.Join(baseDir, ".nebulas")
+	if _, err := os.Stat(nebulaeDir); err != nil {
+		return fmt.Errorf("no .nebulas/ directory found in %s: %w", baseDir, err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating the quasar binary: %w", err)
+	}
+
+	addr, _ := cmd.Flags().GetString("addr")
+	token, _ := cmd.Flags().GetString("token")
+	s := newAPIServer(nebulaeDir, token, func(dir string, req startRequest) *exec.Cmd {
+		args := []string{"nebula", "apply", dir, "--auto", "--no-tui", "--gate-stdin"}
+		if req.MaxWorkers > 0 {
+			args = append(args, "--max-workers", strconv.Itoa(req.MaxWorkers))
+		}
+		if req.AllowDirty {
+			args = append(args, "--allow-dirty")
+		}
+		c := exec.Command(self, args...)
+		c.Dir = baseDir
+		c.Stdout, c.Stderr = os.Stderr, os.Stderr
+		return c
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	srv := &http.Server{Addr: addr, Handler: s.routes()}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+	fmt.Fprintf(os.Stderr, "serving %s on http://%s\n", nebulaeDir, addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// apiServer serves the REST API. It tracks the runs it started so it can
+// refuse duplicates and forward gate decisions to their stdin.
+type apiServer struct {
+	nebulaeDir string
+	token      string
+	// command builds the process that runs the nebula in dir.
+	command func(dir string, req startRequest) *exec.Cmd
+
+	mu   sync.Mutex
+	runs map[string]*servedRun // keyed by nebula name
+}
+
+// servedRun is a nebula run started by the server.
+type servedRun struct {
+	stdin io.WriteCloser
+	done  chan struct{} // closed when the process exits
+	// gates holds the phases whose gate awaits a decision, learned from
+	// the run's event socket; the plan gate is nebula.PlanPhaseID.
+	// Guarded by apiServer.mu.
+	gates map[string]bool
+}
+
+func newAPIServer(nebulaeDir, token string, command func(string, startRequest) *exec.Cmd) *apiServer {
+	return &apiServer{nebulaeDir: nebulaeDir, token: token, command: command, runs: make(map[string]*servedRun)}
+}
+
+// routes returns the API handler with authentication applied.
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /nebulas", s.authorize(s.handleList, false))
+	mux.Handle("POST /nebulas/{name}/runs", s.authorize(s.handleStart, false))
+	mux.Handle("GET /nebulas/{name}/events", s.authorize(s.handleEvents, true))
+	mux.Handle("POST /nebulas/{name}/interventions", s.authorize(s.handleIntervention, false))
+	mux.Handle("POST /nebulas/{name}/gate", s.authorize(s.handleGate, false))
+	return mux
+}
+
+// authorize rejects requests without the bearer token when one is set.
+// With queryToken the token may also be passed as ?token=, which only the
+// event stream allows, since browser EventSource clients cannot set
+// headers; everywhere else a token in the URL would end up in logs.
+func (s *apiServer) authorize(next http.HandlerFunc, queryToken bool) http.Handler {
+	if s.token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if got == "" && queryToken {
+			got = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiNebula is one entry in the GET /nebulas listing.
+type apiNebula struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Phases  int    `json:"phases"`
+	Done    int    `json:"done"`
+	Failed  int    `json:"failed"`
+	Running bool   `json:"running"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (s *apiServer) handleList(w http.ResponseWriter, _ *http.Request) {
+	entries, err := os.ReadDir(s.nebulaeDir)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	list := []apiNebula{}
+	for _, e := range entries {
+		dir := filepath.Join(s.nebulaeDir, e.Name())
+		if _, err := os.Stat(filepath.Join(dir, "nebula.toml")); !e.IsDir() || err != nil {
+			continue
+		}
+		list = append(list, s.describe(e.Name(), dir))
+	}
+	writeAPIJSON(w, http.StatusOK, list)
+}
+
+// describe summarizes the nebula in dir. A nebula that fails to load is
+// listed with its error rather than left out.
+func (s *apiServer) describe(name, dir string) apiNebula {
+	entry := apiNebula{Name: name, Running: s.isRunning(name, dir)}
+	n, err := nebula.Load(dir)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.Title = n.Manifest.Nebula.Name
+	entry.Phases = len(n.Phases)
+	state, err := nebula.LoadState(dir)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	for _, p := range n.Phases {
+		if ps := state.Phases[p.ID]; ps != nil {
+			switch ps.Status {
+			case nebula.PhaseStatusDone:
+				entry.Done++
+			case nebula.PhaseStatusFailed:
+				entry.Failed++
+			}
+		}
+	}
+	return entry
+}
+
+// isRunning reports whether the nebula has a run this server started or
+// any headless run listening on its event socket.
+func (s *apiServer) isRunning(name, dir string) bool {
+	if s.served(name) != nil {
+		return true
+	}
+	conn, err := net.Dial("unix", nebula.EventSocketPath(dir))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// served returns the live run the server started for name, if any.
+func (s *apiServer) served(name string) *servedRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runs[name]
+}
+
+// nebulaDir resolves the {name} path value to the nebula's name and
+// directory, writing a 404 and returning empty strings when there is no
+// such nebula.
+func (s *apiServer) nebulaDir(w http.ResponseWriter, r *http.Request) (string, string) {
+	name := r.PathValue("name")
+	dir := filepath.Join(s.nebulaeDir, name)
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		writeAPIError(w, http.StatusNotFound, "no such nebula")
+		return "", ""
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nebula.toml")); err != nil {
+		writeAPIError(w, http.StatusNotFound, "no such nebula")
+		return "", ""
+	}
+	return name, dir
+}
+
+// startRequest is the optional body of POST /nebulas/{name}/runs.
+type startRequest struct {
+	MaxWorkers int  `json:"max_workers"`
+	AllowDirty bool `json:"allow_dirty"` // start even if the working tree has uncommitted changes
+}
+
+func (s *apiServer) handleStart(w http.ResponseWriter, r *http.Request) {
+	name, dir := s.nebulaDir(w, r)
+	if dir == "" {
+		return
+	}
+	var req startRequest
+	if err := decodeAPIBody(r, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// The run would refuse a dirty tree too, but only after the API had
+	// answered; checking here puts the reason in the response.
+	if !req.AllowDirty {
+		if err := s.checkClean(r.Context(), dir); err != nil {
+			writeAPIError(w, http.StatusConflict, err.Error())
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs[name] != nil {
+		writeAPIError(w, http.StatusConflict, "nebula is already running")
+		return
+	}
+	c := s.command(dir, req)
+	stdin, err := c.StdinPipe()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := c.Start(); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("starting run: %v", err))
+		return
+	}
+	run := &servedRun{stdin: stdin, done: make(chan struct{}), gates: make(map[string]bool)}
+	s.runs[name] = run
+	go s.watchGates(name, dir, run)
+	go func() {
+		_ = c.Wait()
+		s.mu.Lock()
+		delete(s.runs, name)
+		s.mu.Unlock()
+		close(run.done)
+	}()
+	writeAPIJSON(w, http.StatusAccepted, map[string]any{"name": name, "pid": c.Process.Pid})
+}
+
+// checkClean runs the dirty-tree check `nebula apply` makes, against the
+// nebula's working directory: its [context] working_dir, resolved against
+// the directory holding .nebulas/, or that directory itself.
+func (s *apiServer) checkClean(ctx context.Context, dir string) error {
+	base := filepath.Dir(s.nebulaeDir)
+	workDir := base
+	if n, err := nebula.Load(dir); err == nil && n.Manifest.Context.WorkingDir != "" {
+		workDir = n.Manifest.Context.WorkingDir
+		if !filepath.IsAbs(workDir) {
+			workDir = filepath.Join(base, workDir)
+		}
+	}
+	return checkCleanWorkTree(ctx, workDir, dir)
+}
+
+// eventDialRetry is how often the event stream retries the socket of a run
+// the server just started, which opens it only once setup is done.
+const eventDialRetry = 200 * time.Millisecond
+
+// handleEvents relays the run's event socket as server-sent events: each
+// StreamEvent, as `nebula attach` receives it, is one SSE message named
+// after its kind.
+func (s *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	name, dir := s.nebulaDir(w, r)
+	if dir == "" {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	conn, err := s.dialEvents(r.Context(), name, dir)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "nebula is not running")
+		return
+	}
+	defer conn.Close()
+	go func() {
+		<-r.Context().Done()
+		conn.Close()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	br := bufio.NewReader(conn)
+	for {
+		line, err := br.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var ev struct {
+				Kind string `json:"kind"`
+			}
+			if json.Unmarshal(line, &ev) == nil {
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Kind, line)
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// dialEvents connects to the nebula's event socket. For a run the server
+// started it keeps retrying until the socket appears, the run exits, or
+// ctx ends.
+func (s *apiServer) dialEvents(ctx context.Context, name, dir string) (net.Conn, error) {
+	for {
+		conn, err := net.Dial("unix", nebula.EventSocketPath(dir))
+		if err == nil {
+			return conn, nil
+		}
+		run := s.served(name)
+		if run == nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-run.done:
+			return nil, err
+		case <-time.After(eventDialRetry):
+		}
+	}
+}
+
+// interventionRequest is the body of POST /nebulas/{name}/interventions.
+type interventionRequest struct {
+	Action string   `json:"action"` // pause, resume, stop, drain, or retry
+	Phases []string `json:"phases"` // retry only
+}
+
+// handleIntervention writes or removes the same intervention files the TUI
+// and a shell user would.
+func (s *apiServer) handleIntervention(w http.ResponseWriter, r *http.Request) {
+	_, dir := s.nebulaDir(w, r)
+	if dir == "" {
+		return
+	}
+	var req interventionRequest
+	if err := decodeAPIBody(r, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var err error
+	switch req.Action {
+	case "pause":
+		err = os.WriteFile(filepath.Join(dir, "PAUSE"), []byte("paused by quasar serve\n"), 0o644)
+	case "resume":
+		err = os.Remove(filepath.Join(dir, "PAUSE"))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	case "stop":
+		err = os.WriteFile(filepath.Join(dir, "STOP"), []byte("stopped by quasar serve\n"), 0o644)
+	case "drain":
+		err = os.WriteFile(filepath.Join(dir, "DRAIN"), []byte("drained by quasar serve\n"), 0o644)
+	case "retry":
+		if len(req.Phases) == 0 {
+			writeAPIError(w, http.StatusBadRequest, "retry needs at least one phase")
+			return
+		}
+		err = os.WriteFile(filepath.Join(dir, "RETRY"), []byte(strings.Join(req.Phases, "\n")+"\n"), 0o644)
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("unknown action %q: want pause, resume, stop, drain, or retry", req.Action))
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeAPIBody decodes a JSON request body into v. An empty body leaves v
+// unchanged.
+func decodeAPIBody(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeAPIJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, code int, msg string) {
+	writeAPIJSON(w, code, map[string]string{"error": msg})
+}
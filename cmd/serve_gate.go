@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// gateRequest is the body of POST /nebulas/{name}/gate.
+type gateRequest struct {
+	Phase  string `json:"phase"`  // the phase whose gate to answer; nebula.PlanPhaseID for the plan gate
+	Action string `json:"action"` // accept, reject, retry, or skip
+}
+
+// handleGate answers the gate a served run is waiting on for the requested
+// phase, announced by a "gate" event, by writing "<phase> <action>" to the
+// run's stdin. A phase with no gate waiting gets a 409, so a decision that
+// arrives early or twice is refused instead of answering a later gate.
+func (s *apiServer) handleGate(w http.ResponseWriter, r *http.Request) {
+	name, dir := s.nebulaDir(w, r)
+	if dir == "" {
+		return
+	}
+	var req gateRequest
+	if err := decodeAPIBody(r, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Phase == "" {
+		writeAPIError(w, http.StatusBadRequest, "gate decision needs a phase")
+		return
+	}
+	switch nebula.GateAction(req.Action) {
+	case nebula.GateActionAccept, nebula.GateActionReject, nebula.GateActionRetry, nebula.GateActionSkip:
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("unknown gate action %q: want accept, reject, retry, or skip", req.Action))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run := s.runs[name]
+	if run == nil {
+		writeAPIError(w, http.StatusConflict, "nebula has no run started by this server")
+		return
+	}
+	if !run.gates[req.Phase] {
+		writeAPIError(w, http.StatusConflict, fmt.Sprintf("phase %q has no gate waiting", req.Phase))
+		return
+	}
+	delete(run.gates, req.Phase)
+	if _, err := fmt.Fprintf(run.stdin, "%s %s\n", req.Phase, req.Action); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// watchGates follows the event socket of a run the server started and
+// keeps run.gates current: the snapshot lists the gates already open, each
+// "gate" event opens one, and a phase finishing or the run ending closes
+// them.
+func (s *apiServer) watchGates(name, dir string, run *servedRun) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-run.done
+		cancel()
+	}()
+	conn, err := s.dialEvents(ctx, name, dir)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var ev nebula.StreamEvent
+		if err := dec.Decode(&ev); err != nil {
+			return
+		}
+		s.mu.Lock()
+		switch ev.Kind {
+		case nebula.StreamEventSnapshot:
+			if ev.Snapshot != nil {
+				for _, id := range ev.Snapshot.Gates {
+					run.gates[id] = true
+				}
+			}
+		case nebula.StreamEventGate:
+			id := ev.Phase
+			if id == "" {
+				id = nebula.PlanPhaseID
+			}
+			run.gates[id] = true
+		case nebula.StreamEventPhase:
+			switch ev.Status {
+			case nebula.PhaseStatusDone, nebula.PhaseStatusFailed, nebula.PhaseStatusSkipped:
+				delete(run.gates, ev.Phase)
+			}
+		case nebula.StreamEventDone:
+			clear(run.gates)
+		}
+		s.mu.Unlock()
+	}
+}
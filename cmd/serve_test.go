@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// newServeFixture creates a .nebulas directory holding one nebula, "alpha",
+// with a single phase.
+func newServeFixture(t *testing.T) (nebulaeDir, alphaDir string) {
+	t.Helper()
+	nebulaeDir = filepath.Join(t.TempDir(), ".nebulas")
+	alphaDir = filepath.Join(nebulaeDir, "alpha")
+	if err := os.MkdirAll(alphaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(alphaDir, "nebula.toml"), []byte("[nebula]\nname = \"Alpha\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	phase := "+++\nid = \"a\"\ntitle = \"A\"\n+++\nDo it.\n"
+	if err := os.WriteFile(filepath.Join(alphaDir, "a.md"), []byte(phase), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return nebulaeDir, alphaDir
+}
+
+// catCommand stands in for a nebula run: it keeps reading stdin until it
+// is closed, recording what it received in out.
+func catCommand(out string) func(string, startRequest) *exec.Cmd {
+	return func(string, startRequest) *exec.Cmd {
+		return exec.Command("sh", "-c", "cat > "+out)
+	}
+}
+
+func TestServeListAndAuth(t *testing.T) {
+	t.Parallel()
+
+	nebulaeDir, _ := newServeFixture(t)
+	ts := httptest.NewServer(newAPIServer(nebulaeDir, "secret", nil).routes())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/nebulas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("without token: status %d, want 401", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/nebulas", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var list []apiNebula
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "alpha" || list[0].Title != "Alpha" || list[0].Phases != 1 || list[0].Running {
+		t.Errorf("list = %+v", list)
+	}
+
+	// Only the event stream accepts the token in the URL.
+	resp, err = http.Get(ts.URL + "/nebulas?token=secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("?token= on /nebulas: status %d, want 401", resp.StatusCode)
+	}
+	resp, err = http.Get(ts.URL + "/nebulas/alpha/events?token=secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		t.Error("?token= on the event stream was rejected")
+	}
+}
+
+func TestServeInterventions(t *testing.T) {
+	t.Parallel()
+
+	nebulaeDir, alphaDir := newServeFixture(t)
+	ts := httptest.NewServer(newAPIServer(nebulaeDir, "", nil).routes())
+	defer ts.Close()
+
+	post := func(path, body string) int {
+		t.Helper()
+		resp, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := post("/nebulas/alpha/interventions", `{"action":"pause"}`); code != http.StatusNoContent {
+		t.Fatalf("pause: status %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(alphaDir, "PAUSE")); err != nil {
+		t.Errorf("pause did not write PAUSE: %v", err)
+	}
+	post("/nebulas/alpha/interventions", `{"action":"resume"}`)
+	if _, err := os.Stat(filepath.Join(alphaDir, "PAUSE")); !os.IsNotExist(err) {
+		t.Errorf("resume left PAUSE behind: %v", err)
+	}
+	post("/nebulas/alpha/interventions", `{"action":"retry","phases":["a","b"]}`)
+	if data, _ := os.ReadFile(filepath.Join(alphaDir, "RETRY")); string(data) != "a\nb\n" {
+		t.Errorf("RETRY = %q", data)
+	}
+
+	for _, tt := range []struct {
+		path, body string
+		want       int
+	}{
+		{"/nebulas/alpha/interventions", `{"action":"retry"}`, http.StatusBadRequest},
+		{"/nebulas/alpha/interventions", `{"action":"explode"}`, http.StatusBadRequest},
+		{"/nebulas/missing/interventions", `{"action":"stop"}`, http.StatusNotFound},
+		{"/nebulas/alpha/gate", `{"phase":"a","action":"accept"}`, http.StatusConflict},
+		{"/nebulas/alpha/gate", `{"action":"accept"}`, http.StatusBadRequest},
+		{"/nebulas/alpha/gate", `{"phase":"a","action":"maybe"}`, http.StatusBadRequest},
+	} {
+		if code := post(tt.path, tt.body); code != tt.want {
+			t.Errorf("POST %s %s: status %d, want %d", tt.path, tt.body, code, tt.want)
+		}
+	}
+}
+
+func TestServeStartRunAndGate(t *testing.T) {
+	t.Parallel()
+
+	nebulaeDir, _ := newServeFixture(t)
+	out := filepath.Join(t.TempDir(), "stdin.txt")
+	s := newAPIServer(nebulaeDir, "", catCommand(out))
+	ts := httptest.NewServer(s.routes())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/nebulas/alpha/runs", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("start: status %d", resp.StatusCode)
+	}
+	resp, err = http.Post(ts.URL+"/nebulas/alpha/runs", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("second start: status %d, want 409", resp.StatusCode)
+	}
+
+	gate := func(body string) int {
+		t.Helper()
+		resp, err := http.Post(ts.URL+"/nebulas/alpha/gate", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := gate(`{"phase":"a","action":"reject"}`); code != http.StatusConflict {
+		t.Errorf("gate before it opened: status %d, want 409", code)
+	}
+	run := s.served("alpha")
+	s.mu.Lock()
+	run.gates["a"] = true
+	s.mu.Unlock()
+	if code := gate(`{"phase":"a","action":"reject"}`); code != http.StatusAccepted {
+		t.Fatalf("gate: status %d", code)
+	}
+	if code := gate(`{"phase":"a","action":"accept"}`); code != http.StatusConflict {
+		t.Errorf("second decision for the same gate: status %d, want 409", code)
+	}
+
+	run.stdin.Close()
+	select {
+	case <-run.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not exit after stdin closed")
+	}
+	if data, _ := os.ReadFile(out); string(data) != "a reject\n" {
+		t.Errorf("run received %q on stdin, want the gate decision", data)
+	}
+	if s.served("alpha") != nil {
+		t.Error("finished run is still tracked")
+	}
+}
+
+func TestServeEventsRelaysSocket(t *testing.T) {
+	t.Parallel()
+
+	// Unix socket paths are length-limited, so keep the nebula dir short.
+	base, err := os.MkdirTemp("", "qs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(base) })
+	nebulaeDir := filepath.Join(base, ".nebulas")
+	alphaDir := filepath.Join(nebulaeDir, "alpha")
+	if err := os.MkdirAll(alphaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(alphaDir, "nebula.toml"), []byte("[nebula]\nname = \"Alpha\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("unix", nebula.EventSocketPath(alphaDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		enc := json.NewEncoder(conn)
+		_ = enc.Encode(nebula.StreamEvent{Kind: nebula.StreamEventGate, Phase: "a", Reason: "phase awaiting gate decision"})
+		_ = enc.Encode(nebula.StreamEvent{Kind: nebula.StreamEventDone})
+	}()
+
+	ts := httptest.NewServer(newAPIServer(nebulaeDir, "", nil).routes())
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/nebulas/alpha/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	body := strings.Join(lines, "\n")
+	if !strings.HasPrefix(body, "event: gate\ndata: {\"kind\":\"gate\",\"phase\":\"a\"") || !strings.Contains(body, "\n\nevent: done\n") {
+		t.Errorf("event stream:\n%s", body)
+	}
+}
+
+func TestServeWatchGates(t *testing.T) {
+	t.Parallel()
+
+	// Unix socket paths are length-limited, so keep the nebula dir short.
+	base, err := os.MkdirTemp("", "qs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(base) })
+	alphaDir := filepath.Join(base, "alpha")
+	if err := os.MkdirAll(alphaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("unix", nebula.EventSocketPath(alphaDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	next := make(chan nebula.StreamEvent)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		enc := json.NewEncoder(conn)
+		for ev := range next {
+			_ = enc.Encode(ev)
+		}
+	}()
+
+	s := newAPIServer(base, "", nil)
+	run := &servedRun{done: make(chan struct{}), gates: make(map[string]bool)}
+	s.runs["alpha"] = run
+	go s.watchGates("alpha", alphaDir, run)
+	defer close(run.done)
+
+	waitGates := func(want ...string) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); ; {
+			s.mu.Lock()
+			got := len(run.gates) == len(want)
+			for _, id := range want {
+				got = got && run.gates[id]
+			}
+			s.mu.Unlock()
+			if got {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("gates never became %v", want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	next <- nebula.StreamEvent{Kind: nebula.StreamEventSnapshot, Snapshot: &nebula.StreamSnapshot{Gates: []string{"a"}}}
+	waitGates("a")
+	next <- nebula.StreamEvent{Kind: nebula.StreamEventGate}
+	waitGates("a", nebula.PlanPhaseID)
+	next <- nebula.StreamEvent{Kind: nebula.StreamEventPhase, Phase: "a", Status: nebula.PhaseStatusDone}
+	waitGates(nebula.PlanPhaseID)
+	next <- nebula.StreamEvent{Kind: nebula.StreamEventDone}
+	waitGates()
+	close(next)
+}
+
+func TestServeStartRefusesDirtyTree(t *testing.T) {
+	t.Parallel()
+
+	nebulaeDir, _ := newServeFixture(t)
+	repo := filepath.Dir(nebulaeDir)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, "wip.go"), []byte("package wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := newAPIServer(nebulaeDir, "", catCommand(filepath.Join(t.TempDir(), "stdin.txt")))
+	ts := httptest.NewServer(s.routes())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/nebulas/alpha/runs", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]string
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict || !strings.Contains(body["error"], "wip.go") {
+		t.Errorf("dirty start: status %d, body %v; want 409 naming wip.go", resp.StatusCode, body)
+	}
+
+	resp, err = http.Post(ts.URL+"/nebulas/alpha/runs", "application/json", strings.NewReader(`{"allow_dirty":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("start with allow_dirty: status %d", resp.StatusCode)
+	}
+	run := s.served("alpha")
+	run.stdin.Close()
+	<-run.done
+}
@@ -4,17 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/papapumpkin/quasar/internal/agent"
-	"github.com/papapumpkin/quasar/internal/beads"
-	"github.com/papapumpkin/quasar/internal/claude"
 	"github.com/papapumpkin/quasar/internal/config"
-	"github.com/papapumpkin/quasar/internal/loop"
 	"github.com/papapumpkin/quasar/internal/nebula"
 	"github.com/papapumpkin/quasar/internal/tui"
 	"github.com/papapumpkin/quasar/internal/ui"
@@ -36,6 +32,13 @@ func init() {
 	cockpitCmd.Flags().String("dir", "", "directory to scan for .nebulas/ (default: cwd)")
 	cockpitCmd.Flags().Bool("no-splash", false, "skip the startup splash animation")
 	cockpitCmd.Flags().Int("max-workers", 1, "maximum concurrent workers")
+	cockpitCmd.Flags().Bool("allow-dirty", false, "start nebulas even if the working tree has uncommitted changes")
+	cockpitCmd.Flags().Duration("idle-timeout", 0, "pause the run when a gate prompt goes this long without a keypress (0 = never)")
+	cockpitCmd.Flags().Duration("max-runtime", 0, "stop starting phases after this long, let running ones finish, and leave the rest pending")
+	cockpitCmd.Flags().Int("state-backups", nebula.DefaultStateBackups, "previous state files to keep as nebula.state.toml.N (0 = none)")
+	cockpitCmd.Flags().Duration("dispatch-jitter", 0, "delay each phase's start by a random amount up to this, spreading out API calls when many phases start at once")
+	cockpitCmd.Flags().String("phase-timeout-action", "", "what a timed-out phase does when it sets no timeout_action: fail, skip, or retry (overrides execution.timeout_action)")
+	addParamFlag(cockpitCmd)
 	rootCmd.AddCommand(cockpitCmd)
 }
 
@@ -72,12 +75,19 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 	if v, _ := cmd.Flags().GetBool("verbose"); v {
 		cfg.Verbose = true
 	}
+	if cmd.Flags().Changed("idle-timeout") {
+		cfg.IdleTimeout, _ = cmd.Flags().GetDuration("idle-timeout")
+	}
 
 	noSplash, _ := cmd.Flags().GetBool("no-splash")
-	maxWorkers, _ := cmd.Flags().GetInt("max-workers")
-	maxWorkersExplicit := cmd.Flags().Changed("max-workers")
+	opts, err := cockpitRunOptions(cmd)
+	if err != nil {
+		return err
+	}
 
-	// Home-to-execution loop: discover → select → run → repeat.
+	// Home-to-execution loop: discover → select → run → repeat. A failed
+	// side-by-side run sets the exit code once the user quits from home.
+	var multiErr error
 	for {
 		choices, discoverErr := tui.DiscoverAllNebulae(nebulaeDir)
 		if discoverErr != nil {
@@ -96,14 +106,24 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 			return nil
 		}
 
+		// Run the marked nebulas side by side, then return home.
+		if len(appModel.SelectedNebulae) > 0 {
+			multiErr = runMultipleNebulae(cfg, printer, appModel.SelectedNebulae, opts)
+			if multiErr != nil {
+				printer.Error(multiErr.Error())
+			}
+			noSplash = true
+			continue
+		}
+
 		// If no nebula was selected (user quit), exit cleanly.
 		selectedDir := appModel.SelectedNebula
 		if selectedDir == "" {
-			return nil
+			return exitStatus(cmd, nebula.ExitCode(multiErr, nil), nil)
 		}
 
 		// Run the selected nebula.
-		result := runSelectedNebula(cfg, printer, selectedDir, noSplash, maxWorkers, maxWorkersExplicit)
+		result := runSelectedNebula(cfg, printer, selectedDir, noSplash, opts)
 		if result.Err != nil {
 			printer.Error(fmt.Sprintf("nebula execution error: %v", result.Err))
 			// Don't exit — return to the home screen.
@@ -120,7 +140,7 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 		case result.NextNebula != "":
 			// User selected a nebula from the picker — run it directly, then
 			// loop back so the home screen refreshes afterward.
-			nextResult := runSelectedNebula(cfg, printer, result.NextNebula, true, maxWorkers, maxWorkersExplicit)
+			nextResult := runSelectedNebula(cfg, printer, result.NextNebula, true, opts)
 			if nextResult.Err != nil {
 				printer.Error(fmt.Sprintf("nebula execution error: %v", nextResult.Err))
 			}
@@ -128,203 +148,68 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 			continue
 		default:
 			// User pressed q or exited without selecting — quit entirely.
-			return result.Err
+			return exitStatus(cmd, result.ExitCode, result.Err)
 		}
 	}
 }
 
+// nebulaRunOptions are the cockpit flags that shape every nebula run it
+// starts.
+type nebulaRunOptions struct {
+	maxWorkers         int
+	maxWorkersExplicit bool // when false, the manifest's max_workers takes precedence
+	allowDirty         bool // skip the uncommitted-changes check
+	maxRuntime         time.Duration
+	params             []string // raw --param flags, parsed per nebula
+	stateBackups       int
+	timeoutAction      nebula.TimeoutAction
+	dispatchJitter     time.Duration
+}
+
+// cockpitRunOptions reads the run options from the cockpit's flags.
+func cockpitRunOptions(cmd *cobra.Command) (nebulaRunOptions, error) {
+	var opts nebulaRunOptions
+	opts.maxWorkers, _ = cmd.Flags().GetInt("max-workers")
+	opts.maxWorkersExplicit = cmd.Flags().Changed("max-workers")
+	opts.allowDirty, _ = cmd.Flags().GetBool("allow-dirty")
+	opts.maxRuntime, _ = cmd.Flags().GetDuration("max-runtime")
+	opts.params, _ = cmd.Flags().GetStringArray("param")
+	opts.stateBackups, _ = cmd.Flags().GetInt("state-backups")
+	opts.dispatchJitter, _ = cmd.Flags().GetDuration("dispatch-jitter")
+	timeoutActionFlag, _ := cmd.Flags().GetString("phase-timeout-action")
+	opts.timeoutAction = nebula.TimeoutAction(timeoutActionFlag)
+	if opts.timeoutAction != "" && !opts.timeoutAction.Valid() {
+		return opts, fmt.Errorf("invalid --phase-timeout-action %q: must be fail, skip, or retry", timeoutActionFlag)
+	}
+	return opts, nil
+}
+
 // nebulaResult carries the user's intent after a nebula execution completes.
 type nebulaResult struct {
 	Err          error  // execution error (if any)
+	ExitCode     int    // nebula.ExitCode for the run; 0 when it never started
 	ReturnToHome bool   // user pressed Esc on overlay to return to home
 	NextNebula   string // user selected a nebula from the picker
 }
 
 // runSelectedNebula loads, validates, and executes a single nebula in TUI mode.
 // It reuses the same setup logic as runNebulaApply's TUI path.
-// maxWorkersExplicit indicates whether the user explicitly set --max-workers;
-// when false, the nebula manifest's MaxWorkers value takes precedence.
-func runSelectedNebula(cfg config.Config, printer *ui.Printer, dir string, noSplash bool, maxWorkers int, maxWorkersExplicit bool) nebulaResult {
-	n, err := nebula.Load(dir)
-	if err != nil {
-		return nebulaResult{Err: fmt.Errorf("failed to load nebula: %w", err)}
-	}
-
-	if errs := nebula.Validate(n); len(errs) > 0 {
-		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
-		return nebulaResult{Err: fmt.Errorf("validation failed")}
-	}
-
+func runSelectedNebula(cfg config.Config, printer *ui.Printer, dir string, noSplash bool, opts nebulaRunOptions) nebulaResult {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Resolve workDir and checkout nebula branch BEFORE loading state or
-	// applying bead changes. The state file lives on the feature branch;
-	// writing it before checkout creates an untracked file that blocks
-	// the subsequent git checkout.
-	workDir := cfg.WorkDir
-	if n.Manifest.Context.WorkingDir != "" {
-		workDir = n.Manifest.Context.WorkingDir
-	}
-	if workDir == "." || workDir == "" {
-		wd, wdErr := os.Getwd()
-		if wdErr != nil {
-			return nebulaResult{Err: fmt.Errorf("failed to get working directory: %w", wdErr)}
-		}
-		workDir = wd
-	}
-
-	// Create nebula branch if in a git repo.
-	branchMgr, branchErr := nebula.NewBranchManager(ctx, workDir, n.Manifest.Nebula.Name)
-	if branchErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: branch management unavailable: %v\n", branchErr)
-	}
-	if branchMgr != nil {
-		if err := branchMgr.CreateOrCheckout(ctx); err != nil {
-			return nebulaResult{Err: fmt.Errorf("failed to create nebula branch: %w", err)}
-		}
-	}
-	branchName := branchMgr.Branch()
-
-	state, err := nebula.LoadState(dir)
+	run, err := prepareNebulaRun(ctx, cfg, printer, dir, opts)
 	if err != nil {
-		return nebulaResult{Err: fmt.Errorf("failed to load state: %w", err)}
+		return nebulaResult{Err: err}
 	}
-
-	client := &beads.CLI{BeadsPath: cfg.BeadsPath, Verbose: cfg.Verbose}
-
-	plan, err := nebula.BuildPlan(ctx, n, state, client)
-	if err != nil {
-		return nebulaResult{Err: fmt.Errorf("failed to build plan: %w", err)}
-	}
-
-	if !plan.HasChanges() {
-		printer.Info("nothing to do — all phases already applied")
+	if run == nil {
 		return nebulaResult{}
 	}
+	defer run.close()
 
-	if err := nebula.Apply(ctx, plan, n, state, client); err != nil {
-		return nebulaResult{Err: fmt.Errorf("failed to apply plan: %w", err)}
-	}
-
-	// If --max-workers was not explicitly set, use nebula execution config.
-	if maxWorkers <= 0 {
-		maxWorkers = 1
-	}
-	if !maxWorkersExplicit && n.Manifest.Execution.MaxWorkers > 0 {
-		maxWorkers = n.Manifest.Execution.MaxWorkers
-	}
-
-	// Load custom prompts.
-	coderPrompt := agent.DefaultCoderSystemPrompt
-	if cfg.CoderSystemPrompt != "" {
-		coderPrompt = cfg.CoderSystemPrompt
-	}
-	reviewerPrompt := agent.DefaultReviewerSystemPrompt
-	if cfg.ReviewerSystemPrompt != "" {
-		reviewerPrompt = cfg.ReviewerSystemPrompt
-	}
-
-	claudeInv := claude.NewInvoker(cfg.ClaudePath, cfg.Verbose)
-	if err := claudeInv.Validate(); err != nil {
-		return nebulaResult{Err: fmt.Errorf("claude not available: %w", err)}
-	}
-
-	// Initialize fabric infrastructure when the DAG has inter-phase dependencies.
-	fc, fcErr := initFabric(ctx, n, dir, workDir, claudeInv)
-	if fcErr != nil {
-		return nebulaResult{Err: fmt.Errorf("fabric initialization failed: %w", fcErr)}
-	}
-	defer fc.Close()
-
-	git := loop.NewCycleCommitterWithBranch(ctx, workDir, branchName)
-	phaseCommitter := nebula.NewGitCommitterWithBranch(ctx, workDir, branchName)
-
-	// Build TUI phase info, seeding status from saved state.
-	phases := make([]tui.PhaseInfo, 0, len(n.Phases))
-	for _, p := range n.Phases {
-		pi := tui.PhaseInfo{
-			ID:        p.ID,
-			Title:     p.Title,
-			DependsOn: p.DependsOn,
-			PlanBody:  p.Body,
-		}
-		if ps := state.Phases[p.ID]; ps != nil {
-			pi.Status = tui.PhaseStatusFromString(string(ps.Status))
-		}
-		phases = append(phases, pi)
-	}
-
-	tuiProgram := tui.NewNebulaProgram(n.Manifest.Nebula.Name, phases, dir, noSplash)
-
-	wgOpts := []nebula.Option{
-		nebula.WithMaxWorkers(maxWorkers),
-		nebula.WithBeadsClient(client),
-		nebula.WithGlobalCycles(cfg.MaxReviewCycles),
-		nebula.WithGlobalBudget(cfg.MaxBudgetUSD),
-		nebula.WithGlobalModel(cfg.Model),
-		nebula.WithCommitter(phaseCommitter),
-	}
-	wgOpts = append(wgOpts, fc.WorkerGroupOptions()...)
-	wg := nebula.NewWorkerGroup(n, state, wgOpts...)
-
-	wg.Runner = &tuiLoopAdapter{
-		program:      tuiProgram,
-		invoker:      claudeInv,
-		beads:        client,
-		git:          git,
-		linter:       loop.NewLinter(cfg.LintCommands, workDir),
-		maxCycles:    cfg.MaxReviewCycles,
-		maxBudget:    cfg.MaxBudgetUSD,
-		model:        cfg.Model,
-		coderPrompt:  coderPrompt,
-		reviewPrompt: reviewerPrompt,
-		workDir:      workDir,
-		fabric:       wg.Fabric, // nil-safe — emitFabricEvents checks for nil
-	}
-	wg.Logger = io.Discard
-	wg.Prompter = tui.NewGater(tuiProgram)
-	wg.OnProgress = func(completed, total, openBeads, closedBeads int, totalCostUSD float64) {
-		tuiProgram.Send(tui.MsgNebulaProgress{
-			Completed:    completed,
-			Total:        total,
-			OpenBeads:    openBeads,
-			ClosedBeads:  closedBeads,
-			TotalCostUSD: totalCostUSD,
-		})
-	}
-	wg.OnRefactor = func(phaseID string, pending bool) {
-		if pending {
-			tuiProgram.Send(tui.MsgPhaseRefactorPending{PhaseID: phaseID})
-		}
-	}
-
-	// Create watcher for intervention file detection.
-	w, watcherErr := nebula.NewWatcher(dir)
-	if watcherErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: watcher unavailable: %v\n", watcherErr)
-	} else {
-		if startErr := w.Start(); startErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: watcher start failed: %v\n", startErr)
-		} else {
-			wg.Watcher = w
-			defer w.Stop()
-		}
-	}
-
-	// Run workers in goroutine; block on TUI.
-	prog := tuiProgram
-	br := branchName
-	wd := workDir
-	go func() {
-		results, runErr := wg.Run(ctx)
-		prog.Send(tui.MsgNebulaDone{Results: results, Err: runErr})
-		if br != "" {
-			allSucceeded := runErr == nil
-			gitResult := nebula.PostCompletion(context.Background(), wd, br, allSucceeded)
-			prog.Send(tui.MsgGitPostCompletion{Result: gitResult})
-		}
-	}()
+	tuiProgram := tui.NewNebulaProgram(run.n.Manifest.Nebula, run.phases, dir, noSplash)
+	run.attach(tuiProgram)
+	run.start(ctx, tuiProgram)
 
 	finalModel, tuiErr := tuiProgram.Run()
 	cancel()
@@ -340,6 +225,7 @@ func runSelectedNebula(cfg config.Config, printer *ui.Printer, dir string, noSpl
 	res := nebulaResult{
 		ReturnToHome: appModel.ReturnToHome,
 		NextNebula:   appModel.NextNebula,
+		ExitCode:     nebula.ExitCode(appModel.DoneErr, appModel.DoneResults),
 	}
 
 	if appModel.DoneErr != nil && !errors.Is(appModel.DoneErr, nebula.ErrManualStop) {
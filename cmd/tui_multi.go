@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/tui"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// runMultipleNebulae runs the nebulas in dirs concurrently under one
+// multi-nebula TUI. Each nebula checks out its own branch, so nebulas that
+// share a working directory are refused rather than left to fight over it.
+// The returned error joins every nebula that could not start or did not
+// finish cleanly.
+func runMultipleNebulae(cfg config.Config, printer *ui.Printer, dirs []string, opts nebulaRunOptions) error {
+	if err := checkDistinctWorkDirs(cfg, dirs); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	multi := tui.NewMultiModel()
+	var runs []*nebulaRun
+	var errs []error
+	for _, dir := range dirs {
+		run, err := prepareNebulaRun(ctx, cfg, printer, dir, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dir, err))
+			continue
+		}
+		if run == nil {
+			continue
+		}
+		defer run.close()
+		multi.Add(dir, tui.NewNebulaModel(run.n.Manifest.Nebula, run.phases, dir, true))
+		runs = append(runs, run)
+	}
+	if len(runs) == 0 {
+		return errors.Join(errs...)
+	}
+
+	program := tui.NewMultiProgram(multi)
+	for _, run := range runs {
+		fwd := tui.NewNebulaForwarder(program, run.dir)
+		go fwd.Run() //nolint:errcheck // the forwarder has no terminal to fail on
+		defer fwd.Quit()
+		run.attach(fwd)
+		run.start(ctx, fwd)
+	}
+
+	finalModel, tuiErr := program.Run()
+	cancel()
+	if tuiErr != nil {
+		return errors.Join(append(errs, fmt.Errorf("TUI error: %w", tuiErr))...)
+	}
+
+	if final, ok := finalModel.(tui.MultiModel); ok {
+		errs = append(errs, multiRunErrors(final)...)
+	}
+	return errors.Join(errs...)
+}
+
+// multiRunErrors returns an error for each nebula in final whose run ended
+// in an error or with failed phases. A manual stop is not a failure.
+func multiRunErrors(final tui.MultiModel) []error {
+	var errs []error
+	for _, id := range final.Order {
+		s := final.Sections[id]
+		switch {
+		case errors.Is(s.DoneErr, nebula.ErrManualStop):
+		case s.DoneErr != nil:
+			errs = append(errs, fmt.Errorf("%s: nebula execution error: %w", s.StatusBar.Name, s.DoneErr))
+		case nebula.ExitCode(nil, s.DoneResults) != nebula.ExitOK:
+			errs = append(errs, fmt.Errorf("%s: %w", s.StatusBar.Name, failedPhasesError(s.DoneResults)))
+		}
+	}
+	return errs
+}
+
+// failedPhasesError names the phases in results that failed.
+func failedPhasesError(results []nebula.WorkerResult) error {
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.PhaseID)
+		}
+	}
+	return fmt.Errorf("phases failed: %s", strings.Join(failed, ", "))
+}
+
+// checkDistinctWorkDirs returns an error if two of the nebulas in dirs
+// would run in the same working directory.
+func checkDistinctWorkDirs(cfg config.Config, dirs []string) error {
+	owners := make(map[string]string, len(dirs))
+	for _, dir := range dirs {
+		n, err := nebula.Load(dir)
+		if err != nil {
+			return fmt.Errorf("failed to load nebula: %w", err)
+		}
+		workDir, err := nebulaWorkDir(cfg, n)
+		if err != nil {
+			return err
+		}
+		name := n.Manifest.Nebula.Name
+		if other, ok := owners[workDir]; ok {
+			return fmt.Errorf("nebulas %q and %q both run in %s; give each its own context.working_dir to run them together", other, name, workDir)
+		}
+		owners[workDir] = name
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/tui"
+)
+
+func TestMultiRunErrors(t *testing.T) {
+	t.Parallel()
+
+	section := func(name string, err error, results ...nebula.WorkerResult) tui.AppModel {
+		m := tui.AppModel{DoneErr: err, DoneResults: results}
+		m.StatusBar.Name = name
+		return m
+	}
+	multi := tui.NewMultiModel()
+	multi.Add("ok", section("ok", nil, nebula.WorkerResult{PhaseID: "a"}))
+	multi.Add("stopped", section("stopped", nebula.ErrManualStop))
+	multi.Add("broken", section("broken", errors.New("boom")))
+	multi.Add("partial", section("partial", nil,
+		nebula.WorkerResult{PhaseID: "a"},
+		nebula.WorkerResult{PhaseID: "b", Err: errors.New("lint")}))
+
+	errs := multiRunErrors(multi)
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want one each for broken and partial", errs)
+	}
+	if got := errs[0].Error(); !strings.Contains(got, "broken") || !strings.Contains(got, "boom") {
+		t.Errorf("first error = %q", got)
+	}
+	if got := errs[1].Error(); got != "partial: phases failed: b" {
+		t.Errorf("second error = %q", got)
+	}
+	if code := nebula.ExitCode(errors.Join(errs...), nil); code == nebula.ExitOK {
+		t.Error("a failed side-by-side run must not exit 0")
+	}
+}
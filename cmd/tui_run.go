@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+	"github.com/papapumpkin/quasar/internal/beads"
+	"github.com/papapumpkin/quasar/internal/config"
+	"github.com/papapumpkin/quasar/internal/fabric"
+	"github.com/papapumpkin/quasar/internal/loop"
+	"github.com/papapumpkin/quasar/internal/nebula"
+	"github.com/papapumpkin/quasar/internal/tui"
+	"github.com/papapumpkin/quasar/internal/tycho"
+	"github.com/papapumpkin/quasar/internal/ui"
+)
+
+// nebulaRun is a nebula that has been loaded, applied, and given a
+// WorkerGroup, ready to be attached to a TUI program and started.
+type nebulaRun struct {
+	n            *nebula.Nebula
+	dir          string
+	workDir      string
+	branchName   string
+	phases       []tui.PhaseInfo
+	wg           *nebula.WorkerGroup
+	runner       *tuiLoopAdapter
+	limiter      *agent.RateLimiter
+	idle         time.Duration
+	silenceLimit time.Duration
+	etaDefault   time.Duration
+	cleanups     []func()
+}
+
+// close releases the run's resources in reverse order of acquisition.
+func (r *nebulaRun) close() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+// prepareNebulaRun loads, validates, and applies the nebula in dir, checks
+// out its branch, and builds its WorkerGroup. Unless allowDirty is set it
+// refuses a working tree with uncommitted changes, which the first phase
+// commit would sweep up. It returns a nil run and nil error when every
+// phase is already applied. Callers must close the run.
+func prepareNebulaRun(ctx context.Context, cfg config.Config, printer *ui.Printer, dir string, opts nebulaRunOptions) (*nebulaRun, error) {
+	n, err := nebula.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nebula: %w", err)
+	}
+
+	if errs := nebula.Validate(n); len(errs) > 0 {
+		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
+		return nil, fmt.Errorf("validation failed")
+	}
+	params, err := nebula.ParseParams(opts.params)
+	if err != nil {
+		return nil, err
+	}
+	if err := nebula.CheckParams(n.Phases, params); err != nil {
+		return nil, err
+	}
+
+	// Resolve workDir and checkout nebula branch BEFORE loading state or
+	// applying bead changes. The state file lives on the feature branch;
+	// writing it before checkout creates an untracked file that blocks
+	// the subsequent git checkout.
+	workDir, err := nebulaWorkDir(cfg, n)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.allowDirty {
+		if err := checkCleanWorkTree(ctx, workDir, dir); err != nil {
+			return nil, fmt.Errorf("%w (commit or stash them first, or pass --allow-dirty)", err)
+		}
+	}
+
+	// Create nebula branch if in a git repo.
+	branchMgr, branchErr := nebula.NewBranchManager(ctx, workDir, n.Manifest.Nebula.Name)
+	if branchErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: branch management unavailable: %v\n", branchErr)
+	}
+	if branchMgr != nil {
+		if err := branchMgr.CreateOrCheckout(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create nebula branch: %w", err)
+		}
+	}
+	branchName := branchMgr.Branch()
+
+	if errs := nebula.ValidateWorkingDirs(n, workDir); len(errs) > 0 {
+		printer.NebulaValidateResult(n.Manifest.Nebula.Name, len(n.Phases), errs)
+		return nil, fmt.Errorf("validation failed")
+	}
+
+	state, err := nebula.LoadState(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	client := &beads.CLI{BeadsPath: cfg.BeadsPath, Verbose: cfg.Verbose}
+
+	plan, err := nebula.BuildPlan(ctx, n, state, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plan: %w", err)
+	}
+
+	if !plan.HasChanges() {
+		printer.Info(fmt.Sprintf("%s: nothing to do — all phases already applied", n.Manifest.Nebula.Name))
+		return nil, nil
+	}
+
+	if err := nebula.Apply(ctx, plan, n, state, client); err != nil {
+		return nil, fmt.Errorf("failed to apply plan: %w", err)
+	}
+	if err := recordAppliedPlan(n, dir, workDir); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	// If --max-workers was not explicitly set, use nebula execution config.
+	maxWorkers := opts.maxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+	if !opts.maxWorkersExplicit && n.Manifest.Execution.MaxWorkers > 0 {
+		maxWorkers = n.Manifest.Execution.MaxWorkers
+	}
+
+	// Load custom prompts.
+	coderPrompt := agent.DefaultCoderSystemPrompt
+	if cfg.CoderSystemPrompt != "" {
+		coderPrompt = cfg.CoderSystemPrompt
+	}
+	reviewerPrompt := agent.DefaultReviewerSystemPrompt
+	if cfg.ReviewerSystemPrompt != "" {
+		reviewerPrompt = cfg.ReviewerSystemPrompt
+	}
+	coderTmpl, err := parseCoderTemplate(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	claudeInv, limiter := newClaudeInvoker(&cfg)
+	if err := claudeInv.Validate(); err != nil {
+		return nil, fmt.Errorf("claude not available: %w", err)
+	}
+
+	run := &nebulaRun{n: n, dir: dir, workDir: workDir, branchName: branchName, limiter: limiter, idle: cfg.IdleTimeout, silenceLimit: cfg.SilentPhaseWarning, etaDefault: cfg.ETADefaultPhase}
+
+	// Initialize fabric infrastructure when the DAG has inter-phase dependencies.
+	fc, fcErr := initFabric(ctx, n, dir, workDir, claudeInv)
+	if fcErr != nil {
+		return nil, fmt.Errorf("fabric initialization failed: %w", fcErr)
+	}
+	run.cleanups = append(run.cleanups, func() { fc.Close() })
+
+	git, phaseCommitter := newCommitters(ctx, workDir, dir, branchName, false)
+
+	// Build TUI phase info, seeding status from saved state.
+	run.phases = make([]tui.PhaseInfo, 0, len(n.Phases))
+	for _, p := range n.Phases {
+		pi := tui.PhaseInfo{
+			ID:         p.ID,
+			Title:      p.Title,
+			DependsOn:  p.DependsOn,
+			PlanBody:   p.Body,
+			SourceFile: p.SourcePath(dir),
+			Manual:     p.IsManual(),
+		}
+		if ps := state.Phases[p.ID]; ps != nil {
+			pi.Status = tui.PhaseStatusFromString(string(ps.Status))
+			pi.SkipReason = ps.SkipReason
+			pi.Attempts = ps.AttemptLog
+		}
+		run.phases = append(run.phases, pi)
+	}
+
+	wgOpts := []nebula.Option{
+		nebula.WithMaxWorkers(maxWorkers),
+		nebula.WithBeadsClient(client),
+		nebula.WithGlobalCycles(cfg.MaxReviewCycles),
+		nebula.WithGlobalBudget(cfg.MaxBudgetUSD),
+		nebula.WithGlobalModel(cfg.Model),
+		nebula.WithCommitter(phaseCommitter),
+		nebula.WithNotifier(newNotifier(cfg.NotifyWebhook)),
+		nebula.WithWorkDir(workDir),
+		nebula.WithMaxRuntime(opts.maxRuntime),
+		nebula.WithParams(params),
+		nebula.WithStateBackups(opts.stateBackups),
+		nebula.WithTimeoutAction(opts.timeoutAction),
+		nebula.WithDispatchJitter(opts.dispatchJitter),
+		nebula.WithLogger(io.Discard),
+	}
+	wgOpts = append(wgOpts, fc.WorkerGroupOptions()...)
+	run.wg = nebula.NewWorkerGroup(n, state, wgOpts...)
+
+	run.runner = &tuiLoopAdapter{
+		invoker:      claudeInv,
+		beads:        client,
+		beadQueue:    openBeadQueue(workDir),
+		git:          git,
+		linter:       loop.NewLinter(cfg.LintCommands, workDir),
+		maxCycles:    cfg.MaxReviewCycles,
+		maxBudget:    cfg.MaxBudgetUSD,
+		model:        cfg.Model,
+		coderPrompt:  coderPrompt,
+		reviewPrompt: reviewerPrompt,
+		workDir:      workDir,
+		fabric:       run.wg.Fabric, // nil-safe — emitFabricEvents checks for nil
+
+		escalateAfter:    cfg.EscalateReviewAfterCycles,
+		escalationModel:  cfg.EscalationModel,
+		stopOnDegrading:  cfg.StopOnDegradingReview,
+		stopOnNoProgress: cfg.StopOnNoProgress,
+		tester:           loop.NewLinter(cfg.TestCommands, workDir),
+		retryOnTestFail:  cfg.RetryOnTestFailure,
+		includeDiff:      cfg.IncludeDiffInReview,
+		reviewDiffOnly:   cfg.ReviewDiffOnly,
+		autoApply:        cfg.AutoApplySuggestions,
+		coderTemplate:    coderTmpl,
+		hookQueueSize:    cfg.HookQueueSize,
+	}
+	run.wg.Runner = run.runner
+
+	// Create watcher for intervention file detection.
+	w, watcherErr := nebula.NewWatcher(dir)
+	if watcherErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: watcher unavailable: %v\n", watcherErr)
+	} else {
+		if startErr := w.Start(); startErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: watcher start failed: %v\n", startErr)
+		} else {
+			run.wg.Watcher = w
+			run.cleanups = append(run.cleanups, w.Stop)
+		}
+	}
+	return run, nil
+}
+
+// nebulaWorkDir resolves the absolute directory a nebula's agents work in.
+func nebulaWorkDir(cfg config.Config, n *nebula.Nebula) (string, error) {
+	workDir := cfg.WorkDir
+	if n.Manifest.Context.WorkingDir != "" {
+		workDir = n.Manifest.Context.WorkingDir
+	}
+	if workDir == "." || workDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get working directory: %w", err)
+		}
+		workDir = wd
+	}
+	return workDir, nil
+}
+
+// attach routes the run's loop output, gate prompts, and callbacks to p.
+func (r *nebulaRun) attach(p *tui.Program) {
+	r.runner.program = p
+	gater := tui.NewGater(p)
+	r.wg.Prompter = gater
+	r.wg.Budget = gater
+	r.wg.OnProgress = func(completed, total, openBeads, closedBeads int, totalCostUSD float64) {
+		p.Send(tui.MsgNebulaProgress{
+			Completed:    completed,
+			Total:        total,
+			OpenBeads:    openBeads,
+			ClosedBeads:  closedBeads,
+			TotalCostUSD: totalCostUSD,
+		})
+	}
+	r.wg.OnRefactor = func(phaseID string, pending bool) {
+		if pending {
+			p.Send(tui.MsgPhaseRefactorPending{PhaseID: phaseID})
+		}
+	}
+	r.wg.OnSkip = func(phaseID, reason string) {
+		p.Send(tui.MsgPhaseStatus{PhaseID: phaseID, Status: tui.PhaseSkipped, Reason: reason})
+	}
+	r.wg.OnPreserved = func(phaseID, location string) {
+		p.Send(tui.MsgPhasePreserved{PhaseID: phaseID, Location: location})
+	}
+	r.wg.OnAttempt = func(phaseID string, rec nebula.AttemptRecord) {
+		p.Send(tui.MsgPhaseAttempt{PhaseID: phaseID, Attempt: rec})
+	}
+	r.wg.OnPhaseReady = func(phaseID string) {
+		p.Send(tui.MsgPhaseReady{PhaseID: phaseID})
+	}
+	r.wg.OnConflict = func(c fabric.FileConflict) {
+		p.Send(tui.MsgConflict{PhaseID: c.Phase, OtherPhaseID: c.Owner, File: c.File})
+	}
+	r.wg.OnStale = func(items []tycho.StaleItem) {
+		p.Send(tui.MsgStaleWarning{Items: items})
+	}
+}
+
+// start runs the WorkerGroup in the background, reporting its progress and
+// completion to p, and finishes the nebula branch when the run ends.
+func (r *nebulaRun) start(ctx context.Context, p *tui.Program) {
+	reportRateLimit(ctx, p, r.limiter)
+	go func() {
+		p.Send(tui.MsgRefactorerReady{Refactorer: r.wg})
+		p.Send(tui.MsgIdlePauserReady{Pauser: r.wg, Timeout: r.idle})
+		p.Send(tui.MsgSilenceLimit{Limit: r.silenceLimit})
+		p.Send(etaHistory(r.wg, r.etaDefault))
+		if r.wg.MaxRuntime > 0 {
+			p.Send(tui.MsgRuntimeLimit{Deadline: time.Now().Add(r.wg.MaxRuntime)})
+		}
+		sb := tui.NewScratchpadBridge(p, r.wg.Nebula.Dir)
+		if err := sb.Start(); err == nil {
+			defer sb.Stop()
+		}
+		results, runErr := r.wg.Run(ctx)
+		p.Send(tui.MsgNebulaDone{Results: results, Err: runErr, Canceled: errors.Is(runErr, nebula.ErrCanceled)})
+		if r.branchName != "" {
+			allSucceeded := runErr == nil
+			gitResult := nebula.PostCompletion(context.Background(), r.workDir, r.branchName, allSucceeded)
+			p.Send(tui.MsgGitPostCompletion{Result: gitResult})
+		}
+	}()
+}
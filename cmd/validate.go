@@ -37,6 +37,13 @@ var validateCmd = &cobra.Command{
 			fmt.Fprintln(os.Stderr, "✓ beads CLI found")
 		}
 
+		if _, err := parseCoderTemplate(&cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+			ok = false
+		} else if cfg.CoderTemplate != "" {
+			fmt.Fprintln(os.Stderr, "✓ coder_template parses")
+		}
+
 		if !ok {
 			os.Exit(1)
 		}
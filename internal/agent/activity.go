@@ -0,0 +1,37 @@
+package agent
+
+import "context"
+
+// activityKey is the context key for the activity callback.
+type activityKey struct{}
+
+// WithActivity returns a context that carries fn as its activity callback.
+// Invokers call it through ReportActivity whenever an agent shows signs of
+// life, so a caller can tell a slow agent from a hung one.
+func WithActivity(ctx context.Context, fn func()) context.Context {
+	return context.WithValue(ctx, activityKey{}, fn)
+}
+
+// ReportActivity calls the activity callback carried by ctx, if any.
+func ReportActivity(ctx context.Context) {
+	if fn, ok := ctx.Value(activityKey{}).(func()); ok && fn != nil {
+		fn()
+	}
+}
+
+// outputKey is the context key for the output callback.
+type outputKey struct{}
+
+// WithOutput returns a context that carries fn as its output callback.
+// Invokers call it through ReportOutput with the agent's messages as they
+// arrive, before the invocation returns its final result.
+func WithOutput(ctx context.Context, fn func(text string)) context.Context {
+	return context.WithValue(ctx, outputKey{}, fn)
+}
+
+// ReportOutput passes text to the output callback carried by ctx, if any.
+func ReportOutput(ctx context.Context, text string) {
+	if fn, ok := ctx.Value(outputKey{}).(func(string)); ok && fn != nil {
+		fn(text)
+	}
+}
@@ -26,6 +26,7 @@ type Agent struct {
 	Model        string
 	MaxBudgetUSD float64
 	AllowedTools []string   // Tool permissions for this agent (passed as --allowedTools flags)
+	DeniedTools  []string   // Tools this agent may never use (passed as --disallowedTools flags)
 	MCP          *MCPConfig // Optional MCP server configuration
 }
 
@@ -39,8 +40,9 @@ type InvocationResult struct {
 
 // ReviewReport captures structured metadata from the reviewer's REPORT: block.
 type ReviewReport struct {
-	Satisfaction     string `toml:"satisfaction"` // high, medium, low
-	Risk             string `toml:"risk"`         // high, medium, low
+	Satisfaction     string `toml:"satisfaction"`         // high, medium, low
+	Risk             string `toml:"risk"`                 // high, medium, low
+	Confidence       string `toml:"confidence,omitempty"` // high, medium, low; "" when the reviewer gave none
 	NeedsHumanReview bool   `toml:"needs_human_review"`
 	Summary          string `toml:"summary"`
 }
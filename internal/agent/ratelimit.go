@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every invocation that draws from
+// it, so parallel phases and successive cycles together stay under the
+// provider's request and token limits. It is safe for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	requests bucket
+	tokens   bucket // capacity 0 disables the token limit
+	waiting  int    // invocations currently blocked in Wait
+	now      func() time.Time
+}
+
+// bucket refills continuously at perMinute up to a burst of one minute's worth.
+type bucket struct {
+	capacity  float64
+	available float64
+	last      time.Time
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerMin invocations and,
+// when tokensPerMin is positive, an estimated tokensPerMin prompt tokens per
+// minute. It returns nil when requestsPerMin is not positive, which
+// WithRateLimiter treats as no limit.
+func NewRateLimiter(requestsPerMin, tokensPerMin int) *RateLimiter {
+	if requestsPerMin <= 0 {
+		return nil
+	}
+	r := &RateLimiter{now: time.Now}
+	start := r.now()
+	r.requests = bucket{capacity: float64(requestsPerMin), available: float64(requestsPerMin), last: start}
+	if tokensPerMin > 0 {
+		r.tokens = bucket{capacity: float64(tokensPerMin), available: float64(tokensPerMin), last: start}
+	}
+	return r
+}
+
+// refill tops the bucket up for the time elapsed since its last refill.
+func (b *bucket) refill(now time.Time) {
+	if b.capacity == 0 {
+		return
+	}
+	b.available += now.Sub(b.last).Minutes() * b.capacity
+	if b.available > b.capacity {
+		b.available = b.capacity
+	}
+	b.last = now
+}
+
+// wait returns how long until the bucket holds n, or 0 if it already does.
+func (b *bucket) wait(n float64) time.Duration {
+	if b.capacity == 0 || b.available >= n {
+		return 0
+	}
+	return time.Duration((n - b.available) / b.capacity * float64(time.Minute))
+}
+
+// Wait blocks until one request and tokens prompt tokens are available, then
+// takes them. Requests larger than the token limit wait for a full bucket.
+// It returns ctx.Err() if ctx is done first.
+func (r *RateLimiter) Wait(ctx context.Context, tokens int) error {
+	r.mu.Lock()
+	need := float64(tokens)
+	if need > r.tokens.capacity {
+		need = r.tokens.capacity
+	}
+	r.waiting++
+	defer func() {
+		r.mu.Lock()
+		r.waiting--
+		r.mu.Unlock()
+	}()
+
+	for {
+		now := r.now()
+		r.requests.refill(now)
+		r.tokens.refill(now)
+		delay := max(r.requests.wait(1), r.tokens.wait(need))
+		if delay == 0 {
+			r.requests.available--
+			if r.tokens.capacity > 0 {
+				r.tokens.available -= need
+			}
+			r.mu.Unlock()
+			return nil
+		}
+		r.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		r.mu.Lock()
+	}
+}
+
+// Utilization reports how much of the limit is in use, from 0 (idle) to 1
+// (exhausted): the fuller of the request and token buckets. It is 1 while
+// any invocation is blocked waiting for capacity.
+func (r *RateLimiter) Utilization() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.waiting > 0 {
+		return 1
+	}
+	now := r.now()
+	r.requests.refill(now)
+	r.tokens.refill(now)
+	used := 1 - r.requests.available/r.requests.capacity
+	if r.tokens.capacity > 0 {
+		used = max(used, 1-r.tokens.available/r.tokens.capacity)
+	}
+	return min(max(used, 0), 1)
+}
+
+// Throttled reports whether any invocation is currently blocked waiting for
+// capacity.
+func (r *RateLimiter) Throttled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.waiting > 0
+}
+
+// rateLimitedInvoker draws from a RateLimiter before each invocation.
+type rateLimitedInvoker struct {
+	Invoker
+	limiter *RateLimiter
+}
+
+// WithRateLimiter wraps inv so every Invoke first waits on limiter. Wrapping
+// each invoker with the same limiter makes them share one bucket. A nil
+// limiter returns inv unchanged.
+func WithRateLimiter(inv Invoker, limiter *RateLimiter) Invoker {
+	if limiter == nil {
+		return inv
+	}
+	return &rateLimitedInvoker{Invoker: inv, limiter: limiter}
+}
+
+// Invoke waits for rate limit capacity, then delegates to the wrapped invoker.
+func (r *rateLimitedInvoker) Invoke(ctx context.Context, a Agent, prompt string, workDir string) (InvocationResult, error) {
+	if err := r.limiter.Wait(ctx, estimatePromptTokens(a, prompt)); err != nil {
+		return InvocationResult{}, err
+	}
+	return r.Invoker.Invoke(ctx, a, prompt, workDir)
+}
+
+// estimatePromptTokens approximates the input tokens of an invocation at
+// one token per four characters of system prompt and prompt.
+func estimatePromptTokens(a Agent, prompt string) int {
+	return (len(a.SystemPrompt) + len(prompt) + 3) / 4
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// fixedLimiter returns a limiter whose clock never advances, so buckets only
+// refill when the test says so.
+func fixedLimiter(rpm, tpm int) (*RateLimiter, *time.Time) {
+	r := NewRateLimiter(rpm, tpm)
+	now := time.Unix(1000, 0)
+	r.now = func() time.Time { return now }
+	r.requests.last = now
+	r.tokens.last = now
+	return r, &now
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no limit", func(t *testing.T) {
+		t.Parallel()
+		if r := NewRateLimiter(0, 1000); r != nil {
+			t.Errorf("NewRateLimiter(0, 1000) = %+v, want nil", r)
+		}
+	})
+
+	t.Run("request bucket blocks until refilled", func(t *testing.T) {
+		t.Parallel()
+		r, now := fixedLimiter(2, 0)
+		for i := 0; i < 2; i++ {
+			if err := r.Wait(context.Background(), 0); err != nil {
+				t.Fatalf("Wait #%d: %v", i, err)
+			}
+		}
+		if got := r.Utilization(); got != 1 {
+			t.Errorf("Utilization = %v, want 1", got)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := r.Wait(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Wait on empty bucket = %v, want deadline exceeded", err)
+		}
+
+		*now = now.Add(30 * time.Second)
+		if got := r.Utilization(); math.Abs(got-0.5) > 1e-9 {
+			t.Errorf("Utilization after 30s = %v, want 0.5", got)
+		}
+		if err := r.Wait(context.Background(), 0); err != nil {
+			t.Fatalf("Wait after refill: %v", err)
+		}
+	})
+
+	t.Run("token bucket", func(t *testing.T) {
+		t.Parallel()
+		r, _ := fixedLimiter(100, 100)
+		if err := r.Wait(context.Background(), 80); err != nil {
+			t.Fatalf("Wait(80): %v", err)
+		}
+		if got := r.Utilization(); math.Abs(got-0.8) > 1e-9 {
+			t.Errorf("Utilization = %v, want 0.8", got)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := r.Wait(ctx, 80); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Wait(80) over token limit = %v, want deadline exceeded", err)
+		}
+	})
+
+	t.Run("throttled while waiting", func(t *testing.T) {
+		t.Parallel()
+		r, _ := fixedLimiter(1, 0)
+		if err := r.Wait(context.Background(), 0); err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- r.Wait(ctx, 0) }()
+		for !r.Throttled() {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait = %v, want canceled", err)
+		}
+		if r.Throttled() {
+			t.Error("Throttled after the waiter gave up")
+		}
+	})
+}
+
+type countingInvoker struct{ calls int }
+
+func (c *countingInvoker) Invoke(context.Context, Agent, string, string) (InvocationResult, error) {
+	c.calls++
+	return InvocationResult{ResultText: "ok"}, nil
+}
+
+func (c *countingInvoker) Validate() error { return nil }
+
+func TestWithRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingInvoker{}
+	if got := WithRateLimiter(inner, nil); got != Invoker(inner) {
+		t.Errorf("WithRateLimiter(inv, nil) = %v, want inv unchanged", got)
+	}
+
+	r, _ := fixedLimiter(1, 0)
+	inv := WithRateLimiter(inner, r)
+	if _, err := inv.Invoke(context.Background(), Agent{}, "prompt", ""); err != nil {
+		t.Fatalf("first Invoke: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := inv.Invoke(ctx, Agent{}, "prompt", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("second Invoke = %v, want canceled while throttled", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("inner calls = %d, want 1", inner.calls)
+	}
+}
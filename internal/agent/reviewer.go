@@ -44,6 +44,21 @@ OPTIONS:
   C) Accept as-is — explain the risk of doing nothing
 RECOMMENDATION: Which option and why, considering effort vs. impact.
 
+## Suggested Fixes
+
+When a fix is small and concrete, you may attach a SUGGESTION block after the
+issue it addresses. Each block holds a unified diff for ONE existing file, with
+accurate hunk line counts, so the coder can apply it with ` + "`git apply`" + `:
+
+SUGGESTION:
+--- a/path/to/file.go
++++ b/path/to/file.go
+@@ -10,3 +10,3 @@
+ unchanged line
+-old line
++new line
+END_SUGGESTION
+
 ## Approval
 
 If no issues are found across all relevant dimensions:
@@ -57,6 +72,7 @@ Always end with a REPORT block, whether approving or raising issues:
 REPORT:
 SATISFACTION: high|medium|low
 RISK: high|medium|low
+CONFIDENCE: high|medium|low — how sure you are of your verdict; say "low" if you could not fully verify the changes
 NEEDS_HUMAN_REVIEW: yes|no — say "yes" if: security-sensitive changes, architecture decisions, public API changes, or anything with significant blast radius
 SUMMARY: One-sentence summary of the work and your assessment.
 
@@ -0,0 +1,69 @@
+package agent
+
+import "strings"
+
+// knownTools are the Claude CLI tools an agent can be allowed or denied.
+var knownTools = map[string]bool{
+	"Bash":         true,
+	"Edit":         true,
+	"Glob":         true,
+	"Grep":         true,
+	"LS":           true,
+	"MultiEdit":    true,
+	"NotebookEdit": true,
+	"NotebookRead": true,
+	"Read":         true,
+	"Task":         true,
+	"TodoWrite":    true,
+	"WebFetch":     true,
+	"WebSearch":    true,
+	"Write":        true,
+}
+
+// ScratchpadNoteCommand is the command an agent in a nebula phase runs to
+// leave a note on the nebula's scratchpad.
+const ScratchpadNoteCommand = "quasar nebula note"
+
+// ScratchpadNoteTool is the permission rule that lets an agent run
+// ScratchpadNoteCommand. The built-in coder and reviewer tool sets include it.
+const ScratchpadNoteTool = "Bash(" + ScratchpadNoteCommand + " *)"
+
+// ToolName returns the tool a permission rule applies to, e.g. "Bash" for
+// "Bash(go *)".
+func ToolName(rule string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(rule), "(")
+	return name
+}
+
+// IsKnownTool reports whether rule names a known tool, with or without a
+// specifier, or an MCP tool ("mcp__server" or "mcp__server__tool").
+func IsKnownTool(rule string) bool {
+	name := ToolName(rule)
+	return knownTools[name] || strings.HasPrefix(name, "mcp__") && len(name) > len("mcp__")
+}
+
+// WithoutTools returns tools minus the rules in denied. A bare tool name in
+// denied removes every rule for that tool, so "Bash" also removes
+// "Bash(go *)"; a rule with a specifier removes only that exact rule.
+func WithoutTools(tools, denied []string) []string {
+	if len(denied) == 0 {
+		return tools
+	}
+	out := make([]string, 0, len(tools))
+	for _, t := range tools {
+		if !toolDenied(t, denied) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// toolDenied reports whether rule is removed by any entry of denied.
+func toolDenied(rule string, denied []string) bool {
+	for _, d := range denied {
+		if d == rule || !strings.Contains(d, "(") && d == ToolName(rule) {
+			return true
+		}
+	}
+	return false
+}
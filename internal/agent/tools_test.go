@@ -0,0 +1,51 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsKnownTool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		{"Read", true},
+		{"Bash(go *)", true},
+		{"WebFetch(domain:example.com)", true},
+		{"mcp__fabric", true},
+		{"mcp__fabric__post", true},
+		{"mcp__", false},
+		{"Shell", false},
+		{"read", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsKnownTool(tt.rule); got != tt.want {
+			t.Errorf("IsKnownTool(%q) = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestWithoutTools(t *testing.T) {
+	t.Parallel()
+
+	tools := []string{"Read", "Edit", "Bash(go *)", "Bash(git diff *)"}
+	tests := []struct {
+		name   string
+		denied []string
+		want   []string
+	}{
+		{"none", nil, tools},
+		{"bare name removes every rule", []string{"Bash"}, []string{"Read", "Edit"}},
+		{"specifier removes one rule", []string{"Bash(go *)"}, []string{"Read", "Edit", "Bash(git diff *)"}},
+		{"unlisted tool", []string{"WebFetch"}, tools},
+	}
+	for _, tt := range tests {
+		if got := WithoutTools(tools, tt.denied); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: WithoutTools = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
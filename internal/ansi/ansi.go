@@ -9,6 +9,7 @@ const (
 	Reset   = "\033[0m"
 	Bold    = "\033[1m"
 	Dim     = "\033[2m"
+	Reverse = "\033[7m"
 	Blue    = "\033[34m"
 	Yellow  = "\033[33m"
 	Green   = "\033[32m"
@@ -26,7 +26,7 @@ var docExemptions = map[string][]string{
 		"NewBranchManager",
 		"PhaseStatusPending", "PhaseStatusCreated", "PhaseStatusInProgress",
 		"PhaseStatusDone", "PhaseStatusFailed", "PhaseStatusSkipped",
-		"ActionCreate", "ActionUpdate", "ActionSkip", "ActionClose", "ActionRetry",
+		"ActionCreate", "ActionUpdate", "ActionSkip", "ActionClose", "ActionRetry", "ActionRename",
 	},
 	// Typed iota constants whose parent type is documented; values are
 	// self-documenting by name.
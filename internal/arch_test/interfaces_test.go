@@ -50,11 +50,21 @@ var allowedColocations = map[string]map[string]bool{
 	},
 	// Nebula defines gate/committer interfaces alongside their implementations.
 	// GitCommitter wraps git operations; Gater/GatePrompter implement the
-	// strategy pattern with multiple gate modes.
+	// strategy pattern with multiple gate modes. Notifier sits alongside
+	// WebhookNotifier, its only built-in delivery channel. CheckRunner sits
+	// alongside CommandCheckRunner, the shell default, like loop's Linter.
 	"nebula": {
 		"GitCommitter": true,
 		"Gater":        true,
 		"GatePrompter": true,
+		"Notifier":     true,
+		"CheckRunner":  true,
+	},
+	// Tycho defines Remediator alongside its built-in strategies: the no-op
+	// default, the hail escalation, and the RemediatorFunc adapter nebula uses
+	// for bead comments.
+	"tycho": {
+		"Remediator": true,
 	},
 	// UI defines the UI interface alongside Printer, the sole stderr-based
 	// implementation. Consumers import ui.UI for testability.
@@ -14,6 +14,7 @@ var layers = map[string]int{
 	"beads":     0,
 	"config":    0,
 	"dag":       0,
+	"dirsnap":   0,
 	"filter":    0,
 	"snapshot":  0,
 	"telemetry": 0,
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -12,7 +14,7 @@ import (
 	"github.com/papapumpkin/quasar/internal/agent"
 )
 
-// Invoker runs the Claude CLI as a subprocess and parses JSON output.
+// Invoker runs the Claude CLI as a subprocess and parses its stream-json output.
 type Invoker struct {
 	ClaudePath         string
 	Verbose            bool
@@ -49,7 +51,7 @@ func buildEnv(base []string) []string {
 func buildArgs(a agent.Agent, prompt string) []string {
 	args := []string{
 		"-p", prompt,
-		"--output-format", "json",
+		"--output-format", "stream-json", "--verbose",
 	}
 
 	if a.SystemPrompt != "" {
@@ -68,6 +70,10 @@ func buildArgs(a agent.Agent, prompt string) []string {
 		args = append(args, "--allowedTools", tool)
 	}
 
+	for _, tool := range a.DeniedTools {
+		args = append(args, "--disallowedTools", tool)
+	}
+
 	if a.MCP != nil && a.MCP.ConfigPath != "" {
 		args = append(args, "--mcp-config", a.MCP.ConfigPath)
 	}
@@ -85,19 +91,22 @@ func (inv *Invoker) Invoke(ctx context.Context, a agent.Agent, prompt string, wo
 	cmd.Env = buildEnv(os.Environ())
 
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stdout = activityWriter{ctx: ctx, w: io.MultiWriter(&stdout, &messageWriter{ctx: ctx})}
+	cmd.Stderr = activityWriter{ctx: ctx, w: &stderr}
 
 	if inv.Verbose {
 		fmt.Fprintf(os.Stderr, "[claude] running: %s %s\n", inv.ClaudePath, strings.Join(args, " "))
 	}
 
-	if err := cmd.Run(); err != nil {
+	agent.ReportActivity(ctx)
+	err := cmd.Run()
+	agent.ReportActivity(ctx)
+	if err != nil {
 		return agent.InvocationResult{}, fmt.Errorf("claude invocation failed: %w\nstderr: %s", err, stderr.String())
 	}
 
-	var resp CLIResponse
-	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+	resp, err := parseResult(stdout.Bytes())
+	if err != nil {
 		return agent.InvocationResult{}, fmt.Errorf("failed to parse claude JSON output: %w\nraw output: %s", err, stdout.String())
 	}
 
@@ -113,6 +122,77 @@ func (inv *Invoker) Invoke(ctx context.Context, a agent.Agent, prompt string, wo
 	}, nil
 }
 
+// parseResult returns the result event of the CLI's stream-json output: the
+// last line whose type is "result". The lines before it are the session's
+// system, assistant, and tool events, written as the agent works.
+func parseResult(out []byte) (CLIResponse, error) {
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		var resp CLIResponse
+		if json.Unmarshal(lines[i], &resp) == nil && resp.Type == "result" {
+			return resp, nil
+		}
+	}
+	return CLIResponse{}, errors.New("no result event")
+}
+
+// activityWriter reports agent activity on every write of CLI output. The
+// stream-json format writes an event per agent turn and tool call, so the
+// writes track the agent's progress rather than only its exit.
+type activityWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (a activityWriter) Write(p []byte) (int, error) {
+	agent.ReportActivity(a.ctx)
+	return a.w.Write(p)
+}
+
+// messageWriter splits the CLI's stream-json output into events and reports
+// the text and tool calls of each assistant message through
+// agent.ReportOutput as it arrives.
+type messageWriter struct {
+	ctx     context.Context
+	partial []byte // an event line not yet terminated by a newline
+}
+
+func (m *messageWriter) Write(p []byte) (int, error) {
+	m.partial = append(m.partial, p...)
+	for {
+		i := bytes.IndexByte(m.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if text := messageText(m.partial[:i]); text != "" {
+			agent.ReportOutput(m.ctx, text)
+		}
+		m.partial = m.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// messageText returns the text blocks of an assistant event, with a line
+// naming each tool it calls, or "" for any other event.
+func messageText(line []byte) string {
+	var ev streamEvent
+	if json.Unmarshal(line, &ev) != nil || ev.Type != "assistant" {
+		return ""
+	}
+	var parts []string
+	for _, c := range ev.Message.Content {
+		switch c.Type {
+		case "text":
+			if t := strings.TrimSpace(c.Text); t != "" {
+				parts = append(parts, t)
+			}
+		case "tool_use":
+			parts = append(parts, "→ "+c.Name)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
 func (inv *Invoker) Validate() error {
 	cmd := inv.execCommand(inv.ClaudePath, "--version")
 	cmd.Env = buildEnv(os.Environ())
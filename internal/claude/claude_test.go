@@ -6,7 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -101,8 +103,81 @@ func TestInvoke_Success(t *testing.T) {
 	}
 }
 
+func TestInvoke_ReportsActivity(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "claude", `echo "warming up" >&2
+printf '%s' '{"type":"result","result":"ok"}'`)
+
+	inv := newTestInvoker("claude", false, fakeExecContextWith(script), nil)
+	var mu sync.Mutex
+	calls := 0
+	ctx := agent.WithActivity(context.Background(), func() {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+	})
+	if _, err := inv.Invoke(ctx, agent.Agent{}, "do stuff", dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Start, completion, and at least one write each to stdout and stderr.
+	if calls < 4 {
+		t.Errorf("activity reported %d times, want at least 4", calls)
+	}
+}
+
+func TestInvoke_StreamJSON(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "claude", `echo '{"type":"system","subtype":"init","session_id":"s1"}'
+sleep 0.05
+echo '{"type":"assistant","message":{"content":[{"type":"text","text":"working"}]}}'
+sleep 0.05
+echo '{"type":"result","subtype":"success","result":"done","session_id":"s1","total_cost_usd":0.1}'`)
+
+	inv := newTestInvoker("claude", false, fakeExecContextWith(script), nil)
+	var mu sync.Mutex
+	calls := 0
+	ctx := agent.WithActivity(context.Background(), func() {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+	})
+	result, err := inv.Invoke(ctx, agent.Agent{}, "do stuff", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ResultText != "done" || result.SessionID != "s1" || result.CostUSD != 0.1 {
+		t.Errorf("result = %+v, want the result event's fields", result)
+	}
+	// Start, completion, and one write per event while the agent runs.
+	if calls < 5 {
+		t.Errorf("activity reported %d times, want at least 5", calls)
+	}
+}
+
+func TestInvoke_ReportsOutput(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "claude", `echo '{"type":"system","subtype":"init","session_id":"s1"}'
+echo '{"type":"assistant","message":{"content":[{"type":"text","text":"reading the code"},{"type":"tool_use","name":"Bash","input":{}}]}}'
+printf '%s' '{"type":"assistant","message":{"content":[{"type":"text","text":"all done"}]}}'
+sleep 0.05
+echo
+echo '{"type":"result","subtype":"success","result":"all done","session_id":"s1"}'`)
+
+	inv := newTestInvoker("claude", false, fakeExecContextWith(script), nil)
+	var got []string
+	ctx := agent.WithOutput(context.Background(), func(text string) { got = append(got, text) })
+	if _, err := inv.Invoke(ctx, agent.Agent{}, "do stuff", dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"reading the code\n→ Bash", "all done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reported output = %q, want %q", got, want)
+	}
+}
+
 func TestInvoke_IsError(t *testing.T) {
 	resp := CLIResponse{
+		Type:    "result",
 		IsError: true,
 		Result:  "something went wrong in claude",
 	}
@@ -187,6 +262,7 @@ func TestInvoke_ContextCancellation(t *testing.T) {
 
 func TestInvoke_VerboseLogging(t *testing.T) {
 	resp := CLIResponse{
+		Type:      "result",
 		Result:    "done",
 		SessionID: "sess-verbose",
 	}
@@ -275,6 +351,21 @@ func TestBuildArgs_AllowedTools(t *testing.T) {
 	}
 }
 
+func TestBuildArgs_DeniedTools(t *testing.T) {
+	a := agent.Agent{DeniedTools: []string{"WebFetch", "Bash(curl *)"}}
+	args := buildArgs(a, "do stuff")
+
+	var tools []string
+	for i, arg := range args {
+		if arg == "--disallowedTools" && i+1 < len(args) {
+			tools = append(tools, args[i+1])
+		}
+	}
+	if len(tools) != 2 || tools[0] != "WebFetch" || tools[1] != "Bash(curl *)" {
+		t.Errorf("--disallowedTools values = %v, want [WebFetch Bash(curl *)]", tools)
+	}
+}
+
 func TestBuildArgs_NoAllowedTools(t *testing.T) {
 	a := agent.Agent{}
 	args := buildArgs(a, "do stuff")
@@ -352,12 +443,12 @@ func TestBuildArgs_BaseFlags(t *testing.T) {
 	a := agent.Agent{}
 	args := buildArgs(a, "hello world")
 
-	// Should always have -p and --output-format json
+	// Should always have -p and --output-format stream-json, which needs --verbose
 	if args[0] != "-p" || args[1] != "hello world" {
 		t.Errorf("expected args[0:2] = [-p, hello world], got %v", args[0:2])
 	}
-	if args[2] != "--output-format" || args[3] != "json" {
-		t.Errorf("expected args[2:4] = [--output-format, json], got %v", args[2:4])
+	if args[2] != "--output-format" || args[3] != "stream-json" || args[4] != "--verbose" {
+		t.Errorf("expected args[2:5] = [--output-format, stream-json, --verbose], got %v", args[2:5])
 	}
 }
 
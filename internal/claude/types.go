@@ -11,3 +11,16 @@ type CLIResponse struct {
 	SessionID     string  `json:"session_id"`
 	TotalCostUSD  float64 `json:"total_cost_usd"`
 }
+
+// streamEvent is the part of a stream-json event line that carries an
+// assistant message.
+type streamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+			Name string `json:"name"`
+		} `json:"content"`
+	} `json:"message"`
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,16 +13,36 @@ var DefaultLintCommands = []string{"go vet ./...", "go fmt ./..."}
 // Config holds all runtime configuration for a quasar session.
 // Values are populated from .quasar.yaml, QUASAR_* env vars, and CLI flags.
 type Config struct {
-	ClaudePath           string   `mapstructure:"claude_path"`
-	BeadsPath            string   `mapstructure:"beads_path"`
-	WorkDir              string   `mapstructure:"work_dir"`
-	MaxReviewCycles      int      `mapstructure:"max_review_cycles"`
-	MaxBudgetUSD         float64  `mapstructure:"max_budget_usd"`
-	Model                string   `mapstructure:"model"`
-	CoderSystemPrompt    string   `mapstructure:"coder_system_prompt"`
-	ReviewerSystemPrompt string   `mapstructure:"reviewer_system_prompt"`
-	Verbose              bool     `mapstructure:"verbose"`
-	LintCommands         []string `mapstructure:"lint_commands"`
+	ClaudePath           string        `mapstructure:"claude_path"`
+	BeadsPath            string        `mapstructure:"beads_path"`
+	WorkDir              string        `mapstructure:"work_dir"`
+	MaxReviewCycles      int           `mapstructure:"max_review_cycles"`
+	MaxBudgetUSD         float64       `mapstructure:"max_budget_usd"`
+	Model                string        `mapstructure:"model"`
+	CoderSystemPrompt    string        `mapstructure:"coder_system_prompt"`
+	ReviewerSystemPrompt string        `mapstructure:"reviewer_system_prompt"`
+	CoderTemplate        string        `mapstructure:"coder_template"` // text/template for the coder's task prompt; "" = built-in format
+	Verbose              bool          `mapstructure:"verbose"`
+	LintCommands         []string      `mapstructure:"lint_commands"`
+	TestCommands         []string      `mapstructure:"test_commands"`
+	NotifyWebhook        string        `mapstructure:"notify_webhook"`
+	IdleTimeout          time.Duration `mapstructure:"idle_timeout"`
+	SilentPhaseWarning   time.Duration `mapstructure:"silent_phase_warning"` // warn in the TUI when a running phase shows no activity this long
+	ETADefaultPhase      time.Duration `mapstructure:"eta_default_phase"`    // assumed duration of a never-run phase in the TUI's ETA
+
+	EscalateReviewAfterCycles int    `mapstructure:"escalate_review_after_cycles"`
+	EscalationModel           string `mapstructure:"escalation_model"`
+	StopOnDegradingReview     bool   `mapstructure:"stop_on_degrading_review"`
+	StopOnNoProgress          bool   `mapstructure:"stop_on_no_progress"`
+	RetryOnTestFailure        bool   `mapstructure:"retry_on_test_failure"`
+	IncludeDiffInReview       bool   `mapstructure:"include_diff_in_review"`
+	ReviewDiffOnly            bool   `mapstructure:"review_diff_only"`
+	AutoApplySuggestions      bool   `mapstructure:"auto_apply_suggestions"`
+
+	RateLimitRPM int `mapstructure:"rate_limit_rpm"` // agent invocations per minute across all phases; 0 = unlimited
+	RateLimitTPM int `mapstructure:"rate_limit_tpm"` // estimated prompt tokens per minute; 0 = unlimited
+
+	HookQueueSize int `mapstructure:"hook_queue_size"` // pending bead events per task before the loop waits; 0 = run hooks inline
 }
 
 // Load reads configuration from viper, applying built-in defaults for any
@@ -35,8 +56,25 @@ func Load() (Config, error) {
 	viper.SetDefault("model", "")
 	viper.SetDefault("coder_system_prompt", "")
 	viper.SetDefault("reviewer_system_prompt", "")
+	viper.SetDefault("coder_template", "")
 	viper.SetDefault("verbose", false)
 	viper.SetDefault("lint_commands", DefaultLintCommands)
+	viper.SetDefault("test_commands", []string{})
+	viper.SetDefault("notify_webhook", "")
+	viper.SetDefault("idle_timeout", 0)
+	viper.SetDefault("silent_phase_warning", 10*time.Minute)
+	viper.SetDefault("eta_default_phase", 10*time.Minute)
+	viper.SetDefault("escalate_review_after_cycles", 0)
+	viper.SetDefault("stop_on_degrading_review", false)
+	viper.SetDefault("stop_on_no_progress", false)
+	viper.SetDefault("retry_on_test_failure", false)
+	viper.SetDefault("include_diff_in_review", false)
+	viper.SetDefault("review_diff_only", false)
+	viper.SetDefault("auto_apply_suggestions", false)
+	viper.SetDefault("escalation_model", "")
+	viper.SetDefault("rate_limit_rpm", 0)
+	viper.SetDefault("rate_limit_tpm", 0)
+	viper.SetDefault("hook_queue_size", 0)
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
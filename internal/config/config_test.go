@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -33,7 +34,11 @@ func TestLoad_Defaults(t *testing.T) {
 		{"Model", cfg.Model, ""},
 		{"CoderSystemPrompt", cfg.CoderSystemPrompt, ""},
 		{"ReviewerSystemPrompt", cfg.ReviewerSystemPrompt, ""},
+		{"CoderTemplate", cfg.CoderTemplate, ""},
 		{"Verbose", cfg.Verbose, false},
+		{"NotifyWebhook", cfg.NotifyWebhook, ""},
+		{"IdleTimeout", cfg.IdleTimeout, time.Duration(0)},
+		{"ETADefaultPhase", cfg.ETADefaultPhase, 10 * time.Minute},
 	}
 
 	for _, tt := range tests {
@@ -104,6 +109,20 @@ func TestLoad_EnvOverrides(t *testing.T) {
 			field:  func(c Config) any { return c.Verbose },
 			want:   true,
 		},
+		{
+			name:   "notify_webhook",
+			envKey: "QUASAR_NOTIFY_WEBHOOK",
+			envVal: "https://hooks.example.com/T000/B000",
+			field:  func(c Config) any { return c.NotifyWebhook },
+			want:   "https://hooks.example.com/T000/B000",
+		},
+		{
+			name:   "idle_timeout",
+			envKey: "QUASAR_IDLE_TIMEOUT",
+			envVal: "15m",
+			field:  func(c Config) any { return c.IdleTimeout },
+			want:   15 * time.Minute,
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,7 +18,9 @@ func (d *DAG) BetweennessCentrality() map[string]float64 {
 		return cb
 	}
 
-	for s := range d.nodes {
+	// Visit sources and neighbors in ID order so the accumulated scores are
+	// identical from run to run.
+	for _, s := range d.Nodes() {
 		stack, sigma, pred := d.brandesBFS(s)
 		d.brandesAccumulate(s, stack, sigma, pred, cb)
 	}
@@ -55,7 +57,7 @@ func (d *DAG) brandesBFS(s string) ([]string, map[string]float64, map[string][]s
 		stack = append(stack, v)
 
 		// Traverse execution-order edges: from v to its dependents.
-		for w := range d.reverse[v] {
+		for _, w := range sortedKeys(d.reverse[v]) {
 			if dist[w] < 0 {
 				dist[w] = dist[v] + 1
 				queue = append(queue, w)
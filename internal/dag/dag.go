@@ -244,6 +244,16 @@ func (d *DAG) Nodes() []string {
 	return ids
 }
 
+// sortedKeys returns the members of an edge set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Len returns the number of nodes in the DAG.
 func (d *DAG) Len() int {
 	return len(d.nodes)
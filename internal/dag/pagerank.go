@@ -38,8 +38,11 @@ func (d *DAG) PageRank(opts PageRankOptions) map[string]float64 {
 	initial := 1.0 / nf
 	base := (1.0 - opts.Damping) / nf
 
+	// Iterate in ID order so floating-point sums, and therefore scores, are
+	// identical from run to run.
+	ids := d.Nodes()
 	rank := make(map[string]float64, n)
-	for id := range d.nodes {
+	for _, id := range ids {
 		rank[id] = initial
 	}
 
@@ -47,7 +50,7 @@ func (d *DAG) PageRank(opts PageRankOptions) map[string]float64 {
 		// Dangling node contribution: nodes with no dependencies
 		// (out-degree 0 in the link graph) redistribute uniformly.
 		var danglingSum float64
-		for id := range d.nodes {
+		for _, id := range ids {
 			if len(d.adjacency[id]) == 0 {
 				danglingSum += rank[id]
 			}
@@ -55,10 +58,10 @@ func (d *DAG) PageRank(opts PageRankOptions) map[string]float64 {
 		danglingShare := opts.Damping * danglingSum / nf
 
 		newRank := make(map[string]float64, n)
-		for v := range d.nodes {
+		for _, v := range ids {
 			// Sum contributions from nodes that depend on v.
 			var sum float64
-			for u := range d.reverse[v] {
+			for _, u := range sortedKeys(d.reverse[v]) {
 				outDeg := len(d.adjacency[u])
 				if outDeg > 0 {
 					sum += rank[u] / float64(outDeg)
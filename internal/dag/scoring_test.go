@@ -524,3 +524,24 @@ func TestComputeImpact_Complex_RelativeOrdering(t *testing.T) {
 			d.Node("C").Impact, d.Node("D").Impact)
 	}
 }
+
+func TestComputeImpact_Reproducible(t *testing.T) {
+	t.Parallel()
+
+	// Map iteration order varies between calls; the scores must not.
+	want := buildComplex(t)
+	if err := want.ComputeImpact(DefaultScoringOptions()); err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		d := buildComplex(t)
+		if err := d.ComputeImpact(DefaultScoringOptions()); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range d.Nodes() {
+			if got, exp := d.Node(id).Impact, want.Node(id).Impact; got != exp {
+				t.Fatalf("impact of %s = %v, want exactly %v", id, got, exp)
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package dirsnap
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxStatBar is the widest +/- bar DiffStat draws for a single file.
+const maxStatBar = 50
+
+// change is one path that differs between two manifests. old is nil for an
+// added file and new is nil for a removed one.
+type change struct {
+	path     string
+	old, new *File
+}
+
+// changedPaths returns the paths that differ between manifests a and b,
+// sorted by path.
+func changedPaths(a, b map[string]File) []change {
+	all := make(map[string]File, len(a)+len(b))
+	for p, f := range a {
+		all[p] = f
+	}
+	for p, f := range b {
+		all[p] = f
+	}
+	var changes []change
+	for _, p := range sortedPaths(all) {
+		fa, inA := a[p]
+		fb, inB := b[p]
+		if inA && inB && fa == fb {
+			continue
+		}
+		c := change{path: p}
+		if inA {
+			c.old = &fa
+		}
+		if inB {
+			c.new = &fb
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// Diff returns a git-style unified diff from snapshot base to snapshot head.
+func (s *Store) Diff(base, head string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, b, err := s.loadPair(base, head)
+	if err != nil {
+		return "", err
+	}
+	return s.diff(a.Files, b.Files, s.readObjectFile, s.readObjectFile)
+}
+
+// DiffWorkTree returns a git-style unified diff from HEAD to the current
+// contents of the working directory.
+func (s *Store) DiffWorkTree() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head, err := s.head()
+	if err != nil {
+		return "", err
+	}
+	snap, err := s.load(head)
+	if err != nil {
+		return "", err
+	}
+	current, err := s.scan(false)
+	if err != nil {
+		return "", err
+	}
+	return s.diff(snap.Files, current, s.readObjectFile, s.readWorkFile)
+}
+
+// DiffStat returns a git diff --stat style summary from snapshot base to
+// snapshot head: one " path | N +-" line per file and a totals line.
+func (s *Store) DiffStat(base, head string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, b, err := s.loadPair(base, head)
+	if err != nil {
+		return "", err
+	}
+	changes := changedPaths(a.Files, b.Files)
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	type fileStat struct {
+		path     string
+		add, del int
+		binary   string // "Bin X -> Y bytes" for binary files
+	}
+	stats := make([]fileStat, 0, len(changes))
+	var added, removed, maxChange, maxPath int
+	for _, c := range changes {
+		oldData, newData, err := s.pairContent(c, s.readObjectFile, s.readObjectFile)
+		if err != nil {
+			return "", err
+		}
+		st := fileStat{path: c.path}
+		if isBinary(oldData) || isBinary(newData) {
+			st.binary = fmt.Sprintf("Bin %d -> %d bytes", len(oldData), len(newData))
+		} else {
+			st.add, st.del = countEdits(lineEdits(splitLines(oldData), splitLines(newData)))
+			added += st.add
+			removed += st.del
+			maxChange = max(maxChange, st.add+st.del)
+		}
+		maxPath = max(maxPath, len(c.path))
+		stats = append(stats, st)
+	}
+
+	width := len(strconv.Itoa(maxChange))
+	var out strings.Builder
+	for _, st := range stats {
+		stat := st.binary
+		if stat == "" {
+			plus, minus := st.add, st.del
+			if maxChange > maxStatBar {
+				plus, minus = scaleBar(st.add, maxChange), scaleBar(st.del, maxChange)
+			}
+			stat = fmt.Sprintf("%*d %s%s", width, st.add+st.del, strings.Repeat("+", plus), strings.Repeat("-", minus))
+		}
+		fmt.Fprintf(&out, " %-*s | %s\n", maxPath, st.path, stat)
+	}
+	fmt.Fprintf(&out, " %d file%s changed", len(changes), plural(len(changes)))
+	if added > 0 || removed == 0 {
+		fmt.Fprintf(&out, ", %d insertion%s(+)", added, plural(added))
+	}
+	if removed > 0 || added == 0 {
+		fmt.Fprintf(&out, ", %d deletion%s(-)", removed, plural(removed))
+	}
+	out.WriteString("\n")
+	return out.String(), nil
+}
+
+// contentFunc returns the content of path as recorded by f.
+type contentFunc func(path string, f File) ([]byte, error)
+
+// readObjectFile reads f's content from the object store.
+func (s *Store) readObjectFile(_ string, f File) ([]byte, error) {
+	return s.readObject(f.Hash)
+}
+
+// readWorkFile reads path's current content from the working directory.
+func (s *Store) readWorkFile(path string, _ File) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+}
+
+// loadPair loads the snapshots base and head.
+func (s *Store) loadPair(base, head string) (*Snapshot, *Snapshot, error) {
+	a, err := s.load(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := s.load(head)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+// pairContent returns both sides of c, with nil for a missing side.
+func (s *Store) pairContent(c change, readOld, readNew contentFunc) (oldData, newData []byte, err error) {
+	if c.old != nil {
+		if oldData, err = readOld(c.path, *c.old); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.new != nil {
+		if newData, err = readNew(c.path, *c.new); err != nil {
+			return nil, nil, err
+		}
+	}
+	return oldData, newData, nil
+}
+
+// diff renders the unified diff between manifests a and b, reading each
+// side's content with the matching contentFunc.
+func (s *Store) diff(a, b map[string]File, readOld, readNew contentFunc) (string, error) {
+	var out strings.Builder
+	for _, c := range changedPaths(a, b) {
+		oldData, newData, err := s.pairContent(c, readOld, readNew)
+		if err != nil {
+			return "", err
+		}
+		writeFileHeader(&out, c)
+		if c.old != nil && c.new != nil && bytes.Equal(oldData, newData) {
+			continue // mode change only
+		}
+		if isBinary(oldData) || isBinary(newData) {
+			fmt.Fprintf(&out, "Binary files %s and %s differ\n", oldName(c), newName(c))
+			continue
+		}
+		fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName(c), newName(c))
+		writeHunks(&out, lineEdits(splitLines(oldData), splitLines(newData)))
+	}
+	return out.String(), nil
+}
+
+// writeFileHeader writes the "diff --git" line and any file mode lines for c.
+func writeFileHeader(out *strings.Builder, c change) {
+	fmt.Fprintf(out, "diff --git a/%s b/%s\n", c.path, c.path)
+	switch {
+	case c.old == nil:
+		fmt.Fprintf(out, "new file mode %s\n", gitMode(c.new.Mode))
+	case c.new == nil:
+		fmt.Fprintf(out, "deleted file mode %s\n", gitMode(c.old.Mode))
+	case c.old.Mode != c.new.Mode:
+		fmt.Fprintf(out, "old mode %s\nnew mode %s\n", gitMode(c.old.Mode), gitMode(c.new.Mode))
+	}
+}
+
+func oldName(c change) string {
+	if c.old == nil {
+		return "/dev/null"
+	}
+	return "a/" + c.path
+}
+
+func newName(c change) string {
+	if c.new == nil {
+		return "/dev/null"
+	}
+	return "b/" + c.path
+}
+
+// gitMode renders perm the way git shows a regular file's mode.
+func gitMode(perm os.FileMode) string {
+	if perm&0o111 != 0 {
+		return "100755"
+	}
+	return "100644"
+}
+
+// isBinary applies git's heuristic: a NUL byte in the first 8000 bytes.
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0
+}
+
+// scaleBar scales n so that total fits in maxStatBar columns, keeping any
+// non-zero count visible.
+func scaleBar(n, total int) int {
+	if n == 0 {
+		return 0
+	}
+	return max(1, n*maxStatBar/total)
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
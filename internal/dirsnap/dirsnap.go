@@ -0,0 +1,310 @@
+// Package dirsnap records content snapshots of a working directory so that
+// cycles and phases can be checkpointed, diffed, and rolled back in
+// directories that are not git repositories.
+//
+// Snapshots form a chain like commits: each has a parent, a message, and a
+// manifest mapping file paths to content hashes. File contents are stored
+// once under their SHA-256, so unchanged files cost nothing per snapshot.
+// The store lives in .quasar/snapshots under the working directory; .git and
+// .quasar directories are never snapshotted, and only regular files are
+// recorded. Paths matched by a .gitignore, by .quasarignore at the root, or
+// by the store's Exclude list are left out too, so build output and the
+// run's own files are neither diffed nor rolled back.
+package dirsnap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// File is one regular file recorded in a snapshot.
+type File struct {
+	Hash string      `json:"hash"` // hex SHA-256 of the content
+	Mode fs.FileMode `json:"mode"` // permission bits
+}
+
+// Snapshot is a recorded state of the working directory.
+type Snapshot struct {
+	ID      string          `json:"id"`
+	Parent  string          `json:"parent,omitempty"`
+	Message string          `json:"message"`
+	Created time.Time       `json:"created"`
+	Files   map[string]File `json:"files"` // keyed by slash-separated path relative to the root
+}
+
+// Store is a snapshot store for one working directory. It is safe for
+// concurrent use; operations are serialized.
+type Store struct {
+	root    string   // working directory being snapshotted
+	dir     string   // where snapshots and objects are kept
+	exclude []string // paths relative to root left out of snapshots; see Exclude
+	mu      sync.Mutex
+}
+
+// Open returns the snapshot store for the working directory root, creating
+// its storage directories if needed.
+func Open(root string) (*Store, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving snapshot root: %w", err)
+	}
+	s := &Store{root: abs, dir: filepath.Join(abs, ".quasar", "snapshots")}
+	for _, d := range []string{s.objectsDir(), s.snapshotsDir()} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, fmt.Errorf("creating snapshot store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Exclude leaves paths, relative to the root, out of every later scan, so
+// they are neither recorded, diffed, nor touched by Restore. It is meant
+// for files the run itself writes, such as a nebula's state and logs.
+func (s *Store) Exclude(paths ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exclude = append(s.exclude, paths...)
+}
+
+// Head returns the ID of the current snapshot. The first call on a new
+// store records a baseline snapshot of the working directory.
+func (s *Store) Head() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.head()
+}
+
+// Commit records the working directory as a new snapshot on top of HEAD and
+// returns its ID. When nothing changed since HEAD no snapshot is recorded,
+// and the HEAD ID is returned with changed set to false.
+func (s *Store) Commit(message string) (id string, changed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head, err := s.head()
+	if err != nil {
+		return "", false, err
+	}
+	parent, err := s.load(head)
+	if err != nil {
+		return "", false, err
+	}
+	files, err := s.scan(true)
+	if err != nil {
+		return "", false, err
+	}
+	if sameFiles(parent.Files, files) {
+		return head, false, nil
+	}
+	snap, err := s.save(message, head, files)
+	if err != nil {
+		return "", false, err
+	}
+	return snap.ID, true, nil
+}
+
+// Parent returns the parent of snapshot id, or "" for the baseline.
+func (s *Store) Parent(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, err := s.load(id)
+	if err != nil {
+		return "", err
+	}
+	return snap.Parent, nil
+}
+
+// Contains reports whether snapshot id is HEAD or one of its ancestors.
+// An unknown id is not contained.
+func (s *Store) Contains(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	head, err := s.head()
+	if err != nil {
+		return false
+	}
+	ok, err := s.isAncestor(id, head)
+	return ok && err == nil
+}
+
+// Restore makes the working directory match snapshot id and moves HEAD to
+// it, like git reset --hard. id must be HEAD or one of its ancestors.
+// Files that are not in the snapshot are removed.
+func (s *Store) Restore(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head, err := s.head()
+	if err != nil {
+		return err
+	}
+	if ok, err := s.isAncestor(id, head); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("snapshot %s is not an ancestor of HEAD %s", id, head)
+	}
+	target, err := s.load(id)
+	if err != nil {
+		return err
+	}
+	current, err := s.scan(false)
+	if err != nil {
+		return err
+	}
+
+	for path := range current {
+		if _, ok := target.Files[path]; ok {
+			continue
+		}
+		if err := s.removeFile(path); err != nil {
+			return err
+		}
+	}
+	for path, f := range target.Files {
+		if cur, ok := current[path]; ok && cur == f {
+			continue
+		}
+		if err := s.checkout(path, f); err != nil {
+			return err
+		}
+	}
+	return s.writeHead(id)
+}
+
+// Status lists uncommitted changes against HEAD in git status --porcelain
+// style (" M", " D", "??"), skipping paths under any of exclude (relative
+// to the root). An empty result means the working directory matches HEAD.
+func (s *Store) Status(exclude ...string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head, err := s.head()
+	if err != nil {
+		return nil, err
+	}
+	snap, err := s.load(head)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.scan(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, c := range changedPaths(snap.Files, current) {
+		if excluded(c.path, exclude) {
+			continue
+		}
+		switch {
+		case c.old == nil:
+			lines = append(lines, "?? "+c.path)
+		case c.new == nil:
+			lines = append(lines, " D "+c.path)
+		default:
+			lines = append(lines, " M "+c.path)
+		}
+	}
+	return lines, nil
+}
+
+// head returns the HEAD ID, recording a baseline snapshot if there is none.
+// The caller must hold s.mu.
+func (s *Store) head() (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, "HEAD"))
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("reading snapshot HEAD: %w", err)
+	}
+	files, err := s.scan(true)
+	if err != nil {
+		return "", err
+	}
+	snap, err := s.save("baseline", "", files)
+	if err != nil {
+		return "", err
+	}
+	return snap.ID, nil
+}
+
+// writeHead points HEAD at id.
+func (s *Store) writeHead(id string) error {
+	if err := writeAtomic(filepath.Join(s.dir, "HEAD"), []byte(id+"\n"), 0o644); err != nil {
+		return fmt.Errorf("writing snapshot HEAD: %w", err)
+	}
+	return nil
+}
+
+// save writes a snapshot of files with the given parent and makes it HEAD.
+// The ID is derived from the parent and the manifest, so it is stable for
+// the same history.
+func (s *Store) save(message, parent string, files map[string]File) (*Snapshot, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "parent %s\n", parent)
+	for _, path := range sortedPaths(files) {
+		f := files[path]
+		fmt.Fprintf(h, "%s %o %s\n", f.Hash, f.Mode, path)
+	}
+	snap := &Snapshot{
+		ID:      "snap-" + hex.EncodeToString(h.Sum(nil)[:8]),
+		Parent:  parent,
+		Message: message,
+		Created: time.Now().UTC(),
+		Files:   files,
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding snapshot: %w", err)
+	}
+	if err := writeAtomic(s.snapshotPath(snap.ID), data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := s.writeHead(snap.ID); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// load reads snapshot id.
+func (s *Store) load(id string) (*Snapshot, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return nil, fmt.Errorf("invalid snapshot ID %q", id)
+	}
+	data, err := os.ReadFile(s.snapshotPath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("unknown snapshot %q", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", id, err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", id, err)
+	}
+	return &snap, nil
+}
+
+// isAncestor reports whether id is head or reachable from it by parents.
+func (s *Store) isAncestor(id, head string) (bool, error) {
+	for cur := head; cur != ""; {
+		if cur == id {
+			return true, nil
+		}
+		snap, err := s.load(cur)
+		if err != nil {
+			return false, err
+		}
+		cur = snap.Parent
+	}
+	return false, nil
+}
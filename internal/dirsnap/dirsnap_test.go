@@ -0,0 +1,260 @@
+package dirsnap
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFile creates path under root (and its parents) with content.
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func openStore(t *testing.T) (*Store, string) {
+	t.Helper()
+	root := t.TempDir()
+	s, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, root
+}
+
+func TestCommitAndHead(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, "main.go", "package main\n")
+	writeFile(t, root, ".git/config", "ignored")
+
+	base, err := s.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := s.Head(); again != base {
+		t.Errorf("Head changed without a commit: %s -> %s", base, again)
+	}
+
+	id, changed, err := s.Commit("no-op")
+	if err != nil || changed || id != base {
+		t.Errorf("clean Commit = %s, %v, %v; want %s, false, nil", id, changed, err, base)
+	}
+
+	writeFile(t, root, "main.go", "package main\n\nfunc main() {}\n")
+	id, changed, err = s.Commit("cycle 1")
+	if err != nil || !changed || id == base {
+		t.Fatalf("Commit = %s, %v, %v; want a new snapshot", id, changed, err)
+	}
+	if head, _ := s.Head(); head != id {
+		t.Errorf("Head = %s, want %s", head, id)
+	}
+	if parent, _ := s.Parent(id); parent != base {
+		t.Errorf("Parent = %s, want %s", parent, base)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, "keep.txt", "keep")
+	writeFile(t, root, "edit.txt", "before")
+	writeFile(t, root, "gone.txt", "bye")
+	if _, err := s.Head(); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, root, "edit.txt", "after")
+	writeFile(t, root, "new.txt", "hi")
+	writeFile(t, root, "out/build.log", "noise")
+	if err := os.Remove(filepath.Join(root, "gone.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Status("out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{" M edit.txt", " D gone.txt", "?? new.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Status = %q, want %q", got, want)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, "a.txt", "v1\n")
+	base, err := s.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, root, "a.txt", "v2\n")
+	writeFile(t, root, "dir/b.txt", "new\n")
+	if _, _, err := s.Commit("cycle 1"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, root, "scratch.txt", "uncommitted")
+
+	if err := s.Restore(base); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "a.txt")); string(data) != "v1\n" {
+		t.Errorf("a.txt = %q, want v1", data)
+	}
+	for _, p := range []string{"dir/b.txt", "dir", "scratch.txt"} {
+		if _, err := os.Stat(filepath.Join(root, p)); !os.IsNotExist(err) {
+			t.Errorf("%s survived the restore", p)
+		}
+	}
+	if head, _ := s.Head(); head != base {
+		t.Errorf("Head after restore = %s, want %s", head, base)
+	}
+	if lines, _ := s.Status(); len(lines) != 0 {
+		t.Errorf("Status after restore = %q, want clean", lines)
+	}
+}
+
+func TestRestoreRejectsNonAncestor(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, "a.txt", "v1\n")
+	base, _ := s.Head()
+	writeFile(t, root, "a.txt", "v2\n")
+	later, _, err := s.Commit("cycle 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Contains(later) || !s.Contains(base) {
+		t.Error("Contains missed HEAD or its parent")
+	}
+	if err := s.Restore(base); err != nil {
+		t.Fatal(err)
+	}
+	if s.Contains(later) || s.Contains("snap-missing") {
+		t.Error("Contains reported a snapshot ahead of HEAD or an unknown one")
+	}
+
+	if err := s.Restore(later); err == nil {
+		t.Error("Restore to a snapshot ahead of HEAD succeeded")
+	}
+	if err := s.Restore("snap-missing"); err == nil {
+		t.Error("Restore to an unknown snapshot succeeded")
+	}
+	if err := s.Restore("../escape"); err == nil {
+		t.Error("Restore accepted a path-like snapshot ID")
+	}
+}
+
+func TestDiffAndDiffStat(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, "edit.txt", "one\ntwo\nthree\n")
+	writeFile(t, root, "gone.txt", "bye\n")
+	base, _ := s.Head()
+
+	writeFile(t, root, "edit.txt", "one\n2\nthree\n")
+	writeFile(t, root, "new.txt", "hello\n")
+	if err := os.Remove(filepath.Join(root, "gone.txt")); err != nil {
+		t.Fatal(err)
+	}
+	head, _, err := s.Commit("cycle 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := s.Diff(base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "diff --git a/edit.txt b/edit.txt\n--- a/edit.txt\n+++ b/edit.txt\n" +
+		"@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n" +
+		"diff --git a/gone.txt b/gone.txt\ndeleted file mode 100644\n--- a/gone.txt\n+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n-bye\n" +
+		"diff --git a/new.txt b/new.txt\nnew file mode 100644\n--- /dev/null\n+++ b/new.txt\n" +
+		"@@ -0,0 +1 @@\n+hello\n"
+	if diff != want {
+		t.Errorf("Diff:\n%s\nwant:\n%s", diff, want)
+	}
+
+	stat, err := s.DiffStat(base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantStat := " edit.txt | 2 +-\n gone.txt | 1 -\n new.txt  | 1 +\n 3 files changed, 2 insertions(+), 2 deletions(-)\n"
+	if stat != wantStat {
+		t.Errorf("DiffStat:\n%s\nwant:\n%s", stat, wantStat)
+	}
+
+	writeFile(t, root, "new.txt", "hello\nworld\n")
+	work, err := s.DiffWorkTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(work, "+world\n") || strings.Contains(work, "edit.txt") {
+		t.Errorf("DiffWorkTree should show only the uncommitted edit:\n%s", work)
+	}
+}
+
+func TestScanSkipsIgnoredAndExcludedPaths(t *testing.T) {
+	t.Parallel()
+
+	s, root := openStore(t)
+	writeFile(t, root, ".gitignore", "# build output\nnode_modules/\n*.log\n/build\n")
+	writeFile(t, root, ".quasarignore", "secret.txt\n")
+	writeFile(t, root, "main.go", "package main\n")
+	writeFile(t, root, "node_modules/pkg/index.js", "x")
+	writeFile(t, root, "debug.log", "x")
+	writeFile(t, root, "sub/trace.log", "x")
+	writeFile(t, root, "build/out.bin", "x")
+	writeFile(t, root, "sub/build/keep.go", "package build\n")
+	writeFile(t, root, "sub/.gitignore", "gen.go\n")
+	writeFile(t, root, "sub/gen.go", "x")
+	writeFile(t, root, "gen.go", "package main\n")
+	writeFile(t, root, "secret.txt", "x")
+	writeFile(t, root, "neb/nebula.state.toml", "x")
+	s.Exclude("neb")
+
+	base, err := s.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap, err := s.load(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{".gitignore", ".quasarignore", "gen.go", "main.go", "sub/.gitignore", "sub/build/keep.go"}
+	if got := sortedPaths(snap.Files); !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshot files = %v, want %v", got, want)
+	}
+
+	// Restore leaves ignored and excluded files alone.
+	writeFile(t, root, "neb/nebula.state.toml", "updated")
+	writeFile(t, root, "main.go", "package main\n\nfunc main() {}\n")
+	if _, _, err := s.Commit("cycle 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Restore(base); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "neb", "nebula.state.toml")); string(data) != "updated" {
+		t.Errorf("Restore rolled back an excluded file: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(root, "debug.log")); err != nil {
+		t.Errorf("Restore removed an ignored file: %v", err)
+	}
+}
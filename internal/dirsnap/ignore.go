@@ -0,0 +1,108 @@
+package dirsnap
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// quasarIgnoreFile lists extra patterns, in .gitignore syntax, to leave out
+// of snapshots. Only the one at the root is read.
+const quasarIgnoreFile = ".quasarignore"
+
+// ignoreRule is one pattern from a .gitignore or .quasarignore file.
+type ignoreRule struct {
+	base     string // slash-separated directory of the file the rule came from; "" for the root
+	pattern  string
+	anchored bool // the pattern names a path relative to base, not a name at any depth
+	dirOnly  bool // the pattern had a trailing slash
+}
+
+// matches reports whether the slash-separated path rel, relative to the
+// root, is ignored by r.
+func (r ignoreRule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	sub := rel
+	if r.base != "" {
+		if !strings.HasPrefix(rel, r.base+"/") {
+			return false
+		}
+		sub = strings.TrimPrefix(rel, r.base+"/")
+	}
+	if !r.anchored {
+		sub = path.Base(sub)
+	}
+	ok, _ := path.Match(r.pattern, sub)
+	return ok
+}
+
+// readIgnoreFile parses the ignore file at file, whose rules apply beneath
+// base. A missing file has no rules. This is the common subset of the
+// .gitignore syntax: comments, globs, a leading slash to anchor, a trailing
+// slash for directories only, and a leading or trailing "**". Negated
+// patterns ("!") are not supported and are skipped.
+func readIgnoreFile(file, base string) []ignoreRule {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		r := ignoreRule{base: base}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		line = strings.TrimSuffix(line, "/**")
+		if strings.HasPrefix(line, "**/") {
+			line = strings.TrimPrefix(line, "**/")
+		} else if strings.Contains(line, "/") {
+			r.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		r.pattern = line
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// ignorer tracks the ignore rules in effect during a walk of the root.
+type ignorer struct {
+	rules []ignoreRule
+}
+
+// newIgnorer returns an ignorer holding the root's .quasarignore rules.
+// Each directory's .gitignore is added by enter as the walk reaches it.
+func newIgnorer(root string) *ignorer {
+	return &ignorer{rules: readIgnoreFile(filepath.Join(root, quasarIgnoreFile), "")}
+}
+
+// enter adds the rules of the .gitignore in the directory at abs, whose
+// slash-separated path relative to the root is rel ("" for the root).
+func (ig *ignorer) enter(abs, rel string) {
+	ig.rules = append(ig.rules, readIgnoreFile(filepath.Join(abs, ".gitignore"), rel)...)
+}
+
+// ignored reports whether the slash-separated path rel is ignored.
+func (ig *ignorer) ignored(rel string, isDir bool) bool {
+	for _, r := range ig.rules {
+		if r.matches(rel, isDir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package dirsnap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteHunks(t *testing.T) {
+	t.Parallel()
+
+	twelve := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\n"
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "separate hunks and missing final newline",
+			old:  twelve,
+			new:  strings.Replace(twelve, "l2\n", "l2x\n", 1) + "l13",
+			want: "@@ -1,5 +1,5 @@\n l1\n-l2\n+l2x\n l3\n l4\n l5\n" +
+				"@@ -10,3 +10,4 @@\n l10\n l11\n l12\n+l13\n\\ No newline at end of file\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			old:  twelve,
+			new:  strings.NewReplacer("l3\n", "L3\n", "l8\n", "L8\n").Replace(twelve),
+			want: "@@ -1,11 +1,11 @@\n l1\n l2\n-l3\n+L3\n l4\n l5\n l6\n l7\n-l8\n+L8\n l9\n l10\n l11\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "a\nb\n",
+			want: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "deleted single line",
+			old:  "only\n",
+			new:  "",
+			want: "@@ -1 +0,0 @@\n-only\n",
+		},
+		{
+			name: "identical",
+			old:  twelve,
+			new:  twelve,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out strings.Builder
+			writeHunks(&out, lineEdits(splitLines([]byte(tt.old)), splitLines([]byte(tt.new))))
+			if got := out.String(); got != tt.want {
+				t.Errorf("hunks:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditsLargeReplacement(t *testing.T) {
+	t.Parallel()
+
+	// Past maxLCSCells the changed region is replaced wholesale.
+	n := 3000
+	a, b := make([]string, n), make([]string, n)
+	for i := range a {
+		a[i] = "a\n"
+		b[i] = "b\n"
+	}
+	added, removed := countEdits(lineEdits(a, b))
+	if added != n || removed != n {
+		t.Errorf("added, removed = %d, %d; want %d, %d", added, removed, n, n)
+	}
+}
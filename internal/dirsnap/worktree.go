@@ -0,0 +1,224 @@
+package dirsnap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// scan hashes every regular file under the root that is not skipped,
+// excluded, or ignored. When store is set, the content of each file is also
+// added to the object store.
+func (s *Store) scan(store bool) (map[string]File, error) {
+	files := make(map[string]File)
+	ig := newIgnorer(s.root)
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == s.root {
+			ig.enter(path, "")
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if skippedDir(d.Name()) || excluded(rel, s.exclude) || ig.ignored(rel, true) {
+				return filepath.SkipDir
+			}
+			ig.enter(path, rel)
+			return nil
+		}
+		if !d.Type().IsRegular() || excluded(rel, s.exclude) || ig.ignored(rel, false) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hash, err := s.hashFile(path, store)
+		if err != nil {
+			return err
+		}
+		files[rel] = File{Hash: hash, Mode: info.Mode().Perm()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", s.root, err)
+	}
+	return files, nil
+}
+
+// hashFile returns the SHA-256 of the file at path, copying it into the
+// object store when store is set and the object is not already present.
+// The file is streamed, never held in memory, and is read a second time
+// only when its content is new to the store.
+func (s *Store) hashFile(path string, store bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	f.Close()
+	if err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	if store {
+		obj := s.objectPath(hash)
+		if _, err := os.Stat(obj); os.IsNotExist(err) {
+			if err := copyAtomic(path, obj, 0o644); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hash, nil
+}
+
+// readObject returns the stored content for hash.
+func (s *Store) readObject(hash string) ([]byte, error) {
+	data, err := os.ReadFile(s.objectPath(hash))
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot object %s: %w", hash, err)
+	}
+	return data, nil
+}
+
+// checkout writes the stored content of f to path in the working directory.
+func (s *Store) checkout(path string, f File) error {
+	dst := filepath.Join(s.root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := copyAtomic(s.objectPath(f.Hash), dst, f.Mode); err != nil {
+		return fmt.Errorf("restoring %s: %w", path, err)
+	}
+	return nil
+}
+
+// removeFile deletes path from the working directory along with any parent
+// directories the removal leaves empty.
+func (s *Store) removeFile(path string) error {
+	abs := filepath.Join(s.root, filepath.FromSlash(path))
+	if err := os.Remove(abs); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing %s: %w", path, err)
+	}
+	for dir := filepath.Dir(abs); dir != s.root && strings.HasPrefix(dir, s.root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break // not empty
+		}
+	}
+	return nil
+}
+
+func (s *Store) objectsDir() string   { return filepath.Join(s.dir, "objects") }
+func (s *Store) snapshotsDir() string { return filepath.Join(s.dir, "snapshots") }
+
+func (s *Store) objectPath(hash string) string { return filepath.Join(s.objectsDir(), hash) }
+func (s *Store) snapshotPath(id string) string {
+	return filepath.Join(s.snapshotsDir(), id+".json")
+}
+
+// skippedDir reports whether a directory named name is left out of
+// snapshots: VCS metadata and quasar's runtime directory, which holds the
+// store itself.
+func skippedDir(name string) bool {
+	switch name {
+	case ".git", ".quasar":
+		return true
+	}
+	return false
+}
+
+// excluded reports whether path is one of excludes or lies beneath one.
+func excluded(path string, excludes []string) bool {
+	for _, ex := range excludes {
+		ex = strings.TrimSuffix(filepath.ToSlash(ex), "/")
+		if path == ex || strings.HasPrefix(path, ex+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFiles reports whether two manifests are identical.
+func sameFiles(a, b map[string]File) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, f := range a {
+		if b[path] != f {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedPaths returns the keys of files in sorted order.
+func sortedPaths(files map[string]File) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// copyAtomic streams the file at src to dst through a temporary file and a
+// rename, so readers never see a partial file.
+func copyAtomic(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".dirsnap-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// writeAtomic writes data to path through a temporary file and a rename, so
+// readers never see a partial file.
+func writeAtomic(path string, data []byte, mode fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".dirsnap-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
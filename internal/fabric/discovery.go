@@ -12,12 +12,13 @@ var ValidDiscoveryKinds = map[string]bool{
 	DiscoveryFileConflict:          true,
 	DiscoveryRequirementsAmbiguity: true,
 	DiscoveryBudgetAlert:           true,
+	DiscoveryStaleWork:             true,
 }
 
 // ValidateDiscoveryKind returns an error if kind is not a recognized discovery kind.
 func ValidateDiscoveryKind(kind string) error {
 	if !ValidDiscoveryKinds[kind] {
-		return fmt.Errorf("invalid discovery kind %q: must be one of entanglement_dispute, missing_dependency, file_conflict, requirements_ambiguity, budget_alert", kind)
+		return fmt.Errorf("invalid discovery kind %q: must be one of entanglement_dispute, missing_dependency, file_conflict, requirements_ambiguity, budget_alert, stale_work", kind)
 	}
 	return nil
 }
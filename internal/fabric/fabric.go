@@ -48,6 +48,7 @@ const (
 	DiscoveryFileConflict          = "file_conflict"
 	DiscoveryRequirementsAmbiguity = "requirements_ambiguity"
 	DiscoveryBudgetAlert           = "budget_alert"
+	DiscoveryStaleWork             = "stale_work" // a claim or blocked phase tycho keeps finding stale
 )
 
 // Pulse kinds for shared execution context emissions.
@@ -9,6 +9,7 @@ package fabric
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -27,6 +28,17 @@ type Publisher struct {
 	// Logger receives non-fatal warnings (e.g. parse errors). If nil, warnings
 	// are silently discarded.
 	Logger io.Writer
+
+	// OnConflict, if set, is called when a phase touches a file already
+	// claimed by another phase.
+	OnConflict func(FileConflict)
+}
+
+// FileConflict describes a file modified by a phase while claimed by another.
+type FileConflict struct {
+	File  string
+	Phase string // phase whose claim was rejected
+	Owner string // phase that already holds the claim
 }
 
 // PublishPhase extracts entanglements from the git diff of a completed phase
@@ -48,6 +60,9 @@ func (p *Publisher) PublishPhase(ctx context.Context, phaseID, beforeSHA, afterS
 		// Claim the file for this phase.
 		if claimErr := p.Fabric.ClaimFile(ctx, f, phaseID); claimErr != nil {
 			p.logf("publisher: claim %s: %v", f, claimErr)
+			if errors.Is(claimErr, ErrFileAlreadyClaimed) {
+				p.reportConflict(ctx, f, phaseID)
+			}
 		}
 
 		// All files get a file-level entanglement.
@@ -83,6 +98,19 @@ func (p *Publisher) PublishPhase(ctx context.Context, phaseID, beforeSHA, afterS
 	return nil
 }
 
+// reportConflict looks up the current owner of file and forwards the
+// conflict to OnConflict.
+func (p *Publisher) reportConflict(ctx context.Context, file, phaseID string) {
+	if p.OnConflict == nil {
+		return
+	}
+	owner, err := p.Fabric.FileOwner(ctx, file)
+	if err != nil {
+		p.logf("publisher: owner of %s: %v", file, err)
+	}
+	p.OnConflict(FileConflict{File: file, Phase: phaseID, Owner: owner})
+}
+
 // changedFiles runs git diff --name-only between two SHAs and returns the
 // list of relative file paths.
 func (p *Publisher) changedFiles(ctx context.Context, beforeSHA, afterSHA string) ([]string, error) {
@@ -3,6 +3,7 @@ package fabric
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -46,13 +47,18 @@ func (m *mockFabric) ClaimFile(_ context.Context, fp, owner string) error {
 	if m.claimErr != nil {
 		return m.claimErr
 	}
+	if existing, ok := m.claims[fp]; ok && existing != owner {
+		return fmt.Errorf("%w: %q owned by %q", ErrFileAlreadyClaimed, fp, existing)
+	}
 	m.claims[fp] = owner
 	return nil
 }
 
 func (m *mockFabric) ReleaseClaims(_ context.Context, _ string) error       { return nil }
 func (m *mockFabric) ReleaseFileClaim(_ context.Context, _, _ string) error { return nil }
-func (m *mockFabric) FileOwner(_ context.Context, _ string) (string, error) { return "", nil }
+func (m *mockFabric) FileOwner(_ context.Context, fp string) (string, error) {
+	return m.claims[fp], nil
+}
 func (m *mockFabric) AllPhaseStates(_ context.Context) (map[string]string, error) {
 	return nil, nil
 }
@@ -360,6 +366,41 @@ func Broken( {
 		}
 	})
 
+	t.Run("reports conflicts on files claimed by another phase", func(t *testing.T) {
+		t.Parallel()
+
+		dir := initGitRepo(t)
+		beforeSHA := gitSHA(t, dir)
+		for _, name := range []string{"shared.txt", "mine.txt"} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		gitCommit(t, dir, "touch files")
+		afterSHA := gitSHA(t, dir)
+
+		mb := newMockFabric()
+		mb.claims["shared.txt"] = "phase-a"
+		var conflicts []FileConflict
+		pub := &Publisher{
+			Fabric:     mb,
+			WorkDir:    dir,
+			OnConflict: func(c FileConflict) { conflicts = append(conflicts, c) },
+		}
+
+		if err := pub.PublishPhase(context.Background(), "phase-b", beforeSHA, afterSHA); err != nil {
+			t.Fatalf("PublishPhase: %v", err)
+		}
+
+		want := FileConflict{File: "shared.txt", Phase: "phase-b", Owner: "phase-a"}
+		if len(conflicts) != 1 || conflicts[0] != want {
+			t.Errorf("conflicts = %+v, want [%+v]", conflicts, want)
+		}
+		if mb.claims["mine.txt"] != "phase-b" {
+			t.Errorf("unclaimed file owner = %q, want phase-b", mb.claims["mine.txt"])
+		}
+	})
+
 	t.Run("no changes yields no entanglements", func(t *testing.T) {
 		t.Parallel()
 
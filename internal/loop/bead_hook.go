@@ -10,9 +10,16 @@ import (
 
 // BeadHook translates loop lifecycle events into bead operations.
 // It satisfies Hook, TaskCreator, and FindingCreator.
+//
+// When Queue is set, updates, comments, and closes go through its circuit
+// breaker so a beads outage buffers them on disk instead of dropping them.
+// Creates are never buffered because the loop needs the returned IDs.
 type BeadHook struct {
 	Beads beads.Client
 	UI    ui.UI
+	Queue *BeadQueue // nil = log failures and drop the operation
+
+	reported int // last buffered count reported via UI.BeadsDegraded
 }
 
 // Compile-time interface checks.
@@ -43,14 +50,18 @@ func (h *BeadHook) OnEvent(ctx context.Context, event Event) {
 	case EventAgentDone:
 		h.beadComment(ctx, event.BeadID, event.Message)
 
-	case EventRefactored:
+	case EventRefactored, EventFindingRecurred:
 		h.beadComment(ctx, event.BeadID, event.Message)
 
 	case EventReviewComplete:
 		h.beadUpdate(ctx, event.BeadID, beads.UpdateOpts{Assignee: "quasar-coder"})
 
 	case EventTaskSuccess:
-		h.beadClose(ctx, event.BeadID, "Approved by reviewer")
+		reason := "Approved by reviewer"
+		if event.Message != "" {
+			reason = event.Message
+		}
+		h.beadClose(ctx, event.BeadID, reason)
 		if event.Report != nil {
 			h.beadComment(ctx, event.BeadID, FormatReportComment(event.Report))
 		}
@@ -85,21 +96,35 @@ func (h *BeadHook) CreateFindingChildIDs(ctx context.Context, parentBeadID strin
 
 // beadComment logs a comment on the bead, logging any error.
 func (h *BeadHook) beadComment(ctx context.Context, beadID, body string) {
-	if err := h.Beads.AddComment(ctx, beadID, body); err != nil {
-		h.UI.Error(fmt.Sprintf("failed to add bead comment: %v", err))
-	}
+	h.apply(ctx, beadOp{Kind: beadOpComment, BeadID: beadID, Body: body}, "failed to add bead comment")
 }
 
 // beadUpdate updates the bead, logging any error.
 func (h *BeadHook) beadUpdate(ctx context.Context, beadID string, opts beads.UpdateOpts) {
-	if err := h.Beads.Update(ctx, beadID, opts); err != nil {
-		h.UI.Error(fmt.Sprintf("failed to update bead: %v", err))
-	}
+	h.apply(ctx, beadOp{Kind: beadOpUpdate, BeadID: beadID, Status: opts.Status, Assignee: opts.Assignee}, "failed to update bead")
 }
 
 // beadClose closes the bead with a reason, logging any error.
 func (h *BeadHook) beadClose(ctx context.Context, beadID, reason string) {
-	if err := h.Beads.Close(ctx, beadID, reason); err != nil {
-		h.UI.Error(fmt.Sprintf("failed to close bead: %v", err))
+	h.apply(ctx, beadOp{Kind: beadOpClose, BeadID: beadID, Body: reason}, "failed to close bead")
+}
+
+// apply runs op directly or through the Queue, logging unbuffered failures
+// with the given prefix and reporting changes in the buffered count.
+func (h *BeadHook) apply(ctx context.Context, op beadOp, failMsg string) {
+	if h.Queue == nil {
+		if err := op.apply(ctx, h.Beads); err != nil {
+			h.UI.Error(fmt.Sprintf("%s: %v", failMsg, err))
+		}
+		return
+	}
+
+	buffered, err := h.Queue.do(ctx, h.Beads, op)
+	if err != nil {
+		h.UI.Error(fmt.Sprintf("%s: %v", failMsg, err))
+	}
+	if buffered != h.reported {
+		h.reported = buffered
+		h.UI.BeadsDegraded(buffered)
 	}
 }
@@ -0,0 +1,183 @@
+package loop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/papapumpkin/quasar/internal/beads"
+)
+
+// DefaultBeadFailureThreshold is the number of consecutive bead operation
+// failures that trips a BeadQueue into buffering mode.
+const DefaultBeadFailureThreshold = 3
+
+// Bead operation kinds recorded in the queue file.
+const (
+	beadOpUpdate  = "update"
+	beadOpComment = "comment"
+	beadOpClose   = "close"
+)
+
+// beadOp is a single buffered bead mutation, serialized as one JSON line.
+type beadOp struct {
+	Kind     string `json:"kind"`
+	BeadID   string `json:"bead_id"`
+	Body     string `json:"body,omitempty"` // comment body or close reason
+	Status   string `json:"status,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// apply executes the operation against the beads backend.
+func (op beadOp) apply(ctx context.Context, client beads.Client) error {
+	switch op.Kind {
+	case beadOpUpdate:
+		return client.Update(ctx, op.BeadID, beads.UpdateOpts{Status: op.Status, Assignee: op.Assignee})
+	case beadOpComment:
+		return client.AddComment(ctx, op.BeadID, op.Body)
+	case beadOpClose:
+		return client.Close(ctx, op.BeadID, op.Body)
+	default:
+		return fmt.Errorf("unknown bead operation %q", op.Kind)
+	}
+}
+
+// BeadQueue is a circuit breaker with an on-disk buffer for bead mutations.
+// After threshold consecutive failures it stops surfacing errors and instead
+// appends operations to the queue file; every later operation first tries to
+// replay the buffer, so tracking data lands in order once the backend
+// recovers. A single BeadQueue may be shared by several BeadHooks.
+type BeadQueue struct {
+	path      string // "" = buffer in memory only
+	threshold int
+
+	mu       sync.Mutex
+	failures int // consecutive failures while not buffering
+	pending  []beadOp
+}
+
+// NewBeadQueue creates a BeadQueue persisted at path, loading any operations
+// left over from a previous run. A threshold <= 0 uses
+// DefaultBeadFailureThreshold. An empty path buffers in memory only.
+func NewBeadQueue(path string, threshold int) (*BeadQueue, error) {
+	if threshold <= 0 {
+		threshold = DefaultBeadFailureThreshold
+	}
+	q := &BeadQueue{path: path, threshold: threshold}
+	if path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading bead queue: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var op beadOp
+		if err := dec.Decode(&op); err != nil {
+			return nil, fmt.Errorf("parsing bead queue: %w", err)
+		}
+		q.pending = append(q.pending, op)
+	}
+	return q, nil
+}
+
+// Len returns the number of buffered operations.
+func (q *BeadQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// do runs op against client, buffering it when the breaker is open. It
+// returns the number of operations still buffered afterwards, and an error
+// when op failed without being buffered (breaker still closed) or the queue
+// file could not be rewritten.
+func (q *BeadQueue) do(ctx context.Context, client beads.Client, op beadOp) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var replayErr error
+	if len(q.pending) > 0 {
+		replayErr = q.replay(ctx, client)
+		if len(q.pending) > 0 {
+			q.pending = append(q.pending, op)
+			return len(q.pending), q.save()
+		}
+	}
+
+	if err := op.apply(ctx, client); err != nil {
+		q.failures++
+		if q.failures < q.threshold {
+			return 0, errors.Join(err, replayErr)
+		}
+		q.pending = append(q.pending, op)
+		return len(q.pending), q.save()
+	}
+	q.failures = 0
+	return 0, replayErr
+}
+
+// replay applies buffered operations in order, stopping at the first
+// failure, and rewrites the queue file without the applied ones. A failed
+// rewrite leaves them in the file, so a later run would apply them twice.
+// Must be called with mu held.
+func (q *BeadQueue) replay(ctx context.Context, client beads.Client) error {
+	n := 0
+	for _, op := range q.pending {
+		if op.apply(ctx, client) != nil {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	q.pending = q.pending[n:]
+	if len(q.pending) == 0 {
+		q.failures = 0
+	}
+	return q.save()
+}
+
+// save rewrites the queue file atomically. Must be called with mu held.
+func (q *BeadQueue) save() error {
+	if q.path == "" {
+		return nil
+	}
+	if len(q.pending) == 0 {
+		if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing bead queue: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, op := range q.pending {
+		if err := enc.Encode(op); err != nil {
+			return fmt.Errorf("encoding bead queue: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o755); err != nil {
+		return fmt.Errorf("creating bead queue directory: %w", err)
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing bead queue: %w", err)
+	}
+	if err := os.Rename(tmp, q.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("renaming bead queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// flakyBeads fails every mutation while down and records the comments it accepts.
+type flakyBeads struct {
+	noopBeads
+	mu       sync.Mutex
+	down     bool
+	comments []string
+}
+
+func (f *flakyBeads) AddComment(_ context.Context, _ string, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return errors.New("backend unavailable")
+	}
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func (f *flakyBeads) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func TestBeadHookCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q, err := NewBeadQueue(path, 2)
+	if err != nil {
+		t.Fatalf("NewBeadQueue: %v", err)
+	}
+	fb := &flakyBeads{down: true}
+	rUI := &recordingUI{}
+	h := &BeadHook{Beads: fb, UI: rUI, Queue: q}
+	ctx := context.Background()
+
+	// First failure is below the threshold: logged and dropped.
+	h.beadComment(ctx, "b1", "c1")
+	if len(rUI.errors) != 1 || q.Len() != 0 {
+		t.Fatalf("after 1 failure: errors=%d buffered=%d, want 1 and 0", len(rUI.errors), q.Len())
+	}
+
+	// Second failure trips the breaker: buffered, not logged.
+	h.beadComment(ctx, "b1", "c2")
+	h.beadComment(ctx, "b1", "c3")
+	if len(rUI.errors) != 1 {
+		t.Errorf("errors = %d, want 1 (buffered ops should not log)", len(rUI.errors))
+	}
+	if q.Len() != 2 {
+		t.Fatalf("buffered = %d, want 2", q.Len())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("queue file should exist while degraded: %v", err)
+	}
+
+	// A fresh queue loaded from disk sees the same pending operations.
+	reloaded, err := NewBeadQueue(path, 2)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.Len() != 2 {
+		t.Errorf("reloaded buffered = %d, want 2", reloaded.Len())
+	}
+
+	// Recovery: the next operation replays the buffer first, in order.
+	fb.setDown(false)
+	h.beadComment(ctx, "b1", "c4")
+	if want := []string{"c2", "c3", "c4"}; !reflect.DeepEqual(fb.comments, want) {
+		t.Errorf("comments = %v, want %v", fb.comments, want)
+	}
+	if q.Len() != 0 {
+		t.Errorf("buffered = %d, want 0 after recovery", q.Len())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("queue file should be removed after replay, stat err = %v", err)
+	}
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(rUI.beadsDegraded, want) {
+		t.Errorf("BeadsDegraded calls = %v, want %v", rUI.beadsDegraded, want)
+	}
+}
+
+func TestBeadQueueReportsFailedRewrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q, err := NewBeadQueue(path, 1)
+	if err != nil {
+		t.Fatalf("NewBeadQueue: %v", err)
+	}
+	fb := &flakyBeads{down: true}
+	ctx := context.Background()
+	if n, err := q.do(ctx, fb, beadOp{Kind: beadOpComment, BeadID: "b1", Body: "c1"}); n != 1 || err != nil {
+		t.Fatalf("do while down = %d, %v; want 1 buffered", n, err)
+	}
+
+	// Swap the queue file for a non-empty directory so removing it after
+	// the replay fails.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fb.setDown(false)
+	n, err := q.do(ctx, fb, beadOp{Kind: beadOpComment, BeadID: "b1", Body: "c2"})
+	if n != 0 || err == nil {
+		t.Errorf("do after recovery = %d, %v; want 0 buffered and the rewrite error", n, err)
+	}
+	if want := []string{"c1", "c2"}; !reflect.DeepEqual(fb.comments, want) {
+		t.Errorf("comments = %v, want %v", fb.comments, want)
+	}
+}
+
+func TestBeadHookWithoutQueue(t *testing.T) {
+	t.Parallel()
+
+	fb := &flakyBeads{down: true}
+	rUI := &recordingUI{}
+	h := &BeadHook{Beads: fb, UI: rUI}
+	for i := 0; i < DefaultBeadFailureThreshold+1; i++ {
+		h.beadComment(context.Background(), "b1", "c")
+	}
+	if len(rUI.errors) != DefaultBeadFailureThreshold+1 {
+		t.Errorf("errors = %d, want every failure logged", len(rUI.errors))
+	}
+	if len(rUI.beadsDegraded) != 0 {
+		t.Errorf("BeadsDegraded called %d times, want 0", len(rUI.beadsDegraded))
+	}
+}
+
+func TestNewBeadQueue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantLen int
+		wantErr bool
+	}{
+		{"missing file", "", 0, false},
+		{"pending ops", `{"kind":"comment","bead_id":"b1","body":"x"}` + "\n" + `{"kind":"close","bead_id":"b1","body":"done"}` + "\n", 2, false},
+		{"corrupt", "{not json", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "queue.jsonl")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			q, err := NewBeadQueue(path, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBeadQueue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && q.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", q.Len(), tt.wantLen)
+			}
+		})
+	}
+}
@@ -8,4 +8,14 @@ var (
 	ErrMaxCycles = errors.New("maximum review cycles reached")
 	// ErrBudgetExceeded is returned when cumulative cost reaches the budget limit.
 	ErrBudgetExceeded = errors.New("budget exceeded")
+	// ErrReviewDisagreement is returned when an escalation reviewer approves
+	// a cycle the primary reviewer rejected, leaving the decision to a human.
+	ErrReviewDisagreement = errors.New("reviewers disagree on blocking issues")
+	// ErrDegradingReview is returned when Loop.StopOnDegradingReview stops
+	// the loop because reviewer satisfaction dropped two cycles in a row.
+	ErrDegradingReview = errors.New("reviewer satisfaction degrading")
+	// ErrNoProgress is returned when Loop.StopOnNoProgress stops the loop
+	// because two cycles in a row changed nothing, or repeated the previous
+	// cycle's change. It is wrapped with the reason.
+	ErrNoProgress = errors.New("no progress across cycles")
 )
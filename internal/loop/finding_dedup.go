@@ -0,0 +1,45 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+)
+
+// recordFindings accumulates the current cycle's findings into AllFindings
+// and creates a child bead for each new one. Findings are matched by
+// FindingID, so an issue the reviewer reports again in a later cycle (or
+// twice in one review) is not re-beaded: its existing child bead gets a
+// comment noting the recurrence instead, keeping ChildBeadIDs aligned with
+// AllFindings.
+func (l *Loop) recordFindings(ctx context.Context, state *CycleState) {
+	if state.findingBeads == nil {
+		state.findingBeads = make(map[string][]string)
+	}
+	for _, f := range state.Findings {
+		key := FindingID(f.Severity, f.Description)
+		if first, ok := findAccumulated(state.AllFindings, key); ok {
+			if first.Cycle == state.Cycle {
+				continue // repeated within this review
+			}
+			msg := fmt.Sprintf("Finding recurred in cycle %d (first reported in cycle %d).", state.Cycle, first.Cycle)
+			for _, id := range state.findingBeads[key] {
+				l.emit(ctx, Event{Kind: EventFindingRecurred, BeadID: id, Cycle: state.Cycle, Findings: []ReviewFinding{f}, Message: msg})
+			}
+			continue
+		}
+		state.AllFindings = append(state.AllFindings, f)
+		ids := l.createFindingBeads(ctx, state.TaskBeadID, []ReviewFinding{f})
+		state.findingBeads[key] = ids
+		state.ChildBeadIDs = append(state.ChildBeadIDs, ids...)
+	}
+}
+
+// findAccumulated returns the accumulated finding whose FindingID is key.
+func findAccumulated(all []ReviewFinding, key string) (ReviewFinding, bool) {
+	for _, f := range all {
+		if FindingID(f.Severity, f.Description) == key {
+			return f, true
+		}
+	}
+	return ReviewFinding{}, false
+}
@@ -0,0 +1,60 @@
+package loop
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRecordFindingsDeduplicatesAcrossCycles(t *testing.T) {
+	t.Parallel()
+
+	rb := newRecordingBeads()
+	rUI := &recordingUI{}
+	l := &Loop{UI: rUI, Hooks: []Hook{newBeadHook(rb, rUI)}}
+	state := &CycleState{TaskBeadID: "bead-1", TaskTitle: "task"}
+
+	review := func(cycle int, findings ...ReviewFinding) {
+		state.Cycle = cycle
+		for i := range findings {
+			findings[i].Cycle = cycle
+		}
+		state.Findings = findings
+		l.recordFindings(context.Background(), state)
+	}
+
+	review(1,
+		ReviewFinding{Severity: "major", Description: "Missing error check in Load"},
+		ReviewFinding{Severity: "minor", Description: "unused variable"},
+		ReviewFinding{Severity: "major", Description: "Missing error check in Load"},
+	)
+	review(2,
+		ReviewFinding{Severity: "major", Description: "missing error check in  load"},
+		ReviewFinding{Severity: "critical", Description: "nil dereference"},
+	)
+
+	if len(rb.creates) != 3 {
+		t.Errorf("created %d beads, want 3: %v", len(rb.creates), rb.creates)
+	}
+	if len(state.AllFindings) != 3 || len(state.ChildBeadIDs) != 3 {
+		t.Fatalf("AllFindings = %d, ChildBeadIDs = %d; want 3 each", len(state.AllFindings), len(state.ChildBeadIDs))
+	}
+	if got := state.AllFindings[0].Cycle; got != 1 {
+		t.Errorf("recurring finding cycle = %d, want the original cycle 1", got)
+	}
+	if len(rb.comments) != 1 {
+		t.Fatalf("comments = %v, want one recurrence comment", rb.comments)
+	}
+	if c := rb.comments[0]; !strings.Contains(c, "cycle 2") || !strings.Contains(c, "cycle 1") {
+		t.Errorf("recurrence comment = %q, want it to name cycles 2 and 1", c)
+	}
+
+	l.emitBeadUpdate(state, "in_progress")
+	children := rUI.beadUpdates[len(rUI.beadUpdates)-1].children
+	if len(children) != 3 {
+		t.Fatalf("bead update has %d children, want 3", len(children))
+	}
+	if children[2].Severity != "critical" || children[2].Cycle != 2 {
+		t.Errorf("child[2] = %+v, want the cycle 2 critical finding", children[2])
+	}
+}
@@ -8,11 +8,19 @@ import (
 
 // FindingID computes a deterministic identifier for a finding based on its
 // severity and description. The ID is a short hex prefix of a SHA-256 hash,
-// stable across cycles so the same logical finding can be tracked.
+// stable across cycles so the same logical finding can be tracked. Case and
+// whitespace are normalized so a reviewer re-wording only the spacing or
+// capitalization of an issue still produces the same ID.
 func FindingID(severity, description string) string {
 	h := sha256.New()
-	h.Write([]byte(severity))
+	h.Write([]byte(normalizeFindingText(severity)))
 	h.Write([]byte(":"))
-	h.Write([]byte(strings.TrimSpace(description)))
+	h.Write([]byte(normalizeFindingText(description)))
 	return fmt.Sprintf("f-%x", h.Sum(nil)[:6])
 }
+
+// normalizeFindingText lowercases s and collapses every run of whitespace
+// to a single space.
+func normalizeFindingText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
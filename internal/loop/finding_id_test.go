@@ -25,6 +25,15 @@ func TestFindingID(t *testing.T) {
 		}
 	})
 
+	t.Run("StableWithCaseAndSpacing", func(t *testing.T) {
+		t.Parallel()
+		id1 := FindingID("major", "Missing error check in\n  Load")
+		id2 := FindingID("Major", "missing error check in load")
+		if id1 != id2 {
+			t.Errorf("normalized inputs should produce same ID: %q vs %q", id1, id2)
+		}
+	})
+
 	t.Run("DifferentSeverity", func(t *testing.T) {
 		t.Parallel()
 		id1 := FindingID("critical", "missing error check")
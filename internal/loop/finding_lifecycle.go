@@ -1,6 +1,11 @@
 package loop
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
 
 // LifecycleSummary holds counts of finding status transitions for a single cycle.
 type LifecycleSummary struct {
@@ -47,3 +52,53 @@ func ApplyVerifications(allFindings []ReviewFinding, verifications []FindingVeri
 	}
 	return summary
 }
+
+// findingPathRe matches candidate file paths such as
+// "internal/loop/prompts.go" or "main.go" in a finding's description. It
+// also matches selectors like "ctx.Err", so findingPaths filters them.
+var findingPathRe = regexp.MustCompile(`[\w./-]+\.[A-Za-z]{1,5}\b`)
+
+// sourceExts are the extensions that make a bare name such as "main.go" a
+// file rather than a selector.
+var sourceExts = map[string]bool{
+	"go": true, "mod": true, "sum": true, "py": true, "js": true, "jsx": true,
+	"ts": true, "tsx": true, "rs": true, "java": true, "kt": true, "rb": true,
+	"c": true, "h": true, "cc": true, "cpp": true, "hpp": true, "cs": true,
+	"swift": true, "sh": true, "sql": true, "proto": true, "json": true,
+	"yaml": true, "yml": true, "toml": true, "md": true, "html": true, "css": true,
+}
+
+// findingPaths returns the file paths named in desc: candidates with a
+// path separator, or bare names with a known source extension.
+func findingPaths(desc string) []string {
+	var paths []string
+	for _, m := range findingPathRe.FindAllString(desc, -1) {
+		ext := m[strings.LastIndex(m, ".")+1:]
+		if strings.Contains(m, "/") || sourceExts[ext] {
+			paths = append(paths, m)
+		}
+	}
+	return paths
+}
+
+// openFindingsOutside reports whether any finding not yet fixed names a
+// file outside changed, matching a bare file name against any changed
+// path that ends in it.
+func openFindingsOutside(findings []ReviewFinding, changed []diffFileStat) bool {
+	inDiff := func(path string) bool {
+		return slices.ContainsFunc(changed, func(c diffFileStat) bool {
+			return c.path == path || strings.HasSuffix(c.path, "/"+path)
+		})
+	}
+	for _, f := range findings {
+		if f.Status == FindingStatusFixed {
+			continue
+		}
+		for _, path := range findingPaths(f.Description) {
+			if !inDiff(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
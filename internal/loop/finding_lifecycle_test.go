@@ -1,6 +1,9 @@
 package loop
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestApplyVerifications(t *testing.T) {
 	t.Parallel()
@@ -202,3 +205,28 @@ func TestLifecycleSummaryString(t *testing.T) {
 		})
 	}
 }
+
+func TestFindingPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc string
+		want []string
+	}{
+		{"main.go ignores the error", []string{"main.go"}},
+		{"internal/loop/prompts.go: taskDiff leaks", []string{"internal/loop/prompts.go"}},
+		{"see docs/guide.txt and config.yaml", []string{"docs/guide.txt", "config.yaml"}},
+		{"ctx.Err is never checked after the loop", nil},
+		{"wg.mu is held across os.Open, e.g. on retry", nil},
+		{"return fmt.Errorf with %w instead of err.Error", nil},
+		{"bump to v1.2 in go.mod", []string{"go.mod"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			if got := findingPaths(tt.desc); !slices.Equal(got, tt.want) {
+				t.Errorf("findingPaths() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package loop
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/papapumpkin/quasar/internal/dirsnap"
 )
 
-// CycleCommitter creates git commits at coder-cycle boundaries.
+// CycleCommitter creates git commits (or, outside git, working-directory
+// snapshots) at coder-cycle boundaries.
 type CycleCommitter interface {
 	// CommitCycle stages all changes and creates a commit for the given cycle.
 	// The summary is a short human-readable description included in the commit message.
@@ -23,6 +27,9 @@ type CycleCommitter interface {
 	// tree to that commit's state. The SHA must be a valid, reachable commit.
 	// If branch enforcement is active, the current branch is verified first.
 	ResetTo(ctx context.Context, sha string) error
+	// ApplyPatch applies a unified diff to the working tree. The patch is
+	// applied atomically: on error the working tree is left unchanged.
+	ApplyPatch(ctx context.Context, patch string) error
 }
 
 // gitCycleCommitter implements CycleCommitter using the git CLI.
@@ -158,6 +165,27 @@ func (g *gitCycleCommitter) ResetTo(ctx context.Context, sha string) error {
 	return nil
 }
 
+// ApplyPatch applies a unified diff to the working tree with git apply,
+// which rejects the whole patch if any hunk fails. If g is nil, this is a no-op.
+func (g *gitCycleCommitter) ApplyPatch(ctx context.Context, patch string) error {
+	if g == nil {
+		return nil
+	}
+
+	if err := g.ensureBranch(ctx); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", g.dir, "apply", "-")
+	cmd.Stdin = strings.NewReader(patch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git apply: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // ensureBranch verifies the working directory is on the expected branch.
 // If branch is empty, this is a no-op.
 func (g *gitCycleCommitter) ensureBranch(ctx context.Context) error {
@@ -177,3 +205,72 @@ func (g *gitCycleCommitter) ensureBranch(ctx context.Context) error {
 	}
 	return nil
 }
+
+// snapshotCycleCommitter implements CycleCommitter for working directories
+// that are not git repositories, recording each cycle as a dirsnap
+// snapshot. Snapshot IDs stand in for commit SHAs.
+type snapshotCycleCommitter struct {
+	store *dirsnap.Store
+}
+
+// NewSnapshotCycleCommitter returns a CycleCommitter backed by store.
+func NewSnapshotCycleCommitter(store *dirsnap.Store) CycleCommitter {
+	return &snapshotCycleCommitter{store: store}
+}
+
+// CommitCycle records the working directory as a snapshot for the cycle.
+// If nothing changed, the current snapshot ID is returned.
+func (s *snapshotCycleCommitter) CommitCycle(_ context.Context, label string, cycle int, summary string) (string, error) {
+	id, _, err := s.store.Commit(fmt.Sprintf("%s/cycle-%d: %s", label, cycle, summary))
+	return id, err
+}
+
+// HeadSHA returns the current snapshot ID.
+func (s *snapshotCycleCommitter) HeadSHA(context.Context) (string, error) {
+	return s.store.Head()
+}
+
+// DiffRange returns the unified diff between two snapshots.
+func (s *snapshotCycleCommitter) DiffRange(_ context.Context, base, head string) (string, error) {
+	return s.store.Diff(base, head)
+}
+
+// ResetTo restores the working directory to an earlier snapshot.
+func (s *snapshotCycleCommitter) ResetTo(_ context.Context, sha string) error {
+	return s.store.Restore(sha)
+}
+
+// ApplyPatch is not supported without git; callers fall back to leaving
+// the change to the coder.
+func (s *snapshotCycleCommitter) ApplyPatch(context.Context, string) error {
+	return errors.New("applying patches requires a git working directory")
+}
+
+// diffFileStat counts the lines a diff adds to and removes from one file.
+type diffFileStat struct {
+	path           string
+	added, removed int
+}
+
+// summarizeDiff returns the per-file line counts of a unified git diff, in
+// the order the files appear.
+func summarizeDiff(diff string) []diffFileStat {
+	var stats []diffFileStat
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			path := strings.TrimPrefix(line, "diff --git ")
+			if i := strings.LastIndex(path, " b/"); i >= 0 {
+				path = path[i+len(" b/"):]
+			}
+			stats = append(stats, diffFileStat{path: path})
+		case len(stats) == 0, strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			// File headers are not changed lines.
+		case strings.HasPrefix(line, "+"):
+			stats[len(stats)-1].added++
+		case strings.HasPrefix(line, "-"):
+			stats[len(stats)-1].removed++
+		}
+	}
+	return stats
+}
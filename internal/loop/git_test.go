@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/papapumpkin/quasar/internal/dirsnap"
 )
 
 // initGitRepo creates a temporary git repo with an initial commit.
@@ -321,6 +323,51 @@ func TestResetTo(t *testing.T) {
 	})
 }
 
+func TestApplyPatch(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, CycleCommitter) {
+		t.Helper()
+		dir := initGitRepo(t)
+		if err := os.WriteFile(filepath.Join(dir, "f.txt"), []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir, NewCycleCommitter(context.Background(), dir)
+	}
+
+	t.Run("applies clean patch", func(t *testing.T) {
+		t.Parallel()
+		dir, c := setup(t)
+		patch := "--- a/f.txt\n+++ b/f.txt\n@@ -1,3 +1,3 @@\n one\n-two\n+TWO\n three\n"
+		if err := c.ApplyPatch(context.Background(), patch); err != nil {
+			t.Fatalf("ApplyPatch: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "f.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); got != "one\nTWO\nthree\n" {
+			t.Errorf("file = %q, want TWO replaced", got)
+		}
+	})
+
+	t.Run("rejects stale patch without changes", func(t *testing.T) {
+		t.Parallel()
+		dir, c := setup(t)
+		patch := "--- a/f.txt\n+++ b/f.txt\n@@ -1,3 +1,3 @@\n one\n-missing\n+TWO\n three\n"
+		if err := c.ApplyPatch(context.Background(), patch); err == nil {
+			t.Fatal("expected error for patch that does not match")
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "f.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); got != "one\ntwo\nthree\n" {
+			t.Errorf("file changed by failed apply: %q", got)
+		}
+	})
+}
+
 func TestNilCycleCommitter(t *testing.T) {
 	t.Parallel()
 
@@ -369,3 +416,48 @@ func TestNilCycleCommitter(t *testing.T) {
 		}
 	})
 }
+
+func TestSnapshotCycleCommitter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := dirsnap.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewSnapshotCycleCommitter(store)
+
+	base, err := c.HeadSHA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	head, err := c.CommitCycle(ctx, "bead-1", 1, "add main")
+	if err != nil || head == base {
+		t.Fatalf("CommitCycle = %q, %v; want a new snapshot ID", head, err)
+	}
+	if again, _ := c.CommitCycle(ctx, "bead-1", 2, "no changes"); again != head {
+		t.Errorf("clean CommitCycle = %q, want HEAD %q", again, head)
+	}
+
+	diff, err := c.DiffRange(ctx, base, head)
+	if err != nil || !strings.Contains(diff, "+func main() {}") {
+		t.Errorf("DiffRange = %q, %v", diff, err)
+	}
+	if err := c.ResetTo(ctx, base); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != "package main\n" {
+		t.Errorf("after ResetTo, main.go = %q", data)
+	}
+	if err := c.ApplyPatch(ctx, diff); err == nil {
+		t.Error("ApplyPatch succeeded without git")
+	}
+}
@@ -158,6 +158,87 @@ func buildMaxCyclesHail(state *CycleState, phaseID string) Hail {
 	}
 }
 
+// buildReviewDisagreementHail creates a decision-needed hail when the
+// escalation reviewer approves a cycle the primary reviewer rejected. The
+// detail carries both reviewers' summaries and the blocking findings.
+func buildReviewDisagreementHail(state *CycleState, phaseID string) Hail {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "After %d rejected cycles a second reviewer was consulted and approved the work.\n", state.rejectedCycles)
+	if report := ParseReviewReport(state.ReviewOutput); report != nil && report.Summary != "" {
+		fmt.Fprintf(&detail, "Primary reviewer: %s\n", report.Summary)
+	}
+	if report := ParseReviewReport(state.SecondReviewOutput); report != nil && report.Summary != "" {
+		fmt.Fprintf(&detail, "Second reviewer: %s\n", report.Summary)
+	}
+	if len(state.Findings) > 0 {
+		detail.WriteString("\nPrimary reviewer's blocking findings:\n")
+		for _, f := range state.Findings {
+			fmt.Fprintf(&detail, "- [%s] %s\n", f.Severity, firstLine(f.Description, 100))
+		}
+	}
+
+	return Hail{
+		PhaseID:    phaseID,
+		Cycle:      state.Cycle,
+		SourceRole: "reviewer",
+		Kind:       HailDecisionNeeded,
+		Summary:    "Reviewers disagree on blocking issues — human decision needed",
+		Detail:     detail.String(),
+		Options:    []string{"accept as-is", "retry", "abort"},
+	}
+}
+
+// buildDegradingReviewHail creates a HailDecisionNeeded when the reviewer's
+// satisfaction has dropped for consecutive cycles, so a human can decide
+// whether the task is worth more cycles.
+func buildDegradingReviewHail(state *CycleState, phaseID string) Hail {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Reviewer satisfaction by cycle: %s\n", strings.Join(state.SatisfactionTrend, " → "))
+	if report := ParseReviewReport(state.ReviewOutput); report != nil && report.Summary != "" {
+		fmt.Fprintf(&detail, "Latest review: %s\n", report.Summary)
+	}
+	if len(state.Findings) > 0 {
+		detail.WriteString("\nOpen findings:\n")
+		for _, f := range state.Findings {
+			fmt.Fprintf(&detail, "- [%s] %s\n", f.Severity, firstLine(f.Description, 100))
+		}
+	}
+
+	return Hail{
+		PhaseID:    phaseID,
+		Cycle:      state.Cycle,
+		SourceRole: "reviewer",
+		Kind:       HailDecisionNeeded,
+		Summary:    "Reviewer satisfaction is degrading — human decision needed",
+		Detail:     detail.String(),
+		Options:    []string{"accept as-is", "retry", "abort"},
+	}
+}
+
+// buildNoProgressHail creates a HailDecisionNeeded when the loop's cycles
+// have stopped changing the code, so a human can decide whether the task
+// needs a different approach.
+func buildNoProgressHail(state *CycleState, phaseID, reason string) Hail {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Stopped early: %s.\n", reason)
+	if len(state.Findings) > 0 {
+		detail.WriteString("\nOpen findings:\n")
+		for _, f := range state.Findings {
+			fmt.Fprintf(&detail, "- [%s] %s\n", f.Severity, firstLine(f.Description, 100))
+		}
+	}
+
+	return Hail{
+		PhaseID:    phaseID,
+		Cycle:      state.Cycle,
+		SourceRole: "coder",
+		Kind:       HailDecisionNeeded,
+		Summary:    "Cycles are making no progress — human decision needed",
+		Detail:     detail.String(),
+		Options:    []string{"accept as-is", "retry", "abort"},
+	}
+}
+
 // bridgeDiscoveryHails converts Fabric discoveries of kind requirements_ambiguity
 // and missing_dependency into Hail objects so they surface in the UI. Discoveries
 // that are already resolved are skipped.
@@ -2,6 +2,7 @@ package loop
 
 import (
 	"context"
+	"sync"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 )
@@ -16,7 +17,8 @@ const (
 	EventAgentDone
 	// EventReviewComplete is emitted after findings are parsed and child beads created.
 	EventReviewComplete
-	// EventTaskSuccess is emitted when the reviewer approves the changes.
+	// EventTaskSuccess is emitted when the reviewer approves the changes, or
+	// after the coder's pass when Loop.SkipReview is set; Message then says so.
 	EventTaskSuccess
 	// EventTaskFailed is emitted when the loop terminates without approval.
 	EventTaskFailed
@@ -25,6 +27,9 @@ const (
 	// EventStruggleDetected is emitted when the struggle detector triggers,
 	// signaling that the phase should be decomposed.
 	EventStruggleDetected
+	// EventFindingRecurred is emitted for the child bead of a finding that the
+	// reviewer reports again in a later cycle.
+	EventFindingRecurred
 )
 
 // Event represents a lifecycle event in the coder-reviewer loop.
@@ -40,6 +45,8 @@ type Event struct {
 }
 
 // Hook receives lifecycle events from the loop. Implementations must not block.
+// When Loop.HookQueueSize is set, OnEvent runs on a background goroutine and
+// may overlap the loop's TaskCreator and FindingCreator calls.
 type Hook interface {
 	OnEvent(ctx context.Context, event Event)
 }
@@ -61,3 +68,58 @@ type TaskCreator interface {
 type FindingCreator interface {
 	CreateFindingChildIDs(ctx context.Context, parentBeadID string, findings []ReviewFinding) []string
 }
+
+// hookQueue delivers events to hooks on a single background goroutine so
+// slow hooks (a remote beads backend) do not hold up the loop. Events are
+// delivered in the order they were sent, so each bead sees its updates in
+// order. send blocks once the buffer is full, until the run is cancelled.
+type hookQueue struct {
+	hooks  []Hook
+	events chan queuedEvent
+	wg     sync.WaitGroup
+}
+
+// queuedEvent is an event waiting for delivery with the context it was
+// emitted under.
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// startHookQueue starts delivering events to hooks with room for size
+// pending events.
+func startHookQueue(hooks []Hook, size int) *hookQueue {
+	q := &hookQueue{hooks: hooks, events: make(chan queuedEvent, size)}
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for qe := range q.events {
+			for _, h := range q.hooks {
+				h.OnEvent(qe.ctx, qe.event)
+			}
+		}
+	}()
+	return q
+}
+
+// send queues event for delivery, waiting for room when the queue is full.
+// It drops the event instead of waiting once ctx is done, so a cancelled
+// run cannot hang behind a stuck hook.
+func (q *hookQueue) send(ctx context.Context, event Event) {
+	qe := queuedEvent{ctx: ctx, event: event}
+	select {
+	case q.events <- qe:
+		return
+	default:
+	}
+	select {
+	case q.events <- qe:
+	case <-ctx.Done():
+	}
+}
+
+// close waits for every queued event to be delivered.
+func (q *hookQueue) close() {
+	close(q.events)
+	q.wg.Wait()
+}
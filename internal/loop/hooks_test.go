@@ -0,0 +1,121 @@
+package loop
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// kindRecorder records the kinds of the events it receives, waiting on gate
+// (when set) before each one.
+type kindRecorder struct {
+	gate  chan struct{}
+	mu    sync.Mutex
+	kinds []EventKind
+}
+
+func (r *kindRecorder) OnEvent(_ context.Context, event Event) {
+	if r.gate != nil {
+		<-r.gate
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.kinds = append(r.kinds, event.Kind)
+}
+
+func TestHookQueueKeepsOrderAndBlocksWhenFull(t *testing.T) {
+	t.Parallel()
+
+	rec := &kindRecorder{gate: make(chan struct{})}
+	q := startHookQueue([]Hook{rec}, 1)
+	ctx := context.Background()
+
+	// The worker takes the first event and waits on the gate; the second
+	// fills the buffer, so the third must wait.
+	q.send(ctx, Event{Kind: EventCycleStart})
+	q.send(ctx, Event{Kind: EventAgentDone})
+	sent := make(chan struct{})
+	go func() {
+		q.send(ctx, Event{Kind: EventTaskSuccess})
+		close(sent)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("send returned while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(rec.gate)
+	<-sent
+	q.close()
+	want := []EventKind{EventCycleStart, EventAgentDone, EventTaskSuccess}
+	if !slices.Equal(rec.kinds, want) {
+		t.Errorf("delivered %v, want %v", rec.kinds, want)
+	}
+}
+
+func TestRunLoopWithHookQueue(t *testing.T) {
+	t.Parallel()
+
+	rUI := &recordingUI{}
+	rb := newRecordingBeads()
+	inv := &fakeInvoker{
+		responses: []agent.InvocationResult{
+			{ResultText: "implemented feature", CostUSD: 0.50},
+			{ResultText: "APPROVED: Looks great.", CostUSD: 0.25},
+		},
+	}
+	l := &Loop{
+		Invoker:       inv,
+		UI:            rUI,
+		Hooks:         []Hook{newBeadHook(rb, rUI)},
+		MaxCycles:     3,
+		MaxBudgetUSD:  10.0,
+		HookQueueSize: 4,
+	}
+	if _, err := l.runLoop(context.Background(), "bead-1", "implement feature"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every queued bead operation has landed by the time the run returns.
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if len(rb.closes) != 1 {
+		t.Errorf("closes = %v, want the bead closed once", rb.closes)
+	}
+	if len(rb.updates) == 0 || len(rb.comments) == 0 {
+		t.Errorf("updates = %d, comments = %d; want both recorded", len(rb.updates), len(rb.comments))
+	}
+}
+
+func TestHookQueueSendStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	q := startHookQueue([]Hook{HookFunc(func(context.Context, Event) { <-release })}, 1)
+	defer func() {
+		close(release)
+		q.close()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.send(ctx, Event{Kind: EventCycleStart}) // taken by the stuck hook, or buffered
+	q.send(ctx, Event{Kind: EventCycleStart})
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.send(ctx, Event{Kind: EventCycleStart})
+		q.send(ctx, Event{Kind: EventCycleStart})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("send blocked on a full queue after the run was cancelled")
+	}
+}
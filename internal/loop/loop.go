@@ -3,6 +3,12 @@ package loop
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/papapumpkin/quasar/internal/agent"
@@ -11,32 +17,168 @@ import (
 	"github.com/papapumpkin/quasar/internal/ui"
 )
 
+// DefaultBudgetWarnFraction is the fraction of MaxBudgetUSD at which a
+// one-time soft budget warning is emitted before the hard stop.
+const DefaultBudgetWarnFraction = 0.8
+
 // Loop orchestrates the coder-reviewer cycle for a single task.
 type Loop struct {
-	Invoker          agent.Invoker
-	UI               ui.UI
-	Git              CycleCommitter // Optional; nil disables per-cycle commits.
-	Hooks            []Hook         // Lifecycle hooks (e.g., BeadHook for tracking).
-	Linter           Linter         // Optional; nil disables lint checks between coder and reviewer.
-	Filter           filter.Filter  // Optional; nil skips pre-reviewer filtering and goes straight to reviewer.
-	MaxCycles        int
-	MaxLintRetries   int // Max times coder is asked to fix lint issues per cycle. 0 uses DefaultMaxLintRetries.
-	MaxBudgetUSD     float64
-	Model            string
-	CoderPrompt      string
-	ReviewPrompt     string
-	WorkDir          string
-	MCP              *agent.MCPConfig // Optional MCP server config passed to agents.
-	RefactorCh       <-chan string    // Optional channel carrying updated task descriptions from phase edits.
-	CommitSummary    string           // Short label for cycle commit messages. If empty, derived from task title.
-	Fabric           fabric.Fabric    // Optional; when set and FabricEnabled, auto-inject fabric state into prompts.
-	FabricEnabled    bool             // When true, inject fabric protocol into agent system prompts.
-	TaskID           string           // Task ID for fabric context (QUASAR_TASK_ID).
-	ProjectContext   string           // Injected into agent system prompts for prompt caching.
-	MaxContextTokens int              // Token budget for context injection. 0 = use default.
-	HailQueue        HailQueue        // Optional; when set, hails extracted during execution are posted here.
-	HailTimeout      time.Duration    // Auto-resolve timeout for hails. 0 disables auto-resolution.
-	StruggleConfig   StruggleConfig   // Optional; zero value disables struggle detection.
+	Invoker            agent.Invoker
+	UI                 ui.UI
+	Git                CycleCommitter // Optional; nil disables per-cycle commits.
+	Hooks              []Hook         // Lifecycle hooks (e.g., BeadHook for tracking).
+	Linter             Linter         // Optional; nil disables lint checks between coder and reviewer.
+	Filter             filter.Filter  // Optional; nil skips pre-reviewer filtering and goes straight to reviewer.
+	MaxCycles          int
+	MaxLintRetries     int // Max times coder is asked to fix lint issues per cycle. 0 uses DefaultMaxLintRetries.
+	MaxBudgetUSD       float64
+	BudgetWarnFraction float64 // Fraction of MaxBudgetUSD that triggers a one-time soft warning. 0 uses DefaultBudgetWarnFraction; >= 1 disables.
+	Model              string
+	CoderPrompt        string
+	ReviewPrompt       string
+	CoderTools         []string // Allowed tools for the coder. Nil uses the built-in set.
+	ReviewerTools      []string // Allowed tools for the reviewer. Nil uses the built-in set.
+	DeniedTools        []string // Tools neither agent may use, removed from both sets above.
+	WorkDir            string
+	MCP                *agent.MCPConfig // Optional MCP server config passed to agents.
+	RefactorCh         <-chan string    // Optional channel carrying updated task descriptions from phase edits.
+	ForceApproveCh     <-chan struct{}  // Optional channel a human signals to approve the task over the reviewer.
+	ExtendCyclesCh     <-chan int       // Optional channel carrying extra review cycles granted mid-run.
+	CommitSummary      string           // Short label for cycle commit messages. If empty, derived from task title.
+	Fabric             fabric.Fabric    // Optional; when set and FabricEnabled, auto-inject fabric state into prompts.
+	FabricEnabled      bool             // When true, inject fabric protocol into agent system prompts.
+	TaskID             string           // Task ID for fabric context (QUASAR_TASK_ID).
+	ProjectContext     string           // Injected into agent system prompts for prompt caching.
+	MaxContextTokens   int              // Token budget for context injection. 0 = use default.
+	HailQueue          HailQueue        // Optional; when set, hails extracted during execution are posted here.
+	HailTimeout        time.Duration    // Auto-resolve timeout for hails. 0 disables auto-resolution.
+	StruggleConfig     StruggleConfig   // Optional; zero value disables struggle detection.
+	// AutoApplySuggestions applies trivial single-hunk reviewer suggestions
+	// via Git before re-invoking the coder. Requires Git.
+	AutoApplySuggestions bool
+	// EscalateReviewAfterCycles asks a second, independent reviewer for an
+	// opinion on every rejection once this many cycles have been rejected.
+	// Another coder cycle only runs if both reviewers report blocking issues;
+	// if they disagree, the loop stops and hails a human. 0 disables escalation.
+	EscalateReviewAfterCycles int
+	EscalationModel           string // Model for the second reviewer. Empty uses Model.
+	EscalationPrompt          string // System prompt for the second reviewer. Empty uses ReviewPrompt.
+	// StopOnDegradingReview stops the loop and hails a human once reviewer
+	// satisfaction has dropped two cycles in a row (e.g. high → medium →
+	// low), instead of spending the remaining cycles.
+	StopOnDegradingReview bool
+	// StopOnNoProgress stops the loop and hails a human once two rejected
+	// cycles in a row have changed no code, or only repeated the change of
+	// the cycle before. Requires Git.
+	StopOnNoProgress bool
+	// Tester runs the project's tests; an empty output means they pass.
+	// Optional; nil never runs tests.
+	Tester Linter
+	// RetryOnTestFailure runs Tester once the reviewer approves and, if the
+	// tests fail, records the failures as a finding and runs another cycle
+	// (up to MaxCycles) instead of accepting the approval.
+	RetryOnTestFailure bool
+	// IncludeDiffInReview adds the task's code diff so far, from its base
+	// commit to the latest cycle commit, to the reviewer prompt. Large
+	// diffs are truncated and summarized per file. Requires Git; off by
+	// default because it adds to every review's token cost.
+	IncludeDiffInReview bool
+	// ReviewDiffOnly has the reviewer work from that diff instead of
+	// re-reading whole files, cutting review cost on large files. The
+	// review falls back to full files when the diff is too large to include
+	// whole or an open finding names a file the diff does not touch.
+	// Requires Git.
+	ReviewDiffOnly bool
+	// OutputDir, when set, receives each agent's messages as they arrive, in
+	// cycleN-<role>.txt files, so it can be followed outside the TUI.
+	OutputDir string
+	// HookQueueSize, when > 0, delivers lifecycle events to Hooks on a
+	// background goroutine with room for this many pending events, so bead
+	// updates and comments do not block the cycle. Events keep their order,
+	// and the loop waits once the queue is full. 0 runs hooks inline.
+	HookQueueSize int
+	// SkipReview approves each task on the coder's pass without invoking
+	// the reviewer. Lint fixes and the pre-reviewer Filter still run, and a
+	// failing filter still sends the work back to the coder; Tester is not
+	// consulted after the approval.
+	SkipReview bool
+	// CoderTemplate, when set, builds the coder's task prompt each cycle
+	// from CoderTemplateData in place of the built-in format. A refactor
+	// prompt after a mid-run task edit still uses the built-in format. See
+	// ParseCoderTemplate.
+	CoderTemplate *template.Template
+
+	hookQueue *hookQueue // the running task's hook queue; nil runs hooks inline
+}
+
+// CoderTemplateData is what a CoderTemplate is executed with.
+type CoderTemplateData struct {
+	Title       string // the task description
+	BeadID      string
+	Cycle       int
+	MaxCycles   int
+	Findings    string // the reviewer's open findings, one numbered line each; empty on cycle 1
+	Suggestions string // the reviewer's patch suggestions; empty when there are none
+	Diff        string // the task's code diff so far, through last cycle's commit; empty on cycle 1
+}
+
+// ParseCoderTemplate parses text as a CoderTemplate. It executes the
+// template once against sample data, so a placeholder that names no field
+// of CoderTemplateData is reported here rather than on the first cycle.
+// Blank text yields a nil template: the built-in format.
+func ParseCoderTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("coder_template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := CoderTemplateData{Title: "task", BeadID: "bead", Cycle: 2, MaxCycles: 3, Findings: "1. [major] finding\n"}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// renderCoderTemplate builds the coder prompt for state from CoderTemplate.
+// A template that fails to execute falls back to the built-in format, with
+// the error reported.
+func (l *Loop) renderCoderTemplate(state *CycleState) (string, bool) {
+	data := CoderTemplateData{
+		Title:     state.TaskTitle,
+		BeadID:    state.TaskBeadID,
+		Cycle:     state.Cycle,
+		MaxCycles: state.MaxCycles,
+		Diff:      state.coderDiff,
+	}
+	if state.Cycle > 1 {
+		data.Findings = openFindingsList(state.Findings)
+	}
+	if len(state.Suggestions) > 0 {
+		data.Suggestions = buildSuggestionsBlock(state.Suggestions)
+	}
+	var b strings.Builder
+	if err := l.CoderTemplate.Execute(&b, data); err != nil {
+		l.UI.Error(fmt.Sprintf("coder template failed, using the built-in prompt: %v", err))
+		return "", false
+	}
+	return b.String(), true
+}
+
+// openFindingsList numbers the findings not yet marked fixed, one per line,
+// so the coder only works on unresolved issues.
+func openFindingsList(findings []ReviewFinding) string {
+	var b strings.Builder
+	n := 0
+	for _, f := range findings {
+		if f.Status == FindingStatusFixed {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%d. [%s] %s\n", n, f.Severity, f.Description)
+	}
+	return b.String()
 }
 
 // TaskResult holds the outcome of a completed task loop.
@@ -48,7 +190,13 @@ type TaskResult struct {
 	FinalCommitSHA string              // last cycle's sealed SHA (or current HEAD as fallback)
 	Decompose      bool                // true if the loop exited due to a struggle signal
 	StruggleReason string              // human-readable reason from StruggleSignal.Reason
-	AllFindings    []ReviewFinding     // accumulated findings at time of decomposition
+	AllFindings    []ReviewFinding     // accumulated findings at decomposition or when the loop gives up
+	// SatisfactionTrend is the reviewer's satisfaction for each reviewed
+	// cycle, oldest first; "" marks a review without a report.
+	SatisfactionTrend []string
+	// ForceApproved is set when a human approved the task over the
+	// reviewer through ForceApproveCh.
+	ForceApproved bool
 }
 
 // RunTask creates a new bead for the given task and runs the coder-reviewer loop.
@@ -102,8 +250,13 @@ func (l *Loop) GenerateCheckpoint(ctx context.Context, beadID, taskDescription s
 	return result.ResultText, nil
 }
 
-// emit fans out a lifecycle event to all registered hooks.
+// emit fans out a lifecycle event to all registered hooks, through the
+// run's hook queue when HookQueueSize is set.
 func (l *Loop) emit(ctx context.Context, event Event) {
+	if l.hookQueue != nil {
+		l.hookQueue.send(ctx, event)
+		return
+	}
 	for _, h := range l.Hooks {
 		h.OnEvent(ctx, event)
 	}
@@ -111,13 +264,20 @@ func (l *Loop) emit(ctx context.Context, event Event) {
 
 // runLoop is the core coder-reviewer loop extracted from RunTask.
 func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*TaskResult, error) {
+	if l.HookQueueSize > 0 && len(l.Hooks) > 0 {
+		l.hookQueue = startHookQueue(l.Hooks, l.HookQueueSize)
+		defer func() {
+			l.hookQueue.close()
+			l.hookQueue = nil
+		}()
+	}
 	perAgentBudget := l.perAgentBudget()
 	state := l.initCycleState(ctx, beadID, taskDescription)
 	l.emitBeadUpdate(state, "in_progress")
 
-	for cycle := 1; cycle <= l.MaxCycles; cycle++ {
+	for cycle := 1; cycle <= l.cycleCap(state); cycle++ {
 		state.Cycle = cycle
-		l.UI.CycleStart(cycle, l.MaxCycles)
+		l.UI.CycleStart(cycle, state.MaxCycles)
 
 		if err := l.runCoderPhase(ctx, state, perAgentBudget); err != nil {
 			return nil, err
@@ -130,6 +290,9 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 		if err := l.runLintFixLoop(ctx, state, perAgentBudget); err != nil {
 			return nil, err
 		}
+		if l.forceApproveRequested(state) {
+			return l.handleApproval(ctx, state)
+		}
 
 		// Run pre-reviewer filter checks. If the filter fails, bounce
 		// the failure back to the coder as findings instead of invoking
@@ -149,12 +312,19 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 			}
 		}
 
+		if l.SkipReview {
+			return l.handleApproval(ctx, state)
+		}
+
 		if err := l.runReviewerPhase(ctx, state, perAgentBudget); err != nil {
 			return nil, err
 		}
 		if err := l.checkBudget(ctx, state); err != nil {
 			return nil, err
 		}
+		if l.forceApproveRequested(state) {
+			return l.handleApproval(ctx, state)
+		}
 
 		// Apply verification results to update finding lifecycle statuses.
 		if len(state.Verifications) > 0 {
@@ -166,11 +336,41 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 			})
 		}
 
+		state.SatisfactionTrend = append(state.SatisfactionTrend, satisfactionOf(state.ReviewOutput))
+
 		// Extract hails from the reviewer's report and any fabric discoveries.
 		l.extractAndPostHails(ctx, state)
 
+		testsFailed := false
 		if isApproved(state.ReviewOutput) {
-			return l.handleApproval(ctx, state)
+			if testsFailed = l.testsRejectApproval(ctx, state); !testsFailed {
+				return l.handleApproval(ctx, state)
+			}
+		} else {
+			state.rejectedCycles++
+		}
+
+		// Once rejections pile up, only force another coder cycle if an
+		// independent second reviewer agrees there are blocking issues.
+		if !testsFailed && l.EscalateReviewAfterCycles > 0 && state.rejectedCycles >= l.EscalateReviewAfterCycles {
+			if err := l.runSecondReviewerPhase(ctx, state, perAgentBudget); err != nil {
+				return nil, err
+			}
+			if err := l.checkBudget(ctx, state); err != nil {
+				return nil, err
+			}
+			if isApproved(state.SecondReviewOutput) {
+				return l.handleReviewDisagreement(ctx, state)
+			}
+		}
+
+		if l.StopOnDegradingReview && satisfactionDegrading(state.SatisfactionTrend) {
+			return l.handleDegradingReview(ctx, state)
+		}
+		if l.StopOnNoProgress {
+			if reason := l.trackProgress(ctx, state); reason != "" {
+				return l.handleNoProgress(ctx, state, reason)
+			}
 		}
 
 		// Record this cycle's filter check name (empty if filter passed or was nil).
@@ -189,9 +389,7 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 		for i := range state.Findings {
 			state.Findings[i].Cycle = state.Cycle
 		}
-		newChildIDs := l.createFindingBeads(ctx, state)
-		state.ChildBeadIDs = append(state.ChildBeadIDs, newChildIDs...)
-		state.AllFindings = append(state.AllFindings, state.Findings...)
+		l.recordFindings(ctx, state)
 		l.emitBeadUpdate(state, "in_progress")
 
 		// Evaluate struggle detection after findings are accumulated.
@@ -212,6 +410,8 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 					Decompose:      true,
 					StruggleReason: signal.Reason,
 					AllFindings:    state.AllFindings,
+
+					SatisfactionTrend: state.SatisfactionTrend,
 				}, nil
 			}
 		}
@@ -219,18 +419,21 @@ func (l *Loop) runLoop(ctx context.Context, beadID, taskDescription string) (*Ta
 		l.emit(ctx, Event{Kind: EventCycleStart, BeadID: beadID, Cycle: cycle})
 	}
 
-	l.UI.MaxCyclesReached(l.MaxCycles)
+	l.UI.MaxCyclesReached(state.MaxCycles)
 	l.postMaxCyclesHail(state)
 	l.emit(ctx, Event{
 		Kind:    EventTaskFailed,
 		BeadID:  beadID,
-		Message: fmt.Sprintf("Max cycles reached (%d). Manual review recommended.", l.MaxCycles),
+		Message: fmt.Sprintf("Max cycles reached (%d). Manual review recommended.", state.MaxCycles),
 	})
 	return &TaskResult{
 		TotalCostUSD:   state.TotalCostUSD,
 		CyclesUsed:     state.Cycle,
 		BaseCommitSHA:  state.BaseCommitSHA,
 		FinalCommitSHA: l.finalCommitSHA(ctx, state),
+		AllFindings:    state.AllFindings,
+
+		SatisfactionTrend: state.SatisfactionTrend,
 	}, ErrMaxCycles
 }
 
@@ -355,6 +558,33 @@ func (l *Loop) runFilterChecks(ctx context.Context, state *CycleState) (failed b
 	return true, nil
 }
 
+// testsRejectApproval runs Tester after the reviewer approves, when
+// RetryOnTestFailure is set. Failing tests replace the cycle's findings with
+// a critical finding carrying the test output, so the coder sees it next
+// cycle, and the approval is rejected. A test execution error is reported
+// but does not block approval.
+func (l *Loop) testsRejectApproval(ctx context.Context, state *CycleState) bool {
+	if !l.RetryOnTestFailure || l.Tester == nil {
+		return false
+	}
+	l.UI.Info("running project tests…")
+	output, err := l.Tester.Run(ctx)
+	if err != nil {
+		l.UI.Error(fmt.Sprintf("test execution error: %v", err))
+		return false
+	}
+	if output == "" {
+		l.UI.Info("project tests passed")
+		return false
+	}
+	l.UI.Info("project tests fail despite approval, sending back to coder")
+	state.Findings = []ReviewFinding{{
+		Severity:    "critical",
+		Description: "[tests] the project's tests fail:\n" + truncate(output, 3000),
+	}}
+	return true
+}
+
 // drainRefactor checks the RefactorCh for a pending phase edit and applies it
 // to the cycle state. The current cycle always completes before the new
 // description takes effect. Only the most recent value on the channel wins.
@@ -379,6 +609,43 @@ func (l *Loop) drainRefactor(state *CycleState) {
 	}
 }
 
+// forceApproveRequested reports whether a human has signaled ForceApproveCh
+// to override the reviewer. The signal is only seen between agent runs, so
+// the agent already running finishes first.
+func (l *Loop) forceApproveRequested(state *CycleState) bool {
+	if l.ForceApproveCh == nil {
+		return false
+	}
+	select {
+	case <-l.ForceApproveCh:
+		state.forceApproved = true
+		l.UI.Info("reviewer overridden by a human, approving")
+		return true
+	default:
+		return false
+	}
+}
+
+// cycleCap returns the task's cycle limit after adding any cycles granted
+// through ExtendCyclesCh since it was last checked. It runs before each
+// cycle, so cycles granted while the last one runs keep the loop going.
+func (l *Loop) cycleCap(state *CycleState) int {
+	if l.ExtendCyclesCh == nil {
+		return state.MaxCycles
+	}
+	for {
+		select {
+		case n := <-l.ExtendCyclesCh:
+			if n > 0 {
+				state.MaxCycles += n
+				l.UI.Info(fmt.Sprintf("review cycle limit raised to %d", state.MaxCycles))
+			}
+		default:
+			return state.MaxCycles
+		}
+	}
+}
+
 // perAgentBudget computes the per-invocation budget by splitting the total
 // evenly between coder and reviewer across all cycles.
 func (l *Loop) perAgentBudget() float64 {
@@ -422,16 +689,22 @@ func (l *Loop) coderAgent(budget float64) agent.Agent {
 		TaskID:         l.TaskID,
 		ProjectContext: l.ProjectContext,
 	})
+	tools := l.CoderTools
+	if tools == nil {
+		tools = []string{
+			"Read", "Edit", "Write", "Glob", "Grep",
+			"Bash(go *)", "Bash(git diff *)", "Bash(git status)", "Bash(git log *)",
+			agent.ScratchpadNoteTool,
+		}
+	}
 	return agent.Agent{
 		Role:         agent.RoleCoder,
 		SystemPrompt: sysPrompt,
 		Model:        l.Model,
 		MaxBudgetUSD: budget,
-		AllowedTools: []string{
-			"Read", "Edit", "Write", "Glob", "Grep",
-			"Bash(go *)", "Bash(git diff *)", "Bash(git status)", "Bash(git log *)",
-		},
-		MCP: l.MCP,
+		AllowedTools: agent.WithoutTools(tools, l.DeniedTools),
+		DeniedTools:  l.DeniedTools,
+		MCP:          l.MCP,
 	}
 }
 
@@ -444,16 +717,22 @@ func (l *Loop) reviewerAgent(budget float64) agent.Agent {
 		TaskID:         l.TaskID,
 		ProjectContext: l.ProjectContext,
 	})
+	tools := l.ReviewerTools
+	if tools == nil {
+		tools = []string{
+			"Read", "Glob", "Grep",
+			"Bash(go vet *)", "Bash(git diff *)", "Bash(git log *)",
+			agent.ScratchpadNoteTool,
+		}
+	}
 	return agent.Agent{
 		Role:         agent.RoleReviewer,
 		SystemPrompt: sysPrompt,
 		Model:        l.Model,
 		MaxBudgetUSD: budget,
-		AllowedTools: []string{
-			"Read", "Glob", "Grep",
-			"Bash(go vet *)", "Bash(git diff *)", "Bash(git log *)",
-		},
-		MCP: l.MCP,
+		AllowedTools: agent.WithoutTools(tools, l.DeniedTools),
+		DeniedTools:  l.DeniedTools,
+		MCP:          l.MCP,
 	}
 }
 
@@ -463,12 +742,21 @@ func (l *Loop) reviewerAgent(budget float64) agent.Agent {
 func (l *Loop) runCoderPhase(ctx context.Context, state *CycleState, perAgentBudget float64) error {
 	state.Phase = PhaseCoding
 	l.UI.AgentStart("coder")
+	l.logAgentStart("coder", "coder", state.Cycle)
+
+	// Apply trivial reviewer suggestions first so the prompt can report them.
+	if state.Cycle > 1 {
+		l.applyTrivialSuggestions(ctx, state)
+	}
 
 	// Capture refactor state before buildCoderPrompt clears the flag.
 	wasRefactored := state.Refactored
 	origDesc := state.OriginalDescription
 	refactorDesc := state.RefactorDescription
 
+	if l.CoderTemplate != nil {
+		state.coderDiff = l.taskDiff(ctx, state)
+	}
 	prompt := l.buildCoderPrompt(state)
 	relayBlock, relayIDs := l.pendingHailRelay()
 	if relayBlock != "" {
@@ -476,9 +764,11 @@ func (l *Loop) runCoderPhase(ctx context.Context, state *CycleState, perAgentBud
 	}
 	prompt = l.composeContextPrefix(ctx, prompt)
 
-	result, err := l.Invoker.Invoke(ctx, l.coderAgent(perAgentBudget), prompt, l.WorkDir)
+	streamCtx, logResult := l.streamOutput(ctx, "coder", state.Cycle)
+	result, err := l.Invoker.Invoke(streamCtx, l.coderAgent(perAgentBudget), prompt, l.WorkDir)
 	if err != nil {
 		state.Phase = PhaseError
+		l.logOutput("coder", state.Cycle, "error: "+err.Error())
 		return fmt.Errorf("coder invocation failed: %w", err)
 	}
 
@@ -486,6 +776,7 @@ func (l *Loop) runCoderPhase(ctx context.Context, state *CycleState, perAgentBud
 	state.TotalCostUSD += result.CostUSD
 	state.Phase = PhaseCodeComplete
 	l.UI.AgentOutput("coder", state.Cycle, result.ResultText)
+	logResult(result.ResultText)
 	l.UI.AgentDone("coder", result.CostUSD, result.DurationMs)
 	l.emitCycleSummary(state, PhaseCodeComplete, result)
 	l.markHailsRelayed(relayIDs)
@@ -509,7 +800,7 @@ func (l *Loop) runCoderPhase(ctx context.Context, state *CycleState, perAgentBud
 		comment := fmt.Sprintf("[refactor cycle %d] User updated task description mid-execution.\nOriginal: %s\nUpdated: %s",
 			state.Cycle, truncate(origDesc, 500), truncate(refactorDesc, 500))
 		l.emit(ctx, Event{Kind: EventRefactored, BeadID: state.TaskBeadID, Cycle: state.Cycle, Message: comment})
-		l.UI.RefactorApplied(state.TaskBeadID)
+		l.UI.RefactorApplied(state.TaskBeadID, origDesc, refactorDesc)
 	}
 	l.emit(ctx, Event{
 		Kind:    EventAgentDone,
@@ -527,7 +818,9 @@ func (l *Loop) runCoderPhase(ctx context.Context, state *CycleState, perAgentBud
 func (l *Loop) runReviewerPhase(ctx context.Context, state *CycleState, perAgentBudget float64) error {
 	state.Phase = PhaseReviewing
 	l.UI.AgentStart("reviewer")
+	l.logAgentStart("reviewer", "reviewer", state.Cycle)
 
+	state.reviewDiff = l.reviewDiff(ctx, state)
 	prompt := l.buildReviewerPrompt(state)
 	relayBlock, relayIDs := l.pendingHailRelay()
 	if relayBlock != "" {
@@ -535,9 +828,11 @@ func (l *Loop) runReviewerPhase(ctx context.Context, state *CycleState, perAgent
 	}
 	prompt = l.composeContextPrefix(ctx, prompt)
 
-	result, err := l.Invoker.Invoke(ctx, l.reviewerAgent(perAgentBudget), prompt, l.WorkDir)
+	streamCtx, logResult := l.streamOutput(ctx, "reviewer", state.Cycle)
+	result, err := l.Invoker.Invoke(streamCtx, l.reviewerAgent(perAgentBudget), prompt, l.WorkDir)
 	if err != nil {
 		state.Phase = PhaseError
+		l.logOutput("reviewer", state.Cycle, "error: "+err.Error())
 		return fmt.Errorf("reviewer invocation failed: %w", err)
 	}
 
@@ -545,10 +840,12 @@ func (l *Loop) runReviewerPhase(ctx context.Context, state *CycleState, perAgent
 	state.TotalCostUSD += result.CostUSD
 	state.Phase = PhaseReviewComplete
 	l.UI.AgentOutput("reviewer", state.Cycle, result.ResultText)
+	logResult(result.ResultText)
 	l.UI.AgentDone("reviewer", result.CostUSD, result.DurationMs)
 	l.markHailsRelayed(relayIDs)
 	state.Findings = ParseReviewFindings(result.ResultText)
 	state.Verifications = ParseVerifications(result.ResultText)
+	state.Suggestions = ParseSuggestions(result.ResultText)
 	l.emit(ctx, Event{
 		Kind:    EventAgentDone,
 		BeadID:  state.TaskBeadID,
@@ -561,6 +858,254 @@ func (l *Loop) runReviewerPhase(ctx context.Context, state *CycleState, perAgent
 	return nil
 }
 
+// secondReviewerAgent builds the agent configuration for the escalation
+// reviewer: the reviewer role with EscalationModel and EscalationPrompt
+// substituted where set.
+func (l *Loop) secondReviewerAgent(budget float64) agent.Agent {
+	a := l.reviewerAgent(budget)
+	if l.EscalationModel != "" {
+		a.Model = l.EscalationModel
+	}
+	if l.EscalationPrompt != "" {
+		a.SystemPrompt = agent.BuildSystemPrompt(l.EscalationPrompt, agent.PromptOpts{
+			FabricEnabled:  l.FabricEnabled,
+			TaskID:         l.TaskID,
+			ProjectContext: l.ProjectContext,
+		})
+	}
+	return a
+}
+
+// runSecondReviewerPhase asks the escalation reviewer to review the same
+// cycle independently. Its findings are merged into the cycle's findings so
+// both reviews are recorded; findings both reviewers report are kept once.
+func (l *Loop) runSecondReviewerPhase(ctx context.Context, state *CycleState, perAgentBudget float64) error {
+	state.Phase = PhaseReviewing
+	l.UI.Info(fmt.Sprintf("%d cycles rejected, requesting a second reviewer opinion", state.rejectedCycles))
+	l.UI.AgentStart("reviewer")
+	l.logAgentStart("reviewer", "second reviewer", state.Cycle)
+
+	prompt := l.composeContextPrefix(ctx, l.buildReviewerPrompt(state))
+	streamCtx, logResult := l.streamOutput(ctx, "reviewer", state.Cycle)
+	result, err := l.Invoker.Invoke(streamCtx, l.secondReviewerAgent(perAgentBudget), prompt, l.WorkDir)
+	if err != nil {
+		state.Phase = PhaseError
+		l.logOutput("reviewer", state.Cycle, "error: "+err.Error())
+		return fmt.Errorf("second reviewer invocation failed: %w", err)
+	}
+
+	state.SecondReviewOutput = result.ResultText
+	state.TotalCostUSD += result.CostUSD
+	state.Phase = PhaseReviewComplete
+	l.UI.AgentOutput("reviewer", state.Cycle, result.ResultText)
+	logResult(result.ResultText)
+	l.UI.AgentDone("reviewer", result.CostUSD, result.DurationMs)
+	state.Findings = append(state.Findings, ParseReviewFindings(result.ResultText)...)
+	l.emit(ctx, Event{
+		Kind:    EventAgentDone,
+		BeadID:  state.TaskBeadID,
+		Cycle:   state.Cycle,
+		Agent:   "reviewer",
+		Result:  &result,
+		Message: fmt.Sprintf("[second reviewer cycle %d]\n%s", state.Cycle, truncate(result.ResultText, 2000)),
+	})
+	return nil
+}
+
+// handleReviewDisagreement stops the loop when the second reviewer approves
+// a cycle the first reviewer rejected. The first reviewer's findings are
+// recorded and a decision-needed hail carrying both reviews is posted.
+func (l *Loop) handleReviewDisagreement(ctx context.Context, state *CycleState) (*TaskResult, error) {
+	l.closeRejectedCycle(ctx, state)
+
+	l.UI.Info("reviewers disagree on blocking issues, escalating to a human")
+	if l.HailQueue != nil {
+		if err := l.HailQueue.Post(buildReviewDisagreementHail(state, l.TaskID)); err != nil {
+			l.UI.Error(fmt.Sprintf("failed to post review disagreement hail: %v", err))
+		}
+	}
+	l.emit(ctx, Event{
+		Kind:    EventTaskFailed,
+		BeadID:  state.TaskBeadID,
+		Cycle:   state.Cycle,
+		Message: fmt.Sprintf("Reviewers disagree after %d rejected cycles. Human decision needed.", state.rejectedCycles),
+	})
+	return &TaskResult{
+		TotalCostUSD:   state.TotalCostUSD,
+		CyclesUsed:     state.Cycle,
+		BaseCommitSHA:  state.BaseCommitSHA,
+		FinalCommitSHA: l.finalCommitSHA(ctx, state),
+		AllFindings:    state.AllFindings,
+
+		SatisfactionTrend: state.SatisfactionTrend,
+	}, ErrReviewDisagreement
+}
+
+// handleDegradingReview stops the loop when reviewer satisfaction has
+// dropped two cycles in a row. The cycle's findings are recorded and a
+// decision-needed hail carrying the trend is posted.
+func (l *Loop) handleDegradingReview(ctx context.Context, state *CycleState) (*TaskResult, error) {
+	l.closeRejectedCycle(ctx, state)
+
+	trend := strings.Join(state.SatisfactionTrend, " → ")
+	l.UI.Info(fmt.Sprintf("reviewer satisfaction degrading (%s), escalating to a human", trend))
+	if l.HailQueue != nil {
+		if err := l.HailQueue.Post(buildDegradingReviewHail(state, l.TaskID)); err != nil {
+			l.UI.Error(fmt.Sprintf("failed to post degrading review hail: %v", err))
+		}
+	}
+	l.emit(ctx, Event{
+		Kind:    EventTaskFailed,
+		BeadID:  state.TaskBeadID,
+		Cycle:   state.Cycle,
+		Message: fmt.Sprintf("Reviewer satisfaction degrading (%s). Human decision needed.", trend),
+	})
+	return &TaskResult{
+		TotalCostUSD:   state.TotalCostUSD,
+		CyclesUsed:     state.Cycle,
+		BaseCommitSHA:  state.BaseCommitSHA,
+		FinalCommitSHA: l.finalCommitSHA(ctx, state),
+		AllFindings:    state.AllFindings,
+
+		SatisfactionTrend: state.SatisfactionTrend,
+	}, ErrDegradingReview
+}
+
+// noProgressSimilarity is how alike, from 0 to 1, two cycles' diffs must be
+// for the second to count as repeating the first.
+const noProgressSimilarity = 0.9
+
+// trackProgress compares the current cycle's diff with the previous
+// cycle's and, once two cycles in a row have made no progress, returns why.
+// A cycle makes no progress when its diff is empty or nearly identical to
+// the one before. Without Git, or when the diff cannot be read, it never
+// reports a stall.
+func (l *Loop) trackProgress(ctx context.Context, state *CycleState) string {
+	diff, ok := l.cycleDiff(ctx, state)
+	if !ok {
+		state.stalledCycles = 0
+		return ""
+	}
+	prev := state.lastCycleDiff
+	state.lastCycleDiff = diff
+
+	var why string
+	switch {
+	case strings.TrimSpace(diff) == "":
+		why = "changed no code"
+	case state.Cycle > 1 && diffSimilarity(prev, diff) >= noProgressSimilarity:
+		why = "repeated the previous cycle's change"
+	default:
+		state.stalledCycles = 0
+		return ""
+	}
+	state.stalledCycles++
+	if state.stalledCycles < 2 {
+		return ""
+	}
+	return fmt.Sprintf("cycle %d %s, after %d cycles without progress", state.Cycle, why, state.stalledCycles)
+}
+
+// cycleDiff returns the diff of the current cycle alone: from the previous
+// cycle's last commit, or the base commit on the first, to this cycle's.
+// ok is false when there is no Git or the diff fails.
+func (l *Loop) cycleDiff(ctx context.Context, state *CycleState) (string, bool) {
+	if l.Git == nil || state.BaseCommitSHA == "" {
+		return "", false
+	}
+	from := state.BaseCommitSHA
+	if n := len(state.CycleCommits); n > 0 {
+		from = state.CycleCommits[n-1]
+	}
+	to := state.lastCycleSHA
+	if to == "" || to == from {
+		return "", true
+	}
+	diff, err := l.Git.DiffRange(ctx, from, to)
+	if err != nil {
+		l.UI.Error(fmt.Sprintf("failed to diff cycle %d for the progress check: %v", state.Cycle, err))
+		return "", false
+	}
+	return diff, true
+}
+
+// diffSimilarity scores how alike two diffs' added and removed lines are,
+// from 0 (nothing shared) to 1 (the same lines), ignoring order.
+func diffSimilarity(a, b string) float64 {
+	linesA, linesB := changedLines(a), changedLines(b)
+	if len(linesA)+len(linesB) == 0 {
+		return 1
+	}
+	counts := make(map[string]int, len(linesA))
+	for _, line := range linesA {
+		counts[line]++
+	}
+	shared := 0
+	for _, line := range linesB {
+		if counts[line] > 0 {
+			counts[line]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(linesA)+len(linesB))
+}
+
+// changedLines returns the added and removed lines of a unified diff,
+// without the file headers.
+func changedLines(diff string) []string {
+	var lines []string
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+			continue
+		}
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// handleNoProgress stops the loop when its cycles have stopped changing the
+// code. The cycle's findings are recorded, a decision-needed hail carrying
+// the reason is posted, and the reason is wrapped into the returned error.
+func (l *Loop) handleNoProgress(ctx context.Context, state *CycleState, reason string) (*TaskResult, error) {
+	l.closeRejectedCycle(ctx, state)
+
+	l.UI.Info(fmt.Sprintf("no progress: %s, escalating to a human", reason))
+	if l.HailQueue != nil {
+		if err := l.HailQueue.Post(buildNoProgressHail(state, l.TaskID, reason)); err != nil {
+			l.UI.Error(fmt.Sprintf("failed to post no-progress hail: %v", err))
+		}
+	}
+	l.emit(ctx, Event{
+		Kind:    EventTaskFailed,
+		BeadID:  state.TaskBeadID,
+		Cycle:   state.Cycle,
+		Message: fmt.Sprintf("No progress: %s. Human decision needed.", reason),
+	})
+	return &TaskResult{
+		TotalCostUSD:   state.TotalCostUSD,
+		CyclesUsed:     state.Cycle,
+		BaseCommitSHA:  state.BaseCommitSHA,
+		FinalCommitSHA: l.finalCommitSHA(ctx, state),
+		AllFindings:    state.AllFindings,
+
+		SatisfactionTrend: state.SatisfactionTrend,
+	}, fmt.Errorf("%w: %s", ErrNoProgress, reason)
+}
+
+// closeRejectedCycle seals and records a rejected cycle the loop is about
+// to stop on, as the end of a normal rejected cycle would.
+func (l *Loop) closeRejectedCycle(ctx context.Context, state *CycleState) {
+	state.FilterHistory = append(state.FilterHistory, state.FilterCheckName)
+	l.sealCycleSHA(state)
+	for i := range state.Findings {
+		state.Findings[i].Cycle = state.Cycle
+	}
+	l.recordFindings(ctx, state)
+	l.emitBeadUpdate(state, "in_progress")
+}
+
 // extractAndPostHails parses the reviewer's report and queries fabric
 // discoveries, converting them into Hail objects posted to l.HailQueue.
 // It also applies escalation rules: critical findings and high-risk/low-
@@ -644,9 +1189,15 @@ func (l *Loop) postMaxCyclesHail(state *CycleState) {
 
 // emitCycleSummary sends a cycle summary to the UI for the given phase.
 func (l *Loop) emitCycleSummary(state *CycleState, phase Phase, result agent.InvocationResult) {
+	var confidence string
+	if phase == PhaseReviewComplete {
+		if report := ParseReviewReport(state.ReviewOutput); report != nil {
+			confidence = report.Confidence
+		}
+	}
 	l.UI.CycleSummary(ui.CycleSummaryData{
 		Cycle:        state.Cycle,
-		MaxCycles:    l.MaxCycles,
+		MaxCycles:    max(state.MaxCycles, l.MaxCycles), // state.MaxCycles includes cycles granted mid-run
 		Phase:        phase.String(),
 		CostUSD:      result.CostUSD,
 		TotalCostUSD: state.TotalCostUSD,
@@ -654,12 +1205,19 @@ func (l *Loop) emitCycleSummary(state *CycleState, phase Phase, result agent.Inv
 		DurationMs:   result.DurationMs,
 		Approved:     isApproved(state.ReviewOutput),
 		IssueCount:   len(state.Findings),
+		Confidence:   confidence,
 	})
 }
 
 // checkBudget returns ErrBudgetExceeded if the total cost has reached the limit.
+// Below the limit, it emits a one-time BudgetWarning once the cost crosses the
+// soft warning threshold; the run continues.
 func (l *Loop) checkBudget(ctx context.Context, state *CycleState) error {
-	if l.MaxBudgetUSD <= 0 || state.TotalCostUSD < l.MaxBudgetUSD {
+	if l.MaxBudgetUSD <= 0 {
+		return nil
+	}
+	if state.TotalCostUSD < l.MaxBudgetUSD {
+		l.checkBudgetWarning(state)
 		return nil
 	}
 	l.UI.BudgetExceeded(state.TotalCostUSD, l.MaxBudgetUSD)
@@ -671,6 +1229,28 @@ func (l *Loop) checkBudget(ctx context.Context, state *CycleState) error {
 	return ErrBudgetExceeded
 }
 
+// budgetWarnFraction returns the effective soft-warning fraction.
+func (l *Loop) budgetWarnFraction() float64 {
+	if l.BudgetWarnFraction > 0 {
+		return l.BudgetWarnFraction
+	}
+	return DefaultBudgetWarnFraction
+}
+
+// checkBudgetWarning emits BudgetWarning the first time the total cost reaches
+// the warn fraction of MaxBudgetUSD. Subsequent calls are no-ops.
+func (l *Loop) checkBudgetWarning(state *CycleState) {
+	frac := l.budgetWarnFraction()
+	if state.budgetWarned || frac >= 1 {
+		return
+	}
+	if state.TotalCostUSD < l.MaxBudgetUSD*frac {
+		return
+	}
+	state.budgetWarned = true
+	l.UI.BudgetWarning(state.TotalCostUSD, l.MaxBudgetUSD)
+}
+
 // handleApproval seals the final cycle's commit SHA, emits success events,
 // records the review report, and returns the final result.
 func (l *Loop) handleApproval(ctx context.Context, state *CycleState) (*TaskResult, error) {
@@ -679,12 +1259,20 @@ func (l *Loop) handleApproval(ctx context.Context, state *CycleState) (*TaskResu
 	l.UI.Approved()
 
 	report := ParseReviewReport(state.ReviewOutput)
+	var note string
+	switch {
+	case state.forceApproved:
+		note = "Force-approved by a human over the reviewer"
+	case l.SkipReview:
+		note = "Completed without review (skip_review)"
+	}
 
 	l.emit(ctx, Event{
-		Kind:   EventTaskSuccess,
-		BeadID: state.TaskBeadID,
-		Cycle:  state.Cycle,
-		Report: report,
+		Kind:    EventTaskSuccess,
+		BeadID:  state.TaskBeadID,
+		Cycle:   state.Cycle,
+		Report:  report,
+		Message: note,
 	})
 	l.emitBeadUpdate(state, "closed")
 
@@ -695,6 +1283,9 @@ func (l *Loop) handleApproval(ctx context.Context, state *CycleState) (*TaskResu
 		Report:         report,
 		BaseCommitSHA:  state.BaseCommitSHA,
 		FinalCommitSHA: l.finalCommitSHA(ctx, state),
+
+		SatisfactionTrend: state.SatisfactionTrend,
+		ForceApproved:     state.forceApproved,
 	}, nil
 }
 
@@ -758,14 +1349,80 @@ func (l *Loop) emitBeadUpdate(state *CycleState, status string) {
 }
 
 // createFindingBeads delegates to hooks that implement FindingCreator to
-// create child beads for each review finding. Returns the IDs of
-// successfully created beads.
-func (l *Loop) createFindingBeads(ctx context.Context, state *CycleState) []string {
+// create child beads of parentBeadID for each review finding. Returns the IDs
+// of successfully created beads.
+func (l *Loop) createFindingBeads(ctx context.Context, parentBeadID string, findings []ReviewFinding) []string {
 	var ids []string
 	for _, h := range l.Hooks {
 		if fc, ok := h.(FindingCreator); ok {
-			ids = append(ids, fc.CreateFindingChildIDs(ctx, state.TaskBeadID, state.Findings)...)
+			ids = append(ids, fc.CreateFindingChildIDs(ctx, parentBeadID, findings)...)
 		}
 	}
 	return ids
 }
+
+// logAgentStart marks the start of an agent's turn in its cycle's output
+// log, so the file appears while the agent is still working. label names
+// the agent in the marker; role picks the file.
+func (l *Loop) logAgentStart(role, label string, cycle int) {
+	l.logOutput(role, cycle, fmt.Sprintf("── %s started %s ──", label, time.Now().Format("15:04:05")))
+}
+
+// streamOutput returns ctx set up to append the agent's messages to its
+// cycle's output log as the invoker reports them, and a func that logs the
+// final result once the agent is done unless it was the last message
+// already streamed. Without OutputDir, ctx is returned unchanged.
+func (l *Loop) streamOutput(ctx context.Context, role string, cycle int) (context.Context, func(result string)) {
+	if l.OutputDir == "" {
+		return ctx, func(string) {}
+	}
+	var mu sync.Mutex
+	var last string
+	ctx = agent.WithOutput(ctx, func(text string) {
+		mu.Lock()
+		last = text
+		mu.Unlock()
+		l.logOutput(role, cycle, text)
+	})
+	return ctx, func(result string) {
+		mu.Lock()
+		streamed := last
+		mu.Unlock()
+		if strings.TrimSpace(result) != streamed {
+			l.logOutput(role, cycle, result)
+		}
+	}
+}
+
+// logOutput appends text to OutputDir/cycleN-<role>.txt. The file is closed
+// after every write so readers see each entry as soon as it lands. A failed
+// write is reported but never stops the loop.
+func (l *Loop) logOutput(role string, cycle int, text string) {
+	if l.OutputDir == "" {
+		return
+	}
+	if err := appendOutputLog(l.OutputDir, cycle, role, text); err != nil {
+		l.UI.Info(fmt.Sprintf("output log: %v", err))
+	}
+}
+
+// appendOutputLog appends text and a trailing newline to the cycle's log
+// file for role in dir, creating dir as needed.
+func appendOutputLog(dir string, cycle int, role, text string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("cycle%d-%s.txt", cycle, role))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	if _, err := fmt.Fprintln(f, strings.TrimRight(text, "\n")); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", path, err)
+	}
+	return nil
+}
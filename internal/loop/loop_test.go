@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -34,11 +38,13 @@ func (n *noopUI) IssuesFound(int)                                   {}
 func (n *noopUI) Approved()                                         {}
 func (n *noopUI) MaxCyclesReached(int)                              {}
 func (n *noopUI) BudgetExceeded(float64, float64)                   {}
+func (n *noopUI) BudgetWarning(float64, float64)                    {}
 func (n *noopUI) Error(string)                                      {}
 func (n *noopUI) Info(string)                                       {}
 func (n *noopUI) AgentOutput(string, int, string)                   {}
 func (n *noopUI) BeadUpdate(string, string, string, []ui.BeadChild) {}
-func (n *noopUI) RefactorApplied(string)                            {}
+func (n *noopUI) BeadsDegraded(int)                                 {}
+func (n *noopUI) RefactorApplied(string, string, string)            {}
 func (n *noopUI) FindingLifecycle(int, ui.FindingLifecycleData)     {}
 func (n *noopUI) HailReceived(ui.HailInfo)                          {}
 func (n *noopUI) HailResolved(string, string)                       {}
@@ -75,11 +81,13 @@ type recordingUI struct {
 	approvedCalls   int
 	maxCyclesCalls  int
 	budgetCalls     int
+	budgetWarnCalls int
 	issuesCounts    []int
 	errors          []string
 	beadUpdates     []beadUpdateCall
 	cycleSummaries  []ui.CycleSummaryData
 	refactorIDs     []string
+	beadsDegraded   []int
 }
 
 type beadUpdateCall struct {
@@ -129,6 +137,16 @@ func (r *recordingUI) BudgetExceeded(_, _ float64) {
 	defer r.mu.Unlock()
 	r.budgetCalls++
 }
+func (r *recordingUI) BeadsDegraded(buffered int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beadsDegraded = append(r.beadsDegraded, buffered)
+}
+func (r *recordingUI) BudgetWarning(_, _ float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budgetWarnCalls++
+}
 func (r *recordingUI) IssuesFound(count int) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -149,7 +167,7 @@ func (r *recordingUI) CycleSummary(d ui.CycleSummaryData) {
 	defer r.mu.Unlock()
 	r.cycleSummaries = append(r.cycleSummaries, d)
 }
-func (r *recordingUI) RefactorApplied(id string) {
+func (r *recordingUI) RefactorApplied(id, _, _ string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.refactorIDs = append(r.refactorIDs, id)
@@ -247,6 +265,10 @@ type fakeGit struct {
 	commits    int
 	headErr    error
 	commitErr  error
+	applyErr   error
+	applied    []string // patches passed to ApplyPatch
+	diff       string   // returned by DiffRange
+	diffRange  string   // "base..head" of the last DiffRange call
 }
 
 func (g *fakeGit) HeadSHA(_ context.Context) (string, error) {
@@ -267,14 +289,27 @@ func (g *fakeGit) CommitCycle(_ context.Context, _ string, _ int, _ string) (str
 	return fmt.Sprintf("sha-%d", idx), nil
 }
 
-func (g *fakeGit) DiffRange(_ context.Context, _, _ string) (string, error) {
-	return "", nil
+func (g *fakeGit) DiffRange(_ context.Context, base, head string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.diffRange = base + ".." + head
+	return g.diff, nil
 }
 
 func (g *fakeGit) ResetTo(_ context.Context, _ string) error {
 	return nil
 }
 
+func (g *fakeGit) ApplyPatch(_ context.Context, patch string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.applyErr != nil {
+		return g.applyErr
+	}
+	g.applied = append(g.applied, patch)
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Existing tests (kept as-is)
 // ---------------------------------------------------------------------------
@@ -375,6 +410,52 @@ func TestCheckBudget(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// TestCheckBudgetWarning
+// ---------------------------------------------------------------------------
+
+func TestCheckBudgetWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		fraction  float64
+		costs     []float64
+		wantWarns int
+	}{
+		{"BelowDefaultThreshold", 0, []float64{5.0}, 0},
+		{"AtDefaultThreshold", 0, []float64{8.0}, 1},
+		{"WarnsOnlyOnce", 0, []float64{8.0, 9.0, 9.5}, 1},
+		{"CustomFraction", 0.5, []float64{5.0}, 1},
+		{"DisabledAtOne", 1.0, []float64{9.9}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rUI := &recordingUI{}
+			l := &Loop{
+				MaxBudgetUSD:       10.0,
+				BudgetWarnFraction: tt.fraction,
+				UI:                 rUI,
+			}
+			state := &CycleState{TaskBeadID: "bead-1"}
+			for _, c := range tt.costs {
+				state.TotalCostUSD = c
+				if err := l.checkBudget(context.Background(), state); err != nil {
+					t.Fatalf("checkBudget() unexpected error: %v", err)
+				}
+			}
+			if rUI.budgetWarnCalls != tt.wantWarns {
+				t.Errorf("budgetWarnCalls = %d, want %d", rUI.budgetWarnCalls, tt.wantWarns)
+			}
+			if rUI.budgetCalls != 0 {
+				t.Errorf("budgetCalls = %d, want 0 (soft warning must not hard-stop)", rUI.budgetCalls)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // TestInitCycleState
 // ---------------------------------------------------------------------------
@@ -525,6 +606,41 @@ func TestReviewerAgentWithProjectContext(t *testing.T) {
 	}
 }
 
+func TestAgentToolOverrides(t *testing.T) {
+	t.Parallel()
+
+	l := &Loop{
+		CoderTools:    []string{"Read", "Edit"},
+		ReviewerTools: []string{"Read", "Bash(gosec *)"},
+	}
+	if got := l.coderAgent(1.0).AllowedTools; !reflect.DeepEqual(got, l.CoderTools) {
+		t.Errorf("coder tools = %v, want %v", got, l.CoderTools)
+	}
+	if got := l.reviewerAgent(1.0).AllowedTools; !reflect.DeepEqual(got, l.ReviewerTools) {
+		t.Errorf("reviewer tools = %v, want %v", got, l.ReviewerTools)
+	}
+	if got := (&Loop{}).reviewerAgent(1.0).AllowedTools; len(got) == 0 || got[0] != "Read" {
+		t.Errorf("default reviewer tools = %v, want the built-in set", got)
+	}
+	for _, a := range []agent.Agent{(&Loop{}).coderAgent(1.0), (&Loop{}).reviewerAgent(1.0)} {
+		if !slices.Contains(a.AllowedTools, agent.ScratchpadNoteTool) {
+			t.Errorf("default %s tools = %v, want %q for scratchpad notes", a.Role, a.AllowedTools, agent.ScratchpadNoteTool)
+		}
+	}
+
+	denied := &Loop{DeniedTools: []string{"Bash", "Write"}}
+	for _, a := range []agent.Agent{denied.coderAgent(1.0), denied.reviewerAgent(1.0)} {
+		for _, tool := range a.AllowedTools {
+			if agent.ToolName(tool) == "Bash" || tool == "Write" {
+				t.Errorf("%s allowed denied tool %q", a.Role, tool)
+			}
+		}
+		if !reflect.DeepEqual(a.DeniedTools, denied.DeniedTools) {
+			t.Errorf("%s denied tools = %v, want %v", a.Role, a.DeniedTools, denied.DeniedTools)
+		}
+	}
+}
+
 func TestReviewerAgentWithFabric(t *testing.T) {
 	t.Parallel()
 
@@ -1082,7 +1198,7 @@ func TestCreateFindingBeads(t *testing.T) {
 				{Severity: "minor", Description: "naming"},
 			},
 		}
-		ids := l.createFindingBeads(context.Background(), state)
+		ids := l.createFindingBeads(context.Background(), state.TaskBeadID, state.Findings)
 		if len(ids) != 2 {
 			t.Fatalf("expected 2 IDs, got %d", len(ids))
 		}
@@ -1109,7 +1225,7 @@ func TestCreateFindingBeads(t *testing.T) {
 				{Severity: "major", Description: "bug"},
 			},
 		}
-		ids := l.createFindingBeads(context.Background(), state)
+		ids := l.createFindingBeads(context.Background(), state.TaskBeadID, state.Findings)
 		if len(ids) != 0 {
 			t.Errorf("expected 0 IDs on error, got %d", len(ids))
 		}
@@ -1123,7 +1239,7 @@ func TestCreateFindingBeads(t *testing.T) {
 		nui := &noopUI{}
 		l := &Loop{UI: nui, Hooks: []Hook{newBeadHook(&noopBeads{}, nui)}}
 		state := &CycleState{TaskBeadID: "bead-1"}
-		ids := l.createFindingBeads(context.Background(), state)
+		ids := l.createFindingBeads(context.Background(), state.TaskBeadID, state.Findings)
 		if len(ids) != 0 {
 			t.Errorf("expected 0 IDs for no findings, got %d", len(ids))
 		}
@@ -1297,6 +1413,9 @@ func TestRunLoop(t *testing.T) {
 		if result.CyclesUsed != 2 {
 			t.Errorf("CyclesUsed = %d, want 2", result.CyclesUsed)
 		}
+		if n := len(result.AllFindings); n != 2 || result.AllFindings[n-1].Cycle != 2 {
+			t.Errorf("AllFindings = %+v, want both cycles' findings", result.AllFindings)
+		}
 		if rUI.maxCyclesCalls != 1 {
 			t.Errorf("MaxCyclesReached calls = %d, want 1", rUI.maxCyclesCalls)
 		}
@@ -2213,3 +2332,562 @@ func TestRunLoopWithStruggleDetection(t *testing.T) {
 		}
 	})
 }
+
+func TestRunLoop_ReviewEscalation(t *testing.T) {
+	t.Parallel()
+
+	rejection := "ISSUE:\nSEVERITY: major\nDESCRIPTION: Missing error handling."
+
+	t.Run("second reviewer agrees", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{
+			responses: []agent.InvocationResult{
+				{ResultText: "first attempt"},
+				{ResultText: rejection},
+				{ResultText: "ISSUE:\nSEVERITY: minor\nDESCRIPTION: Unclear variable name."},
+				{ResultText: "second attempt"},
+				{ResultText: "APPROVED: Looks good."},
+			},
+		}
+		l := &Loop{
+			Invoker:                   inv,
+			UI:                        &noopUI{},
+			MaxCycles:                 3,
+			EscalateReviewAfterCycles: 1,
+			EscalationModel:           "other-model",
+			EscalationPrompt:          "second opinion",
+		}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.CyclesUsed != 2 {
+			t.Errorf("CyclesUsed = %d, want 2", result.CyclesUsed)
+		}
+		second := inv.agents[2]
+		if second.Role != agent.RoleReviewer || second.Model != "other-model" || !strings.Contains(second.SystemPrompt, "second opinion") {
+			t.Errorf("second reviewer agent = %+v, want reviewer with escalation model and prompt", second)
+		}
+		// Both reviews' findings reach the next coder cycle.
+		coderPrompt := inv.prompts[3]
+		for _, want := range []string{"Missing error handling", "Unclear variable name"} {
+			if !strings.Contains(coderPrompt, want) {
+				t.Errorf("cycle 2 coder prompt missing %q", want)
+			}
+		}
+	})
+
+	t.Run("second reviewer disagrees", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{
+			responses: []agent.InvocationResult{
+				{ResultText: "first attempt"},
+				{ResultText: rejection},
+				{ResultText: "second attempt"},
+				{ResultText: rejection},
+				{ResultText: "APPROVED: The error handling is adequate."},
+			},
+		}
+		q := NewMemoryHailQueue()
+		l := &Loop{
+			Invoker:                   inv,
+			UI:                        &noopUI{},
+			HailQueue:                 q,
+			MaxCycles:                 5,
+			EscalateReviewAfterCycles: 2,
+		}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if !errors.Is(err, ErrReviewDisagreement) {
+			t.Fatalf("err = %v, want ErrReviewDisagreement", err)
+		}
+		if inv.calls != 5 {
+			t.Errorf("invocations = %d, want 5 (no third coder cycle)", inv.calls)
+		}
+		if result.CyclesUsed != 2 || len(result.AllFindings) != 1 {
+			t.Errorf("result = %+v, want 2 cycles and 1 recorded finding", result)
+		}
+		var found bool
+		for _, h := range q.Unresolved() {
+			if h.Kind == HailDecisionNeeded && strings.Contains(h.Detail, "Missing error handling") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no decision-needed hail with the disputed finding in %+v", q.Unresolved())
+		}
+	})
+}
+
+func TestRunLoop_StopOnDegradingReview(t *testing.T) {
+	t.Parallel()
+
+	review := func(level string) agent.InvocationResult {
+		return agent.InvocationResult{ResultText: "ISSUE:\nSEVERITY: major\nDESCRIPTION: Still broken.\n\nREPORT:\nSATISFACTION: " + level}
+	}
+	responses := []agent.InvocationResult{
+		{ResultText: "attempt 1"}, review("high"),
+		{ResultText: "attempt 2"}, review("medium"),
+		{ResultText: "attempt 3"}, review("low"),
+		{ResultText: "attempt 4"}, {ResultText: "APPROVED: Fine."},
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{responses: responses}
+		q := NewMemoryHailQueue()
+		l := &Loop{
+			Invoker:               inv,
+			UI:                    &noopUI{},
+			HailQueue:             q,
+			MaxCycles:             5,
+			StopOnDegradingReview: true,
+		}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if !errors.Is(err, ErrDegradingReview) {
+			t.Fatalf("err = %v, want ErrDegradingReview", err)
+		}
+		if result.CyclesUsed != 3 || inv.calls != 6 {
+			t.Errorf("cycles = %d, invocations = %d, want 3 and 6", result.CyclesUsed, inv.calls)
+		}
+		if got := strings.Join(result.SatisfactionTrend, ","); got != "high,medium,low" {
+			t.Errorf("SatisfactionTrend = %q, want high,medium,low", got)
+		}
+		var found bool
+		for _, h := range q.Unresolved() {
+			if h.Kind == HailDecisionNeeded && strings.Contains(h.Detail, "high → medium → low") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no decision-needed hail with the trend in %+v", q.Unresolved())
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{responses: responses}
+		l := &Loop{Invoker: inv, UI: &noopUI{}, MaxCycles: 5}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.CyclesUsed != 4 {
+			t.Errorf("CyclesUsed = %d, want 4", result.CyclesUsed)
+		}
+		if got := strings.Join(result.SatisfactionTrend, ","); got != "high,medium,low," {
+			t.Errorf("SatisfactionTrend = %q, want the approving review recorded as unreported", got)
+		}
+	})
+}
+
+func TestRunLoop_RetryOnTestFailure(t *testing.T) {
+	t.Parallel()
+
+	approved := agent.InvocationResult{ResultText: "APPROVED: Looks good."}
+
+	t.Run("failing tests force another cycle", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{responses: []agent.InvocationResult{
+			{ResultText: "attempt 1"}, approved,
+			{ResultText: "attempt 2"}, approved,
+		}}
+		tester := &fakeLinter{outputs: []string{"--- FAIL: TestLogin", ""}}
+		l := &Loop{
+			Invoker:            inv,
+			UI:                 &noopUI{},
+			MaxCycles:          3,
+			Tester:             tester,
+			RetryOnTestFailure: true,
+		}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.CyclesUsed != 2 || tester.calls != 2 {
+			t.Errorf("cycles = %d, test runs = %d, want 2 and 2", result.CyclesUsed, tester.calls)
+		}
+		if !strings.Contains(inv.prompts[2], "--- FAIL: TestLogin") {
+			t.Errorf("second coder prompt does not carry the test failure:\n%s", inv.prompts[2])
+		}
+	})
+
+	t.Run("max cycles still applies", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{responses: []agent.InvocationResult{
+			{ResultText: "attempt 1"}, approved,
+			{ResultText: "attempt 2"}, approved,
+		}}
+		l := &Loop{
+			Invoker:            inv,
+			UI:                 &noopUI{},
+			MaxCycles:          2,
+			Tester:             &fakeLinter{outputs: []string{"FAIL", "FAIL"}},
+			RetryOnTestFailure: true,
+		}
+		result, err := l.runLoop(context.Background(), "bead-1", "task")
+		if !errors.Is(err, ErrMaxCycles) {
+			t.Fatalf("err = %v, want ErrMaxCycles", err)
+		}
+		if result.CyclesUsed != 2 || inv.calls != 4 {
+			t.Errorf("cycles = %d, invocations = %d, want 2 and 4", result.CyclesUsed, inv.calls)
+		}
+	})
+
+	t.Run("disabled ignores the tester", func(t *testing.T) {
+		t.Parallel()
+		inv := &fakeInvoker{responses: []agent.InvocationResult{{ResultText: "attempt 1"}, approved}}
+		tester := &fakeLinter{outputs: []string{"FAIL"}}
+		l := &Loop{Invoker: inv, UI: &noopUI{}, MaxCycles: 3, Tester: tester}
+		if _, err := l.runLoop(context.Background(), "bead-1", "task"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tester.calls != 0 {
+			t.Errorf("tester ran %d times with RetryOnTestFailure off", tester.calls)
+		}
+	})
+}
+
+func TestRunLoop_OutputDir(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "logs", "phase-a")
+	inv := &fakeInvoker{responses: []agent.InvocationResult{
+		{ResultText: "first attempt"}, {ResultText: "ISSUE:\nSEVERITY: major\nDESCRIPTION: missing test"},
+		{ResultText: "second attempt"}, {ResultText: "APPROVED: Looks good."},
+	}}
+	l := &Loop{Invoker: inv, UI: &noopUI{}, MaxCycles: 3, OutputDir: dir}
+	if _, err := l.runLoop(context.Background(), "bead-1", "task"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"cycle1-coder.txt":    "first attempt",
+		"cycle1-reviewer.txt": "missing test",
+		"cycle2-coder.txt":    "second attempt",
+		"cycle2-reviewer.txt": "APPROVED",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !strings.Contains(string(data), want) || !strings.Contains(string(data), "started") {
+			t.Errorf("%s = %q, want the start marker and %q", name, data, want)
+		}
+	}
+}
+
+// streamingInvoker reports each of its messages as output before returning
+// the last one as the result.
+type streamingInvoker struct {
+	messages []string
+	seen     []string // contents of the output log when each message was reported
+	logPath  string
+}
+
+func (s *streamingInvoker) Invoke(ctx context.Context, _ agent.Agent, _ string, _ string) (agent.InvocationResult, error) {
+	for _, m := range s.messages {
+		agent.ReportOutput(ctx, m)
+		data, _ := os.ReadFile(s.logPath)
+		s.seen = append(s.seen, string(data))
+	}
+	return agent.InvocationResult{ResultText: s.messages[len(s.messages)-1]}, nil
+}
+
+func (s *streamingInvoker) Validate() error { return nil }
+
+func TestRunLoop_OutputDirStreams(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	inv := &streamingInvoker{
+		messages: []string{"reading files", "→ Bash", "done editing"},
+		logPath:  filepath.Join(dir, "cycle1-coder.txt"),
+	}
+	l := &Loop{Invoker: inv, UI: &noopUI{}, MaxCycles: 1, OutputDir: dir, SkipReview: true}
+	if _, err := l.runLoop(context.Background(), "bead-1", "task"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, m := range inv.messages {
+		if !strings.Contains(inv.seen[i], m) {
+			t.Errorf("message %q was not in the log while the agent ran:\n%s", m, inv.seen[i])
+		}
+	}
+	data, err := os.ReadFile(inv.logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "done editing"); n != 1 {
+		t.Errorf("final message logged %d times, want once:\n%s", n, data)
+	}
+}
+
+func TestAppendOutputLogWrapsErrors(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := appendOutputLog(filepath.Join(file, "logs"), 1, "coder", "text")
+	if err == nil || !strings.Contains(err.Error(), "creating") {
+		t.Fatalf("appendOutputLog under a file = %v, want a wrapped creating error", err)
+	}
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("error %v does not wrap the underlying *os.PathError", err)
+	}
+}
+
+func TestRunLoopSkipReview(t *testing.T) {
+	t.Parallel()
+
+	rUI := &recordingUI{}
+	rb := newRecordingBeads()
+	git := &fakeGit{headSHA: "base", commitSHAs: []string{"c1"}}
+	inv := &fakeInvoker{
+		responses: []agent.InvocationResult{
+			{ResultText: "reformatted files", CostUSD: 0.40},
+		},
+	}
+	l := &Loop{
+		Invoker:      inv,
+		UI:           rUI,
+		Git:          git,
+		Hooks:        []Hook{newBeadHook(rb, rUI)},
+		MaxCycles:    3,
+		MaxBudgetUSD: 10.0,
+		SkipReview:   true,
+	}
+	result, err := l.runLoop(context.Background(), "bead-1", "run gofmt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.calls != 1 || inv.agents[0].Role != agent.RoleCoder {
+		t.Errorf("invocations = %d (%v), want only the coder", inv.calls, inv.agents)
+	}
+	if result.CyclesUsed != 1 || result.TotalCostUSD != 0.40 || result.FinalCommitSHA != "c1" {
+		t.Errorf("result = %+v, want one committed cycle costing 0.40", result)
+	}
+	if rUI.approvedCalls != 1 {
+		t.Errorf("Approved calls = %d, want 1", rUI.approvedCalls)
+	}
+	if len(rb.closes) != 1 || rb.closes[0] != "Completed without review (skip_review)" {
+		t.Errorf("closes = %v, want the bead closed without review", rb.closes)
+	}
+}
+
+// overridingInvoker signals override while the reviewer runs, as a human
+// pressing the override key mid-review would.
+type overridingInvoker struct {
+	*fakeInvoker
+	override chan<- struct{}
+}
+
+func (o *overridingInvoker) Invoke(ctx context.Context, a agent.Agent, prompt, workDir string) (agent.InvocationResult, error) {
+	if a.Role == agent.RoleReviewer {
+		o.override <- struct{}{}
+	}
+	return o.fakeInvoker.Invoke(ctx, a, prompt, workDir)
+}
+
+func TestRunLoopForceApprove(t *testing.T) {
+	t.Parallel()
+
+	rUI := &recordingUI{}
+	rb := newRecordingBeads()
+	ch := make(chan struct{}, 1)
+	inv := &overridingInvoker{
+		fakeInvoker: &fakeInvoker{
+			responses: []agent.InvocationResult{
+				{ResultText: "implemented feature", CostUSD: 0.50},
+				{ResultText: "ISSUE:\nSEVERITY: major\nDESCRIPTION: naming is off", CostUSD: 0.25},
+			},
+		},
+		override: ch,
+	}
+	l := &Loop{
+		Invoker:        inv,
+		UI:             rUI,
+		Hooks:          []Hook{newBeadHook(rb, rUI)},
+		MaxCycles:      3,
+		MaxBudgetUSD:   10.0,
+		ForceApproveCh: ch,
+	}
+	result, err := l.runLoop(context.Background(), "bead-1", "implement feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.calls != 2 {
+		t.Errorf("invocations = %d, want the coder and the rejecting reviewer only", inv.calls)
+	}
+	if !result.ForceApproved || result.CyclesUsed != 1 {
+		t.Errorf("result = %+v, want a force-approved first cycle", result)
+	}
+	if len(rb.closes) != 1 || rb.closes[0] != "Force-approved by a human over the reviewer" {
+		t.Errorf("closes = %v, want the bead closed as overridden", rb.closes)
+	}
+
+	// A loop without ForceApproveCh is never overridden.
+	l = &Loop{Invoker: &fakeInvoker{}, UI: &recordingUI{}}
+	if l.forceApproveRequested(&CycleState{}) {
+		t.Error("force approval requested without a channel")
+	}
+}
+
+// extendingInvoker grants extra cycles during the first review, as a human
+// raising the cycle limit of a phase about to hit it would.
+type extendingInvoker struct {
+	*fakeInvoker
+	extend   chan<- int
+	reviewed bool
+}
+
+func (e *extendingInvoker) Invoke(ctx context.Context, a agent.Agent, prompt, workDir string) (agent.InvocationResult, error) {
+	if a.Role == agent.RoleReviewer && !e.reviewed {
+		e.reviewed = true
+		e.extend <- 2
+	}
+	return e.fakeInvoker.Invoke(ctx, a, prompt, workDir)
+}
+
+func TestRunLoopExtendCycles(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, 1)
+	inv := &extendingInvoker{
+		fakeInvoker: &fakeInvoker{
+			responses: []agent.InvocationResult{
+				{ResultText: "attempt 1", CostUSD: 0.20},
+				{ResultText: "ISSUE:\nSEVERITY: major\nDESCRIPTION: Still broken.", CostUSD: 0.15},
+				{ResultText: "attempt 2", CostUSD: 0.20},
+				{ResultText: "APPROVED: Looks good.", CostUSD: 0.15},
+			},
+		},
+		extend: ch,
+	}
+	l := &Loop{
+		Invoker:        inv,
+		UI:             &recordingUI{},
+		MaxCycles:      1,
+		MaxBudgetUSD:   10.0,
+		ExtendCyclesCh: ch,
+	}
+	result, err := l.runLoop(context.Background(), "bead-1", "fix bug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CyclesUsed != 2 {
+		t.Errorf("CyclesUsed = %d, want the granted second cycle to approve", result.CyclesUsed)
+	}
+
+	// Non-positive grants are ignored.
+	ch <- -3
+	state := &CycleState{MaxCycles: 4}
+	if got := l.cycleCap(state); got != 4 {
+		t.Errorf("cycleCap = %d, want 4", got)
+	}
+}
+
+func TestRunLoop_StopOnNoProgress(t *testing.T) {
+	t.Parallel()
+
+	reject := agent.InvocationResult{ResultText: "ISSUE:\nSEVERITY: major\nDESCRIPTION: Still broken.\n"}
+	diff := "--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-old\n+new\n"
+	tests := []struct {
+		name       string
+		git        *fakeGit
+		enabled    bool
+		wantErr    error
+		wantCycles int
+		wantReason string
+	}{
+		{
+			name:       "no changes for two cycles",
+			git:        &fakeGit{headSHA: "base", commitSHAs: []string{"base", "base", "base", "base"}},
+			enabled:    true,
+			wantErr:    ErrNoProgress,
+			wantCycles: 2,
+			wantReason: "cycle 2 changed no code",
+		},
+		{
+			name:       "same change repeated",
+			git:        &fakeGit{headSHA: "base", diff: diff},
+			enabled:    true,
+			wantErr:    ErrNoProgress,
+			wantCycles: 3,
+			wantReason: "cycle 3 repeated the previous cycle's change",
+		},
+		{
+			name:       "disabled",
+			git:        &fakeGit{headSHA: "base", commitSHAs: []string{"base", "base", "base", "base"}},
+			wantErr:    ErrMaxCycles,
+			wantCycles: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var responses []agent.InvocationResult
+			for i := 0; i < 4; i++ {
+				responses = append(responses, agent.InvocationResult{ResultText: "attempt"}, reject)
+			}
+			q := NewMemoryHailQueue()
+			l := &Loop{
+				Invoker:          &fakeInvoker{responses: responses},
+				UI:               &noopUI{},
+				Git:              tt.git,
+				HailQueue:        q,
+				MaxCycles:        4,
+				StopOnNoProgress: tt.enabled,
+			}
+			result, err := l.runLoop(context.Background(), "bead-1", "task")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if result.CyclesUsed != tt.wantCycles {
+				t.Errorf("CyclesUsed = %d, want %d", result.CyclesUsed, tt.wantCycles)
+			}
+			if tt.wantReason == "" {
+				return
+			}
+			if !strings.Contains(err.Error(), tt.wantReason) {
+				t.Errorf("err = %q, want reason %q", err, tt.wantReason)
+			}
+			var found bool
+			for _, h := range q.Unresolved() {
+				if h.Kind == HailDecisionNeeded && strings.Contains(h.Detail, tt.wantReason) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("no decision-needed hail with the reason in %+v", q.Unresolved())
+			}
+		})
+	}
+}
+
+func TestDiffSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"identical", "+a\n-b\n", "+a\n-b\n", 1},
+		{"both empty", "", "", 1},
+		{"disjoint", "+a\n", "+b\n", 0},
+		{"half shared", "+a\n+b\n", "+a\n+c\n", 0.5},
+		{"file headers ignored", "--- a/x\n+++ b/x\n+a\n", "--- a/y\n+++ b/y\n+a\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := diffSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("diffSimilarity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,7 +12,8 @@ import (
 
 // buildCoderPrompt constructs the prompt sent to the coder agent for a given
 // cycle. On the first cycle it provides the task description; on subsequent
-// cycles it includes the reviewer's findings for the coder to address.
+// cycles it includes the reviewer's findings for the coder to address. A
+// CoderTemplate, when set, replaces this format.
 func (l *Loop) buildCoderPrompt(state *CycleState) string {
 	var b strings.Builder
 
@@ -26,21 +27,21 @@ func (l *Loop) buildCoderPrompt(state *CycleState) string {
 		return b.String()
 	}
 
+	if l.CoderTemplate != nil {
+		if prompt, ok := l.renderCoderTemplate(state); ok {
+			return prompt
+		}
+	}
+
+	fmt.Fprintf(&b, "Task (bead %s): %s\n\n", state.TaskBeadID, state.TaskTitle)
 	if state.Cycle == 1 {
-		fmt.Fprintf(&b, "Task (bead %s): %s\n\n", state.TaskBeadID, state.TaskTitle)
 		b.WriteString("Implement this task. Read existing code first to understand the codebase, then make the necessary changes.")
 	} else {
-		fmt.Fprintf(&b, "Task (bead %s): %s\n\n", state.TaskBeadID, state.TaskTitle)
 		b.WriteString("The reviewer found issues with your previous implementation. Please address them:\n\n")
-		// Filter out findings already marked as fixed so the coder only
-		// works on unresolved issues.
-		n := 0
-		for _, f := range state.Findings {
-			if f.Status == FindingStatusFixed {
-				continue
-			}
-			n++
-			fmt.Fprintf(&b, "%d. [%s] %s\n", n, f.Severity, f.Description)
+		b.WriteString(openFindingsList(state.Findings))
+		if len(state.Suggestions) > 0 {
+			b.WriteString("\n")
+			b.WriteString(buildSuggestionsBlock(state.Suggestions))
 		}
 		b.WriteString("\nFix these issues. Read the relevant files to understand current state before making changes.")
 	}
@@ -114,11 +115,20 @@ func (l *Loop) buildReviewerPrompt(state *CycleState) string {
 		b.WriteString(truncate(state.LintOutput, 2000))
 	}
 
+	if state.reviewDiff != "" {
+		b.WriteString("\n\n")
+		b.WriteString(buildDiffBlock(state.reviewDiff, maxReviewDiffBytes))
+	}
+
 	b.WriteString("\n\nREVIEW INSTRUCTIONS:\n")
-	b.WriteString("1. READ THE ACTUAL SOURCE FILES to verify the changes — do not rely solely on the summary above.\n")
-	b.WriteString("2. Check for correctness, security, error handling, code quality, and edge cases.\n")
-	b.WriteString("3. Check for any linting issues (`go vet`, `go fmt`). If linting problems exist, flag them as issues for the coder to fix.\n")
-	b.WriteString("4. End your review with either APPROVED: or one or more ISSUE: blocks.\n")
+	if l.diffOnlyReview(state) {
+		b.WriteString(diffOnlyReviewInstructions)
+	} else {
+		b.WriteString("1. READ THE ACTUAL SOURCE FILES to verify the changes — do not rely solely on the summary above.\n")
+		b.WriteString("2. Check for correctness, security, error handling, code quality, and edge cases.\n")
+		b.WriteString("3. Check for any linting issues (`go vet`, `go fmt`). If linting problems exist, flag them as issues for the coder to fix.\n")
+		b.WriteString("4. End your review with either APPROVED: or one or more ISSUE: blocks.\n")
+	}
 
 	// Inject prior findings for verification when this is not the first cycle.
 	if len(state.AllFindings) > 0 {
@@ -129,6 +139,88 @@ func (l *Loop) buildReviewerPrompt(state *CycleState) string {
 	return b.String()
 }
 
+// maxReviewDiffBytes caps the code diff included in the reviewer prompt.
+const maxReviewDiffBytes = 24000
+
+// diffOnlyReviewInstructions replace the review instructions when the
+// reviewer works from the diff alone (Loop.ReviewDiffOnly).
+const diffOnlyReviewInstructions = `1. Review the CODE DIFF above; it is the complete change. Do not re-read whole files the diff already shows.
+2. Open a source file only to check code the diff depends on but does not show, such as a called function's contract or a caller of a changed signature.
+3. Check for correctness, security, error handling, code quality, and edge cases in the changed lines, and flag linting problems (` + "`go vet`, `go fmt`" + `).
+4. End your review with either APPROVED: or one or more ISSUE: blocks. Name the file of any issue outside the diff so the next review reads it in full.
+`
+
+// diffOnlyReview reports whether this cycle's review can work from the
+// diff alone: ReviewDiffOnly is set, the diff fits in the prompt whole,
+// and no open finding names a file the diff does not touch.
+func (l *Loop) diffOnlyReview(state *CycleState) bool {
+	if !l.ReviewDiffOnly || state.reviewDiff == "" || len(state.reviewDiff) > maxReviewDiffBytes {
+		return false
+	}
+	return !openFindingsOutside(state.AllFindings, summarizeDiff(state.reviewDiff))
+}
+
+// reviewDiff returns the unified diff of the task's changes so far for the
+// reviewer. It is empty unless IncludeDiffInReview or ReviewDiffOnly is set.
+func (l *Loop) reviewDiff(ctx context.Context, state *CycleState) string {
+	if !l.IncludeDiffInReview && !l.ReviewDiffOnly {
+		return ""
+	}
+	return l.taskDiff(ctx, state)
+}
+
+// taskDiff returns the unified diff of the task's changes so far, from its
+// base commit to the current cycle's commit, for the reviewer's diff and
+// the coder template's Diff. It is empty unless Git is configured; a failed
+// diff is reported and leaves the prompt without one.
+func (l *Loop) taskDiff(ctx context.Context, state *CycleState) string {
+	if l.Git == nil || state.BaseCommitSHA == "" {
+		return ""
+	}
+	head := state.lastCycleSHA
+	if head == "" {
+		sha, err := l.Git.HeadSHA(ctx)
+		if err != nil {
+			l.UI.Error(fmt.Sprintf("failed to read HEAD for the task diff: %v", err))
+			return ""
+		}
+		head = sha
+	}
+	if head == state.BaseCommitSHA {
+		return ""
+	}
+	diff, err := l.Git.DiffRange(ctx, state.BaseCommitSHA, head)
+	if err != nil {
+		l.UI.Error(fmt.Sprintf("failed to diff cycle %d: %v", state.Cycle, err))
+		return ""
+	}
+	return diff
+}
+
+// buildDiffBlock renders the code diff section of the reviewer prompt. A
+// diff longer than limit is cut, at a file boundary where one is close, and
+// led by a per-file summary so the reviewer knows which files to read.
+func buildDiffBlock(diff string, limit int) string {
+	var b strings.Builder
+	b.WriteString("CODE DIFF (base commit to this cycle):\n")
+	if len(diff) > limit {
+		fmt.Fprintf(&b, "The diff is %d bytes, too large to include whole. Files changed:\n", len(diff))
+		for _, f := range summarizeDiff(diff) {
+			fmt.Fprintf(&b, "  %s +%d -%d\n", f.path, f.added, f.removed)
+		}
+		cut := strings.LastIndex(diff[:limit], "\ndiff --git ")
+		if cut < limit/2 {
+			cut = strings.LastIndex(diff[:limit], "\n")
+		}
+		diff = diff[:cut+1]
+		fmt.Fprintf(&b, "The first %d bytes follow; read the remaining changes from the source files.\n", len(diff))
+	}
+	b.WriteString("```diff\n")
+	b.WriteString(strings.TrimRight(diff, "\n"))
+	b.WriteString("\n```")
+	return b.String()
+}
+
 // buildPriorFindingsBlock constructs the prior-findings section injected into
 // the reviewer prompt on cycles > 1. It serializes all accumulated findings
 // and adds explicit instructions for the reviewer to verify each one.
@@ -148,6 +240,28 @@ func buildPriorFindingsBlock(findings []ReviewFinding) string {
 	return b.String()
 }
 
+// buildSuggestionsBlock renders the reviewer's patch suggestions for the
+// coder prompt. Suggestions already applied to the working tree are listed
+// for verification; the rest are offered as patches to apply or adapt.
+func buildSuggestionsBlock(suggestions []Suggestion) string {
+	var b strings.Builder
+	b.WriteString("[REVIEWER SUGGESTIONS]\n")
+	b.WriteString("The reviewer proposed these patches. Apply them with `git apply` if they are correct, or make equivalent edits.\n")
+	for i, s := range suggestions {
+		fmt.Fprintf(&b, "\nSuggestion %d (%s)", i+1, s.File)
+		switch {
+		case s.Applied:
+			b.WriteString(" — already applied to the working tree; verify it")
+		case s.Overlaps:
+			b.WriteString(" — overlaps an earlier suggestion; reconcile before applying")
+		}
+		b.WriteString(":\n```diff\n")
+		b.WriteString(s.Patch)
+		b.WriteString("```\n")
+	}
+	return b.String()
+}
+
 // PrependFabricContext adds current entanglements, claims, and pulses to the
 // task description so the agent starts with full coordination context rather
 // than needing to query fabric state as its first action.
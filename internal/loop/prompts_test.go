@@ -1,8 +1,12 @@
 package loop
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/papapumpkin/quasar/internal/agent"
 )
 
 func TestBuildReviewerPrompt_NoPriorFindings(t *testing.T) {
@@ -216,3 +220,193 @@ func TestBuildReviewerPrompt_WithLintOutput(t *testing.T) {
 		t.Error("expected [PRIOR FINDINGS] block after lint output")
 	}
 }
+
+func TestBuildReviewerPrompt_WithDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	diff := "diff --git a/foo.go b/foo.go\n--- a/foo.go\n+++ b/foo.go\n@@ -1 +1 @@\n-old\n+new\n"
+	tests := []struct {
+		name     string
+		include  bool
+		git      *fakeGit
+		wantDiff bool
+	}{
+		{"opted in", true, &fakeGit{diff: diff}, true},
+		{"off by default", false, &fakeGit{diff: diff}, false},
+		{"no git", true, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := &Loop{IncludeDiffInReview: tt.include, UI: &noopUI{}}
+			if tt.git != nil {
+				l.Git = tt.git
+			}
+			state := &CycleState{TaskBeadID: "b", Cycle: 2, BaseCommitSHA: "base", lastCycleSHA: "cycle2"}
+			state.reviewDiff = l.reviewDiff(ctx, state)
+			prompt := l.buildReviewerPrompt(state)
+			if got := strings.Contains(prompt, "+new"); got != tt.wantDiff {
+				t.Errorf("diff in prompt = %v, want %v:\n%s", got, tt.wantDiff, prompt)
+			}
+			if tt.wantDiff && tt.git.diffRange != "base..cycle2" {
+				t.Errorf("diffed %q, want base..cycle2", tt.git.diffRange)
+			}
+		})
+	}
+}
+
+func TestBuildReviewerPrompt_DiffOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	diff := "diff --git a/internal/foo/foo.go b/internal/foo/foo.go\n--- a/internal/foo/foo.go\n+++ b/internal/foo/foo.go\n@@ -1 +1 @@\n-old\n+new\n"
+	tests := []struct {
+		name     string
+		diff     string
+		findings []ReviewFinding
+		wantOnly bool
+	}{
+		{"diff only", diff, nil, true},
+		{"finding inside the diff", diff, []ReviewFinding{{ID: "f1", Description: "foo.go ignores the error"}}, true},
+		{"finding outside the diff", diff, []ReviewFinding{{ID: "f1", Description: "bar.go still calls the old API"}}, false},
+		{"selectors are not files", diff, []ReviewFinding{{ID: "f1", Description: "ctx.Err and wg.mu are misused, e.g. around os.Open"}}, true},
+		{"fixed finding outside the diff", diff, []ReviewFinding{{ID: "f1", Description: "bar.go", Status: FindingStatusFixed}}, true},
+		{"diff too large to include whole", diff + strings.Repeat("+x\n", maxReviewDiffBytes), nil, false},
+		{"no diff", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := &Loop{ReviewDiffOnly: true, UI: &noopUI{}, Git: &fakeGit{diff: tt.diff}}
+			state := &CycleState{TaskBeadID: "b", Cycle: 2, BaseCommitSHA: "base", lastCycleSHA: "cycle2", AllFindings: tt.findings}
+			state.reviewDiff = l.reviewDiff(ctx, state)
+			prompt := l.buildReviewerPrompt(state)
+			if got := strings.Contains(prompt, "it is the complete change"); got != tt.wantOnly {
+				t.Errorf("diff-only instructions = %v, want %v:\n%s", got, tt.wantOnly, prompt)
+			}
+			if got := strings.Contains(prompt, "READ THE ACTUAL SOURCE FILES"); got == tt.wantOnly {
+				t.Errorf("full-file instructions = %v, want %v", got, !tt.wantOnly)
+			}
+		})
+	}
+}
+
+func TestBuildDiffBlock_Truncates(t *testing.T) {
+	t.Parallel()
+
+	file := func(name string, lines int) string {
+		var b strings.Builder
+		fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n@@ -1 +1,%d @@\n-gone\n", name, name, name, name, lines)
+		for i := range lines {
+			fmt.Fprintf(&b, "+line %d\n", i)
+		}
+		return b.String()
+	}
+	diff := file("a.go", 40) + file("b.go", 40)
+
+	if got := buildDiffBlock(diff, len(diff)); strings.Contains(got, "too large") || !strings.Contains(got, "b.go") {
+		t.Errorf("diff within the limit should be included whole:\n%s", got)
+	}
+
+	got := buildDiffBlock(diff, len(diff)-10)
+	for _, want := range []string{"too large", "a.go +40 -1", "b.go +40 -1", "+line 39"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("truncated block missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "diff --git a/b.go") {
+		t.Errorf("truncated block should stop at the b.go file boundary:\n%s", got)
+	}
+}
+
+func TestBuildCoderPrompt_Template(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseCoderTemplate("Cycle {{.Cycle}}/{{.MaxCycles}} on {{.BeadID}}: {{.Title}}\n{{.Findings}}{{if .Diff}}DIFF:\n{{.Diff}}{{end}}")
+	if err != nil {
+		t.Fatalf("ParseCoderTemplate: %v", err)
+	}
+	tests := []struct {
+		name  string
+		state *CycleState
+		want  string
+	}{
+		{
+			name:  "first cycle",
+			state: &CycleState{TaskBeadID: "b-1", TaskTitle: "Fix the widget", Cycle: 1, MaxCycles: 3},
+			want:  "Cycle 1/3 on b-1: Fix the widget\n",
+		},
+		{
+			name: "later cycle lists open findings and the diff",
+			state: &CycleState{
+				TaskBeadID: "b-1", TaskTitle: "Fix the widget", Cycle: 2, MaxCycles: 3,
+				Findings: []ReviewFinding{
+					{Severity: "major", Description: "nil check missing"},
+					{Severity: "minor", Description: "typo", Status: FindingStatusFixed},
+				},
+				coderDiff: "+x",
+			},
+			want: "Cycle 2/3 on b-1: Fix the widget\n1. [major] nil check missing\nDIFF:\n+x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := &Loop{UI: &noopUI{}, CoderTemplate: tmpl}
+			if got := l.buildCoderPrompt(tt.state); got != tt.want {
+				t.Errorf("prompt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCoderPhase_TemplateDiffWithoutReviewDiff(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseCoderTemplate("{{.Title}}\n{{.Diff}}")
+	if err != nil {
+		t.Fatalf("ParseCoderTemplate: %v", err)
+	}
+	git := &fakeGit{headSHA: "cycle1", diff: "+new\n"}
+	inv := &fakeInvoker{responses: []agent.InvocationResult{{ResultText: "done"}}}
+	l := &Loop{Invoker: inv, UI: &noopUI{}, Git: git, MaxCycles: 3, CoderTemplate: tmpl}
+	state := &CycleState{TaskBeadID: "b", TaskTitle: "task", Cycle: 2, MaxCycles: 3, BaseCommitSHA: "base"}
+	if err := l.runCoderPhase(context.Background(), state, 1.0); err != nil {
+		t.Fatalf("runCoderPhase: %v", err)
+	}
+	if len(inv.prompts) != 1 || !strings.Contains(inv.prompts[0], "+new") {
+		t.Errorf("coder prompt = %q, want the cycle diff", inv.prompts)
+	}
+	if git.diffRange != "base..cycle1" {
+		t.Errorf("diffed %q, want base..cycle1", git.diffRange)
+	}
+}
+
+func TestParseCoderTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		wantNil bool
+		wantErr bool
+	}{
+		{"blank is the built-in format", "  \n", true, false},
+		{"all placeholders", "{{.Title}}{{.BeadID}}{{.Cycle}}{{.MaxCycles}}{{.Findings}}{{.Suggestions}}{{.Diff}}", false, false},
+		{"syntax error", "{{if .Cycle}}", true, true},
+		{"unknown placeholder", "{{.Description}}", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := ParseCoderTemplate(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (tmpl == nil) != tt.wantNil {
+				t.Errorf("template nil = %v, want %v", tmpl == nil, tt.wantNil)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package loop
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 )
@@ -40,6 +41,9 @@ func parseReportBlock(lines []string) (*agent.ReviewReport, bool) {
 		case strings.HasPrefix(line, "RISK:"):
 			report.Risk = parseField(line, "RISK:")
 			found = true
+		case strings.HasPrefix(line, "CONFIDENCE:"):
+			report.Confidence = parseField(line, "CONFIDENCE:")
+			found = true
 		case strings.HasPrefix(line, "NEEDS_HUMAN_REVIEW:"):
 			val := parseField(line, "NEEDS_HUMAN_REVIEW:")
 			report.NeedsHumanReview = val == "yes" || val == "true"
@@ -54,10 +58,17 @@ func parseReportBlock(lines []string) (*agent.ReviewReport, bool) {
 	return report, found
 }
 
-// parseField extracts and normalizes a field value by trimming the prefix,
-// whitespace, and converting to lowercase.
+// parseField extracts a field's value: the first word after the prefix,
+// lowercased. Reviewers often explain a level on the same line, as in
+// "low — couldn't run the tests", and only the level itself is kept.
 func parseField(line, prefix string) string {
-	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, prefix)))
+	words := strings.FieldsFunc(strings.ToLower(strings.TrimPrefix(line, prefix)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
 }
 
 // FormatReportComment formats a ReviewReport as a beads comment string.
@@ -66,6 +77,46 @@ func FormatReportComment(r *agent.ReviewReport) string {
 	if r.NeedsHumanReview {
 		humanReview = "yes"
 	}
-	return fmt.Sprintf("[reviewer report]\nSatisfaction: %s\nRisk: %s\nNeeds human review: %s\nSummary: %s",
-		r.Satisfaction, r.Risk, humanReview, r.Summary)
+	var b strings.Builder
+	fmt.Fprintf(&b, "[reviewer report]\nSatisfaction: %s\nRisk: %s\n", r.Satisfaction, r.Risk)
+	if r.Confidence != "" {
+		fmt.Fprintf(&b, "Confidence: %s\n", r.Confidence)
+	}
+	fmt.Fprintf(&b, "Needs human review: %s\nSummary: %s", humanReview, r.Summary)
+	return b.String()
+}
+
+// satisfactionRank orders reviewer satisfaction levels from low (1) to
+// high (3). Unreported or unrecognized levels rank 0.
+func satisfactionRank(level string) int {
+	switch level {
+	case "low":
+		return 1
+	case "medium":
+		return 2
+	case "high":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// satisfactionDegrading reports whether the last three entries of trend
+// show satisfaction dropping twice in a row. Unreported levels break the
+// streak.
+func satisfactionDegrading(trend []string) bool {
+	if len(trend) < 3 {
+		return false
+	}
+	a, b, c := satisfactionRank(trend[len(trend)-3]), satisfactionRank(trend[len(trend)-2]), satisfactionRank(trend[len(trend)-1])
+	return c > 0 && a > b && b > c
+}
+
+// satisfactionOf returns the satisfaction level from the reviewer's report
+// in output, or "" when it has none.
+func satisfactionOf(output string) string {
+	if report := ParseReviewReport(output); report != nil {
+		return report.Satisfaction
+	}
+	return ""
 }
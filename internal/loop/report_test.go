@@ -15,6 +15,7 @@ func TestParseReviewReport(t *testing.T) {
 		wantSatisfaction string
 		wantRisk         string
 		wantHumanReview  bool
+		wantConfidence   string
 		wantSummary      bool // true if summary should be non-empty
 	}{
 		{
@@ -64,6 +65,35 @@ SUMMARY: Acceptable implementation with minor style concerns.`,
 			wantRisk:         "medium",
 			wantHumanReview:  false,
 		},
+		{
+			name: "Confidence",
+			input: `APPROVED: Probably fine.
+
+REPORT:
+SATISFACTION: medium
+RISK: low
+CONFIDENCE: Low
+NEEDS_HUMAN_REVIEW: no
+SUMMARY: Could not run the migration locally.`,
+			wantSatisfaction: "medium",
+			wantRisk:         "low",
+			wantConfidence:   "low",
+			wantSummary:      true,
+		},
+		{
+			name: "TrailingCommentary",
+			input: `REPORT:
+SATISFACTION: high.
+RISK: medium - touches the auth path
+CONFIDENCE: low — couldn't run the tests
+NEEDS_HUMAN_REVIEW: yes, the migration is irreversible
+SUMMARY: Looks right but unverified.`,
+			wantSatisfaction: "high",
+			wantRisk:         "medium",
+			wantConfidence:   "low",
+			wantHumanReview:  true,
+			wantSummary:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +118,9 @@ SUMMARY: Acceptable implementation with minor style concerns.`,
 			if report.Risk != tt.wantRisk {
 				t.Errorf("expected risk %q, got %q", tt.wantRisk, report.Risk)
 			}
+			if report.Confidence != tt.wantConfidence {
+				t.Errorf("Confidence = %q, want %q", report.Confidence, tt.wantConfidence)
+			}
 			if report.NeedsHumanReview != tt.wantHumanReview {
 				t.Errorf("NeedsHumanReview = %v, want %v", report.NeedsHumanReview, tt.wantHumanReview)
 			}
@@ -115,4 +148,39 @@ func TestFormatReportComment(t *testing.T) {
 	if !strings.Contains(comment, "Needs human review: no") {
 		t.Errorf("expected human review in comment, got %q", comment)
 	}
+	if strings.Contains(comment, "Confidence") {
+		t.Errorf("comment without a confidence mentions one: %q", comment)
+	}
+
+	r.Confidence = "low"
+	want := "[reviewer report]\nSatisfaction: high\nRisk: low\nConfidence: low\nNeeds human review: no\nSummary: All good."
+	if got := FormatReportComment(r); got != want {
+		t.Errorf("FormatReportComment = %q, want %q", got, want)
+	}
+}
+
+func TestSatisfactionDegrading(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		trend []string
+		want  bool
+	}{
+		{"too short", []string{"high", "medium"}, false},
+		{"two drops", []string{"high", "medium", "low"}, true},
+		{"two drops after a rise", []string{"low", "high", "medium", "low"}, true},
+		{"one drop then flat", []string{"high", "medium", "medium"}, false},
+		{"recovering", []string{"high", "low", "medium"}, false},
+		{"unreported breaks the streak", []string{"high", "", "low"}, false},
+		{"unreported latest", []string{"high", "medium", ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := satisfactionDegrading(tt.trend); got != tt.want {
+				t.Errorf("satisfactionDegrading(%q) = %v, want %v", tt.trend, got, tt.want)
+			}
+		})
+	}
 }
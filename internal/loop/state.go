@@ -91,8 +91,10 @@ type CycleState struct {
 	FilterOutput        string // output from pre-reviewer filter on failure
 	FilterCheckName     string // name of the failing filter check (empty if passed)
 	ReviewOutput        string
+	SecondReviewOutput  string                // escalation reviewer's output for the current cycle (empty unless escalated)
 	Findings            []ReviewFinding       // current cycle's findings (reset each cycle)
 	Verifications       []FindingVerification // current cycle's verification results
+	Suggestions         []Suggestion          // reviewer's patch suggestions from the latest review
 	AllFindings         []ReviewFinding       // accumulated findings across all cycles
 	ChildBeadIDs        []string              // accumulated child bead IDs across all cycles
 	Refactored          bool                  // true when a mid-run phase edit was applied
@@ -101,6 +103,15 @@ type CycleState struct {
 	BaseCommitSHA       string                // HEAD before first cycle (captured at task start)
 	FilterHistory       []string              // accumulated FilterCheckName per cycle (index = cycle-1)
 	CycleCommits        []string              // commit SHA per cycle (index = cycle-1)
+	SatisfactionTrend   []string              // reviewer satisfaction per reviewed cycle, oldest first ("" when unreported)
 	lastCycleSHA        string                // transient: last commit SHA for the current cycle (sealed into CycleCommits at cycle end)
+	reviewDiff          string                // transient: code diff shown to the reviewer this cycle (IncludeDiffInReview)
+	coderDiff           string                // transient: code diff so far, for the coder's CoderTemplate
+	lastCycleDiff       string                // the previous cycle's own diff (StopOnNoProgress)
+	stalledCycles       int                   // consecutive cycles that made no progress (StopOnNoProgress)
 	bridgedDiscoveryIDs map[int64]bool        // tracks fabric discovery IDs already bridged to hails, preventing duplicates across cycles
+	findingBeads        map[string][]string   // child bead IDs keyed by FindingID, so recurring findings are commented on instead of re-beaded
+	budgetWarned        bool                  // true once the soft budget warning has been emitted
+	rejectedCycles      int                   // cycles the primary reviewer has rejected so far
+	forceApproved       bool                  // true once a human overrode the reviewer via ForceApproveCh
 }
@@ -0,0 +1,278 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxTrivialSuggestionLines caps the added+removed lines of a suggestion
+// that AutoApplySuggestions may apply without the coder.
+const maxTrivialSuggestionLines = 10
+
+// Suggestion is a concrete code change proposed by the reviewer in a
+// SUGGESTION: block, normalized into a single-file unified diff.
+type Suggestion struct {
+	File     string // target path from the +++ header, without the b/ prefix
+	Patch    string // normalized unified diff, ready for git apply
+	Overlaps bool   // touches lines already covered by an earlier suggestion
+	Applied  bool   // applied to the working tree before the coder ran
+	hunks    []hunkRange
+}
+
+// hunkRange is the old-file span and size of one @@ hunk.
+type hunkRange struct {
+	oldStart, oldLines int
+	changed            int // added + removed lines
+}
+
+// end returns the first old-file line past the hunk. Pure insertions
+// (oldLines == 0) still occupy their anchor line so that two insertions at
+// the same spot count as overlapping.
+func (h hunkRange) end() int {
+	if h.oldLines == 0 {
+		return h.oldStart + 1
+	}
+	return h.oldStart + h.oldLines
+}
+
+// trivial reports whether the suggestion is small enough to apply without
+// the coder: a single non-overlapping hunk with few changed lines.
+func (s Suggestion) trivial() bool {
+	return !s.Overlaps && len(s.hunks) == 1 && s.hunks[0].changed <= maxTrivialSuggestionLines
+}
+
+// ParseSuggestions scans reviewer output for SUGGESTION: blocks. Each block
+// holds a unified diff for one file, optionally wrapped in a ``` fence and
+// terminated by END_SUGGESTION, the closing fence, or the next block marker.
+// Malformed diffs — bad headers, hunk counts that don't match their bodies,
+// or overlapping hunks — are dropped. A suggestion whose hunks overlap an
+// earlier suggestion for the same file is kept but marked Overlaps.
+func ParseSuggestions(output string) []Suggestion {
+	var suggestions []Suggestion
+	lines := strings.Split(output, "\n")
+	for i := 0; i < len(lines); {
+		if strings.TrimSpace(lines[i]) != "SUGGESTION:" {
+			i++
+			continue
+		}
+		body, next := collectSuggestionBlock(lines, i+1)
+		i = next
+		s, err := parseSuggestionDiff(body)
+		if err != nil {
+			continue
+		}
+		s.Overlaps = overlapsEarlier(s, suggestions)
+		suggestions = append(suggestions, s)
+	}
+	return suggestions
+}
+
+// collectSuggestionBlock gathers the diff lines of a SUGGESTION: block that
+// starts at index start. It returns the lines and the index to resume from.
+func collectSuggestionBlock(lines []string, start int) ([]string, int) {
+	i := start
+	fenced := false
+	if i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+		fenced = true
+		i++
+	}
+	var body []string
+	for i < len(lines) {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "END_SUGGESTION":
+			return body, i + 1
+		case fenced && strings.HasPrefix(trimmed, "```"):
+			i++
+			if i < len(lines) && strings.TrimSpace(lines[i]) == "END_SUGGESTION" {
+				i++
+			}
+			return body, i
+		case isBlockMarker(trimmed):
+			return body, i
+		}
+		body = append(body, line)
+		i++
+	}
+	return body, i
+}
+
+// isBlockMarker reports whether a line starts another structured block in
+// the reviewer output.
+func isBlockMarker(line string) bool {
+	switch line {
+	case "SUGGESTION:", "ISSUE:", "VERIFICATION:", "REPORT:":
+		return true
+	}
+	return strings.HasPrefix(line, "APPROVED:")
+}
+
+// parseSuggestionDiff validates a single-file unified diff and returns it as
+// a Suggestion with a normalized patch body.
+func parseSuggestionDiff(body []string) (Suggestion, error) {
+	// Trim surrounding blank lines; blank lines inside hunks are context.
+	for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+		body = body[1:]
+	}
+	for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+		body = body[:len(body)-1]
+	}
+	if len(body) < 3 || !strings.HasPrefix(body[0], "--- ") || !strings.HasPrefix(body[1], "+++ ") {
+		return Suggestion{}, fmt.Errorf("missing ---/+++ file headers")
+	}
+	oldPath := diffPath(body[0], "--- ", "a/")
+	newPath := diffPath(body[1], "+++ ", "b/")
+	if oldPath == "/dev/null" || newPath == "/dev/null" || newPath == "" {
+		return Suggestion{}, fmt.Errorf("suggestions must modify an existing file")
+	}
+
+	s := Suggestion{File: newPath}
+	var patch strings.Builder
+	fmt.Fprintf(&patch, "--- a/%s\n+++ b/%s\n", oldPath, newPath)
+
+	i := 2
+	for i < len(body) {
+		h, err := parseHunkHeader(body[i])
+		if err != nil {
+			return Suggestion{}, err
+		}
+		if n := len(s.hunks); n > 0 && h.oldStart < s.hunks[n-1].end() {
+			return Suggestion{}, fmt.Errorf("hunk at line %d overlaps the previous hunk", h.oldStart)
+		}
+		patch.WriteString(body[i] + "\n")
+		i++
+
+		oldSeen, newSeen := 0, 0
+		for i < len(body) && (oldSeen < h.oldLines || newSeen < h.newLines) {
+			line := body[i]
+			if line == "" {
+				line = " " // trailing whitespace stripped from a context line
+			}
+			switch line[0] {
+			case ' ':
+				oldSeen++
+				newSeen++
+			case '-':
+				oldSeen++
+				h.changed++
+			case '+':
+				newSeen++
+				h.changed++
+			case '\\':
+				// "\ No newline at end of file" — not counted.
+			default:
+				return Suggestion{}, fmt.Errorf("unexpected line in hunk: %q", body[i])
+			}
+			patch.WriteString(line + "\n")
+			i++
+		}
+		if oldSeen != h.oldLines || newSeen != h.newLines {
+			return Suggestion{}, fmt.Errorf("hunk at line %d: header says -%d +%d, body has -%d +%d",
+				h.oldStart, h.oldLines, h.newLines, oldSeen, newSeen)
+		}
+		if i < len(body) && strings.HasPrefix(body[i], `\`) {
+			patch.WriteString(body[i] + "\n")
+			i++
+		}
+		s.hunks = append(s.hunks, h.hunkRange)
+	}
+	if len(s.hunks) == 0 {
+		return Suggestion{}, fmt.Errorf("no hunks")
+	}
+	s.Patch = patch.String()
+	return s, nil
+}
+
+// parsedHunk is a hunk header plus the new-file line count used while
+// validating the hunk body.
+type parsedHunk struct {
+	hunkRange
+	newLines int
+}
+
+// parseHunkHeader parses "@@ -a[,b] +c[,d] @@ ...".
+func parseHunkHeader(line string) (parsedHunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return parsedHunk{}, fmt.Errorf("malformed hunk header: %q", line)
+	}
+	oldStart, oldLines, err := parseHunkSpan(fields[1][1:])
+	if err != nil {
+		return parsedHunk{}, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	_, newLines, err := parseHunkSpan(fields[2][1:])
+	if err != nil {
+		return parsedHunk{}, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	return parsedHunk{hunkRange: hunkRange{oldStart: oldStart, oldLines: oldLines}, newLines: newLines}, nil
+}
+
+// parseHunkSpan parses "start[,count]"; count defaults to 1.
+func parseHunkSpan(span string) (start, count int, err error) {
+	startStr, countStr, hasCount := strings.Cut(span, ",")
+	if start, err = strconv.Atoi(startStr); err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("bad start %q", startStr)
+	}
+	count = 1
+	if hasCount {
+		if count, err = strconv.Atoi(countStr); err != nil || count < 0 {
+			return 0, 0, fmt.Errorf("bad count %q", countStr)
+		}
+	}
+	return start, count, nil
+}
+
+// diffPath extracts the path from a ---/+++ header, dropping the a/ or b/
+// prefix and any trailing timestamp.
+func diffPath(line, marker, prefix string) string {
+	p := strings.TrimPrefix(line, marker)
+	if tab := strings.IndexByte(p, '\t'); tab >= 0 {
+		p = p[:tab]
+	}
+	p = strings.TrimSpace(p)
+	return strings.TrimPrefix(p, prefix)
+}
+
+// overlapsEarlier reports whether any hunk of s touches lines covered by an
+// earlier suggestion for the same file.
+func overlapsEarlier(s Suggestion, earlier []Suggestion) bool {
+	for _, e := range earlier {
+		if e.File != s.File {
+			continue
+		}
+		for _, a := range e.hunks {
+			for _, b := range s.hunks {
+				if a.oldStart < b.end() && b.oldStart < a.end() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// applyTrivialSuggestions applies the reviewer's trivial suggestions via git
+// before the coder is re-invoked, marking each success as Applied. Failures
+// are left for the coder to handle from the prompt. No-op unless
+// AutoApplySuggestions is set and a CycleCommitter is configured.
+func (l *Loop) applyTrivialSuggestions(ctx context.Context, state *CycleState) {
+	if !l.AutoApplySuggestions || l.Git == nil {
+		return
+	}
+	for i := range state.Suggestions {
+		s := &state.Suggestions[i]
+		if s.Applied || !s.trivial() {
+			continue
+		}
+		if err := l.Git.ApplyPatch(ctx, s.Patch); err != nil {
+			l.UI.Info(fmt.Sprintf("reviewer suggestion for %s did not apply cleanly; leaving it to the coder", s.File))
+			continue
+		}
+		s.Applied = true
+		l.UI.Info(fmt.Sprintf("applied reviewer suggestion to %s", s.File))
+	}
+}
@@ -0,0 +1,263 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const singleHunkSuggestion = `SUGGESTION:
+--- a/main.go
++++ b/main.go
+@@ -10,3 +10,3 @@
+ func main() {
+-	println("hi")
++	fmt.Println("hi")
+ }
+END_SUGGESTION`
+
+func TestParseSuggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		output    string
+		wantFiles []string
+		wantHunks []int
+	}{
+		{
+			name:      "single hunk",
+			output:    "ISSUE:\nSEVERITY: minor\nDESCRIPTION: use fmt\n\n" + singleHunkSuggestion,
+			wantFiles: []string{"main.go"},
+			wantHunks: []int{1},
+		},
+		{
+			name:      "fenced block",
+			output:    "SUGGESTION:\n```diff\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\n```\n",
+			wantFiles: []string{"x.go"},
+			wantHunks: []int{1},
+		},
+		{
+			name:      "multiple hunks",
+			output:    "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,2 @@\n a\n-b\n+B\n@@ -10,1 +10,2 @@\n z\n+zz\nEND_SUGGESTION",
+			wantFiles: []string{"x.go"},
+			wantHunks: []int{2},
+		},
+		{
+			name:      "stripped blank context line",
+			output:    "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1,3 +1,3 @@\n a\n\n-c\n+C\nEND_SUGGESTION",
+			wantFiles: []string{"x.go"},
+			wantHunks: []int{1},
+		},
+		{
+			name:      "terminated by next block marker",
+			output:    "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\nAPPROVED: fine otherwise",
+			wantFiles: []string{"x.go"},
+			wantHunks: []int{1},
+		},
+		{
+			name:   "missing file headers",
+			output: "SUGGESTION:\n@@ -1 +1 @@\n-a\n+b\nEND_SUGGESTION",
+		},
+		{
+			name:   "malformed hunk header",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -x +1 @@\n-a\n+b\nEND_SUGGESTION",
+		},
+		{
+			name:   "hunk shorter than header",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1,3 +1,3 @@\n-a\n+b\nEND_SUGGESTION",
+		},
+		{
+			name:   "hunk longer than header",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\n+c\nEND_SUGGESTION",
+		},
+		{
+			name:   "overlapping hunks",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n@@ -2,1 +2,1 @@\n-b\n+BB\nEND_SUGGESTION",
+		},
+		{
+			name:   "out of order hunks",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -10 +10 @@\n-a\n+b\n@@ -1 +1 @@\n-a\n+b\nEND_SUGGESTION",
+		},
+		{
+			name:   "new file",
+			output: "SUGGESTION:\n--- /dev/null\n+++ b/x.go\n@@ -0,0 +1 @@\n+a\nEND_SUGGESTION",
+		},
+		{
+			name:   "second file in block",
+			output: "SUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\n--- a/y.go\n+++ b/y.go\n@@ -1 +1 @@\n-a\n+b\nEND_SUGGESTION",
+		},
+		{
+			name:      "malformed block does not hide later valid one",
+			output:    "SUGGESTION:\ngarbage\nEND_SUGGESTION\n" + singleHunkSuggestion,
+			wantFiles: []string{"main.go"},
+			wantHunks: []int{1},
+		},
+		{
+			name:   "no suggestions",
+			output: "APPROVED: looks good",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ParseSuggestions(tt.output)
+			if len(got) != len(tt.wantFiles) {
+				t.Fatalf("got %d suggestions, want %d: %+v", len(got), len(tt.wantFiles), got)
+			}
+			for i, s := range got {
+				if s.File != tt.wantFiles[i] {
+					t.Errorf("suggestion %d file = %q, want %q", i, s.File, tt.wantFiles[i])
+				}
+				if len(s.hunks) != tt.wantHunks[i] {
+					t.Errorf("suggestion %d hunks = %d, want %d", i, len(s.hunks), tt.wantHunks[i])
+				}
+				if !strings.HasPrefix(s.Patch, "--- a/"+s.File+"\n+++ b/"+s.File+"\n@@ ") {
+					t.Errorf("suggestion %d patch not normalized:\n%s", i, s.Patch)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSuggestionsNormalizesPatch(t *testing.T) {
+	t.Parallel()
+
+	got := ParseSuggestions("SUGGESTION:\n--- x.go\t2024-01-01\n+++ x.go\n@@ -1,2 +1,2 @@\n\n-a\n+b\nEND_SUGGESTION")
+	if len(got) != 1 {
+		t.Fatalf("got %d suggestions, want 1", len(got))
+	}
+	want := "--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,2 @@\n \n-a\n+b\n"
+	if got[0].Patch != want {
+		t.Errorf("Patch = %q, want %q", got[0].Patch, want)
+	}
+}
+
+func TestParseSuggestionsOverlapAcrossBlocks(t *testing.T) {
+	t.Parallel()
+
+	block := func(file string, start int) string {
+		return "SUGGESTION:\n--- a/" + file + "\n+++ b/" + file + "\n@@ -" +
+			strconv.Itoa(start) + ",2 +" + strconv.Itoa(start) + ",2 @@\n a\n-b\n+B\nEND_SUGGESTION\n"
+	}
+	tests := []struct {
+		name   string
+		output string
+		want   []bool
+	}{
+		{"same lines same file", block("x.go", 5) + block("x.go", 6), []bool{false, true}},
+		{"disjoint lines same file", block("x.go", 5) + block("x.go", 20), []bool{false, false}},
+		{"same lines different files", block("x.go", 5) + block("y.go", 5), []bool{false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ParseSuggestions(tt.output)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d suggestions, want %d", len(got), len(tt.want))
+			}
+			for i, s := range got {
+				if s.Overlaps != tt.want[i] {
+					t.Errorf("suggestion %d Overlaps = %v, want %v", i, s.Overlaps, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyTrivialSuggestions(t *testing.T) {
+	t.Parallel()
+
+	var big strings.Builder
+	big.WriteString("SUGGESTION:\n--- a/big.go\n+++ b/big.go\n@@ -1,6 +1,6 @@\n")
+	for _, c := range "abcdef" {
+		big.WriteString("-" + string(c) + "\n")
+	}
+	for _, c := range "ABCDEF" {
+		big.WriteString("+" + string(c) + "\n")
+	}
+	big.WriteString("END_SUGGESTION\n")
+	twoHunks := "SUGGESTION:\n--- a/two.go\n+++ b/two.go\n@@ -1 +1 @@\n-a\n+b\n@@ -9 +9 @@\n-a\n+b\nEND_SUGGESTION\n"
+	output := singleHunkSuggestion + "\n" + big.String() + twoHunks
+
+	t.Run("applies only trivial suggestions", func(t *testing.T) {
+		t.Parallel()
+		g := &fakeGit{}
+		l := &Loop{UI: &noopUI{}, Git: g, AutoApplySuggestions: true}
+		state := &CycleState{Suggestions: ParseSuggestions(output)}
+
+		l.applyTrivialSuggestions(context.Background(), state)
+
+		if len(g.applied) != 1 || !strings.Contains(g.applied[0], "main.go") {
+			t.Fatalf("applied = %v, want only main.go", g.applied)
+		}
+		for _, s := range state.Suggestions {
+			if s.Applied != (s.File == "main.go") {
+				t.Errorf("%s Applied = %v", s.File, s.Applied)
+			}
+		}
+
+		// A second pass does not re-apply.
+		l.applyTrivialSuggestions(context.Background(), state)
+		if len(g.applied) != 1 {
+			t.Errorf("re-applied suggestions: %d patches", len(g.applied))
+		}
+	})
+
+	t.Run("failed apply leaves suggestion for coder", func(t *testing.T) {
+		t.Parallel()
+		g := &fakeGit{applyErr: errors.New("does not apply")}
+		l := &Loop{UI: &noopUI{}, Git: g, AutoApplySuggestions: true}
+		state := &CycleState{Suggestions: ParseSuggestions(singleHunkSuggestion)}
+
+		l.applyTrivialSuggestions(context.Background(), state)
+
+		if state.Suggestions[0].Applied {
+			t.Error("suggestion marked applied after git failure")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		g := &fakeGit{}
+		l := &Loop{UI: &noopUI{}, Git: g}
+		state := &CycleState{Suggestions: ParseSuggestions(singleHunkSuggestion)}
+
+		l.applyTrivialSuggestions(context.Background(), state)
+
+		if len(g.applied) != 0 {
+			t.Errorf("applied %d patches with AutoApplySuggestions off", len(g.applied))
+		}
+	})
+}
+
+func TestCoderPromptIncludesSuggestions(t *testing.T) {
+	t.Parallel()
+
+	suggestions := ParseSuggestions(singleHunkSuggestion + "\nSUGGESTION:\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\nEND_SUGGESTION")
+	suggestions[0].Applied = true
+	l := &Loop{}
+	state := &CycleState{
+		Cycle:       2,
+		TaskBeadID:  "b-1",
+		Findings:    []ReviewFinding{{Severity: "minor", Description: "use fmt"}},
+		Suggestions: suggestions,
+	}
+
+	prompt := l.buildCoderPrompt(state)
+
+	for _, want := range []string{
+		"[REVIEWER SUGGESTIONS]",
+		"Suggestion 1 (main.go) — already applied",
+		"Suggestion 2 (x.go):",
+		"```diff\n--- a/x.go\n+++ b/x.go\n",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -41,6 +41,8 @@ func applyAction(ctx context.Context, action Action, phasesByID map[string]*Phas
 		return applyUpdateBead(ctx, client, phasesByID[action.PhaseID], state, dir)
 	case ActionClose:
 		return applyCloseBead(ctx, client, action, state, dir)
+	case ActionRename:
+		return applyRenamePhase(ctx, client, action, phasesByID[action.PhaseID], state, dir)
 	}
 	return nil
 }
@@ -50,7 +52,7 @@ func applyAction(ctx context.Context, action Action, phasesByID map[string]*Phas
 func applyCreateBead(ctx context.Context, client beads.Client, phase *PhaseSpec, state *State, dir string) error {
 	beadID, err := client.Create(ctx, phase.Title, beads.CreateOpts{
 		Description: phase.Body,
-		Type:        phase.Type,
+		Type:        phase.beadType(),
 		Labels:      phase.Labels,
 		Assignee:    phase.Assignee,
 		Priority:    priorityStr(phase.Priority),
@@ -102,6 +104,21 @@ func applyCloseBead(ctx context.Context, client beads.Client, action Action, sta
 	return nil
 }
 
+// applyRenamePhase moves a renamed phase's state, and with it its bead, to
+// the new phase ID, then updates the bead like any existing phase.
+func applyRenamePhase(ctx context.Context, client beads.Client, action Action, phase *PhaseSpec, state *State, dir string) error {
+	ps := state.Phases[action.From]
+	if phase == nil || ps == nil {
+		return nil
+	}
+	state.Phases[action.PhaseID] = ps
+	delete(state.Phases, action.From)
+	if err := SaveState(dir, state); err != nil {
+		return fmt.Errorf("saving state after renaming %q to %q: %w", action.From, action.PhaseID, err)
+	}
+	return applyUpdateBead(ctx, client, phase, state, dir)
+}
+
 func priorityStr(p int) string {
 	if p == 0 {
 		return ""
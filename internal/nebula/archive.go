@@ -0,0 +1,173 @@
+package nebula
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Export writes the nebula in dir to w as a gzipped tar archive: the
+// manifest, phase files, state, and metrics history, including imported
+// sub-nebula directories. Intervention files (see GitExcludePatterns), the
+// .nebula.env file, which may hold secrets, state backups, and anything that
+// is not a regular file or directory are left out. Paths in the archive are relative
+// to dir.
+func Export(dir string, w io.Writer) error {
+	if _, err := os.Stat(filepath.Join(dir, "nebula.toml")); err != nil {
+		return fmt.Errorf("%w: %s", ErrNoManifest, dir)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	walkErr := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if excludedFromExport(d) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return copyFileTo(tw, p)
+	})
+	if walkErr != nil {
+		return fmt.Errorf("exporting nebula: %w", walkErr)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("exporting nebula: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("exporting nebula: %w", err)
+	}
+	return nil
+}
+
+// excludedFromExport reports whether Export skips the entry d.
+func excludedFromExport(d fs.DirEntry) bool {
+	if !d.IsDir() && !d.Type().IsRegular() {
+		return true
+	}
+	if d.Name() == envFileName {
+		return true
+	}
+	if ok, _ := path.Match(stateFileName+".*", d.Name()); ok {
+		return true
+	}
+	for _, pattern := range GitExcludePatterns() {
+		if ok, _ := path.Match(pattern, d.Name()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// copyFileTo copies the file at p to w.
+func copyFileTo(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// Import unpacks an archive written by Export into dest, which must not
+// exist or must be an empty directory. Entries that would land outside
+// dest, and entry types Export never writes, are rejected.
+func Import(r io.Reader, dest string) error {
+	if entries, err := os.ReadDir(dest); err == nil && len(entries) > 0 {
+		return fmt.Errorf("import destination %s is not empty", dest)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading import destination: %w", err)
+	}
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("creating import destination: %w", err)
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("reading nebula archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading nebula archive: %w", err)
+		}
+		if err := extractEntry(tr, hdr, dest); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "nebula.toml")); err != nil {
+		return fmt.Errorf("%w: archive has no nebula.toml", ErrNoManifest)
+	}
+	return nil
+}
+
+// extractEntry writes one archive entry under dest.
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dest string) error {
+	name := filepath.FromSlash(path.Clean(hdr.Name))
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("nebula archive entry %q escapes the destination", hdr.Name)
+	}
+	target := filepath.Join(dest, name)
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		return nil
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fs.FileMode(hdr.Mode)&0o755|0o600)
+		if err != nil {
+			return fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("nebula archive entry %q has unsupported type %q", hdr.Name, hdr.Typeflag)
+	}
+}
@@ -0,0 +1,105 @@
+package nebula
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	files := map[string]string{
+		"nebula.toml":         "[nebula]\nname = \"demo\"\n",
+		"a.md":                "+++\nid = \"a\"\n+++\nbody\n",
+		"nebula.state.toml":   "version = 1\n",
+		"metrics.toml":        "[[phases]]\n",
+		"backend/nebula.toml": "[nebula]\nname = \"backend\"\n",
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(src, name), content)
+	}
+	for _, name := range append(InterventionFileNames(), envFileName, "nebula.state.toml.1") {
+		writeTestFile(t, filepath.Join(src, name), "skip me")
+	}
+	// A headless run's event socket is not a regular file and is skipped.
+	if l, err := net.Listen("unix", filepath.Join(src, "s.sock")); err == nil {
+		defer l.Close()
+	}
+
+	var archive bytes.Buffer
+	if err := Export(src, &archive); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "copy")
+	if err := Import(bytes.NewReader(archive.Bytes()), dest); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	var got []string
+	filepath.WalkDir(dest, func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dest, p)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if len(got) != len(files) {
+		t.Errorf("imported files = %v, want exactly %d nebula files", got, len(files))
+	}
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(dest, name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, content)
+		}
+	}
+
+	if err := Import(bytes.NewReader(archive.Bytes()), dest); err == nil {
+		t.Error("Import into a non-empty directory succeeded")
+	}
+}
+
+func TestExportRequiresManifest(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Export(t.TempDir(), &buf); !errors.Is(err, ErrNoManifest) {
+		t.Errorf("Export error = %v, want ErrNoManifest", err)
+	}
+}
+
+func TestImportRejectsUnsafeEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		hdr  tar.Header
+	}{
+		{"parent traversal", tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644}},
+		{"absolute path", tar.Header{Name: "/tmp/evil", Typeflag: tar.TypeReg, Mode: 0o644}},
+		{"symlink", tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			if err := tw.WriteHeader(&tt.hdr); err != nil {
+				t.Fatal(err)
+			}
+			tw.Close()
+			gz.Close()
+
+			if err := Import(&buf, filepath.Join(t.TempDir(), "dest")); err == nil {
+				t.Error("Import accepted an unsafe entry")
+			}
+		})
+	}
+}
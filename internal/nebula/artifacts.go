@@ -0,0 +1,180 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// artifactsDirName is the directory under the nebula directory that phase
+// artifacts are collected into, one subdirectory per phase.
+const artifactsDirName = "artifacts"
+
+// ArtifactsDir returns the directory that phaseID's artifacts are copied into.
+func ArtifactsDir(nebulaDir, phaseID string) string {
+	return filepath.Join(nebulaDir, artifactsDirName, phaseID)
+}
+
+// checkArtifactPattern rejects artifact globs that are malformed or could
+// reach outside the working directory.
+func checkArtifactPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("empty path")
+	}
+	if filepath.IsAbs(pattern) || strings.HasPrefix(pattern, "/") {
+		return errors.New("must be relative to the working directory")
+	}
+	for _, part := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if part == ".." {
+			return errors.New("must not contain \"..\"")
+		}
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// artifactErrors reports each artifact path in p that checkArtifactPattern rejects.
+func artifactErrors(p PhaseSpec) []ValidationError {
+	var errs []ValidationError
+	for _, pattern := range p.Artifacts {
+		if err := checkArtifactPattern(pattern); err != nil {
+			errs = append(errs, ValidationError{
+				Category:   ValCatInvalidArtifact,
+				PhaseID:    p.ID,
+				SourceFile: p.SourceFile,
+				Field:      "artifacts",
+				Err:        fmt.Errorf("%w: %q: %v", ErrInvalidArtifact, pattern, err),
+			})
+		}
+	}
+	return errs
+}
+
+// collectArtifacts copies the files matched by phase's artifact globs into
+// ArtifactsDir, keeping their paths relative to the phase's working
+// directory (its working_dir under WorkDir, if set), and
+// returns the copied paths. Artifacts from an earlier attempt are replaced.
+// Problems are logged as warnings and never fail the phase.
+func (wg *WorkerGroup) collectArtifacts(phase *PhaseSpec) []string {
+	if len(phase.Artifacts) == 0 {
+		return nil
+	}
+	root := filepath.Join(wg.Nebula.Dir, artifactsDirName)
+	dest := ArtifactsDir(wg.Nebula.Dir, phase.ID)
+	if err := os.RemoveAll(dest); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: clearing artifacts for phase %q: %v\n", phase.ID, err)
+		return nil
+	}
+	if err := ensureArtifactsRoot(root); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: creating artifacts directory: %v\n", err)
+		return nil
+	}
+
+	workDir := wg.resolvePhaseExecution(phase).WorkDirUnder(wg.WorkDir)
+	if workDir == "" {
+		workDir = "."
+	}
+	var collected []string
+	for _, pattern := range phase.Artifacts {
+		if err := checkArtifactPattern(pattern); err != nil {
+			fmt.Fprintf(wg.logger(), "warning: skipping artifact %q for phase %q: %v\n", pattern, phase.ID, err)
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(workDir, pattern)) // pattern already checked
+		if len(matches) == 0 {
+			fmt.Fprintf(wg.logger(), "warning: artifact %q for phase %q matched nothing\n", pattern, phase.ID)
+			continue
+		}
+		for _, src := range matches {
+			rel, err := filepath.Rel(workDir, src)
+			if err != nil {
+				fmt.Fprintf(wg.logger(), "warning: collecting artifact %s: %v\n", src, err)
+				continue
+			}
+			files, err := copyArtifact(src, filepath.Join(dest, rel), root)
+			collected = append(collected, files...)
+			if err != nil {
+				fmt.Fprintf(wg.logger(), "warning: collecting artifact %s for phase %q: %v\n", src, phase.ID, err)
+			}
+		}
+	}
+	return collected
+}
+
+// ensureArtifactsRoot creates the artifacts root with a .gitignore so that
+// collected artifacts are never swept into phase commits.
+func ensureArtifactsRoot(root string) error {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+	ignore := filepath.Join(root, ".gitignore")
+	if _, err := os.Stat(ignore); err == nil {
+		return nil
+	}
+	return os.WriteFile(ignore, []byte("*\n"), 0o644)
+}
+
+// copyArtifact copies the regular files under src (a file or a directory) to
+// dst and returns the paths written. It never descends into skip, the
+// artifacts root, so a broad glob cannot copy artifacts into themselves.
+// Symlinks and other non-regular files are ignored.
+func copyArtifact(src, dst, skip string) ([]string, error) {
+	var written []string
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if filepath.Clean(path) == filepath.Clean(skip) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := copyFile(path, target); err != nil {
+			return err
+		}
+		written = append(written, target)
+		return nil
+	})
+	return written, err
+}
+
+// copyFile copies the regular file src to dst, creating parent directories
+// and preserving the file mode.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,204 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCheckArtifactPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{"coverage.out", false},
+		{"build/*.tar.gz", false},
+		{"./reports", false},
+		{"", true},
+		{"  ", true},
+		{"/etc/passwd", true},
+		{"../secrets", true},
+		{"reports/../../x", true},
+		{"reports/[", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			if err := checkArtifactPattern(tt.pattern); (err != nil) != tt.wantErr {
+				t.Errorf("checkArtifactPattern(%q) = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateArtifacts(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Manifest: Manifest{Nebula: Info{Name: "n"}},
+		Phases:   []PhaseSpec{{ID: "a", Title: "A", Artifacts: []string{"cover.out", "/abs", "../up"}}},
+	}
+	var got int
+	for _, e := range Validate(n) {
+		if e.Category != ValCatInvalidArtifact || e.Field != "artifacts" {
+			t.Errorf("unexpected error: %v", e)
+			continue
+		}
+		if !errors.Is(&e, ErrInvalidArtifact) {
+			t.Errorf("error %v does not wrap ErrInvalidArtifact", e)
+		}
+		got++
+	}
+	if got != 2 {
+		t.Errorf("got %d artifact errors, want 2", got)
+	}
+}
+
+// writeTestFile creates path (and its parents) with content.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	nebDir := filepath.Join(work, ".nebulas", "demo")
+	writeTestFile(t, filepath.Join(work, "cover.out"), "mode: set\n")
+	writeTestFile(t, filepath.Join(work, "dist", "app.tar.gz"), "tarball")
+	writeTestFile(t, filepath.Join(work, "dist", "app.zip"), "zip")
+	writeTestFile(t, filepath.Join(work, "reports", "html", "index.html"), "<html>")
+	// Left over from an earlier attempt; must not survive collection.
+	writeTestFile(t, filepath.Join(ArtifactsDir(nebDir, "a"), "stale.txt"), "old")
+
+	var logs bytes.Buffer
+	wg := NewWorkerGroup(&Nebula{Dir: nebDir}, &State{}, WithWorkDir(work), WithLogger(&logs))
+	phase := &PhaseSpec{ID: "a", Artifacts: []string{"cover.out", "dist/*.tar.gz", "reports", "missing/*.log"}}
+
+	got := wg.collectArtifacts(phase)
+	sort.Strings(got)
+	dest := ArtifactsDir(nebDir, "a")
+	want := []string{
+		filepath.Join(dest, "cover.out"),
+		filepath.Join(dest, "dist", "app.tar.gz"),
+		filepath.Join(dest, "reports", "html", "index.html"),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("collected = %v, want %v", got, want)
+	}
+	for _, p := range want {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("artifact %s not copied: %v", p, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "stale.txt")); !os.IsNotExist(err) {
+		t.Error("stale artifact from an earlier attempt was kept")
+	}
+	if !strings.Contains(logs.String(), `"missing/*.log"`) {
+		t.Errorf("no warning for the unmatched artifact; log:\n%s", logs.String())
+	}
+	if data, err := os.ReadFile(filepath.Join(nebDir, artifactsDirName, ".gitignore")); err != nil || string(data) != "*\n" {
+		t.Errorf("artifacts .gitignore = %q, %v; want \"*\\n\"", data, err)
+	}
+}
+
+func TestCollectArtifactsUsesPhaseWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	nebDir := filepath.Join(work, ".nebulas", "demo")
+	writeTestFile(t, filepath.Join(work, "cover.out"), "root")
+	writeTestFile(t, filepath.Join(work, "services", "api", "cover.out"), "api")
+	wg := NewWorkerGroup(&Nebula{Dir: nebDir}, &State{}, WithWorkDir(work), WithLogger(&bytes.Buffer{}))
+
+	got := wg.collectArtifacts(&PhaseSpec{ID: "a", WorkingDir: "services/api", Artifacts: []string{"cover.out"}})
+	want := filepath.Join(ArtifactsDir(nebDir, "a"), "cover.out")
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("collected = %v, want [%s]", got, want)
+	}
+	if data, err := os.ReadFile(want); err != nil || string(data) != "api" {
+		t.Errorf("artifact = %q, %v; want the phase directory's cover.out", data, err)
+	}
+}
+
+func TestCollectArtifactsSkipsArtifactsRoot(t *testing.T) {
+	t.Parallel()
+
+	// The nebula lives inside the working dir, so "*" also matches the
+	// artifacts root; it must not be copied into itself.
+	work := t.TempDir()
+	writeTestFile(t, filepath.Join(work, "out.txt"), "x")
+	wg := NewWorkerGroup(&Nebula{Dir: work}, &State{}, WithWorkDir(work), WithLogger(&bytes.Buffer{}))
+
+	got := wg.collectArtifacts(&PhaseSpec{ID: "a", Artifacts: []string{"*"}})
+	for _, p := range got {
+		if strings.Contains(p, filepath.Join(artifactsDirName, "a", artifactsDirName)) {
+			t.Errorf("artifacts root copied into itself: %s", p)
+		}
+	}
+	if len(got) != 1 || filepath.Base(got[0]) != "out.txt" {
+		t.Errorf("collected = %v, want only out.txt", got)
+	}
+}
+
+func TestRunRecordsArtifacts(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	nebDir := filepath.Join(work, "neb")
+	writeTestFile(t, filepath.Join(work, "cover.out"), "mode: set\n")
+
+	n := &Nebula{
+		Dir:      nebDir,
+		Manifest: Manifest{Nebula: Info{Name: "art"}},
+		Phases: []PhaseSpec{
+			{ID: "ok", Title: "OK", Artifacts: []string{"cover.out"}},
+			{ID: "bad", Title: "Bad", Artifacts: []string{"cover.out"}},
+		},
+	}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{
+		"ok":  {BeadID: "bead-ok", Status: PhaseStatusCreated},
+		"bad": {BeadID: "bead-bad", Status: PhaseStatusCreated},
+	}}
+	runner := &mockRunner{resultFunc: func(string) *PhaseRunnerResult { return &PhaseRunnerResult{} }}
+	failing := &failingRunner{PhaseRunner: runner, fail: "bead-bad"}
+	wg := NewWorkerGroup(n, state, WithRunner(failing), WithWorkDir(work), WithMaxWorkers(2), WithLogger(&bytes.Buffer{}))
+
+	results, _ := wg.Run(context.Background())
+	byPhase := map[string]WorkerResult{}
+	for _, r := range results {
+		byPhase[r.PhaseID] = r
+	}
+	if got := byPhase["ok"].Artifacts; len(got) != 1 || got[0] != filepath.Join(ArtifactsDir(nebDir, "ok"), "cover.out") {
+		t.Errorf("ok artifacts = %v, want the copied cover.out", got)
+	}
+	if got := byPhase["bad"].Artifacts; len(got) != 0 {
+		t.Errorf("failed phase collected artifacts %v", got)
+	}
+}
+
+// failingRunner fails the phase whose bead ID is fail and delegates the rest.
+type failingRunner struct {
+	PhaseRunner
+	fail string
+}
+
+func (f *failingRunner) RunExistingPhase(ctx context.Context, phaseID, beadID, title, desc string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	if beadID == f.fail {
+		return nil, errors.New("boom")
+	}
+	return f.PhaseRunner.RunExistingPhase(ctx, phaseID, beadID, title, desc, exec)
+}
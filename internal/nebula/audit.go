@@ -0,0 +1,133 @@
+package nebula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditEvent identifies what an AuditRecord describes.
+type AuditEvent string
+
+const (
+	// AuditRunStart is recorded when WorkerGroup.Run begins.
+	AuditRunStart AuditEvent = "run_start"
+	// AuditRunDone is recorded when WorkerGroup.Run returns.
+	AuditRunDone AuditEvent = "run_done"
+	// AuditPhaseStart is recorded when a phase begins executing.
+	AuditPhaseStart AuditEvent = "phase_start"
+	// AuditPhaseComplete is recorded when a phase finishes successfully.
+	AuditPhaseComplete AuditEvent = "phase_complete"
+	// AuditPhaseFail is recorded when a phase fails.
+	AuditPhaseFail AuditEvent = "phase_fail"
+	// AuditGateDecision is recorded for every plan, phase, and budget gate.
+	AuditGateDecision AuditEvent = "gate_decision"
+	// AuditIntervention is recorded when a PAUSE, STOP, RETRY, or resume is picked up.
+	AuditIntervention AuditEvent = "intervention"
+	// AuditHotAdd is recorded when a phase is added to the DAG mid-run.
+	AuditHotAdd AuditEvent = "hot_add"
+	// AuditCost is recorded when spend is added to the nebula's total.
+	AuditCost AuditEvent = "cost"
+)
+
+// Actors recorded on gate decisions.
+const (
+	AuditActorHuman = "human" // a person answered a prompt
+	AuditActorAuto  = "auto"  // the gate mode decided without prompting
+)
+
+// AuditActionOverrideReviewer is the gate decision recorded when a human
+// force-approved a phase over its reviewer.
+const AuditActionOverrideReviewer = "override_reviewer"
+
+// AuditRecord is one line of the audit log. Only the fields relevant to
+// Event are set.
+type AuditRecord struct {
+	Time         time.Time  `json:"time"`
+	Event        AuditEvent `json:"event"`
+	Nebula       string     `json:"nebula"`
+	Phase        string     `json:"phase,omitempty"`
+	Action       string     `json:"action,omitempty"` // gate action or intervention kind
+	Actor        string     `json:"actor,omitempty"`  // gate decisions only
+	CostUSD      float64    `json:"cost_usd,omitempty"`
+	TotalCostUSD float64    `json:"total_cost_usd,omitempty"`
+	Detail       string     `json:"detail,omitempty"` // error or other context
+}
+
+// auditLog appends AuditRecords to a writer as JSON lines. Writes are
+// serialized so records from concurrent workers never interleave.
+type auditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// audit appends r to the audit log, stamping the time and nebula name. It
+// is a no-op when no audit log is configured; write failures are logged and
+// otherwise ignored so auditing never stops a run.
+func (wg *WorkerGroup) audit(r AuditRecord) {
+	a := wg.auditor
+	if a == nil {
+		return
+	}
+	r.Nebula = wg.Nebula.Manifest.Nebula.Name
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Stamp under the lock so records appear in time order.
+	r.Time = a.now().UTC()
+	data, err := json.Marshal(r)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: encoding audit record: %v\n", err)
+		return
+	}
+	if _, err := a.w.Write(append(data, '\n')); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: writing audit record: %v\n", err)
+	}
+}
+
+// auditDone records the end of a run with its error, or a summary of the
+// phases run.
+func (wg *WorkerGroup) auditDone(results []WorkerResult, err error) {
+	wg.mu.Lock()
+	r := AuditRecord{Event: AuditRunDone, TotalCostUSD: wg.State.TotalCostUSD}
+	wg.mu.Unlock()
+	if err != nil {
+		r.Detail = err.Error()
+	} else {
+		failed := 0
+		for _, res := range results {
+			if res.Err != nil {
+				failed++
+			}
+		}
+		r.Detail = fmt.Sprintf("%d phases run, %d failed", len(results), failed)
+	}
+	wg.audit(r)
+}
+
+// gateActor reports who decides the given phase's gate: a human when its
+// gate mode prompts and a prompter is configured, otherwise the gate mode.
+func (wg *WorkerGroup) gateActor(phase *PhaseSpec) string {
+	mode := ResolveGate(wg.Nebula.Manifest.Execution, *phase)
+	if wg.Prompter != nil && (mode == GateModeReview || mode == GateModeApprove) {
+		return AuditActorHuman
+	}
+	return AuditActorAuto
+}
+
+// hotAddFunc returns the hot-add callback for the HotReloader: it audits
+// the addition, then forwards to OnHotAdd.
+func (wg *WorkerGroup) hotAddFunc() HotAddFunc {
+	if wg.auditor == nil {
+		return wg.OnHotAdd
+	}
+	return func(phaseID, title string, dependsOn []string) {
+		wg.audit(AuditRecord{Event: AuditHotAdd, Phase: phaseID, Detail: title})
+		if wg.OnHotAdd != nil {
+			wg.OnHotAdd(phaseID, title, dependsOn)
+		}
+	}
+}
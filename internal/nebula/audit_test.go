@@ -0,0 +1,90 @@
+package nebula
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkerGroup_AuditLog(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir: t.TempDir(),
+		Manifest: Manifest{
+			Nebula:    Info{Name: "audited"},
+			Execution: Execution{Gate: GateModeApprove},
+		},
+		Phases: []PhaseSpec{
+			{ID: "a", Body: "phase a"},
+			{ID: "b", Body: "phase b", DependsOn: []string{"a"}, Gate: GateModeTrust},
+		},
+	}
+	state := &State{
+		Version: 1,
+		Phases: map[string]*PhaseState{
+			"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+			"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+		},
+	}
+	var buf bytes.Buffer
+	wg := NewWorkerGroup(n, state,
+		WithRunner(&mockRunner{result: &PhaseRunnerResult{TotalCostUSD: 0.5}}),
+		WithPrompter(&mockGater{action: GateActionAccept}),
+		WithAuditLog(&buf),
+	)
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var records []AuditRecord
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("line %q is not a JSON audit record: %v", sc.Text(), err)
+		}
+		if r.Time.IsZero() || r.Nebula != "audited" {
+			t.Errorf("record %+v missing time or nebula name", r)
+		}
+		records = append(records, r)
+	}
+
+	type key struct {
+		event AuditEvent
+		phase string
+	}
+	got := make(map[key]AuditRecord)
+	for _, r := range records {
+		got[key{r.Event, r.Phase}] = r
+	}
+	for _, k := range []key{
+		{AuditRunStart, ""},
+		{AuditPhaseStart, "a"},
+		{AuditPhaseComplete, "a"},
+		{AuditPhaseComplete, "b"},
+		{AuditRunDone, ""},
+	} {
+		if _, ok := got[k]; !ok {
+			t.Errorf("no %s record for phase %q", k.event, k.phase)
+		}
+	}
+
+	if r := got[key{AuditGateDecision, PlanPhaseID}]; r.Actor != AuditActorHuman || r.Action != string(GateActionAccept) {
+		t.Errorf("plan gate record = %+v, want human accept", r)
+	}
+	if r := got[key{AuditGateDecision, "a"}]; r.Actor != AuditActorHuman {
+		t.Errorf("phase a gate actor = %q, want %q", r.Actor, AuditActorHuman)
+	}
+	if r := got[key{AuditGateDecision, "b"}]; r.Actor != AuditActorAuto {
+		t.Errorf("phase b gate actor = %q, want %q (trust override)", r.Actor, AuditActorAuto)
+	}
+	if r := got[key{AuditCost, "b"}]; r.CostUSD != 0.5 || r.TotalCostUSD != 1.0 {
+		t.Errorf("phase b cost record = %+v, want cost 0.5, total 1.0", r)
+	}
+	if records[0].Event != AuditRunStart || records[len(records)-1].Event != AuditRunDone {
+		t.Errorf("records should be bracketed by run_start and run_done, got %s ... %s", records[0].Event, records[len(records)-1].Event)
+	}
+}
@@ -0,0 +1,110 @@
+package nebula
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// minMentionLen is the shortest phase ID or title looked for in other
+// phases' bodies; shorter ones match ordinary words too often.
+const minMentionLen = 3
+
+// proseLine matches a whole line, so replaceOutsideFences can be used to
+// visit the lines outside fenced code blocks.
+var proseLine = regexp.MustCompile(`^.*$`)
+
+// UndeclaredDependencies flags phases whose body mentions another phase, by
+// ID or by title, without depending on it. A mention of a phase that is
+// already ordered before or after this one, directly or transitively, is
+// not flagged. Text inside fenced code blocks is ignored. The check is a
+// heuristic, so `nebula validate` runs it only with --strict.
+func UndeclaredDependencies(n *Nebula) []ValidationError {
+	phases := clonePhases(n.Phases)
+	expandBlocks(phases, indexPhases(phases))
+	d, err := NewDAGFromPhases(phases)
+	if err != nil {
+		return nil // Validate reports cycles and unknown dependencies.
+	}
+
+	var errs []ValidationError
+	for _, p := range phases {
+		prose := proseOf(p.Body)
+		for _, other := range phases {
+			if other.ID == p.ID || d.HasPath(p.ID, other.ID) || d.HasPath(other.ID, p.ID) {
+				continue
+			}
+			mention := mentionOf(prose, other)
+			if mention == "" {
+				continue
+			}
+			errs = append(errs, ValidationError{
+				Category:   ValCatUndeclaredDependency,
+				PhaseID:    p.ID,
+				SourceFile: p.SourceFile,
+				Field:      "depends_on",
+				Err:        fmt.Errorf("%w: body mentions %q but depends_on does not include %q", ErrUndeclaredDependency, mention, other.ID),
+			})
+		}
+	}
+	return errs
+}
+
+// proseOf returns the lines of body outside fenced code blocks.
+func proseOf(body string) string {
+	var b strings.Builder
+	replaceOutsideFences(body, proseLine, func(line string) string {
+		b.WriteString(line)
+		b.WriteByte('\n')
+		return line
+	})
+	return b.String()
+}
+
+// mentionOf returns the text by which prose refers to phase p: its title as
+// a whole phrase in any case, or else its ID as a whole token. It returns
+// "" when neither appears.
+func mentionOf(prose string, p PhaseSpec) string {
+	if utf8.RuneCountInString(p.Title) >= minMentionLen && containsToken(prose, p.Title, true) {
+		return p.Title
+	}
+	if utf8.RuneCountInString(p.ID) >= minMentionLen && containsToken(prose, p.ID, false) {
+		return p.ID
+	}
+	return ""
+}
+
+// containsToken reports whether s occurs in text with no word character,
+// hyphen, or slash on either side, so "api" does not match "api-client".
+func containsToken(text, s string, foldCase bool) bool {
+	if foldCase {
+		text, s = strings.ToLower(text), strings.ToLower(s)
+	}
+	for i := 0; ; {
+		j := strings.Index(text[i:], s)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(s)
+		if !tokenRune(text[:start], true) && !tokenRune(text[end:], false) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// tokenRune reports whether the rune just before (last) or just after the
+// given text would extend a token.
+func tokenRune(text string, last bool) bool {
+	var r rune
+	if last {
+		r, _ = utf8.DecodeLastRuneInString(text)
+	} else {
+		r, _ = utf8.DecodeRuneInString(text)
+	}
+	if r == utf8.RuneError {
+		return false
+	}
+	return r == '_' || r == '-' || r == '/' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
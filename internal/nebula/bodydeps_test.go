@@ -0,0 +1,95 @@
+package nebula
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUndeclaredDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		phases []PhaseSpec
+		want   []string // "phase→mention" for each flagged pair
+	}{
+		{
+			name: "mention by ID",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API"},
+				{ID: "ui", Title: "Frontend", Body: "Call the endpoints added in api."},
+			},
+			want: []string{"ui→api"},
+		},
+		{
+			name: "mention by title in any case",
+			phases: []PhaseSpec{
+				{ID: "schema", Title: "Database Schema"},
+				{ID: "seed", Title: "Seed data", Body: "Load fixtures once the database schema exists."},
+			},
+			want: []string{"seed→Database Schema"},
+		},
+		{
+			name: "declared dependency",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API"},
+				{ID: "ui", Title: "Frontend", DependsOn: []string{"api"}, Body: "Call the endpoints added in api."},
+			},
+		},
+		{
+			name: "transitive dependency",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API"},
+				{ID: "auth", Title: "Auth", DependsOn: []string{"api"}},
+				{ID: "ui", Title: "Frontend", DependsOn: []string{"auth"}, Body: "Call api."},
+			},
+		},
+		{
+			name: "dependent mentions are not flagged",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API", Body: "The ui phase will call these endpoints."},
+				{ID: "ui", Title: "Frontend", DependsOn: []string{"api"}},
+			},
+		},
+		{
+			name: "blocks orders the pair",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API", Blocks: []string{"ui"}},
+				{ID: "ui", Title: "Frontend", Body: "Call api."},
+			},
+		},
+		{
+			name: "partial tokens and fenced code are ignored",
+			phases: []PhaseSpec{
+				{ID: "api", Title: "Build the API"},
+				{ID: "ui", Title: "Frontend", Body: "Use the api-client package and rapid.go.\n```\ncurl api\n```"},
+			},
+		},
+		{
+			name: "short IDs are ignored",
+			phases: []PhaseSpec{
+				{ID: "db", Title: "DB"},
+				{ID: "ui", Title: "Frontend", Body: "Read from the db."},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := UndeclaredDependencies(&Nebula{Phases: tt.phases})
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got %d errors (%v), want %v", len(errs), errs, tt.want)
+			}
+			for i, e := range errs {
+				if !errors.Is(e.Err, ErrUndeclaredDependency) || e.Category != ValCatUndeclaredDependency || e.Field != "depends_on" {
+					t.Errorf("unexpected error: %+v", e)
+				}
+				phase, mention, _ := strings.Cut(tt.want[i], "→")
+				if e.PhaseID != phase || !strings.Contains(e.Err.Error(), mention) {
+					t.Errorf("error %q on %s, want %s mentioning %q", e.Err, e.PhaseID, phase, mention)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// recordInterrupted puts a phase whose run was cut short by the run's
+// context being canceled back to created, so the next `nebula apply` runs it
+// again instead of retrying it as a failure or skipping it as locked.
+// Must NOT be called with wg.mu held.
+func (wg *WorkerGroup) recordInterrupted(phaseID string, ps *PhaseState, phaseResult *PhaseRunnerResult, inFlight map[string]bool) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	delete(inFlight, phaseID)
+	wg.chargeRun(phaseID, ps, phaseResult)
+	wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusCreated)
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+}
+
+// handleCanceled saves state after the run's context ends and returns the
+// error Run reports: ErrCanceled for a cancellation, or the context's error
+// itself for a deadline.
+func (wg *WorkerGroup) handleCanceled(ctx context.Context) error {
+	wg.mu.Lock()
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+	wg.mu.Unlock()
+
+	fmt.Fprintf(wg.logger(), "\n── Nebula canceled ────────────────────────────────\n")
+	fmt.Fprintf(wg.logger(), "   Interrupted phases will run again. Resume with: quasar nebula apply\n")
+	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ErrCanceled
+	}
+	return ctx.Err()
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/papapumpkin/quasar/internal/ansi"
 )
@@ -21,14 +22,18 @@ type Checkpoint struct {
 	Status           PhaseStatus
 	ReviewCycles     int
 	CostUSD          float64
-	ReviewSummary    string       // From ReviewReport.Summary
-	NeedsHumanReview bool         // Reviewer flagged requirements-level issues
-	Satisfaction     string       // Reviewer satisfaction level (high, medium, low)
-	Risk             string       // Reviewer risk assessment (high, medium, low)
-	Diff             string       // Output of git diff (the phase's commit vs prior)
-	FilesChanged     []FileChange // Parsed summary of changed files
-	BaseCommitSHA    string       // HEAD at start of the phase (empty if unavailable)
-	FinalCommitSHA   string       // Last cycle's sealed SHA (empty if unavailable)
+	ReviewSummary    string        // From ReviewReport.Summary
+	NeedsHumanReview bool          // Reviewer flagged requirements-level issues
+	Satisfaction     string        // Reviewer satisfaction level (high, medium, low)
+	Risk             string        // Reviewer risk assessment (high, medium, low)
+	Confidence       string        // Reviewer confidence in its verdict (high, medium, low; "" if not given)
+	Diff             string        // Output of git diff (the phase's commit vs prior)
+	FilesChanged     []FileChange  // Parsed summary of changed files
+	BaseCommitSHA    string        // HEAD at start of the phase (empty if unavailable)
+	FinalCommitSHA   string        // Last cycle's sealed SHA (empty if unavailable)
+	Manual           bool          // A manual phase waiting for a human to complete it
+	Instructions     string        // Manual phases: the phase body, telling the human what to do
+	Checks           []CheckResult // Results of the phase's checks, run before the gate
 }
 
 // FileChange summarizes a single file's changes within a phase commit.
@@ -65,6 +70,7 @@ func BuildCheckpoint(ctx context.Context, git GitCommitter, phaseID string, resu
 		cp.NeedsHumanReview = result.Report.NeedsHumanReview
 		cp.Satisfaction = result.Report.Satisfaction
 		cp.Risk = result.Report.Risk
+		cp.Confidence = result.Report.Confidence
 	}
 
 	// Retrieve the diff and stat for the phase.
@@ -229,10 +235,31 @@ func RenderCheckpoint(w io.Writer, cp *Checkpoint) {
 		}
 	}
 
+	// Check results.
+	if len(cp.Checks) > 0 {
+		fmt.Fprintf(w, "   "+ansi.Dim+"Checks:"+ansi.Reset+"\n")
+		for _, c := range cp.Checks {
+			icon, color := "✓", ansi.Green
+			if !c.Passed {
+				icon, color = "✗", ansi.Red
+			}
+			optional := ""
+			if !c.Required {
+				optional = ansi.Dim + " (optional)" + ansi.Reset
+			}
+			fmt.Fprintf(w, "     %s%s %s%s %s%s\n", color, icon, c.Name, ansi.Reset, c.Duration.Round(time.Millisecond), optional)
+		}
+	}
+
 	// Reviewer summary.
 	if cp.ReviewSummary != "" {
 		fmt.Fprintf(w, "   "+ansi.Dim+"Reviewer:"+ansi.Reset+" %q\n", cp.ReviewSummary)
 	}
+	if cp.Confidence == "low" {
+		fmt.Fprintf(w, "   "+ansi.Dim+"Confidence:"+ansi.Reset+" "+ansi.Yellow+"low"+ansi.Reset+" — the reviewer was unsure of this approval\n")
+	} else if cp.Confidence != "" {
+		fmt.Fprintf(w, "   "+ansi.Dim+"Confidence:"+ansi.Reset+" %s\n", cp.Confidence)
+	}
 
 	fmt.Fprintln(w, separator)
 }
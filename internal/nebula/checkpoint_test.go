@@ -19,6 +19,9 @@ type mockGitCommitter struct {
 	diffStatRange      string
 	diffRangeErr       error
 	diffStatRangeErr   error
+	status             []string
+	statusExclude      []string
+	dropped            map[string]bool // commits HasCommit reports as gone
 }
 
 func (m *mockGitCommitter) CommitPhase(_ context.Context, _, _, _ string) error {
@@ -49,6 +52,23 @@ func (m *mockGitCommitter) ResetTo(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *mockGitCommitter) Status(_ context.Context, exclude ...string) ([]string, error) {
+	m.statusExclude = exclude
+	return m.status, nil
+}
+
+func (m *mockGitCommitter) SquashRange(_ context.Context, _, head, _ string) (string, error) {
+	return head, nil
+}
+
+func (m *mockGitCommitter) PreserveWorkTree(_ context.Context, name, _ string) (string, error) {
+	return "quasar/failed/" + name, nil
+}
+
+func (m *mockGitCommitter) HasCommit(_ context.Context, sha string) bool {
+	return !m.dropped[sha]
+}
+
 func TestParseDiffStat(t *testing.T) {
 	t.Parallel()
 
@@ -403,6 +423,29 @@ func TestRenderCheckpoint(t *testing.T) {
 		}
 	})
 
+	t.Run("renders check results", func(t *testing.T) {
+		t.Parallel()
+		cp := &Checkpoint{
+			PhaseID: "api",
+			Status:  PhaseStatusDone,
+			Checks: []CheckResult{
+				{Name: "test", Required: true, Passed: true},
+				{Name: "lint", Passed: false},
+			},
+		}
+
+		var buf bytes.Buffer
+		RenderCheckpoint(&buf, cp)
+		output := buf.String()
+
+		if !strings.Contains(output, "Checks:") || !strings.Contains(output, "✓ test") || !strings.Contains(output, "✗ lint") {
+			t.Errorf("output missing check results:\n%s", output)
+		}
+		if !strings.Contains(output, "(optional)") {
+			t.Error("output should mark the optional check")
+		}
+	})
+
 	t.Run("renders checkpoint without review summary", func(t *testing.T) {
 		t.Parallel()
 		cp := &Checkpoint{
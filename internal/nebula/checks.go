@@ -0,0 +1,153 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// maxCheckOutputBytes bounds the output kept from one check. Build and test
+// failures report at the end, so the tail is what is kept.
+const maxCheckOutputBytes = 4000
+
+// Check is a command run against a phase's work after the agents finish and
+// before its gate, such as a build, test suite, or linter.
+type Check struct {
+	Name     string `toml:"name"`
+	Command  string `toml:"command"`  // run with sh -c in the phase's working directory
+	Required bool   `toml:"required"` // a failure fails the phase even when the reviewer approved
+}
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Name     string
+	Command  string
+	Required bool
+	Passed   bool
+	Output   string // combined stdout and stderr, trimmed to its tail
+	Duration time.Duration
+}
+
+// CheckRunner runs a phase check. A nil CheckRunner on the WorkerGroup uses
+// CommandCheckRunner.
+type CheckRunner interface {
+	RunCheck(ctx context.Context, dir string, check Check) CheckResult
+}
+
+// CommandCheckRunner runs checks as shell commands.
+type CommandCheckRunner struct{}
+
+// RunCheck runs check.Command with sh -c in dir. A non-zero exit, or a
+// command that cannot start, fails the check.
+func (CommandCheckRunner) RunCheck(ctx context.Context, dir string, check Check) CheckResult {
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "sh", "-c", check.Command)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	output := strings.TrimSpace(out.String())
+	if err != nil && output == "" {
+		output = err.Error()
+	}
+	if len(output) > maxCheckOutputBytes {
+		output = "..." + output[len(output)-maxCheckOutputBytes:]
+	}
+	return CheckResult{
+		Name:     check.Name,
+		Command:  check.Command,
+		Required: check.Required,
+		Passed:   err == nil,
+		Output:   output,
+		Duration: time.Since(start),
+	}
+}
+
+// resolveChecks returns the checks for a phase: the nebula's checks, with
+// a phase check replacing the nebula check of the same name, then the
+// phase's own.
+func resolveChecks(nebulaChecks, phaseChecks []Check) []Check {
+	if len(phaseChecks) == 0 {
+		return nebulaChecks
+	}
+	overridden := make(map[string]bool, len(phaseChecks))
+	for _, c := range phaseChecks {
+		overridden[c.Name] = true
+	}
+	checks := make([]Check, 0, len(nebulaChecks)+len(phaseChecks))
+	for _, c := range nebulaChecks {
+		if !overridden[c.Name] {
+			checks = append(checks, c)
+		}
+	}
+	return append(checks, phaseChecks...)
+}
+
+// runPhaseChecks runs a phase's checks in its working directory. It returns
+// every result, and an ErrCheckFailed error naming the required checks that
+// failed.
+func (wg *WorkerGroup) runPhaseChecks(ctx context.Context, phase *PhaseSpec, exec ResolvedExecution) ([]CheckResult, error) {
+	checks := resolveChecks(wg.Nebula.Manifest.Execution.Checks, phase.Checks)
+	if len(checks) == 0 {
+		return nil, nil
+	}
+	runner := wg.CheckRunner
+	if runner == nil {
+		runner = CommandCheckRunner{}
+	}
+	dir := exec.WorkDirUnder(wg.WorkDir)
+
+	results := make([]CheckResult, 0, len(checks))
+	var failed []string
+	for _, c := range checks {
+		r := runner.RunCheck(ctx, dir, c)
+		results = append(results, r)
+		if r.Passed {
+			fmt.Fprintf(wg.logger(), "[%s] check %s passed (%s)\n", phase.ID, c.Name, r.Duration.Round(time.Millisecond))
+			continue
+		}
+		fmt.Fprintf(wg.logger(), "[%s] check %s failed (%s):\n%s\n", phase.ID, c.Name, r.Duration.Round(time.Millisecond), r.Output)
+		if c.Required {
+			failed = append(failed, c.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrCheckFailed, strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// checkErrors validates a list of checks from field (e.g. "checks" or
+// "execution.checks"): each needs a name and a command, and names must be
+// unique within the list.
+func checkErrors(checks []Check, phaseID, sourceFile, field string) []ValidationError {
+	var errs []ValidationError
+	seen := make(map[string]bool, len(checks))
+	for i, c := range checks {
+		var problem string
+		switch {
+		case strings.TrimSpace(c.Name) == "":
+			problem = fmt.Sprintf("check %d has no name", i+1)
+		case strings.TrimSpace(c.Command) == "":
+			problem = fmt.Sprintf("check %q has no command", c.Name)
+		case seen[c.Name]:
+			problem = fmt.Sprintf("check %q is defined more than once", c.Name)
+		}
+		seen[c.Name] = true
+		if problem != "" {
+			errs = append(errs, ValidationError{
+				Category:   ValCatInvalidCheck,
+				PhaseID:    phaseID,
+				SourceFile: sourceFile,
+				Field:      field,
+				Err:        fmt.Errorf("%w: %s", ErrInvalidCheck, problem),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,137 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveChecks(t *testing.T) {
+	t.Parallel()
+
+	nebulaChecks := []Check{{Name: "build", Command: "go build ./..."}, {Name: "test", Command: "go test ./...", Required: true}}
+	got := resolveChecks(nebulaChecks, []Check{{Name: "test", Command: "go test ./pkg/..."}, {Name: "lint", Command: "golangci-lint run"}})
+	var names []string
+	for _, c := range got {
+		names = append(names, c.Name+"="+c.Command)
+	}
+	want := []string{"build=go build ./...", "test=go test ./pkg/...", "lint=golangci-lint run"}
+	if !slices.Equal(names, want) {
+		t.Errorf("resolveChecks = %v, want %v", names, want)
+	}
+	if got := resolveChecks(nebulaChecks, nil); len(got) != 2 {
+		t.Errorf("phase without checks got %d checks, want the nebula's 2", len(got))
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		checks []Check
+		want   []string
+	}{
+		{"valid", []Check{{Name: "build", Command: "make"}, {Name: "test", Command: "make test"}}, nil},
+		{"missing name", []Check{{Command: "make"}}, []string{"check 1 has no name"}},
+		{"missing command", []Check{{Name: "build"}}, []string{`check "build" has no command`}},
+		{"duplicate", []Check{{Name: "build", Command: "make"}, {Name: "build", Command: "make all"}}, []string{`check "build" is defined more than once`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := checkErrors(tt.checks, "p", "p.md", "checks")
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got %d errors, want %d: %v", len(errs), len(tt.want), errs)
+			}
+			for i, e := range errs {
+				if e.Category != ValCatInvalidCheck || !errors.Is(e.Err, ErrInvalidCheck) || !strings.Contains(e.Err.Error(), tt.want[i]) {
+					t.Errorf("error %d = %+v, want %q", i, e, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCommandCheckRunner(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r := CommandCheckRunner{}.RunCheck(context.Background(), dir, Check{Name: "pwd", Command: "pwd"})
+	if !r.Passed || !strings.HasSuffix(r.Output, filepath.Base(dir)) {
+		t.Errorf("pwd check = %+v, want a pass run in %s", r, dir)
+	}
+	r = CommandCheckRunner{}.RunCheck(context.Background(), dir, Check{Name: "fail", Command: "echo broken >&2; exit 3", Required: true})
+	if r.Passed || r.Output != "broken" || !r.Required {
+		t.Errorf("failing check = %+v, want a required failure with its stderr", r)
+	}
+}
+
+// stubCheckRunner passes every check except those named in fail.
+type stubCheckRunner struct {
+	fail map[string]bool
+}
+
+func (s stubCheckRunner) RunCheck(_ context.Context, _ string, c Check) CheckResult {
+	return CheckResult{Name: c.Name, Command: c.Command, Required: c.Required, Passed: !s.fail[c.Name]}
+}
+
+func TestWorkerGroup_PhaseChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		fail       string
+		wantStatus PhaseStatus
+		wantGate   bool
+	}{
+		{"all pass", "", PhaseStatusDone, true},
+		{"optional failure still gates", "lint", PhaseStatusDone, true},
+		{"required failure fails the phase", "test", PhaseStatusFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir: t.TempDir(),
+				Manifest: Manifest{
+					Nebula:    Info{Name: "test"},
+					Execution: Execution{Gate: GateModeReview, Checks: []Check{{Name: "test", Command: "go test ./...", Required: true}}},
+				},
+				Phases: []PhaseSpec{{ID: "a", Title: "A", Checks: []Check{{Name: "lint", Command: "go vet ./..."}}}},
+			}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+			prompter := &scriptedPrompter{actions: []GateAction{GateActionAccept}}
+			wg := NewWorkerGroup(n, state,
+				WithRunner(&mockRunner{result: &PhaseRunnerResult{}}),
+				WithPrompter(prompter),
+				WithCheckRunner(stubCheckRunner{fail: map[string]bool{tt.fail: true}}),
+				WithLogger(io.Discard))
+
+			results, _ := wg.Run(context.Background())
+			if got := state.Phases["a"].Status; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+			if tt.wantStatus == PhaseStatusFailed && (len(results) != 1 || !errors.Is(results[0].Err, ErrCheckFailed)) {
+				t.Errorf("results = %+v, want one ErrCheckFailed", results)
+			}
+			if !tt.wantGate {
+				if len(prompter.seen) != 0 {
+					t.Error("a phase with a failed required check reached its gate")
+				}
+				return
+			}
+			if len(prompter.seen) != 1 {
+				t.Fatalf("gate prompted %d times, want 1", len(prompter.seen))
+			}
+			checks := prompter.seen[0].Checks
+			if len(checks) != 2 || checks[0].Name != "test" || checks[1].Name != "lint" || checks[1].Passed == (tt.fail == "lint") {
+				t.Errorf("checkpoint checks = %+v", checks)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// hailKindDecisionNeeded is the hail kind for a question put to a human.
+const hailKindDecisionNeeded = "decision_needed"
+
+// lowConfidence reports whether result is an approval the reviewer marked
+// CONFIDENCE: low.
+func lowConfidence(result *PhaseRunnerResult) bool {
+	return result != nil && result.Report != nil && result.Report.Confidence == "low"
+}
+
+// phaseGate runs the gate of a phase that finished and reports who decided
+// it. With EscalateLowConfidence, an approval the reviewer had low
+// confidence in raises a hail, and is put to a human through the Prompter
+// even when the phase's gate mode would accept it on its own.
+func (wg *WorkerGroup) phaseGate(ctx context.Context, phase *PhaseSpec, cp *Checkpoint, result *PhaseRunnerResult) (GateAction, string, error) {
+	actor := wg.gateActor(phase)
+	if !wg.EscalateLowConfidence || !lowConfidence(result) {
+		action, err := wg.Gater.PhaseGate(ctx, phase, cp)
+		return action, actor, err
+	}
+
+	reason := fmt.Sprintf("reviewer approved phase %q with low confidence", phase.ID)
+	if summary := result.Report.Summary; summary != "" {
+		reason += ": " + summary
+	}
+	fmt.Fprintf(wg.logger(), "warning: %s\n", reason)
+	if hail := wg.hailFunc(ctx); hail != nil {
+		hail(phase.ID, fabric.Discovery{SourceTask: phase.ID, Kind: hailKindDecisionNeeded, Detail: reason})
+	}
+	if wg.Prompter == nil || actor == AuditActorHuman {
+		action, err := wg.Gater.PhaseGate(ctx, phase, cp)
+		return action, actor, err
+	}
+	defer wg.announceGate(ctx, phase.ID, "low-confidence approval awaiting gate decision")()
+	gater := &reviewGater{prompter: wg.Prompter, logger: wg.logger()}
+	action, err := gater.PhaseGate(ctx, phase, cp)
+	return action, AuditActorHuman, err
+}
@@ -0,0 +1,56 @@
+package nebula
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+func TestWorkerGroupEscalateLowConfidence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		escalate   bool
+		confidence string
+		wantPrompt bool
+		wantStatus PhaseStatus
+	}{
+		{"off accepts in trust mode", false, "low", false, PhaseStatusDone},
+		{"high confidence accepts", true, "high", false, PhaseStatusDone},
+		{"low confidence asks a human", true, "low", true, PhaseStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}, Execution: Execution{Gate: GateModeTrust}},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", Body: "phase a"}},
+			}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+			runner := &mockRunner{result: &PhaseRunnerResult{Report: &agent.ReviewReport{Confidence: tt.confidence, Summary: "unsure"}}}
+			prompter := &scriptedPrompter{actions: []GateAction{GateActionReject}}
+			var hails []fabric.Discovery
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithLogger(io.Discard), WithPrompter(prompter), WithEscalateLowConfidence(tt.escalate))
+			wg.OnHail = func(_ string, d fabric.Discovery) { hails = append(hails, d) }
+
+			// A human rejecting the phase at its gate stops the run.
+			if _, err := wg.Run(context.Background()); (err != nil) != tt.wantPrompt {
+				t.Fatalf("Run error = %v, want one only when a human rejected", err)
+			}
+			if prompted := len(prompter.seen) == 1; prompted != tt.wantPrompt {
+				t.Errorf("prompted = %v, want %v", prompted, tt.wantPrompt)
+			}
+			if raised := len(hails) == 1 && hails[0].Kind == hailKindDecisionNeeded; raised != tt.wantPrompt {
+				t.Errorf("hails = %+v, want a decision hail = %v", hails, tt.wantPrompt)
+			}
+			if got := state.Phases["a"].Status; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
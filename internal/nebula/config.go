@@ -1,15 +1,31 @@
 package nebula
 
-import "github.com/papapumpkin/quasar/internal/dag"
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
 
 // ResolvedExecution holds the fully resolved execution config for a single phase.
 type ResolvedExecution struct {
 	MaxReviewCycles int
 	MaxBudgetUSD    float64
 	Model           string
-	RoutedTier      string  // Non-empty when auto-routing selected the model.
-	ComplexityScore float64 // Zero when auto-routing was not applied.
-	AutoDecompose   bool    // true if struggle detection + auto-decomposition is enabled for this phase.
+	RoutedTier      string   // Non-empty when auto-routing selected the model.
+	ComplexityScore float64  // Zero when auto-routing was not applied.
+	AutoDecompose   bool     // true if struggle detection + auto-decomposition is enabled for this phase.
+	ProfileName     string   // Agent profile selected by the phase's assignee ("" = none).
+	Profile         Profile  // Prompt and tool overrides from that profile.
+	WorkingDir      string   // Phase directory relative to the nebula working dir ("" = nebula default).
+	RetryBudgetUSD  float64  // Spend allowed across retries of the phase. 0 = retries share the phase budget.
+	AllowedTools    []string // Coder tools for the phase. Nil = the profile's coder_tools, else the built-in set.
+	DeniedTools     []string // Tools neither agent may use in the phase.
+	OutputDir       string   // Directory for the phase's agent output logs ("" = not persisted).
+	SkipReview      bool     // Approve on the coder's pass without invoking the reviewer.
+
+	Timeout       time.Duration // Bound on each run of the phase. 0 = none.
+	TimeoutAction TimeoutAction // What a timed-out run does. "" = TimeoutActionFail.
 }
 
 // RoutingContext carries the optional data needed for adaptive model routing.
@@ -27,15 +43,17 @@ const DefaultMaxBudgetUSD = 5.0
 
 // ResolveExecution merges config from phase → nebula → global, picking the
 // first non-zero value. When routing is enabled and no explicit model is set,
-// complexity scoring selects the model tier.
+// complexity scoring selects the model tier. profiles maps phase assignees to
+// agent profiles (see Manifest.AgentProfiles); it may be nil.
 //
 // Precedence (highest wins):
 //  1. Phase-level Model (explicit pin)
-//  2. Nebula-level Execution.Model (blanket override)
-//  3. Auto-routed model (from complexity scoring, when enabled)
-//  4. Global --model flag / QUASAR_MODEL env
-//  5. Built-in default (empty string — invoker picks)
-func ResolveExecution(globalCycles int, globalBudget float64, globalModel string, neb *Execution, phase *PhaseSpec, rc *RoutingContext) ResolvedExecution {
+//  2. Model of the agent profile matching the phase's assignee
+//  3. Nebula-level Execution.Model (blanket override)
+//  4. Auto-routed model (from complexity scoring, when enabled)
+//  5. Global --model flag / QUASAR_MODEL env
+//  6. Built-in default (empty string — invoker picks)
+func ResolveExecution(globalCycles int, globalBudget float64, globalModel string, neb *Execution, phase *PhaseSpec, rc *RoutingContext, profiles map[string]Profile) ResolvedExecution {
 	r := ResolvedExecution{
 		MaxReviewCycles: DefaultMaxReviewCycles,
 		MaxBudgetUSD:    DefaultMaxBudgetUSD,
@@ -63,9 +81,24 @@ func ResolveExecution(globalCycles int, globalBudget float64, globalModel string
 		if neb.Model != "" {
 			r.Model = neb.Model
 		}
+		r.RetryBudgetUSD = neb.RetryBudgetUSD
+		r.Timeout, _, _ = parseTimeout(neb.PhaseTimeout)
+		r.TimeoutAction = neb.TimeoutAction
+	}
+
+	// The assignee's agent profile overrides nebula; unmapped assignees keep
+	// the defaults.
+	if phase != nil && phase.Assignee != "" {
+		if p, ok := profiles[phase.Assignee]; ok {
+			r.ProfileName = phase.Assignee
+			r.Profile = p
+			if p.Model != "" {
+				r.Model = p.Model
+			}
+		}
 	}
 
-	// Phase overrides nebula.
+	// Phase overrides profile and nebula.
 	if phase != nil {
 		if phase.MaxReviewCycles > 0 {
 			r.MaxReviewCycles = phase.MaxReviewCycles
@@ -76,6 +109,19 @@ func ResolveExecution(globalCycles int, globalBudget float64, globalModel string
 		if phase.Model != "" {
 			r.Model = phase.Model
 		}
+		if phase.RetryBudgetUSD > 0 {
+			r.RetryBudgetUSD = phase.RetryBudgetUSD
+		}
+		r.WorkingDir = phase.WorkingDir
+		r.AllowedTools = phase.AllowedTools
+		r.DeniedTools = phase.DeniedTools
+		r.SkipReview = phase.SkipReview
+		if d, ok, _ := parseTimeout(phase.Timeout); ok {
+			r.Timeout = d
+		}
+		if phase.TimeoutAction != "" {
+			r.TimeoutAction = phase.TimeoutAction
+		}
 	}
 
 	// Auto-routing: if enabled, no explicit model was set at any level, and we
@@ -97,8 +143,9 @@ func ResolveExecution(globalCycles int, globalBudget float64, globalModel string
 		if phase.AutoDecompose != nil {
 			r.AutoDecompose = *phase.AutoDecompose
 		}
-		// Phases produced by decomposition must not be decomposed again.
-		if phase.Decomposed {
+		// Phases produced by decomposition must not be decomposed again, and
+		// struggle detection needs reviewer findings a skip_review phase lacks.
+		if phase.Decomposed || phase.SkipReview {
 			r.AutoDecompose = false
 		}
 	}
@@ -106,6 +153,15 @@ func ResolveExecution(globalCycles int, globalBudget float64, globalModel string
 	return r
 }
 
+// WorkDirUnder returns the directory the phase runs in when the nebula runs
+// in root: root itself, or the phase's working_dir beneath it.
+func (r ResolvedExecution) WorkDirUnder(root string) string {
+	if r.WorkingDir == "" {
+		return root
+	}
+	return filepath.Join(root, filepath.FromSlash(r.WorkingDir))
+}
+
 // ResolveGate returns the effective gate mode for a phase.
 // Precedence: phase override → manifest default → GateModeTrust.
 func ResolveGate(manifest Execution, phase PhaseSpec) GateMode {
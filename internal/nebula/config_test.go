@@ -1,6 +1,7 @@
 package nebula
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -8,7 +9,7 @@ import (
 )
 
 func TestResolveExecution_BuiltInDefaults(t *testing.T) {
-	r := ResolveExecution(0, 0, "", nil, nil, nil)
+	r := ResolveExecution(0, 0, "", nil, nil, nil, nil)
 	if r.MaxReviewCycles != DefaultMaxReviewCycles {
 		t.Errorf("expected %d cycles, got %d", DefaultMaxReviewCycles, r.MaxReviewCycles)
 	}
@@ -21,7 +22,7 @@ func TestResolveExecution_BuiltInDefaults(t *testing.T) {
 }
 
 func TestResolveExecution_GlobalOverridesDefaults(t *testing.T) {
-	r := ResolveExecution(10, 20.0, "claude-sonnet", nil, nil, nil)
+	r := ResolveExecution(10, 20.0, "claude-sonnet", nil, nil, nil, nil)
 	if r.MaxReviewCycles != 10 {
 		t.Errorf("expected 10 cycles, got %d", r.MaxReviewCycles)
 	}
@@ -35,7 +36,7 @@ func TestResolveExecution_GlobalOverridesDefaults(t *testing.T) {
 
 func TestResolveExecution_NebulaOverridesGlobal(t *testing.T) {
 	neb := &Execution{MaxReviewCycles: 5, MaxBudgetUSD: 8.0, Model: "claude-opus"}
-	r := ResolveExecution(10, 20.0, "claude-sonnet", neb, nil, nil)
+	r := ResolveExecution(10, 20.0, "claude-sonnet", neb, nil, nil, nil)
 	if r.MaxReviewCycles != 5 {
 		t.Errorf("expected 5 cycles, got %d", r.MaxReviewCycles)
 	}
@@ -50,7 +51,7 @@ func TestResolveExecution_NebulaOverridesGlobal(t *testing.T) {
 func TestResolveExecution_PhaseOverridesNebula(t *testing.T) {
 	neb := &Execution{MaxReviewCycles: 5, MaxBudgetUSD: 8.0, Model: "claude-opus"}
 	phase := &PhaseSpec{MaxReviewCycles: 7, MaxBudgetUSD: 15.0, Model: "claude-haiku"}
-	r := ResolveExecution(10, 20.0, "claude-sonnet", neb, phase, nil)
+	r := ResolveExecution(10, 20.0, "claude-sonnet", neb, phase, nil, nil)
 	if r.MaxReviewCycles != 7 {
 		t.Errorf("expected 7 cycles, got %d", r.MaxReviewCycles)
 	}
@@ -66,7 +67,7 @@ func TestResolveExecution_PartialOverrides(t *testing.T) {
 	// Nebula sets cycles, phase sets budget, global sets model.
 	neb := &Execution{MaxReviewCycles: 5}
 	phase := &PhaseSpec{MaxBudgetUSD: 12.0}
-	r := ResolveExecution(0, 0, "claude-sonnet", neb, phase, nil)
+	r := ResolveExecution(0, 0, "claude-sonnet", neb, phase, nil, nil)
 	if r.MaxReviewCycles != 5 {
 		t.Errorf("expected 5 cycles from nebula, got %d", r.MaxReviewCycles)
 	}
@@ -81,7 +82,7 @@ func TestResolveExecution_PartialOverrides(t *testing.T) {
 func TestResolveExecution_ZeroPhaseDoesNotOverride(t *testing.T) {
 	neb := &Execution{MaxReviewCycles: 5, MaxBudgetUSD: 8.0}
 	phase := &PhaseSpec{MaxReviewCycles: 0, MaxBudgetUSD: 0}
-	r := ResolveExecution(0, 0, "", neb, phase, nil)
+	r := ResolveExecution(0, 0, "", neb, phase, nil, nil)
 	if r.MaxReviewCycles != 5 {
 		t.Errorf("zero phase cycles should not override nebula, got %d", r.MaxReviewCycles)
 	}
@@ -115,7 +116,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 
 	t.Run("NilRoutingContext", func(t *testing.T) {
 		t.Parallel()
-		r := ResolveExecution(0, 0, "", nil, simplePhase, nil)
+		r := ResolveExecution(0, 0, "", nil, simplePhase, nil, nil)
 		if r.Model != "" {
 			t.Errorf("expected empty model with nil routing context, got %q", r.Model)
 		}
@@ -132,7 +133,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 		rc := &RoutingContext{
 			Routing: TierConfig{Enabled: false, Tiers: DefaultTiers},
 		}
-		r := ResolveExecution(0, 0, "", nil, simplePhase, rc)
+		r := ResolveExecution(0, 0, "", nil, simplePhase, rc, nil)
 		if r.Model != "" {
 			t.Errorf("expected empty model with routing disabled, got %q", r.Model)
 		}
@@ -144,7 +145,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 	t.Run("RoutingSelectsFastTier", func(t *testing.T) {
 		t.Parallel()
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "", nil, simplePhase, rc)
+		r := ResolveExecution(0, 0, "", nil, simplePhase, rc, nil)
 		if r.Model != "claude-haiku" {
 			t.Errorf("expected claude-haiku for simple phase, got %q", r.Model)
 		}
@@ -159,7 +160,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 	t.Run("RoutingSelectsHeavyTier", func(t *testing.T) {
 		t.Parallel()
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "", nil, complexPhase, rc)
+		r := ResolveExecution(0, 0, "", nil, complexPhase, rc, nil)
 		if r.RoutedTier != "heavy" {
 			t.Errorf("expected heavy tier for complex phase, got %q", r.RoutedTier)
 		}
@@ -177,7 +178,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 			Model: "claude-custom",
 		}
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "", nil, phase, rc)
+		r := ResolveExecution(0, 0, "", nil, phase, rc, nil)
 		if r.Model != "claude-custom" {
 			t.Errorf("phase model should win, got %q", r.Model)
 		}
@@ -190,7 +191,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 		t.Parallel()
 		neb := &Execution{Model: "claude-blanket"}
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "", neb, simplePhase, rc)
+		r := ResolveExecution(0, 0, "", neb, simplePhase, rc, nil)
 		if r.Model != "claude-blanket" {
 			t.Errorf("nebula model should win, got %q", r.Model)
 		}
@@ -202,7 +203,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 	t.Run("GlobalModelOverridesRouting", func(t *testing.T) {
 		t.Parallel()
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "claude-global", nil, simplePhase, rc)
+		r := ResolveExecution(0, 0, "claude-global", nil, simplePhase, rc, nil)
 		if r.Model != "claude-global" {
 			t.Errorf("global model should win, got %q", r.Model)
 		}
@@ -217,7 +218,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 			Routing: enabledRouting,
 			DAG:     nil, // depth signal becomes 0
 		}
-		r := ResolveExecution(0, 0, "", nil, simplePhase, rc)
+		r := ResolveExecution(0, 0, "", nil, simplePhase, rc, nil)
 		if r.Model == "" {
 			t.Error("expected a routed model even with nil DAG")
 		}
@@ -246,7 +247,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 			Routing: enabledRouting,
 			DAG:     d,
 		}
-		r := ResolveExecution(0, 0, "", nil, phase, rc)
+		r := ResolveExecution(0, 0, "", nil, phase, rc, nil)
 		if r.RoutedTier == "" {
 			t.Error("expected routing to select a tier")
 		}
@@ -258,7 +259,7 @@ func TestResolveExecution_Routing(t *testing.T) {
 	t.Run("NilPhaseSkipsRouting", func(t *testing.T) {
 		t.Parallel()
 		rc := &RoutingContext{Routing: enabledRouting}
-		r := ResolveExecution(0, 0, "", nil, nil, rc)
+		r := ResolveExecution(0, 0, "", nil, nil, rc, nil)
 		if r.RoutedTier != "" {
 			t.Errorf("expected empty routed tier with nil phase, got %q", r.RoutedTier)
 		}
@@ -322,6 +323,12 @@ func TestResolveExecution_AutoDecompose(t *testing.T) {
 			phase:      &PhaseSpec{ID: "a", AutoDecompose: &trueVal, Decomposed: true},
 			wantDecomp: false,
 		},
+		{
+			name:       "SkipReviewPhaseBlocksManifestDefault",
+			neb:        &Execution{AutoDecompose: true},
+			phase:      &PhaseSpec{ID: "a", SkipReview: true},
+			wantDecomp: false,
+		},
 		{
 			name:       "NilPhase",
 			neb:        &Execution{AutoDecompose: true},
@@ -339,10 +346,72 @@ func TestResolveExecution_AutoDecompose(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			r := ResolveExecution(0, 0, "", tt.neb, tt.phase, nil)
+			r := ResolveExecution(0, 0, "", tt.neb, tt.phase, nil, nil)
 			if r.AutoDecompose != tt.wantDecomp {
 				t.Errorf("AutoDecompose = %v, want %v", r.AutoDecompose, tt.wantDecomp)
 			}
 		})
 	}
 }
+
+func TestResolveExecution_AgentProfiles(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[string]Profile{
+		"security": {Model: "claude-opus", ReviewerPrompt: "Audit for vulnerabilities.", ReviewerTools: []string{"Read"}},
+		"docs":     {CoderPrompt: "Write clear prose."},
+	}
+	neb := &Execution{Model: "claude-sonnet"}
+
+	tests := []struct {
+		name        string
+		phase       *PhaseSpec
+		wantModel   string
+		wantProfile string
+	}{
+		{"profile model overrides nebula", &PhaseSpec{ID: "a", Assignee: "security"}, "claude-opus", "security"},
+		{"phase model overrides profile", &PhaseSpec{ID: "a", Assignee: "security", Model: "claude-haiku"}, "claude-haiku", "security"},
+		{"profile without model keeps nebula model", &PhaseSpec{ID: "a", Assignee: "docs"}, "claude-sonnet", "docs"},
+		{"unmapped assignee falls back", &PhaseSpec{ID: "a", Assignee: "alice"}, "claude-sonnet", ""},
+		{"no assignee", &PhaseSpec{ID: "a"}, "claude-sonnet", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ResolveExecution(0, 0, "", neb, tt.phase, nil, profiles)
+			if r.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", r.Model, tt.wantModel)
+			}
+			if r.ProfileName != tt.wantProfile {
+				t.Errorf("ProfileName = %q, want %q", r.ProfileName, tt.wantProfile)
+			}
+			if tt.wantProfile != "" && r.Profile.CoderPrompt != profiles[tt.wantProfile].CoderPrompt {
+				t.Errorf("Profile = %+v, want %+v", r.Profile, profiles[tt.wantProfile])
+			}
+		})
+	}
+}
+
+func TestResolveExecution_RetryBudget(t *testing.T) {
+	neb := &Execution{RetryBudgetUSD: 2}
+	if r := ResolveExecution(0, 0, "", neb, &PhaseSpec{}, nil, nil); r.RetryBudgetUSD != 2 {
+		t.Errorf("expected nebula retry budget $2.00, got $%.2f", r.RetryBudgetUSD)
+	}
+	if r := ResolveExecution(0, 0, "", neb, &PhaseSpec{RetryBudgetUSD: 0.5}, nil, nil); r.RetryBudgetUSD != 0.5 {
+		t.Errorf("expected phase retry budget $0.50, got $%.2f", r.RetryBudgetUSD)
+	}
+	if r := ResolveExecution(0, 0, "", nil, nil, nil, nil); r.RetryBudgetUSD != 0 {
+		t.Errorf("expected no retry budget, got $%.2f", r.RetryBudgetUSD)
+	}
+}
+
+func TestResolveExecution_Tools(t *testing.T) {
+	phase := &PhaseSpec{AllowedTools: []string{"Read", "Bash(make deploy)"}, DeniedTools: []string{"WebFetch"}}
+	r := ResolveExecution(0, 0, "", nil, phase, nil, nil)
+	if !slices.Equal(r.AllowedTools, phase.AllowedTools) || !slices.Equal(r.DeniedTools, phase.DeniedTools) {
+		t.Errorf("tools = %v / %v, want the phase's lists", r.AllowedTools, r.DeniedTools)
+	}
+	if r := ResolveExecution(0, 0, "", nil, &PhaseSpec{}, nil, nil); r.AllowedTools != nil || r.DeniedTools != nil {
+		t.Errorf("phase without tool lists resolved %v / %v, want nil", r.AllowedTools, r.DeniedTools)
+	}
+}
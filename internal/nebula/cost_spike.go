@@ -0,0 +1,105 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// hailKindBlocker is a copy of loop.HailBlocker, the hail kind the TUI
+// highlights as needing a human. nebula cannot import loop (loop imports ui,
+// which imports nebula), so the two values must be kept in step by hand.
+const hailKindBlocker = "blocker"
+
+// costBaseline is what phases cost in the previous recorded run.
+type costBaseline struct {
+	perPhase map[string]float64 // phase ID -> cost of its last recorded attempt
+	average  float64            // mean cost of the recorded attempts; 0 = none
+}
+
+// loadCostBaseline reads the previous run's phase costs from the metrics
+// file in dir.
+func loadCostBaseline(dir string) (*costBaseline, error) {
+	m, err := LoadMetrics(dir)
+	if err != nil {
+		return nil, err
+	}
+	b := &costBaseline{perPhase: make(map[string]float64, len(m.Phases))}
+	total, n := 0.0, 0
+	for _, p := range m.Phases {
+		if p.CostUSD <= 0 {
+			continue
+		}
+		b.perPhase[p.PhaseID] = p.CostUSD
+		total += p.CostUSD
+		n++
+	}
+	if n > 0 {
+		b.average = total / float64(n)
+	}
+	return b, nil
+}
+
+// expectedCost returns what phaseID is expected to cost: its own cost in
+// the previous run, else the previous run's average phase cost, else the
+// average cost of the phases already done in this run. It returns 0 when
+// there is nothing to compare against.
+func (wg *WorkerGroup) expectedCost(phaseID string) float64 {
+	if b := wg.costBaseline; b != nil {
+		if c, ok := b.perPhase[phaseID]; ok {
+			return c
+		}
+		if b.average > 0 {
+			return b.average
+		}
+	}
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	total, n := 0.0, 0
+	for id, ps := range wg.State.Phases {
+		if id != phaseID && ps.Status == PhaseStatusDone && ps.CostUSD > 0 {
+			total += ps.CostUSD
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// checkCostSpike pauses the run and raises a blocker hail when a run of
+// phaseID cost more than execution.cost_spike_multiplier times what the
+// phase is expected to cost. It runs only once a phase has finished, so it
+// cannot stop a phase whose run is still spending; the phase budget is what
+// bounds a single run. Phases already running finish; no new phase starts
+// until the PAUSE file is removed. It does nothing when the safeguard is
+// off or there is no expected cost yet.
+func (wg *WorkerGroup) checkCostSpike(ctx context.Context, phaseID string, cost float64) {
+	mult := wg.Nebula.Manifest.Execution.CostSpikeMultiplier
+	if mult <= 0 || cost <= 0 {
+		return
+	}
+	expected := wg.expectedCost(phaseID)
+	if expected <= 0 || cost <= mult*expected {
+		return
+	}
+
+	reason := fmt.Sprintf("phase %q spent $%.2f, %.1fx its expected $%.2f (limit %.1fx); run paused for review",
+		phaseID, cost, cost/expected, expected, mult)
+	fmt.Fprintf(wg.logger(), "warning: %s\n", reason)
+	pausePath := filepath.Join(wg.Nebula.Dir, "PAUSE")
+	if err := os.WriteFile(pausePath, []byte(reason+"\n"), 0644); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: writing PAUSE file: %v\n", err)
+	}
+	if hail := wg.hailFunc(ctx); hail != nil {
+		hail(phaseID, fabric.Discovery{
+			SourceTask: phaseID,
+			Kind:       hailKindBlocker,
+			Detail:     reason + "\n- Remove the PAUSE file to continue\n- Create a STOP file to end the run",
+		})
+	}
+}
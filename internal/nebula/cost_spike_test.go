@@ -0,0 +1,70 @@
+package nebula
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+func TestCheckCostSpike(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mult      float64
+		history   []PhaseMetrics
+		cost      float64
+		wantPause bool
+	}{
+		{"off", 0, nil, 100, false},
+		{"own history within limit", 3, []PhaseMetrics{{PhaseID: "x", CostUSD: 2}}, 5, false},
+		{"own history exceeded", 3, []PhaseMetrics{{PhaseID: "x", CostUSD: 2}}, 7, true},
+		{"history average exceeded", 3, []PhaseMetrics{{PhaseID: "a", CostUSD: 1}, {PhaseID: "b", CostUSD: 3}}, 7, true},
+		{"run average exceeded", 3, nil, 4, true},
+		{"run average within limit", 3, nil, 2.5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			if tt.history != nil {
+				if err := SaveMetrics(dir, &Metrics{NebulaName: "n", Phases: tt.history}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			n := &Nebula{Dir: dir, Manifest: Manifest{Execution: Execution{CostSpikeMultiplier: tt.mult}}}
+			// Phases done earlier in this run average $1.
+			state := &State{Phases: map[string]*PhaseState{
+				"done": {Status: PhaseStatusDone, CostUSD: 1},
+				"x":    {Status: PhaseStatusInProgress},
+			}}
+			var hails []fabric.Discovery
+			wg := NewWorkerGroup(n, state, WithLogger(io.Discard))
+			wg.OnHail = func(_ string, d fabric.Discovery) { hails = append(hails, d) }
+			if tt.mult > 0 {
+				b, err := loadCostBaseline(dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				wg.costBaseline = b
+			}
+
+			wg.checkCostSpike(context.Background(), "x", tt.cost)
+
+			_, err := os.Stat(filepath.Join(dir, "PAUSE"))
+			if paused := err == nil; paused != tt.wantPause {
+				t.Errorf("paused = %v, want %v", paused, tt.wantPause)
+			}
+			if tt.wantPause && (len(hails) != 1 || hails[0].Kind != hailKindBlocker) {
+				t.Errorf("hails = %+v, want one blocker", hails)
+			}
+			if !tt.wantPause && len(hails) != 0 {
+				t.Errorf("unexpected hails %+v", hails)
+			}
+		})
+	}
+}
@@ -19,10 +19,13 @@ type Dashboard struct {
 	MaxBudgetUSD float64
 	IsTTY        bool // controls whether to use ANSI cursor movement
 	AppendOnly   bool // when true, never use cursor movement (watch mode scroll-back)
+	Trace        bool // when true, print a plain wave-by-wave trace instead (see renderTrace)
+	MaxWorkers   int  // worker limit, used for wave parallelism in the trace
 
 	mu        sync.Mutex
-	lineCount int  // number of lines rendered in the last draw (for cursor-up in TTY mode)
-	rendered  bool // whether the dashboard has been rendered at least once
+	lineCount int        // number of lines rendered in the last draw (for cursor-up in TTY mode)
+	rendered  bool       // whether the dashboard has been rendered at least once
+	trace     *waveTrace // trace bookkeeping; nil until the first trace render
 }
 
 // NewDashboard creates a new Dashboard wired to the given nebula and state.
@@ -46,11 +49,14 @@ func (d *Dashboard) ProgressCallback() ProgressFunc {
 
 // Render draws the full dashboard. Thread-safe.
 // In AppendOnly mode (watch), always uses plain rendering for scroll-back compatibility.
+// In Trace mode, prints only the trace lines for what changed since the last call.
 func (d *Dashboard) Render() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.AppendOnly || !d.IsTTY {
+	if d.Trace {
+		d.renderTrace()
+	} else if d.AppendOnly || !d.IsTTY {
 		d.renderPlain()
 	} else {
 		d.renderTTY()
@@ -59,12 +65,12 @@ func (d *Dashboard) Render() {
 
 // Pause clears the dashboard state so that gate prompts or other output
 // can write to stderr without visual conflicts. Thread-safe.
-// In AppendOnly mode this is a no-op because there is no cursor movement to undo.
+// In AppendOnly and Trace modes this is a no-op because there is no cursor movement to undo.
 func (d *Dashboard) Pause() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.AppendOnly {
+	if d.AppendOnly || d.Trace {
 		return
 	}
 
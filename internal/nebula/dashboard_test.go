@@ -403,3 +403,49 @@ func TestStatusIcon(t *testing.T) {
 		})
 	}
 }
+
+func TestDashboard_Trace(t *testing.T) {
+	t.Parallel()
+
+	n := newTestNebula("trace-test", []PhaseSpec{
+		{ID: "a"},
+		{ID: "b"},
+		{ID: "c", DependsOn: []string{"a", "b"}},
+	})
+	state := newTestState(map[string]*PhaseState{
+		"a": {Status: PhaseStatusPending},
+		"b": {Status: PhaseStatusPending},
+		"c": {Status: PhaseStatusPending},
+	}, 0)
+
+	var buf bytes.Buffer
+	d := NewDashboard(&buf, n, state, 0, true)
+	d.Trace = true
+	d.MaxWorkers = 2
+
+	step := func(id string, status PhaseStatus, cost float64) {
+		state.Phases[id].Status = status
+		state.Phases[id].CostUSD = cost
+		d.Render()
+	}
+	step("a", PhaseStatusInProgress, 0)
+	step("b", PhaseStatusInProgress, 0)
+	step("a", PhaseStatusDone, 0.25)
+	step("b", PhaseStatusFailed, 0.50)
+	step("c", PhaseStatusSkipped, 0)
+
+	want := []string{
+		"[wave 1] start: a, b | parallelism 2",
+		"[wave 1] done    a | $0.25 | 0s",
+		"[wave 1] failed  b | $0.50 | 0s",
+		"[wave 1] end: 1 done, 1 failed, 0 skipped | $0.75 | 0s",
+		"[wave 2] skipped c",
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("trace =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Error("trace contains ANSI escapes")
+	}
+}
@@ -0,0 +1,161 @@
+package nebula
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
+
+// waveTrace is the Dashboard's memory of what trace mode has printed.
+type waveTrace struct {
+	phaseCount int            // len(Nebula.Phases) when waves were computed
+	waves      []Wave         // dependency waves of the nebula
+	waveOf     map[string]int // phase ID -> index into waves
+	status     map[string]PhaseStatus
+	startedAt  map[string]time.Time
+	waveStart  map[int]time.Time // wave index -> time its first phase started
+	open       map[int]bool      // waves started but not yet summarized
+}
+
+// renderTrace prints one line for each change since the last render: a
+// banner when a wave's first phase starts, a line per finished phase, and
+// a summary once every phase of a started wave has finished. Lines carry no
+// ANSI escapes and start with "[wave N]", so the trace reads cleanly in CI
+// logs and can be grepped by wave. Waves can overlap because phases start as
+// soon as their own dependencies are done. Must be called with d.mu held.
+func (d *Dashboard) renderTrace() {
+	t := d.traceState()
+	now := time.Now()
+	for _, p := range d.Nebula.Phases {
+		ps := d.State.Phases[p.ID]
+		if ps == nil || ps.Status == t.status[p.ID] {
+			continue
+		}
+		t.status[p.ID] = ps.Status
+		w := t.waveOf[p.ID]
+		switch ps.Status {
+		case PhaseStatusInProgress:
+			t.startedAt[p.ID] = now
+			if _, started := t.waveStart[w]; !started {
+				t.waveStart[w] = now
+				d.traceBanner(w)
+			}
+			t.open[w] = true
+		case PhaseStatusDone, PhaseStatusFailed, PhaseStatusSkipped:
+			d.tracePhase(w, p.ID, ps, now)
+			if t.open[w] && d.waveFinished(w) {
+				d.traceSummary(w, now)
+				delete(t.open, w)
+			}
+		}
+	}
+}
+
+// traceState returns the trace bookkeeping, computing the waves on first use
+// and again after phases are hot-added. Phases that are already finished
+// when tracing starts are not reported.
+func (d *Dashboard) traceState() *waveTrace {
+	t := d.trace
+	if t != nil && t.phaseCount == len(d.Nebula.Phases) {
+		return t
+	}
+	if t == nil {
+		t = &waveTrace{
+			status:    make(map[string]PhaseStatus),
+			startedAt: make(map[string]time.Time),
+			waveStart: make(map[int]time.Time),
+			open:      make(map[int]bool),
+		}
+		for id, ps := range d.State.Phases {
+			if ps.Status == PhaseStatusDone || ps.Status == PhaseStatusFailed || ps.Status == PhaseStatusSkipped {
+				t.status[id] = ps.Status
+			}
+		}
+		d.trace = t
+	}
+	t.phaseCount = len(d.Nebula.Phases)
+	dg, err := phasesToDAG(d.Nebula.Phases)
+	if err == nil {
+		t.waves, err = dg.ComputeWaves()
+	}
+	if err != nil {
+		// Nebula is already validated; fall back to a single wave.
+		fmt.Fprintf(d.Writer, "warning: trace DAG build: %v\n", err)
+		ids := make([]string, len(d.Nebula.Phases))
+		for i, p := range d.Nebula.Phases {
+			ids[i] = p.ID
+		}
+		t.waves = []Wave{{Number: 1, NodeIDs: ids}}
+	}
+	t.waveOf = make(map[string]int, t.phaseCount)
+	for i, w := range t.waves {
+		for _, id := range w.NodeIDs {
+			t.waveOf[id] = i
+		}
+	}
+	return t
+}
+
+// traceBanner prints the start of wave w with its phases and how many of
+// them can run at once.
+func (d *Dashboard) traceBanner(w int) {
+	wave := d.trace.waves[w]
+	dg, err := phasesToDAG(d.Nebula.Phases)
+	if err != nil {
+		dg = dag.New()
+	}
+	workers := max(d.MaxWorkers, 1)
+	fmt.Fprintf(d.Writer, "[wave %d] start: %s | parallelism %d\n",
+		wave.Number, strings.Join(wave.NodeIDs, ", "), EffectiveParallelism(wave, d.Nebula.Phases, dg, workers))
+}
+
+// tracePhase prints how a phase of wave w finished.
+func (d *Dashboard) tracePhase(w int, id string, ps *PhaseState, now time.Time) {
+	line := fmt.Sprintf("[wave %d] %-7s %s", d.trace.waves[w].Number, ps.Status, id)
+	if ps.Status == PhaseStatusSkipped {
+		if ps.SkipReason != "" {
+			line += " (" + ps.SkipReason + ")"
+		}
+		fmt.Fprintln(d.Writer, line)
+		return
+	}
+	line += fmt.Sprintf(" | $%.2f", ps.CostUSD)
+	if start, ok := d.trace.startedAt[id]; ok {
+		line += " | " + now.Sub(start).Round(time.Second).String()
+	}
+	if ps.DoneReason != "" {
+		line += " | " + ps.DoneReason
+	}
+	fmt.Fprintln(d.Writer, line)
+}
+
+// waveFinished reports whether every phase of wave w is done, failed, or
+// skipped.
+func (d *Dashboard) waveFinished(w int) bool {
+	for _, id := range d.trace.waves[w].NodeIDs {
+		switch d.trace.status[id] {
+		case PhaseStatusDone, PhaseStatusFailed, PhaseStatusSkipped:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// traceSummary prints the outcome of wave w.
+func (d *Dashboard) traceSummary(w int, now time.Time) {
+	wave := d.trace.waves[w]
+	counts := make(map[PhaseStatus]int)
+	cost := 0.0
+	for _, id := range wave.NodeIDs {
+		counts[d.trace.status[id]]++
+		if ps := d.State.Phases[id]; ps != nil {
+			cost += ps.CostUSD
+		}
+	}
+	fmt.Fprintf(d.Writer, "[wave %d] end: %d done, %d failed, %d skipped | $%.2f | %s\n",
+		wave.Number, counts[PhaseStatusDone], counts[PhaseStatusFailed], counts[PhaseStatusSkipped],
+		cost, now.Sub(d.trace.waveStart[w]).Round(time.Second))
+}
@@ -3,13 +3,16 @@
 package nebula
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/papapumpkin/quasar/internal/beads"
 	"github.com/papapumpkin/quasar/internal/dag"
+	"github.com/papapumpkin/quasar/internal/fabric"
 )
 
 // DecomposeOp describes a decomposition operation to be applied to the DAG.
@@ -214,3 +217,165 @@ func annotateDecomposed(neb *Nebula, phaseID string) {
 	annotated := s[:idx+len(marker)] + "decomposed = true\n" + s[idx+len(marker):]
 	_ = os.WriteFile(path, []byte(annotated), 0o644)
 }
+
+// shouldDecompose checks whether a phase is eligible for auto-decomposition.
+// Decomposition is disabled for phases that were themselves produced by
+// decomposition (to prevent infinite recursion), and when the manifest or
+// per-phase override disables auto_decompose.
+func (wg *WorkerGroup) shouldDecompose(phase *PhaseSpec) bool {
+	if phase.Decomposed {
+		return false
+	}
+	if wg.Invoker == nil {
+		return false
+	}
+	// Per-phase override takes precedence over the manifest default.
+	if phase.AutoDecompose != nil {
+		return *phase.AutoDecompose
+	}
+	return wg.Nebula.Manifest.Execution.AutoDecompose
+}
+
+// decomposePhase invokes the architect to decompose a struggling phase and
+// applies the resulting sub-phases to the DAG. It returns the IDs of the
+// newly created sub-phases. Must NOT be called with wg.mu held.
+func (wg *WorkerGroup) decomposePhase(ctx context.Context, phaseID string, result *PhaseRunnerResult) ([]string, error) {
+	wg.mu.Lock()
+	phasesByID := wg.tracker.PhasesByIDMap()
+	phase := phasesByID[phaseID]
+	nebSnap := wg.Nebula.Snapshot()
+	wg.mu.Unlock()
+
+	if phase == nil {
+		return nil, fmt.Errorf("phase %q not found in tracker", phaseID)
+	}
+
+	req := ArchitectRequest{
+		Mode:           ArchitectModeDecompose,
+		UserPrompt:     phase.Body,
+		Nebula:         nebSnap,
+		PhaseID:        phaseID,
+		StruggleReason: result.StruggleReason,
+		CyclesUsed:     result.CyclesUsed,
+		AllFindings:    result.AllFindings,
+		CostSoFar:      result.TotalCostUSD,
+	}
+
+	decomp, err := RunDecompose(ctx, wg.Invoker, req)
+	if err != nil {
+		return nil, fmt.Errorf("running decompose for %s: %w", phaseID, err)
+	}
+
+	// Build the DecomposeOp from the architect result.
+	op := DecomposeOp{
+		OriginalPhaseID: phaseID,
+		SubPhases:       make([]SubPhaseEntry, len(decomp.SubPhases)),
+	}
+	for i, sp := range decomp.SubPhases {
+		sp.PhaseSpec.Decomposed = true
+		op.SubPhases[i] = SubPhaseEntry{
+			Spec:     sp.PhaseSpec,
+			Body:     sp.Body,
+			Filename: sp.Filename,
+		}
+	}
+
+	// Apply decomposition under lock.
+	wg.mu.Lock()
+
+	// Build live graph if hot-reload state is available, otherwise build from phases.
+	var liveGraph *dag.DAG
+	var livePhasesMap map[string]*PhaseSpec
+	if wg.hotReload != nil && wg.hotReload.liveGraph != nil {
+		liveGraph = wg.hotReload.liveGraph
+		livePhasesMap = wg.hotReload.livePhasesByID
+	}
+	if liveGraph == nil {
+		// Fallback: build from phases.
+		g, _ := phasesToDAG(wg.Nebula.Phases)
+		liveGraph = g
+		livePhasesMap = PhasesByID(wg.Nebula.Phases)
+	}
+
+	subIDs, err := ApplyDecompositionToNebula(wg.Nebula, liveGraph, op, livePhasesMap)
+	if err != nil {
+		wg.mu.Unlock()
+		return nil, fmt.Errorf("applying decomposition for %s: %w", phaseID, err)
+	}
+	wg.mu.Unlock()
+
+	// Set fabric state for the original phase (no lock needed for fabric RPCs).
+	if wg.Fabric != nil {
+		if stateErr := wg.Fabric.SetPhaseState(ctx, phaseID, fabric.StateDecomposed); stateErr != nil {
+			fmt.Fprintf(wg.logger(), "warning: failed to set fabric state for decomposed phase %s: %v\n", phaseID, stateErr)
+		}
+	}
+
+	// Create beads for sub-phases outside the lock to avoid panics from
+	// a deferred Unlock when the RPC is in an unlocked state.
+	type beadResult struct {
+		specID string
+		beadID string
+		body   string
+		ok     bool
+	}
+	var beadResults []beadResult
+	for _, sp := range op.SubPhases {
+		br := beadResult{specID: sp.Spec.ID}
+		if wg.BeadsClient != nil {
+			id, createErr := wg.BeadsClient.Create(ctx, sp.Spec.Title, beads.CreateOpts{
+				Description: sp.Body,
+				Type:        sp.Spec.Type,
+				Labels:      sp.Spec.Labels,
+				Assignee:    sp.Spec.Assignee,
+				Priority:    priorityStr(sp.Spec.Priority),
+			})
+			if createErr != nil {
+				fmt.Fprintf(wg.logger(), "warning: failed to create bead for sub-phase %q: %v\n", sp.Spec.ID, createErr)
+				continue
+			}
+			br.beadID = id
+		}
+		br.ok = true
+		beadResults = append(beadResults, br)
+	}
+
+	// Apply bead results and fabric state under lock.
+	wg.mu.Lock()
+	for _, br := range beadResults {
+		if !br.ok {
+			continue
+		}
+		wg.State.SetPhaseState(br.specID, br.beadID, PhaseStatusPending)
+
+		// Set fabric state for sub-phase.
+		if wg.Fabric != nil {
+			if stateErr := wg.Fabric.SetPhaseState(ctx, br.specID, fabric.StateQueued); stateErr != nil {
+				fmt.Fprintf(wg.logger(), "warning: failed to set fabric state for sub-phase %s: %v\n", br.specID, stateErr)
+			}
+		}
+	}
+
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+	wg.mu.Unlock()
+
+	// Notify TUI of hot-added sub-phases (callbacks must not hold the lock).
+	if wg.OnHotAdd != nil {
+		for _, sp := range op.SubPhases {
+			wg.OnHotAdd(sp.Spec.ID, sp.Spec.Title, sp.Spec.DependsOn)
+		}
+	}
+
+	// Post a hail if configured.
+	if wg.OnHail != nil {
+		wg.OnHail(phaseID, fabric.Discovery{
+			Kind:   "decomposition",
+			Detail: fmt.Sprintf("Phase %q decomposed into %d sub-phases: %s (reason: %s)", phaseID, len(subIDs), strings.Join(subIDs, ", "), result.StruggleReason),
+		})
+	}
+
+	fmt.Fprintf(wg.logger(), "phase %q decomposed into %d sub-phases: %s\n", phaseID, len(subIDs), strings.Join(subIDs, ", "))
+
+	return subIDs, nil
+}
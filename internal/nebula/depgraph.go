@@ -149,7 +149,7 @@ func inferScopeOverlapEdges(phases []PhaseSpec) []DepEdge {
 			if hasDep(phases[j].DependsOn, phases[i].ID) || hasDep(phases[i].DependsOn, phases[j].ID) {
 				continue
 			}
-			if _, _, overlaps := scopesOverlap(phases[i].Scope, phases[j].Scope); overlaps {
+			if _, _, overlaps := scopesOverlap(phases[i].rootedScope(), phases[j].rootedScope()); overlaps {
 				edges = append(edges, DepEdge{
 					From:   phases[j].ID,
 					To:     phases[i].ID,
@@ -198,6 +198,8 @@ func inferFileMentionEdges(phases []PhaseSpec) []DepEdge {
 		if len(mentions) == 0 {
 			continue
 		}
+		// Mentions are relative to the mentioning phase's working_dir.
+		rooted := PhaseSpec{WorkingDir: phases[i].WorkingDir, Scope: mentions}.rootedScope()
 		for j := range phases {
 			if i == j {
 				continue
@@ -209,8 +211,8 @@ func inferFileMentionEdges(phases []PhaseSpec) []DepEdge {
 			if hasDep(phases[i].DependsOn, phases[j].ID) {
 				continue
 			}
-			for _, mention := range mentions {
-				if _, _, overlaps := scopesOverlap([]string{mention}, phases[j].Scope); overlaps {
+			for k, mention := range mentions {
+				if _, _, overlaps := scopesOverlap(rooted[k:k+1], phases[j].rootedScope()); overlaps {
 					edges = append(edges, DepEdge{
 						From:   phases[i].ID,
 						To:     phases[j].ID,
@@ -0,0 +1,86 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// drainEligible filters eligible down to the phases a drain lets finish.
+// The first call after a DRAIN intervention fixes that set: the phases in
+// flight, plus the eligible phases in the DAG wave the run has reached, so
+// the current wave completes. Later waves and hot-added phases stay pending
+// for a resume. A phase sent back by a gate retry is still in the set and
+// runs again.
+func (wg *WorkerGroup) drainEligible(eligible []string) []string {
+	if wg.drainSet == nil {
+		wg.drainSet = wg.buildDrainSet(eligible)
+		fmt.Fprintf(wg.logger(), "\n── Draining ───────────────────────────────────────\n")
+		fmt.Fprintf(wg.logger(), "   Finishing %d running or ready phase(s); the rest stay pending.\n", len(wg.drainSet))
+		fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+	}
+	kept := make([]string, 0, len(eligible))
+	for _, id := range eligible {
+		if wg.drainSet[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// buildDrainSet returns the phases a drain lets finish. The current wave
+// is the latest one among phases started in this run; before anything has
+// started, it is the earliest wave with an eligible phase.
+func (wg *WorkerGroup) buildDrainSet(eligible []string) map[string]bool {
+	waveOf := make(map[string]int)
+	for _, w := range wg.tychoScheduler.Waves {
+		for _, id := range w.NodeIDs {
+			waveOf[id] = w.Number
+		}
+	}
+
+	set := make(map[string]bool)
+	current := 0
+	wg.mu.Lock()
+	for id := range wg.tracker.InFlight() {
+		set[id] = true
+		current = max(current, waveOf[id])
+	}
+	for _, r := range wg.results {
+		current = max(current, waveOf[r.PhaseID])
+	}
+	wg.mu.Unlock()
+	if current == 0 {
+		for _, id := range eligible {
+			if w := waveOf[id]; w > 0 && (current == 0 || w < current) {
+				current = w
+			}
+		}
+	}
+
+	for _, id := range eligible {
+		if w := waveOf[id]; w > 0 && w <= current {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// handleDrained saves state, removes the DRAIN file, and prints how to
+// resume. Unlike a gate skip, the phases that did not run keep their
+// status, so the next apply picks them up.
+func (wg *WorkerGroup) handleDrained() {
+	wg.mu.Lock()
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+	wg.mu.Unlock()
+
+	drainPath := filepath.Join(wg.Nebula.Dir, "DRAIN")
+	if err := os.Remove(drainPath); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(wg.logger(), "warning: failed to remove DRAIN file: %v\n", err)
+	}
+
+	fmt.Fprintf(wg.logger(), "\n── Nebula drained ─────────────────────────────────\n")
+	fmt.Fprintf(wg.logger(), "   Remaining phases are pending. Resume with: quasar nebula apply\n")
+	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+}
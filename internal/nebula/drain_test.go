@@ -0,0 +1,85 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWorkerGroup_DrainIntervention(t *testing.T) {
+	t.Parallel()
+
+	// a and c are ready at once; b waits on a, d waits on c.
+	phases := []PhaseSpec{
+		{ID: "a", Body: "phase a"},
+		{ID: "b", Body: "phase b", DependsOn: []string{"a"}},
+		{ID: "c", Body: "phase c"},
+		{ID: "d", Body: "phase d", DependsOn: []string{"c"}},
+	}
+	tests := []struct {
+		name     string
+		midRun   bool // send DRAIN while a is running instead of before Run
+		wantRuns []string
+	}{
+		{"before the first dispatch", false, []string{"bead-a", "bead-c"}},
+		{"while a phase runs", true, []string{"bead-a", "bead-c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			n := &Nebula{Dir: dir, Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: phases}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+			for _, p := range phases {
+				state.Phases[p.ID] = &PhaseState{BeadID: "bead-" + p.ID, Status: PhaseStatusCreated}
+			}
+			drainFile := filepath.Join(dir, "DRAIN")
+			if err := os.WriteFile(drainFile, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			w := newTestWatcher(dir)
+			runner := &mockRunner{result: &PhaseRunnerResult{}}
+			if tt.midRun {
+				// c finishes only after a has sent DRAIN, so d cannot start
+				// before the drain is seen.
+				drained := make(chan struct{})
+				runner.resultFunc = func(beadID string) *PhaseRunnerResult {
+					switch beadID {
+					case "bead-a":
+						w.SendIntervention(InterventionDrain)
+						close(drained)
+					case "bead-c":
+						<-drained
+					}
+					return &PhaseRunnerResult{}
+				}
+			} else {
+				w.interventions <- InterventionDrain
+			}
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithMaxWorkers(2), WithWatcher(w), WithLogger(io.Discard))
+
+			_, err := wg.Run(context.Background())
+			if !errors.Is(err, ErrDrained) || !errors.Is(err, ErrManualStop) {
+				t.Fatalf("Run error = %v, want ErrDrained matching ErrManualStop", err)
+			}
+			calls := runner.getCalls()
+			slices.Sort(calls)
+			if !slices.Equal(calls, tt.wantRuns) {
+				t.Errorf("ran %v, want %v", calls, tt.wantRuns)
+			}
+			for _, id := range []string{"b", "d"} {
+				if got := state.Phases[id].Status; got != PhaseStatusCreated {
+					t.Errorf("phase %s status = %s, want it left pending", id, got)
+				}
+			}
+			if _, err := os.Stat(drainFile); !os.IsNotExist(err) {
+				t.Error("expected DRAIN file to be removed")
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package nebula
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// envFileName is the optional per-nebula file of KEY=VALUE pairs used for
+// ${VAR} substitution in phase bodies. The process environment wins over it.
+const envFileName = ".nebula.env"
+
+// envVarRef matches ${VAR}, ${VAR:-default}, and the escaped form $${VAR}.
+var envVarRef = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// loadNebulaEnv reads the .nebula.env file in dir. A missing file yields an
+// empty map. Lines are KEY=VALUE; blank lines and # comments are ignored,
+// an optional "export " prefix is accepted, and matching surrounding quotes
+// are stripped from values.
+func loadNebulaEnv(dir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, envFileName))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", envFileName, err)
+	}
+
+	env := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", envFileName, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[key] = value
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", envFileName, err)
+	}
+	return env, nil
+}
+
+// substituteEnv expands ${VAR} and ${VAR:-default} references in a phase
+// body, resolving from the process environment and then fileEnv. As in the
+// shell, the default applies when the variable is unset or empty. Text
+// inside ``` or ~~~ fenced code blocks is left untouched, and $${VAR}
+// yields a literal ${VAR}. References with no value and no default are kept
+// verbatim and their names returned (deduplicated, in order of appearance).
+func substituteEnv(body string, fileEnv map[string]string) (string, []string) {
+	lookup := func(name string) string {
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		return fileEnv[name]
+	}
+
+	var undefined []string
+	seen := make(map[string]bool)
+	expand := func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envVarRef.FindStringSubmatch(ref)
+		name, hasDefault := m[1], strings.Contains(ref, ":-")
+		if v := lookup(name); v != "" {
+			return v
+		}
+		if hasDefault {
+			return m[2]
+		}
+		if _, set := os.LookupEnv(name); set {
+			return ""
+		}
+		if _, set := fileEnv[name]; set {
+			return ""
+		}
+		if !seen[name] {
+			seen[name] = true
+			undefined = append(undefined, name)
+		}
+		return ref
+	}
+
+	return replaceOutsideFences(body, envVarRef, expand), undefined
+}
+
+// replaceOutsideFences replaces every match of re in body with repl(match),
+// leaving text inside ``` or ~~~ fenced code blocks untouched.
+func replaceOutsideFences(body string, re *regexp.Regexp, repl func(string) string) string {
+	lines := strings.Split(body, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		lines[i] = re.ReplaceAllStringFunc(line, repl)
+	}
+	return strings.Join(lines, "\n")
+}
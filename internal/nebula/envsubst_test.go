@@ -0,0 +1,136 @@
+package nebula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSubstituteEnv(t *testing.T) {
+	t.Setenv("QUASAR_TEST_BRANCH", "main")
+	t.Setenv("QUASAR_TEST_EMPTY", "")
+	t.Setenv("QUASAR_TEST_SHADOW", "from-process")
+
+	fileEnv := map[string]string{
+		"API_BASE":           "https://api.test",
+		"QUASAR_TEST_SHADOW": "from-file",
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		want          string
+		wantUndefined []string
+	}{
+		{"process env", "Target ${QUASAR_TEST_BRANCH}.", "Target main.", nil},
+		{"env file", "Call ${API_BASE}/v1", "Call https://api.test/v1", nil},
+		{"process env wins", "${QUASAR_TEST_SHADOW}", "from-process", nil},
+		{"default when unset", "${QUASAR_TEST_UNSET:-dev}", "dev", nil},
+		{"default when empty", "${QUASAR_TEST_EMPTY:-dev}", "dev", nil},
+		{"empty default", "[${QUASAR_TEST_UNSET:-}]", "[]", nil},
+		{"set but empty", "[${QUASAR_TEST_EMPTY}]", "[]", nil},
+		{"undefined kept verbatim", "use ${QUASAR_TEST_UNSET} and ${QUASAR_TEST_UNSET}", "use ${QUASAR_TEST_UNSET} and ${QUASAR_TEST_UNSET}", []string{"QUASAR_TEST_UNSET"}},
+		{"escape", "literal $${QUASAR_TEST_BRANCH}", "literal ${QUASAR_TEST_BRANCH}", nil},
+		{"bare dollar untouched", "costs $5 and $HOME", "costs $5 and $HOME", nil},
+		{
+			"fenced block untouched",
+			"Branch ${QUASAR_TEST_BRANCH}\n```sh\necho ${QUASAR_TEST_UNSET}\n```\nafter ${QUASAR_TEST_BRANCH}",
+			"Branch main\n```sh\necho ${QUASAR_TEST_UNSET}\n```\nafter main",
+			nil,
+		},
+		{
+			"tilde fence closes only on tilde",
+			"~~~\n${QUASAR_TEST_BRANCH}\n```\n${QUASAR_TEST_BRANCH}\n~~~\n${QUASAR_TEST_BRANCH}",
+			"~~~\n${QUASAR_TEST_BRANCH}\n```\n${QUASAR_TEST_BRANCH}\n~~~\nmain",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, undefined := substituteEnv(tt.body, fileEnv)
+			if got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(undefined, tt.wantUndefined) {
+				t.Errorf("undefined = %v, want %v", undefined, tt.wantUndefined)
+			}
+		})
+	}
+}
+
+func TestLoadNebulaEnv(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		env, err := loadNebulaEnv(t.TempDir())
+		if err != nil || len(env) != 0 {
+			t.Fatalf("loadNebulaEnv = %v, %v; want empty, nil", env, err)
+		}
+	})
+
+	t.Run("parses entries", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		content := "# comment\n\nA=1\nexport B = two\nC=\"quoted value\"\nD='single'\nE=a=b\n"
+		if err := os.WriteFile(filepath.Join(dir, envFileName), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		env, err := loadNebulaEnv(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{"A": "1", "B": "two", "C": "quoted value", "D": "single", "E": "a=b"}
+		if !reflect.DeepEqual(env, want) {
+			t.Errorf("env = %v, want %v", env, want)
+		}
+	})
+
+	t.Run("malformed line", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, envFileName), []byte("A=1\nnot an assignment\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadNebulaEnv(dir); err == nil {
+			t.Fatal("expected error for malformed line")
+		}
+	})
+}
+
+func TestLoadSubstitutesAndValidateReportsUndefined(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("QUASAR_TEST_BRANCH", "release")
+	files := map[string]string{
+		"nebula.toml": "[nebula]\nname = \"env-test\"\n",
+		envFileName:   "API_BASE=https://api.test\n",
+		"a.md":        "+++\nid = \"a\"\ntitle = \"A\"\n+++\nMerge into ${QUASAR_TEST_BRANCH} and call ${API_BASE}.\n",
+		"b.md":        "+++\nid = \"b\"\ntitle = \"B\"\n+++\nNeeds ${QUASAR_TEST_MISSING}.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, p := range n.Phases {
+		if p.ID == "a" && p.Body != "Merge into release and call https://api.test." {
+			t.Errorf("phase a body = %q", p.Body)
+		}
+	}
+
+	errs := Validate(n)
+	if len(errs) != 1 {
+		t.Fatalf("Validate returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if errs[0].Category != ValCatUndefinedVar || errs[0].PhaseID != "b" || !errors.Is(&errs[0], ErrUndefinedVar) {
+		t.Errorf("unexpected error: %+v", errs[0])
+	}
+}
@@ -1,6 +1,10 @@
 package nebula
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for nebula validation and dependency checking.
 var (
@@ -18,6 +22,15 @@ var (
 	ErrUnmetDependency = errors.New("unmet external dependency")
 	// ErrManualStop indicates the user requested a graceful stop via a STOP file.
 	ErrManualStop = errors.New("nebula stopped by user")
+	// ErrDrained indicates the user asked the run to drain via a DRAIN file: it finished the phases
+	// already running or eligible and stopped, leaving the rest pending. It matches ErrManualStop.
+	ErrDrained = fmt.Errorf("%w after draining", ErrManualStop)
+	// ErrCanceled indicates the run's context was canceled, e.g. by Ctrl-C, before the run finished. Phases
+	// that were running are left ready to run again rather than failed. It matches context.Canceled.
+	ErrCanceled = fmt.Errorf("nebula run canceled: %w", context.Canceled)
+	// ErrMaxRuntime indicates the run reached its MaxRuntime: it let the phases already running finish and
+	// stopped, leaving the rest pending.
+	ErrMaxRuntime = errors.New("nebula reached its max runtime")
 	// ErrInvalidGate indicates an unrecognized gate mode value.
 	ErrInvalidGate = errors.New("invalid gate mode")
 	// ErrPlanRejected indicates the human rejected the execution plan before any phases ran.
@@ -28,6 +41,68 @@ var (
 	ErrPhaseAlreadyStarted = errors.New("phase already started")
 	// ErrPlanHasErrors indicates the execution plan contains error-severity risks.
 	ErrPlanHasErrors = errors.New("execution plan has error-severity risks")
+	// ErrNotRunning indicates an operation that requires an active WorkerGroup.Run was attempted before it started.
+	ErrNotRunning = errors.New("nebula is not running")
+	// ErrUnknownPhase indicates a phase ID that does not exist in the nebula.
+	ErrUnknownPhase = errors.New("unknown phase ID")
+	// ErrUndefinedVar indicates a phase body references an environment variable that is not set and has no default.
+	ErrUndefinedVar = errors.New("undefined variable")
+	// ErrDirtyWorkTree indicates uncommitted changes that a phase commit would sweep up.
+	ErrDirtyWorkTree = errors.New("working tree has uncommitted changes")
+	// ErrImportCycle indicates a chain of phase imports that leads back to a nebula already being loaded.
+	ErrImportCycle = errors.New("nebula import cycle")
+	// ErrInvalidImport indicates a phase import that cannot be expanded in its context.
+	ErrInvalidImport = errors.New("invalid phase import")
+	// ErrNamespaceCollision indicates a phase ID that clashes with an import group's namespace.
+	ErrNamespaceCollision = errors.New("phase ID collides with import namespace")
+	// ErrPhaseBudgetExceeded indicates a phase stopped because it spent its per-phase budget cap.
+	ErrPhaseBudgetExceeded = errors.New("phase budget exceeded")
+	// ErrInvalidProfile indicates a malformed agent profile in the manifest.
+	ErrInvalidProfile = errors.New("invalid agent profile")
+	// ErrInvalidFailurePolicy indicates an unrecognized execution.on_failure value.
+	ErrInvalidFailurePolicy = errors.New("invalid on_failure policy")
+	// ErrAbortedOnFailure indicates a run stopped early because a phase failed under on_failure = "abort".
+	ErrAbortedOnFailure = errors.New("nebula aborted on phase failure")
+	// ErrInvalidArtifact indicates an artifact path that is malformed or escapes the working directory.
+	ErrInvalidArtifact = errors.New("invalid artifact path")
+	// ErrInvalidWorkingDir indicates a phase working_dir that escapes the repository or does not exist.
+	ErrInvalidWorkingDir = errors.New("invalid phase working_dir")
+	// ErrRetryBudgetExhausted indicates a phase whose retries have spent its whole retry budget; it is not run again.
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+	// ErrTotalBudgetExhausted indicates a phase that was not run because what is left of execution.total_budget_usd cannot cover its budget.
+	ErrTotalBudgetExhausted = errors.New("total budget exhausted")
+	// ErrManifestVersion indicates a nebula.toml version this build cannot read.
+	ErrManifestVersion = errors.New("unsupported nebula.toml version")
+	// ErrInvalidIncludeFile indicates a context.include_files entry that is missing or not a regular file.
+	ErrInvalidIncludeFile = errors.New("invalid context include file")
+	// ErrInvalidTool indicates a phase allowed_tools or denied_tools entry that names no known tool.
+	ErrInvalidTool = errors.New("invalid tool")
+	// ErrInvalidStaleAction indicates an unrecognized execution.stale_action value or a malformed stale_action_after.
+	ErrInvalidStaleAction = errors.New("invalid stale action")
+	// ErrInvalidManualPhase indicates a manual phase that sets an agent-only field such as model or max_budget_usd.
+	ErrInvalidManualPhase = errors.New("invalid manual phase")
+	// ErrInvalidSkipReview indicates a skip_review phase that also sets a policy only a reviewer can drive, such as auto_decompose.
+	ErrInvalidSkipReview = errors.New("invalid skip_review phase")
+	// ErrInvalidTimeout indicates a malformed phase_timeout or timeout, or an unrecognized timeout_action.
+	ErrInvalidTimeout = errors.New("invalid timeout")
+	// ErrInvalidParam indicates a malformed params name, requires_params entry, or --param flag.
+	ErrInvalidParam = errors.New("invalid param")
+	// ErrMissingParam indicates a phase that requires or references a param with no value.
+	ErrMissingParam = errors.New("missing param")
+	// ErrPhaseTimeout indicates a phase run that was stopped for running past its timeout.
+	ErrPhaseTimeout = errors.New("phase timed out")
+	// ErrManualPhaseFailed indicates a human marked a manual phase as failed.
+	ErrManualPhaseFailed = errors.New("manual phase marked failed")
+	// ErrInvalidIdentity indicates a nebula.icon or nebula.color the TUI cannot render.
+	ErrInvalidIdentity = errors.New("invalid nebula identity")
+	// ErrInvalidCheck indicates a check with no name or command, or a duplicate name.
+	ErrInvalidCheck = errors.New("invalid check")
+	// ErrCheckFailed indicates a required check failed after a phase ran.
+	ErrCheckFailed = errors.New("required check failed")
+	// ErrUndeclaredDependency indicates a phase body mentions another phase it does not depend on.
+	ErrUndeclaredDependency = errors.New("possible undeclared dependency")
+	// ErrUnknownLabel indicates a phase label missing from the manifest's allowed_labels.
+	ErrUnknownLabel = errors.New("label not in allowed_labels")
 )
 
 // ValidationCategory classifies a validation error for programmatic handling.
@@ -50,6 +125,42 @@ const (
 	ValCatBoundsViolation ValidationCategory = "bounds_violation"
 	// ValCatInvalidRouting indicates a problem with the model routing configuration.
 	ValCatInvalidRouting ValidationCategory = "invalid_routing"
+	// ValCatUndefinedVar indicates a ${VAR} reference with no value and no default.
+	ValCatUndefinedVar ValidationCategory = "undefined_var"
+	// ValCatInvalidImport indicates a phase import that cannot be expanded in its context.
+	ValCatInvalidImport ValidationCategory = "invalid_import"
+	// ValCatNamespaceCollision indicates a phase ID inside an import group's namespace that the group did not produce.
+	ValCatNamespaceCollision ValidationCategory = "namespace_collision"
+	// ValCatInvalidProfile indicates a malformed agent profile.
+	ValCatInvalidProfile ValidationCategory = "invalid_profile"
+	// ValCatInvalidFailurePolicy indicates an unrecognized execution.on_failure value.
+	ValCatInvalidFailurePolicy ValidationCategory = "invalid_failure_policy"
+	// ValCatInvalidArtifact indicates an artifact path that is malformed or escapes the working directory.
+	ValCatInvalidArtifact ValidationCategory = "invalid_artifact"
+	// ValCatInvalidWorkingDir indicates a phase working_dir that escapes the repository or does not exist.
+	ValCatInvalidWorkingDir ValidationCategory = "invalid_working_dir"
+	// ValCatInvalidIncludeFile indicates a context.include_files entry that is missing or not a regular file.
+	ValCatInvalidIncludeFile ValidationCategory = "invalid_include_file"
+	// ValCatInvalidTool indicates a phase allowed_tools or denied_tools entry that names no known tool.
+	ValCatInvalidTool ValidationCategory = "invalid_tool"
+	// ValCatInvalidStaleAction indicates an unrecognized execution.stale_action value or a malformed stale_action_after.
+	ValCatInvalidStaleAction ValidationCategory = "invalid_stale_action"
+	// ValCatInvalidManualPhase indicates a manual phase that sets an agent-only field.
+	ValCatInvalidManualPhase ValidationCategory = "invalid_manual_phase"
+	// ValCatInvalidSkipReview indicates a skip_review phase that also sets a reviewer-driven policy.
+	ValCatInvalidSkipReview ValidationCategory = "invalid_skip_review"
+	// ValCatInvalidTimeout indicates a malformed timeout duration or an unrecognized timeout_action.
+	ValCatInvalidTimeout ValidationCategory = "invalid_timeout"
+	// ValCatInvalidParam indicates a malformed params name or requires_params entry.
+	ValCatInvalidParam ValidationCategory = "invalid_param"
+	// ValCatInvalidIdentity indicates a malformed nebula.icon or nebula.color.
+	ValCatInvalidIdentity ValidationCategory = "invalid_identity"
+	// ValCatInvalidCheck indicates a malformed execution.checks or phase checks entry.
+	ValCatInvalidCheck ValidationCategory = "invalid_check"
+	// ValCatUndeclaredDependency indicates a phase body that mentions a phase missing from its depends_on.
+	ValCatUndeclaredDependency ValidationCategory = "undeclared_dependency"
+	// ValCatUnknownLabel indicates a phase label outside the manifest's allowed_labels.
+	ValCatUnknownLabel ValidationCategory = "unknown_label"
 )
 
 // ValidationError records a validation problem with source context.
@@ -0,0 +1,131 @@
+package nebula
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
+
+// DefaultETAPhaseDuration is the duration EstimateETA assumes for a phase
+// with no recorded run.
+const DefaultETAPhaseDuration = 10 * time.Minute
+
+// ETAPhase is one phase as seen by EstimateETA.
+type ETAPhase struct {
+	ID        string
+	DependsOn []string
+	Finished  bool          // done, failed, or skipped; contributes no time
+	Elapsed   time.Duration // time already spent, for a phase that is running
+	Estimate  time.Duration // the phase's estimated_minutes hint (0 = none)
+}
+
+// ETA is an estimate of the time left in a run.
+type ETA struct {
+	Remaining time.Duration
+	Known     int // unfinished phases estimated from a recorded duration
+	Hinted    int // unfinished phases estimated from their estimated_minutes
+	Unknown   int // unfinished phases estimated with the default duration
+}
+
+// Narrow reports whether most unfinished phases have a recorded duration
+// or an author's estimate, making the estimate fairly reliable.
+func (e ETA) Narrow() bool {
+	return e.Known+e.Hinted > e.Unknown
+}
+
+// EstimateETA estimates the wall-clock time left for phases. Phases are
+// grouped into dependency waves that run one after another; a wave takes as
+// long as its slowest unfinished phase, or its total work spread across
+// maxWorkers when that is longer. Each phase is expected to take its
+// duration in history, else its Estimate, else fallback, less any time it
+// has already been running. Dependencies on unknown phases are ignored.
+func EstimateETA(phases []ETAPhase, history map[string]time.Duration, fallback time.Duration, maxWorkers int) ETA {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	byID := make(map[string]ETAPhase, len(phases))
+	d := dag.New()
+	for _, p := range phases {
+		byID[p.ID] = p
+		d.AddNodeIdempotent(p.ID, 0)
+	}
+	for _, p := range phases {
+		for _, dep := range p.DependsOn {
+			if _, ok := byID[dep]; ok {
+				_ = d.AddEdge(p.ID, dep) // a cycle just leaves the edge out
+			}
+		}
+	}
+	waves, _ := d.ComputeWaves() // AddEdge rejected any cycle, so this cannot fail
+
+	var eta ETA
+	for _, w := range waves {
+		var slowest, total time.Duration
+		for _, id := range w.NodeIDs {
+			p := byID[id]
+			if p.Finished {
+				continue
+			}
+			expected := history[id]
+			switch {
+			case expected > 0:
+				eta.Known++
+			case p.Estimate > 0:
+				expected = p.Estimate
+				eta.Hinted++
+			default:
+				expected = fallback
+				eta.Unknown++
+			}
+			left := max(expected-p.Elapsed, 0)
+			slowest = max(slowest, left)
+			total += left
+		}
+		eta.Remaining += max(slowest, total/time.Duration(maxWorkers))
+	}
+	return eta
+}
+
+// PhaseEstimates returns the estimated_minutes hint of each phase that
+// declares one.
+func PhaseEstimates(phases []PhaseSpec) map[string]time.Duration {
+	estimates := make(map[string]time.Duration)
+	for _, p := range phases {
+		if p.EstimatedMinutes > 0 {
+			estimates[p.ID] = time.Duration(p.EstimatedMinutes) * time.Minute
+		}
+	}
+	return estimates
+}
+
+// estimateErrors reports a negative estimated_minutes on p.
+func estimateErrors(p PhaseSpec) []ValidationError {
+	if p.EstimatedMinutes >= 0 {
+		return nil
+	}
+	return []ValidationError{{
+		Category:   ValCatBoundsViolation,
+		PhaseID:    p.ID,
+		SourceFile: p.SourceFile,
+		Field:      "estimated_minutes",
+		Err:        fmt.Errorf("estimated_minutes must be >= 0, got %d", p.EstimatedMinutes),
+	}}
+}
+
+// PhaseDurations returns how long each phase took in the most recently
+// recorded run of the nebula in dir. Phases without a recorded duration
+// are left out. A nebula with no metrics file yields an empty map.
+func PhaseDurations(dir string) (map[string]time.Duration, error) {
+	m, err := LoadMetrics(dir)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[string]time.Duration, len(m.Phases))
+	for _, p := range m.Phases {
+		if p.Duration > 0 {
+			durations[p.PhaseID] = p.Duration
+		}
+	}
+	return durations, nil
+}
@@ -0,0 +1,103 @@
+package nebula
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateETA(t *testing.T) {
+	t.Parallel()
+
+	history := map[string]time.Duration{
+		"a": 4 * time.Minute,
+		"b": 6 * time.Minute,
+		"c": 5 * time.Minute,
+	}
+	const fallback = 10 * time.Minute
+
+	tests := []struct {
+		name        string
+		phases      []ETAPhase
+		workers     int
+		want        time.Duration
+		wantNarrow  bool
+		wantUnknown int
+	}{
+		{
+			name:       "waves run in sequence, slowest phase per wave",
+			phases:     []ETAPhase{{ID: "a"}, {ID: "b"}, {ID: "c", DependsOn: []string{"a", "b"}}},
+			workers:    4,
+			want:       11 * time.Minute, // max(4, 6) + 5
+			wantNarrow: true,
+		},
+		{
+			name:       "worker limit spreads a wide wave",
+			phases:     []ETAPhase{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			workers:    1,
+			want:       15 * time.Minute,
+			wantNarrow: true,
+		},
+		{
+			name:       "finished phases and elapsed time are subtracted",
+			phases:     []ETAPhase{{ID: "a", Finished: true}, {ID: "b", Elapsed: 2 * time.Minute}, {ID: "c", DependsOn: []string{"a", "b"}}},
+			workers:    4,
+			want:       9 * time.Minute,
+			wantNarrow: true,
+		},
+		{
+			name:        "never-run phases use the fallback",
+			phases:      []ETAPhase{{ID: "a"}, {ID: "new", DependsOn: []string{"a"}}, {ID: "newer", DependsOn: []string{"new"}}},
+			workers:     1,
+			want:        24 * time.Minute,
+			wantUnknown: 2,
+		},
+		{
+			name:       "estimates fill in for never-run phases",
+			phases:     []ETAPhase{{ID: "a", Estimate: time.Hour}, {ID: "new", Estimate: 3 * time.Minute, DependsOn: []string{"a"}}},
+			workers:    1,
+			want:       7 * time.Minute, // history wins over the estimate for a
+			wantNarrow: true,
+		},
+		{
+			name:    "all finished",
+			phases:  []ETAPhase{{ID: "a", Finished: true}},
+			workers: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			eta := EstimateETA(tt.phases, history, fallback, tt.workers)
+			if eta.Remaining != tt.want {
+				t.Errorf("Remaining = %v, want %v", eta.Remaining, tt.want)
+			}
+			if eta.Narrow() != tt.wantNarrow {
+				t.Errorf("Narrow() = %v, want %v (known %d, unknown %d)", eta.Narrow(), tt.wantNarrow, eta.Known, eta.Unknown)
+			}
+			if eta.Unknown != tt.wantUnknown {
+				t.Errorf("Unknown = %d, want %d", eta.Unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestPhaseEstimates(t *testing.T) {
+	t.Parallel()
+
+	got := PhaseEstimates([]PhaseSpec{{ID: "a", EstimatedMinutes: 15}, {ID: "b"}})
+	if len(got) != 1 || got["a"] != 15*time.Minute {
+		t.Errorf("PhaseEstimates = %v, want only a: 15m", got)
+	}
+}
+
+func TestEstimateErrors(t *testing.T) {
+	t.Parallel()
+
+	if errs := estimateErrors(PhaseSpec{ID: "a", EstimatedMinutes: 0}); len(errs) != 0 {
+		t.Errorf("zero estimate: got %v, want no errors", errs)
+	}
+	errs := estimateErrors(PhaseSpec{ID: "a", EstimatedMinutes: -5})
+	if len(errs) != 1 || errs[0].Category != ValCatBoundsViolation || errs[0].Field != "estimated_minutes" {
+		t.Errorf("negative estimate: got %+v, want one bounds violation on estimated_minutes", errs)
+	}
+}
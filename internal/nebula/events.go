@@ -0,0 +1,328 @@
+package nebula
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// eventSocketName is the event socket a headless run exposes in its nebula
+// directory for `quasar nebula attach`.
+const eventSocketName = ".quasar-events.sock"
+
+// eventClientBuffer is how many events may queue for one attached client
+// before it is considered stalled and disconnected.
+const eventClientBuffer = 256
+
+// EventSocketPath returns the event socket path for the nebula in dir.
+func EventSocketPath(dir string) string {
+	return filepath.Join(dir, eventSocketName)
+}
+
+// StreamEventKind identifies a StreamEvent.
+type StreamEventKind string
+
+const (
+	// StreamEventSnapshot carries the full nebula state; it is the first event on every connection.
+	StreamEventSnapshot StreamEventKind = "snapshot"
+	// StreamEventProgress carries updated progress counts.
+	StreamEventProgress StreamEventKind = "progress"
+	// StreamEventPhase reports a phase status change.
+	StreamEventPhase StreamEventKind = "phase"
+	// StreamEventHail reports a hail surfaced to the human.
+	StreamEventHail StreamEventKind = "hail"
+	// StreamEventGate reports a gate waiting for a human decision; Phase is empty for the plan gate.
+	StreamEventGate StreamEventKind = "gate"
+	// StreamEventConflict reports a file-level conflict between phases.
+	StreamEventConflict StreamEventKind = "conflict"
+	// StreamEventDone reports that the run finished.
+	StreamEventDone StreamEventKind = "done"
+)
+
+// StreamEvent is one newline-delimited JSON message on the event socket.
+// Only the fields relevant to Kind are set.
+type StreamEvent struct {
+	Kind     StreamEventKind      `json:"kind"`
+	Phase    string               `json:"phase,omitempty"`
+	Status   PhaseStatus          `json:"status,omitempty"`
+	Reason   string               `json:"reason,omitempty"` // StreamEventPhase: why a skipped phase never ran; StreamEventGate: what awaits a decision
+	Snapshot *StreamSnapshot      `json:"snapshot,omitempty"`
+	Progress *StreamProgress      `json:"progress,omitempty"`
+	Hail     *fabric.Discovery    `json:"hail,omitempty"`
+	Conflict *fabric.FileConflict `json:"conflict,omitempty"`
+	Results  []StreamResult       `json:"results,omitempty"` // StreamEventDone only
+	Error    string               `json:"error,omitempty"`   // StreamEventDone only; the run's error
+}
+
+// StreamSnapshot is the nebula state replayed to a newly attached client.
+type StreamSnapshot struct {
+	Name     string         `json:"name"`
+	Icon     string         `json:"icon,omitempty"`
+	Color    string         `json:"color,omitempty"` // nebula.Info.ColorCode
+	Phases   []StreamPhase  `json:"phases"`
+	Progress StreamProgress `json:"progress"`
+	Gates    []string       `json:"gates,omitempty"` // phases whose gate awaits a decision; the plan gate is PlanPhaseID
+}
+
+// StreamPhase describes one phase in a snapshot.
+type StreamPhase struct {
+	ID         string      `json:"id"`
+	Title      string      `json:"title"`
+	DependsOn  []string    `json:"depends_on,omitempty"`
+	SourceFile string      `json:"source_file,omitempty"`
+	Status     PhaseStatus `json:"status"`
+	SkipReason string      `json:"skip_reason,omitempty"`
+}
+
+// StreamProgress mirrors the arguments of a ProgressFunc.
+type StreamProgress struct {
+	Completed    int     `json:"completed"`
+	Total        int     `json:"total"`
+	OpenBeads    int     `json:"open_beads"`
+	ClosedBeads  int     `json:"closed_beads"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+}
+
+// StreamResult summarizes one phase result in the done event.
+type StreamResult struct {
+	PhaseID   string   `json:"phase_id"`
+	Error     string   `json:"error,omitempty"`
+	Artifacts []string `json:"artifacts,omitempty"`
+}
+
+// eventServer fans StreamEvents out to clients connected to a unix socket.
+// All methods are safe on a nil receiver, which means "no socket".
+type eventServer struct {
+	ln   net.Listener
+	path string
+
+	mu      sync.Mutex
+	clients map[*eventClient]bool
+	closed  bool
+
+	// last holds the phase statuses already published. Guarded by the
+	// WorkerGroup mutex, like the State it shadows.
+	last map[string]PhaseStatus
+}
+
+// eventClient is one attached connection with its outbound queue.
+type eventClient struct {
+	conn net.Conn
+	ch   chan StreamEvent
+}
+
+// listenEvents opens the event socket at path, replacing a stale socket
+// left behind by a previous run.
+func listenEvents(path string) (*eventServer, error) {
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(path)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on event socket: %w", err)
+	}
+	return &eventServer{ln: ln, path: path, clients: make(map[*eventClient]bool), last: make(map[string]PhaseStatus)}, nil
+}
+
+// publish queues ev for every client, disconnecting clients that have
+// fallen eventClientBuffer events behind.
+func (s *eventServer) publish(ev StreamEvent) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		select {
+		case c.ch <- ev:
+		default:
+			s.dropLocked(c)
+		}
+	}
+}
+
+// add registers a client whose queue starts with first. It returns nil
+// (and closes conn) if the server has already been closed.
+func (s *eventServer) add(conn net.Conn, first StreamEvent) *eventClient {
+	c := &eventClient{conn: conn, ch: make(chan StreamEvent, eventClientBuffer)}
+	c.ch <- first
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		conn.Close()
+		return nil
+	}
+	s.clients[c] = true
+	return c
+}
+
+// dropLocked unregisters c and closes its queue. s.mu must be held.
+func (s *eventServer) dropLocked(c *eventClient) {
+	if s.clients[c] {
+		delete(s.clients, c)
+		close(c.ch)
+	}
+}
+
+// write drains c's queue onto its connection until the queue is closed or
+// the client goes away.
+func (s *eventServer) write(c *eventClient) {
+	defer c.conn.Close()
+	enc := json.NewEncoder(c.conn)
+	for ev := range c.ch {
+		if err := enc.Encode(ev); err != nil {
+			s.mu.Lock()
+			s.dropLocked(c)
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// close stops accepting clients, lets queued events flush, and removes the
+// socket file.
+func (s *eventServer) close() {
+	if s == nil {
+		return
+	}
+	_ = s.ln.Close()
+	s.mu.Lock()
+	s.closed = true
+	for c := range s.clients {
+		s.dropLocked(c)
+	}
+	s.mu.Unlock()
+	_ = os.Remove(s.path)
+}
+
+// startEvents opens the event socket when EventSocket is set. Failure is
+// logged and the run continues without it.
+func (wg *WorkerGroup) startEvents() {
+	if wg.EventSocket == "" {
+		return
+	}
+	s, err := listenEvents(wg.EventSocket)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: %v\n", err)
+		return
+	}
+	wg.mu.Lock()
+	for id, ps := range wg.State.Phases {
+		s.last[id] = ps.Status
+	}
+	wg.events = s
+	wg.mu.Unlock()
+	go wg.acceptEvents(s)
+}
+
+// acceptEvents serves new clients until the listener is closed. Each client
+// is registered under wg.mu together with its snapshot, so it sees every
+// change after the snapshot exactly once.
+func (wg *WorkerGroup) acceptEvents(s *eventServer) {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				fmt.Fprintf(wg.logger(), "warning: event socket: %v\n", err)
+			}
+			return
+		}
+		wg.mu.Lock()
+		c := s.add(conn, StreamEvent{Kind: StreamEventSnapshot, Snapshot: wg.streamSnapshot()})
+		wg.mu.Unlock()
+		if c != nil {
+			go s.write(c)
+		}
+	}
+}
+
+// streamSnapshot captures the current nebula state. Must be called with
+// wg.mu held.
+func (wg *WorkerGroup) streamSnapshot() *StreamSnapshot {
+	snap := &StreamSnapshot{
+		Name:     wg.Nebula.Manifest.Nebula.Name,
+		Icon:     wg.Nebula.Manifest.Nebula.Icon,
+		Color:    wg.Nebula.Manifest.Nebula.ColorCode(),
+		Phases:   make([]StreamPhase, 0, len(wg.Nebula.Phases)),
+		Progress: wg.streamProgress(),
+	}
+	for _, p := range wg.Nebula.Phases {
+		sp := StreamPhase{ID: p.ID, Title: p.Title, DependsOn: p.DependsOn, SourceFile: p.SourceFile}
+		if ps := wg.State.Phases[p.ID]; ps != nil {
+			sp.Status = ps.Status
+			sp.SkipReason = ps.SkipReason
+		}
+		snap.Phases = append(snap.Phases, sp)
+	}
+	for id := range wg.openGates {
+		snap.Gates = append(snap.Gates, id)
+	}
+	sort.Strings(snap.Gates)
+	return snap
+}
+
+// streamProgress computes the current progress counts. Must be called with
+// wg.mu held.
+func (wg *WorkerGroup) streamProgress() StreamProgress {
+	completed, open, closed := progressCounts(wg.State)
+	return StreamProgress{
+		Completed:    completed,
+		Total:        len(wg.Nebula.Phases),
+		OpenBeads:    open,
+		ClosedBeads:  closed,
+		TotalCostUSD: wg.State.TotalCostUSD,
+	}
+}
+
+// progressFunc returns the progress callback for the ProgressReporter: it
+// publishes phase status changes and progress to the event socket, then
+// calls OnProgress.
+func (wg *WorkerGroup) progressFunc() ProgressFunc {
+	if wg.events == nil {
+		return wg.OnProgress
+	}
+	return func(completed, total, openBeads, closedBeads int, totalCostUSD float64) {
+		// Called with wg.mu held (see ProgressReporter.ReportProgress).
+		for _, p := range wg.Nebula.Phases {
+			ps := wg.State.Phases[p.ID]
+			if ps == nil || wg.events.last[p.ID] == ps.Status {
+				continue
+			}
+			wg.events.last[p.ID] = ps.Status
+			wg.events.publish(StreamEvent{Kind: StreamEventPhase, Phase: p.ID, Status: ps.Status, Reason: ps.SkipReason})
+		}
+		wg.events.publish(StreamEvent{Kind: StreamEventProgress, Progress: &StreamProgress{
+			Completed: completed, Total: total, OpenBeads: openBeads, ClosedBeads: closedBeads, TotalCostUSD: totalCostUSD,
+		}})
+		if wg.OnProgress != nil {
+			wg.OnProgress(completed, total, openBeads, closedBeads, totalCostUSD)
+		}
+	}
+}
+
+// finishEvents publishes the done event and closes the event socket.
+func (wg *WorkerGroup) finishEvents(results []WorkerResult, err error) {
+	if wg.events == nil {
+		return
+	}
+	ev := StreamEvent{Kind: StreamEventDone}
+	for _, r := range results {
+		sr := StreamResult{PhaseID: r.PhaseID, Artifacts: r.Artifacts}
+		if r.Err != nil {
+			sr.Error = r.Err.Error()
+		}
+		ev.Results = append(ev.Results, sr)
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	wg.events.publish(ev)
+	wg.events.close()
+}
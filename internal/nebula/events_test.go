@@ -0,0 +1,271 @@
+package nebula
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// dialEvents connects to the event socket at path, retrying until the run
+// has opened it.
+func dialEvents(t *testing.T, path string) net.Conn {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("unix", path)
+		if err == nil {
+			return conn
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("dial %s: %v", path, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEventSocketStream(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{
+		Dir:      dir,
+		Manifest: Manifest{Nebula: Info{Name: "stream"}},
+		Phases: []PhaseSpec{
+			{ID: "a", Title: "A", Body: "do a"},
+			{ID: "b", Title: "B", Body: "do b", DependsOn: []string{"a"}},
+		},
+	}
+	state := &State{
+		Version: 1,
+		Phases: map[string]*PhaseState{
+			"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+			"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+		},
+	}
+	release := make(chan struct{})
+	runner := &mockRunner{resultFunc: func(beadID string) *PhaseRunnerResult {
+		if beadID == "bead-a" {
+			<-release
+		}
+		return &PhaseRunnerResult{TotalCostUSD: 0.5}
+	}}
+	sock := EventSocketPath(dir)
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithEventSocket(sock), WithLogger(io.Discard))
+
+	runErr := make(chan error, 1)
+	go func() {
+		_, err := wg.Run(context.Background())
+		runErr <- err
+	}()
+
+	conn := dialEvents(t, sock)
+	defer conn.Close()
+	dec := json.NewDecoder(bufio.NewReader(conn))
+
+	var first StreamEvent
+	if err := dec.Decode(&first); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+	if first.Kind != StreamEventSnapshot || first.Snapshot == nil {
+		t.Fatalf("first event = %+v, want a snapshot", first)
+	}
+	if first.Snapshot.Name != "stream" || len(first.Snapshot.Phases) != 2 {
+		t.Errorf("snapshot = %+v, want nebula stream with 2 phases", first.Snapshot)
+	}
+	if got := first.Snapshot.Phases[1].DependsOn; len(got) != 1 || got[0] != "a" {
+		t.Errorf("snapshot phase b depends_on = %v, want [a]", got)
+	}
+	close(release)
+
+	done := map[string]PhaseStatus{}
+	var last StreamEvent
+	for {
+		var ev StreamEvent
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatalf("stream ended before done event: %v", err)
+		}
+		if ev.Kind == StreamEventPhase {
+			done[ev.Phase] = ev.Status
+		}
+		if ev.Kind == StreamEventDone {
+			last = ev
+			break
+		}
+	}
+	if done["a"] != PhaseStatusDone || done["b"] != PhaseStatusDone {
+		t.Errorf("final phase events = %v, want a and b done", done)
+	}
+	if len(last.Results) != 2 || last.Error != "" {
+		t.Errorf("done event = %+v, want 2 results and no error", last)
+	}
+
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, err := os.Stat(sock); !os.IsNotExist(err) {
+		t.Errorf("socket %s still exists after the run (stat err %v)", sock, err)
+	}
+}
+
+func TestEventServerDropsStalledClient(t *testing.T) {
+	t.Parallel()
+
+	s, err := listenEvents(filepath.Join(t.TempDir(), eventSocketName))
+	if err != nil {
+		t.Fatalf("listenEvents: %v", err)
+	}
+	defer s.close()
+
+	client, server := net.Pipe()
+	defer client.Close()
+	c := s.add(server, StreamEvent{Kind: StreamEventSnapshot})
+
+	// Nobody drains the queue, so it fills and the client is dropped.
+	for i := 0; i < eventClientBuffer; i++ {
+		s.publish(StreamEvent{Kind: StreamEventProgress})
+	}
+	s.mu.Lock()
+	stalled := !s.clients[c]
+	s.mu.Unlock()
+	if !stalled {
+		t.Error("client with a full queue was not dropped")
+	}
+}
+
+func TestEventServerNilIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var s *eventServer
+	s.publish(StreamEvent{Kind: StreamEventDone})
+	s.close()
+}
+
+func TestEventSocketGateEvent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{
+		Dir: dir,
+		Manifest: Manifest{
+			Nebula:    Info{Name: "gated"},
+			Execution: Execution{Gate: GateModeReview},
+		},
+		Phases: []PhaseSpec{{ID: "a", Title: "A", Body: "do a"}},
+	}
+	state := &State{
+		Version: 1,
+		Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+	}
+	release := make(chan struct{})
+	runner := &mockRunner{resultFunc: func(string) *PhaseRunnerResult {
+		<-release
+		return &PhaseRunnerResult{}
+	}}
+	sock := EventSocketPath(dir)
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithPrompter(&mockGater{action: GateActionAccept}),
+		WithEventSocket(sock), WithLogger(io.Discard))
+
+	runErr := make(chan error, 1)
+	go func() {
+		_, err := wg.Run(context.Background())
+		runErr <- err
+	}()
+
+	conn := dialEvents(t, sock)
+	defer conn.Close()
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	var snapshot StreamEvent
+	if err := dec.Decode(&snapshot); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+	close(release)
+
+	var gate *StreamEvent
+	for {
+		var ev StreamEvent
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatalf("stream ended before done event: %v", err)
+		}
+		if ev.Kind == StreamEventGate {
+			gate = &ev
+		}
+		if ev.Kind == StreamEventDone {
+			break
+		}
+	}
+	if gate == nil || gate.Phase != "a" || gate.Reason == "" {
+		t.Errorf("gate event = %+v, want one for phase a", gate)
+	}
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+// heldPrompter signals when a gate prompts and answers it only once
+// release is closed.
+type heldPrompter struct {
+	prompted chan struct{}
+	release  chan struct{}
+}
+
+func (p *heldPrompter) Prompt(context.Context, *Checkpoint) (GateAction, error) {
+	close(p.prompted)
+	<-p.release
+	return GateActionAccept, nil
+}
+
+func TestEventSocketSnapshotListsOpenGates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{
+		Dir: dir,
+		Manifest: Manifest{
+			Nebula:    Info{Name: "gated"},
+			Execution: Execution{Gate: GateModeReview},
+		},
+		Phases: []PhaseSpec{{ID: "a", Title: "A", Body: "do a"}},
+	}
+	state := &State{
+		Version: 1,
+		Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+	}
+	prompter := &heldPrompter{prompted: make(chan struct{}), release: make(chan struct{})}
+	sock := EventSocketPath(dir)
+	wg := NewWorkerGroup(n, state, WithRunner(&mockRunner{}), WithPrompter(prompter),
+		WithEventSocket(sock), WithLogger(io.Discard))
+
+	runErr := make(chan error, 1)
+	go func() {
+		_, err := wg.Run(context.Background())
+		runErr <- err
+	}()
+	<-prompter.prompted
+
+	// A client attaching while the gate waits learns of it from the snapshot.
+	conn := dialEvents(t, sock)
+	var snapshot StreamEvent
+	if err := json.NewDecoder(conn).Decode(&snapshot); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+	conn.Close()
+	if got := snapshot.Snapshot.Gates; len(got) != 1 || got[0] != "a" {
+		t.Errorf("snapshot gates = %v, want [a]", got)
+	}
+
+	close(prompter.release)
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if len(wg.openGates) != 0 {
+		t.Errorf("gates still open after the run: %v", wg.openGates)
+	}
+}
@@ -0,0 +1,46 @@
+package nebula
+
+import "errors"
+
+// Process exit codes reported by commands that run a nebula, so wrapping
+// scripts can tell outcomes apart without parsing output.
+const (
+	ExitOK             = 0 // every phase finished successfully
+	ExitError          = 1 // the run itself failed (setup, I/O, invalid nebula, ...)
+	ExitPartialFailure = 2 // the run finished but one or more phases failed
+	ExitPlanRejected   = 3 // the execution plan was rejected before any phase ran
+	ExitManualStop     = 4 // the run was stopped by the user
+	ExitBudgetExceeded = 5 // a phase stopped, or never started, because it ran out of budget
+	ExitMaxRuntime     = 6 // the run reached its max runtime with phases left pending
+)
+
+// ExitCode maps the outcome of WorkerGroup.Run to a process exit code. The
+// run error takes precedence over phase results; among results, a budget
+// exhaustion outranks other phase failures.
+func ExitCode(err error, results []WorkerResult) int {
+	switch {
+	case errors.Is(err, ErrPlanRejected):
+		return ExitPlanRejected
+	case errors.Is(err, ErrManualStop), errors.Is(err, ErrCanceled):
+		return ExitManualStop
+	case errors.Is(err, ErrPhaseBudgetExceeded), errors.Is(err, ErrTotalBudgetExhausted):
+		return ExitBudgetExceeded
+	case errors.Is(err, ErrMaxRuntime):
+		return ExitMaxRuntime
+	case errors.Is(err, ErrAbortedOnFailure):
+		return ExitPartialFailure
+	case err != nil:
+		return ExitError
+	}
+
+	code := ExitOK
+	for _, r := range results {
+		switch {
+		case errors.Is(r.Err, ErrPhaseBudgetExceeded), errors.Is(r.Err, ErrTotalBudgetExhausted):
+			return ExitBudgetExceeded
+		case r.Err != nil:
+			code = ExitPartialFailure
+		}
+	}
+	return code
+}
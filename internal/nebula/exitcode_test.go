@@ -0,0 +1,40 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	failed := WorkerResult{PhaseID: "a", Err: errors.New("tests failed")}
+	overBudget := WorkerResult{PhaseID: "b", Err: fmt.Errorf("%w: budget exceeded", ErrPhaseBudgetExceeded)}
+	ok := WorkerResult{PhaseID: "c"}
+
+	tests := []struct {
+		name    string
+		err     error
+		results []WorkerResult
+		want    int
+	}{
+		{"no results", nil, nil, ExitOK},
+		{"all done", nil, []WorkerResult{ok, ok}, ExitOK},
+		{"partial failure", nil, []WorkerResult{ok, failed}, ExitPartialFailure},
+		{"budget outranks failure", nil, []WorkerResult{failed, overBudget, ok}, ExitBudgetExceeded},
+		{"plan rejected", ErrPlanRejected, nil, ExitPlanRejected},
+		{"manual stop with failures", ErrManualStop, []WorkerResult{failed}, ExitManualStop},
+		{"max runtime with results", fmt.Errorf("%w (1h0m0s)", ErrMaxRuntime), []WorkerResult{ok}, ExitMaxRuntime},
+		{"wrapped run error", fmt.Errorf("phase x: %w", ErrPhaseBudgetExceeded), nil, ExitBudgetExceeded},
+		{"other run error", errors.New("boom"), []WorkerResult{ok}, ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ExitCode(tt.err, tt.results); got != tt.want {
+				t.Errorf("ExitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
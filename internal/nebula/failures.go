@@ -0,0 +1,90 @@
+package nebula
+
+import (
+	"errors"
+	"slices"
+)
+
+// annotateFailures records on each failed result the phases that did not
+// run because of it: its dependents, direct or transitive, split by whether
+// they were left pending or marked skipped.
+func annotateFailures(results []WorkerResult, phases []PhaseSpec, state *State) {
+	phases = clonePhases(phases)
+	expandBlocks(phases, indexPhases(phases))
+	dependents := make(map[string][]string)
+	for _, p := range phases {
+		for _, dep := range p.DependsOn {
+			dependents[dep] = append(dependents[dep], p.ID)
+		}
+	}
+
+	for i := range results {
+		r := &results[i]
+		if r.Err == nil {
+			continue
+		}
+		r.Blocked, r.Skipped = nil, nil
+		for _, id := range transitiveDependents(r.PhaseID, dependents) {
+			ps := state.Phases[id]
+			if ps == nil {
+				continue
+			}
+			switch ps.Status {
+			case PhaseStatusPending, PhaseStatusCreated:
+				r.Blocked = append(r.Blocked, id)
+			case PhaseStatusSkipped:
+				r.Skipped = append(r.Skipped, id)
+			}
+		}
+	}
+}
+
+// transitiveDependents returns every phase reachable from id through
+// dependents, sorted by ID.
+func transitiveDependents(id string, dependents map[string][]string) []string {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	var out []string
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[cur] {
+			if !seen[d] {
+				seen[d] = true
+				out = append(out, d)
+				queue = append(queue, d)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// lastCycleFindings returns the findings raised in the latest cycle that
+// raised any.
+func lastCycleFindings(findings []DecomposeFinding) []DecomposeFinding {
+	last := 0
+	for _, f := range findings {
+		last = max(last, f.Cycle)
+	}
+	var out []DecomposeFinding
+	for _, f := range findings {
+		if f.Cycle == last {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// RetryCandidates returns the IDs of the failed phases worth retrying, in
+// result order. Phases whose retry budget is spent are left out, since
+// `nebula apply` does not run them again.
+func RetryCandidates(results []WorkerResult) []string {
+	var ids []string
+	for _, r := range results {
+		if r.Err != nil && !errors.Is(r.Err, ErrRetryBudgetExhausted) {
+			ids = append(ids, r.PhaseID)
+		}
+	}
+	return ids
+}
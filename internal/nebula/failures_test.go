@@ -0,0 +1,76 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestAnnotateFailures(t *testing.T) {
+	t.Parallel()
+
+	phases := []PhaseSpec{
+		{ID: "api"},
+		{ID: "auth", DependsOn: []string{"api"}},
+		{ID: "ui", DependsOn: []string{"auth"}},
+		{ID: "docs", Blocks: []string{"ui"}},
+		{ID: "seed", Blocks: []string{"cli"}},
+		{ID: "cli", DependsOn: []string{"api"}},
+	}
+	state := &State{Phases: map[string]*PhaseState{
+		"api":  {Status: PhaseStatusFailed},
+		"auth": {Status: PhaseStatusPending},
+		"ui":   {Status: PhaseStatusSkipped},
+		"docs": {Status: PhaseStatusFailed},
+		"seed": {Status: PhaseStatusDone},
+		"cli":  {Status: PhaseStatusCreated},
+	}}
+	results := []WorkerResult{
+		{PhaseID: "api", Err: errors.New("boom")},
+		{PhaseID: "docs", Err: errors.New("boom")},
+		{PhaseID: "seed"},
+	}
+	annotateFailures(results, phases, state)
+
+	if got := results[0]; !slices.Equal(got.Blocked, []string{"auth", "cli"}) || !slices.Equal(got.Skipped, []string{"ui"}) {
+		t.Errorf("api: blocked %v skipped %v, want [auth cli] and [ui]", got.Blocked, got.Skipped)
+	}
+	if got := results[1]; got.Blocked != nil || !slices.Equal(got.Skipped, []string{"ui"}) {
+		t.Errorf("docs (blocks ui): blocked %v skipped %v, want none and [ui]", got.Blocked, got.Skipped)
+	}
+	if got := results[2]; got.Blocked != nil || got.Skipped != nil {
+		t.Errorf("successful phase annotated: %+v", got)
+	}
+}
+
+func TestLastCycleFindings(t *testing.T) {
+	t.Parallel()
+
+	got := lastCycleFindings([]DecomposeFinding{
+		{Description: "a", Cycle: 1},
+		{Description: "b", Cycle: 3},
+		{Description: "c", Cycle: 2},
+		{Description: "d", Cycle: 3},
+	})
+	if len(got) != 2 || got[0].Description != "b" || got[1].Description != "d" {
+		t.Errorf("got %+v, want the two cycle 3 findings", got)
+	}
+	if got := lastCycleFindings(nil); got != nil {
+		t.Errorf("got %+v for no findings", got)
+	}
+}
+
+func TestRetryCandidates(t *testing.T) {
+	t.Parallel()
+
+	got := RetryCandidates([]WorkerResult{
+		{PhaseID: "a", Err: errors.New("review failed")},
+		{PhaseID: "b"},
+		{PhaseID: "c", Err: fmt.Errorf("%w: spent", ErrRetryBudgetExhausted)},
+		{PhaseID: "d", Err: errors.New("build failed")},
+	})
+	if !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("got %v, want [a d]", got)
+	}
+}
@@ -101,9 +101,13 @@ type reviewGater struct {
 }
 
 // PhaseGate renders the checkpoint and prompts for a decision.
-func (g *reviewGater) PhaseGate(ctx context.Context, _ *PhaseSpec, cp *Checkpoint) (GateAction, error) {
+func (g *reviewGater) PhaseGate(ctx context.Context, phase *PhaseSpec, cp *Checkpoint) (GateAction, error) {
 	if cp != nil {
 		RenderCheckpoint(g.logger, cp)
+	} else {
+		// Without a commit to inspect there is nothing to render, but the
+		// prompter still needs to know which phase it is asking about.
+		cp = &Checkpoint{PhaseID: phase.ID, PhaseTitle: phase.Title}
 	}
 	action, err := g.prompter.Prompt(ctx, cp)
 	if err != nil {
@@ -126,9 +130,13 @@ type approveGater struct {
 }
 
 // PhaseGate renders the checkpoint and prompts for a decision.
-func (g *approveGater) PhaseGate(ctx context.Context, _ *PhaseSpec, cp *Checkpoint) (GateAction, error) {
+func (g *approveGater) PhaseGate(ctx context.Context, phase *PhaseSpec, cp *Checkpoint) (GateAction, error) {
 	if cp != nil {
 		RenderCheckpoint(g.logger, cp)
+	} else {
+		// Without a commit to inspect there is nothing to render, but the
+		// prompter still needs to know which phase it is asking about.
+		cp = &Checkpoint{PhaseID: phase.ID, PhaseTitle: phase.Title}
 	}
 	action, err := g.prompter.Prompt(ctx, cp)
 	if err != nil {
@@ -257,7 +265,12 @@ func (g *terminalGater) isTTYInput() bool {
 // In non-TTY environments, it defaults to accept with a warning.
 // If the context is canceled, it returns GateActionSkip.
 func (g *terminalGater) Prompt(ctx context.Context, cp *Checkpoint) (GateAction, error) {
-	// Non-TTY: auto-accept with warning.
+	// Non-TTY: auto-accept with warning. A manual phase cannot be confirmed
+	// without someone to ask, so it fails instead.
+	if !g.isTTYInput() && cp != nil && cp.Manual {
+		fmt.Fprintf(g.out, "warning: non-TTY stdin, cannot confirm manual phase %q; marking it failed\n", cp.PhaseID)
+		return GateActionReject, nil
+	}
 	if !g.isTTYInput() {
 		phaseID := "unknown"
 		if cp != nil {
@@ -269,6 +282,11 @@ func (g *terminalGater) Prompt(ctx context.Context, cp *Checkpoint) (GateAction,
 
 	if cp != nil && cp.PhaseID == PlanPhaseID {
 		fmt.Fprintf(g.out, "\n   [a]pprove  [s]kip (abort)\n   > ")
+	} else if cp != nil && cp.Manual {
+		if cp.Instructions != "" {
+			fmt.Fprintf(g.out, "\n%s\n", strings.TrimSpace(cp.Instructions))
+		}
+		fmt.Fprintf(g.out, "\n   [a] mark complete  [r] mark failed  [s]kip\n   > ")
 	} else {
 		fmt.Fprintf(g.out, "\n   [a]ccept  [r]eject  re[t]ry  [s]kip\n   > ")
 	}
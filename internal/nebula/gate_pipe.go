@@ -0,0 +1,101 @@
+package nebula
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// pipeGater reads gate decisions from a pipe, one "<phase> <action>" line
+// per decision, for a process that drives the run over stdin (quasar
+// serve). Each line answers the gate of the phase it names, so decisions
+// for concurrent gates cannot cross, and a line naming a phase with no
+// gate waiting is reported and dropped rather than held for a later gate.
+type pipeGater struct {
+	in  io.Reader
+	out io.Writer
+
+	start sync.Once
+	mu    sync.Mutex
+	// waiting maps the phase ID of each open gate to the channel its
+	// decision is delivered on. The plan gate is keyed by PlanPhaseID.
+	waiting map[string]chan GateAction
+	closed  bool // in reached EOF; every later gate is skipped
+}
+
+// NewPipeGater creates a GatePrompter that announces each gate on out and
+// reads "<phase> <action>" lines from in even when in is not a terminal.
+func NewPipeGater(in io.Reader, out io.Writer) GatePrompter {
+	return &pipeGater{in: in, out: out, waiting: make(map[string]chan GateAction)}
+}
+
+// Prompt waits for the decision on cp's phase. If the context is canceled
+// or the pipe closes, it returns GateActionSkip.
+func (g *pipeGater) Prompt(ctx context.Context, cp *Checkpoint) (GateAction, error) {
+	phaseID := PlanPhaseID
+	if cp != nil {
+		phaseID = cp.PhaseID
+	}
+	g.start.Do(func() { go g.read() })
+
+	ch := make(chan GateAction, 1)
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return GateActionSkip, nil
+	}
+	if _, dup := g.waiting[phaseID]; dup {
+		g.mu.Unlock()
+		return "", fmt.Errorf("phase %q already has a gate waiting", phaseID)
+	}
+	g.waiting[phaseID] = ch
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		delete(g.waiting, phaseID)
+		g.mu.Unlock()
+	}()
+
+	fmt.Fprintf(g.out, "gate %s: awaiting accept, reject, retry, or skip\n", phaseID)
+	select {
+	case <-ctx.Done():
+		return GateActionSkip, nil
+	case action := <-ch:
+		return action, nil
+	}
+}
+
+// read delivers each decision line to the gate it names until in closes,
+// then skips every gate still waiting.
+func (g *pipeGater) read() {
+	scanner := bufio.NewScanner(g.in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			fmt.Fprintf(g.out, "warning: ignoring gate input %q: want \"<phase> <action>\"\n", scanner.Text())
+			continue
+		}
+		g.mu.Lock()
+		ch, ok := g.waiting[fields[0]]
+		if ok {
+			delete(g.waiting, fields[0])
+		}
+		g.mu.Unlock()
+		if !ok {
+			fmt.Fprintf(g.out, "warning: ignoring gate decision for phase %q: no gate is waiting\n", fields[0])
+			continue
+		}
+		ch <- parseGateInput(fields[1])
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed = true
+	for id, ch := range g.waiting {
+		ch <- GateActionSkip
+		delete(g.waiting, id)
+	}
+}
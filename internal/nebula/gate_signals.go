@@ -0,0 +1,55 @@
+package nebula
+
+import "fmt"
+
+// processGateSignals handles pending gate signals after a batch completes.
+// Returns true if the dispatch loop should stop, along with any error.
+// Must NOT be called with wg.mu held.
+func (wg *WorkerGroup) processGateSignals() (stop bool, err error) {
+	wg.mu.Lock()
+	signals := wg.drainGateSignals()
+	wg.mu.Unlock()
+
+	for _, sig := range signals {
+		switch sig.action {
+		case GateActionReject:
+			wg.mu.Lock()
+			wg.skipRemaining(fmt.Sprintf("run stopped: phase %q rejected at its gate", sig.phaseID))
+			wg.mu.Unlock()
+			return true, fmt.Errorf("phase %q rejected at gate", sig.phaseID)
+
+		case GateActionSkip:
+			wg.mu.Lock()
+			wg.skipRemaining(fmt.Sprintf("run stopped: remaining phases skipped at phase %q's gate", sig.phaseID))
+			wg.mu.Unlock()
+			return true, nil
+
+		case GateActionRetry:
+			// Phase already removed from inFlight; re-eligible next iteration.
+		}
+	}
+
+	// Under on_failure = "abort", a recorded failure ends the run the same
+	// way a gate rejection does.
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.abortErr != nil {
+		wg.skipRemaining(fmt.Sprintf("run aborted: phase %q failed (on_failure = abort)", wg.abortPhase))
+		return true, wg.abortErr
+	}
+	return false, nil
+}
+
+// skipRemaining marks every phase that has not run as skipped for reason,
+// persists the state, and reports each skip. Must be called with wg.mu held.
+func (wg *WorkerGroup) skipRemaining(reason string) {
+	skipped := wg.tracker.MarkRemainingSkipped(wg.Nebula.Phases, wg.State, reason)
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+	if wg.OnSkip == nil {
+		return
+	}
+	for _, id := range skipped {
+		wg.OnSkip(id, wg.State.Phases[id].SkipReason)
+	}
+}
@@ -3,8 +3,12 @@ package nebula
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestParseGateInput(t *testing.T) {
@@ -214,3 +218,78 @@ func TestTerminalGater_EOF(t *testing.T) {
 		t.Errorf("expected skip on EOF, got %q", action)
 	}
 }
+
+func TestPipeGater_RoutesDecisionsByPhase(t *testing.T) {
+	t.Parallel()
+
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	g := NewPipeGater(pr, &out).(*pipeGater)
+
+	type answer struct {
+		phase  string
+		action GateAction
+	}
+	answers := make(chan answer, 2)
+	for _, id := range []string{"a", "b"} {
+		go func() {
+			action, err := g.Prompt(context.Background(), &Checkpoint{PhaseID: id})
+			if err != nil {
+				t.Errorf("Prompt(%s): %v", id, err)
+			}
+			answers <- answer{id, action}
+		}()
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		g.mu.Lock()
+		n := len(g.waiting)
+		g.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("gates never opened")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// A decision for a phase with no gate waiting is dropped, not queued.
+	fmt.Fprintln(pw, "c accept")
+	fmt.Fprintln(pw, "b reject")
+	fmt.Fprintln(pw, "a retry")
+	got := map[string]GateAction{}
+	for range 2 {
+		a := <-answers
+		got[a.phase] = a.action
+	}
+	if got["a"] != GateActionRetry || got["b"] != GateActionReject {
+		t.Errorf("decisions = %v, want a retry and b reject", got)
+	}
+	if !strings.Contains(out.String(), `no gate is waiting`) {
+		t.Errorf("stray decision was not reported:\n%s", out.String())
+	}
+
+	pw.Close()
+	action, err := g.Prompt(context.Background(), &Checkpoint{PhaseID: "c"})
+	if err != nil || action != GateActionSkip {
+		t.Errorf("after EOF: Prompt = %q, %v; want skip", action, err)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -2,12 +2,15 @@ package nebula
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/papapumpkin/quasar/internal/dirsnap"
 )
 
 // initTestRepo creates a temporary git repo with an initial commit.
@@ -223,6 +226,59 @@ func TestGitCommitter_CommitPhase(t *testing.T) {
 	})
 }
 
+func TestCheckCleanWorkTree(t *testing.T) {
+	t.Run("nil committer passes", func(t *testing.T) {
+		if err := CheckCleanWorkTree(context.Background(), nil); err != nil {
+			t.Errorf("expected nil for non-git directory, got %v", err)
+		}
+	})
+
+	t.Run("clean tree passes", func(t *testing.T) {
+		dir := initTestRepo(t)
+		ctx := context.Background()
+		if err := CheckCleanWorkTree(ctx, NewGitCommitter(ctx, dir)); err != nil {
+			t.Errorf("expected clean tree to pass, got %v", err)
+		}
+	})
+
+	t.Run("dirty tree fails outside excluded paths", func(t *testing.T) {
+		dir := initTestRepo(t)
+		ctx := context.Background()
+		gc := NewGitCommitter(ctx, dir)
+		if err := os.MkdirAll(filepath.Join(dir, "nebula"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "nebula", "nebula.state.toml"), []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := CheckCleanWorkTree(ctx, gc, "nebula"); err != nil {
+			t.Fatalf("changes under an excluded path should pass, got %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "stray.txt"), []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		err := CheckCleanWorkTree(ctx, gc, "nebula")
+		if !errors.Is(err, ErrDirtyWorkTree) {
+			t.Fatalf("expected ErrDirtyWorkTree, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "stray.txt") || strings.Contains(err.Error(), "nebula.state.toml") {
+			t.Errorf("error should list only the stray file: %v", err)
+		}
+	})
+
+	t.Run("long lists are truncated", func(t *testing.T) {
+		mock := &mockGitCommitter{status: []string{"?? a", "?? b", "?? c", "?? d", "?? e", "?? f", "?? g"}}
+		err := CheckCleanWorkTree(context.Background(), mock, ".quasar")
+		if err == nil || !strings.Contains(err.Error(), "7 uncommitted") || !strings.Contains(err.Error(), "and 2 more") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if len(mock.statusExclude) != 1 || mock.statusExclude[0] != ".quasar" {
+			t.Errorf("excludes not forwarded: %v", mock.statusExclude)
+		}
+	})
+}
+
 func TestGitCommitter_Diff(t *testing.T) {
 	t.Run("returns empty diff on clean tree", func(t *testing.T) {
 		dir := initTestRepo(t)
@@ -765,3 +821,48 @@ func TestDetectDefaultBranch(t *testing.T) {
 		}
 	})
 }
+
+func TestSnapshotCommitter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, []byte("one\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := dirsnap.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewSnapshotCommitter(store)
+
+	if diff, err := c.DiffLastCommit(ctx); err != nil || diff != "" {
+		t.Errorf("DiffLastCommit on the baseline = %q, %v; want empty", diff, err)
+	}
+	if err := os.WriteFile(file, []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if lines, err := c.Status(ctx); err != nil || len(lines) != 1 || lines[0] != " M a.txt" {
+		t.Errorf("Status = %q, %v; want [\" M a.txt\"]", lines, err)
+	}
+	if err := c.CommitPhase(ctx, "neb", "p1", "Add two"); err != nil {
+		t.Fatal(err)
+	}
+	if lines, _ := c.Status(ctx); len(lines) != 0 {
+		t.Errorf("Status after CommitPhase = %q, want clean", lines)
+	}
+
+	diff, err := c.DiffLastCommit(ctx)
+	if err != nil || !strings.Contains(diff, "+two") {
+		t.Errorf("DiffLastCommit = %q, %v", diff, err)
+	}
+	stat, err := c.DiffStatLastCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := ParseDiffStat(stat)
+	if len(changes) != 1 || changes[0].Path != "a.txt" || changes[0].LinesAdded != 1 {
+		t.Errorf("ParseDiffStat(%q) = %+v", stat, changes)
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"io"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/papapumpkin/quasar/internal/beads"
 	"github.com/papapumpkin/quasar/internal/dag"
 )
 
+// DefaultEditDebounce is the window in which repeated edits to the same
+// phase file collapse into a single refactor.
+const DefaultEditDebounce = 500 * time.Millisecond
+
 // HotReloader handles in-flight file watching, phase modification, hot-add
 // of new phases into the live DAG, and phase loop registration for refactors.
 type HotReloader struct {
@@ -23,6 +28,7 @@ type HotReloader struct {
 	onRefactor  func(phaseID string, pending bool)
 	onHotAdd    HotAddFunc
 	logger      io.Writer
+	debounce    time.Duration
 
 	// mu is a pointer to the WorkerGroup's mutex so all collaborators
 	// share the same lock for coordinating access to shared state.
@@ -50,6 +56,7 @@ type HotReloaderConfig struct {
 	OnRefactor  func(phaseID string, pending bool)
 	OnHotAdd    HotAddFunc
 	Logger      io.Writer
+	Debounce    time.Duration // window for coalescing edits to one phase; 0 = no coalescing
 	Mu          *sync.Mutex
 	OutputMu    *sync.Mutex
 }
@@ -66,6 +73,7 @@ func NewHotReloader(cfg HotReloaderConfig) *HotReloader {
 		onRefactor:       cfg.OnRefactor,
 		onHotAdd:         cfg.OnHotAdd,
 		logger:           cfg.Logger,
+		debounce:         cfg.Debounce,
 		mu:               cfg.Mu,
 		outputMu:         cfg.OutputMu,
 		phaseLoops:       make(map[string]*phaseLoopHandle),
@@ -93,40 +101,92 @@ func (hr *HotReloader) WaitHotAddWg() {
 
 // ConsumeChanges reads from Watcher.Changes and dispatches to the appropriate
 // handler. It runs until the channel is closed (watcher stopped).
+//
+// Modifications are batched per phase: the first edit opens a debounce
+// window, and when it closes a single refactor is dispatched from the file
+// as it is then, so a burst of saves yields one refactor with the latest
+// body. Additions and removals are handled immediately and never wait on
+// another file's window.
 func (hr *HotReloader) ConsumeChanges(ctx context.Context) {
-	for change := range hr.watcher.Changes {
-		switch change.Kind {
-		case ChangeModified:
-			hr.handlePhaseModified(change)
-		case ChangeAdded:
-			hr.hotAddWg.Add(1)
-			hr.handlePhaseAdded(ctx, change)
-			hr.hotAddWg.Done()
-		case ChangeRemoved:
-			fmt.Fprintf(hr.logger, "warning: phase file removed: %s (ignored)\n", change.File)
+	pending := make(map[string]Change) // latest modification per phase, awaiting its window
+	due := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+
+	for {
+		select {
+		case change, ok := <-hr.watcher.Changes:
+			if !ok {
+				for _, c := range pending {
+					hr.handlePhaseModified(c)
+				}
+				return
+			}
+			if change.Kind == ChangeModified && hr.debounce > 0 {
+				if _, waiting := pending[change.PhaseID]; !waiting {
+					phaseID := change.PhaseID
+					time.AfterFunc(hr.debounce, func() {
+						select {
+						case due <- phaseID:
+						case <-done:
+						}
+					})
+				}
+				pending[change.PhaseID] = change
+				continue
+			}
+			hr.dispatchChange(ctx, change)
+		case phaseID := <-due:
+			if c, ok := pending[phaseID]; ok {
+				delete(pending, phaseID)
+				hr.handlePhaseModified(c)
+			}
 		}
 	}
 }
 
-// handlePhaseModified re-parses the modified phase file and, if the phase is
+// dispatchChange routes a single watcher change to its handler.
+func (hr *HotReloader) dispatchChange(ctx context.Context, change Change) {
+	switch change.Kind {
+	case ChangeModified:
+		hr.handlePhaseModified(change)
+	case ChangeAdded:
+		hr.hotAddWg.Add(1)
+		hr.handlePhaseAdded(ctx, change)
+		hr.hotAddWg.Done()
+	case ChangeRemoved:
+		fmt.Fprintf(hr.logger, "warning: phase file removed: %s (ignored)\n", change.File)
+	}
+}
+
+// handlePhaseModified forwards a watcher-detected phase file edit to
+// queueRefactor, logging parse failures as warnings.
+func (hr *HotReloader) handlePhaseModified(change Change) {
+	if err := hr.queueRefactor(change.PhaseID, change.File); err != nil {
+		fmt.Fprintf(hr.logger, "warning: %v\n", err)
+		return
+	}
+	fmt.Fprintf(hr.logger, "phase %q modified — refactor queued\n", change.PhaseID)
+}
+
+// queueRefactor re-parses the phase file at path and, if the phase is
 // currently running, sends the updated body on its refactor channel. If the
 // phase has not started yet, the body is stored in pendingRefactors for later.
-func (hr *HotReloader) handlePhaseModified(change Change) {
-	phase, err := parsePhaseFile(change.File, Defaults{})
+func (hr *HotReloader) queueRefactor(phaseID, path string) error {
+	phase, err := parsePhaseFile(path, Defaults{})
 	if err != nil {
-		fmt.Fprintf(hr.logger, "warning: failed to re-parse modified phase %q: %v\n", change.PhaseID, err)
-		return
+		return fmt.Errorf("failed to re-parse modified phase %q: %w", phaseID, err)
 	}
 
 	newBody := phase.Body
 
 	hr.mu.Lock()
-	handle, running := hr.phaseLoops[change.PhaseID]
-	hr.pendingRefactors[change.PhaseID] = newBody
+	handle, running := hr.phaseLoops[phaseID]
+	hr.pendingRefactors[phaseID] = newBody
 	hr.mu.Unlock()
 
 	if hr.onRefactor != nil {
-		hr.onRefactor(change.PhaseID, true)
+		hr.onRefactor(phaseID, true)
 	}
 
 	if running {
@@ -137,8 +197,7 @@ func (hr *HotReloader) handlePhaseModified(change Change) {
 		default:
 		}
 	}
-
-	fmt.Fprintf(hr.logger, "phase %q modified — refactor queued\n", change.PhaseID)
+	return nil
 }
 
 // handlePhaseAdded parses a newly added phase file, validates it, and inserts
@@ -204,7 +263,7 @@ func (hr *HotReloader) handlePhaseAdded(ctx context.Context, change Change) {
 		var createErr error
 		beadID, createErr = hr.beadsClient.Create(ctx, phase.Title, beads.CreateOpts{
 			Description: phase.Body,
-			Type:        phase.Type,
+			Type:        phase.beadType(),
 			Labels:      phase.Labels,
 			Assignee:    phase.Assignee,
 			Priority:    priorityStr(phase.Priority),
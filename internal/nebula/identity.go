@@ -0,0 +1,91 @@
+package nebula
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxIconRunes bounds nebula.icon so it stays a glyph rather than a label.
+// Emoji built from a sequence (flags, skin tones, ZWJ joins) take several runes.
+const maxIconRunes = 8
+
+// ColorCode returns the nebula's color as a value lipgloss.Color accepts:
+// the hex string itself, or the ANSI code for a named color. It returns ""
+// when no color is set or the value is not recognized.
+func (i Info) ColorCode() string {
+	c := strings.ToLower(strings.TrimSpace(i.Color))
+	if isHexColor(c) {
+		return c
+	}
+	return namedColorCode(c)
+}
+
+// isHexColor reports whether s is "#rgb" or "#rrggbb".
+func isHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// namedColorCode maps a color name to its ANSI 256-color code, or "" for
+// an unknown name. The basic eight use the terminal's palette so they
+// follow the user's theme.
+func namedColorCode(name string) string {
+	switch name {
+	case "black":
+		return "0"
+	case "red":
+		return "1"
+	case "green":
+		return "2"
+	case "yellow":
+		return "3"
+	case "blue":
+		return "4"
+	case "magenta":
+		return "5"
+	case "cyan":
+		return "6"
+	case "white":
+		return "7"
+	case "gray", "grey":
+		return "8"
+	case "orange":
+		return "208"
+	case "purple":
+		return "135"
+	case "pink":
+		return "212"
+	default:
+		return ""
+	}
+}
+
+// identityErrors checks the manifest's icon and color.
+func identityErrors(info Info) []ValidationError {
+	var errs []ValidationError
+	if info.Color != "" && info.ColorCode() == "" {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidIdentity,
+			SourceFile: "nebula.toml",
+			Field:      "nebula.color",
+			Err:        fmt.Errorf("%w: color %q is neither a hex color (#rgb or #rrggbb) nor a known name", ErrInvalidIdentity, info.Color),
+		})
+	}
+	if utf8.RuneCountInString(info.Icon) > maxIconRunes || strings.ContainsAny(info.Icon, " \t\n") {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidIdentity,
+			SourceFile: "nebula.toml",
+			Field:      "nebula.icon",
+			Err:        fmt.Errorf("%w: icon %q must be a single emoji or glyph", ErrInvalidIdentity, info.Icon),
+		})
+	}
+	return errs
+}
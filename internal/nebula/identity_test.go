@@ -0,0 +1,64 @@
+package nebula
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInfoColorCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"", ""},
+		{"#FF8800", "#ff8800"},
+		{"#abc", "#abc"},
+		{" Cyan ", "6"},
+		{"grey", "8"},
+		{"orange", "208"},
+		{"#ff88", ""},
+		{"#gg0000", ""},
+		{"chartreuse", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			t.Parallel()
+			if got := (Info{Color: tt.color}).ColorCode(); got != tt.want {
+				t.Errorf("ColorCode(%q) = %q, want %q", tt.color, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		info   Info
+		fields []string
+	}{
+		{"unset", Info{Name: "n"}, nil},
+		{"emoji and hex color", Info{Icon: "🚀", Color: "#0af"}, nil},
+		{"flag emoji", Info{Icon: "🇳🇱", Color: "green"}, nil},
+		{"unknown color", Info{Color: "chartreuse"}, []string{"nebula.color"}},
+		{"icon is a label", Info{Icon: "my project"}, []string{"nebula.icon"}},
+		{"both invalid", Info{Icon: "rocketship", Color: "#12345"}, []string{"nebula.color", "nebula.icon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := identityErrors(tt.info)
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("got %d errors, want %d: %v", len(errs), len(tt.fields), errs)
+			}
+			for i, e := range errs {
+				if e.Field != tt.fields[i] || e.Category != ValCatInvalidIdentity || !errors.Is(e.Err, ErrInvalidIdentity) {
+					t.Errorf("error %d = %+v, want field %q", i, e, tt.fields[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// IdlePause pauses the run because the gate prompt for phaseID has gone
+// unanswered for idle. It writes the PAUSE file, so the run behaves exactly
+// as if a human had paused it, and sends an idle notification in the
+// background so a slow endpoint cannot stall the caller. Removing PAUSE
+// resumes the run.
+func (wg *WorkerGroup) IdlePause(phaseID string, idle time.Duration) error {
+	reason := fmt.Sprintf("gate unanswered for %s, run paused", idle.Round(time.Second))
+	pausePath := filepath.Join(wg.Nebula.Dir, "PAUSE")
+	if err := os.WriteFile(pausePath, []byte(reason+"\n"), 0644); err != nil {
+		return fmt.Errorf("writing PAUSE file: %w", err)
+	}
+	go wg.notify(context.Background(), NotifyIdle, phaseID, reason)
+	return nil
+}
@@ -0,0 +1,59 @@
+package nebula
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chanNotifier forwards every notification to a channel.
+type chanNotifier chan Notification
+
+func (c chanNotifier) Notify(_ context.Context, n Notification) error {
+	c <- n
+	return nil
+}
+
+func TestIdlePause(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notes := make(chanNotifier, 1)
+	wg := NewWorkerGroup(&Nebula{Dir: dir, Manifest: Manifest{Nebula: Info{Name: "idle"}}}, &State{},
+		WithNotifier(notes),
+	)
+
+	if err := wg.IdlePause("a", 10*time.Minute); err != nil {
+		t.Fatalf("IdlePause: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "PAUSE"))
+	if err != nil {
+		t.Fatalf("reading PAUSE: %v", err)
+	}
+	if !strings.Contains(string(data), "10m0s") {
+		t.Errorf("PAUSE = %q, want the idle duration", data)
+	}
+
+	select {
+	case n := <-notes:
+		if n.Event != NotifyIdle || n.Phase != "a" || n.Nebula != "idle" {
+			t.Errorf("notification = %+v, want idle on phase a of nebula idle", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no idle notification sent")
+	}
+}
+
+func TestIdlePauseWriteError(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	wg := NewWorkerGroup(&Nebula{Dir: missing}, &State{})
+	if err := wg.IdlePause("a", time.Minute); err == nil {
+		t.Fatal("expected an error writing PAUSE into a missing directory")
+	}
+}
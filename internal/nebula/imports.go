@@ -0,0 +1,163 @@
+package nebula
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// importSeparator joins an importing phase's ID with the IDs of the phases
+// it imports, e.g. "backend/migrate".
+const importSeparator = "/"
+
+// importGroup records the namespaced phases an import phase expanded into.
+type importGroup struct {
+	roots  []string // members with no dependency inside the group
+	leaves []string // members no other member depends on
+}
+
+// expandImports replaces every phase with an Import path by the phases of
+// the nebula in that directory (relative to dir), namespaced under the
+// importing phase's ID. Inside the imported nebula, depends_on and blocks
+// entries naming a sibling are namespaced; anything else is kept verbatim so
+// it can refer to phases across the boundary by their full ID. The import
+// phase's own depends_on is inherited by the group's roots and its blocks by
+// the group's leaves. Elsewhere, depending on the group ID means depending on
+// every leaf, and blocking it means blocking every root. stack holds the
+// absolute directories currently being loaded, for cycle detection.
+func expandImports(dir string, phases []PhaseSpec, stack []string) ([]PhaseSpec, error) {
+	groups := make(map[string]importGroup)
+	var out []PhaseSpec
+	for _, p := range phases {
+		if p.Import == "" {
+			out = append(out, p)
+			continue
+		}
+		members, g, err := importPhases(dir, p, stack)
+		if err != nil {
+			return nil, fmt.Errorf("importing %q into phase %q: %w", p.Import, p.ID, err)
+		}
+		groups[p.ID] = g
+		out = append(out, members...)
+	}
+	if len(groups) == 0 {
+		return out, nil
+	}
+
+	for i := range out {
+		out[i].DependsOn = expandGroupRefs(out[i].DependsOn, groups, func(g importGroup) []string { return g.leaves })
+		out[i].Blocks = expandGroupRefs(out[i].Blocks, groups, func(g importGroup) []string { return g.roots })
+	}
+	return out, nil
+}
+
+// importPhases loads the nebula referenced by p.Import and returns its
+// phases namespaced under p.ID.
+func importPhases(dir string, p PhaseSpec, stack []string) ([]PhaseSpec, importGroup, error) {
+	if p.ID == "" {
+		return nil, importGroup{}, fmt.Errorf("%w: id", ErrMissingField)
+	}
+	subDir := p.Import
+	if !filepath.IsAbs(subDir) {
+		subDir = filepath.Join(dir, subDir)
+	}
+	sub, err := load(subDir, stack)
+	if err != nil {
+		return nil, importGroup{}, err
+	}
+
+	prefix := p.ID + importSeparator
+	local := make(map[string]bool, len(sub.Phases))
+	for _, s := range sub.Phases {
+		local[s.ID] = true
+	}
+	namespace := func(ids []string) []string {
+		if ids == nil {
+			return nil
+		}
+		out := make([]string, len(ids))
+		for i, id := range ids {
+			if local[id] {
+				id = prefix + id
+			}
+			out[i] = id
+		}
+		return out
+	}
+
+	dependedOn := make(map[string]bool)
+	for _, s := range sub.Phases {
+		for _, dep := range s.DependsOn {
+			if local[dep] {
+				dependedOn[dep] = true
+			}
+		}
+	}
+
+	var g importGroup
+	members := make([]PhaseSpec, 0, len(sub.Phases))
+	for _, s := range sub.Phases {
+		root := true
+		for _, dep := range s.DependsOn {
+			if local[dep] {
+				root = false
+				break
+			}
+		}
+		m := s
+		m.ID = prefix + s.ID
+		m.DependsOn = namespace(s.DependsOn)
+		m.Blocks = namespace(s.Blocks)
+		if !filepath.IsAbs(m.SourceFile) {
+			m.SourceFile = filepath.Join(p.Import, m.SourceFile)
+		}
+		m.group = p.ID
+		if s.group != "" {
+			m.group = prefix + s.group
+		}
+		if root {
+			m.DependsOn = append(m.DependsOn, p.DependsOn...)
+			g.roots = append(g.roots, m.ID)
+		}
+		if !dependedOn[s.ID] {
+			m.Blocks = append(m.Blocks, p.Blocks...)
+			g.leaves = append(g.leaves, m.ID)
+		}
+		members = append(members, m)
+	}
+	return members, g, nil
+}
+
+// SourcePath returns the path of the file p was loaded from, resolving
+// SourceFile against the nebula directory dir. Phases imported through an
+// absolute import path already carry an absolute SourceFile.
+func (p PhaseSpec) SourcePath(dir string) string {
+	if filepath.IsAbs(p.SourceFile) {
+		return p.SourceFile
+	}
+	return filepath.Join(dir, p.SourceFile)
+}
+
+// expandGroupRefs replaces references to an import group's ID with the
+// group's member IDs chosen by pick.
+func expandGroupRefs(ids []string, groups map[string]importGroup, pick func(importGroup) []string) []string {
+	var out []string
+	changed := false
+	for _, id := range ids {
+		if g, ok := groups[id]; ok {
+			out = append(out, pick(g)...)
+			changed = true
+			continue
+		}
+		out = append(out, id)
+	}
+	if !changed {
+		return ids
+	}
+	return out
+}
+
+// inImportNamespace reports whether id is group itself or lies under it.
+func inImportNamespace(id, group string) bool {
+	return id == group || strings.HasPrefix(id, group+importSeparator)
+}
@@ -0,0 +1,210 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// phaseFile renders a minimal phase file with the given extra frontmatter.
+func phaseFile(id, extra string) string {
+	return "+++\nid = \"" + id + "\"\ntitle = \"" + id + "\"\n" + extra + "+++\nDo " + id + ".\n"
+}
+
+// writeImportFixture lays out a parent nebula importing "backend/" with
+// setup → backend(migrate → seed) → deploy.
+func writeImportFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"parent\"\n")
+	writeFile(t, filepath.Join(dir, "setup.md"), phaseFile("setup", ""))
+	writeFile(t, filepath.Join(dir, "backend.md"), phaseFile("backend", "import = \"backend\"\ndepends_on = [\"setup\"]\n"))
+	writeFile(t, filepath.Join(dir, "deploy.md"), phaseFile("deploy", "depends_on = [\"backend\"]\n"))
+	writeFile(t, filepath.Join(dir, "docs.md"), phaseFile("docs", "depends_on = [\"backend/migrate\"]\n"))
+
+	writeFile(t, filepath.Join(dir, "backend", "nebula.toml"), "[nebula]\nname = \"backend\"\n")
+	writeFile(t, filepath.Join(dir, "backend", "migrate.md"), phaseFile("migrate", ""))
+	writeFile(t, filepath.Join(dir, "backend", "seed.md"), phaseFile("seed", "depends_on = [\"migrate\"]\n"))
+	return dir
+}
+
+func TestLoadExpandsImports(t *testing.T) {
+	t.Parallel()
+
+	n, err := Load(writeImportFixture(t))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if errs := Validate(n); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+
+	byID := PhasesByID(n.Phases)
+	if _, ok := byID["backend"]; ok {
+		t.Error("import phase should be replaced by the imported phases")
+	}
+
+	tests := []struct {
+		id         string
+		deps       []string
+		sourceFile string
+		group      string
+	}{
+		{"backend/migrate", []string{"setup"}, filepath.Join("backend", "migrate.md"), "backend"},
+		{"backend/seed", []string{"backend/migrate"}, filepath.Join("backend", "seed.md"), "backend"},
+		{"deploy", []string{"backend/seed"}, "deploy.md", ""},
+		{"docs", []string{"backend/migrate"}, "docs.md", ""},
+	}
+	for _, tt := range tests {
+		p, ok := byID[tt.id]
+		if !ok {
+			t.Errorf("missing phase %q", tt.id)
+			continue
+		}
+		deps := append([]string(nil), p.DependsOn...)
+		sort.Strings(deps)
+		if !reflect.DeepEqual(deps, tt.deps) {
+			t.Errorf("%s depends_on = %v, want %v", tt.id, deps, tt.deps)
+		}
+		if p.SourceFile != tt.sourceFile {
+			t.Errorf("%s source file = %q, want %q", tt.id, p.SourceFile, tt.sourceFile)
+		}
+		if p.group != tt.group {
+			t.Errorf("%s group = %q, want %q", tt.id, p.group, tt.group)
+		}
+	}
+}
+
+func TestLoadNestedImports(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"top\"\n")
+	writeFile(t, filepath.Join(dir, "svc.md"), phaseFile("svc", "import = \"svc\"\n"))
+	writeFile(t, filepath.Join(dir, "svc", "nebula.toml"), "[nebula]\nname = \"svc\"\n")
+	writeFile(t, filepath.Join(dir, "svc", "db.md"), phaseFile("db", "import = \"db\"\n"))
+	writeFile(t, filepath.Join(dir, "svc", "api.md"), phaseFile("api", "depends_on = [\"db\"]\n"))
+	writeFile(t, filepath.Join(dir, "svc", "db", "nebula.toml"), "[nebula]\nname = \"db\"\n")
+	writeFile(t, filepath.Join(dir, "svc", "db", "schema.md"), phaseFile("schema", ""))
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	byID := PhasesByID(n.Phases)
+	schema, ok := byID["svc/db/schema"]
+	if !ok {
+		t.Fatalf("missing svc/db/schema; have %v", phaseIDs(n.Phases))
+	}
+	if schema.group != "svc/db" {
+		t.Errorf("group = %q, want svc/db", schema.group)
+	}
+	if api := byID["svc/api"]; api == nil || !reflect.DeepEqual(api.DependsOn, []string{"svc/db/schema"}) {
+		t.Errorf("svc/api = %+v, want depends_on [svc/db/schema]", api)
+	}
+	if errs := Validate(n); len(errs) != 0 {
+		t.Errorf("Validate: %v", errs)
+	}
+}
+
+func TestLoadAbsoluteImport(t *testing.T) {
+	t.Parallel()
+
+	shared := t.TempDir()
+	writeFile(t, filepath.Join(shared, "nebula.toml"), "[nebula]\nname = \"shared\"\n")
+	writeFile(t, filepath.Join(shared, "lint.md"), phaseFile("lint", ""))
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"top\"\n")
+	writeFile(t, filepath.Join(dir, "shared.md"), phaseFile("shared", fmt.Sprintf("import = %q\n", shared)))
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	lint := PhasesByID(n.Phases)["shared/lint"]
+	if lint == nil {
+		t.Fatalf("missing shared/lint; have %v", phaseIDs(n.Phases))
+	}
+	want := filepath.Join(shared, "lint.md")
+	if lint.SourceFile != want {
+		t.Errorf("source file = %q, want %q", lint.SourceFile, want)
+	}
+	if got := lint.SourcePath(dir); got != want {
+		t.Errorf("SourcePath = %q, want %q", got, want)
+	}
+}
+
+func TestLoadImportErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"a\"\n")
+		writeFile(t, filepath.Join(dir, "b.md"), phaseFile("b", "import = \"b\"\n"))
+		writeFile(t, filepath.Join(dir, "b", "nebula.toml"), "[nebula]\nname = \"b\"\n")
+		writeFile(t, filepath.Join(dir, "b", "back.md"), phaseFile("back", "import = \"..\"\n"))
+
+		if _, err := Load(dir); !errors.Is(err, ErrImportCycle) {
+			t.Errorf("Load error = %v, want ErrImportCycle", err)
+		}
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"a\"\n")
+		writeFile(t, filepath.Join(dir, "x.md"), phaseFile("x", "import = \"nowhere\"\n"))
+
+		if _, err := Load(dir); !errors.Is(err, ErrNoManifest) {
+			t.Errorf("Load error = %v, want ErrNoManifest", err)
+		}
+	})
+}
+
+func TestValidateNamespaceCollision(t *testing.T) {
+	t.Parallel()
+
+	dir := writeImportFixture(t)
+	writeFile(t, filepath.Join(dir, "impostor.md"), phaseFile("backend/seed", ""))
+	writeFile(t, filepath.Join(dir, "shadow.md"), "+++\nid = \"backend/extra\"\ntitle = \"x\"\n+++\n")
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	var collisions []string
+	for _, e := range Validate(n) {
+		if e.Category == ValCatNamespaceCollision {
+			if !errors.Is(&e, ErrNamespaceCollision) {
+				t.Errorf("error %v does not wrap ErrNamespaceCollision", e)
+			}
+			collisions = append(collisions, e.SourceFile)
+		}
+	}
+	sort.Strings(collisions)
+	if want := []string{"impostor.md", "shadow.md"}; !reflect.DeepEqual(collisions, want) {
+		t.Errorf("namespace collisions in %v, want %v", collisions, want)
+	}
+}
+
+func TestValidateHotAddRejectsImport(t *testing.T) {
+	t.Parallel()
+
+	errs := ValidateHotAdd(PhaseSpec{ID: "x", Title: "X", Import: "sub"}, map[string]bool{}, nil)
+	if len(errs) != 1 || errs[0].Category != ValCatInvalidImport {
+		t.Errorf("ValidateHotAdd = %v, want one invalid_import error", errs)
+	}
+}
+
+func phaseIDs(phases []PhaseSpec) []string {
+	ids := make([]string, len(phases))
+	for i, p := range phases {
+		ids[i] = p.ID
+	}
+	return ids
+}
@@ -0,0 +1,96 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Size limits for files injected into phase prompts by context.include_files.
+const (
+	// MaxIncludeFileBytes caps how much of one include file is injected.
+	MaxIncludeFileBytes = 16 << 10
+	// MaxIncludeTotalBytes caps how much all include files inject together.
+	MaxIncludeTotalBytes = 48 << 10
+)
+
+// includedFile is the loaded, possibly truncated, content of one
+// context.include_files entry.
+type includedFile struct {
+	Path    string // as written in the manifest
+	Content string
+}
+
+// includePath resolves an include_files entry against the nebula directory.
+func includePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
+
+// loadIncludeFiles reads ctx.IncludeFiles, resolved against dir, into ctx.
+// Each file is truncated to MaxIncludeFileBytes and the files together to
+// MaxIncludeTotalBytes, in manifest order. Entries that are missing or not
+// regular files are left out for Validate to report.
+func loadIncludeFiles(dir string, ctx *Context) error {
+	left := MaxIncludeTotalBytes
+	for _, path := range ctx.IncludeFiles {
+		full := includePath(dir, path)
+		if info, err := os.Stat(full); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return fmt.Errorf("reading include file %s: %w", path, err)
+		}
+		content := truncateInclude(string(data), min(MaxIncludeFileBytes, left))
+		left = max(left-len(content), 0)
+		ctx.included = append(ctx.included, includedFile{Path: path, Content: content})
+	}
+	return nil
+}
+
+// truncateInclude cuts s to at most limit bytes, backing up to a line
+// break when one is near, and notes how much was dropped.
+func truncateInclude(s string, limit int) string {
+	s = strings.TrimRight(s, "\n")
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if nl := strings.LastIndexByte(s[:cut], '\n'); nl >= cut/2 {
+		cut = nl
+	}
+	return fmt.Sprintf("%s\n[... truncated: %d of %d bytes shown]", s[:cut], cut, len(s))
+}
+
+// includeFileErrors reports include_files entries that do not name a
+// readable regular file.
+func includeFileErrors(n *Nebula) []ValidationError {
+	var errs []ValidationError
+	for _, path := range n.Manifest.Context.IncludeFiles {
+		info, err := os.Stat(includePath(n.Dir, path))
+		reason := ""
+		switch {
+		case err != nil:
+			reason = "does not exist"
+		case !info.Mode().IsRegular():
+			reason = "is not a regular file"
+		default:
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidIncludeFile,
+			SourceFile: "nebula.toml",
+			Field:      "context.include_files",
+			Err:        fmt.Errorf("%w: %s %s", ErrInvalidIncludeFile, path, reason),
+		})
+	}
+	return errs
+}
@@ -0,0 +1,111 @@
+package nebula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadIncludeFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("nebula.toml", `[nebula]
+name = "inc"
+
+[context]
+goals = ["ship it"]
+include_files = ["docs/CONVENTIONS.md", "missing.md"]
+`)
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("docs/CONVENTIONS.md", "Use table-driven tests.\n")
+	write("a.md", "+++\nid = \"a\"\ntitle = \"A\"\n+++\nDo the thing.\n")
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	prompt := buildPhasePrompt(&n.Phases[0], &n.Manifest.Context, nil)
+	goals := strings.Index(prompt, "ship it")
+	conv := strings.Index(prompt, "--- docs/CONVENTIONS.md ---\nUse table-driven tests.")
+	body := strings.Index(prompt, "PHASE:\nDo the thing.")
+	if goals < 0 || conv < goals || body < conv {
+		t.Errorf("prompt does not order goals, include file, body:\n%s", prompt)
+	}
+
+	errs := Validate(n)
+	if len(errs) != 1 || !errors.Is(&errs[0], ErrInvalidIncludeFile) || !strings.Contains(errs[0].Error(), "missing.md") {
+		t.Errorf("Validate = %v, want one missing include file error", errs)
+	}
+}
+
+func TestTruncateInclude(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       string
+		limit    int
+		want     string
+		truncate bool
+	}{
+		{"fits", "short\n", 100, "short", false},
+		{"cuts at line break", "line one\nline two\nline three", 15, "line one", true},
+		{"cuts mid-line without a near break", strings.Repeat("x", 40), 10, strings.Repeat("x", 10), true},
+		{"keeps runes whole", "ééééé", 3, "é", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := truncateInclude(tt.in, tt.limit)
+			kept, marker, found := strings.Cut(got, "\n[... truncated:")
+			if found != tt.truncate {
+				t.Fatalf("truncateInclude(%q, %d) = %q, truncated %v, want %v", tt.in, tt.limit, got, found, tt.truncate)
+			}
+			if kept != tt.want {
+				t.Errorf("kept %q, want %q", kept, tt.want)
+			}
+			if found && !strings.HasSuffix(marker, "bytes shown]") {
+				t.Errorf("bad truncation marker %q", marker)
+			}
+		})
+	}
+}
+
+func TestLoadIncludeFiles_TotalLimit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	big := strings.Repeat("0123456789abcde\n", MaxIncludeFileBytes/16+10)
+	ctx := Context{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(big), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		ctx.IncludeFiles = append(ctx.IncludeFiles, name)
+	}
+	if err := loadIncludeFiles(dir, &ctx); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, f := range ctx.included {
+		total += len(f.Content)
+	}
+	if total > MaxIncludeTotalBytes+len(ctx.included)*64 {
+		t.Errorf("included %d bytes, want at most about %d", total, MaxIncludeTotalBytes)
+	}
+	if last := ctx.included[len(ctx.included)-1].Content; !strings.Contains(last, "truncated: 0 of") {
+		t.Errorf("file past the total limit was not emptied: %.60q", last)
+	}
+}
@@ -0,0 +1,100 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkInterventions drains the intervention channel and returns the most
+// significant pending intervention (stop > drain > pause > none). Retries
+// are handled as they are read.
+func (wg *WorkerGroup) checkInterventions() InterventionKind {
+	if wg.Watcher == nil {
+		return ""
+	}
+	var latest InterventionKind
+	for {
+		select {
+		case kind := <-wg.Watcher.Interventions:
+			wg.audit(AuditRecord{Event: AuditIntervention, Action: string(kind)})
+			if kind == InterventionStop {
+				return InterventionStop
+			}
+			if kind == InterventionRetry {
+				wg.handleRetry()
+				continue
+			}
+			if kind == InterventionDrain || (kind == InterventionPause && latest != InterventionDrain) {
+				latest = kind
+			}
+		default:
+			return latest
+		}
+	}
+}
+
+// handleStop saves state, cleans up the STOP file, and prints a message.
+func (wg *WorkerGroup) handleStop() {
+	wg.mu.Lock()
+	wg.progress.SaveState()
+	wg.mu.Unlock()
+
+	stopPath := filepath.Join(wg.Nebula.Dir, "STOP")
+	if err := os.Remove(stopPath); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: failed to remove STOP file: %v\n", err)
+	}
+
+	fmt.Fprintf(wg.logger(), "\n── Nebula stopped by user ─────────────────────────\n")
+	fmt.Fprintf(wg.logger(), "   State saved. Resume with: quasar nebula apply\n")
+	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+}
+
+// handleRetry reads the RETRY file, resets the phases it names (one ID per
+// line), and removes the file.
+func (wg *WorkerGroup) handleRetry() {
+	retryPath := filepath.Join(wg.Nebula.Dir, "RETRY")
+	content, err := os.ReadFile(retryPath)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: failed to read RETRY file: %v\n", err)
+		return
+	}
+
+	phaseIDs := strings.Fields(string(content))
+	if len(phaseIDs) == 0 {
+		fmt.Fprintf(wg.logger(), "warning: RETRY file is empty\n")
+		_ = os.Remove(retryPath)
+		return
+	}
+
+	if err := os.Remove(retryPath); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: failed to remove RETRY file: %v\n", err)
+	}
+
+	done := wg.tracker.Done()
+	failed := wg.tracker.Failed()
+	inFlight := wg.tracker.InFlight()
+
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+
+	for _, phaseID := range phaseIDs {
+		if !failed[phaseID] {
+			fmt.Fprintf(wg.logger(), "warning: phase %q is not failed, ignoring retry\n", phaseID)
+			continue
+		}
+
+		delete(failed, phaseID)
+		delete(done, phaseID)
+		delete(inFlight, phaseID)
+
+		ps := wg.State.Phases[phaseID]
+		if ps != nil {
+			wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusInProgress)
+			wg.progress.SaveState()
+		}
+
+		fmt.Fprintf(wg.logger(), "\n── Retrying phase %q ──────────────────────────────\n\n", phaseID)
+	}
+}
@@ -0,0 +1,24 @@
+package nebula
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// waitJitter sleeps a random fraction of DispatchJitter so phases dispatched
+// together do not all call the provider in the same instant. It reports
+// false when ctx ends first, in which case the phase must not start.
+func (wg *WorkerGroup) waitJitter(ctx context.Context) bool {
+	if wg.DispatchJitter <= 0 {
+		return true
+	}
+	t := time.NewTimer(rand.N(wg.DispatchJitter))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
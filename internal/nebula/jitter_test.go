@@ -0,0 +1,69 @@
+package nebula
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWaitJitter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Parallel()
+		wg := NewWorkerGroup(&Nebula{}, &State{})
+		start := time.Now()
+		if !wg.waitJitter(context.Background()) {
+			t.Fatal("waitJitter = false with no jitter")
+		}
+		if time.Since(start) > 50*time.Millisecond {
+			t.Error("waited with no jitter set")
+		}
+	})
+
+	t.Run("bounded delay", func(t *testing.T) {
+		t.Parallel()
+		wg := NewWorkerGroup(&Nebula{}, &State{}, WithDispatchJitter(20*time.Millisecond))
+		for range 5 {
+			start := time.Now()
+			if !wg.waitJitter(context.Background()) {
+				t.Fatal("waitJitter = false with a live context")
+			}
+			if d := time.Since(start); d > time.Second {
+				t.Fatalf("waited %v, want at most about the 20ms jitter", d)
+			}
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		t.Parallel()
+		wg := NewWorkerGroup(&Nebula{}, &State{}, WithDispatchJitter(time.Hour))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if wg.waitJitter(ctx) {
+			t.Error("waitJitter = true after the context was canceled")
+		}
+	})
+}
+
+func TestWorkerGroup_DispatchJitterCanceled(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{Dir: t.TempDir(), Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: []PhaseSpec{{ID: "a", Body: "phase a"}}}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+	runner := &mockRunner{result: &PhaseRunnerResult{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithDispatchJitter(time.Hour), WithLogger(io.Discard))
+
+	if _, err := wg.Run(ctx); err == nil {
+		t.Fatal("Run returned no error after its context ended")
+	}
+	if calls := runner.getCalls(); len(calls) != 0 {
+		t.Errorf("runner called %v while the phase waited out its jitter", calls)
+	}
+	if got := state.Phases["a"].Status; got != PhaseStatusCreated {
+		t.Errorf("phase status = %s, want it left created", got)
+	}
+}
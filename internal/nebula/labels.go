@@ -0,0 +1,72 @@
+package nebula
+
+import (
+	"fmt"
+	"slices"
+)
+
+// labelErrors reports phase labels outside the manifest's allowed_labels,
+// suggesting the closest allowed label for likely typos. Labels are
+// free-form when allowed_labels is empty.
+func labelErrors(n *Nebula) []ValidationError {
+	allowed := n.Manifest.AllowedLabels
+	if len(allowed) == 0 {
+		return nil
+	}
+	var errs []ValidationError
+	for _, p := range n.Phases {
+		for _, label := range p.Labels {
+			if slices.Contains(allowed, label) {
+				continue
+			}
+			err := fmt.Errorf("%w: %q", ErrUnknownLabel, label)
+			if s := closestLabel(label, allowed); s != "" {
+				err = fmt.Errorf("%w: %q (did you mean %q?)", ErrUnknownLabel, label, s)
+			}
+			errs = append(errs, ValidationError{
+				Category:   ValCatUnknownLabel,
+				PhaseID:    p.ID,
+				SourceFile: p.SourceFile,
+				Field:      "labels",
+				Err:        err,
+			})
+		}
+	}
+	return errs
+}
+
+// closestLabel returns the allowed label nearest to label by edit distance,
+// or "" when none is close enough to be a likely typo: at most two edits,
+// and fewer edits than label has runes.
+func closestLabel(label string, allowed []string) string {
+	best, bestDist := "", min(3, len([]rune(label)))
+	for _, a := range allowed {
+		if d := editDistance(label, a); d < bestDist {
+			best, bestDist = a, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b, counting
+// runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,88 @@
+package nebula
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAllowedLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		labels  []string
+		want    []string // substrings of each reported error, in order
+	}{
+		{name: "free-form without allowed_labels", labels: []string{"anything", "tets"}},
+		{name: "allowed labels pass", allowed: []string{"test", "docs"}, labels: []string{"docs", "test"}},
+		{
+			name:    "typo gets a suggestion",
+			allowed: []string{"test", "docs"},
+			labels:  []string{"tets"},
+			want:    []string{`"tets" (did you mean "test"?)`},
+		},
+		{
+			name:    "unrelated label gets none",
+			allowed: []string{"test", "docs"},
+			labels:  []string{"infra", "doc"},
+			want:    []string{`label not in allowed_labels: "infra"`, `"doc" (did you mean "docs"?)`},
+		},
+		{
+			name:    "short labels need a closer match",
+			allowed: []string{"ui"},
+			labels:  []string{"ux", "db"},
+			want:    []string{`"ux" (did you mean "ui"?)`, `"db"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Manifest: Manifest{Nebula: Info{Name: "n"}, AllowedLabels: tt.allowed},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", Labels: tt.labels}},
+			}
+			var got []ValidationError
+			for _, e := range Validate(n) {
+				if e.Category == ValCatUnknownLabel {
+					got = append(got, e)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d label errors (%v), want %d", len(got), got, len(tt.want))
+			}
+			for i, e := range got {
+				if !errors.Is(e.Err, ErrUnknownLabel) || e.PhaseID != "a" || e.Field != "labels" {
+					t.Errorf("unexpected error: %+v", e)
+				}
+				if !strings.Contains(e.Err.Error(), tt.want[i]) {
+					t.Errorf("error %q, want it to contain %q", e.Err, tt.want[i])
+				}
+				if strings.Contains(tt.want[i], "did you mean") != strings.Contains(e.Err.Error(), "did you mean") {
+					t.Errorf("error %q: unexpected suggestion", e.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"test", "test", 0},
+		{"tets", "test", 2},
+		{"doc", "docs", 1},
+		{"kitten", "sitting", 3},
+		{"héllo", "hello", 1},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
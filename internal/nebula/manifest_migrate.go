@@ -0,0 +1,62 @@
+package nebula
+
+import (
+	"fmt"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// CurrentManifestVersion is the nebula.toml layout this build reads and
+// writes. Manifests without a version key are version 0.
+const CurrentManifestVersion = 1
+
+// manifestStep rewrites raw manifest data from one layout version to the
+// next, returning a warning for each deprecated field it rewrote.
+type manifestStep func(raw map[string]any) []string
+
+// manifestMigrations maps a layout version to the step that upgrades it.
+// Version 1 only added the version key: every field of the unversioned
+// layout kept its name, type and meaning, so version 0 needs no step and
+// decodes as it is. A layout change that renames or retypes a field adds
+// its step here along with a bump of CurrentManifestVersion.
+var manifestMigrations = map[int64]manifestStep{}
+
+// migrateManifest upgrades nebula.toml data written in an older layout to
+// the current one. It returns the data to decode — unchanged when nothing
+// needed rewriting — and a warning for each deprecated field it rewrote.
+// A manifest newer than CurrentManifestVersion is an error.
+func migrateManifest(data []byte) ([]byte, []string, error) {
+	return migrateManifestWith(data, manifestMigrations)
+}
+
+// migrateManifestWith is migrateManifest with the migration steps passed in.
+func migrateManifestWith(data []byte, steps map[int64]manifestStep) ([]byte, []string, error) {
+	var raw map[string]any
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	version, ok := raw["version"].(int64)
+	if _, present := raw["version"]; present && !ok {
+		return nil, nil, fmt.Errorf("%w: version must be an integer", ErrManifestVersion)
+	}
+	if version > CurrentManifestVersion {
+		return nil, nil, fmt.Errorf("%w: version %d is newer than this quasar supports (%d); upgrade quasar",
+			ErrManifestVersion, version, CurrentManifestVersion)
+	}
+
+	var warnings []string
+	for v := version; v < CurrentManifestVersion; v++ {
+		if step := steps[v]; step != nil {
+			warnings = append(warnings, step(raw)...)
+		}
+	}
+	if len(warnings) == 0 {
+		return data, nil, nil
+	}
+	raw["version"] = int64(CurrentManifestVersion)
+	out, err := toml.Marshal(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rewriting migrated manifest: %w", err)
+	}
+	return out, warnings, nil
+}
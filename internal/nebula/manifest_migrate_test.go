@@ -0,0 +1,123 @@
+package nebula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+func TestLoad_MigratesV0Manifest(t *testing.T) {
+	t.Parallel()
+
+	// testdata/v0-manifest is a nebula written before manifests carried a
+	// version, copied unchanged from the repository's own .nebulas.
+	n, err := Load("testdata/v0-manifest")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	m := n.Manifest
+	if m.Version != CurrentManifestVersion {
+		t.Errorf("Version = %d, want %d", m.Version, CurrentManifestVersion)
+	}
+	if m.Nebula.Name != "progressive-review" || m.Context.WorkingDir != "." || len(m.Context.Goals) != 4 {
+		t.Errorf("nebula/context = %+v %+v", m.Nebula, m.Context)
+	}
+	if m.Execution.MaxWorkers != 6 || m.Execution.MaxReviewCycles != 5 || m.Execution.MaxBudgetUSD != 40 || m.Execution.Gate != GateModeTrust {
+		t.Errorf("execution = %+v", m.Execution)
+	}
+	if m.Defaults.Type != "task" || m.Defaults.Priority != 2 || len(m.Defaults.Labels) != 3 {
+		t.Errorf("defaults = %+v", m.Defaults)
+	}
+	if len(n.Phases) != 1 || n.Phases[0].ID != "strictness-tiers" || n.Phases[0].Priority != 1 {
+		t.Errorf("phases = %+v", n.Phases)
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "v0-manifest", "nebula.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, warnings, err := migrateManifest(data)
+	if err != nil || len(warnings) != 0 || string(out) != string(data) {
+		t.Errorf("migrateManifest rewrote a v0 manifest: warnings %q, err %v", warnings, err)
+	}
+}
+
+func TestMigrateManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		warnings []string
+		wantErr  error
+	}{
+		{name: "current layout", data: "version = 1\n[execution]\nmax_workers = 2\n"},
+		{name: "unversioned current fields", data: "[execution]\nmax_workers = 2\n"},
+		{name: "newer version", data: "version = 99\n", wantErr: ErrManifestVersion},
+		{name: "non-integer version", data: "version = \"1\"\n", wantErr: ErrManifestVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, warnings, err := migrateManifest([]byte(tt.data))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) != len(tt.warnings) {
+				t.Fatalf("warnings = %q, want %d", warnings, len(tt.warnings))
+			}
+			for i, w := range tt.warnings {
+				if !strings.Contains(warnings[i], w) {
+					t.Errorf("warning %d = %q, want mention of %s", i, warnings[i], w)
+				}
+			}
+			if len(tt.warnings) == 0 && string(out) != tt.data {
+				t.Errorf("data rewritten without a migration:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestMigrateManifestWithStep(t *testing.T) {
+	t.Parallel()
+
+	steps := map[int64]manifestStep{
+		0: func(raw map[string]any) []string {
+			exec := raw["execution"].(map[string]any)
+			exec["max_workers"] = exec["old_workers"]
+			delete(exec, "old_workers")
+			return []string{"execution.old_workers is deprecated"}
+		},
+	}
+	out, warnings, err := migrateManifestWith([]byte("[execution]\nold_workers = 3\n"), steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %q, want one", warnings)
+	}
+	var m Manifest
+	if err := toml.Unmarshal(out, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != CurrentManifestVersion || m.Execution.MaxWorkers != 3 {
+		t.Errorf("migrated manifest = version %d, max_workers %d; want %d and 3", m.Version, m.Execution.MaxWorkers, CurrentManifestVersion)
+	}
+
+	// A manifest already at the current version skips the step.
+	current := "version = 1\n[execution]\nold_workers = 3\n"
+	if out, warnings, _ := migrateManifestWith([]byte(current), steps); len(warnings) != 0 || string(out) != current {
+		t.Errorf("current manifest migrated: %q\n%s", warnings, out)
+	}
+}
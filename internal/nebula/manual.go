@@ -0,0 +1,139 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// PhaseTypeManual marks a phase that a human completes, such as getting a
+// sign-off. The run never hands it to an agent; it waits for someone to mark
+// it complete or failed, and its dependents stay blocked until then.
+const PhaseTypeManual = "manual"
+
+// IsManual reports whether the phase is completed by a human.
+func (p PhaseSpec) IsManual() bool {
+	return p.Type == PhaseTypeManual
+}
+
+// beadType returns the bead type to create for the phase. Beads has no
+// manual type, so manual phases are tracked as tasks.
+func (p PhaseSpec) beadType() string {
+	if p.IsManual() {
+		return "task"
+	}
+	return p.Type
+}
+
+// manualPhaseErrors reports agent-only settings on a manual phase, which
+// would otherwise be silently ignored.
+func manualPhaseErrors(p PhaseSpec) []ValidationError {
+	if !p.IsManual() {
+		return nil
+	}
+	var errs []ValidationError
+	invalid := func(field string) {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidManualPhase,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      field,
+			Err:        fmt.Errorf("%w: %s does not apply to a phase no agent runs", ErrInvalidManualPhase, field),
+		})
+	}
+	if p.Model != "" {
+		invalid("model")
+	}
+	if p.MaxBudgetUSD != 0 {
+		invalid("max_budget_usd")
+	}
+	if p.RetryBudgetUSD != 0 {
+		invalid("retry_budget_usd")
+	}
+	if p.MaxReviewCycles != 0 {
+		invalid("max_review_cycles")
+	}
+	if len(p.AllowedTools) > 0 || len(p.DeniedTools) > 0 {
+		invalid("allowed_tools/denied_tools")
+	}
+	if len(p.Checks) > 0 {
+		invalid("checks")
+	}
+	if p.SkipReview {
+		invalid("skip_review")
+	}
+	if p.Timeout != "" || p.TimeoutAction != "" {
+		invalid("timeout/timeout_action")
+	}
+	return errs
+}
+
+// isManualPhase reports whether the phase with id is a manual phase.
+func (wg *WorkerGroup) isManualPhase(id string) bool {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	p := wg.tracker.PhasesByIDMap()[id]
+	return p != nil && p.IsManual()
+}
+
+// parkManualPhase starts the manual phase id outside the worker pool. No
+// agent runs it, so waiting for the human must not hold a worker slot: only
+// the phase's dependents wait for the answer, and other phases keep
+// running. It counts as active, so the dispatch loop hears when it
+// finishes.
+func (wg *WorkerGroup) parkManualPhase(ctx context.Context, id string, trackID int, completionCh chan<- string, activeCount *int64) {
+	wg.mu.Lock()
+	wg.tracker.InFlight()[id] = true
+	wg.mu.Unlock()
+	atomic.AddInt64(activeCount, 1)
+	go func() {
+		defer func() { completionCh <- id }()
+		wg.executePhase(ctx, id, trackID)
+	}()
+}
+
+// runManualPhase asks a human to complete phase instead of running an agent
+// and records their answer. It runs parked outside the worker pool (see
+// parkManualPhase). Without a prompter it falls back to the terminal, which
+// fails the phase when stdin is not a terminal.
+func (wg *WorkerGroup) runManualPhase(ctx context.Context, phase *PhaseSpec, ps *PhaseState, done, failed, inFlight map[string]bool) {
+	prompter := wg.Prompter
+	if prompter == nil {
+		prompter = NewTerminalGater()
+	}
+	cp := &Checkpoint{
+		PhaseID:      phase.ID,
+		PhaseTitle:   phase.Title,
+		NebulaName:   wg.Nebula.Manifest.Nebula.Name,
+		Status:       PhaseStatusInProgress,
+		Manual:       true,
+		Instructions: phase.Body,
+	}
+	defer wg.announceGate(ctx, phase.ID, "manual phase awaiting completion")()
+
+	for {
+		fmt.Fprintf(wg.logger(), "\n   Manual phase %q: %s\n", phase.ID, phase.Title)
+		action, err := prompter.Prompt(ctx, cp)
+		if err != nil {
+			wg.recordResult(phase.ID, ps, nil, fmt.Errorf("prompting for manual phase %q: %w", phase.ID, err), done, failed, inFlight, nil)
+			return
+		}
+		wg.audit(AuditRecord{Event: AuditGateDecision, Phase: phase.ID, Action: string(action), Actor: AuditActorHuman})
+		switch action {
+		case GateActionAccept:
+			wg.recordResult(phase.ID, ps, nil, nil, done, failed, inFlight, nil)
+			wg.fabricPhaseComplete(ctx, phase.ID, nil)
+			return
+		case GateActionReject:
+			wg.recordResult(phase.ID, ps, nil, fmt.Errorf("%w: %q", ErrManualPhaseFailed, phase.ID), done, failed, inFlight, nil)
+			return
+		case GateActionSkip:
+			wg.recordResult(phase.ID, ps, nil, nil, done, failed, inFlight, nil)
+			wg.mu.Lock()
+			wg.gateSignals = append(wg.gateSignals, gateSignal{phaseID: phase.ID, action: GateActionSkip})
+			wg.mu.Unlock()
+			return
+		}
+		// Retry means nothing for a human step; ask again.
+	}
+}
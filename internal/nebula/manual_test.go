@@ -0,0 +1,203 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManualPhaseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		phase  PhaseSpec
+		fields []string
+	}{
+		{"agent phase is ignored", PhaseSpec{ID: "a", Type: "task", Model: "m", MaxBudgetUSD: 5}, nil},
+		{"plain manual phase", PhaseSpec{ID: "a", Type: PhaseTypeManual}, nil},
+		{
+			"agent settings on a manual phase",
+			PhaseSpec{ID: "a", Type: PhaseTypeManual, Model: "m", MaxBudgetUSD: 5, RetryBudgetUSD: 1, MaxReviewCycles: 2, DeniedTools: []string{"Bash"}},
+			[]string{"model", "max_budget_usd", "retry_budget_usd", "max_review_cycles", "allowed_tools/denied_tools"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := manualPhaseErrors(tt.phase)
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("got %d errors, want %d: %v", len(errs), len(tt.fields), errs)
+			}
+			for i, e := range errs {
+				if e.Field != tt.fields[i] || e.Category != ValCatInvalidManualPhase || !errors.Is(e.Err, ErrInvalidManualPhase) {
+					t.Errorf("error %d = %+v, want field %q", i, e, tt.fields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPhaseSpecBeadType(t *testing.T) {
+	t.Parallel()
+
+	if got := (PhaseSpec{Type: PhaseTypeManual}).beadType(); got != "task" {
+		t.Errorf("manual beadType = %q, want task", got)
+	}
+	if got := (PhaseSpec{Type: "bug"}).beadType(); got != "bug" {
+		t.Errorf("bug beadType = %q, want bug", got)
+	}
+}
+
+// scriptedPrompter answers prompts with actions in order and records the
+// checkpoints it was shown.
+type scriptedPrompter struct {
+	actions []GateAction
+	seen    []*Checkpoint
+}
+
+func (p *scriptedPrompter) Prompt(_ context.Context, cp *Checkpoint) (GateAction, error) {
+	p.seen = append(p.seen, cp)
+	action := p.actions[0]
+	p.actions = p.actions[1:]
+	return action, nil
+}
+
+func TestWorkerGroup_ManualPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		actions    []GateAction
+		wantStatus PhaseStatus
+		wantRuns   int // agent runs of the dependent phase
+	}{
+		{"marked complete unblocks dependents", []GateAction{GateActionAccept}, PhaseStatusDone, 1},
+		{"retry asks again", []GateAction{GateActionRetry, GateActionAccept}, PhaseStatusDone, 1},
+		{"marked failed blocks dependents", []GateAction{GateActionReject}, PhaseStatusFailed, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}},
+				Phases: []PhaseSpec{
+					{ID: "signoff", Title: "Legal sign-off", Type: PhaseTypeManual, Body: "Get legal to approve the terms."},
+					{ID: "ship", Title: "Ship", DependsOn: []string{"signoff"}},
+				},
+			}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{
+				"signoff": {BeadID: "bead-signoff", Status: PhaseStatusCreated},
+				"ship":    {BeadID: "bead-ship", Status: PhaseStatusCreated},
+			}}
+			runner := &mockRunner{result: &PhaseRunnerResult{}}
+			prompter := &scriptedPrompter{actions: tt.actions}
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithPrompter(prompter), WithLogger(io.Discard))
+
+			results, err := wg.Run(context.Background())
+			if tt.wantStatus == PhaseStatusDone && err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got := state.Phases["signoff"].Status; got != tt.wantStatus {
+				t.Errorf("signoff status = %s, want %s", got, tt.wantStatus)
+			}
+			for _, c := range runner.getCalls() {
+				if c == "bead-signoff" {
+					t.Error("manual phase was handed to the runner")
+				}
+			}
+			if got := len(runner.getCalls()); got != tt.wantRuns {
+				t.Errorf("runner calls = %d, want %d", got, tt.wantRuns)
+			}
+			if len(prompter.seen) != len(tt.actions) {
+				t.Fatalf("prompted %d times, want %d", len(prompter.seen), len(tt.actions))
+			}
+			if cp := prompter.seen[0]; !cp.Manual || cp.Instructions != "Get legal to approve the terms." {
+				t.Errorf("checkpoint = %+v, want a manual checkpoint with the phase body", cp)
+			}
+			if tt.wantStatus == PhaseStatusFailed {
+				for _, r := range results {
+					if r.PhaseID == "signoff" && !errors.Is(r.Err, ErrManualPhaseFailed) {
+						t.Errorf("signoff error = %v, want ErrManualPhaseFailed", r.Err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// awaitPrompter answers every prompt with accept once ready is closed, or
+// with reject when it is not closed in time.
+type awaitPrompter struct {
+	ready <-chan struct{}
+}
+
+func (p awaitPrompter) Prompt(context.Context, *Checkpoint) (GateAction, error) {
+	select {
+	case <-p.ready:
+		return GateActionAccept, nil
+	case <-time.After(5 * time.Second):
+		return GateActionReject, nil
+	}
+}
+
+func TestWorkerGroup_ManualPhaseFreesWorkerSlot(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases: []PhaseSpec{
+			{ID: "signoff", Title: "Legal sign-off", Type: PhaseTypeManual},
+			{ID: "ship", Title: "Ship", DependsOn: []string{"signoff"}},
+			{ID: "docs", Title: "Docs"},
+		},
+	}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{
+		"signoff": {BeadID: "bead-signoff", Status: PhaseStatusCreated},
+		"ship":    {BeadID: "bead-ship", Status: PhaseStatusCreated},
+		"docs":    {BeadID: "bead-docs", Status: PhaseStatusCreated},
+	}}
+	// The human answers only after the independent phase has run, which
+	// with one worker is possible only if the wait holds no worker slot.
+	docsRan := make(chan struct{})
+	runner := &mockRunner{resultFunc: func(beadID string) *PhaseRunnerResult {
+		if beadID == "bead-docs" {
+			close(docsRan)
+		}
+		return &PhaseRunnerResult{}
+	}}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithPrompter(awaitPrompter{ready: docsRan}),
+		WithMaxWorkers(1), WithLogger(io.Discard))
+
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, id := range []string{"signoff", "ship", "docs"} {
+		if got := state.Phases[id].Status; got != PhaseStatusDone {
+			t.Errorf("%s status = %s, want done", id, got)
+		}
+	}
+	if calls := runner.getCalls(); len(calls) != 2 || calls[0] != "bead-docs" || calls[1] != "bead-ship" {
+		t.Errorf("runner calls = %v, want docs before ship", calls)
+	}
+}
+
+func TestTerminalGater_ManualPhaseNonTTYFails(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	g := newTerminalGaterWithIO(strings.NewReader("a\n"), &out)
+	action, err := g.Prompt(context.Background(), &Checkpoint{PhaseID: "signoff", Manual: true})
+	if err != nil || action != GateActionReject {
+		t.Errorf("Prompt = %s, %v; want reject", action, err)
+	}
+	if !strings.Contains(out.String(), "cannot confirm manual phase") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -45,6 +45,7 @@ type Metrics struct {
 	TotalWaves     int
 	TotalConflicts int
 	TotalRestarts  int
+	MaxWorkers     int // configured worker limit for the run (0 = not recorded)
 	Phases         []PhaseMetrics
 	Waves          []WaveMetrics
 	mu             sync.Mutex
@@ -135,6 +136,14 @@ func (m *Metrics) RecordLockWait(phaseID string, waited time.Duration) {
 	}
 }
 
+// RecordMaxWorkers records the configured worker limit for the run.
+func (m *Metrics) RecordMaxWorkers(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MaxWorkers = n
+}
+
 // RecordWaveComplete records the completion of a wave of parallel phases.
 func (m *Metrics) RecordWaveComplete(wave int, effective, actual int) {
 	m.mu.Lock()
@@ -180,6 +189,7 @@ func (m *Metrics) Snapshot() *Metrics {
 		TotalWaves:     m.TotalWaves,
 		TotalConflicts: m.TotalConflicts,
 		TotalRestarts:  m.TotalRestarts,
+		MaxWorkers:     m.MaxWorkers,
 	}
 
 	snap.Phases = make([]PhaseMetrics, len(m.Phases))
@@ -33,6 +33,7 @@ type metricsRecord struct {
 	TotalWaves     int           `toml:"total_waves"`
 	TotalConflicts int           `toml:"total_conflicts"`
 	TotalRestarts  int           `toml:"total_restarts"`
+	MaxWorkers     int           `toml:"max_workers,omitempty"`
 	Phases         []phaseRecord `toml:"phases"`
 	Waves          []waveRecord  `toml:"waves"`
 }
@@ -102,11 +103,14 @@ func SaveMetrics(dir string, m *Metrics) error {
 		history = history[len(history)-maxHistoryEntries:]
 	}
 
-	file := metricsFile{
+	return writeMetricsFile(dir, metricsFile{
 		Current: record,
 		History: history,
-	}
+	})
+}
 
+// writeMetricsFile atomically replaces the metrics file in dir with file.
+func writeMetricsFile(dir string, file metricsFile) error {
 	data, err := toml.Marshal(file)
 	if err != nil {
 		return fmt.Errorf("marshaling metrics: %w", err)
@@ -249,6 +253,7 @@ func metricsToRecord(m *Metrics) metricsRecord {
 		TotalWaves:     m.TotalWaves,
 		TotalConflicts: m.TotalConflicts,
 		TotalRestarts:  m.TotalRestarts,
+		MaxWorkers:     m.MaxWorkers,
 		Phases:         phases,
 		Waves:          waves,
 	}
@@ -297,6 +302,7 @@ func recordToMetrics(r metricsRecord) *Metrics {
 		TotalWaves:     r.TotalWaves,
 		TotalConflicts: r.TotalConflicts,
 		TotalRestarts:  r.TotalRestarts,
+		MaxWorkers:     r.MaxWorkers,
 		Phases:         phases,
 		Waves:          waves,
 	}
@@ -25,6 +25,7 @@ func TestSaveAndLoadMetrics(t *testing.T) {
 	m.RecordRestart("p1")
 	m.RecordLockWait("p1", 50*time.Millisecond)
 	m.RecordWaveComplete(0, 4, 2)
+	m.RecordMaxWorkers(6)
 
 	// Set coordination fields on the wave directly to verify round-trip.
 	m.Waves[0].ChangeVolume = 42
@@ -57,6 +58,9 @@ func TestSaveAndLoadMetrics(t *testing.T) {
 	if loaded.TotalConflicts != snap.TotalConflicts {
 		t.Errorf("TotalConflicts = %d, want %d", loaded.TotalConflicts, snap.TotalConflicts)
 	}
+	if loaded.MaxWorkers != snap.MaxWorkers {
+		t.Errorf("MaxWorkers = %d, want %d", loaded.MaxWorkers, snap.MaxWorkers)
+	}
 	if loaded.TotalRestarts != snap.TotalRestarts {
 		t.Errorf("TotalRestarts = %d, want %d", loaded.TotalRestarts, snap.TotalRestarts)
 	}
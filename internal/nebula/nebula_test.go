@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -356,15 +357,17 @@ type mockBeadsClient struct {
 	created   map[string]string // title → id
 	shown     map[string]*beads.Bead
 	closed    map[string]string
+	comments  map[string][]string // id → comment bodies
 	nextID    int
 	createErr error
 }
 
 func newMockBeadsClient() *mockBeadsClient {
 	return &mockBeadsClient{
-		created: make(map[string]string),
-		shown:   make(map[string]*beads.Bead),
-		closed:  make(map[string]string),
+		created:  make(map[string]string),
+		shown:    make(map[string]*beads.Bead),
+		closed:   make(map[string]string),
+		comments: make(map[string][]string),
 	}
 }
 
@@ -397,6 +400,9 @@ func (m *mockBeadsClient) Close(_ context.Context, id string, reason string) err
 }
 
 func (m *mockBeadsClient) AddComment(_ context.Context, id string, body string) error {
+	if m.comments != nil {
+		m.comments[id] = append(m.comments[id], body)
+	}
 	return nil
 }
 
@@ -494,6 +500,89 @@ func TestBuildPlan_FailedPhase(t *testing.T) {
 	}
 }
 
+func TestBuildPlan_RemovedPhases(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases:   []PhaseSpec{{ID: "api-v2", Title: "API"}},
+	}
+	state := &State{
+		Version: 1,
+		Phases: map[string]*PhaseState{
+			"zeta":    {BeadID: "bead-z", Status: PhaseStatusCreated},
+			"api":     {BeadID: "bead-api", Status: PhaseStatusCreated},
+			"shipped": {BeadID: "bead-s", Status: PhaseStatusDone},
+			"no-bead": {Status: PhaseStatusPending},
+		},
+	}
+
+	plan, err := BuildPlan(context.Background(), n, state, newMockBeadsClient())
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	var closed []string
+	for _, a := range plan.Actions {
+		if a.Type == ActionClose {
+			if a.Reason != "removed from spec" {
+				t.Errorf("close reason = %q", a.Reason)
+			}
+			closed = append(closed, a.PhaseID)
+		}
+	}
+	if strings.Join(closed, ",") != "api,zeta" {
+		t.Errorf("closed = %v, want api and zeta in order", closed)
+	}
+
+	removed, added := plan.RenameCandidates(n, state)
+	if strings.Join(removed, ",") != "api,shipped,zeta" || strings.Join(added, ",") != "api-v2" {
+		t.Errorf("candidates = %v / %v", removed, added)
+	}
+	if err := plan.Rename(n, state, "no-bead", "api-v2"); !errors.Is(err, ErrUnknownPhase) {
+		t.Errorf("renaming a phase without a bead: err = %v", err)
+	}
+	if err := plan.Rename(n, state, "api", "missing"); !errors.Is(err, ErrUnknownPhase) {
+		t.Errorf("renaming to an unknown phase: err = %v", err)
+	}
+}
+
+func TestApply_RenamedPhaseKeepsBead(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases:   []PhaseSpec{{ID: "api-v2", Title: "API"}},
+	}
+	state := &State{
+		Version: 1,
+		Phases:  map[string]*PhaseState{"api": {BeadID: "bead-api", Status: PhaseStatusFailed, Attempts: 1}},
+	}
+	client := newMockBeadsClient()
+
+	plan, err := BuildPlan(context.Background(), n, state, client)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	if err := plan.Rename(n, state, "api", "api-v2"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if len(plan.Actions) != 1 || plan.Actions[0].Type != ActionRename || plan.Actions[0].From != "api" {
+		t.Fatalf("actions = %+v, want a single rename", plan.Actions)
+	}
+
+	if err := Apply(context.Background(), plan, n, state, client); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(client.created) != 0 || len(client.closed) != 0 {
+		t.Errorf("rename created %v and closed %v", client.created, client.closed)
+	}
+	ps := state.Phases["api-v2"]
+	if _, old := state.Phases["api"]; old || ps == nil || ps.BeadID != "bead-api" || ps.Attempts != 1 {
+		t.Errorf("state after rename = %+v", state.Phases)
+	}
+}
+
 // --- Apply tests ---
 
 func TestApply_CreatesBeads(t *testing.T) {
@@ -978,6 +1067,7 @@ func TestIsInterventionFile(t *testing.T) {
 		{"PAUSE", true},
 		{"STOP", true},
 		{"RETRY", true},
+		{"DRAIN", true},
 		{"pause", false},
 		{"stop", false},
 		{"retry", false},
@@ -997,20 +1087,20 @@ func TestIsInterventionFile(t *testing.T) {
 
 func TestInterventionFileNames(t *testing.T) {
 	names := InterventionFileNames()
-	if len(names) != 3 {
-		t.Fatalf("expected 3 intervention file names, got %d", len(names))
+	if len(names) != 4 {
+		t.Fatalf("expected 4 intervention file names, got %d", len(names))
 	}
 
 	sort.Strings(names)
-	if names[0] != "PAUSE" || names[1] != "RETRY" || names[2] != "STOP" {
-		t.Errorf("expected [PAUSE, RETRY, STOP], got %v", names)
+	if names[0] != "DRAIN" || names[1] != "PAUSE" || names[2] != "RETRY" || names[3] != "STOP" {
+		t.Errorf("expected [DRAIN, PAUSE, RETRY, STOP], got %v", names)
 	}
 }
 
 func TestGitExcludePatterns(t *testing.T) {
 	patterns := GitExcludePatterns()
-	if len(patterns) != 3 {
-		t.Fatalf("expected 3 patterns, got %d", len(patterns))
+	if len(patterns) != 4 {
+		t.Fatalf("expected 4 patterns, got %d", len(patterns))
 	}
 
 	joined := strings.Join(patterns, ",")
@@ -1023,6 +1113,9 @@ func TestGitExcludePatterns(t *testing.T) {
 	if !strings.Contains(joined, "RETRY") {
 		t.Error("expected RETRY in exclude patterns")
 	}
+	if !strings.Contains(joined, "DRAIN") {
+		t.Error("expected DRAIN in exclude patterns")
+	}
 }
 
 // --- Gate mode tests ---
@@ -1105,6 +1198,30 @@ func TestValidate_InvalidManifestGate(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidOnFailure(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Manifest: Manifest{
+			Nebula:    Info{Name: "test"},
+			Execution: Execution{OnFailure: "explode"},
+		},
+		Phases: []PhaseSpec{
+			{ID: "a", Title: "Phase A", Body: "do stuff", SourceFile: "a.md"},
+		},
+	}
+
+	errs := Validate(n)
+	if len(errs) != 1 || errs[0].Category != ValCatInvalidFailurePolicy || !errors.Is(errs[0].Err, ErrInvalidFailurePolicy) {
+		t.Errorf("expected one invalid on_failure error, got %v", errs)
+	}
+
+	n.Manifest.Execution.OnFailure = FailurePolicyAbort
+	if errs := Validate(n); len(errs) != 0 {
+		t.Errorf("on_failure = abort should validate, got %v", errs)
+	}
+}
+
 func TestValidate_InvalidPhaseGate(t *testing.T) {
 	t.Parallel()
 
@@ -1690,6 +1807,133 @@ func TestWorkerGroup_ApproveMode_PlanRejectedWithReject(t *testing.T) {
 	}
 }
 
+func TestWorkerGroup_OnFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy    FailurePolicy
+		wantCalls int
+		wantErr   error
+	}{
+		{policy: "", wantCalls: 2},
+		{policy: FailurePolicyContinue, wantCalls: 2},
+		{policy: FailurePolicyAbort, wantCalls: 1, wantErr: ErrAbortedOnFailure},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir: t.TempDir(),
+				Manifest: Manifest{
+					Nebula:    Info{Name: "test"},
+					Execution: Execution{OnFailure: tt.policy},
+				},
+				Phases: []PhaseSpec{
+					{ID: "a", Body: "phase a"},
+					{ID: "b", Body: "phase b"},
+				},
+			}
+			state := &State{
+				Version: 1,
+				Phases: map[string]*PhaseState{
+					"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+					"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+				},
+			}
+			runner := &mockRunner{err: errors.New("tests failed")}
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithMaxWorkers(1))
+
+			results, err := wg.Run(context.Background())
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Run error = %v, want %v", err, tt.wantErr)
+			}
+			if got := len(runner.getCalls()); got != tt.wantCalls {
+				t.Errorf("phase executions = %d, want %d", got, tt.wantCalls)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+			if code := ExitCode(err, results); code != ExitPartialFailure {
+				t.Errorf("ExitCode = %d, want %d", code, ExitPartialFailure)
+			}
+			var skipped int
+			for _, ps := range state.Phases {
+				if ps.Status == PhaseStatusSkipped {
+					skipped++
+				}
+			}
+			if skipped != 1 {
+				t.Errorf("skipped phases = %d, want 1", skipped)
+			}
+		})
+	}
+}
+
+func TestWorkerGroup_SkipReasons(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir: t.TempDir(),
+		Manifest: Manifest{
+			Nebula:    Info{Name: "test"},
+			Execution: Execution{OnFailure: FailurePolicyAbort},
+		},
+		Phases: []PhaseSpec{
+			{ID: "a", Body: "phase a", Priority: 1},
+			{ID: "b", Body: "phase b", DependsOn: []string{"a"}},
+			{ID: "c", Body: "phase c", Priority: 2},
+		},
+	}
+	state := &State{
+		Version: 1,
+		Phases: map[string]*PhaseState{
+			"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+			"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+			"c": {BeadID: "bead-c", Status: PhaseStatusCreated},
+		},
+	}
+	var mu sync.Mutex
+	reported := make(map[string]string)
+	wg := NewWorkerGroup(n, state,
+		WithRunner(&mockRunner{err: errors.New("tests failed")}),
+		WithMaxWorkers(1),
+		WithOnSkip(func(phaseID, reason string) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported[phaseID] = reason
+		}),
+	)
+
+	if _, err := wg.Run(context.Background()); !errors.Is(err, ErrAbortedOnFailure) {
+		t.Fatalf("Run error = %v, want ErrAbortedOnFailure", err)
+	}
+
+	want := map[string]string{
+		"b": `dependency "a" failed`,
+		"c": `run aborted: phase "a" failed (on_failure = abort)`,
+	}
+	for id, reason := range want {
+		ps := state.Phases[id]
+		if ps.Status != PhaseStatusSkipped || ps.SkipReason != reason {
+			t.Errorf("phase %s = %s (%q), want skipped (%q)", id, ps.Status, ps.SkipReason, reason)
+		}
+		if reported[id] != reason {
+			t.Errorf("OnSkip(%s) reason = %q, want %q", id, reported[id], reason)
+		}
+	}
+	if state.Phases["a"].SkipReason != "" {
+		t.Errorf("failed phase a has skip reason %q", state.Phases["a"].SkipReason)
+	}
+
+	loaded, err := LoadState(n.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Phases["b"].SkipReason; got != want["b"] {
+		t.Errorf("persisted skip reason = %q, want %q", got, want["b"])
+	}
+}
+
 // --- Metrics instrumentation tests ---
 
 func TestWorkerGroup_NilMetrics_NoPanics(t *testing.T) {
@@ -2000,3 +2244,37 @@ func TestWorkerGroup_NilGater_NoPlanGate(t *testing.T) {
 		t.Errorf("expected 1 phase execution with nil gater, got %d", len(runner.getCalls()))
 	}
 }
+
+func TestWorkerGroup_PriorityOrder(t *testing.T) {
+	t.Parallel()
+
+	// One wave of three independent phases and a single worker: the
+	// explicit priorities decide the order, and unset (0) runs last.
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases: []PhaseSpec{
+			{ID: "a", Body: "phase a"},
+			{ID: "b", Body: "phase b", Priority: 2},
+			{ID: "c", Body: "phase c", Priority: 1},
+		},
+	}
+	state := &State{
+		Version: 1,
+		Phases: map[string]*PhaseState{
+			"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+			"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+			"c": {BeadID: "bead-c", Status: PhaseStatusCreated},
+		},
+	}
+	runner := &mockRunner{result: &PhaseRunnerResult{}}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithMaxWorkers(1))
+
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := []string{"bead-c", "bead-b", "bead-a"}
+	if got := runner.getCalls(); !slices.Equal(got, want) {
+		t.Errorf("execution order = %v, want %v", got, want)
+	}
+}
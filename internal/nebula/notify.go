@@ -0,0 +1,186 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// notifyTimeout bounds a single notification delivery so a slow endpoint
+// cannot stall a gate prompt or the end of a run.
+const notifyTimeout = 10 * time.Second
+
+// NotifyEvent identifies why a notification was sent.
+type NotifyEvent string
+
+const (
+	// NotifyGate is sent when a gate prompt is waiting for a human decision.
+	NotifyGate NotifyEvent = "gate"
+	// NotifyHail is sent when a blocked phase escalates to a human.
+	NotifyHail NotifyEvent = "hail"
+	// NotifyDone is sent when the nebula run finishes.
+	NotifyDone NotifyEvent = "done"
+	// NotifyIdle is sent when an unanswered gate prompt pauses the run.
+	NotifyIdle NotifyEvent = "idle"
+)
+
+// Notification is the payload delivered to a Notifier.
+type Notification struct {
+	Event  NotifyEvent `json:"event"`
+	Nebula string      `json:"nebula"`
+	Phase  string      `json:"phase,omitempty"`
+	Reason string      `json:"reason"`
+}
+
+// Text renders the notification as a one-line human-readable message.
+func (n Notification) Text() string {
+	if n.Phase == "" {
+		return fmt.Sprintf("[%s] %s: %s", n.Nebula, n.Event, n.Reason)
+	}
+	return fmt.Sprintf("[%s] %s on phase %q: %s", n.Nebula, n.Event, n.Phase, n.Reason)
+}
+
+// Notifier delivers out-of-band notifications for long unattended runs.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// WebhookNotifier POSTs notifications as JSON to a URL. The body carries the
+// Notification fields plus a "text" field, so Slack incoming webhooks render
+// it directly.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier that posts to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+// Notify posts the notification and returns an error for transport failures
+// and non-2xx responses.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(struct {
+		Notification
+		Text string `json:"text"`
+	}{n, n.Text()})
+	if err != nil {
+		return fmt.Errorf("encoding notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// notify delivers a notification if a Notifier is configured. Failures are
+// logged and otherwise ignored. Delivery outlives ctx cancellation (up to
+// notifyTimeout) so the final notification of an interrupted run still goes out.
+func (wg *WorkerGroup) notify(ctx context.Context, event NotifyEvent, phaseID, reason string) {
+	if wg.Notifier == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	n := Notification{Event: event, Nebula: wg.Nebula.Manifest.Nebula.Name, Phase: phaseID, Reason: reason}
+	if err := wg.Notifier.Notify(ctx, n); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: notification failed: %v\n", err)
+	}
+}
+
+// hailFunc returns the hail callback handed to the scheduler: it notifies,
+// publishes to the event socket, and then forwards to OnHail. It returns
+// nil when none of them is configured.
+func (wg *WorkerGroup) hailFunc(ctx context.Context) func(string, fabric.Discovery) {
+	if wg.Notifier == nil && wg.events == nil {
+		return wg.OnHail
+	}
+	return func(phaseID string, d fabric.Discovery) {
+		wg.notify(ctx, NotifyHail, phaseID, d.Detail)
+		wg.events.publish(StreamEvent{Kind: StreamEventHail, Phase: phaseID, Hail: &d})
+		if wg.OnHail != nil {
+			wg.OnHail(phaseID, d)
+		}
+	}
+}
+
+// notifyDone sends the completion notification for a run.
+func (wg *WorkerGroup) notifyDone(ctx context.Context, results []WorkerResult, err error) {
+	if wg.Notifier == nil {
+		return
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	reason := fmt.Sprintf("finished: %d phases run, %d failed", len(results), failed)
+	if err != nil {
+		reason = fmt.Sprintf("stopped: %v", err)
+	}
+	wg.notify(ctx, NotifyDone, "", reason)
+}
+
+// notifyingGater announces each gate that waits for a human — phase gates
+// in review or approve mode and the plan gate in approve mode — to the
+// Notifier and the event socket before it prompts.
+type notifyingGater struct {
+	Gater
+	wg *WorkerGroup
+}
+
+// PhaseGate notifies when the phase's gate mode prompts, then delegates.
+func (g notifyingGater) PhaseGate(ctx context.Context, phase *PhaseSpec, cp *Checkpoint) (GateAction, error) {
+	if mode := ResolveGate(g.wg.Nebula.Manifest.Execution, *phase); mode == GateModeReview || mode == GateModeApprove {
+		defer g.wg.announceGate(ctx, phase.ID, "phase awaiting gate decision")()
+	}
+	return g.Gater.PhaseGate(ctx, phase, cp)
+}
+
+// PlanGate notifies when the plan needs approval, then delegates.
+func (g notifyingGater) PlanGate(ctx context.Context, cp *Checkpoint) error {
+	if g.wg.Nebula.Manifest.Execution.Gate == GateModeApprove {
+		defer g.wg.announceGate(ctx, "", "execution plan awaiting approval")()
+	}
+	return g.Gater.PlanGate(ctx, cp)
+}
+
+// announceGate notifies and publishes a gate event for a gate about to
+// prompt, and records the gate as open until the returned func is called
+// once the gate is answered. phaseID is empty for the plan gate.
+func (wg *WorkerGroup) announceGate(ctx context.Context, phaseID, reason string) func() {
+	wg.notify(ctx, NotifyGate, phaseID, reason)
+	key := phaseID
+	if key == "" {
+		key = PlanPhaseID
+	}
+	wg.mu.Lock()
+	if wg.openGates == nil {
+		wg.openGates = make(map[string]bool)
+	}
+	wg.openGates[key] = true
+	wg.events.publish(StreamEvent{Kind: StreamEventGate, Phase: phaseID, Reason: reason})
+	wg.mu.Unlock()
+	return func() {
+		wg.mu.Lock()
+		delete(wg.openGates, key)
+		wg.mu.Unlock()
+	}
+}
@@ -0,0 +1,161 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// webhookRecorder is an httptest handler that records decoded payloads and
+// replies with status.
+type webhookRecorder struct {
+	mu       sync.Mutex
+	payloads []map[string]string
+	status   int
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var p map[string]string
+	if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.payloads = append(r.payloads, p)
+	r.mu.Unlock()
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookRecorder) events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, p := range r.payloads {
+		out = append(out, p["event"]+":"+p["phase"])
+	}
+	return out
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	rec := &webhookRecorder{status: http.StatusOK}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := Notification{Event: NotifyGate, Nebula: "demo", Phase: "api", Reason: "phase awaiting gate decision"}
+	if err := NewWebhookNotifier(srv.URL).Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(rec.payloads) != 1 {
+		t.Fatalf("got %d requests, want 1", len(rec.payloads))
+	}
+	got := rec.payloads[0]
+	want := map[string]string{
+		"event":  "gate",
+		"nebula": "demo",
+		"phase":  "api",
+		"reason": "phase awaiting gate decision",
+		"text":   `[demo] gate on phase "api": phase awaiting gate decision`,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("payload[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestWebhookNotifierErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(&webhookRecorder{status: http.StatusInternalServerError})
+	defer srv.Close()
+
+	err := NewWebhookNotifier(srv.URL).Notify(context.Background(), Notification{Event: NotifyDone, Nebula: "demo"})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Notify error = %v, want a 500 status error", err)
+	}
+}
+
+func TestWorkerGroupNotifications(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		gate   GateMode
+		status int
+		want   []string
+	}{
+		{"trust mode only reports completion", GateModeTrust, http.StatusOK, []string{"done:"}},
+		{"review mode reports each gate", GateModeReview, http.StatusOK, []string{"gate:a", "done:"}},
+		{"approve mode reports the plan gate", GateModeApprove, http.StatusOK, []string{"gate:", "gate:a", "done:"}},
+		{"webhook failures are not fatal", GateModeReview, http.StatusBadGateway, []string{"gate:a", "done:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := &webhookRecorder{status: tt.status}
+			srv := httptest.NewServer(rec)
+			defer srv.Close()
+
+			n := &Nebula{
+				Dir: t.TempDir(),
+				Manifest: Manifest{
+					Nebula:    Info{Name: "test"},
+					Execution: Execution{Gate: tt.gate},
+				},
+				Phases: []PhaseSpec{{ID: "a", Body: "do stuff"}},
+			}
+			state := &State{
+				Version: 1,
+				Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+			}
+			var logs bytes.Buffer
+			wg := NewWorkerGroup(n, state,
+				WithRunner(&mockRunner{}),
+				WithPrompter(&mockGater{action: GateActionAccept}),
+				WithNotifier(NewWebhookNotifier(srv.URL)),
+				WithLogger(&logs),
+			)
+
+			if _, err := wg.Run(context.Background()); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got := strings.Join(rec.events(), ","); got != strings.Join(tt.want, ",") {
+				t.Errorf("notifications = %s, want %s", got, strings.Join(tt.want, ","))
+			}
+			if tt.status != http.StatusOK && !strings.Contains(logs.String(), "notification failed") {
+				t.Errorf("expected a logged warning, got %q", logs.String())
+			}
+		})
+	}
+}
+
+func TestHailFuncNotifiesAndForwards(t *testing.T) {
+	t.Parallel()
+
+	rec := &webhookRecorder{status: http.StatusOK}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	var forwarded string
+	wg := NewWorkerGroup(&Nebula{Manifest: Manifest{Nebula: Info{Name: "test"}}}, &State{},
+		WithNotifier(NewWebhookNotifier(srv.URL)),
+	)
+	wg.OnHail = func(phaseID string, _ fabric.Discovery) { forwarded = phaseID }
+
+	wg.hailFunc(context.Background())("b", fabric.Discovery{Detail: "escalated"})
+	if forwarded != "b" {
+		t.Errorf("OnHail got %q, want b", forwarded)
+	}
+	if len(rec.payloads) != 1 || rec.payloads[0]["event"] != "hail" || rec.payloads[0]["reason"] != "escalated" {
+		t.Errorf("payloads = %v, want one hail with reason escalated", rec.payloads)
+	}
+}
@@ -0,0 +1,63 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// outputLogDirName is the directory under the nebula that holds per-phase
+// agent output when output persistence is on.
+const outputLogDirName = "logs"
+
+// OutputLogDir returns the directory under the nebula in dir that holds
+// per-phase agent output logs.
+func OutputLogDir(dir string) string {
+	return filepath.Join(dir, outputLogDirName)
+}
+
+// PhaseOutputLogs returns the paths of phaseID's output logs under the
+// nebula in dir, in the order they were written: by cycle, then coder
+// before reviewer. A phase with no logs yet returns none.
+func PhaseOutputLogs(dir, phaseID string) ([]string, error) {
+	phaseDir := filepath.Join(OutputLogDir(dir), phaseID)
+	entries, err := os.ReadDir(phaseDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading output logs: %w", err)
+	}
+
+	type logFile struct {
+		cycle int
+		role  string
+		name  string
+	}
+	var logs []logFile
+	for _, e := range entries {
+		var cycle int
+		var rest string
+		if e.IsDir() {
+			continue
+		}
+		if _, err := fmt.Sscanf(e.Name(), "cycle%d-%s", &cycle, &rest); err != nil || !strings.HasSuffix(rest, ".txt") {
+			continue
+		}
+		logs = append(logs, logFile{cycle: cycle, role: strings.TrimSuffix(rest, ".txt"), name: e.Name()})
+	}
+	slices.SortFunc(logs, func(a, b logFile) int {
+		if a.cycle != b.cycle {
+			return a.cycle - b.cycle
+		}
+		return strings.Compare(a.role, b.role)
+	})
+
+	paths := make([]string, len(logs))
+	for i, l := range logs {
+		paths[i] = filepath.Join(phaseDir, l.name)
+	}
+	return paths, nil
+}
@@ -0,0 +1,40 @@
+package nebula
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPhaseOutputLogs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if logs, err := PhaseOutputLogs(dir, "a"); err != nil || logs != nil {
+		t.Fatalf("no logs yet: got %v, %v", logs, err)
+	}
+
+	phaseDir := filepath.Join(OutputLogDir(dir), "a")
+	if err := os.MkdirAll(phaseDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"cycle10-coder.txt", "cycle2-reviewer.txt", "cycle2-coder.txt", "notes.md", "cycle1-coder.txt"} {
+		if err := os.WriteFile(filepath.Join(phaseDir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logs, err := PhaseOutputLogs(dir, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range logs {
+		names = append(names, filepath.Base(p))
+	}
+	want := []string{"cycle1-coder.txt", "cycle2-coder.txt", "cycle2-reviewer.txt", "cycle10-coder.txt"}
+	if !slices.Equal(names, want) {
+		t.Errorf("logs = %v, want %v", names, want)
+	}
+}
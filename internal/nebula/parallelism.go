@@ -46,7 +46,7 @@ func EffectiveParallelism(wave Wave, phases []PhaseSpec, d *dag.DAG, maxWorkers
 				continue
 			}
 
-			if _, _, overlaps := scopesOverlap(a.Scope, b.Scope); overlaps {
+			if _, _, overlaps := scopesOverlap(a.rootedScope(), b.rootedScope()); overlaps {
 				if conflicts[a.ID] == nil {
 					conflicts[a.ID] = make(map[string]bool)
 				}
@@ -0,0 +1,140 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// paramName is the form of a param name, as declared in params or
+// requires_params and passed with --param.
+var paramName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// paramRef matches a ${params.NAME} reference in a phase body.
+var paramRef = regexp.MustCompile(`\$\{params\.([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// ParseParams parses --param flags of the form key=value. A later flag for
+// the same key wins.
+func ParseParams(flags []string) (map[string]string, error) {
+	params := make(map[string]string, len(flags))
+	for _, f := range flags {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || !paramName.MatchString(key) {
+			return nil, fmt.Errorf("%w: %q (want key=value)", ErrInvalidParam, f)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// ResolveParams returns the params a phase uses, those it declares in
+// params or requires_params or references in its body, with its own
+// defaults overridden by the launch params. A param with no value from
+// either source is left out.
+func ResolveParams(p *PhaseSpec, launch map[string]string) map[string]string {
+	var resolved map[string]string
+	for _, name := range usedParams(p) {
+		v, ok := launch[name]
+		if !ok {
+			v, ok = p.Params[name]
+		}
+		if ok {
+			if resolved == nil {
+				resolved = make(map[string]string)
+			}
+			resolved[name] = v
+		}
+	}
+	return resolved
+}
+
+// usedParams returns the names of the params p declares in params or
+// needs, sorted and deduplicated.
+func usedParams(p *PhaseSpec) []string {
+	names := append(slices.Collect(maps.Keys(p.Params)), neededParams(p)...)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// neededParams returns the names of the params p lists in requires_params
+// or references in its body outside code fences, sorted and deduplicated.
+func neededParams(p *PhaseSpec) []string {
+	names := slices.Clone(p.RequiresParams)
+	replaceOutsideFences(p.Body, paramRef, func(ref string) string {
+		names = append(names, paramRef.FindStringSubmatch(ref)[1])
+		return ref
+	})
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// substituteParams expands ${params.NAME} references in body outside
+// fenced code blocks. References to params with no value are left as is.
+func substituteParams(body string, params map[string]string) string {
+	if len(params) == 0 {
+		return body
+	}
+	return replaceOutsideFences(body, paramRef, func(ref string) string {
+		if v, ok := params[paramRef.FindStringSubmatch(ref)[1]]; ok {
+			return v
+		}
+		return ref
+	})
+}
+
+// MissingParams returns the params p lists in requires_params or
+// references in its body that have no value in resolved, sorted. A param
+// set to the empty string has a value.
+func MissingParams(p *PhaseSpec, resolved map[string]string) []string {
+	var missing []string
+	for _, name := range neededParams(p) {
+		if _, ok := resolved[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// CheckParams reports every phase that requires or references a param with
+// no value once launch is applied, so a run can fail before any phase
+// starts instead of sending an agent an unfilled prompt.
+func CheckParams(phases []PhaseSpec, launch map[string]string) error {
+	var errs []error
+	for i := range phases {
+		p := &phases[i]
+		if missing := MissingParams(p, ResolveParams(p, launch)); len(missing) > 0 {
+			errs = append(errs, fmt.Errorf("%w: phase %q needs %s (pass --param %s=...)",
+				ErrMissingParam, p.ID, strings.Join(missing, ", "), missing[0]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// paramErrors reports malformed names in a phase's params and
+// requires_params.
+func paramErrors(p PhaseSpec) []ValidationError {
+	var errs []ValidationError
+	report := func(field, name string) {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidParam,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      field,
+			Err:        fmt.Errorf("%w: %q (use letters, digits, _ and -)", ErrInvalidParam, name),
+		})
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.Params)) {
+		if !paramName.MatchString(name) {
+			report("params", name)
+		}
+	}
+	for _, name := range p.RequiresParams {
+		if !paramName.MatchString(name) {
+			report("requires_params", name)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,147 @@
+package nebula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		flags   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"none", nil, map[string]string{}, false},
+		{"pairs", []string{"version=1.2.0", "target=prod"}, map[string]string{"version": "1.2.0", "target": "prod"}, false},
+		{"value with equals and commas", []string{"flags=a=1,b=2"}, map[string]string{"flags": "a=1,b=2"}, false},
+		{"later wins", []string{"v=1", "v=2"}, map[string]string{"v": "2"}, false},
+		{"empty value", []string{"note="}, map[string]string{"note": ""}, false},
+		{"no equals", []string{"version"}, nil, true},
+		{"bad name", []string{"has space=1"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseParams(tt.flags)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidParam) {
+					t.Fatalf("err = %v, want ErrInvalidParam", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveAndSubstituteParams(t *testing.T) {
+	t.Parallel()
+
+	p := &PhaseSpec{
+		ID:     "release",
+		Params: map[string]string{"target": "staging", "channel": "beta"},
+		Body:   "Tag ${params.version} and deploy to ${params.target}.\n```sh\necho ${params.version}\n```\nKeep ${params.unset}.",
+	}
+	resolved := ResolveParams(p, map[string]string{"version": "1.2.0", "target": "prod", "other": "x"})
+	if len(resolved) != 3 || resolved["version"] != "1.2.0" || resolved["target"] != "prod" || resolved["channel"] != "beta" {
+		t.Errorf("resolved = %v, want version, the overridden target, and channel only", resolved)
+	}
+
+	want := "Tag 1.2.0 and deploy to prod.\n```sh\necho ${params.version}\n```\nKeep ${params.unset}."
+	if got := buildPhasePrompt(p, nil, resolved); got != want {
+		t.Errorf("prompt = %q, want %q", got, want)
+	}
+}
+
+func TestCheckParams(t *testing.T) {
+	t.Parallel()
+
+	phases := []PhaseSpec{
+		{ID: "a", Body: "Release ${params.version}."},
+		{ID: "b", RequiresParams: []string{"target"}, Params: map[string]string{"target": "staging"}},
+		{ID: "c", RequiresParams: []string{"token"}},
+		{ID: "d", Body: "No params here."},
+	}
+	err := CheckParams(phases, nil)
+	if !errors.Is(err, ErrMissingParam) {
+		t.Fatalf("err = %v, want ErrMissingParam", err)
+	}
+	for _, want := range []string{`phase "a" needs version`, `phase "c" needs token`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), `"b"`) {
+		t.Errorf("phase b has a default for its required param: %v", err)
+	}
+
+	if err := CheckParams(phases, map[string]string{"version": "1.0", "token": "t"}); err != nil {
+		t.Errorf("all params supplied: %v", err)
+	}
+	if err := CheckParams(phases, map[string]string{"version": "", "token": "t"}); err != nil {
+		t.Errorf("an explicit empty value should satisfy a required param: %v", err)
+	}
+}
+
+func TestParamErrors(t *testing.T) {
+	t.Parallel()
+
+	ok := PhaseSpec{ID: "a", Params: map[string]string{"version": "1", "build-id": "2"}, RequiresParams: []string{"target_env"}}
+	if errs := paramErrors(ok); len(errs) != 0 {
+		t.Errorf("valid params: %v", errs)
+	}
+	bad := PhaseSpec{ID: "a", Params: map[string]string{"1st": "x"}, RequiresParams: []string{"has.dot"}}
+	errs := paramErrors(bad)
+	if len(errs) != 2 || errs[0].Field != "params" || errs[1].Field != "requires_params" {
+		t.Fatalf("errs = %v, want one params and one requires_params error", errs)
+	}
+	if !errors.Is(&errs[0], ErrInvalidParam) || errs[0].Category != ValCatInvalidParam {
+		t.Errorf("unexpected error: %+v", errs[0])
+	}
+}
+
+func TestLoadPhaseParams(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"nebula.toml": "[nebula]\nname = \"params-test\"\n",
+		"a.md":        "+++\nid = \"a\"\ntitle = \"A\"\nrequires_params = [\"version\"]\n\n[params]\ntarget = \"staging\"\n+++\nShip ${params.version} to ${params.target}.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if errs := Validate(n); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+	p := &n.Phases[0]
+	if p.Params["target"] != "staging" || len(p.RequiresParams) != 1 {
+		t.Fatalf("phase = %+v, want params and requires_params parsed", p)
+	}
+	if !strings.Contains(p.Body, "${params.version}") {
+		t.Errorf("body = %q, want param references left for run time", p.Body)
+	}
+}
@@ -12,6 +12,22 @@ import (
 
 // Load reads a nebula directory, parsing nebula.toml and all *.md phase files.
 func Load(dir string) (*Nebula, error) {
+	return load(dir, nil)
+}
+
+// load parses the nebula in dir and expands its imports. stack holds the
+// absolute directories of the importing nebulas, for cycle detection.
+func load(dir string, stack []string) (*Nebula, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving nebula directory: %w", err)
+	}
+	for _, d := range stack {
+		if d == abs {
+			return nil, fmt.Errorf("%w: %s", ErrImportCycle, strings.Join(append(stack, abs), " → "))
+		}
+	}
+
 	manifestPath := filepath.Join(dir, "nebula.toml")
 	data, err := os.ReadFile(manifestPath)
 	if err != nil {
@@ -21,10 +37,19 @@ func Load(dir string) (*Nebula, error) {
 		return nil, fmt.Errorf("reading nebula.toml: %w", err)
 	}
 
+	data, warnings, err := migrateManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing nebula.toml: %w", err)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s\n", manifestPath, w)
+	}
+
 	var manifest Manifest
 	if err := toml.Unmarshal(data, &manifest); err != nil {
 		return nil, fmt.Errorf("parsing nebula.toml: %w", err)
 	}
+	manifest.Version = CurrentManifestVersion
 
 	// Validate hail_timeout if present.
 	if ht := manifest.Execution.HailTimeout; ht != "" && ht != "0" {
@@ -33,6 +58,10 @@ func Load(dir string) (*Nebula, error) {
 		}
 	}
 
+	if err := loadIncludeFiles(dir, &manifest.Context); err != nil {
+		return nil, err
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("reading nebula directory: %w", err)
@@ -52,6 +81,11 @@ func Load(dir string) (*Nebula, error) {
 		phases = append(phases, phase)
 	}
 
+	phases, err = expandImports(dir, phases, append(stack, abs))
+	if err != nil {
+		return nil, err
+	}
+
 	return &Nebula{
 		Dir:      dir,
 		Manifest: manifest,
@@ -77,7 +111,11 @@ func parsePhaseFile(path string, defaults Defaults) (PhaseSpec, error) {
 		return PhaseSpec{}, fmt.Errorf("parsing TOML frontmatter: %w", err)
 	}
 
-	phase.Body = strings.TrimSpace(body)
+	env, err := loadNebulaEnv(filepath.Dir(path))
+	if err != nil {
+		return PhaseSpec{}, err
+	}
+	phase.Body, phase.undefinedVars = substituteEnv(strings.TrimSpace(body), env)
 
 	// Apply defaults for zero-valued fields.
 	if phase.Type == "" {
@@ -145,6 +183,7 @@ type phaseSpecFrontmatter struct {
 	Blocks            []string `toml:"blocks,omitempty"`
 	Scope             []string `toml:"scope,omitempty"`
 	AllowScopeOverlap bool     `toml:"allow_scope_overlap,omitempty"`
+	Import            string   `toml:"import,omitempty"`
 }
 
 // MarshalPhaseFile serializes a PhaseSpec into the +++TOML+++ frontmatter
@@ -166,6 +205,7 @@ func MarshalPhaseFile(spec PhaseSpec) ([]byte, error) {
 		Blocks:            spec.Blocks,
 		Scope:             spec.Scope,
 		AllowScopeOverlap: spec.AllowScopeOverlap,
+		Import:            spec.Import,
 	}
 	tomlBytes, err := toml.Marshal(fm)
 	if err != nil {
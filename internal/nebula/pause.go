@@ -0,0 +1,61 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultPausePoll is how often a paused run checks whether the PAUSE file
+// is gone, in case the watcher missed its removal.
+const DefaultPausePoll = 2 * time.Second
+
+// pausePoll returns the effective PAUSE file check interval.
+func (wg *WorkerGroup) pausePoll() time.Duration {
+	if wg.PausePoll > 0 {
+		return wg.PausePoll
+	}
+	return DefaultPausePoll
+}
+
+// handlePause blocks until the PAUSE file is removed from the nebula
+// directory. A resume intervention from the watcher ends the pause at once;
+// the file is also checked on every pausePoll tick so a removal the watcher
+// missed still resumes the run.
+func (wg *WorkerGroup) handlePause() {
+	pausePath := filepath.Join(wg.Nebula.Dir, "PAUSE")
+	fmt.Fprintf(wg.logger(), "\n── Nebula paused ──────────────────────────────────\n")
+	fmt.Fprintf(wg.logger(), "   Remove the PAUSE file to continue:\n")
+	fmt.Fprintf(wg.logger(), "   rm %s\n", pausePath)
+	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+
+	if _, err := os.Stat(pausePath); os.IsNotExist(err) {
+		return
+	}
+
+	ticker := time.NewTicker(wg.pausePoll())
+	defer ticker.Stop()
+	for {
+		select {
+		case kind, ok := <-wg.Watcher.Interventions:
+			if !ok {
+				return
+			}
+			wg.audit(AuditRecord{Event: AuditIntervention, Action: string(kind)})
+			if kind == InterventionResume {
+				return
+			}
+			if kind == InterventionStop || kind == InterventionDrain {
+				wg.Watcher.SendIntervention(kind)
+				return
+			}
+		case <-ticker.C:
+			if _, err := os.Stat(pausePath); os.IsNotExist(err) {
+				fmt.Fprintf(wg.logger(), "PAUSE file is gone; resuming\n")
+				wg.audit(AuditRecord{Event: AuditIntervention, Action: string(InterventionResume)})
+				return
+			}
+		}
+	}
+}
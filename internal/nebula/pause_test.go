@@ -0,0 +1,55 @@
+package nebula
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkerGroup_PauseResumesWhenFileRemovedWithoutEvent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{Dir: dir, Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: []PhaseSpec{{ID: "a", Body: "phase a"}}}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+	pauseFile := filepath.Join(dir, "PAUSE")
+	if err := os.WriteFile(pauseFile, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := newTestWatcher(dir)
+	w.interventions <- InterventionPause
+	runner := &mockRunner{result: &PhaseRunnerResult{}}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithWatcher(w), WithPausePoll(10*time.Millisecond), WithLogger(io.Discard))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := wg.Run(context.Background())
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if calls := runner.getCalls(); len(calls) != 0 {
+		t.Fatalf("ran %v while paused", calls)
+	}
+
+	// Remove the file without a resume intervention, as if the watcher
+	// missed the removal.
+	if err := os.Remove(pauseFile); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the run to resume")
+	}
+	if calls := runner.getCalls(); len(calls) != 1 {
+		t.Errorf("runner calls = %v, want phase a run once", calls)
+	}
+}
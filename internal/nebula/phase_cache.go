@@ -0,0 +1,187 @@
+package nebula
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	toml "github.com/pelletier/go-toml/v2"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// phaseCacheFileName is the per-nebula cache of successful phase runs.
+const phaseCacheFileName = "phase-cache.toml"
+
+// DoneReasonCached marks a phase completed from the phase cache instead of
+// being run.
+const DoneReasonCached = "cached"
+
+// phaseCache is the TOML-serializable phase cache, keyed by phase ID.
+type phaseCache struct {
+	Entries map[string]phaseCacheEntry `toml:"entries"`
+}
+
+// phaseCacheEntry records the last successful run of one phase.
+type phaseCacheEntry struct {
+	Key         string              `toml:"key"`    // hash of the run's inputs
+	Output      string              `toml:"output"` // what dependents hash in: the final commit, or Key without one
+	BaseCommit  string              `toml:"base_commit,omitempty"`
+	FinalCommit string              `toml:"final_commit,omitempty"`
+	Report      *agent.ReviewReport `toml:"report,omitempty"`
+}
+
+// key hashes everything that determines what a phase produces: its prompt,
+// its resolved execution settings, and the recorded output of each
+// dependency, so a dependency that produced something new invalidates the
+// phases after it. Budgets are left out since raising one does not change
+// what the phase should produce. ok is false when a dependency has no
+// recorded output, as a change to it would then go unnoticed.
+func (c phaseCache) key(phase *PhaseSpec, prompt string, exec ResolvedExecution) (key string, ok bool) {
+	exec.MaxBudgetUSD, exec.RetryBudgetUSD = 0, 0
+	settings, err := json.Marshal(exec)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", prompt, settings)
+	for _, dep := range slices.Sorted(slices.Values(phase.DependsOn)) {
+		e, ok := c.Entries[dep]
+		if !ok {
+			return "", false
+		}
+		fmt.Fprintf(h, "%s=%s\x00", dep, e.Output)
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// result rebuilds the runner result of the cached run. It carries no cost
+// or cycles since nothing ran.
+func (e phaseCacheEntry) result() *PhaseRunnerResult {
+	return &PhaseRunnerResult{BaseCommitSHA: e.BaseCommit, FinalCommitSHA: e.FinalCommit, Report: e.Report}
+}
+
+// cachedPhase returns the phase's cache key and, when a prior successful
+// run had the same key and its final commit is still in the history, its
+// cache entry. Otherwise the phase's entry is dropped, so dependents cannot
+// match against an output this run is about to replace. The key is "" when
+// caching is off or the phase cannot be cached.
+func (wg *WorkerGroup) cachedPhase(ctx context.Context, phase *PhaseSpec, prompt string, exec ResolvedExecution) (string, *phaseCacheEntry) {
+	key, e := wg.lookupCachedPhase(phase, prompt, exec)
+	if e == nil || e.FinalCommit == "" {
+		return key, e
+	}
+	// A reset or rebase since the cached run may have dropped its commit;
+	// reusing it would hand dependents code that is not in the tree.
+	if wg.Committer != nil && !wg.Committer.HasCommit(ctx, e.FinalCommit) {
+		fmt.Fprintf(wg.logger(), "Phase %q's cached commit %s is no longer in the history; running it again\n", phase.ID, e.FinalCommit)
+		wg.mu.Lock()
+		delete(wg.cache.Entries, phase.ID)
+		wg.savePhaseCache()
+		wg.mu.Unlock()
+		return key, nil
+	}
+	return key, e
+}
+
+// lookupCachedPhase is cachedPhase without the history check.
+func (wg *WorkerGroup) lookupCachedPhase(phase *PhaseSpec, prompt string, exec ResolvedExecution) (string, *phaseCacheEntry) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.cache == nil {
+		return "", nil
+	}
+	key, ok := wg.cache.key(phase, prompt, exec)
+	e, cached := wg.cache.Entries[phase.ID]
+	if ok && cached && e.Key == key {
+		return key, &e
+	}
+	if cached {
+		delete(wg.cache.Entries, phase.ID)
+		wg.savePhaseCache()
+	}
+	return key, nil
+}
+
+// recordCacheHit completes a phase from its cache entry without running it,
+// skipping the phase commit and gate since the work was already accepted.
+func (wg *WorkerGroup) recordCacheHit(ctx context.Context, phase *PhaseSpec, ps *PhaseState, e *phaseCacheEntry, done, failed, inFlight map[string]bool) {
+	fmt.Fprintf(wg.logger(), "Phase %q is unchanged since its last successful run; reusing it\n", phase.ID)
+	result := e.result()
+	wg.mu.Lock()
+	ps.DoneReason = DoneReasonCached
+	wg.mu.Unlock()
+	wg.progress.RecordPhaseComplete(phase.ID, *result)
+	wg.recordResult(phase.ID, ps, result, nil, done, failed, inFlight, wg.collectArtifacts(phase))
+	wg.fabricPhaseComplete(ctx, phase.ID, result)
+}
+
+// storeCachedPhase records a successful run of a phase under key. It is a
+// no-op when key is "".
+func (wg *WorkerGroup) storeCachedPhase(phaseID, key string, result *PhaseRunnerResult) {
+	if key == "" || result == nil {
+		return
+	}
+	e := phaseCacheEntry{Key: key, Output: result.FinalCommitSHA, BaseCommit: result.BaseCommitSHA, FinalCommit: result.FinalCommitSHA, Report: result.Report}
+	if e.Output == "" {
+		e.Output = key
+	}
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.cache == nil {
+		return
+	}
+	wg.cache.Entries[phaseID] = e
+	wg.savePhaseCache()
+}
+
+// savePhaseCache persists the cache, logging rather than failing the run
+// when it cannot. Must be called with wg.mu held.
+func (wg *WorkerGroup) savePhaseCache() {
+	if err := savePhaseCache(wg.Nebula.Dir, *wg.cache); err != nil {
+		fmt.Fprintf(wg.logger(), "warning: %v\n", err)
+	}
+}
+
+// loadPhaseCache reads the cache file, returning an empty cache when it
+// does not exist.
+func loadPhaseCache(dir string) (phaseCache, error) {
+	cache := phaseCache{Entries: map[string]phaseCacheEntry{}}
+	data, err := os.ReadFile(filepath.Join(dir, phaseCacheFileName))
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("reading phase cache: %w", err)
+	}
+	if err := toml.Unmarshal(data, &cache); err != nil {
+		return cache, fmt.Errorf("parsing phase cache: %w", err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]phaseCacheEntry{}
+	}
+	return cache, nil
+}
+
+// savePhaseCache writes the cache file atomically.
+func savePhaseCache(dir string, cache phaseCache) error {
+	data, err := toml.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("marshaling phase cache: %w", err)
+	}
+	path := filepath.Join(dir, phaseCacheFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing phase cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("renaming phase cache: %w", err)
+	}
+	return nil
+}
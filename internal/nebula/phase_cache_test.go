@@ -0,0 +1,160 @@
+package nebula
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+)
+
+// commitRunner succeeds every phase, reporting a final commit SHA taken
+// from commits (default "sha-<id>"), and records which phases ran.
+type commitRunner struct {
+	mu      sync.Mutex
+	commits map[string]string
+	ran     []string
+}
+
+func (r *commitRunner) RunExistingPhase(_ context.Context, phaseID, _, _, _ string, _ ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ran = append(r.ran, phaseID)
+	sha := r.commits[phaseID]
+	if sha == "" {
+		sha = "sha-" + phaseID
+	}
+	return &PhaseRunnerResult{TotalCostUSD: 1, FinalCommitSHA: sha}, nil
+}
+
+func (r *commitRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestWorkerGroupPhaseCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{
+		Dir:      dir,
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases: []PhaseSpec{
+			{ID: "a", Title: "A", Body: "build a"},
+			{ID: "b", Title: "B", Body: "build b", DependsOn: []string{"a"}},
+		},
+	}
+	// run re-runs both phases from scratch and returns the phases that the
+	// runner was invoked for, along with the final state.
+	run := func(commits map[string]string) ([]string, *State) {
+		t.Helper()
+		state := &State{Version: 1, Phases: map[string]*PhaseState{
+			"a": {BeadID: "bead-a", Status: PhaseStatusCreated},
+			"b": {BeadID: "bead-b", Status: PhaseStatusCreated},
+		}}
+		runner := &commitRunner{commits: commits}
+		wg := NewWorkerGroup(n, state, WithRunner(runner), WithPhaseCache(true))
+		if _, err := wg.Run(context.Background()); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return runner.ran, state
+	}
+
+	if ran, _ := run(nil); len(ran) != 2 {
+		t.Fatalf("first run ran %v, want both phases", ran)
+	}
+
+	ran, state := run(nil)
+	if len(ran) != 0 {
+		t.Errorf("unchanged re-run ran %v, want nothing", ran)
+	}
+	for id, ps := range state.Phases {
+		if ps.Status != PhaseStatusDone || ps.DoneReason != DoneReasonCached {
+			t.Errorf("phase %s = %s/%q, want done/cached", id, ps.Status, ps.DoneReason)
+		}
+	}
+	if state.TotalCostUSD != 0 {
+		t.Errorf("cached run cost $%.2f, want $0", state.TotalCostUSD)
+	}
+
+	// Editing a re-runs it; b still hits because a produced the same commit.
+	n.Phases[0].Body = "build a, with docs"
+	if ran, _ := run(nil); len(ran) != 1 || ran[0] != "a" {
+		t.Errorf("after editing a, ran %v, want [a]", ran)
+	}
+
+	// A new output from a invalidates b.
+	n.Phases[0].Body = "build a, differently"
+	if ran, _ := run(map[string]string{"a": "sha-a2"}); len(ran) != 2 {
+		t.Errorf("after a produced a new commit, ran %v, want [a b]", ran)
+	}
+	_, state = run(map[string]string{"a": "sha-a2"})
+	if state.Phases["b"].DoneReason != DoneReasonCached {
+		t.Error("b was not cached against a's new output")
+	}
+}
+
+func TestWorkerGroupPhaseCacheMissesDroppedCommit(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases:   []PhaseSpec{{ID: "a", Title: "A", Body: "build a"}},
+	}
+	run := func(dropped map[string]bool) []string {
+		t.Helper()
+		state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+		runner := &commitRunner{}
+		committer := &mockGitCommitter{dropped: dropped}
+		wg := NewWorkerGroup(n, state, WithRunner(runner), WithCommitter(committer), WithPhaseCache(true), WithLogger(io.Discard))
+		if _, err := wg.Run(context.Background()); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return runner.ran
+	}
+
+	run(nil)
+	if ran := run(nil); len(ran) != 0 {
+		t.Errorf("re-run with the commit in history ran %v, want nothing", ran)
+	}
+	// After a reset that drops sha-a, the cached run cannot be reused.
+	if ran := run(map[string]bool{"sha-a": true}); len(ran) != 1 {
+		t.Errorf("re-run after the commit was dropped ran %v, want [a]", ran)
+	}
+}
+
+func TestPhaseCacheKey(t *testing.T) {
+	t.Parallel()
+
+	cache := phaseCache{Entries: map[string]phaseCacheEntry{"dep": {Output: "sha-1"}}}
+	phase := &PhaseSpec{ID: "p", DependsOn: []string{"dep"}}
+	exec := ResolvedExecution{MaxReviewCycles: 3, MaxBudgetUSD: 5, Model: "m"}
+
+	base, ok := cache.key(phase, "prompt", exec)
+	if !ok {
+		t.Fatal("key not computed")
+	}
+
+	bumped := exec
+	bumped.MaxBudgetUSD = 8
+	if k, _ := cache.key(phase, "prompt", bumped); k != base {
+		t.Error("budget change altered the key")
+	}
+	other := exec
+	other.Model = "n"
+	if k, _ := cache.key(phase, "prompt", other); k == base {
+		t.Error("model change kept the key")
+	}
+	if k, _ := cache.key(phase, "prompt 2", exec); k == base {
+		t.Error("prompt change kept the key")
+	}
+
+	cache.Entries["dep"] = phaseCacheEntry{Output: "sha-2"}
+	if k, _ := cache.key(phase, "prompt", exec); k == base {
+		t.Error("dependency output change kept the key")
+	}
+
+	delete(cache.Entries, "dep")
+	if _, ok := cache.key(phase, "prompt", exec); ok {
+		t.Error("key computed without the dependency's output")
+	}
+}
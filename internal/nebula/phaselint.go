@@ -0,0 +1,232 @@
+package nebula
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// DefaultPhaseLintModel is the cheap model used to score phase bodies.
+const DefaultPhaseLintModel = "claude-haiku"
+
+// DefaultPhaseLintBudgetUSD bounds the total spend of one LintPhases run.
+const DefaultPhaseLintBudgetUSD = 0.50
+
+// phaseLintCacheFileName is the per-nebula cache of scores keyed by body hash.
+const phaseLintCacheFileName = "phase-lint-cache.toml"
+
+// phaseLintRiskScore is the highest score still flagged as likely to cause
+// extra review cycles.
+const phaseLintRiskScore = 2
+
+// phaseLintMaxScore is the top of the rubric scale.
+const phaseLintMaxScore = 5
+
+const phaseLintSystemPrompt = `You grade task descriptions for an autonomous coding agent.
+Do not use any tools. Judge only the text you are given.
+
+Score how clear and actionable the task is on a 1-5 scale:
+5 — precise: concrete files or components, explicit acceptance criteria, no open questions.
+4 — clear: minor gaps the agent can resolve from the codebase.
+3 — workable: some ambiguity about scope or expected behavior.
+2 — vague: key decisions are left open; a reviewer will likely bounce the result.
+1 — unactionable: the goal itself is unclear.
+
+Respond with exactly one SCORE line, then up to three SUGGESTION lines naming the
+most valuable edits to the description:
+
+SCORE: <1-5>
+SUGGESTION: <one concrete improvement>`
+
+// PhaseLintOptions configures LintPhases.
+type PhaseLintOptions struct {
+	Model        string  // "" uses DefaultPhaseLintModel
+	MaxBudgetUSD float64 // <= 0 uses DefaultPhaseLintBudgetUSD
+}
+
+// PhaseLintResult is the rubric score for a single phase body.
+type PhaseLintResult struct {
+	PhaseID     string
+	Score       int // 1 (unactionable) to 5 (precise); 0 when unscored
+	Suggestions []string
+	Cached      bool // served from the body-hash cache
+	Skipped     bool // not scored because the budget ran out
+}
+
+// Risky reports whether the phase is likely to cause extra review cycles.
+func (r PhaseLintResult) Risky() bool {
+	return r.Score > 0 && r.Score <= phaseLintRiskScore
+}
+
+// PhaseLintReport collects the results of a LintPhases run.
+type PhaseLintReport struct {
+	Results []PhaseLintResult
+	CostUSD float64 // spend on fresh (uncached) scores
+}
+
+// phaseLintCache is the TOML-serializable cache, keyed by body hash.
+type phaseLintCache struct {
+	Entries map[string]phaseLintEntry `toml:"entries"`
+}
+
+// phaseLintEntry is one cached score.
+type phaseLintEntry struct {
+	Score       int      `toml:"score"`
+	Suggestions []string `toml:"suggestions,omitempty"`
+}
+
+// LintPhases scores each phase body for clarity and actionability with a
+// cheap model. Scores are cached in the nebula directory by a hash of the
+// phase title and body, so unchanged phases are free on re-runs. The total
+// spend on fresh scores is bounded by opts.MaxBudgetUSD; phases left when it
+// runs out are reported as Skipped.
+func LintPhases(ctx context.Context, invoker agent.Invoker, n *Nebula, opts PhaseLintOptions) (*PhaseLintReport, error) {
+	model := opts.Model
+	if model == "" {
+		model = DefaultPhaseLintModel
+	}
+	budget := opts.MaxBudgetUSD
+	if budget <= 0 {
+		budget = DefaultPhaseLintBudgetUSD
+	}
+
+	cache, err := loadPhaseLintCache(n.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(n.Phases))
+	uncached := 0
+	for i, p := range n.Phases {
+		hashes[i] = phaseLintHash(model, p)
+		if _, ok := cache.Entries[hashes[i]]; !ok {
+			uncached++
+		}
+	}
+
+	report := &PhaseLintReport{Results: make([]PhaseLintResult, 0, len(n.Phases))}
+	fresh := make(map[string]phaseLintEntry, len(n.Phases))
+	for i, p := range n.Phases {
+		if e, ok := cache.Entries[hashes[i]]; ok {
+			fresh[hashes[i]] = e
+			report.Results = append(report.Results, PhaseLintResult{
+				PhaseID: p.ID, Score: e.Score, Suggestions: e.Suggestions, Cached: true,
+			})
+			continue
+		}
+
+		remaining := budget - report.CostUSD
+		if remaining <= 0 {
+			report.Results = append(report.Results, PhaseLintResult{PhaseID: p.ID, Skipped: true})
+			continue
+		}
+
+		a := agent.Agent{
+			Role:         agent.RoleReviewer,
+			SystemPrompt: phaseLintSystemPrompt,
+			Model:        model,
+			MaxBudgetUSD: remaining / float64(uncached),
+		}
+		uncached--
+		res, err := invoker.Invoke(ctx, a, phaseLintPrompt(p), n.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("linting phase %q: %w", p.ID, err)
+		}
+		report.CostUSD += res.CostUSD
+
+		score, suggestions := parsePhaseLintOutput(res.ResultText)
+		report.Results = append(report.Results, PhaseLintResult{
+			PhaseID: p.ID, Score: score, Suggestions: suggestions,
+		})
+		if score > 0 {
+			fresh[hashes[i]] = phaseLintEntry{Score: score, Suggestions: suggestions}
+		}
+	}
+
+	// Rewrite the cache with only the current phases' entries so edited
+	// bodies don't accumulate stale scores.
+	if err := savePhaseLintCache(n.Dir, phaseLintCache{Entries: fresh}); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// phaseLintPrompt builds the user prompt for one phase.
+func phaseLintPrompt(p PhaseSpec) string {
+	return fmt.Sprintf("Task title: %s\n\nTask description:\n%s", p.Title, p.Body)
+}
+
+// phaseLintHash keys the cache on the model and everything the model sees.
+func phaseLintHash(model string, p PhaseSpec) string {
+	sum := sha256.Sum256([]byte(model + "\x00" + phaseLintPrompt(p)))
+	return hex.EncodeToString(sum[:])
+}
+
+// parsePhaseLintOutput extracts the SCORE and SUGGESTION lines. A missing or
+// out-of-range score yields 0 (unscored).
+func parsePhaseLintOutput(output string) (int, []string) {
+	score := 0
+	var suggestions []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "SCORE:"):
+			v, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "SCORE:")))
+			if err == nil && v >= 1 && v <= phaseLintMaxScore {
+				score = v
+			}
+		case strings.HasPrefix(line, "SUGGESTION:"):
+			if s := strings.TrimSpace(strings.TrimPrefix(line, "SUGGESTION:")); s != "" {
+				suggestions = append(suggestions, s)
+			}
+		}
+	}
+	return score, suggestions
+}
+
+// loadPhaseLintCache reads the cache file, returning an empty cache when it
+// does not exist.
+func loadPhaseLintCache(dir string) (phaseLintCache, error) {
+	cache := phaseLintCache{Entries: map[string]phaseLintEntry{}}
+	data, err := os.ReadFile(filepath.Join(dir, phaseLintCacheFileName))
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("reading phase lint cache: %w", err)
+	}
+	if err := toml.Unmarshal(data, &cache); err != nil {
+		return cache, fmt.Errorf("parsing phase lint cache: %w", err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]phaseLintEntry{}
+	}
+	return cache, nil
+}
+
+// savePhaseLintCache writes the cache file atomically.
+func savePhaseLintCache(dir string, cache phaseLintCache) error {
+	data, err := toml.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("marshaling phase lint cache: %w", err)
+	}
+	path := filepath.Join(dir, phaseLintCacheFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing phase lint cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("renaming phase lint cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// lintInvoker returns canned responses keyed by phase title and counts calls.
+type lintInvoker struct {
+	responses map[string]string // phase title → result text
+	cost      float64
+	err       error
+	calls     int
+	budgets   []float64
+}
+
+func (m *lintInvoker) Invoke(_ context.Context, a agent.Agent, prompt string, _ string) (agent.InvocationResult, error) {
+	m.calls++
+	m.budgets = append(m.budgets, a.MaxBudgetUSD)
+	if m.err != nil {
+		return agent.InvocationResult{}, m.err
+	}
+	for title, text := range m.responses {
+		if strings.HasPrefix(prompt, "Task title: "+title+"\n") {
+			return agent.InvocationResult{ResultText: text, CostUSD: m.cost}, nil
+		}
+	}
+	return agent.InvocationResult{ResultText: "SCORE: 4", CostUSD: m.cost}, nil
+}
+
+func (m *lintInvoker) Validate() error { return nil }
+
+func lintNebula(dir string) *Nebula {
+	return &Nebula{
+		Dir: dir,
+		Phases: []PhaseSpec{
+			{ID: "clear", Title: "Clear", Body: "Add Foo() to pkg/foo.go returning 42, with a table test."},
+			{ID: "vague", Title: "Vague", Body: "Make it better."},
+		},
+	}
+}
+
+func TestParsePhaseLintOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		output    string
+		wantScore int
+		wantSugg  int
+	}{
+		{"score and suggestions", "SCORE: 2\nSUGGESTION: name the file\nSUGGESTION: add criteria", 2, 2},
+		{"score only", "Some preamble\nSCORE: 5\n", 5, 0},
+		{"out of range", "SCORE: 9", 0, 0},
+		{"not a number", "SCORE: high", 0, 0},
+		{"missing", "looks fine", 0, 0},
+		{"empty suggestion dropped", "SCORE: 3\nSUGGESTION:   ", 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			score, sugg := parsePhaseLintOutput(tt.output)
+			if score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+			if len(sugg) != tt.wantSugg {
+				t.Errorf("suggestions = %v, want %d", sugg, tt.wantSugg)
+			}
+		})
+	}
+}
+
+func TestLintPhases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scores and flags vague phases", func(t *testing.T) {
+		t.Parallel()
+		inv := &lintInvoker{
+			responses: map[string]string{
+				"Clear": "SCORE: 5",
+				"Vague": "SCORE: 1\nSUGGESTION: say what to change",
+			},
+			cost: 0.01,
+		}
+		report, err := LintPhases(context.Background(), inv, lintNebula(t.TempDir()), PhaseLintOptions{})
+		if err != nil {
+			t.Fatalf("LintPhases: %v", err)
+		}
+		if len(report.Results) != 2 {
+			t.Fatalf("got %d results, want 2", len(report.Results))
+		}
+		if r := report.Results[0]; r.Score != 5 || r.Risky() {
+			t.Errorf("clear = %+v, want score 5 not risky", r)
+		}
+		if r := report.Results[1]; r.Score != 1 || !r.Risky() || len(r.Suggestions) != 1 {
+			t.Errorf("vague = %+v, want score 1, risky, one suggestion", r)
+		}
+		if report.CostUSD != 0.02 {
+			t.Errorf("CostUSD = %v, want 0.02", report.CostUSD)
+		}
+	})
+
+	t.Run("re-run is served from cache", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		inv := &lintInvoker{cost: 0.01}
+		if _, err := LintPhases(context.Background(), inv, lintNebula(dir), PhaseLintOptions{}); err != nil {
+			t.Fatal(err)
+		}
+
+		n := lintNebula(dir)
+		n.Phases[1].Body = "Rename Bar to Baz in pkg/bar.go."
+		report, err := LintPhases(context.Background(), inv, n, PhaseLintOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inv.calls != 3 {
+			t.Errorf("invoker calls = %d, want 3 (only the edited phase re-scored)", inv.calls)
+		}
+		if !report.Results[0].Cached || report.Results[1].Cached {
+			t.Errorf("cached flags = %v/%v, want true/false", report.Results[0].Cached, report.Results[1].Cached)
+		}
+		if report.CostUSD != 0.01 {
+			t.Errorf("CostUSD = %v, want 0.01", report.CostUSD)
+		}
+	})
+
+	t.Run("unscored responses are not cached", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		inv := &lintInvoker{responses: map[string]string{"Clear": "no idea", "Vague": "no idea"}}
+		for i := 0; i < 2; i++ {
+			if _, err := LintPhases(context.Background(), inv, lintNebula(dir), PhaseLintOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if inv.calls != 4 {
+			t.Errorf("invoker calls = %d, want 4", inv.calls)
+		}
+	})
+
+	t.Run("budget bounds spend", func(t *testing.T) {
+		t.Parallel()
+		inv := &lintInvoker{cost: 0.10}
+		report, err := LintPhases(context.Background(), inv, lintNebula(t.TempDir()), PhaseLintOptions{MaxBudgetUSD: 0.10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inv.calls != 1 {
+			t.Errorf("invoker calls = %d, want 1", inv.calls)
+		}
+		if inv.budgets[0] != 0.05 {
+			t.Errorf("per-phase budget = %v, want 0.05", inv.budgets[0])
+		}
+		if !report.Results[1].Skipped {
+			t.Errorf("second phase = %+v, want skipped", report.Results[1])
+		}
+	})
+
+	t.Run("invoker error", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		inv := &lintInvoker{err: errors.New("boom")}
+		if _, err := LintPhases(context.Background(), inv, lintNebula(dir), PhaseLintOptions{}); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := os.Stat(filepath.Join(dir, phaseLintCacheFileName)); !os.IsNotExist(err) {
+			t.Error("cache written after failed run")
+		}
+	})
+}
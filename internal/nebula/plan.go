@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/papapumpkin/quasar/internal/ansi"
@@ -67,12 +68,6 @@ func BuildPlan(ctx context.Context, n *Nebula, state *State, client beads.Client
 		NebulaName: n.Manifest.Nebula.Name,
 	}
 
-	// Build set of desired phase IDs.
-	desired := make(map[string]bool)
-	for _, p := range n.Phases {
-		desired[p.ID] = true
-	}
-
 	// Determine action for each phase in the nebula.
 	for _, p := range n.Phases {
 		ps, exists := state.Phases[p.ID]
@@ -147,23 +142,84 @@ func BuildPlan(ctx context.Context, n *Nebula, state *State, client beads.Client
 	}
 
 	// Phases in state that are no longer in the nebula → close.
-	for phaseID, ps := range state.Phases {
-		if desired[phaseID] {
+	for _, phaseID := range removedPhases(n, state) {
+		if state.Phases[phaseID].Status == PhaseStatusDone {
 			continue
 		}
-		if ps.Status == PhaseStatusDone {
+		plan.Actions = append(plan.Actions, Action{
+			PhaseID: phaseID,
+			Type:    ActionClose,
+			Reason:  "removed from spec",
+		})
+	}
+
+	return plan, nil
+}
+
+// removedPhases returns, sorted, the IDs of phases in state that have a
+// bead but are no longer in the nebula.
+func removedPhases(n *Nebula, state *State) []string {
+	desired := make(map[string]bool, len(n.Phases))
+	for _, p := range n.Phases {
+		desired[p.ID] = true
+	}
+	var removed []string
+	for phaseID, ps := range state.Phases {
+		if !desired[phaseID] && ps.BeadID != "" {
+			removed = append(removed, phaseID)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// RenameCandidates returns what a rename could connect: phases removed from
+// the nebula that still have a bead, and phases the plan creates from
+// scratch. A phase that was only renamed shows up as one of each, and
+// Rename turns the pair into a single ActionRename so its bead and status
+// carry over instead of the bead being closed and a new one created.
+func (p *Plan) RenameCandidates(n *Nebula, state *State) (removed, added []string) {
+	removed = removedPhases(n, state)
+	for _, a := range p.Actions {
+		if a.Type != ActionCreate {
 			continue
 		}
-		if ps.BeadID != "" {
-			plan.Actions = append(plan.Actions, Action{
-				PhaseID: phaseID,
-				Type:    ActionClose,
-				Reason:  "phase removed from nebula",
-			})
+		if _, inState := state.Phases[a.PhaseID]; !inState {
+			added = append(added, a.PhaseID)
 		}
 	}
+	return removed, added
+}
 
-	return plan, nil
+// Rename replaces the close of oldID and the creation of newID with an
+// ActionRename that moves oldID's state, bead included, to newID. Both IDs
+// must be among RenameCandidates.
+func (p *Plan) Rename(n *Nebula, state *State, oldID, newID string) error {
+	removed, added := p.RenameCandidates(n, state)
+	if !slices.Contains(removed, oldID) {
+		return fmt.Errorf("%w: %s is not a removed phase with a bead", ErrUnknownPhase, oldID)
+	}
+	if !slices.Contains(added, newID) {
+		return fmt.Errorf("%w: %s is not a new phase", ErrUnknownPhase, newID)
+	}
+
+	actions := p.Actions[:0]
+	for _, a := range p.Actions {
+		switch {
+		case a.Type == ActionClose && a.PhaseID == oldID:
+			continue
+		case a.Type == ActionCreate && a.PhaseID == newID:
+			a = Action{
+				PhaseID: newID,
+				Type:    ActionRename,
+				Reason:  fmt.Sprintf("renamed from %s (keeps bead %s)", oldID, state.Phases[oldID].BeadID),
+				From:    oldID,
+			}
+		}
+		actions = append(actions, a)
+	}
+	p.Actions = actions
+	return nil
 }
 
 // HasChanges returns true if the plan contains any non-skip actions.
@@ -28,6 +28,7 @@ type ExecutionPlan struct {
 	ImpactOrder []string               `json:"impact_order"`
 	Risks       []PlanRisk             `json:"risks"`
 	Stats       PlanStats              `json:"stats"`
+	Phases      []PlanPhase            `json:"phases,omitempty"`
 }
 
 // PlanRisk describes a potential issue detected during plan analysis.
@@ -104,6 +105,7 @@ func (pe *PlanEngine) Plan(n *Nebula) (*ExecutionPlan, error) {
 		ImpactOrder: impactOrder,
 		Risks:       risks,
 		Stats:       stats,
+		Phases:      planPhases(n.Phases),
 	}, nil
 }
 
@@ -222,6 +224,9 @@ func Diff(old, new *ExecutionPlan) []PlanChange {
 		}
 	}
 
+	// Detect phases whose dependencies or body changed.
+	changes = append(changes, diffPhases(old.Phases, new.Phases)...)
+
 	// Sort changes for stable output.
 	sort.Slice(changes, func(i, j int) bool {
 		if changes[i].Kind != changes[j].Kind {
@@ -290,7 +295,7 @@ func aggregateRisks(n *Nebula, report *fabric.ContractReport, tracks []dag.Track
 			if a.AllowScopeOverlap || b.AllowScopeOverlap {
 				continue
 			}
-			if patA, patB, overlaps := scopesOverlap(a.Scope, b.Scope); overlaps {
+			if patA, patB, overlaps := scopesOverlap(a.rootedScope(), b.rootedScope()); overlaps {
 				risks = append(risks, PlanRisk{
 					Severity: "error",
 					PhaseID:  a.ID,
@@ -457,6 +457,38 @@ func TestDiff(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "phase modified",
+			old: &ExecutionPlan{
+				Waves: []dag.Wave{{Number: 1, NodeIDs: []string{"a", "b", "c"}}},
+				Phases: planPhases([]PhaseSpec{
+					{ID: "a", Body: "build it"},
+					{ID: "b", Body: "test it", DependsOn: []string{"a"}},
+					{ID: "c", Body: "ship it", DependsOn: []string{"a", "b"}},
+				}),
+			},
+			new: &ExecutionPlan{
+				Waves: []dag.Wave{{Number: 1, NodeIDs: []string{"a", "b", "c"}}},
+				Phases: planPhases([]PhaseSpec{
+					{ID: "a", Body: "build it properly"},
+					{ID: "b", Body: "test it", DependsOn: []string{"c"}},
+					{ID: "c", Body: "ship it", DependsOn: []string{"b", "a"}},
+				}),
+			},
+			wantCount: 2,
+			checkDiffs: func(t *testing.T, changes []PlanChange) {
+				t.Helper()
+				want := map[string]string{
+					"a": "phase modified: body edited",
+					"b": "phase modified: dependencies +c -a",
+				}
+				for _, c := range changes {
+					if c.Kind != "changed" || want[c.Subject] != c.Detail {
+						t.Errorf("unexpected change %+v", c)
+					}
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,85 @@
+package nebula
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PlanPhase records what Diff compares for each phase between two plans:
+// its dependencies and a hash of its body.
+type PlanPhase struct {
+	ID        string   `json:"id"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	BodyHash  string   `json:"body_hash"`
+}
+
+// planPhases snapshots phases for a plan, with dependencies sorted so the
+// order they are listed in does not register as a change.
+func planPhases(phases []PhaseSpec) []PlanPhase {
+	out := make([]PlanPhase, len(phases))
+	for i, p := range phases {
+		deps := slices.Clone(p.DependsOn)
+		slices.Sort(deps)
+		sum := sha256.Sum256([]byte(p.Body))
+		out[i] = PlanPhase{ID: p.ID, DependsOn: deps, BodyHash: hex.EncodeToString(sum[:])}
+	}
+	return out
+}
+
+// diffPhases reports phases present in both plans whose dependencies or
+// body changed. Plans saved before phases were recorded have none, so they
+// report no modifications.
+func diffPhases(old, new []PlanPhase) []PlanChange {
+	prev := make(map[string]PlanPhase, len(old))
+	for _, p := range old {
+		prev[p.ID] = p
+	}
+	var changes []PlanChange
+	for _, p := range new {
+		o, ok := prev[p.ID]
+		if !ok {
+			continue
+		}
+		var what []string
+		if added, removed := depChanges(o.DependsOn, p.DependsOn); len(added)+len(removed) > 0 {
+			var parts []string
+			for _, d := range added {
+				parts = append(parts, "+"+d)
+			}
+			for _, d := range removed {
+				parts = append(parts, "-"+d)
+			}
+			what = append(what, "dependencies "+strings.Join(parts, " "))
+		}
+		if o.BodyHash != p.BodyHash {
+			what = append(what, "body edited")
+		}
+		if len(what) > 0 {
+			changes = append(changes, PlanChange{
+				Kind:    "changed",
+				Subject: p.ID,
+				Detail:  fmt.Sprintf("phase modified: %s", strings.Join(what, "; ")),
+			})
+		}
+	}
+	return changes
+}
+
+// depChanges returns the dependencies in new but not old, and in old but
+// not new. Both inputs are sorted.
+func depChanges(old, new []string) (added, removed []string) {
+	for _, d := range new {
+		if !slices.Contains(old, d) {
+			added = append(added, d)
+		}
+	}
+	for _, d := range old {
+		if !slices.Contains(new, d) {
+			removed = append(removed, d)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,151 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PostCompletionResult holds the outcomes of the post-completion git workflow
+// (commit remaining changes, push to origin, checkout main).
+type PostCompletionResult struct {
+	// PushBranch is the branch that was pushed (e.g., "nebula/my-nebula").
+	PushBranch string
+	// CommitErr is non-nil if the final commit of remaining changes failed.
+	CommitErr error
+	// PushErr is non-nil if the push failed.
+	PushErr error
+	// CheckoutBranch is the branch that was checked out (e.g., "main").
+	CheckoutBranch string
+	// CheckoutErr is non-nil if the checkout to the default branch failed.
+	CheckoutErr error
+}
+
+// Summary returns a human-readable summary of the git workflow results.
+func (r *PostCompletionResult) Summary() string {
+	var b strings.Builder
+	if r.CommitErr != nil {
+		fmt.Fprintf(&b, "Commit failed: %v", r.CommitErr)
+		b.WriteString("\n")
+	}
+	if r.PushErr != nil {
+		fmt.Fprintf(&b, "Push failed: %v", r.PushErr)
+	} else {
+		fmt.Fprintf(&b, "Pushed to origin/%s", r.PushBranch)
+	}
+	b.WriteString("\n")
+	if r.CheckoutBranch == "" {
+		// Checkout was skipped (incomplete nebula — staying on branch).
+		fmt.Fprintf(&b, "Staying on %s", r.PushBranch)
+	} else if r.CheckoutErr != nil {
+		fmt.Fprintf(&b, "Checkout %s failed: %v", r.CheckoutBranch, r.CheckoutErr)
+	} else {
+		fmt.Fprintf(&b, "Checked out %s", r.CheckoutBranch)
+	}
+	return b.String()
+}
+
+// PostCompletion runs the post-nebula git workflow: commit any remaining
+// changes, push the branch to origin with --set-upstream, and optionally
+// checkout the default branch. When completed is false (nebula failed or
+// is still in-progress), the checkout is skipped so the working tree stays
+// on the nebula branch for easy re-runs. Errors are captured in the result,
+// not returned, so the caller can display them without aborting.
+func PostCompletion(ctx context.Context, dir, branch string, completed bool) *PostCompletionResult {
+	result := &PostCompletionResult{PushBranch: branch}
+
+	// Stage and commit any remaining uncommitted changes.
+	// Non-fatal: we still try to push whatever commits exist.
+	if err := commitRemaining(ctx, dir, branch); err != nil {
+		result.CommitErr = err
+	}
+
+	// Push with --set-upstream to handle branches with no upstream.
+	pushCmd := exec.CommandContext(ctx, "git", "-C", dir, "push", "--set-upstream", "origin", branch)
+	var pushStderr bytes.Buffer
+	pushCmd.Stderr = &pushStderr
+	if err := pushCmd.Run(); err != nil {
+		result.PushErr = fmt.Errorf("%w: %s", err, strings.TrimSpace(pushStderr.String()))
+	}
+
+	// Only checkout the default branch when the nebula completed
+	// successfully. For failed/in-progress nebulas, stay on the nebula
+	// branch so re-runs don't require a branch switch.
+	if completed {
+		defaultBranch := detectDefaultBranch(ctx, dir)
+		result.CheckoutBranch = defaultBranch
+		checkoutCmd := exec.CommandContext(ctx, "git", "-C", dir, "checkout", defaultBranch)
+		var checkoutStderr bytes.Buffer
+		checkoutCmd.Stderr = &checkoutStderr
+		if err := checkoutCmd.Run(); err != nil {
+			result.CheckoutErr = fmt.Errorf("%w: %s", err, strings.TrimSpace(checkoutStderr.String()))
+		}
+	}
+
+	return result
+}
+
+// detectDefaultBranch determines the repository's default branch name.
+// It first tries to read origin's HEAD ref (git symbolic-ref refs/remotes/origin/HEAD),
+// then falls back to checking whether "main" or "master" branches exist locally.
+// If all detection methods fail, it returns "main" as a best-effort default.
+func detectDefaultBranch(ctx context.Context, dir string) string {
+	// Try to resolve origin's default branch via symbolic-ref.
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "symbolic-ref", "refs/remotes/origin/HEAD")
+	if out, err := cmd.Output(); err == nil {
+		ref := strings.TrimSpace(string(out))
+		// ref looks like "refs/remotes/origin/main" — extract the branch name.
+		if parts := strings.SplitN(ref, "refs/remotes/origin/", 2); len(parts) == 2 && parts[1] != "" {
+			return parts[1]
+		}
+	}
+
+	// Fallback: check if "main" or "master" branches exist locally.
+	for _, candidate := range []string{"main", "master"} {
+		check := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--verify", candidate)
+		if check.Run() == nil {
+			return candidate
+		}
+	}
+
+	// Last resort: assume "main".
+	return "main"
+}
+
+// commitRemaining stages and commits any uncommitted changes. If the working
+// tree is clean, this is a no-op. Returns nil on success or clean tree.
+func commitRemaining(ctx context.Context, dir, branch string) error {
+	// Loop to handle pre-commit hooks (e.g. beads export) that may modify
+	// tracked files during the commit, leaving the tree dirty after a
+	// successful commit. Cap iterations to avoid infinite loops.
+	const maxPasses = 3
+	for i := range maxPasses {
+		statusCmd := exec.CommandContext(ctx, "git", "-C", dir, "status", "--porcelain")
+		out, err := statusCmd.Output()
+		if err != nil {
+			return fmt.Errorf("git status: %w", err)
+		}
+		if len(bytes.TrimSpace(out)) == 0 {
+			return nil // clean working tree
+		}
+
+		addCmd := exec.CommandContext(ctx, "git", "-C", dir, "add", "-A")
+		if err := addCmd.Run(); err != nil {
+			return fmt.Errorf("git add: %w", err)
+		}
+
+		var msg string
+		if i == 0 {
+			msg = fmt.Sprintf("nebula: final changes on %s", branch)
+		} else {
+			msg = fmt.Sprintf("nebula: commit hook artifacts on %s", branch)
+		}
+		commitCmd := exec.CommandContext(ctx, "git", "-C", dir, "commit", "-m", msg)
+		if err := commitCmd.Run(); err != nil {
+			return fmt.Errorf("git commit: %w", err)
+		}
+	}
+	return nil
+}
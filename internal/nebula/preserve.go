@@ -0,0 +1,89 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// preserveBranchPrefix names the branches that keep failed phases' work.
+const preserveBranchPrefix = "quasar/failed/"
+
+// PreserveWorkTree records the working tree, uncommitted and untracked
+// changes included, as a commit on top of HEAD and points the branch
+// quasar/failed/<name> at it, replacing any earlier one. The working tree,
+// the index, and the current branch are left untouched, so phases still
+// running in the same checkout are unaffected. It returns the branch name.
+func (g *gitCommitter) PreserveWorkTree(ctx context.Context, name, message string) (string, error) {
+	// Stage into a throwaway index so the real one is not disturbed.
+	tmp, err := os.MkdirTemp("", "quasar-preserve-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	env := append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(tmp, "index"))
+
+	if _, err := g.gitEnv(ctx, env, "read-tree", "HEAD"); err != nil {
+		return "", err
+	}
+	if _, err := g.gitEnv(ctx, env, "add", "-A"); err != nil {
+		return "", err
+	}
+	tree, err := g.gitEnv(ctx, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	sha, err := g.gitEnv(ctx, env, "commit-tree", tree, "-p", "HEAD", "-m", message)
+	if err != nil {
+		return "", err
+	}
+	branch := preserveBranchPrefix + name
+	if err := g.git(ctx, "update-ref", "refs/heads/"+branch, sha); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// gitEnv runs a git subcommand in g.dir with env and returns its trimmed
+// stdout.
+func (g *gitCommitter) gitEnv(ctx context.Context, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.dir}, args...)...)
+	cmd.Env = env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// preserveFailedWork keeps what a failed phase left in the working tree
+// when PreserveFailedWorktrees is set, before a later phase commits over
+// it, and records where in ps.Preserved.
+// Must NOT be called with wg.mu held.
+func (wg *WorkerGroup) preserveFailedWork(ctx context.Context, phaseID string, ps *PhaseState, result *PhaseRunnerResult, runErr error) {
+	if !wg.PreserveFailedWorktrees || wg.Committer == nil || result == nil {
+		return
+	}
+	msg := fmt.Sprintf("%s/%s: work left by failed phase\n\n%v", wg.Nebula.Manifest.Nebula.Name, phaseID, runErr)
+	if result.BaseCommitSHA != "" {
+		msg += "\n\nPhase started at " + result.BaseCommitSHA + "."
+	}
+	where, err := wg.Committer.PreserveWorkTree(ctx, phaseID, msg)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: failed to preserve work of phase %q: %v\n", phaseID, err)
+		return
+	}
+	fmt.Fprintf(wg.logger(), "   Work of failed phase %q kept at %s\n", phaseID, where)
+	wg.mu.Lock()
+	ps.Preserved = where
+	wg.mu.Unlock()
+	if wg.OnPreserved != nil {
+		wg.OnPreserved(phaseID, where)
+	}
+}
@@ -0,0 +1,106 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitCommitter_PreserveWorkTree(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := context.Background()
+	gc := NewGitCommitter(ctx, dir)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("untracked\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	statusBefore, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := headSHA(ctx, t, dir)
+
+	branch, err := gc.PreserveWorkTree(ctx, "auth/login", "neb/auth/login: work left by failed phase")
+	if err != nil {
+		t.Fatalf("PreserveWorkTree: %v", err)
+	}
+	if branch != "quasar/failed/auth/login" {
+		t.Errorf("branch = %q", branch)
+	}
+
+	// The checkout is untouched: same HEAD, same uncommitted changes.
+	if got := headSHA(ctx, t, dir); got != head {
+		t.Errorf("HEAD moved from %s to %s", head, got)
+	}
+	statusAfter, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(statusAfter) != string(statusBefore) {
+		t.Errorf("status changed:\nbefore:\n%s\nafter:\n%s", statusBefore, statusAfter)
+	}
+
+	// The branch holds both the edit and the untracked file, on top of HEAD.
+	for file, want := range map[string]string{"README.md": "# edited\n", "new.txt": "untracked\n"} {
+		out, err := exec.Command("git", "-C", dir, "show", branch+":"+file).Output()
+		if err != nil || string(out) != want {
+			t.Errorf("%s on %s = %q, %v; want %q", file, branch, out, err, want)
+		}
+	}
+	parent, err := exec.Command("git", "-C", dir, "rev-parse", branch+"^").Output()
+	if err != nil || string(parent[:len(parent)-1]) != head {
+		t.Errorf("branch parent = %q, %v; want HEAD %s", parent, err, head)
+	}
+}
+
+func TestWorkerGroup_PreserveFailedWorktrees(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		preserve bool
+		runErr   error
+		want     string
+	}{
+		{"failed phase is kept", true, errors.New("max cycles reached"), "quasar/failed/a"},
+		{"off by default", false, errors.New("max cycles reached"), ""},
+		{"successful phase is not kept", true, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}},
+				Phases:   []PhaseSpec{{ID: "a", Body: "phase a"}},
+			}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+			runner := &mockRunner{result: &PhaseRunnerResult{BaseCommitSHA: "abc123"}, err: tt.runErr}
+			var notified string
+			wg := NewWorkerGroup(n, state,
+				WithRunner(runner),
+				WithCommitter(&mockGitCommitter{}),
+				WithPreserveFailedWorktrees(tt.preserve),
+				WithLogger(io.Discard),
+			)
+			wg.OnPreserved = func(_, location string) { notified = location }
+
+			results, err := wg.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if len(results) != 1 || results[0].Preserved != tt.want {
+				t.Fatalf("results = %+v, want Preserved %q", results, tt.want)
+			}
+			if notified != tt.want || state.Phases["a"].Preserved != tt.want {
+				t.Errorf("OnPreserved got %q, state has %q; want %q", notified, state.Phases["a"].Preserved, tt.want)
+			}
+		})
+	}
+}
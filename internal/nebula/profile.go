@@ -0,0 +1,54 @@
+package nebula
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// profileErrors reports agent profiles that cannot be selected or would
+// leave an agent without usable tools. Profiles are checked in name order so
+// the output is stable.
+func profileErrors(profiles map[string]Profile) []ValidationError {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []ValidationError
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidProfile,
+			SourceFile: "nebula.toml",
+			Field:      field,
+			Err:        fmt.Errorf("%w: "+format, append([]any{ErrInvalidProfile}, args...)...),
+		})
+	}
+	for _, name := range names {
+		p := profiles[name]
+		field := "agent_profiles." + name
+		if strings.TrimSpace(name) == "" {
+			invalid(field, "profile name must be a non-empty assignee")
+			continue
+		}
+		if p.Model == "" && p.CoderPrompt == "" && p.ReviewerPrompt == "" && p.CoderTools == nil && p.ReviewerTools == nil {
+			invalid(field, "profile %q overrides nothing", name)
+		}
+		for _, tools := range []struct {
+			key  string
+			list []string
+		}{{"coder_tools", p.CoderTools}, {"reviewer_tools", p.ReviewerTools}} {
+			if tools.list != nil && len(tools.list) == 0 {
+				invalid(field+"."+tools.key, "profile %q has an empty %s list", name, tools.key)
+			}
+			for _, t := range tools.list {
+				if strings.TrimSpace(t) == "" {
+					invalid(field+"."+tools.key, "profile %q has an empty entry in %s", name, tools.key)
+					break
+				}
+			}
+		}
+	}
+	return errs
+}
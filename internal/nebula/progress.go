@@ -13,6 +13,7 @@ type ProgressReporter struct {
 	onProgress ProgressFunc
 	metrics    *Metrics
 	logger     io.Writer
+	backups    int // previous state files to keep on each save; 0 = none
 }
 
 // NewProgressReporter creates a ProgressReporter with the given dependencies.
@@ -32,33 +33,30 @@ func (pr *ProgressReporter) ReportProgress() {
 	if pr.onProgress == nil {
 		return
 	}
-	total := len(pr.nebula.Phases)
-	var completed, open, closed int
-	for _, ps := range pr.state.Phases {
+	completed, open, closed := progressCounts(pr.state)
+	pr.onProgress(completed, len(pr.nebula.Phases), open, closed, pr.state.TotalCostUSD)
+}
+
+// progressCounts tallies completed, open, and closed phases in state.
+// Pending phases have no bead yet and are counted in none of them, though
+// they still contribute to the nebula's total.
+func progressCounts(state *State) (completed, open, closed int) {
+	for _, ps := range state.Phases {
 		switch ps.Status {
-		case PhaseStatusDone:
-			closed++
-			completed++
-		case PhaseStatusFailed:
-			closed++
-			completed++
-		case PhaseStatusSkipped:
+		case PhaseStatusDone, PhaseStatusFailed, PhaseStatusSkipped:
 			closed++
 			completed++
 		case PhaseStatusInProgress, PhaseStatusCreated:
 			open++
-		case PhaseStatusPending:
-			// Pending phases have no bead yet — not counted in open or closed.
-			// They still contribute to total (via len(pr.nebula.Phases)).
 		}
 	}
-	pr.onProgress(completed, total, open, closed, pr.state.TotalCostUSD)
+	return completed, open, closed
 }
 
 // SaveState persists the current state to disk. Logs a warning on failure.
 // Must be called with the WorkerGroup mutex held.
 func (pr *ProgressReporter) SaveState() {
-	if err := SaveState(pr.nebula.Dir, pr.state); err != nil {
+	if err := SaveStateWithBackups(pr.nebula.Dir, pr.state, pr.backups); err != nil {
 		fmt.Fprintf(pr.logger, "warning: failed to save state: %v\n", err)
 	}
 }
@@ -77,6 +75,13 @@ func (pr *ProgressReporter) RecordPhaseComplete(phaseID string, result PhaseRunn
 	}
 }
 
+// RecordMaxWorkers records the configured worker limit if metrics collection is enabled.
+func (pr *ProgressReporter) RecordMaxWorkers(n int) {
+	if pr.metrics != nil {
+		pr.metrics.RecordMaxWorkers(n)
+	}
+}
+
 // RecordWaveComplete records wave completion metrics if metrics collection is enabled.
 func (pr *ProgressReporter) RecordWaveComplete(waveNumber, effective, peak int) {
 	if pr.metrics != nil {
@@ -0,0 +1,217 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Kinds of run artifact Prune removes, as reported in PrunedItem.Kind.
+const (
+	PruneKindStateBackup    = "state backup"
+	PruneKindLogs           = "logs"
+	PruneKindArtifacts      = "artifacts"
+	PruneKindMetricsHistory = "metrics run" // one past run's summary in the metrics history
+)
+
+// PruneOptions sets which run artifacts Prune removes. An artifact is
+// removed when it is older than OlderThan or is not among the Keep newest
+// of its kind; a zero value turns that limit off.
+type PruneOptions struct {
+	OlderThan time.Duration
+	Keep      int
+	DryRun    bool      // report what would be removed without removing it
+	Now       time.Time // reference time for OlderThan; zero means time.Now()
+}
+
+// PrunedItem is one artifact Prune removed, or would remove with DryRun.
+type PrunedItem struct {
+	Path    string // for PruneKindMetricsHistory, the metrics file holding the run
+	Kind    string // one of the PruneKind constants
+	ModTime time.Time
+	Size    int64 // bytes, summed over a directory's files; 0 for a metrics run
+}
+
+// Prune removes old run artifacts from the nebula in dir: rotated state
+// backups, the per-phase directories under logs/ and artifacts/, and past
+// runs in the metrics history, dated by when they completed. It never
+// touches the manifest, phase files, the current state, or the latest run's
+// metrics, skips intervention files (see GitExcludePatterns), and leaves
+// alone the logs and artifacts of phases the state records as in progress.
+// Checkpoints are built from git when a gate shows them, so there are none
+// on disk to prune. Items are returned oldest first.
+func Prune(dir string, opts PruneOptions) ([]PrunedItem, error) {
+	if opts.OlderThan <= 0 && opts.Keep <= 0 {
+		return nil, errors.New("prune needs an age or a count limit")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nebula.toml")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoManifest, dir)
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	state, err := LoadState(dir)
+	if err != nil {
+		return nil, err
+	}
+	running := make(map[string]bool)
+	for id, ps := range state.Phases {
+		running[id] = ps.Status == PhaseStatusInProgress
+	}
+
+	backups, err := pruneCandidates(dir, PruneKindStateBackup, func(e fs.DirEntry) bool {
+		ok, _ := path.Match(stateFileName+".*", e.Name())
+		return ok && e.Type().IsRegular()
+	})
+	if err != nil {
+		return nil, err
+	}
+	perPhase := func(e fs.DirEntry) bool { return e.IsDir() && !running[e.Name()] }
+	logs, err := pruneCandidates(OutputLogDir(dir), PruneKindLogs, perPhase)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := pruneCandidates(filepath.Join(dir, artifactsDirName), PruneKindArtifacts, perPhase)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := loadMetricsFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	runs := metricsHistoryCandidates(dir, metrics)
+
+	var pruned []PrunedItem
+	for _, items := range [][]PrunedItem{backups, logs, artifacts, runs} {
+		pruned = append(pruned, selectPruned(items, opts)...)
+	}
+	sort.SliceStable(pruned, func(i, j int) bool { return pruned[i].ModTime.Before(pruned[j].ModTime) })
+	if opts.DryRun {
+		return pruned, nil
+	}
+	prunedRuns := make(map[time.Time]bool)
+	for _, item := range pruned {
+		if item.Kind == PruneKindMetricsHistory {
+			prunedRuns[item.ModTime] = true
+			continue
+		}
+		if err := os.RemoveAll(item.Path); err != nil {
+			return nil, fmt.Errorf("pruning %s: %w", item.Path, err)
+		}
+	}
+	if len(prunedRuns) > 0 {
+		var kept []historySummary
+		for _, h := range metrics.History {
+			if !prunedRuns[historyRunTime(h)] {
+				kept = append(kept, h)
+			}
+		}
+		metrics.History = kept
+		if err := writeMetricsFile(dir, *metrics); err != nil {
+			return nil, fmt.Errorf("pruning metrics history: %w", err)
+		}
+	}
+	return pruned, nil
+}
+
+// metricsHistoryCandidates lists the past runs in metrics' history, newest
+// first. A nil metrics file has none.
+func metricsHistoryCandidates(dir string, metrics *metricsFile) []PrunedItem {
+	if metrics == nil {
+		return nil
+	}
+	var items []PrunedItem
+	for _, h := range metrics.History {
+		items = append(items, PrunedItem{
+			Path:    filepath.Join(dir, metricsFileName),
+			Kind:    PruneKindMetricsHistory,
+			ModTime: historyRunTime(h),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ModTime.After(items[j].ModTime) })
+	return items
+}
+
+// historyRunTime dates a past run by when it completed, or by when it
+// started if it never did.
+func historyRunTime(h historySummary) time.Time {
+	if h.CompletedAt.IsZero() {
+		return h.StartedAt
+	}
+	return h.CompletedAt
+}
+
+// pruneCandidates lists the entries of dir that match, newest first. A
+// missing dir has none.
+func pruneCandidates(dir, kind string, match func(fs.DirEntry) bool) ([]PrunedItem, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	var items []PrunedItem
+	for _, e := range entries {
+		if !match(e) || excludedFromPrune(e.Name()) {
+			continue
+		}
+		item := PrunedItem{Path: filepath.Join(dir, e.Name()), Kind: kind}
+		if err := statPruneItem(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ModTime.After(items[j].ModTime) })
+	return items, nil
+}
+
+// statPruneItem fills in item's size and modification time. A directory
+// counts as modified when its most recently written file was.
+func statPruneItem(item *PrunedItem) error {
+	return filepath.WalkDir(item.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(item.ModTime) {
+			item.ModTime = info.ModTime()
+		}
+		if info.Mode().IsRegular() {
+			item.Size += info.Size()
+		}
+		return nil
+	})
+}
+
+// excludedFromPrune reports whether name is an intervention file, which
+// prune must leave for the run that reads it.
+func excludedFromPrune(name string) bool {
+	for _, pattern := range GitExcludePatterns() {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// selectPruned returns the items, newest first, that opts removes.
+func selectPruned(items []PrunedItem, opts PruneOptions) []PrunedItem {
+	var pruned []PrunedItem
+	for i, item := range items {
+		tooMany := opts.Keep > 0 && i >= opts.Keep
+		tooOld := opts.OlderThan > 0 && opts.Now.Sub(item.ModTime) > opts.OlderThan
+		if tooMany || tooOld {
+			pruned = append(pruned, item)
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,138 @@
+package nebula
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePruneFixture builds a nebula in a temp dir with run artifacts of
+// various ages and returns the dir.
+func writePruneFixture(t *testing.T, now time.Time) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "nebula.toml"), "[nebula]\nname = \"demo\"\n")
+	writeTestFile(t, filepath.Join(dir, "a.md"), "+++\nid = \"a\"\n+++\nbody\n")
+	writeTestFile(t, filepath.Join(dir, "nebula.state.toml"), "version = 1\n[phases.b]\nstatus = \"in_progress\"\n")
+
+	ages := map[string]time.Duration{
+		"nebula.state.toml.1":     time.Hour,
+		"nebula.state.toml.2":     48 * time.Hour,
+		"logs/a/cycle1-coder.txt": 72 * time.Hour,
+		"logs/b/cycle1-coder.txt": 72 * time.Hour, // b is still running
+		"logs/c/cycle1-coder.txt": time.Hour,
+		"artifacts/a/report.txt":  96 * time.Hour,
+		"artifacts/RETRY/x.txt":   96 * time.Hour, // intervention name, never pruned
+	}
+	for name, age := range ages {
+		p := filepath.Join(dir, name)
+		writeTestFile(t, p, "data")
+		when := now.Add(-age)
+		for ; p != dir; p = filepath.Dir(p) {
+			if err := os.Chtimes(p, when, when); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return dir
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		opts PruneOptions
+		want []string
+	}{
+		{"older than a day", PruneOptions{OlderThan: 24 * time.Hour}, []string{"artifacts/a", "logs/a", "nebula.state.toml.2"}},
+		{"keep newest one", PruneOptions{Keep: 1}, []string{"logs/a", "nebula.state.toml.2"}},
+		{"dry run removes nothing", PruneOptions{OlderThan: 24 * time.Hour, DryRun: true}, []string{"artifacts/a", "logs/a", "nebula.state.toml.2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := writePruneFixture(t, now)
+			tt.opts.Now = now
+
+			items, err := Prune(dir, tt.opts)
+			if err != nil {
+				t.Fatalf("Prune: %v", err)
+			}
+			var got []string
+			for _, item := range items {
+				rel, _ := filepath.Rel(dir, item.Path)
+				got = append(got, filepath.ToSlash(rel))
+				_, statErr := os.Stat(item.Path)
+				if removed := errors.Is(statErr, fs.ErrNotExist); removed == tt.opts.DryRun {
+					t.Errorf("%s removed = %v with DryRun %v", rel, removed, tt.opts.DryRun)
+				}
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("pruned = %v, want %v", got, tt.want)
+			}
+			for _, keep := range []string{"nebula.toml", "a.md", "nebula.state.toml", "logs/b", "logs/c", "artifacts/RETRY"} {
+				if _, err := os.Stat(filepath.Join(dir, keep)); err != nil {
+					t.Errorf("%s should be kept: %v", keep, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPruneNeedsLimit(t *testing.T) {
+	t.Parallel()
+
+	dir := writePruneFixture(t, time.Now())
+	if _, err := Prune(dir, PruneOptions{}); err == nil {
+		t.Error("Prune with no limit should fail")
+	}
+	if _, err := Prune(t.TempDir(), PruneOptions{Keep: 1}); !errors.Is(err, ErrNoManifest) {
+		t.Errorf("Prune outside a nebula = %v, want ErrNoManifest", err)
+	}
+}
+
+func TestPruneMetricsHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	dir := writePruneFixture(t, now)
+	file := metricsFile{Current: metricsRecord{NebulaName: "demo", StartedAt: now.Add(-200 * time.Hour)}}
+	for _, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, time.Hour} {
+		file.History = append(file.History, historySummary{NebulaName: "demo", CompletedAt: now.Add(-age)})
+	}
+	if err := writeMetricsFile(dir, file); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := Prune(dir, PruneOptions{OlderThan: 24 * time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	var runs int
+	for _, item := range items {
+		if item.Kind == PruneKindMetricsHistory {
+			runs++
+		}
+	}
+	if runs != 2 {
+		t.Errorf("pruned %d metrics runs, want 2", runs)
+	}
+	current, history, err := LoadMetricsWithHistory(dir)
+	if err != nil {
+		t.Fatalf("LoadMetricsWithHistory: %v", err)
+	}
+	if current == nil || current.NebulaName != "demo" {
+		t.Errorf("current metrics = %+v, want them kept", current)
+	}
+	if len(history) != 1 || now.Sub(history[0].CompletedAt) > 2*time.Hour {
+		t.Errorf("history = %+v, want only the run from an hour ago", history)
+	}
+}
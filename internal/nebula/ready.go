@@ -0,0 +1,21 @@
+package nebula
+
+// announceReady passes to OnPhaseReady each phase in eligible that was not
+// eligible on any earlier dispatch pass of this run. The phases eligible on
+// the first pass are recorded without being announced: they were never
+// waiting on anything.
+func (wg *WorkerGroup) announceReady(eligible []string) {
+	first := wg.readySeen == nil
+	if first {
+		wg.readySeen = make(map[string]bool, len(eligible))
+	}
+	for _, id := range eligible {
+		if wg.readySeen[id] {
+			continue
+		}
+		wg.readySeen[id] = true
+		if !first && wg.OnPhaseReady != nil {
+			wg.OnPhaseReady(id)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package nebula
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnnounceReady(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		passes [][]string
+		want   []string
+	}{
+		{"first pass is not announced", [][]string{{"a", "b"}}, nil},
+		{"newly eligible phase is announced", [][]string{{"a"}, {"b", "c"}}, []string{"b", "c"}},
+		{"phase is announced once", [][]string{{"a"}, {"b"}, {"b"}, {"b", "c"}}, []string{"b", "c"}},
+		{"first-pass phase eligible again is not announced", [][]string{{"a"}, {}, {"a"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			wg := &WorkerGroup{OnPhaseReady: func(id string) { got = append(got, id) }}
+			for _, eligible := range tt.passes {
+				wg.announceReady(eligible)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("announced %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
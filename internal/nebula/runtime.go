@@ -0,0 +1,49 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// runtimeLimit derives the context whose deadline ends dispatching when
+// MaxRuntime is set. It is only consulted by the dispatch loop, never passed
+// to phases, so phases already running when it expires finish normally.
+func (wg *WorkerGroup) runtimeLimit(ctx context.Context) (context.Context, context.CancelFunc) {
+	if wg.MaxRuntime <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, wg.MaxRuntime)
+}
+
+// runtimeExceeded reports whether the MaxRuntime deadline of limit has
+// passed, as opposed to the run being canceled.
+func runtimeExceeded(limit context.Context) bool {
+	return errors.Is(limit.Err(), context.DeadlineExceeded)
+}
+
+// phasesRemain reports whether, with nothing in flight, any phase could
+// still be dispatched or is waiting on a contract. A run that reaches its
+// MaxRuntime with none left finished all its work and ends normally.
+func (wg *WorkerGroup) phasesRemain(ctx context.Context) bool {
+	wg.mu.Lock()
+	eligible, _ := wg.tychoScheduler.Eligible(ctx)
+	wg.mu.Unlock()
+	return len(eligible) > 0 || wg.fabricBlocked() > 0
+}
+
+// handleMaxRuntime saves state once the phases running at the deadline
+// have finished, and prints how to resume. Phases that never started keep
+// their status, so the next apply picks them up.
+func (wg *WorkerGroup) handleMaxRuntime() error {
+	wg.mu.Lock()
+	wg.progress.SaveState()
+	wg.progress.ReportProgress()
+	wg.mu.Unlock()
+
+	fmt.Fprintf(wg.logger(), "\n── Max runtime reached ────────────────────────────\n")
+	fmt.Fprintf(wg.logger(), "   Ran for the %s limit; remaining phases are pending.\n", wg.MaxRuntime)
+	fmt.Fprintf(wg.logger(), "   Resume with: quasar nebula apply\n")
+	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
+	return fmt.Errorf("%w (%s)", ErrMaxRuntime, wg.MaxRuntime)
+}
@@ -0,0 +1,91 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// slowRunner takes delay to run each phase, honoring cancellation.
+type slowRunner struct {
+	mockRunner
+	delay time.Duration
+}
+
+func (r *slowRunner) RunExistingPhase(ctx context.Context, phaseID, beadID, phaseTitle, phaseDescription string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mockRunner.RunExistingPhase(ctx, phaseID, beadID, phaseTitle, phaseDescription, exec)
+	select {
+	case <-time.After(r.delay):
+		return &PhaseRunnerResult{TotalCostUSD: 0.1}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestWorkerGroup_MaxRuntime(t *testing.T) {
+	t.Parallel()
+
+	phases := []PhaseSpec{
+		{ID: "a", Body: "phase a"},
+		{ID: "b", Body: "phase b", DependsOn: []string{"a"}},
+	}
+	n := &Nebula{Dir: t.TempDir(), Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: phases}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+	for _, p := range phases {
+		state.Phases[p.ID] = &PhaseState{BeadID: "bead-" + p.ID, Status: PhaseStatusCreated}
+	}
+
+	// a outlives the limit: it must finish, and b must not start.
+	runner := &slowRunner{delay: 100 * time.Millisecond}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithLogger(io.Discard), WithMaxRuntime(20*time.Millisecond))
+
+	results, err := wg.Run(context.Background())
+	if !errors.Is(err, ErrMaxRuntime) {
+		t.Fatalf("Run error = %v, want ErrMaxRuntime", err)
+	}
+	if len(results) != 1 || results[0].PhaseID != "a" || results[0].Err != nil {
+		t.Errorf("results = %+v, want only a, finished", results)
+	}
+	if got := state.Phases["a"].Status; got != PhaseStatusDone {
+		t.Errorf("phase a status = %s, want done", got)
+	}
+	if got := state.Phases["b"].Status; got != PhaseStatusCreated {
+		t.Errorf("phase b status = %s, want created so a resume runs it", got)
+	}
+	if got := ExitCode(err, results); got != ExitMaxRuntime {
+		t.Errorf("ExitCode = %d, want ExitMaxRuntime", got)
+	}
+}
+
+func TestWorkerGroup_MaxRuntimeAfterLastPhase(t *testing.T) {
+	t.Parallel()
+
+	// The only phase finishes after the deadline: nothing is left pending,
+	// so the run succeeds instead of reporting ErrMaxRuntime.
+	n := &Nebula{Dir: t.TempDir(), Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: []PhaseSpec{{ID: "a", Body: "phase a"}}}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+	runner := &slowRunner{delay: 100 * time.Millisecond}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithLogger(io.Discard), WithMaxRuntime(20*time.Millisecond))
+
+	results, err := wg.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run error = %v, want nil", err)
+	}
+	if got := ExitCode(err, results); got != ExitOK {
+		t.Errorf("ExitCode = %d, want ExitOK", got)
+	}
+}
+
+func TestWorkerGroup_MaxRuntimeNotReached(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{Dir: t.TempDir(), Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: []PhaseSpec{{ID: "a", Body: "phase a"}}}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}}}
+	wg := NewWorkerGroup(n, state, WithRunner(&slowRunner{}), WithLogger(io.Discard), WithMaxRuntime(time.Hour))
+
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run error = %v, want nil", err)
+	}
+}
@@ -2,6 +2,7 @@ package nebula
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/papapumpkin/quasar/internal/dag"
@@ -59,21 +60,16 @@ func NewScheduler(phases []PhaseSpec) (*Scheduler, error) {
 }
 
 // ReadyTasks returns task IDs whose dependencies are all in the done set,
-// sorted by composite impact score (highest first). This ensures
-// high-impact bottleneck phases are scheduled before leaf nodes.
+// in dispatch order (see sortForDispatch). Within a priority, high-impact
+// bottleneck phases are scheduled before leaf nodes.
 func (s *Scheduler) ReadyTasks(done map[string]bool) []string {
 	ready := s.analyzer.ReadyWithDone(done)
-
-	// Sort by impact score descending for impact-aware scheduling.
-	scores := s.scores
-	sort.Slice(ready, func(i, j int) bool {
-		return scores[ready[i]] > scores[ready[j]]
-	})
+	s.sortForDispatch(ready)
 	return ready
 }
 
-// AllPending returns all phase IDs that are not in the done set,
-// sorted by impact score (highest first). Unlike ReadyTasks, this
+// AllPending returns all phase IDs that are not in the done set, in
+// dispatch order (see sortForDispatch). Unlike ReadyTasks, this
 // does not filter by DAG dependency satisfaction — all non-complete
 // phases are candidates. This enables soft-DAG dispatch when the
 // fabric's wave scanner and contract poller handle ordering and safety.
@@ -84,14 +80,36 @@ func (s *Scheduler) AllPending(done map[string]bool) []string {
 			pending = append(pending, id)
 		}
 	}
-	// Sort by impact score descending.
-	scores := s.scores
-	sort.Slice(pending, func(i, j int) bool {
-		return scores[pending[i]] > scores[pending[j]]
-	})
+	s.sortForDispatch(pending)
 	return pending
 }
 
+// sortForDispatch orders ids so that, when a wave has more eligible phases
+// than workers, the most important run first: by phase priority (1 is
+// highest; 0 means unset and sorts last), then by impact score descending,
+// then by ID for determinism.
+func (s *Scheduler) sortForDispatch(ids []string) {
+	d := s.analyzer.DAG()
+	rank := func(id string) int {
+		n := d.Node(id)
+		if n == nil || n.Priority <= 0 {
+			return math.MaxInt
+		}
+		return n.Priority
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ri, rj := rank(ids[i]), rank(ids[j])
+		if ri != rj {
+			return ri < rj
+		}
+		si, sj := s.scores[ids[i]], s.scores[ids[j]]
+		if si != sj {
+			return si > sj
+		}
+		return ids[i] < ids[j]
+	})
+}
+
 // Tracks returns the independent parallel tracks. Each track can be
 // assigned to a separate worker without risk of dependency conflict.
 func (s *Scheduler) Tracks() []dag.Track {
@@ -39,7 +39,7 @@ func validateScopeOverlaps(phases []PhaseSpec, d *dag.DAG) []ValidationError {
 				continue
 			}
 
-			if patA, patB, overlaps := scopesOverlap(a.Scope, b.Scope); overlaps {
+			if patA, patB, overlaps := scopesOverlap(a.rootedScope(), b.rootedScope()); overlaps {
 				pattern := patA
 				if patA != patB {
 					pattern = patA + " / " + patB
@@ -60,6 +60,20 @@ func validateScopeOverlaps(phases []PhaseSpec, d *dag.DAG) []ValidationError {
 	return errs
 }
 
+// rootedScope returns p's scope patterns relative to the nebula working
+// directory rather than the phase's own working_dir, so phases that run in
+// different directories can be compared.
+func (p PhaseSpec) rootedScope() []string {
+	if p.WorkingDir == "" {
+		return p.Scope
+	}
+	rooted := make([]string, len(p.Scope))
+	for i, pattern := range p.Scope {
+		rooted[i] = filepath.Join(filepath.FromSlash(p.WorkingDir), pattern)
+	}
+	return rooted
+}
+
 // scopesOverlap reports whether any pattern in a overlaps with any pattern in b.
 // It returns the first overlapping pair and true, or empty strings and false.
 func scopesOverlap(a, b []string) (string, string, bool) {
@@ -0,0 +1,99 @@
+package nebula
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// scratchpadFile is the JSONL file in the nebula directory that holds the
+// notes agents leave with `quasar nebula note`.
+const scratchpadFile = "scratchpad.jsonl"
+
+// ScratchpadNote is one note an agent left on the nebula's scratchpad.
+type ScratchpadNote struct {
+	Time    time.Time `json:"time"`
+	PhaseID string    `json:"phase"`
+	Role    string    `json:"role,omitempty"` // "coder", "reviewer", or "" when unstated
+	Text    string    `json:"text"`
+}
+
+// ScratchpadPath returns the path of the scratchpad file in nebula dir.
+func ScratchpadPath(dir string) string {
+	return filepath.Join(dir, scratchpadFile)
+}
+
+// AppendScratchpadNote appends note to the scratchpad in dir, stamping the
+// time when it is unset. Each note is written as a single line in one
+// append, so notes from concurrent agents never interleave.
+func AppendScratchpadNote(dir string, note ScratchpadNote) error {
+	note.Text = strings.TrimSpace(note.Text)
+	if note.Text == "" {
+		return errors.New("scratchpad note is empty")
+	}
+	if note.Time.IsZero() {
+		note.Time = time.Now().UTC()
+	}
+	data, err := json.Marshal(note)
+	if err != nil {
+		return fmt.Errorf("encoding scratchpad note: %w", err)
+	}
+	f, err := os.OpenFile(ScratchpadPath(dir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening scratchpad: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing scratchpad note: %w", err)
+	}
+	return f.Close()
+}
+
+// LoadScratchpadNotes returns the notes in dir's scratchpad, oldest first.
+// A missing scratchpad yields no notes; malformed lines are skipped.
+func LoadScratchpadNotes(dir string) ([]ScratchpadNote, error) {
+	f, err := os.Open(ScratchpadPath(dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening scratchpad: %w", err)
+	}
+	defer f.Close()
+
+	var notes []ScratchpadNote
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var note ScratchpadNote
+		if json.Unmarshal(scanner.Bytes(), &note) == nil {
+			notes = append(notes, note)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading scratchpad: %w", err)
+	}
+	return notes, nil
+}
+
+// scratchpadInstructions tells the agents of phaseID how to leave a note on
+// the scratchpad of the nebula in dir.
+func scratchpadInstructions(dir, phaseID string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return fmt.Sprintf(`
+
+SCRATCHPAD:
+To think out loud where the human watching this run can see it — a plan, a
+surprise, a trade-off you chose — leave a short note (your role is coder or
+reviewer):
+  Run: %s %q %s --role <role> "<note>"
+Notes are optional and do not replace your final report.`, agent.ScratchpadNoteCommand, dir, phaseID)
+}
@@ -0,0 +1,71 @@
+package nebula
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+func TestScratchpadNotesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notes, err := LoadScratchpadNotes(dir)
+	if err != nil || len(notes) != 0 {
+		t.Fatalf("missing scratchpad: got %v, %v; want no notes", notes, err)
+	}
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, n := range []ScratchpadNote{
+		{Time: at, PhaseID: "api", Role: "coder", Text: "  going with a table-driven router  "},
+		{PhaseID: "api", Role: "reviewer", Text: "router looks fine"},
+	} {
+		if err := AppendScratchpadNote(dir, n); err != nil {
+			t.Fatalf("AppendScratchpadNote: %v", err)
+		}
+	}
+	if err := AppendScratchpadNote(dir, ScratchpadNote{PhaseID: "api", Text: " "}); err == nil {
+		t.Error("expected an error for an empty note")
+	}
+
+	// A malformed line is skipped rather than failing the load.
+	f, err := os.OpenFile(ScratchpadPath(dir), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("not json\n")
+	f.Close()
+
+	notes, err = LoadScratchpadNotes(dir)
+	if err != nil {
+		t.Fatalf("LoadScratchpadNotes: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("got %d notes, want 2: %+v", len(notes), notes)
+	}
+	if !notes[0].Time.Equal(at) || notes[0].Text != "going with a table-driven router" || notes[0].Role != "coder" {
+		t.Errorf("first note = %+v", notes[0])
+	}
+	if notes[1].Time.IsZero() || notes[1].Role != "reviewer" {
+		t.Errorf("second note = %+v, want a stamped reviewer note", notes[1])
+	}
+}
+
+func TestScratchpadInstructions(t *testing.T) {
+	t.Parallel()
+
+	got := scratchpadInstructions("/work/.nebulas/demo", "api")
+	if !strings.Contains(got, `quasar nebula note "/work/.nebulas/demo" api --role <role>`) {
+		t.Errorf("instructions missing the note command:\n%s", got)
+	}
+
+	// The command the agents are told to run must be one the built-in tool
+	// sets allow.
+	prefix := strings.TrimSuffix(strings.TrimPrefix(agent.ScratchpadNoteTool, "Bash("), "*)")
+	if !strings.Contains(got, "Run: "+prefix) {
+		t.Errorf("instructions do not run a command allowed by %q:\n%s", agent.ScratchpadNoteTool, got)
+	}
+}
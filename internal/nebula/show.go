@@ -0,0 +1,201 @@
+package nebula
+
+import "time"
+
+// ShowVersion is the version of the ShowDoc shape. It is raised only when a
+// field is removed or changes meaning; new fields may appear without it.
+const ShowVersion = 1
+
+// ShowDoc is the structure `quasar nebula show --format json|yaml` prints:
+// the nebula's manifest settings, its phases with their dependencies, and
+// each phase's saved state. Every field is always present, with zero values
+// and empty lists rather than omissions, so consumers can rely on the keys.
+type ShowDoc struct {
+	Version      int              `json:"version" yaml:"version"`
+	Name         string           `json:"name" yaml:"name"`
+	Description  string           `json:"description" yaml:"description"`
+	Execution    ShowExecution    `json:"execution" yaml:"execution"`
+	Context      ShowContext      `json:"context" yaml:"context"`
+	Dependencies ShowDependencies `json:"dependencies" yaml:"dependencies"`
+	TotalCostUSD float64          `json:"total_cost_usd" yaml:"total_cost_usd"`
+	Phases       []ShowPhase      `json:"phases" yaml:"phases"`
+}
+
+// ShowExecution is the manifest's [execution] table.
+type ShowExecution struct {
+	MaxWorkers      int     `json:"max_workers" yaml:"max_workers"`
+	MaxReviewCycles int     `json:"max_review_cycles" yaml:"max_review_cycles"`
+	MaxBudgetUSD    float64 `json:"max_budget_usd" yaml:"max_budget_usd"`
+	RetryBudgetUSD  float64 `json:"retry_budget_usd" yaml:"retry_budget_usd"`
+	TotalBudgetUSD  float64 `json:"total_budget_usd" yaml:"total_budget_usd"`
+	Model           string  `json:"model" yaml:"model"`
+	Gate            string  `json:"gate" yaml:"gate"`
+	OnFailure       string  `json:"on_failure" yaml:"on_failure"`
+	PhaseTimeout    string  `json:"phase_timeout" yaml:"phase_timeout"`
+	TimeoutAction   string  `json:"timeout_action" yaml:"timeout_action"`
+}
+
+// ShowContext is the manifest's [context] table.
+type ShowContext struct {
+	Repo        string   `json:"repo" yaml:"repo"`
+	WorkingDir  string   `json:"working_dir" yaml:"working_dir"`
+	Goals       []string `json:"goals" yaml:"goals"`
+	Constraints []string `json:"constraints" yaml:"constraints"`
+}
+
+// ShowDependencies is the manifest's [dependencies] table.
+type ShowDependencies struct {
+	RequiresBeads   []string `json:"requires_beads" yaml:"requires_beads"`
+	RequiresNebulae []string `json:"requires_nebulae" yaml:"requires_nebulae"`
+}
+
+// ShowPhase is one phase: its spec, its params resolved against the launch
+// params, and its saved state.
+type ShowPhase struct {
+	ID              string            `json:"id" yaml:"id"`
+	Title           string            `json:"title" yaml:"title"`
+	Type            string            `json:"type" yaml:"type"`
+	Priority        int               `json:"priority" yaml:"priority"`
+	DependsOn       []string          `json:"depends_on" yaml:"depends_on"`
+	Labels          []string          `json:"labels" yaml:"labels"`
+	Assignee        string            `json:"assignee" yaml:"assignee"`
+	Gate            string            `json:"gate" yaml:"gate"`
+	Model           string            `json:"model" yaml:"model"`
+	MaxReviewCycles int               `json:"max_review_cycles" yaml:"max_review_cycles"`
+	MaxBudgetUSD    float64           `json:"max_budget_usd" yaml:"max_budget_usd"`
+	Scope           []string          `json:"scope" yaml:"scope"`
+	Params          map[string]string `json:"params" yaml:"params"`
+	MissingParams   []string          `json:"missing_params" yaml:"missing_params"`
+	State           ShowPhaseState    `json:"state" yaml:"state"`
+}
+
+// ShowPhaseState is a phase's entry in the saved state. A phase the state
+// does not record yet is "pending" with every other field zero.
+type ShowPhaseState struct {
+	Status     string      `json:"status" yaml:"status"`
+	BeadID     string      `json:"bead_id" yaml:"bead_id"`
+	DoneReason string      `json:"done_reason" yaml:"done_reason"`
+	SkipReason string      `json:"skip_reason" yaml:"skip_reason"`
+	Attempts   int         `json:"attempts" yaml:"attempts"`
+	CostUSD    float64     `json:"cost_usd" yaml:"cost_usd"`
+	UpdatedAt  string      `json:"updated_at" yaml:"updated_at"` // RFC 3339; "" when pending
+	Report     *ShowReport `json:"report" yaml:"report"`         // null until the phase is reviewed
+}
+
+// ShowReport is the reviewer's report on a phase's last cycle.
+type ShowReport struct {
+	Satisfaction     string `json:"satisfaction" yaml:"satisfaction"`
+	Risk             string `json:"risk" yaml:"risk"`
+	Confidence       string `json:"confidence" yaml:"confidence"`
+	NeedsHumanReview bool   `json:"needs_human_review" yaml:"needs_human_review"`
+	Summary          string `json:"summary" yaml:"summary"`
+}
+
+// NewShowDoc builds the ShowDoc of n and its state, resolving phase params
+// against the launch params.
+func NewShowDoc(n *Nebula, state *State, params map[string]string) ShowDoc {
+	m := n.Manifest
+	doc := ShowDoc{
+		Version:     ShowVersion,
+		Name:        m.Nebula.Name,
+		Description: m.Nebula.Description,
+		Execution: ShowExecution{
+			MaxWorkers:      m.Execution.MaxWorkers,
+			MaxReviewCycles: m.Execution.MaxReviewCycles,
+			MaxBudgetUSD:    m.Execution.MaxBudgetUSD,
+			RetryBudgetUSD:  m.Execution.RetryBudgetUSD,
+			TotalBudgetUSD:  m.Execution.TotalBudgetUSD,
+			Model:           m.Execution.Model,
+			Gate:            string(m.Execution.Gate),
+			OnFailure:       string(m.Execution.OnFailure),
+			PhaseTimeout:    m.Execution.PhaseTimeout,
+			TimeoutAction:   string(m.Execution.TimeoutAction),
+		},
+		Context: ShowContext{
+			Repo:        m.Context.Repo,
+			WorkingDir:  m.Context.WorkingDir,
+			Goals:       nonNil(m.Context.Goals),
+			Constraints: nonNil(m.Context.Constraints),
+		},
+		Dependencies: ShowDependencies{
+			RequiresBeads:   nonNil(m.Dependencies.RequiresBeads),
+			RequiresNebulae: nonNil(m.Dependencies.RequiresNebulae),
+		},
+		Phases: make([]ShowPhase, 0, len(n.Phases)),
+	}
+	if state != nil {
+		doc.TotalCostUSD = state.TotalCostUSD
+	}
+	for i := range n.Phases {
+		doc.Phases = append(doc.Phases, newShowPhase(&n.Phases[i], state, params))
+	}
+	return doc
+}
+
+// newShowPhase builds the ShowPhase of p.
+func newShowPhase(p *PhaseSpec, state *State, params map[string]string) ShowPhase {
+	resolved := ResolveParams(p, params)
+	if resolved == nil {
+		resolved = map[string]string{}
+	}
+	missing := []string{}
+	for _, k := range MissingParams(p, resolved) {
+		if _, ok := resolved[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	sp := ShowPhase{
+		ID:              p.ID,
+		Title:           p.Title,
+		Type:            p.Type,
+		Priority:        p.Priority,
+		DependsOn:       nonNil(p.DependsOn),
+		Labels:          nonNil(p.Labels),
+		Assignee:        p.Assignee,
+		Gate:            string(p.Gate),
+		Model:           p.Model,
+		MaxReviewCycles: p.MaxReviewCycles,
+		MaxBudgetUSD:    p.MaxBudgetUSD,
+		Scope:           nonNil(p.Scope),
+		Params:          resolved,
+		MissingParams:   missing,
+		State:           ShowPhaseState{Status: "pending"},
+	}
+	var ps *PhaseState
+	if state != nil {
+		ps = state.Phases[p.ID]
+	}
+	if ps == nil {
+		return sp
+	}
+	sp.State = ShowPhaseState{
+		Status:     string(ps.Status),
+		BeadID:     ps.BeadID,
+		DoneReason: ps.DoneReason,
+		SkipReason: ps.SkipReason,
+		Attempts:   ps.Attempts,
+		CostUSD:    ps.CostUSD,
+	}
+	if !ps.UpdatedAt.IsZero() {
+		sp.State.UpdatedAt = ps.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	if r := ps.Report; r != nil {
+		sp.State.Report = &ShowReport{
+			Satisfaction:     r.Satisfaction,
+			Risk:             r.Risk,
+			Confidence:       r.Confidence,
+			NeedsHumanReview: r.NeedsHumanReview,
+			Summary:          r.Summary,
+		}
+	}
+	return sp
+}
+
+// nonNil returns s, or an empty slice when s is nil, so it encodes as []
+// rather than null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,85 @@
+package nebula
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+func TestNewShowDoc(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Manifest: Manifest{
+			Nebula:    Info{Name: "auth", Description: "Rework auth"},
+			Execution: Execution{MaxWorkers: 2, Gate: GateModeReview},
+		},
+		Phases: []PhaseSpec{
+			{ID: "schema", Title: "Schema"},
+			{ID: "api", Title: "API", DependsOn: []string{"schema"}},
+		},
+	}
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &State{
+		TotalCostUSD: 1.5,
+		Phases: map[string]*PhaseState{
+			"schema": {
+				BeadID: "b-1", Status: PhaseStatusDone, UpdatedAt: updated, Attempts: 1, CostUSD: 1.5,
+				Report: &agent.ReviewReport{Satisfaction: "high", Risk: "low"},
+			},
+		},
+	}
+
+	doc := NewShowDoc(n, state, nil)
+
+	if doc.Version != ShowVersion || doc.Name != "auth" || doc.Execution.MaxWorkers != 2 || doc.Execution.Gate != "review" {
+		t.Errorf("header = %+v", doc)
+	}
+	tests := []struct {
+		name string
+		got  ShowPhase
+		want ShowPhaseState
+		deps []string
+	}{
+		{"recorded phase", doc.Phases[0], ShowPhaseState{
+			Status: "done", BeadID: "b-1", Attempts: 1, CostUSD: 1.5, UpdatedAt: "2026-03-01T12:00:00Z",
+			Report: &ShowReport{Satisfaction: "high", Risk: "low"},
+		}, []string{}},
+		{"pending phase", doc.Phases[1], ShowPhaseState{Status: "pending"}, []string{"schema"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.got.State
+			if got.Status != tt.want.Status || got.BeadID != tt.want.BeadID || got.UpdatedAt != tt.want.UpdatedAt ||
+				got.Attempts != tt.want.Attempts || got.CostUSD != tt.want.CostUSD {
+				t.Errorf("state = %+v, want %+v", got, tt.want)
+			}
+			if (got.Report == nil) != (tt.want.Report == nil) || got.Report != nil && *got.Report != *tt.want.Report {
+				t.Errorf("report = %+v, want %+v", got.Report, tt.want.Report)
+			}
+			if strings.Join(tt.got.DependsOn, ",") != strings.Join(tt.deps, ",") || tt.got.DependsOn == nil {
+				t.Errorf("depends_on = %#v, want %#v", tt.got.DependsOn, tt.deps)
+			}
+		})
+	}
+}
+
+func TestShowDocJSONHasNoNullLists(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{Phases: []PhaseSpec{{ID: "a"}}}
+	data, err := json.Marshal(NewShowDoc(n, &State{}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, key := range []string{`"goals":[]`, `"requires_beads":[]`, `"depends_on":[]`, `"params":{}`, `"missing_params":[]`, `"report":null`} {
+		if !strings.Contains(got, key) {
+			t.Errorf("JSON missing %s:\n%s", key, got)
+		}
+	}
+}
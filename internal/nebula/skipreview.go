@@ -0,0 +1,21 @@
+package nebula
+
+import "fmt"
+
+// skipReviewErrors reports settings on a skip_review phase that only work
+// with reviewer findings. Manual phases are reported by manualPhaseErrors.
+func skipReviewErrors(p PhaseSpec) []ValidationError {
+	if !p.SkipReview || p.IsManual() {
+		return nil
+	}
+	if p.AutoDecompose != nil && *p.AutoDecompose {
+		return []ValidationError{{
+			Category:   ValCatInvalidSkipReview,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      "auto_decompose",
+			Err:        fmt.Errorf("%w: auto_decompose needs reviewer findings to detect a struggling phase", ErrInvalidSkipReview),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package nebula
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSkipReviewErrors(t *testing.T) {
+	t.Parallel()
+
+	trueVal, falseVal := true, false
+	tests := []struct {
+		name    string
+		phase   PhaseSpec
+		wantErr bool
+	}{
+		{"plain skip_review", PhaseSpec{ID: "a", SkipReview: true}, false},
+		{"auto_decompose off", PhaseSpec{ID: "a", SkipReview: true, AutoDecompose: &falseVal}, false},
+		{"auto_decompose without skip_review", PhaseSpec{ID: "a", AutoDecompose: &trueVal}, false},
+		{"auto_decompose on", PhaseSpec{ID: "a", SkipReview: true, AutoDecompose: &trueVal}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := skipReviewErrors(tt.phase)
+			if !tt.wantErr {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != "auto_decompose" || errs[0].Category != ValCatInvalidSkipReview || !errors.Is(errs[0].Err, ErrInvalidSkipReview) {
+				t.Errorf("errors = %+v, want one auto_decompose error", errs)
+			}
+		})
+	}
+
+	// A manual phase reports skip_review as an agent-only setting instead.
+	p := PhaseSpec{ID: "a", Type: PhaseTypeManual, SkipReview: true, AutoDecompose: &trueVal}
+	if errs := skipReviewErrors(p); len(errs) != 0 {
+		t.Errorf("manual phase errors = %v, want none", errs)
+	}
+	if errs := manualPhaseErrors(p); len(errs) != 1 || errs[0].Field != "skip_review" {
+		t.Errorf("manualPhaseErrors = %+v, want skip_review", errs)
+	}
+}
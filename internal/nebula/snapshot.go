@@ -0,0 +1,104 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/papapumpkin/quasar/internal/dirsnap"
+)
+
+// snapshotCommitter implements GitCommitter for working directories that
+// are not git repositories, recording each phase as a dirsnap snapshot.
+// Snapshot IDs stand in for commit SHAs, so checkpoints built from them
+// work unchanged.
+type snapshotCommitter struct {
+	store *dirsnap.Store
+}
+
+// NewSnapshotCommitter returns a GitCommitter backed by store.
+func NewSnapshotCommitter(store *dirsnap.Store) GitCommitter {
+	return &snapshotCommitter{store: store}
+}
+
+// CommitPhase records the working directory as a snapshot for the phase.
+// If nothing changed, this is a no-op.
+func (s *snapshotCommitter) CommitPhase(_ context.Context, nebulaName, phaseID, phaseTitle string) error {
+	_, _, err := s.store.Commit(fmt.Sprintf("%s/%s: %s", nebulaName, phaseID, phaseTitle))
+	return err
+}
+
+// Diff returns the diff of the working directory against the current snapshot.
+func (s *snapshotCommitter) Diff(context.Context) (string, error) {
+	return s.store.DiffWorkTree()
+}
+
+// DiffLastCommit returns the diff introduced by the current snapshot.
+func (s *snapshotCommitter) DiffLastCommit(context.Context) (string, error) {
+	parent, head, err := s.lastRange()
+	if err != nil || parent == "" {
+		return "", err
+	}
+	return s.store.Diff(parent, head)
+}
+
+// DiffStatLastCommit returns the stat summary for the current snapshot.
+func (s *snapshotCommitter) DiffStatLastCommit(context.Context) (string, error) {
+	parent, head, err := s.lastRange()
+	if err != nil || parent == "" {
+		return "", err
+	}
+	return s.store.DiffStat(parent, head)
+}
+
+// DiffRange returns the unified diff between two snapshots.
+func (s *snapshotCommitter) DiffRange(_ context.Context, base, head string) (string, error) {
+	return s.store.Diff(base, head)
+}
+
+// DiffStatRange returns the stat summary between two snapshots.
+func (s *snapshotCommitter) DiffStatRange(_ context.Context, base, head string) (string, error) {
+	return s.store.DiffStat(base, head)
+}
+
+// ResetTo restores the working directory to an earlier snapshot.
+func (s *snapshotCommitter) ResetTo(_ context.Context, sha string) error {
+	return s.store.Restore(sha)
+}
+
+// Status lists changes since the current snapshot, skipping exclude.
+func (s *snapshotCommitter) Status(_ context.Context, exclude ...string) ([]string, error) {
+	return s.store.Status(exclude...)
+}
+
+// SquashRange is a no-op that returns head: snapshots are content-addressed,
+// so intermediate ones cost little and serve as rollback points.
+func (s *snapshotCommitter) SquashRange(_ context.Context, _, head, _ string) (string, error) {
+	return head, nil
+}
+
+// PreserveWorkTree records the working directory as a snapshot and returns
+// "snapshot <id>". The name is only kept in the snapshot's message.
+func (s *snapshotCommitter) PreserveWorkTree(_ context.Context, name, message string) (string, error) {
+	id, _, err := s.store.Commit(name + ": " + message)
+	if err != nil {
+		return "", err
+	}
+	return "snapshot " + id, nil
+}
+
+// HasCommit reports whether snapshot sha is the current snapshot or one of
+// its ancestors.
+func (s *snapshotCommitter) HasCommit(_ context.Context, sha string) bool {
+	return s.store.Contains(sha)
+}
+
+// lastRange returns the current snapshot and its parent. The parent is ""
+// when the current snapshot is the baseline.
+func (s *snapshotCommitter) lastRange() (parent, head string, err error) {
+	head, err = s.store.Head()
+	if err != nil {
+		return "", "", err
+	}
+	parent, err = s.store.Parent(head)
+	return parent, head, err
+}
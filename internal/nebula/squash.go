@@ -0,0 +1,113 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SquashRange replaces the commits in base..head with a single commit
+// carrying message and returns its SHA. head must be the current HEAD, so
+// no later commit is rewritten. If base and head are the same commit, this
+// is a no-op that returns head.
+func (g *gitCommitter) SquashRange(ctx context.Context, base, head, message string) (string, error) {
+	if err := g.ensureBranch(ctx); err != nil {
+		return "", err
+	}
+	baseSHA, err := g.revParse(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	headSHA, err := g.revParse(ctx, head)
+	if err != nil {
+		return "", err
+	}
+	current, err := g.revParse(ctx, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if headSHA != current {
+		return "", fmt.Errorf("squash %s..%s: %s is not HEAD", base, head, head)
+	}
+	if baseSHA == headSHA {
+		return headSHA, nil
+	}
+	if err := g.git(ctx, "merge-base", "--is-ancestor", baseSHA, headSHA); err != nil {
+		return "", fmt.Errorf("squash %s..%s: %s is not an ancestor of %s: %w", base, head, base, head, err)
+	}
+	if err := g.git(ctx, "reset", "--soft", baseSHA); err != nil {
+		return "", err
+	}
+	// A range whose changes cancel out leaves nothing staged; keep the
+	// phase visible in history anyway.
+	if err := g.git(ctx, "commit", "--allow-empty", "-m", message); err != nil {
+		return "", err
+	}
+	return g.revParse(ctx, "HEAD")
+}
+
+// revParse resolves rev to a full commit SHA.
+func (g *gitCommitter) revParse(ctx context.Context, rev string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", g.dir, "rev-parse", "--verify", rev+"^{commit}")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git rev-parse %s: %w: %s", rev, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// git runs a git subcommand in g.dir, folding its stderr into the error.
+func (g *gitCommitter) git(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// squashPhaseCommits folds a completed phase's cycle commits and its phase
+// commit into one commit and points result.FinalCommitSHA at it. Phases
+// running in parallel share a branch, so their commits interleave; in that
+// case the commits are left as they are. Diffs the TUI already received per
+// cycle are held in memory and are unaffected.
+func (wg *WorkerGroup) squashPhaseCommits(ctx context.Context, phase *PhaseSpec, result *PhaseRunnerResult) {
+	if !wg.SquashPhaseCommits || wg.Committer == nil || result == nil || result.BaseCommitSHA == "" {
+		return
+	}
+	if wg.MaxWorkers > 1 {
+		wg.squashWarned.Do(func() {
+			fmt.Fprintf(wg.logger(), "warning: not squashing phase commits: phases run in parallel (max workers %d) share the branch\n", wg.MaxWorkers)
+		})
+		return
+	}
+	sha, err := wg.Committer.SquashRange(ctx, result.BaseCommitSHA, "HEAD", squashMessage(wg.Nebula.Manifest.Nebula.Name, phase, result))
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: failed to squash commits for phase %q: %v\n", phase.ID, err)
+		return
+	}
+	result.FinalCommitSHA = sha
+}
+
+// squashMessage builds the commit message for a squashed phase: the phase
+// commit's subject, then the cycle count and the reviewer's verdict.
+func squashMessage(nebulaName string, phase *PhaseSpec, result *PhaseRunnerResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s/%s: %s\n\n", nebulaName, phase.ID, phase.Title)
+	fmt.Fprintf(&b, "Squashed from %d review cycle(s).", result.CyclesUsed)
+	if r := result.Report; r != nil {
+		fmt.Fprintf(&b, "\nReviewer: satisfaction %s, risk %s", r.Satisfaction, r.Risk)
+		if r.NeedsHumanReview {
+			b.WriteString(", needs human review")
+		}
+		if r.Summary != "" {
+			fmt.Fprintf(&b, "\n\n%s", r.Summary)
+		}
+	}
+	return b.String()
+}
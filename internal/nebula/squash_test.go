@@ -0,0 +1,119 @@
+package nebula
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// commitFile writes name with content and commits it with msg.
+func commitFile(ctx context.Context, t *testing.T, dir, name, content, msg string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run(ctx, t, dir, "git", "add", "-A")
+	run(ctx, t, dir, "git", "commit", "-m", msg)
+}
+
+func TestGitCommitter_SquashRange(t *testing.T) {
+	t.Run("squashes the range into one commit", func(t *testing.T) {
+		dir := initTestRepo(t)
+		ctx := context.Background()
+		gc := NewGitCommitter(ctx, dir)
+		base := headSHA(ctx, t, dir)
+		commitFile(ctx, t, dir, "a.txt", "one\n", "bead-1/cycle-1: first")
+		cycle1 := headSHA(ctx, t, dir)
+		commitFile(ctx, t, dir, "a.txt", "two\n", "bead-1/cycle-2: second")
+		commitFile(ctx, t, dir, "b.txt", "b\n", "neb/p1: phase")
+		before := commitCount(ctx, t, dir)
+
+		sha, err := gc.SquashRange(ctx, base, "HEAD", "neb/p1: phase\n\nSquashed from 2 review cycle(s).")
+		if err != nil {
+			t.Fatalf("SquashRange: %v", err)
+		}
+		if got := commitCount(ctx, t, dir); got != before-2 {
+			t.Errorf("commit count = %d, want %d", got, before-2)
+		}
+		if sha != headSHA(ctx, t, dir) {
+			t.Errorf("returned SHA %s is not HEAD", sha)
+		}
+		if !gc.HasCommit(ctx, sha) || !gc.HasCommit(ctx, base) || gc.HasCommit(ctx, cycle1) {
+			t.Error("HasCommit should keep HEAD and base but not the squashed cycle commit")
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+		if err != nil || string(data) != "two\n" {
+			t.Errorf("a.txt = %q, %v; want the final content", data, err)
+		}
+		out, err := exec.Command("git", "-C", dir, "log", "-1", "--format=%B").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(out), "Squashed from 2 review cycle(s).") {
+			t.Errorf("commit message = %q", out)
+		}
+	})
+
+	t.Run("empty range is a no-op", func(t *testing.T) {
+		dir := initTestRepo(t)
+		ctx := context.Background()
+		gc := NewGitCommitter(ctx, dir)
+		head := headSHA(ctx, t, dir)
+		sha, err := gc.SquashRange(ctx, head, "HEAD", "msg")
+		if err != nil || sha != head {
+			t.Errorf("SquashRange = %q, %v; want %q, nil", sha, err, head)
+		}
+	})
+
+	t.Run("refuses a head behind HEAD", func(t *testing.T) {
+		dir := initTestRepo(t)
+		ctx := context.Background()
+		gc := NewGitCommitter(ctx, dir)
+		base := headSHA(ctx, t, dir)
+		commitFile(ctx, t, dir, "a.txt", "a\n", "one")
+		mid := headSHA(ctx, t, dir)
+		commitFile(ctx, t, dir, "b.txt", "b\n", "two")
+		if _, err := gc.SquashRange(ctx, base, mid, "msg"); err == nil {
+			t.Fatal("expected an error squashing a range that ends before HEAD")
+		}
+	})
+}
+
+func TestSquashMessage(t *testing.T) {
+	t.Parallel()
+
+	phase := &PhaseSpec{ID: "p1", Title: "Add login"}
+	got := squashMessage("neb", phase, &PhaseRunnerResult{
+		CyclesUsed: 3,
+		Report:     &agent.ReviewReport{Satisfaction: "high", Risk: "low", Summary: "Looks good."},
+	})
+	for _, want := range []string{"neb/p1: Add login\n\n", "Squashed from 3 review cycle(s).", "satisfaction high, risk low", "Looks good."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("squashMessage missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestSquashPhaseCommitsSkipsParallelRuns(t *testing.T) {
+	t.Parallel()
+
+	var log bytes.Buffer
+	wg := NewWorkerGroup(&Nebula{}, &State{}, WithMaxWorkers(2), WithSquashPhaseCommits(true),
+		WithCommitter(&mockGitCommitter{}), WithLogger(&log))
+	result := &PhaseRunnerResult{BaseCommitSHA: "base", FinalCommitSHA: "final"}
+	for range 2 {
+		wg.squashPhaseCommits(context.Background(), &PhaseSpec{ID: "p1"}, result)
+	}
+	if result.FinalCommitSHA != "final" {
+		t.Errorf("FinalCommitSHA = %q, want it untouched", result.FinalCommitSHA)
+	}
+	if n := strings.Count(log.String(), "not squashing"); n != 1 {
+		t.Errorf("warning printed %d times, want once:\n%s", n, log.String())
+	}
+}
@@ -0,0 +1,115 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/tycho"
+)
+
+// StaleAction selects what a run does about a fabric claim or blocked phase
+// that tycho keeps finding stale.
+type StaleAction string
+
+const (
+	// StaleActionHail escalates the stale item to a hail on its phase.
+	StaleActionHail StaleAction = "hail"
+	// StaleActionComment posts a reminder comment on the phase's bead.
+	StaleActionComment StaleAction = "comment"
+	// StaleActionPing pings the phase's worker with a fabric pulse.
+	StaleActionPing StaleAction = "ping"
+)
+
+// Valid reports whether a is a recognized action. Empty is valid and means
+// stale items are only warned about.
+func (a StaleAction) Valid() bool {
+	switch a {
+	case "", StaleActionHail, StaleActionComment, StaleActionPing:
+		return true
+	}
+	return false
+}
+
+// ParsedStaleActionAfter returns stale_action_after as a duration. Empty
+// returns 0: act as soon as an item is flagged.
+func (e Execution) ParsedStaleActionAfter() (time.Duration, error) {
+	if e.StaleActionAfter == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(e.StaleActionAfter)
+	if err != nil {
+		return 0, fmt.Errorf("stale_action_after: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("stale_action_after must be >= 0, got %s", d)
+	}
+	return d, nil
+}
+
+// staleRemediator returns the remediator tycho runs on stale items and how
+// long an item must be stale first. WithStaleRemediator takes precedence
+// over the manifest's stale_action; with neither, the result is nil.
+func (wg *WorkerGroup) staleRemediator(ctx context.Context) (tycho.Remediator, time.Duration) {
+	exec := wg.Nebula.Manifest.Execution
+	after, _ := exec.ParsedStaleActionAfter() // checked by Validate
+	if wg.StaleRemediator != nil {
+		return wg.StaleRemediator, after
+	}
+	switch exec.StaleAction {
+	case StaleActionHail:
+		return tycho.HailRemediator{Fabric: wg.Fabric, OnHail: wg.hailFunc(ctx)}, after
+	case StaleActionComment:
+		if wg.BeadsClient == nil {
+			return nil, 0
+		}
+		return tycho.RemediatorFunc(wg.remindOnBead), after
+	case StaleActionPing:
+		return tycho.PingRemediator{Fabric: wg.Fabric}, after
+	}
+	return nil, 0
+}
+
+// remindOnBead posts a reminder about item on its phase's bead.
+func (wg *WorkerGroup) remindOnBead(ctx context.Context, item tycho.StaleItem) (string, error) {
+	wg.mu.Lock()
+	var beadID string
+	if ps := wg.State.Phases[item.Phase]; ps != nil {
+		beadID = ps.BeadID
+	}
+	wg.mu.Unlock()
+	if beadID == "" {
+		return "", nil
+	}
+	body := fmt.Sprintf("Reminder: %s %q has been stale for %s (%s).", item.Kind, item.ID, item.Age.Round(time.Second), item.Details)
+	if err := wg.BeadsClient.AddComment(ctx, beadID, body); err != nil {
+		return "", fmt.Errorf("commenting on bead %s: %w", beadID, err)
+	}
+	return "reminder posted on bead " + beadID, nil
+}
+
+// checkStale runs tycho's stale scan and passes the items to OnStale
+// whenever they, or the actions taken on them, change. It is a no-op
+// without a fabric. Must NOT be called with wg.mu held.
+func (wg *WorkerGroup) checkStale(ctx context.Context) {
+	if wg.tychoScheduler == nil || wg.Fabric == nil {
+		return
+	}
+	items, err := wg.tychoScheduler.StaleCheck(ctx, tycho.DefaultStaleClaim, tycho.DefaultStaleTask)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: stale check failed: %v\n", err)
+		return
+	}
+	var sig strings.Builder
+	for _, it := range items {
+		fmt.Fprintf(&sig, "%s:%s:%s\n", it.Kind, it.ID, it.Remediation)
+	}
+	if sig.String() == wg.lastStale {
+		return
+	}
+	wg.lastStale = sig.String()
+	if wg.OnStale != nil {
+		wg.OnStale(items)
+	}
+}
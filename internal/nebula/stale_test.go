@@ -0,0 +1,88 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/tycho"
+)
+
+func TestValidate_StaleAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		exec    Execution
+		wantErr bool
+	}{
+		{"unset", Execution{}, false},
+		{"hail after 20m", Execution{StaleAction: StaleActionHail, StaleActionAfter: "20m"}, false},
+		{"comment", Execution{StaleAction: StaleActionComment}, false},
+		{"ping", Execution{StaleAction: StaleActionPing}, false},
+		{"unknown action", Execution{StaleAction: "page"}, true},
+		{"malformed after", Execution{StaleAction: StaleActionHail, StaleActionAfter: "soon"}, true},
+		{"negative after", Execution{StaleActionAfter: "-1m"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Manifest: Manifest{Nebula: Info{Name: "test"}, Execution: tt.exec},
+				Phases:   []PhaseSpec{{ID: "a", Title: "Phase A", Body: "do stuff", SourceFile: "a.md"}},
+			}
+			errs := Validate(n)
+			gotErr := len(errs) == 1 && errs[0].Category == ValCatInvalidStaleAction && errors.Is(errs[0].Err, ErrInvalidStaleAction)
+			if tt.wantErr != gotErr || (!tt.wantErr && len(errs) > 0) {
+				t.Errorf("Validate = %v, want stale action error: %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaleRemediator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("comment posts a reminder on the phase bead", func(t *testing.T) {
+		t.Parallel()
+		client := newMockBeadsClient()
+		n := &Nebula{Manifest: Manifest{Execution: Execution{StaleAction: StaleActionComment, StaleActionAfter: "15m"}}}
+		state := &State{Phases: map[string]*PhaseState{"a": {BeadID: "bead-a"}}}
+		wg := NewWorkerGroup(n, state, WithBeadsClient(client))
+
+		r, after := wg.staleRemediator(context.Background())
+		if r == nil || after != 15*time.Minute {
+			t.Fatalf("staleRemediator = %v, %s; want a remediator after 15m", r, after)
+		}
+		action, err := r.Remediate(context.Background(), tycho.StaleItem{Kind: "task", ID: "a", Phase: "a", Age: 20 * time.Minute, Details: "blocked"})
+		if err != nil {
+			t.Fatalf("Remediate: %v", err)
+		}
+		if action != "reminder posted on bead bead-a" {
+			t.Errorf("action = %q", action)
+		}
+		if got := client.comments["bead-a"]; len(got) != 1 || !strings.Contains(got[0], `task "a" has been stale for 20m0s`) {
+			t.Errorf("comments = %v", got)
+		}
+	})
+
+	t.Run("option overrides the manifest", func(t *testing.T) {
+		t.Parallel()
+		custom := tycho.NoopRemediator{}
+		n := &Nebula{Manifest: Manifest{Execution: Execution{StaleAction: StaleActionHail}}}
+		wg := NewWorkerGroup(n, &State{}, WithStaleRemediator(custom))
+		if r, _ := wg.staleRemediator(context.Background()); r != custom {
+			t.Errorf("staleRemediator = %v, want the configured one", r)
+		}
+	})
+
+	t.Run("no action configured", func(t *testing.T) {
+		t.Parallel()
+		wg := NewWorkerGroup(&Nebula{}, &State{})
+		if r, _ := wg.staleRemediator(context.Background()); r != nil {
+			t.Errorf("staleRemediator = %v, want nil", r)
+		}
+	})
+}
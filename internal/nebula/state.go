@@ -92,4 +92,10 @@ func (s *State) SetPhaseState(phaseID, beadID string, status PhaseStatus) {
 	ps.BeadID = beadID
 	ps.Status = status
 	ps.UpdatedAt = now
+	if status != PhaseStatusSkipped {
+		ps.SkipReason = ""
+	}
+	if status != PhaseStatusDone {
+		ps.DoneReason = ""
+	}
 }
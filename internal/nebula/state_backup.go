@@ -0,0 +1,93 @@
+package nebula
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// DefaultStateBackups is the number of previous state files `nebula apply`
+// keeps when no --state-backups flag is given.
+const DefaultStateBackups = 3
+
+// StateBackupPath returns the path of the nth most recent state backup
+// (1 = the state before the latest save).
+func StateBackupPath(dir string, n int) string {
+	return fmt.Sprintf("%s.%d", filepath.Join(dir, stateFileName), n)
+}
+
+// SaveStateWithBackups writes the state file like SaveState, first rotating
+// the current file into nebula.state.toml.1, shifting older backups up by
+// one, and pruning any beyond keep. keep <= 0 behaves exactly like
+// SaveState and leaves existing backups alone.
+func SaveStateWithBackups(dir string, state *State, keep int) error {
+	if keep > 0 {
+		if err := rotateStateBackups(dir, keep); err != nil {
+			return err
+		}
+	}
+	return SaveState(dir, state)
+}
+
+// rotateStateBackups shifts backup i to i+1 for i < keep, copies the current
+// state file to backup 1, and removes backups numbered keep and above. The
+// current file is copied rather than renamed so a state file always exists.
+func rotateStateBackups(dir string, keep int) error {
+	for n := keep; ; n++ {
+		err := os.Remove(StateBackupPath(dir, n))
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("pruning state backup: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading state file for backup: %w", err)
+	}
+	for n := keep - 1; n >= 1; n-- {
+		err := os.Rename(StateBackupPath(dir, n), StateBackupPath(dir, n+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("rotating state backup: %w", err)
+		}
+	}
+	if err := os.WriteFile(StateBackupPath(dir, 1), data, 0644); err != nil {
+		return fmt.Errorf("writing state backup: %w", err)
+	}
+	return nil
+}
+
+// RestoreStateBackup replaces the state file with backup n after checking
+// that the backup parses. Backups are left in place, so a restore can be
+// repeated with a different n.
+func RestoreStateBackup(dir string, n int) error {
+	src := StateBackupPath(dir, n)
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("reading state backup %d: %w", n, err)
+	}
+	var state State
+	if err := toml.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("parsing state backup %d: %w", n, err)
+	}
+
+	path := filepath.Join(dir, stateFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing temp state file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("renaming state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package nebula
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestSaveStateWithBackups(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for cost := 1; cost <= 5; cost++ {
+		state := &State{Version: 1, TotalCostUSD: float64(cost), Phases: map[string]*PhaseState{}}
+		if err := SaveStateWithBackups(dir, state, 2); err != nil {
+			t.Fatalf("save %d: %v", cost, err)
+		}
+	}
+
+	// The current state is the last save; backups hold the two before it.
+	assertCost := func(label string, want float64) {
+		t.Helper()
+		state, err := LoadState(dir)
+		if err != nil {
+			t.Fatalf("%s: LoadState: %v", label, err)
+		}
+		if state.TotalCostUSD != want {
+			t.Errorf("%s: TotalCostUSD = %v, want %v", label, state.TotalCostUSD, want)
+		}
+	}
+	assertCost("current", 5)
+	if _, err := os.Stat(StateBackupPath(dir, 3)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("backup 3 should have been pruned, stat err = %v", err)
+	}
+
+	if err := RestoreStateBackup(dir, 2); err != nil {
+		t.Fatalf("RestoreStateBackup(2): %v", err)
+	}
+	assertCost("after restoring backup 2", 3)
+	if err := RestoreStateBackup(dir, 1); err != nil {
+		t.Fatalf("RestoreStateBackup(1): %v", err)
+	}
+	assertCost("after restoring backup 1", 4)
+
+	if err := RestoreStateBackup(dir, 3); err == nil {
+		t.Error("restoring a missing backup should fail")
+	}
+}
+
+func TestSaveStateWithBackupsDisabled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+	for range 2 {
+		if err := SaveStateWithBackups(dir, state, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(StateBackupPath(dir, 1)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("keep = 0 should write no backups, stat err = %v", err)
+	}
+}
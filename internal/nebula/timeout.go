@@ -0,0 +1,163 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutAction is what happens to a phase that runs past its timeout.
+type TimeoutAction string
+
+const (
+	// TimeoutActionFail fails the phase, blocking its dependents.
+	TimeoutActionFail TimeoutAction = "fail"
+	// TimeoutActionSkip marks the phase done so its dependents proceed.
+	TimeoutActionSkip TimeoutAction = "skip"
+	// TimeoutActionRetry runs the phase once more with twice the timeout
+	// and a larger budget, then fails it if it times out again.
+	TimeoutActionRetry TimeoutAction = "retry"
+)
+
+// DoneReasonTimedOut marks a phase that timed out and was skipped with
+// TimeoutActionSkip.
+const DoneReasonTimedOut = "timed_out"
+
+// Valid reports whether a is a recognized action. Empty is valid and means
+// TimeoutActionFail.
+func (a TimeoutAction) Valid() bool {
+	switch a {
+	case "", TimeoutActionFail, TimeoutActionSkip, TimeoutActionRetry:
+		return true
+	}
+	return false
+}
+
+// parseTimeout parses a phase_timeout or timeout value. Empty returns 0
+// with ok false, meaning the setting is inherited; "0" disables the timeout.
+func parseTimeout(s string) (d time.Duration, ok bool, err error) {
+	if s == "" {
+		return 0, false, nil
+	}
+	d, err = time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = errors.New("must not be negative")
+	}
+	return d, err == nil, err
+}
+
+// timeoutError reports an invalid timeout duration or timeout_action.
+func timeoutError(phaseID, sourceFile, field string, err error) ValidationError {
+	return ValidationError{
+		Category:   ValCatInvalidTimeout,
+		PhaseID:    phaseID,
+		SourceFile: sourceFile,
+		Field:      field,
+		Err:        fmt.Errorf("%w: %w", ErrInvalidTimeout, err),
+	}
+}
+
+// executionTimeoutErrors reports a malformed execution.phase_timeout or
+// an unrecognized execution.timeout_action.
+func executionTimeoutErrors(exec Execution) []ValidationError {
+	var errs []ValidationError
+	if _, _, err := parseTimeout(exec.PhaseTimeout); err != nil {
+		errs = append(errs, timeoutError("", "nebula.toml", "execution.phase_timeout", fmt.Errorf("%q: %w", exec.PhaseTimeout, err)))
+	}
+	if !exec.TimeoutAction.Valid() {
+		errs = append(errs, timeoutError("", "nebula.toml", "execution.timeout_action", fmt.Errorf("%q", exec.TimeoutAction)))
+	}
+	return errs
+}
+
+// timeoutErrors reports a malformed timeout or an unrecognized
+// timeout_action on p.
+func timeoutErrors(p PhaseSpec) []ValidationError {
+	var errs []ValidationError
+	if _, _, err := parseTimeout(p.Timeout); err != nil {
+		errs = append(errs, timeoutError(p.ID, p.SourceFile, "timeout", fmt.Errorf("%q: %w", p.Timeout, err)))
+	}
+	if !p.TimeoutAction.Valid() {
+		errs = append(errs, timeoutError(p.ID, p.SourceFile, "timeout_action", fmt.Errorf("%q", p.TimeoutAction)))
+	}
+	return errs
+}
+
+// runWithTimeout runs the phase under exec.Timeout, when one is set. A run
+// cut short by the timeout returns an error wrapping ErrPhaseTimeout, while
+// a cancellation of ctx itself is passed through untouched.
+func (wg *WorkerGroup) runWithTimeout(ctx context.Context, phase *PhaseSpec, beadID, prompt string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	timeout := wg.attemptTimeout(phase.ID, exec.Timeout)
+	if timeout <= 0 {
+		return wg.Runner.RunExistingPhase(ctx, phase.ID, beadID, phase.Title, prompt, exec)
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := wg.Runner.RunExistingPhase(runCtx, phase.ID, beadID, phase.Title, prompt, exec)
+	if err != nil && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: phase %q ran longer than %s: %w", ErrPhaseTimeout, phase.ID, timeout, err)
+	}
+	return result, err
+}
+
+// attemptTimeout returns the timeout for the next run of phaseID. The run
+// after a timeout retry gets twice the configured timeout: a phase that hit
+// the limit for a real reason, such as a slow test suite, would only time
+// out again with the same one.
+func (wg *WorkerGroup) attemptTimeout(phaseID string, timeout time.Duration) time.Duration {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.timeoutRetried[phaseID] {
+		return 2 * timeout
+	}
+	return timeout
+}
+
+// handleTimeout applies the phase's timeout action after a run timed out.
+// It returns true when the phase was settled (skipped) or re-queued
+// (retried), and false when the timeout should be recorded as a failure.
+func (wg *WorkerGroup) handleTimeout(phase *PhaseSpec, ps *PhaseState, exec ResolvedExecution, result *PhaseRunnerResult, done, failed, inFlight map[string]bool) bool {
+	action := exec.TimeoutAction
+	if action == "" {
+		action = TimeoutActionFail
+	}
+	timeout := wg.attemptTimeout(phase.ID, exec.Timeout)
+	wg.mu.Lock()
+	if action == TimeoutActionRetry && wg.timeoutRetried[phase.ID] {
+		action = TimeoutActionFail // the one retry has been used
+	}
+	if wg.timeoutActions == nil {
+		wg.timeoutActions = make(map[string]TimeoutAction)
+	}
+	wg.timeoutActions[phase.ID] = action
+	wg.mu.Unlock()
+	wg.audit(AuditRecord{
+		Event: AuditGateDecision, Phase: phase.ID, Action: string(action), Actor: AuditActorAuto,
+		Detail: fmt.Sprintf("timed out after %s", timeout),
+	})
+
+	switch action {
+	case TimeoutActionSkip:
+		fmt.Fprintf(wg.logger(), "phase %q timed out after %s; skipping it so its dependents can run\n", phase.ID, timeout)
+		wg.mu.Lock()
+		ps.DoneReason = DoneReasonTimedOut
+		wg.mu.Unlock()
+		wg.recordResult(phase.ID, ps, result, nil, done, failed, inFlight, nil)
+		if wg.OnSkip != nil {
+			wg.OnSkip(phase.ID, fmt.Sprintf("timed out after %s", timeout))
+		}
+		return true
+	case TimeoutActionRetry:
+		fmt.Fprintf(wg.logger(), "phase %q timed out after %s; retrying once with a %s timeout and $%.2f more budget\n", phase.ID, timeout, 2*timeout, wg.budgetStep())
+		wg.mu.Lock()
+		if wg.timeoutRetried == nil {
+			wg.timeoutRetried = make(map[string]bool)
+		}
+		wg.timeoutRetried[phase.ID] = true
+		wg.mu.Unlock()
+		wg.requeueWithMoreBudget(phase.ID, ps, wg.budgetStep(), result)
+		return true
+	}
+	return false
+}
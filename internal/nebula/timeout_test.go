@@ -0,0 +1,159 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimeoutErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		phase PhaseSpec
+		want  int
+	}{
+		{"unset", PhaseSpec{ID: "a"}, 0},
+		{"valid", PhaseSpec{ID: "a", Timeout: "30m", TimeoutAction: TimeoutActionSkip}, 0},
+		{"zero disables", PhaseSpec{ID: "a", Timeout: "0"}, 0},
+		{"malformed duration", PhaseSpec{ID: "a", Timeout: "soon"}, 1},
+		{"negative duration", PhaseSpec{ID: "a", Timeout: "-5m"}, 1},
+		{"unknown action", PhaseSpec{ID: "a", TimeoutAction: "ignore"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := timeoutErrors(tt.phase)
+			if len(errs) != tt.want {
+				t.Fatalf("got %d errors (%v), want %d", len(errs), errs, tt.want)
+			}
+			for _, e := range errs {
+				if !errors.Is(e.Err, ErrInvalidTimeout) || e.Category != ValCatInvalidTimeout {
+					t.Errorf("error = %+v, want ErrInvalidTimeout", e)
+				}
+			}
+		})
+	}
+
+	if errs := executionTimeoutErrors(Execution{PhaseTimeout: "1h", TimeoutAction: TimeoutActionRetry}); len(errs) != 0 {
+		t.Errorf("valid execution: %v", errs)
+	}
+	if errs := executionTimeoutErrors(Execution{PhaseTimeout: "x", TimeoutAction: "later"}); len(errs) != 2 {
+		t.Errorf("invalid execution: got %d errors, want 2", len(errs))
+	}
+}
+
+func TestResolveExecutionTimeout(t *testing.T) {
+	t.Parallel()
+
+	neb := &Execution{PhaseTimeout: "1h", TimeoutAction: TimeoutActionSkip}
+	r := ResolveExecution(0, 0, "", neb, &PhaseSpec{ID: "a"}, nil, nil)
+	if r.Timeout != time.Hour || r.TimeoutAction != TimeoutActionSkip {
+		t.Errorf("inherited = %s/%q, want 1h/skip", r.Timeout, r.TimeoutAction)
+	}
+	r = ResolveExecution(0, 0, "", neb, &PhaseSpec{ID: "a", Timeout: "0", TimeoutAction: TimeoutActionRetry}, nil, nil)
+	if r.Timeout != 0 || r.TimeoutAction != TimeoutActionRetry {
+		t.Errorf("overridden = %s/%q, want 0/retry", r.Timeout, r.TimeoutAction)
+	}
+}
+
+// hangingRunner blocks phase "slow" until its context ends and completes
+// every other phase immediately.
+type hangingRunner struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *hangingRunner) RunExistingPhase(ctx context.Context, phaseID, _, _, _ string, _ ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, phaseID)
+	r.mu.Unlock()
+	if phaseID == "slow" {
+		<-ctx.Done()
+		return &PhaseRunnerResult{TotalCostUSD: 0.1}, ctx.Err()
+	}
+	return &PhaseRunnerResult{TotalCostUSD: 0.1}, nil
+}
+
+func (r *hangingRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestWorkerGroupTimeoutAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		phase      TimeoutAction // set on the phase
+		flag       TimeoutAction // set on the worker group
+		wantStatus PhaseStatus
+		wantCalls  []string
+		wantAction TimeoutAction
+	}{
+		{"default fails", "", "", PhaseStatusFailed, []string{"slow"}, TimeoutActionFail},
+		{"skip lets dependents run", TimeoutActionSkip, "", PhaseStatusDone, []string{"slow", "next"}, TimeoutActionSkip},
+		{"retry once then fail", TimeoutActionRetry, "", PhaseStatusFailed, []string{"slow", "slow"}, TimeoutActionFail},
+		{"flag applies when phase is unset", "", TimeoutActionSkip, PhaseStatusDone, []string{"slow", "next"}, TimeoutActionSkip},
+		{"phase beats flag", TimeoutActionFail, TimeoutActionSkip, PhaseStatusFailed, []string{"slow"}, TimeoutActionFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}},
+				Phases: []PhaseSpec{
+					{ID: "slow", Title: "Slow", Body: "slow", Timeout: "20ms", TimeoutAction: tt.phase},
+					{ID: "next", Title: "Next", Body: "next", DependsOn: []string{"slow"}},
+				},
+			}
+			state := &State{
+				Version: 1,
+				Phases: map[string]*PhaseState{
+					"slow": {BeadID: "bead-slow", Status: PhaseStatusCreated},
+					"next": {BeadID: "bead-next", Status: PhaseStatusCreated},
+				},
+			}
+			runner := &hangingRunner{}
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithTimeoutAction(tt.flag))
+
+			results, err := wg.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got := state.Phases["slow"].Status; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+			runner.mu.Lock()
+			calls := runner.calls
+			runner.mu.Unlock()
+			if len(calls) != len(tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			var slow *WorkerResult
+			for i := range results {
+				if results[i].PhaseID == "slow" {
+					slow = &results[i]
+				}
+			}
+			if slow == nil || slow.TimeoutAction != tt.wantAction {
+				t.Fatalf("slow result = %+v, want timeout action %q", slow, tt.wantAction)
+			}
+			if tt.wantStatus == PhaseStatusDone {
+				if state.Phases["slow"].DoneReason != DoneReasonTimedOut || slow.Err != nil {
+					t.Errorf("skipped phase: done reason %q, err %v", state.Phases["slow"].DoneReason, slow.Err)
+				}
+			} else if !errors.Is(slow.Err, ErrPhaseTimeout) {
+				t.Errorf("err = %v, want ErrPhaseTimeout", slow.Err)
+			}
+			// The retry runs with twice the timeout.
+			if tt.phase == TimeoutActionRetry && !strings.Contains(slow.Err.Error(), "longer than 40ms") {
+				t.Errorf("err after retry = %v, want the doubled 40ms timeout", slow.Err)
+			}
+		})
+	}
+}
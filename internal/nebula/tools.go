@@ -0,0 +1,37 @@
+package nebula
+
+import (
+	"fmt"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+)
+
+// toolErrors reports allowed_tools and denied_tools entries of p that name
+// no known tool, and an allowed_tools list that is present but empty, which
+// would leave the coder without tools.
+func toolErrors(p PhaseSpec) []ValidationError {
+	var errs []ValidationError
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidTool,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      field,
+			Err:        fmt.Errorf("%w: "+format, append([]any{ErrInvalidTool}, args...)...),
+		})
+	}
+	if p.AllowedTools != nil && len(p.AllowedTools) == 0 {
+		invalid("allowed_tools", "allowed_tools is empty; omit it to keep the default tools")
+	}
+	for _, list := range []struct {
+		field string
+		tools []string
+	}{{"allowed_tools", p.AllowedTools}, {"denied_tools", p.DeniedTools}} {
+		for _, t := range list.tools {
+			if !agent.IsKnownTool(t) {
+				invalid(list.field, "%q is not a known tool", t)
+			}
+		}
+	}
+	return errs
+}
@@ -1,6 +1,8 @@
 package nebula
 
 import (
+	"fmt"
+
 	"github.com/papapumpkin/quasar/internal/dag"
 )
 
@@ -109,7 +111,7 @@ func (pt *PhaseTracker) hasScopeConflictWithInFlight(phaseID string) bool {
 		if flySpec == nil || len(flySpec.Scope) == 0 || flySpec.AllowScopeOverlap {
 			continue
 		}
-		if _, _, overlaps := scopesOverlap(spec.Scope, flySpec.Scope); overlaps {
+		if _, _, overlaps := scopesOverlap(spec.rootedScope(), flySpec.rootedScope()); overlaps {
 			return true
 		}
 	}
@@ -128,16 +130,19 @@ func (pt *PhaseTracker) hasScopeConflictWith(phaseID string, ids []string) bool
 		if otherSpec == nil || len(otherSpec.Scope) == 0 || otherSpec.AllowScopeOverlap {
 			continue
 		}
-		if _, _, overlaps := scopesOverlap(spec.Scope, otherSpec.Scope); overlaps {
+		if _, _, overlaps := scopesOverlap(spec.rootedScope(), otherSpec.rootedScope()); overlaps {
 			return true
 		}
 	}
 	return false
 }
 
-// MarkRemainingSkipped sets all pending/created phases to skipped status.
+// MarkRemainingSkipped sets all pending/created phases to skipped status and
+// returns their IDs. Each records reason as its SkipReason, unless one of its
+// direct dependencies failed, which is reported instead.
 // Must be called with the WorkerGroup mutex held.
-func (pt *PhaseTracker) MarkRemainingSkipped(phases []PhaseSpec, state *State) {
+func (pt *PhaseTracker) MarkRemainingSkipped(phases []PhaseSpec, state *State, reason string) []string {
+	var skipped []string
 	for _, phase := range phases {
 		if pt.done[phase.ID] {
 			continue
@@ -148,6 +153,15 @@ func (pt *PhaseTracker) MarkRemainingSkipped(phases []PhaseSpec, state *State) {
 		}
 		if ps.Status == PhaseStatusPending || ps.Status == PhaseStatusCreated {
 			state.SetPhaseState(phase.ID, ps.BeadID, PhaseStatusSkipped)
+			ps.SkipReason = reason
+			for _, dep := range phase.DependsOn {
+				if pt.failed[dep] {
+					ps.SkipReason = fmt.Sprintf("dependency %q failed", dep)
+					break
+				}
+			}
+			skipped = append(skipped, phase.ID)
 		}
 	}
+	return skipped
 }
@@ -0,0 +1,93 @@
+package nebula
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// minTuningRuns is the number of previous runs required before
+// SuggestMaxWorkers makes a recommendation.
+const minTuningRuns = 3
+
+// tuningCoverage is the fraction of observed wave wall-clock time a
+// suggested worker count must cover. Waves above it are treated as rare.
+const tuningCoverage = 0.9
+
+// parallelismSample is one wave's parallelism weighted by its duration.
+type parallelismSample struct {
+	level  int
+	weight time.Duration
+}
+
+// SuggestMaxWorkers recommends a MaxWorkers value from recorded run metrics.
+// It compares the effective parallelism waves actually achieved against the
+// configured limit, weighting each wave by its duration. It returns 0 and an
+// empty explanation when fewer than minTuningRuns runs are in history or the
+// metrics carry no wave data or configured limit.
+func SuggestMaxWorkers(history []HistorySummary, metrics []*Metrics) (int, string) {
+	if len(history) < minTuningRuns {
+		return 0, ""
+	}
+
+	configured := 0
+	var effective, demand []parallelismSample
+	for _, m := range metrics {
+		if m == nil {
+			continue
+		}
+		if m.MaxWorkers > configured {
+			configured = m.MaxWorkers
+		}
+		for _, w := range m.Waves {
+			weight := w.TotalDuration
+			if weight <= 0 {
+				weight = time.Nanosecond // unknown duration: count the wave, not its length
+			}
+			effective = append(effective, parallelismSample{level: w.EffectiveParallelism, weight: weight})
+			demand = append(demand, parallelismSample{level: w.PhaseCount, weight: weight})
+		}
+	}
+	if configured == 0 || len(effective) == 0 {
+		return 0, ""
+	}
+
+	typical := coveringLevel(effective, tuningCoverage)
+	if typical < configured {
+		// One worker of headroom over what waves rarely exceeded.
+		suggested := typical + 1
+		if suggested >= configured {
+			return configured, fmt.Sprintf("waves used up to %d of %d workers; current setting fits", typical, configured)
+		}
+		return suggested, fmt.Sprintf("waves rarely exceeded parallelism %d; reducing from %d to %d won't slow runs", typical, configured, suggested)
+	}
+
+	// Waves saturated the limit — raise it if more phases were ready.
+	if wanted := coveringLevel(demand, tuningCoverage); wanted > configured {
+		return wanted, fmt.Sprintf("waves saturated all %d workers with up to %d phases ready; raising to %d may shorten runs", configured, wanted, wanted)
+	}
+	return configured, fmt.Sprintf("waves used all %d workers; current setting fits", configured)
+}
+
+// coveringLevel returns the smallest parallelism level such that waves at or
+// below it account for at least frac of the total sample weight.
+func coveringLevel(samples []parallelismSample, frac float64) int {
+	sorted := make([]parallelismSample, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].level < sorted[j].level })
+
+	var total time.Duration
+	for _, s := range sorted {
+		total += s.weight
+	}
+	threshold := time.Duration(float64(total) * frac)
+
+	var acc time.Duration
+	for _, s := range sorted {
+		acc += s.weight
+		if acc >= threshold {
+			return s.level
+		}
+	}
+	return sorted[len(sorted)-1].level
+}
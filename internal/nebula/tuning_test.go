@@ -0,0 +1,104 @@
+package nebula
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuggestMaxWorkers(t *testing.T) {
+	t.Parallel()
+
+	history := make([]HistorySummary, minTuningRuns)
+	wave := func(effective, phases int, d time.Duration) WaveMetrics {
+		return WaveMetrics{EffectiveParallelism: effective, PhaseCount: phases, TotalDuration: d}
+	}
+
+	tests := []struct {
+		name     string
+		history  []HistorySummary
+		metrics  []*Metrics
+		want     int
+		wantText string
+	}{
+		{
+			name:    "not enough history",
+			history: history[:minTuningRuns-1],
+			metrics: []*Metrics{{MaxWorkers: 8, Waves: []WaveMetrics{wave(2, 2, time.Minute)}}},
+			want:    0,
+		},
+		{
+			name:    "no configured limit",
+			history: history,
+			metrics: []*Metrics{{Waves: []WaveMetrics{wave(2, 2, time.Minute)}}},
+			want:    0,
+		},
+		{
+			name:    "nil metrics",
+			history: history,
+			metrics: []*Metrics{nil},
+			want:    0,
+		},
+		{
+			name:    "over-provisioned",
+			history: history,
+			metrics: []*Metrics{{MaxWorkers: 8, Waves: []WaveMetrics{
+				wave(3, 3, 10*time.Minute),
+				wave(2, 2, 10*time.Minute),
+				wave(7, 7, time.Minute), // rare spike
+			}}},
+			want:     4,
+			wantText: "rarely exceeded parallelism 3; reducing from 8 to 4",
+		},
+		{
+			name:     "fits with headroom",
+			history:  history,
+			metrics:  []*Metrics{{MaxWorkers: 4, Waves: []WaveMetrics{wave(3, 3, time.Minute)}}},
+			want:     4,
+			wantText: "current setting fits",
+		},
+		{
+			name:    "saturated with more ready phases",
+			history: history,
+			metrics: []*Metrics{{MaxWorkers: 2, Waves: []WaveMetrics{
+				wave(2, 5, 5*time.Minute),
+				wave(2, 4, 5*time.Minute),
+			}}},
+			want:     5,
+			wantText: "raising to 5",
+		},
+		{
+			name:     "saturated with no extra demand",
+			history:  history,
+			metrics:  []*Metrics{{MaxWorkers: 2, Waves: []WaveMetrics{wave(2, 2, time.Minute)}}},
+			want:     2,
+			wantText: "used all 2 workers",
+		},
+		{
+			name:    "zero durations count waves equally",
+			history: history,
+			metrics: []*Metrics{{MaxWorkers: 6, Waves: []WaveMetrics{
+				wave(1, 1, 0), wave(1, 1, 0), wave(1, 1, 0), wave(1, 1, 0), wave(1, 1, 0),
+				wave(1, 1, 0), wave(1, 1, 0), wave(1, 1, 0), wave(1, 1, 0), wave(6, 6, 0),
+			}}},
+			want:     2,
+			wantText: "reducing from 6 to 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, text := SuggestMaxWorkers(tt.history, tt.metrics)
+			if got != tt.want {
+				t.Errorf("SuggestMaxWorkers() = %d, want %d (%q)", got, tt.want, text)
+			}
+			if tt.wantText == "" && text != "" {
+				t.Errorf("explanation = %q, want empty", text)
+			}
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("explanation = %q, want substring %q", text, tt.wantText)
+			}
+		})
+	}
+}
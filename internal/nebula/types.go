@@ -8,24 +8,79 @@ import (
 
 // Manifest is parsed from nebula.toml in the nebula directory root.
 type Manifest struct {
-	Nebula       Info         `toml:"nebula"`
-	Defaults     Defaults     `toml:"defaults"`
-	Execution    Execution    `toml:"execution"`
-	Context      Context      `toml:"context"`
-	Dependencies Dependencies `toml:"dependencies"`
+	Version       int                `toml:"version"` // Layout version; see CurrentManifestVersion.
+	Nebula        Info               `toml:"nebula"`
+	Defaults      Defaults           `toml:"defaults"`
+	Execution     Execution          `toml:"execution"`
+	Context       Context            `toml:"context"`
+	Dependencies  Dependencies       `toml:"dependencies"`
+	AgentProfiles map[string]Profile `toml:"agent_profiles"` // Keyed by phase assignee.
+	AllowedLabels []string           `toml:"allowed_labels"` // When set, the only labels phases may use.
+}
+
+// Profile overrides the agent configuration for phases whose assignee matches
+// the profile's key in Manifest.AgentProfiles. Empty fields keep the defaults.
+type Profile struct {
+	Model          string   `toml:"model"`
+	CoderPrompt    string   `toml:"coder_prompt"`
+	ReviewerPrompt string   `toml:"reviewer_prompt"`
+	CoderTools     []string `toml:"coder_tools"`    // Replaces the coder's allowed tools.
+	ReviewerTools  []string `toml:"reviewer_tools"` // Replaces the reviewer's allowed tools.
 }
 
 // Execution holds default execution parameters for the nebula.
 type Execution struct {
-	MaxWorkers       int        `toml:"max_workers"`
-	MaxReviewCycles  int        `toml:"max_review_cycles"`
-	MaxBudgetUSD     float64    `toml:"max_budget_usd"`
-	MaxContextTokens int        `toml:"max_context_tokens"` // Token budget for context injection. 0 = disabled.
-	Model            string     `toml:"model"`
-	Gate             GateMode   `toml:"gate"`           // Default gate mode for all phases
-	HailTimeout      string     `toml:"hail_timeout"`   // Duration string for hail auto-resolve timeout (e.g. "5m"). Empty = default (5m). "0" = disabled.
-	Routing          TierConfig `toml:"routing"`        // Auto-routing config. Zero-value = disabled.
-	AutoDecompose    bool       `toml:"auto_decompose"` // Enable auto-decomposition on struggle.
+	MaxWorkers       int           `toml:"max_workers"`
+	MaxReviewCycles  int           `toml:"max_review_cycles"`
+	MaxBudgetUSD     float64       `toml:"max_budget_usd"`
+	RetryBudgetUSD   float64       `toml:"retry_budget_usd"`   // Spend allowed across all retries of a phase. 0 = retries share the phase budget.
+	TotalBudgetUSD   float64       `toml:"total_budget_usd"`   // Spend allowed across all phases of one run. 0 = no run-wide cap.
+	MaxContextTokens int           `toml:"max_context_tokens"` // Token budget for context injection. 0 = disabled.
+	Model            string        `toml:"model"`
+	Gate             GateMode      `toml:"gate"`           // Default gate mode for all phases
+	HailTimeout      string        `toml:"hail_timeout"`   // Duration string for hail auto-resolve timeout (e.g. "5m"). Empty = default (5m). "0" = disabled.
+	Routing          TierConfig    `toml:"routing"`        // Auto-routing config. Zero-value = disabled.
+	AutoDecompose    bool          `toml:"auto_decompose"` // Enable auto-decomposition on struggle.
+	OnFailure        FailurePolicy `toml:"on_failure"`     // What a phase failure does to the rest of the run. Empty = continue.
+	// CostSpikeMultiplier pauses the run when a phase costs more than this
+	// many times its expected cost, checked as each phase finishes. 0 = off.
+	CostSpikeMultiplier float64 `toml:"cost_spike_multiplier"`
+	// StaleAction is what to do about a fabric claim or blocked phase that
+	// stays stale. Empty = only warn.
+	StaleAction StaleAction `toml:"stale_action"`
+	// StaleActionAfter is how long an item must be stale before StaleAction
+	// runs (e.g. "20m"). Empty = as soon as it is flagged.
+	StaleActionAfter string `toml:"stale_action_after"`
+	// Checks run after every phase finishes and before its gate. A phase's
+	// own checks replace the ones here with the same name.
+	Checks []Check `toml:"checks"`
+	// PhaseTimeout bounds each run of a phase (e.g. "30m"). Empty or "0" =
+	// no timeout.
+	PhaseTimeout string `toml:"phase_timeout"`
+	// TimeoutAction is what a timed-out phase does. Empty = fail.
+	TimeoutAction TimeoutAction `toml:"timeout_action"`
+}
+
+// FailurePolicy controls how a phase failure affects the rest of a run.
+type FailurePolicy string
+
+const (
+	// FailurePolicyContinue blocks only the failed phase's dependents;
+	// independent phases keep running.
+	FailurePolicyContinue FailurePolicy = "continue"
+	// FailurePolicyAbort stops the whole run on the first phase failure:
+	// in-flight phases finish and everything else is skipped.
+	FailurePolicyAbort FailurePolicy = "abort"
+)
+
+// Valid reports whether f is a recognized policy. Empty is valid and means
+// FailurePolicyContinue.
+func (f FailurePolicy) Valid() bool {
+	switch f {
+	case "", FailurePolicyContinue, FailurePolicyAbort:
+		return true
+	}
+	return false
 }
 
 // DefaultHailTimeout is the built-in fallback for hail auto-resolution timeout.
@@ -54,6 +109,12 @@ type Context struct {
 	WorkingDir  string   `toml:"working_dir"`
 	Goals       []string `toml:"goals"`
 	Constraints []string `toml:"constraints"`
+	// IncludeFiles lists files, relative to the nebula directory, whose
+	// contents are added to every phase prompt after the goals and
+	// constraints. They are read when the nebula is loaded.
+	IncludeFiles []string `toml:"include_files"`
+
+	included []includedFile // contents of IncludeFiles, filled in by Load
 }
 
 // Dependencies declares external prerequisites that must be met before apply.
@@ -62,10 +123,13 @@ type Dependencies struct {
 	RequiresNebulae []string `toml:"requires_nebulae"`
 }
 
-// Info holds the nebula's name and description from the manifest.
+// Info holds the nebula's name and description from the manifest, plus an
+// optional icon and color that set it apart in the TUI.
 type Info struct {
 	Name        string `toml:"name"`
 	Description string `toml:"description"`
+	Icon        string `toml:"icon"`  // emoji or short glyph shown before the name
+	Color       string `toml:"color"` // hex ("#ff8800") or named color; see ColorCode
 }
 
 // Defaults holds fallback values applied to phases that omit those fields.
@@ -94,8 +158,31 @@ type PhaseSpec struct {
 	AllowScopeOverlap bool     `toml:"allow_scope_overlap"`      // Override: permit overlap
 	Decomposed        bool     `toml:"decomposed,omitempty"`     // true if this phase was produced by auto-decomposition
 	AutoDecompose     *bool    `toml:"auto_decompose,omitempty"` // per-phase override (nil = inherit from manifest)
+	Import            string   `toml:"import,omitempty"`         // nebula directory to expand in place of this phase
+	Artifacts         []string `toml:"artifacts"`                // Glob paths (relative to the working dir) collected after success
+	WorkingDir        string   `toml:"working_dir"`              // Directory relative to the nebula working dir ("" = nebula default)
+	RetryBudgetUSD    float64  `toml:"retry_budget_usd"`         // 0 = use default
+	AllowedTools      []string `toml:"allowed_tools"`            // Replaces the coder's allowed tools (nil = profile or built-in set)
+	DeniedTools       []string `toml:"denied_tools"`             // Tools neither agent may use in this phase
+	Checks            []Check  `toml:"checks"`                   // Run before the gate, after the nebula's execution.checks
+	SkipReview        bool     `toml:"skip_review"`              // Approve on the coder's pass without invoking the reviewer
+	Timeout           string   `toml:"timeout"`                  // Bound on each run of the phase ("" = execution.phase_timeout, "0" = none)
+	EstimatedMinutes  int      `toml:"estimated_minutes"`        // Expected duration for the ETA until a run is recorded (0 = none)
 	Body              string   // Markdown body after +++ block
 	SourceFile        string   // Relative path for error context
+
+	// TimeoutAction is what a timed-out run of this phase does ("" =
+	// --phase-timeout-action, else execution.timeout_action).
+	TimeoutAction TimeoutAction `toml:"timeout_action"`
+
+	// Params are default values for ${params.NAME} references in Body,
+	// overridden by --param at launch. RequiresParams names params that
+	// must have a value before the run starts.
+	Params         map[string]string `toml:"params"`
+	RequiresParams []string          `toml:"requires_params"`
+
+	undefinedVars []string // ${VAR} references in Body with no value or default
+	group         string   // ID of the import phase this phase was expanded from ("" = not imported)
 }
 
 // Nebula is the fully parsed representation of a nebula directory.
@@ -148,6 +235,9 @@ func (n *Nebula) Snapshot() *Nebula {
 			if p.Blocks != nil {
 				cp.Phases[i].Blocks = append([]string{}, p.Blocks...)
 			}
+			if p.Artifacts != nil {
+				cp.Phases[i].Artifacts = append([]string{}, p.Artifacts...)
+			}
 			if p.AutoDecompose != nil {
 				v := *p.AutoDecompose
 				cp.Phases[i].AutoDecompose = &v
@@ -207,8 +297,46 @@ type PhaseState struct {
 	CreatedAt time.Time           `toml:"created_at"`
 	UpdatedAt time.Time           `toml:"updated_at"`
 	Report    *agent.ReviewReport `toml:"report,omitempty"`
+	// SkipReason explains why a skipped phase never ran, e.g. a failed
+	// dependency or a run stopped at another phase's gate.
+	SkipReason string `toml:"skip_reason,omitempty"`
+	// Attempts counts finished runs of the phase; every run after the
+	// first is a retry.
+	Attempts int `toml:"attempts,omitempty"`
+	// AttemptLog records each finished run of the phase, oldest first.
+	AttemptLog []AttemptRecord `toml:"attempt_log,omitempty"`
+	// RetrySpentUSD is the cost of the phase's retries, drawn from its
+	// retry budget rather than its phase budget.
+	RetrySpentUSD float64 `toml:"retry_spent_usd,omitempty"`
+	// CostUSD is what every run of the phase has cost, retries included.
+	CostUSD float64 `toml:"cost_usd,omitempty"`
+	// DoneReason explains how a done phase finished when it did not run,
+	// e.g. DoneReasonCached.
+	DoneReason string `toml:"done_reason,omitempty"`
+	// Preserved is where the work left by the phase's last failed run was
+	// kept, with WorkerGroup.PreserveFailedWorktrees; cleared on success.
+	Preserved string `toml:"preserved,omitempty"`
+}
+
+// AttemptRecord is one finished run of a phase.
+type AttemptRecord struct {
+	Cycles  int       `toml:"cycles"`          // review cycles the run used
+	CostUSD float64   `toml:"cost_usd"`        // what the run cost
+	Model   string    `toml:"model,omitempty"` // model the coder ran with; "" for the default
+	Outcome string    `toml:"outcome"`         // one of the AttemptOutcome constants
+	At      time.Time `toml:"at"`              // when the run finished
 }
 
+// Outcomes of a phase run, as recorded in AttemptRecord.Outcome.
+const (
+	AttemptOutcomeDone       = "done"
+	AttemptOutcomeDecomposed = "decomposed"
+	AttemptOutcomeFailed     = "failed"
+	AttemptOutcomeTimedOut   = "timed out"
+	AttemptOutcomeOverBudget = "over budget"
+	AttemptOutcomeCanceled   = "canceled"
+)
+
 // ActionType describes what apply will do for a phase.
 type ActionType string
 
@@ -218,6 +346,7 @@ const (
 	ActionSkip   ActionType = "skip"
 	ActionClose  ActionType = "close"
 	ActionRetry  ActionType = "retry"
+	ActionRename ActionType = "rename"
 )
 
 // Action is a single planned change.
@@ -225,6 +354,7 @@ type Action struct {
 	PhaseID string
 	Type    ActionType
 	Reason  string // Human-readable explanation
+	From    string // Previous phase ID whose bead the phase takes over (ActionRename only)
 }
 
 // Plan is the diff between desired nebula state and actual beads state.
@@ -235,8 +365,29 @@ type Plan struct {
 
 // WorkerResult records the outcome of a single worker execution.
 type WorkerResult struct {
-	PhaseID string
-	BeadID  string
-	Err     error
-	Report  *agent.ReviewReport
+	PhaseID   string
+	BeadID    string
+	Err       error
+	Report    *agent.ReviewReport
+	Artifacts []string // paths of the artifacts collected for the phase
+	// RetrySpentUSD is what retries of the phase have cost so far.
+	RetrySpentUSD float64
+	// Cached is set when the phase was completed from the phase cache.
+	Cached bool
+	// Preserved is where the work a failed phase left behind was kept (a
+	// branch, or a snapshot outside git); empty when it was not kept.
+	Preserved string
+	// TimeoutAction is what was done when the phase's last run timed out;
+	// empty when it did not time out.
+	TimeoutAction TimeoutAction
+
+	// Failure details, set only on failed results.
+	//
+	// Findings are the reviewer's findings from the phase's last reviewed
+	// cycle. Blocked and Skipped list the dependents, direct or transitive,
+	// that did not run because the phase failed: Blocked were left pending
+	// and run once it succeeds; Skipped were marked skipped.
+	Findings []DecomposeFinding
+	Blocked  []string
+	Skipped  []string
 }
@@ -34,6 +34,7 @@ func TestNebulaSnapshot(t *testing.T) {
 					Labels:    []string{"l1"},
 					Scope:     []string{"s1", "s2"},
 					Blocks:    []string{"b1"},
+					Artifacts: []string{"cover.out"},
 				},
 			},
 		}
@@ -45,6 +46,7 @@ func TestNebulaSnapshot(t *testing.T) {
 		n.Phases[0].Labels[0] = "CHANGED"
 		n.Phases[0].Scope[0] = "CHANGED"
 		n.Phases[0].Blocks[0] = "CHANGED"
+		n.Phases[0].Artifacts[0] = "CHANGED"
 
 		if snap.Phases[0].DependsOn[0] != "x" {
 			t.Errorf("DependsOn[0] = %q, want %q", snap.Phases[0].DependsOn[0], "x")
@@ -58,6 +60,9 @@ func TestNebulaSnapshot(t *testing.T) {
 		if snap.Phases[0].Blocks[0] != "b1" {
 			t.Errorf("Blocks[0] = %q, want %q", snap.Phases[0].Blocks[0], "b1")
 		}
+		if snap.Phases[0].Artifacts[0] != "cover.out" {
+			t.Errorf("Artifacts[0] = %q, want %q", snap.Phases[0].Artifacts[0], "cover.out")
+		}
 	})
 
 	t.Run("nil Phases", func(t *testing.T) {
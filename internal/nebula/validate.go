@@ -3,6 +3,8 @@ package nebula
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/papapumpkin/quasar/internal/dag"
 )
@@ -21,6 +23,8 @@ func Validate(n *Nebula) []ValidationError {
 		})
 	}
 
+	errs = append(errs, identityErrors(n.Manifest.Nebula)...)
+
 	seen := make(map[string]string) // id → source file
 	ids := make(map[string]bool)
 
@@ -58,6 +62,8 @@ func Validate(n *Nebula) []ValidationError {
 		ids[p.ID] = true
 	}
 
+	errs = append(errs, namespaceCollisionErrors(n.Phases)...)
+
 	// Validate dependencies reference known IDs.
 	for _, p := range n.Phases {
 		for _, dep := range p.DependsOn {
@@ -83,12 +89,30 @@ func Validate(n *Nebula) []ValidationError {
 			Err:        fmt.Errorf("execution.max_review_cycles must be >= 0, got %d", exec.MaxReviewCycles),
 		})
 	}
-	if exec.MaxBudgetUSD < 0 {
+	for _, b := range []struct {
+		field string
+		usd   float64
+	}{
+		{"max_budget_usd", exec.MaxBudgetUSD},
+		{"total_budget_usd", exec.TotalBudgetUSD},
+		{"retry_budget_usd", exec.RetryBudgetUSD},
+	} {
+		if b.usd < 0 {
+			errs = append(errs, ValidationError{
+				Category:   ValCatBoundsViolation,
+				SourceFile: "nebula.toml",
+				Field:      "execution." + b.field,
+				Err:        fmt.Errorf("execution.%s must be >= 0, got %f", b.field, b.usd),
+			})
+		}
+	}
+
+	if m := exec.CostSpikeMultiplier; m != 0 && m <= 1 {
 		errs = append(errs, ValidationError{
 			Category:   ValCatBoundsViolation,
 			SourceFile: "nebula.toml",
-			Field:      "execution.max_budget_usd",
-			Err:        fmt.Errorf("execution.max_budget_usd must be >= 0, got %f", exec.MaxBudgetUSD),
+			Field:      "execution.cost_spike_multiplier",
+			Err:        fmt.Errorf("execution.cost_spike_multiplier must be 0 (off) or > 1, got %g", m),
 		})
 	}
 
@@ -105,6 +129,32 @@ func Validate(n *Nebula) []ValidationError {
 		})
 	}
 
+	if !exec.OnFailure.Valid() {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidFailurePolicy,
+			SourceFile: "nebula.toml",
+			Field:      "execution.on_failure",
+			Err:        fmt.Errorf("%w: %q (want %q or %q)", ErrInvalidFailurePolicy, exec.OnFailure, FailurePolicyContinue, FailurePolicyAbort),
+		})
+	}
+
+	if !exec.StaleAction.Valid() {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidStaleAction,
+			SourceFile: "nebula.toml",
+			Field:      "execution.stale_action",
+			Err:        fmt.Errorf("%w: %q (want %q, %q, or %q)", ErrInvalidStaleAction, exec.StaleAction, StaleActionHail, StaleActionComment, StaleActionPing),
+		})
+	}
+	if _, err := exec.ParsedStaleActionAfter(); err != nil {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidStaleAction,
+			SourceFile: "nebula.toml",
+			Field:      "execution.stale_action_after",
+			Err:        fmt.Errorf("%w: %v", ErrInvalidStaleAction, err),
+		})
+	}
+
 	// Validate per-phase execution overrides.
 	for _, p := range n.Phases {
 		if p.MaxReviewCycles < 0 {
@@ -125,6 +175,15 @@ func Validate(n *Nebula) []ValidationError {
 				Err:        fmt.Errorf("max_budget_usd must be >= 0, got %f", p.MaxBudgetUSD),
 			})
 		}
+		if p.RetryBudgetUSD < 0 {
+			errs = append(errs, ValidationError{
+				Category:   ValCatBoundsViolation,
+				PhaseID:    p.ID,
+				SourceFile: p.SourceFile,
+				Field:      "retry_budget_usd",
+				Err:        fmt.Errorf("retry_budget_usd must be >= 0, got %f", p.RetryBudgetUSD),
+			})
+		}
 		if p.Gate != "" && !ValidGateModes[p.Gate] {
 			errs = append(errs, ValidationError{
 				Category:   ValCatInvalidGate,
@@ -134,7 +193,16 @@ func Validate(n *Nebula) []ValidationError {
 				Err:        fmt.Errorf("%w: %q", ErrInvalidGate, p.Gate),
 			})
 		}
+		for _, check := range []func(PhaseSpec) []ValidationError{undefinedVarErrors, artifactErrors, workingDirErrors, toolErrors, manualPhaseErrors, skipReviewErrors, timeoutErrors, paramErrors, estimateErrors} {
+			errs = append(errs, check(p)...)
+		}
+		errs = append(errs, checkErrors(p.Checks, p.ID, p.SourceFile, "checks")...)
 	}
+	errs = append(errs, checkErrors(n.Manifest.Execution.Checks, "", "nebula.toml", "execution.checks")...)
+	errs = append(errs, executionTimeoutErrors(n.Manifest.Execution)...)
+
+	errs = append(errs, profileErrors(n.Manifest.AgentProfiles)...)
+	errs = append(append(errs, includeFileErrors(n)...), labelErrors(n)...)
 
 	// Validate dependency entries are non-empty strings.
 	for _, dep := range n.Manifest.Dependencies.RequiresBeads {
@@ -180,6 +248,65 @@ func Validate(n *Nebula) []ValidationError {
 	return errs
 }
 
+// undefinedVarErrors reports each ${VAR} reference in the phase body that had
+// no value and no default when the phase file was parsed.
+func undefinedVarErrors(p PhaseSpec) []ValidationError {
+	var errs []ValidationError
+	for _, name := range p.undefinedVars {
+		errs = append(errs, ValidationError{
+			Category:   ValCatUndefinedVar,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      "body",
+			Err:        fmt.Errorf("%w: ${%s} (set it in the environment or %s, or use ${%s:-default})", ErrUndefinedVar, name, envFileName, name),
+		})
+	}
+	return errs
+}
+
+// namespaceCollisionErrors reports phases whose IDs fall inside an import
+// group's namespace (or equal the group ID) without having been produced by
+// that import, so namespaced dependencies stay unambiguous.
+func namespaceCollisionErrors(phases []PhaseSpec) []ValidationError {
+	groups := make(map[string]bool)
+	for _, p := range phases {
+		for g := p.group; g != ""; {
+			groups[g] = true
+			i := strings.LastIndex(g, importSeparator)
+			if i < 0 {
+				break
+			}
+			g = g[:i]
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	sorted := make([]string, 0, len(groups))
+	for g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Strings(sorted)
+
+	var errs []ValidationError
+	for _, p := range phases {
+		for _, g := range sorted {
+			if !inImportNamespace(p.ID, g) || (p.group != "" && inImportNamespace(p.group, g)) {
+				continue
+			}
+			errs = append(errs, ValidationError{
+				Category:   ValCatNamespaceCollision,
+				PhaseID:    p.ID,
+				SourceFile: p.SourceFile,
+				Field:      "id",
+				Err:        fmt.Errorf("%w: %q is reserved for phases imported by %q", ErrNamespaceCollision, p.ID, g),
+			})
+			break
+		}
+	}
+	return errs
+}
+
 // ValidateHotAdd checks whether a new phase can be safely inserted into a
 // running nebula. It validates required fields, ID uniqueness against the
 // existing registry, and cycle detection against the live graph.
@@ -216,6 +343,18 @@ func ValidateHotAdd(phase PhaseSpec, existingIDs map[string]bool, d *dag.DAG) []
 			Err:        fmt.Errorf("%w: %q", ErrDuplicateID, phase.ID),
 		})
 	}
+	if phase.Import != "" {
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidImport,
+			PhaseID:    phase.ID,
+			SourceFile: phase.SourceFile,
+			Field:      "import",
+			Err:        fmt.Errorf("%w: imports cannot be added to a running nebula", ErrInvalidImport),
+		})
+	}
+	errs = append(errs, undefinedVarErrors(phase)...)
+	errs = append(errs, workingDirErrors(phase)...)
+	errs = append(errs, toolErrors(phase)...)
 	if len(errs) > 0 {
 		return errs
 	}
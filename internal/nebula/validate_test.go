@@ -3,6 +3,7 @@ package nebula
 import (
 	"bytes"
 	"errors"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -187,3 +188,85 @@ func TestCheckHotAddedReady(t *testing.T) {
 		t.Error("expected b to be signaled as ready")
 	}
 }
+
+func TestValidateAgentProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		profiles map[string]Profile
+		want     []string // offending fields
+	}{
+		{"none", nil, nil},
+		{"valid", map[string]Profile{"security": {ReviewerPrompt: "Audit.", ReviewerTools: []string{"Read"}}}, nil},
+		{"empty profile", map[string]Profile{"security": {}}, []string{"agent_profiles.security"}},
+		{"blank name", map[string]Profile{" ": {Model: "m"}}, []string{"agent_profiles. "}},
+		{"empty tool list", map[string]Profile{"qa": {CoderTools: []string{}}}, []string{"agent_profiles.qa.coder_tools"}},
+		{"blank tool", map[string]Profile{"qa": {ReviewerTools: []string{"Read", ""}}}, []string{"agent_profiles.qa.reviewer_tools"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Manifest: Manifest{Nebula: Info{Name: "n"}, AgentProfiles: tt.profiles},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", Assignee: "security"}},
+			}
+			var got []string
+			for _, e := range Validate(n) {
+				if e.Category != ValCatInvalidProfile {
+					t.Errorf("unexpected error: %v", e)
+					continue
+				}
+				if !errors.Is(&e, ErrInvalidProfile) {
+					t.Errorf("error %v does not wrap ErrInvalidProfile", e)
+				}
+				got = append(got, e.Field)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("invalid profile fields = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("field[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePhaseTools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		want    []string // offending fields
+	}{
+		{"unset", nil, nil, nil},
+		{"valid", []string{"Read", "Bash(make deploy)", "mcp__fabric"}, []string{"WebFetch", "Bash"}, nil},
+		{"empty allow list", []string{}, nil, []string{"allowed_tools"}},
+		{"unknown allowed", []string{"Read", "Shell"}, nil, []string{"allowed_tools"}},
+		{"unknown denied", nil, []string{"Network"}, []string{"denied_tools"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Manifest: Manifest{Nebula: Info{Name: "n"}},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", AllowedTools: tt.allowed, DeniedTools: tt.denied}},
+			}
+			var got []string
+			for _, e := range Validate(n) {
+				if e.Category != ValCatInvalidTool || !errors.Is(&e, ErrInvalidTool) {
+					t.Errorf("unexpected error: %v", e)
+					continue
+				}
+				got = append(got, e.Field)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("invalid tool fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,9 @@ const (
 	InterventionResume InterventionKind = "resume"
 	// InterventionRetry indicates the user created a RETRY file for a phase.
 	InterventionRetry InterventionKind = "retry"
+	// InterventionDrain indicates the user created a DRAIN file: finish the
+	// phases already running or eligible, then stop.
+	InterventionDrain InterventionKind = "drain"
 )
 
 // interventionFiles maps filenames to their intervention kinds.
@@ -44,9 +47,10 @@ var interventionFiles = map[string]InterventionKind{
 	"PAUSE": InterventionPause,
 	"STOP":  InterventionStop,
 	"RETRY": InterventionRetry,
+	"DRAIN": InterventionDrain,
 }
 
-// IsInterventionFile reports whether the given filename is an intervention file (PAUSE or STOP).
+// IsInterventionFile reports whether the given filename is an intervention file (PAUSE, STOP, RETRY, or DRAIN).
 func IsInterventionFile(name string) bool {
 	_, ok := interventionFiles[name]
 	return ok
@@ -207,7 +211,7 @@ func (w *Watcher) handleIntervention(event fsnotify.Event) bool {
 			default:
 			}
 		}
-		// Removing other intervention files (STOP, RETRY) is a no-op.
+		// Removing other intervention files (STOP, RETRY, DRAIN) is a no-op.
 		return true
 	}
 
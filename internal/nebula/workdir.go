@@ -0,0 +1,51 @@
+package nebula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// workingDirErrors reports a phase working_dir that is absolute or climbs
+// out of the nebula working directory.
+func workingDirErrors(p PhaseSpec) []ValidationError {
+	if p.WorkingDir == "" || filepath.IsLocal(filepath.FromSlash(p.WorkingDir)) {
+		return nil
+	}
+	return []ValidationError{{
+		Category:   ValCatInvalidWorkingDir,
+		PhaseID:    p.ID,
+		SourceFile: p.SourceFile,
+		Field:      "working_dir",
+		Err:        fmt.Errorf("%w: %q must be a relative path inside the working directory", ErrInvalidWorkingDir, p.WorkingDir),
+	}}
+}
+
+// ValidateWorkingDirs checks that every phase working_dir names an existing
+// directory under root, the directory the nebula runs in. Validate cannot
+// do this because it does not know where the nebula will run.
+func ValidateWorkingDirs(n *Nebula, root string) []ValidationError {
+	var errs []ValidationError
+	for _, p := range n.Phases {
+		if p.WorkingDir == "" || len(workingDirErrors(p)) > 0 {
+			continue
+		}
+		dir := filepath.Join(root, filepath.FromSlash(p.WorkingDir))
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			continue
+		}
+		reason := "is not a directory"
+		if err != nil {
+			reason = "does not exist"
+		}
+		errs = append(errs, ValidationError{
+			Category:   ValCatInvalidWorkingDir,
+			PhaseID:    p.ID,
+			SourceFile: p.SourceFile,
+			Field:      "working_dir",
+			Err:        fmt.Errorf("%w: %s %s", ErrInvalidWorkingDir, dir, reason),
+		})
+	}
+	return errs
+}
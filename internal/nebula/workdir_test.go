@@ -0,0 +1,120 @@
+package nebula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidatePhaseWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dir     string
+		wantErr bool
+	}{
+		{"", false},
+		{"services/api", false},
+		{"./web", false},
+		{"/srv/api", true},
+		{"../sibling", true},
+		{"web/../../x", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Manifest: Manifest{Nebula: Info{Name: "n"}},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", WorkingDir: tt.dir}},
+			}
+			errs := Validate(n)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Fatalf("Validate with working_dir %q = %v, wantErr %v", tt.dir, errs, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(&errs[0], ErrInvalidWorkingDir) {
+				t.Errorf("error %v does not wrap ErrInvalidWorkingDir", errs[0])
+			}
+		})
+	}
+}
+
+func TestValidateWorkingDirs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "services", "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n := &Nebula{Phases: []PhaseSpec{
+		{ID: "default"},
+		{ID: "api", WorkingDir: "services/api"},
+		{ID: "web", WorkingDir: "web"},
+		{ID: "file", WorkingDir: "README"},
+	}}
+
+	errs := ValidateWorkingDirs(n, root)
+	var ids []string
+	for _, e := range errs {
+		if e.Category != ValCatInvalidWorkingDir {
+			t.Errorf("unexpected error: %v", e)
+		}
+		ids = append(ids, e.PhaseID)
+	}
+	if len(ids) != 2 || ids[0] != "web" || ids[1] != "file" {
+		t.Errorf("phases with bad working dirs = %v, want [web file]", ids)
+	}
+}
+
+func TestWorkDirUnder(t *testing.T) {
+	t.Parallel()
+
+	phase := &PhaseSpec{ID: "api", WorkingDir: "services/api"}
+	exec := ResolveExecution(0, 0, "", nil, phase, nil, nil)
+	if got, want := exec.WorkDirUnder("/repo"), filepath.Join("/repo", "services", "api"); got != want {
+		t.Errorf("WorkDirUnder = %q, want %q", got, want)
+	}
+	if got := (ResolvedExecution{}).WorkDirUnder("/repo"); got != "/repo" {
+		t.Errorf("WorkDirUnder without override = %q, want /repo", got)
+	}
+}
+
+func TestScopeOverlap_WorkingDirs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		a, b    PhaseSpec
+		overlap bool
+	}{
+		{
+			name:    "same scope in different dirs",
+			a:       PhaseSpec{ID: "api", WorkingDir: "services/api", Scope: []string{"internal/**"}},
+			b:       PhaseSpec{ID: "web", WorkingDir: "web", Scope: []string{"internal/**"}},
+			overlap: false,
+		},
+		{
+			name:    "root scope covers a phase dir",
+			a:       PhaseSpec{ID: "api", WorkingDir: "services/api", Scope: []string{"handlers"}},
+			b:       PhaseSpec{ID: "root", Scope: []string{"services/**"}},
+			overlap: true,
+		},
+		{
+			name:    "same dir",
+			a:       PhaseSpec{ID: "a", WorkingDir: "web", Scope: []string{"src"}},
+			b:       PhaseSpec{ID: "b", WorkingDir: "web", Scope: []string{"src/app"}},
+			overlap: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, _, got := scopesOverlap(tt.a.rootedScope(), tt.b.rootedScope()); got != tt.overlap {
+				t.Errorf("overlap = %v, want %v", got, tt.overlap)
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/beads"
@@ -23,7 +25,8 @@ func NewWorkerGroup(n *Nebula, state *State, opts ...Option) *WorkerGroup {
 	wg := &WorkerGroup{
 		Nebula:     n,
 		State:      state,
-		MaxWorkers: 1,
+		MaxWorkers:   1,
+		EditDebounce: DefaultEditDebounce,
 	}
 	for _, opt := range opts {
 		opt(wg)
@@ -43,6 +46,8 @@ type WorkerGroup struct {
 	Committer    GitCommitter      // nil = no phase-boundary commits
 	Gater        Gater             // nil = built from Prompter + manifest at Run time
 	Prompter     GatePrompter      // used to build Gater if Gater is nil
+	Budget       BudgetPrompter    // nil = budget exhaustion fails the phase
+	CheckRunner  CheckRunner       // nil = checks run as shell commands
 	Dashboard    *Dashboard        // nil = no dashboard; used to coordinate watch-mode output
 	BeadsClient  beads.Client      // nil = hot-added phases cannot create beads
 	Fabric       fabric.Fabric     // nil = no fabric (legacy behavior)
@@ -51,19 +56,91 @@ type WorkerGroup struct {
 	GlobalCycles int
 	GlobalBudget float64
 	GlobalModel  string
+	BudgetStep   float64                                  // extra USD offered on budget exhaustion; <= 0 uses DefaultBudgetStepUSD
 	OnProgress   ProgressFunc                             // optional progress callback
 	OnRefactor   func(phaseID string, pending bool)       // optional callback for refactor notifications
 	OnHotAdd     HotAddFunc                               // optional callback for hot-added phases
+	OnSkip       func(phaseID, reason string)             // optional callback for phases skipped when a run stops early
 	OnHail       func(phaseID string, d fabric.Discovery) // optional callback for hail surfacing
 	OnScanning   func(phaseID string)                     // optional callback for fabric scanning notifications
+	OnConflict   func(c fabric.FileConflict)              // optional callback for file-level conflicts between phases
+	OnStale      func(items []tycho.StaleItem)            // optional callback when the fabric's stale claims or blocked phases change
+	Notifier     Notifier                                 // optional; pinged on gate prompts, escalation hails, and completion
+	EventSocket  string                                   // optional unix socket path streaming state to `nebula attach`
+	WorkDir      string                                   // directory phase artifact globs resolve against; "" = current directory
+	OutputDir    string                                   // optional; agent output is written to OutputDir/<phaseID>/ as it arrives
+	EditDebounce time.Duration                            // window for coalescing edits to one phase file; <= 0 = no coalescing
+	PausePoll    time.Duration                            // how often a pause re-checks the PAUSE file; <= 0 uses DefaultPausePoll
+	StateBackups int                                      // previous state files rotated on each save; 0 = none
 	Invoker      agent.Invoker                            // optional; required for auto-decomposition
 	Metrics      *Metrics                                 // optional; nil = no collection
 	Logger       io.Writer                                // optional; nil = os.Stderr
-
-	mu          sync.Mutex
-	outputMu    sync.Mutex // serializes checkpoint + dashboard output in watch mode
-	results     []WorkerResult
-	gateSignals []gateSignal // collected after each batch
+	AuditLog     io.Writer                                // optional; receives a JSONL record per significant event
+	// Deterministic makes dispatch reproducible: each batch of eligible
+	// phases must finish before the next is chosen, so completion timing
+	// cannot change which phases run together, and results are reported in
+	// phase ID order.
+	Deterministic bool
+	// PhaseCache skips phases whose inputs match their last successful
+	// run, reusing that run's result. See phase_cache.go.
+	PhaseCache bool
+	// SquashPhaseCommits folds each completed phase's cycle commits into a
+	// single commit. See squash.go.
+	SquashPhaseCommits bool
+	// PreserveFailedWorktrees keeps what a failed phase left in the working
+	// tree on a quasar/failed/<phase> branch. See preserve.go.
+	PreserveFailedWorktrees bool
+	// OnPreserved is called with where a failed phase's work was kept.
+	OnPreserved func(phaseID, location string)
+	// OnAttempt is called with the record of each finished phase run.
+	OnAttempt func(phaseID string, rec AttemptRecord)
+	// OnPhaseReady is called when a phase's dependencies are met and it
+	// becomes eligible for dispatch partway through the run. See ready.go.
+	OnPhaseReady func(phaseID string)
+	// TimeoutAction overrides execution.timeout_action for phases that set
+	// no timeout_action of their own. See timeout.go.
+	TimeoutAction TimeoutAction
+	// Params are the launch params (--param), overriding each phase's own
+	// params defaults. See params.go.
+	Params map[string]string
+	// StaleRemediator acts on stale fabric items in place of the manifest's
+	// stale_action. See stale.go.
+	StaleRemediator tycho.Remediator
+	// DispatchJitter bounds a random delay each dispatched phase waits
+	// before it starts, spreading out the first API calls of a wide wave.
+	// 0 = none. See jitter.go.
+	DispatchJitter time.Duration
+	// MaxRuntime caps the run's wall-clock time: once it passes, no new
+	// phase starts, running phases finish, and Run returns ErrMaxRuntime.
+	// 0 = no limit. See runtime.go.
+	MaxRuntime time.Duration
+	// EscalateLowConfidence raises a hail for an approval the reviewer had
+	// low confidence in and puts its gate to a human. See confidence.go.
+	EscalateLowConfidence bool
+
+	mu           sync.Mutex
+	outputMu     sync.Mutex // serializes checkpoint + dashboard output in watch mode
+	results      []WorkerResult
+	gateSignals  []gateSignal       // collected after each batch
+	abortErr     error              // first phase failure under on_failure = "abort"
+	abortPhase   string             // ID of the phase behind abortErr
+	budgetBumps  map[string]float64 // extra budget granted per phase ID
+	reserved     map[string]float64 // total-budget reservations of dispatched phases; see worker_budget.go
+	runCostBase  float64            // State.TotalCostUSD when Run started; the total budget counts spend from here
+	events       *eventServer       // nil when EventSocket is unset or failed to open
+	openGates    map[string]bool    // phases whose gate awaits a human, the plan gate as PlanPhaseID; replayed in the event snapshot
+	auditor      *auditLog          // nil when AuditLog is unset
+	cache        *phaseCache        // nil when PhaseCache is off or the cache failed to load
+	costBaseline *costBaseline      // previous run's phase costs; nil when the cost spike check is off or has no history
+	squashWarned sync.Once          // the parallel-squash warning prints once
+	lastStale    string             // stale items last passed to OnStale, to report only changes
+	draining     bool               // a DRAIN intervention was seen; see drain.go
+	drainSet     map[string]bool    // phases the drain lets finish; nil until the drain's first dispatch pass
+	readySeen    map[string]bool    // phases eligible on some dispatch pass; nil until the first; see ready.go
+
+	// Timeout handling — see timeout.go.
+	timeoutActions map[string]TimeoutAction // action taken on each phase's last timeout
+	timeoutRetried map[string]bool          // phases that have used their one timeout retry
 
 	// Collaborators — constructed during Run.
 	tracker         *PhaseTracker
@@ -83,6 +160,12 @@ func (wg *WorkerGroup) logger() io.Writer {
 	return os.Stderr
 }
 
+// editDebounce returns the effective edit-coalescing window; 0 applies
+// every edit as soon as it is seen.
+func (wg *WorkerGroup) editDebounce() time.Duration {
+	return max(wg.EditDebounce, 0)
+}
+
 // SnapshotNebula returns a deep copy of the Nebula under the WorkerGroup's
 // mutex, making it safe to call from any goroutine.
 func (wg *WorkerGroup) SnapshotNebula() *Nebula {
@@ -106,10 +189,41 @@ func (wg *WorkerGroup) UnregisterPhaseLoop(phaseID string) {
 	}
 }
 
-// buildPhasePrompt prepends nebula context (goals, constraints) to the phase body.
-func buildPhasePrompt(phase *PhaseSpec, ctx *Context) string {
-	if ctx == nil || (len(ctx.Goals) == 0 && len(ctx.Constraints) == 0) {
-		return phase.Body
+// RefactorPhase re-reads phaseID's source file and queues the updated body
+// as a refactor, exactly as if the Watcher had observed the edit. When a
+// Watcher is active this is a no-op, since the watcher picks up the change
+// on its own. Returns ErrNotRunning before Run has started.
+func (wg *WorkerGroup) RefactorPhase(phaseID string) error {
+	if wg.Watcher != nil {
+		return nil
+	}
+
+	wg.mu.Lock()
+	hr := wg.hotReload
+	var path string
+	for _, p := range wg.Nebula.Phases {
+		if p.ID == phaseID {
+			path = p.SourcePath(wg.Nebula.Dir)
+			break
+		}
+	}
+	wg.mu.Unlock()
+
+	if hr == nil {
+		return ErrNotRunning
+	}
+	if path == "" {
+		return fmt.Errorf("%w: %s", ErrUnknownPhase, phaseID)
+	}
+	return hr.queueRefactor(phaseID, path)
+}
+
+// buildPhasePrompt prepends nebula context (goals, constraints, include
+// files) to the phase body, after expanding its ${params.NAME} references.
+func buildPhasePrompt(phase *PhaseSpec, ctx *Context, params map[string]string) string {
+	body := substituteParams(phase.Body, params)
+	if ctx == nil || (len(ctx.Goals) == 0 && len(ctx.Constraints) == 0 && len(ctx.included) == 0) {
+		return body
 	}
 
 	var sb strings.Builder
@@ -130,8 +244,11 @@ func buildPhasePrompt(phase *PhaseSpec, ctx *Context) string {
 			sb.WriteString("\n")
 		}
 	}
+	for _, f := range ctx.included {
+		fmt.Fprintf(&sb, "\n--- %s ---\n%s\n", f.Path, f.Content)
+	}
 	sb.WriteString("\nPHASE:\n")
-	sb.WriteString(phase.Body)
+	sb.WriteString(body)
 	return sb.String()
 }
 
@@ -150,6 +267,9 @@ func (wg *WorkerGroup) ensureGater() {
 		Mu:        &wg.mu,
 		Dashboard: wg.Dashboard,
 	})
+	if wg.Notifier != nil || wg.events != nil {
+		wg.Gater = notifyingGater{Gater: wg.Gater, wg: wg}
+	}
 }
 
 // gatePlan displays the execution plan and gates it for approval via the Gater.
@@ -170,7 +290,24 @@ func (wg *WorkerGroup) gatePlan(ctx context.Context, d *dag.DAG) error {
 		PhaseTitle: "Execution Plan",
 		NebulaName: wg.Nebula.Manifest.Nebula.Name,
 	}
-	return wg.Gater.PlanGate(ctx, cp)
+	err = wg.Gater.PlanGate(ctx, cp)
+	actor := AuditActorAuto
+	if wg.Prompter != nil && mode == GateModeApprove {
+		actor = AuditActorHuman
+	}
+	action := GateActionAccept
+	if err != nil {
+		action = GateActionReject
+	}
+	wg.audit(AuditRecord{Event: AuditGateDecision, Phase: PlanPhaseID, Action: string(action), Actor: actor})
+	return err
+}
+
+// aborting reports whether a phase failure has triggered on_failure = "abort".
+func (wg *WorkerGroup) aborting() bool {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	return wg.abortErr != nil
 }
 
 // drainGateSignals returns and clears any pending gate signals.
@@ -181,10 +318,17 @@ func (wg *WorkerGroup) drainGateSignals() []gateSignal {
 	return signals
 }
 
-// collectResults returns a snapshot of the current results.
+// collectResults returns a snapshot of the current results, sorted by phase
+// ID in deterministic mode.
 func (wg *WorkerGroup) collectResults() []WorkerResult {
 	wg.mu.Lock()
 	defer wg.mu.Unlock()
+	if wg.Deterministic {
+		sort.SliceStable(wg.results, func(i, j int) bool {
+			return wg.results[i].PhaseID < wg.results[j].PhaseID
+		})
+	}
+	annotateFailures(wg.results, wg.Nebula.Phases, wg.State)
 	return wg.results
 }
 
@@ -216,18 +360,50 @@ func (wg *WorkerGroup) drainActive(completionCh <-chan string, activeCount *int6
 //
 // Dispatch is truly continuous: when any single goroutine completes, the
 // loop immediately re-evaluates for newly-ready phases. There are no
-// wave or batch barriers.
-func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
+// wave or batch barriers, unless Deterministic is set.
+//
+// When a Notifier is configured, a completion notification is sent however
+// Run returns.
+func (wg *WorkerGroup) Run(ctx context.Context) (results []WorkerResult, err error) {
+	defer func() { wg.notifyDone(ctx, results, err) }()
+	wg.startEvents()
+	defer func() { wg.finishEvents(results, err) }()
+	if wg.AuditLog != nil {
+		wg.auditor = &auditLog{w: wg.AuditLog, now: time.Now}
+	}
+	wg.audit(AuditRecord{Event: AuditRunStart})
+	defer func() { wg.auditDone(results, err) }()
+
 	if wg.MaxWorkers <= 0 {
 		wg.MaxWorkers = 1
 	}
 
 	wg.ensureGater()
+	wg.runCostBase = wg.State.TotalCostUSD
+
+	if wg.PhaseCache {
+		cache, err := loadPhaseCache(wg.Nebula.Dir)
+		if err != nil {
+			fmt.Fprintf(wg.logger(), "warning: phase cache disabled: %v\n", err)
+		} else {
+			wg.cache = &cache
+		}
+	}
+
+	if wg.Nebula.Manifest.Execution.CostSpikeMultiplier > 0 {
+		baseline, err := loadCostBaseline(wg.Nebula.Dir)
+		if err != nil {
+			fmt.Fprintf(wg.logger(), "warning: cost spike check has no history: %v\n", err)
+		}
+		wg.costBaseline = baseline
+	}
 
 	// Construct collaborators.
 	wg.tracker = NewPhaseTracker(wg.Nebula.Phases, wg.State)
-	wg.progress = NewProgressReporter(wg.Nebula, wg.State, wg.OnProgress, wg.Metrics, wg.logger())
-	wg.hotReload = NewHotReloader(HotReloaderConfig{
+	wg.progress = NewProgressReporter(wg.Nebula, wg.State, wg.progressFunc(), wg.Metrics, wg.logger())
+	wg.progress.backups = wg.StateBackups
+	wg.progress.RecordMaxWorkers(wg.MaxWorkers)
+	hotReload := NewHotReloader(HotReloaderConfig{
 		Watcher:     wg.Watcher,
 		BeadsClient: wg.BeadsClient,
 		Nebula:      wg.Nebula,
@@ -235,11 +411,16 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 		Tracker:     wg.tracker,
 		Progress:    wg.progress,
 		OnRefactor:  wg.OnRefactor,
-		OnHotAdd:    wg.OnHotAdd,
+		OnHotAdd:    wg.hotAddFunc(),
 		Logger:      wg.logger(),
+		Debounce:    wg.editDebounce(),
 		Mu:          &wg.mu,
 		OutputMu:    &wg.outputMu,
 	})
+	// Publish under mu so RefactorPhase callers on other goroutines see it.
+	wg.mu.Lock()
+	wg.hotReload = hotReload
+	wg.mu.Unlock()
 
 	if wg.Watcher != nil {
 		go wg.hotReload.ConsumeChanges(ctx)
@@ -256,6 +437,9 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 		wg.blockedTracker = fabric.NewBlockedTracker()
 		wg.pushbackHandler = &fabric.PushbackHandler{Fabric: wg.Fabric}
 	}
+	if wg.Publisher != nil {
+		wg.Publisher.OnConflict = wg.recordConflict
+	}
 
 	// Pre-compute waves for wave-aware scanning. Used by the WaveScanner
 	// to walk phases layer-by-layer, pruning descendants of blocked phases.
@@ -288,10 +472,11 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 			wg:        wg,
 			scheduler: scheduler,
 		},
-		OnHail: wg.OnHail, // may be nil — surfaced via cockpit TUI when set
-		Waves:  waves,     // may be nil if ComputeWaves failed
+		OnHail: wg.hailFunc(ctx), // may be nil — surfaced via cockpit TUI and Notifier when set
+		Waves:  waves,            // may be nil if ComputeWaves failed
 		DAG:    dagGraph,
 	}
+	wg.tychoScheduler.Remediator, wg.tychoScheduler.RemediateAfter = wg.staleRemediator(ctx)
 
 	// Wire wave-aware scanner when fabric components are available and
 	// waves were computed successfully.
@@ -348,21 +533,36 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 	var activeCount int64
 	var peakConcurrent int64
 
+	runtimeCtx, stopRuntime := wg.runtimeLimit(ctx)
+	defer stopRuntime()
+
 	// Continuous dispatch loop: phases are dispatched as soon as their
 	// dependencies complete. When any goroutine finishes, the loop
 	// immediately re-evaluates for newly-ready tasks — no wave barriers.
 	for ctx.Err() == nil {
+		if runtimeExceeded(runtimeCtx) {
+			wg.drainActive(completionCh, &activeCount)
+			if wg.phasesRemain(ctx) {
+				return wg.collectResults(), wg.handleMaxRuntime()
+			}
+			break // the last phases finished after the deadline; the run is complete
+		}
 		switch wg.checkInterventions() {
 		case InterventionStop:
 			wg.handleStop()
 			wg.drainActive(completionCh, &activeCount)
 			return wg.collectResults(), ErrManualStop
+		case InterventionDrain:
+			wg.draining = true
 		case InterventionPause:
 			wg.handlePause()
-			if wg.checkInterventions() == InterventionStop {
+			switch wg.checkInterventions() {
+			case InterventionStop:
 				wg.handleStop()
 				wg.drainActive(completionCh, &activeCount)
 				return wg.collectResults(), ErrManualStop
+			case InterventionDrain:
+				wg.draining = true
 			}
 		}
 
@@ -372,6 +572,17 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 		anyInFlight := wg.tychoScheduler.AnyInFlight()
 		wg.mu.Unlock()
 
+		// While draining, only the phases that were running or eligible
+		// when the drain began may start; the run ends once they finish.
+		if wg.draining {
+			eligible = wg.drainEligible(eligible)
+			if len(eligible) == 0 && !anyInFlight {
+				wg.handleDrained()
+				return wg.collectResults(), ErrDrained
+			}
+		}
+		wg.announceReady(eligible)
+
 		// Notify the TUI that eligible phases are entering the fabric scan gate.
 		// Only fires when fabric is configured (OnScanning is wired) so legacy
 		// mode never produces scanning toasts.
@@ -401,6 +612,7 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 			// Wait for any one in-flight phase to complete, then re-evaluate.
 			wg.awaitCompletion(completionCh, &activeCount)
 			wg.reevaluateBlocked(ctx)
+			wg.checkStale(ctx)
 			stop, retErr := wg.processGateSignals()
 			if stop {
 				wg.drainActive(completionCh, &activeCount)
@@ -409,16 +621,38 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 			continue
 		}
 
-		// Dispatch all currently eligible phases.
+		// Dispatch all currently eligible phases. A phase the total budget
+		// cannot cover yet waits for running phases to settle their
+		// reservations.
 		for _, id := range eligible {
-			if ctx.Err() != nil {
+			if runtimeCtx.Err() != nil {
 				break
 			}
+			if wg.isManualPhase(id) {
+				if wg.aborting() {
+					break
+				}
+				wg.parkManualPhase(ctx, id, scheduler.TrackForTask(id), completionCh, &activeCount)
+				continue
+			}
+			if ok, err := wg.reserveBudget(id, true); !ok && err == nil {
+				continue
+			}
 			wg.mu.Lock()
 			inFlight[id] = true
 			wg.mu.Unlock()
 
 			sem <- struct{}{} // block if at worker capacity
+			if wg.aborting() {
+				// A phase failed under on_failure = "abort" while we waited
+				// for a worker; dispatch nothing further.
+				<-sem
+				wg.mu.Lock()
+				delete(inFlight, id)
+				wg.releaseBudget(id)
+				wg.mu.Unlock()
+				break
+			}
 			atomic.AddInt64(&activeCount, 1)
 			go func(phaseID string) {
 				defer func() {
@@ -433,6 +667,13 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 						break
 					}
 				}
+				if !wg.waitJitter(ctx) {
+					wg.mu.Lock()
+					delete(inFlight, phaseID)
+					wg.releaseBudget(phaseID)
+					wg.mu.Unlock()
+					return
+				}
 				trackID := scheduler.TrackForTask(phaseID)
 				wg.executePhase(ctx, phaseID, trackID)
 			}(id)
@@ -440,9 +681,15 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 
 		// After dispatching, wait for any one goroutine to finish before
 		// re-evaluating. This avoids busy-spinning and ensures newly-ready
-		// phases are picked up as soon as any dependency completes.
-		wg.awaitCompletion(completionCh, &activeCount)
+		// phases are picked up as soon as any dependency completes. In
+		// deterministic mode, wait for the whole batch instead.
+		if wg.Deterministic {
+			wg.drainActive(completionCh, &activeCount)
+		} else {
+			wg.awaitCompletion(completionCh, &activeCount)
+		}
 		wg.reevaluateBlocked(ctx)
+		wg.checkStale(ctx)
 		stop, retErr := wg.processGateSignals()
 		if stop {
 			wg.drainActive(completionCh, &activeCount)
@@ -453,6 +700,9 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 	// Drain remaining in-flight goroutines on context cancellation or
 	// post-loop exit (e.g., all-blocked escalation).
 	wg.drainActive(completionCh, &activeCount)
+	if ctx.Err() != nil {
+		return wg.collectResults(), wg.handleCanceled(ctx)
+	}
 
 	// Process any gate signals accumulated during or after the loop
 	// (e.g., from escalateAllBlocked). This ensures escalated phases
@@ -485,8 +735,5 @@ func (wg *WorkerGroup) Run(ctx context.Context) ([]WorkerResult, error) {
 		}
 	}
 
-	wg.mu.Lock()
-	results := wg.results
-	wg.mu.Unlock()
-	return results, nil
+	return wg.collectResults(), nil
 }
@@ -0,0 +1,239 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultBudgetStepUSD is the extra budget offered when a phase exhausts its
+// per-phase cap and WorkerGroup.BudgetStep is unset.
+const DefaultBudgetStepUSD = 1.0
+
+// BudgetRequest describes a phase that stopped at its per-phase budget cap.
+type BudgetRequest struct {
+	PhaseID    string
+	PhaseTitle string
+	SpentUSD   float64 // cost of the attempt that hit the cap
+	BudgetUSD  float64 // the cap that was hit
+	ExtraUSD   float64 // additional budget offered for the retry
+}
+
+// BudgetPrompter asks a human whether a phase that exhausted its budget
+// should be granted more and re-run.
+type BudgetPrompter interface {
+	// PromptBudget blocks until the user decides. GateActionRetry grants
+	// req.ExtraUSD and re-dispatches the phase; any other action fails it.
+	PromptBudget(ctx context.Context, req BudgetRequest) (GateAction, error)
+}
+
+// resolvePhaseExecution resolves the execution parameters for a phase,
+// including any extra budget granted after earlier budget exhaustion.
+func (wg *WorkerGroup) resolvePhaseExecution(phase *PhaseSpec) ResolvedExecution {
+	exec := ResolveExecution(wg.GlobalCycles, wg.GlobalBudget, wg.GlobalModel, &wg.Nebula.Manifest.Execution, phase, wg.routingCtx, wg.Nebula.Manifest.AgentProfiles)
+	wg.mu.Lock()
+	exec.MaxBudgetUSD += wg.budgetBumps[phase.ID]
+	if phase.TimeoutAction == "" && wg.TimeoutAction != "" {
+		exec.TimeoutAction = wg.TimeoutAction
+	}
+	if r, ok := wg.reserved[phase.ID]; ok && exec.MaxBudgetUSD <= 0 {
+		exec.MaxBudgetUSD = r
+	}
+	wg.mu.Unlock()
+	return exec
+}
+
+// budgetStep returns the extra budget offered per budget prompt.
+func (wg *WorkerGroup) budgetStep() float64 {
+	if wg.BudgetStep > 0 {
+		return wg.BudgetStep
+	}
+	return DefaultBudgetStepUSD
+}
+
+// retryWithMoreBudget prompts for more budget after a phase hit its cap.
+// When the user accepts, it raises the phase's budget, accounts for the
+// spent cost, and re-queues the phase like a gate retry, returning true.
+// It returns false (leaving the failure to be recorded) when no prompter is
+// configured or the user declines.
+func (wg *WorkerGroup) retryWithMoreBudget(ctx context.Context, phase *PhaseSpec, ps *PhaseState, exec ResolvedExecution, result *PhaseRunnerResult) bool {
+	if wg.Budget == nil {
+		return false
+	}
+	req := BudgetRequest{
+		PhaseID:    phase.ID,
+		PhaseTitle: phase.Title,
+		BudgetUSD:  exec.MaxBudgetUSD,
+		ExtraUSD:   wg.budgetStep(),
+	}
+	if result != nil {
+		req.SpentUSD = result.TotalCostUSD
+	}
+
+	action, err := wg.Budget.PromptBudget(ctx, req)
+	if err != nil {
+		fmt.Fprintf(wg.logger(), "warning: budget prompt failed for phase %q: %v\n", phase.ID, err)
+		return false
+	}
+	wg.audit(AuditRecord{
+		Event: AuditGateDecision, Phase: phase.ID, Action: string(action), Actor: AuditActorHuman,
+		Detail: fmt.Sprintf("budget exhausted after $%.2f; offered $%.2f more", req.SpentUSD, req.ExtraUSD),
+	})
+	if action != GateActionRetry {
+		return false
+	}
+
+	wg.requeueWithMoreBudget(phase.ID, ps, req.ExtraUSD, result)
+	return true
+}
+
+// requeueWithMoreBudget raises phaseID's budget by extra, settles the run
+// that just ended, and re-queues the phase like a gate retry.
+func (wg *WorkerGroup) requeueWithMoreBudget(phaseID string, ps *PhaseState, extra float64, result *PhaseRunnerResult) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.budgetBumps == nil {
+		wg.budgetBumps = make(map[string]float64)
+	}
+	wg.budgetBumps[phaseID] += extra
+	delete(wg.tracker.InFlight(), phaseID)
+	wg.chargeRun(phaseID, ps, result)
+	wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusInProgress)
+	wg.progress.SaveState()
+	wg.gateSignals = append(wg.gateSignals, gateSignal{phaseID: phaseID, action: GateActionRetry})
+}
+
+// beginAttempt prepares a run of the phase in ps. Every run after the first
+// is a retry; when the phase has a retry budget, a retry is capped at what is
+// left of that budget instead of the phase budget. It reports whether the run
+// draws from the retry budget, and returns ErrRetryBudgetExhausted without
+// running anything once none is left.
+func (wg *WorkerGroup) beginAttempt(phaseID string, ps *PhaseState, exec *ResolvedExecution) (bool, error) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if ps.Attempts == 0 || exec.RetryBudgetUSD <= 0 {
+		return false, nil
+	}
+	left := exec.RetryBudgetUSD - ps.RetrySpentUSD
+	if left <= 0 {
+		return false, fmt.Errorf("%w: phase %q spent $%.2f of its $%.2f retry budget", ErrRetryBudgetExhausted, phaseID, ps.RetrySpentUSD, exec.RetryBudgetUSD)
+	}
+	exec.MaxBudgetUSD = min(exec.MaxBudgetUSD, left)
+	return true, nil
+}
+
+// endAttempt records a finished run of phaseID in ps and returns the
+// run's error. A retry's cost is charged to the retry budget, and a retry
+// that fails with nothing left in it is terminal: its error is wrapped in
+// ErrRetryBudgetExhausted. The run is reported to OnAttempt.
+func (wg *WorkerGroup) endAttempt(phaseID string, ps *PhaseState, exec ResolvedExecution, retry bool, result *PhaseRunnerResult, err error) error {
+	wg.mu.Lock()
+	ps.Attempts++
+	if retry && result != nil {
+		ps.RetrySpentUSD += result.TotalCostUSD
+	}
+	if retry && err != nil && ps.RetrySpentUSD >= exec.RetryBudgetUSD {
+		err = fmt.Errorf("%w ($%.2f spent): %w", ErrRetryBudgetExhausted, ps.RetrySpentUSD, err)
+	}
+	rec := newAttemptRecord(exec, result, err)
+	ps.AttemptLog = append(ps.AttemptLog, rec)
+	wg.mu.Unlock()
+
+	if wg.OnAttempt != nil {
+		wg.OnAttempt(phaseID, rec)
+	}
+	return err
+}
+
+// newAttemptRecord describes a run that returned result and err.
+func newAttemptRecord(exec ResolvedExecution, result *PhaseRunnerResult, err error) AttemptRecord {
+	rec := AttemptRecord{Model: exec.Model, Outcome: AttemptOutcomeDone, At: time.Now().UTC()}
+	if result != nil {
+		rec.Cycles = result.CyclesUsed
+		rec.CostUSD = result.TotalCostUSD
+		if result.Decompose {
+			rec.Outcome = AttemptOutcomeDecomposed
+		}
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled):
+		rec.Outcome = AttemptOutcomeCanceled
+	case errors.Is(err, ErrPhaseTimeout):
+		rec.Outcome = AttemptOutcomeTimedOut
+	case errors.Is(err, ErrPhaseBudgetExceeded), errors.Is(err, ErrRetryBudgetExhausted):
+		rec.Outcome = AttemptOutcomeOverBudget
+	default:
+		rec.Outcome = AttemptOutcomeFailed
+	}
+	return rec
+}
+
+// reserveBudget sets aside phaseID's budget against the manifest's
+// total_budget_usd before the phase runs, so phases running in parallel
+// cannot together spend past it. A phase without a budget of its own
+// reserves everything that is left. Manual phases spend nothing and are
+// not counted.
+//
+// When the phase does not fit, it returns false with a nil error if wait
+// is set and other phases hold reservations: it can be dispatched once
+// they finish. Otherwise the phase can never fit and the error wraps
+// ErrTotalBudgetExhausted. A phase that already holds a reservation is
+// left as it is.
+func (wg *WorkerGroup) reserveBudget(phaseID string, wait bool) (bool, error) {
+	// The tracker's phase map changes under wg.mu when a phase is hot-added.
+	wg.mu.Lock()
+	total := wg.Nebula.Manifest.Execution.TotalBudgetUSD
+	phase := wg.tracker.PhasesByIDMap()[phaseID]
+	wg.mu.Unlock()
+	if total <= 0 || phase == nil || phase.IsManual() {
+		return true, nil
+	}
+	need := wg.resolvePhaseExecution(phase).MaxBudgetUSD
+
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if _, ok := wg.reserved[phaseID]; ok {
+		return true, nil
+	}
+	var held float64
+	for _, r := range wg.reserved {
+		held += r
+	}
+	left := total - (wg.State.TotalCostUSD - wg.runCostBase) - held
+	if need <= 0 {
+		need = left
+	}
+	if need <= 0 || need > left {
+		if wait && held > 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: phase %q needs $%.2f, $%.2f of $%.2f left", ErrTotalBudgetExhausted, phaseID, need, max(left, 0), total)
+	}
+	if wg.reserved == nil {
+		wg.reserved = make(map[string]float64)
+	}
+	wg.reserved[phaseID] = need
+	return true, nil
+}
+
+// chargeRun settles a run of phaseID that ends without recordResult, as
+// when the phase is retried or decomposed: its cost is added to the run
+// and phase totals and its reservation is released.
+// Must be called with wg.mu held.
+func (wg *WorkerGroup) chargeRun(phaseID string, ps *PhaseState, result *PhaseRunnerResult) {
+	if result != nil {
+		wg.State.TotalCostUSD += result.TotalCostUSD
+		ps.CostUSD += result.TotalCostUSD
+	}
+	wg.releaseBudget(phaseID)
+}
+
+// releaseBudget returns phaseID's reservation to the total budget. The
+// phase's actual cost is added to State.TotalCostUSD in the same critical
+// section, so the pool never counts it twice or not at all.
+// Must be called with wg.mu held.
+func (wg *WorkerGroup) releaseBudget(phaseID string) {
+	delete(wg.reserved, phaseID)
+}
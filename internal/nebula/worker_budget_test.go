@@ -0,0 +1,401 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// budgetRunner fails each phase's first attempt with ErrPhaseBudgetExceeded
+// and records the budget every attempt ran with.
+type budgetRunner struct {
+	mu      sync.Mutex
+	budgets []float64
+}
+
+func (r *budgetRunner) RunExistingPhase(_ context.Context, _, _, _, _ string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budgets = append(r.budgets, exec.MaxBudgetUSD)
+	if len(r.budgets) == 1 {
+		return &PhaseRunnerResult{TotalCostUSD: exec.MaxBudgetUSD}, fmt.Errorf("%w: budget exceeded", ErrPhaseBudgetExceeded)
+	}
+	return &PhaseRunnerResult{TotalCostUSD: 0.5}, nil
+}
+
+func (r *budgetRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+// stubBudgetPrompter answers every prompt with a fixed action.
+type stubBudgetPrompter struct {
+	action   GateAction
+	requests []BudgetRequest
+}
+
+func (p *stubBudgetPrompter) PromptBudget(_ context.Context, req BudgetRequest) (GateAction, error) {
+	p.requests = append(p.requests, req)
+	return p.action, nil
+}
+
+func TestWorkerGroupBudgetExhaustion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prompter   *stubBudgetPrompter
+		wantStatus PhaseStatus
+		wantRuns   []float64
+		wantCost   float64
+	}{
+		{"no prompter fails", nil, PhaseStatusFailed, []float64{2}, 2},
+		{"declined fails", &stubBudgetPrompter{action: GateActionReject}, PhaseStatusFailed, []float64{2}, 2},
+		{"accepted retries with more budget", &stubBudgetPrompter{action: GateActionRetry}, PhaseStatusDone, []float64{2, 2.75}, 2.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", Body: "phase a", MaxBudgetUSD: 2}},
+			}
+			state := &State{
+				Version: 1,
+				Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+			}
+			runner := &budgetRunner{}
+			opts := []Option{WithRunner(runner)}
+			if tt.prompter != nil {
+				opts = append(opts, WithBudgetPrompter(tt.prompter))
+			}
+			wg := NewWorkerGroup(n, state, opts...)
+			wg.BudgetStep = 0.75
+
+			if _, err := wg.Run(context.Background()); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got := state.Phases["a"].Status; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+			if fmt.Sprint(runner.budgets) != fmt.Sprint(tt.wantRuns) {
+				t.Errorf("run budgets = %v, want %v", runner.budgets, tt.wantRuns)
+			}
+			if state.TotalCostUSD != tt.wantCost {
+				t.Errorf("total cost = %v, want %v", state.TotalCostUSD, tt.wantCost)
+			}
+			if tt.prompter != nil {
+				want := BudgetRequest{PhaseID: "a", PhaseTitle: "A", SpentUSD: 2, BudgetUSD: 2, ExtraUSD: 0.75}
+				if len(tt.prompter.requests) != 1 || tt.prompter.requests[0] != want {
+					t.Errorf("requests = %+v, want [%+v]", tt.prompter.requests, want)
+				}
+			}
+		})
+	}
+}
+
+// spendingRunner fails every attempt after spending its whole budget.
+type spendingRunner struct {
+	mu      sync.Mutex
+	budgets []float64
+}
+
+func (r *spendingRunner) RunExistingPhase(_ context.Context, _, _, _, _ string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budgets = append(r.budgets, exec.MaxBudgetUSD)
+	return &PhaseRunnerResult{TotalCostUSD: exec.MaxBudgetUSD}, errors.New("review rejected")
+}
+
+func (r *spendingRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestWorkerGroupRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		retryBudget   float64
+		prior         PhaseState
+		wantRuns      []float64
+		wantExhausted bool
+		wantSpent     float64
+	}{
+		{"first attempt uses the phase budget", 1, PhaseState{}, []float64{5}, false, 0},
+		{"retry is capped by the retry budget", 1.5, PhaseState{Attempts: 1}, []float64{1.5}, true, 1.5},
+		{"retry draws only what is left", 1.5, PhaseState{Attempts: 2, RetrySpentUSD: 1}, []float64{0.5}, true, 1.5},
+		{"exhausted retry budget does not run", 1.5, PhaseState{Attempts: 3, RetrySpentUSD: 1.5}, nil, true, 1.5},
+		{"no retry budget shares the phase budget", 0, PhaseState{Attempts: 1}, []float64{5}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir:      t.TempDir(),
+				Manifest: Manifest{Nebula: Info{Name: "test"}, Execution: Execution{RetryBudgetUSD: tt.retryBudget}},
+				Phases:   []PhaseSpec{{ID: "a", Title: "A", Body: "phase a", MaxBudgetUSD: 5}},
+			}
+			ps := tt.prior
+			ps.BeadID, ps.Status = "bead-a", PhaseStatusCreated
+			state := &State{Version: 1, Phases: map[string]*PhaseState{"a": &ps}}
+			runner := &spendingRunner{}
+			wg := NewWorkerGroup(n, state, WithRunner(runner))
+
+			results, err := wg.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if fmt.Sprint(runner.budgets) != fmt.Sprint(tt.wantRuns) {
+				t.Errorf("run budgets = %v, want %v", runner.budgets, tt.wantRuns)
+			}
+			if len(results) != 1 {
+				t.Fatalf("results = %+v, want one", results)
+			}
+			if got := errors.Is(results[0].Err, ErrRetryBudgetExhausted); got != tt.wantExhausted {
+				t.Errorf("error %v: exhausted = %v, want %v", results[0].Err, got, tt.wantExhausted)
+			}
+			if results[0].RetrySpentUSD != tt.wantSpent {
+				t.Errorf("RetrySpentUSD = %v, want %v", results[0].RetrySpentUSD, tt.wantSpent)
+			}
+			if state.Phases["a"].Status != PhaseStatusFailed {
+				t.Errorf("status = %s, want failed", state.Phases["a"].Status)
+			}
+		})
+	}
+}
+
+// concurrencyRunner charges a fixed cost per phase and records the peak
+// number of phases running at once.
+type concurrencyRunner struct {
+	mu      sync.Mutex
+	cost    float64
+	running int
+	peak    int
+	calls   int
+}
+
+func (r *concurrencyRunner) RunExistingPhase(_ context.Context, _, _, _, _ string, _ ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	r.calls++
+	r.running++
+	r.peak = max(r.peak, r.running)
+	r.mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+	return &PhaseRunnerResult{TotalCostUSD: r.cost}, nil
+}
+
+func (r *concurrencyRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestWorkerGroupTotalBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cost       float64 // spent by each phase; every phase has a $2 budget
+		workers    int
+		wantPeak   int
+		wantFailed int
+	}{
+		// $5 covers two $2 reservations at once; the third waits until a
+		// phase settles at $1 and frees room.
+		{"parallel phases wait for room", 1, 3, 2, 0},
+		// After two phases spend $2 each, $1 is left and nothing else holds
+		// a reservation, so the last phase can never fit.
+		{"phase that cannot fit fails", 2, 1, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := &Nebula{
+				Dir: t.TempDir(),
+				Manifest: Manifest{
+					Nebula:    Info{Name: "test"},
+					Execution: Execution{TotalBudgetUSD: 5},
+				},
+			}
+			state := &State{Version: 1, Phases: map[string]*PhaseState{}, TotalCostUSD: 10}
+			for _, id := range []string{"a", "b", "c"} {
+				n.Phases = append(n.Phases, PhaseSpec{ID: id, Body: "phase " + id, MaxBudgetUSD: 2})
+				state.Phases[id] = &PhaseState{BeadID: "bead-" + id, Status: PhaseStatusCreated}
+			}
+			runner := &concurrencyRunner{cost: tt.cost}
+			wg := NewWorkerGroup(n, state, WithRunner(runner), WithMaxWorkers(tt.workers), WithLogger(io.Discard))
+
+			results, err := wg.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if runner.peak > tt.wantPeak {
+				t.Errorf("peak concurrency = %d, want at most %d", runner.peak, tt.wantPeak)
+			}
+			if want := 3 - tt.wantFailed; runner.calls != want {
+				t.Errorf("runner calls = %d, want %d", runner.calls, want)
+			}
+			failed := 0
+			for _, r := range results {
+				if r.Err != nil {
+					failed++
+					if !errors.Is(r.Err, ErrTotalBudgetExhausted) {
+						t.Errorf("phase %s error = %v, want ErrTotalBudgetExhausted", r.PhaseID, r.Err)
+					}
+				}
+			}
+			if failed != tt.wantFailed {
+				t.Errorf("failed phases = %d, want %d", failed, tt.wantFailed)
+			}
+			if tt.wantFailed > 0 && ExitCode(err, results) != ExitBudgetExceeded {
+				t.Errorf("exit code = %d, want %d", ExitCode(err, results), ExitBudgetExceeded)
+			}
+			if len(wg.reserved) != 0 {
+				t.Errorf("reservations left after the run: %v", wg.reserved)
+			}
+		})
+	}
+}
+
+func TestWorkerGroupChargesGateRetries(t *testing.T) {
+	t.Parallel()
+
+	// A run sent back by a gate retry never reaches recordResult; its cost
+	// is still charged to the phase and run totals and its reservation freed.
+	n := &Nebula{
+		Dir: t.TempDir(),
+		Manifest: Manifest{
+			Nebula:    Info{Name: "test"},
+			Execution: Execution{Gate: GateModeReview, TotalBudgetUSD: 10},
+		},
+		Phases: []PhaseSpec{{ID: "a", Title: "A", Body: "phase a", MaxBudgetUSD: 2}},
+	}
+	state := &State{
+		Version: 1,
+		Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+	}
+	runner := &concurrencyRunner{cost: 1.5}
+	prompter := &scriptedPrompter{actions: []GateAction{GateActionRetry, GateActionAccept}}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithPrompter(prompter), WithLogger(io.Discard))
+
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if runner.calls != 2 {
+		t.Fatalf("runner calls = %d, want 2", runner.calls)
+	}
+	if state.TotalCostUSD != 3 || state.Phases["a"].CostUSD != 3 {
+		t.Errorf("total cost = %v, phase cost = %v, want both 3", state.TotalCostUSD, state.Phases["a"].CostUSD)
+	}
+	if len(wg.reserved) != 0 {
+		t.Errorf("reservations left after the run: %v", wg.reserved)
+	}
+}
+
+func TestWorkerGroupAttemptLog(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases:   []PhaseSpec{{ID: "a", Title: "A", Body: "phase a", MaxBudgetUSD: 2, Model: "opus"}},
+	}
+	state := &State{
+		Version: 1,
+		Phases:  map[string]*PhaseState{"a": {BeadID: "bead-a", Status: PhaseStatusCreated}},
+	}
+	wg := NewWorkerGroup(n, state, WithRunner(&budgetRunner{}), WithBudgetPrompter(&stubBudgetPrompter{action: GateActionRetry}))
+	var reported []AttemptRecord
+	wg.OnAttempt = func(phaseID string, rec AttemptRecord) {
+		if phaseID == "a" {
+			reported = append(reported, rec)
+		}
+	}
+
+	if _, err := wg.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	log := state.Phases["a"].AttemptLog
+	if len(log) != 2 {
+		t.Fatalf("AttemptLog = %+v, want two attempts", log)
+	}
+	want := []struct {
+		outcome string
+		cost    float64
+	}{{AttemptOutcomeOverBudget, 2}, {AttemptOutcomeDone, 0.5}}
+	for i, w := range want {
+		if log[i].Outcome != w.outcome || log[i].CostUSD != w.cost || log[i].Model != "opus" || log[i].At.IsZero() {
+			t.Errorf("attempt %d = %+v, want %s at $%.2f with opus", i+1, log[i], w.outcome, w.cost)
+		}
+	}
+	if fmt.Sprint(reported) != fmt.Sprint(log) {
+		t.Errorf("OnAttempt reported %+v, want %+v", reported, log)
+	}
+}
+
+func TestNewAttemptRecordOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result *PhaseRunnerResult
+		err    error
+		want   string
+	}{
+		{"success", &PhaseRunnerResult{}, nil, AttemptOutcomeDone},
+		{"decomposed", &PhaseRunnerResult{Decompose: true}, nil, AttemptOutcomeDecomposed},
+		{"timed out", nil, fmt.Errorf("x: %w", ErrPhaseTimeout), AttemptOutcomeTimedOut},
+		{"retry budget", nil, fmt.Errorf("%w: x", ErrRetryBudgetExhausted), AttemptOutcomeOverBudget},
+		{"canceled", nil, context.Canceled, AttemptOutcomeCanceled},
+		{"rejected", nil, errors.New("review rejected"), AttemptOutcomeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := newAttemptRecord(ResolvedExecution{}, tt.result, tt.err).Outcome; got != tt.want {
+				t.Errorf("Outcome = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReserveBudgetRacesHotAdd(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Manifest: Manifest{Execution: Execution{TotalBudgetUSD: 100}},
+		Phases:   []PhaseSpec{{ID: "a", MaxBudgetUSD: 1}},
+	}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+	wg := NewWorkerGroup(n, state, WithLogger(io.Discard))
+	wg.tracker = NewPhaseTracker(n.Phases, state)
+
+	// Hot-adding a phase writes the tracker's map under wg.mu, as the hot
+	// reloader does; run with -race to catch an unlocked read.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 1000 {
+			id := fmt.Sprintf("added-%d", i)
+			wg.mu.Lock()
+			wg.tracker.PhasesByIDMap()[id] = &PhaseSpec{ID: id}
+			wg.mu.Unlock()
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if ok, err := wg.reserveBudget("a", false); !ok || err != nil {
+			t.Fatalf("reserveBudget = %v, %v", ok, err)
+		}
+	}
+}
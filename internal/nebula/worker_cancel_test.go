@@ -0,0 +1,62 @@
+package nebula
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// cancelingRunner cancels the run while its phase is running and fails the
+// phase with the context's error, as a loop interrupted by Ctrl-C does.
+type cancelingRunner struct {
+	mockRunner
+	cancel context.CancelFunc
+}
+
+func (r *cancelingRunner) RunExistingPhase(ctx context.Context, phaseID, beadID, phaseTitle, phaseDescription string, exec ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mockRunner.RunExistingPhase(ctx, phaseID, beadID, phaseTitle, phaseDescription, exec)
+	r.cancel()
+	return &PhaseRunnerResult{TotalCostUSD: 0.5}, ctx.Err()
+}
+
+func TestWorkerGroup_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	phases := []PhaseSpec{
+		{ID: "a", Body: "phase a"},
+		{ID: "b", Body: "phase b", DependsOn: []string{"a"}},
+	}
+	n := &Nebula{Dir: t.TempDir(), Manifest: Manifest{Nebula: Info{Name: "test"}}, Phases: phases}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+	for _, p := range phases {
+		state.Phases[p.ID] = &PhaseState{BeadID: "bead-" + p.ID, Status: PhaseStatusCreated}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner := &cancelingRunner{cancel: cancel}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithLogger(io.Discard))
+
+	results, err := wg.Run(ctx)
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want ErrCanceled matching context.Canceled", err)
+	}
+	if errors.Is(err, ErrManualStop) {
+		t.Error("a canceled run should be distinguishable from a STOP")
+	}
+	if len(results) != 0 {
+		t.Errorf("results = %+v, want the interrupted phase left out", results)
+	}
+	for _, id := range []string{"a", "b"} {
+		if got := state.Phases[id].Status; got != PhaseStatusCreated {
+			t.Errorf("phase %s status = %s, want created so a resume runs it", id, got)
+		}
+	}
+	if got := state.Phases["a"].CostUSD; got != 0.5 {
+		t.Errorf("interrupted phase cost = %v, want what it spent kept", got)
+	}
+	if got := ExitCode(err, results); got != ExitManualStop {
+		t.Errorf("ExitCode = %d, want ExitManualStop", got)
+	}
+}
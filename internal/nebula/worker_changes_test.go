@@ -3,12 +3,15 @@ package nebula
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/papapumpkin/quasar/internal/dag"
 )
@@ -102,6 +105,64 @@ func TestHandlePhaseModified_BadFile(t *testing.T) {
 	}
 }
 
+func TestRefactorPhase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends body to running loop", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTestPhaseFile(t, dir, "phase-1", "Edited from the TUI")
+		wg := newTestWorkerGroup(t)
+		wg.Nebula = &Nebula{Dir: dir, Phases: []PhaseSpec{{ID: "phase-1", SourceFile: "phase-1.md"}}}
+		ch := make(chan string, 1)
+		wg.RegisterPhaseLoop("phase-1", ch)
+
+		if err := wg.RefactorPhase("phase-1"); err != nil {
+			t.Fatalf("RefactorPhase() error = %v", err)
+		}
+		select {
+		case got := <-ch:
+			if got != "Edited from the TUI" {
+				t.Errorf("refactorCh received %q, want %q", got, "Edited from the TUI")
+			}
+		default:
+			t.Error("expected value on refactorCh, got nothing")
+		}
+	})
+
+	t.Run("unknown phase", func(t *testing.T) {
+		t.Parallel()
+		wg := newTestWorkerGroup(t)
+		wg.Nebula = &Nebula{Dir: t.TempDir()}
+		if err := wg.RefactorPhase("missing"); !errors.Is(err, ErrUnknownPhase) {
+			t.Errorf("RefactorPhase() error = %v, want ErrUnknownPhase", err)
+		}
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		t.Parallel()
+		wg := &WorkerGroup{Nebula: &Nebula{}}
+		if err := wg.RefactorPhase("phase-1"); !errors.Is(err, ErrNotRunning) {
+			t.Errorf("RefactorPhase() error = %v, want ErrNotRunning", err)
+		}
+	})
+
+	t.Run("no-op with watcher", func(t *testing.T) {
+		t.Parallel()
+		wg := newTestWorkerGroup(t)
+		wg.Watcher = &Watcher{}
+		if err := wg.RefactorPhase("phase-1"); err != nil {
+			t.Errorf("RefactorPhase() error = %v, want nil", err)
+		}
+		wg.mu.Lock()
+		n := len(wg.hotReload.pendingRefactors)
+		wg.mu.Unlock()
+		if n != 0 {
+			t.Errorf("pendingRefactors has %d entries, want 0", n)
+		}
+	})
+}
+
 func TestRegisterPhaseLoop_FlushPending(t *testing.T) {
 	t.Parallel()
 	wg := newTestWorkerGroup(t)
@@ -644,3 +705,134 @@ func TestOnRefactorCallback(t *testing.T) {
 		t.Error("callback pending = false, want true")
 	}
 }
+
+func TestConsumeChangesCoalescesModifications(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var mu sync.Mutex
+	ch := make(chan Change, 10)
+	refactors := make(chan string, 10)
+	hr := NewHotReloader(HotReloaderConfig{
+		Watcher:    &Watcher{Changes: ch},
+		Logger:     io.Discard,
+		Mu:         &mu,
+		Debounce:   50 * time.Millisecond,
+		OnRefactor: func(phaseID string, _ bool) { refactors <- phaseID },
+	})
+	loopCh := make(chan string, 10)
+	hr.RegisterPhaseLoop("p", loopCh)
+
+	done := make(chan struct{})
+	go func() {
+		hr.ConsumeChanges(context.Background())
+		close(done)
+	}()
+
+	// A burst of saves to p, plus one edit to q inside the same window.
+	for _, body := range []string{"one", "two", "three"} {
+		ch <- Change{Kind: ChangeModified, PhaseID: "p", File: writeTestPhaseFile(t, dir, "p", body)}
+	}
+	ch <- Change{Kind: ChangeModified, PhaseID: "q", File: writeTestPhaseFile(t, dir, "q", "q body")}
+
+	got := map[string]int{}
+	for len(got) < 2 {
+		select {
+		case id := <-refactors:
+			got[id]++
+		case <-time.After(5 * time.Second):
+			t.Fatalf("refactors dispatched = %v, want one each for p and q", got)
+		}
+	}
+	select {
+	case id := <-refactors:
+		t.Errorf("extra refactor dispatched for %q", id)
+	case <-time.After(150 * time.Millisecond):
+	}
+	if got["p"] != 1 || got["q"] != 1 {
+		t.Errorf("refactors dispatched = %v, want one each for p and q", got)
+	}
+	if len(loopCh) != 1 {
+		t.Fatalf("running loop received %d refactors, want 1", len(loopCh))
+	}
+	if body := <-loopCh; body != "three" {
+		t.Errorf("refactor body = %q, want the latest save %q", body, "three")
+	}
+
+	close(ch)
+	<-done
+}
+
+// logLines forwards every write to a channel so a test can observe log
+// output from another goroutine without racing.
+type logLines chan string
+
+func (l logLines) Write(p []byte) (int, error) {
+	l <- string(p)
+	return len(p), nil
+}
+
+func TestConsumeChangesDoesNotDelayRemovals(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var mu sync.Mutex
+	ch := make(chan Change, 10)
+	logs := make(logLines, 10)
+	hr := NewHotReloader(HotReloaderConfig{
+		Watcher:  &Watcher{Changes: ch},
+		Logger:   logs,
+		Mu:       &mu,
+		Debounce: time.Hour,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		hr.ConsumeChanges(context.Background())
+		close(done)
+	}()
+
+	// The pending edit's window never closes during the test; the removal
+	// of another file must still be handled straight away.
+	ch <- Change{Kind: ChangeModified, PhaseID: "p", File: writeTestPhaseFile(t, dir, "p", "body")}
+	ch <- Change{Kind: ChangeRemoved, File: filepath.Join(dir, "gone.md")}
+	select {
+	case line := <-logs:
+		if !strings.Contains(line, "phase file removed") {
+			t.Errorf("first log line = %q, want the removal warning", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("removal was not handled while an edit was pending")
+	}
+
+	// Closing the watcher flushes the pending edit.
+	close(ch)
+	<-done
+	hr.mu.Lock()
+	_, pending := hr.pendingRefactors["p"]
+	hr.mu.Unlock()
+	if !pending {
+		t.Error("pending edit was dropped when the watcher closed")
+	}
+}
+
+func TestEditDebounce(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts []Option
+		want time.Duration
+	}{
+		{"default", nil, DefaultEditDebounce},
+		{"explicit window", []Option{WithEditDebounce(2 * time.Second)}, 2 * time.Second},
+		{"zero disables coalescing", []Option{WithEditDebounce(0)}, 0},
+		{"negative disables coalescing", []Option{WithEditDebounce(-time.Second)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wg := NewWorkerGroup(&Nebula{}, &State{}, tt.opts...)
+			if got := wg.editDebounce(); got != tt.want {
+				t.Errorf("editDebounce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
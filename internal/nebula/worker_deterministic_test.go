@@ -0,0 +1,74 @@
+package nebula
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderRunner records when each phase starts and finishes. Phase "b" is
+// slow so that, without a batch barrier, "d" would start before it ends.
+type orderRunner struct {
+	mu       sync.Mutex
+	running  map[string]bool
+	overlaps map[string][]string // phase ID -> phases running when it started
+}
+
+func (r *orderRunner) RunExistingPhase(_ context.Context, phaseID, _, _, _ string, _ ResolvedExecution) (*PhaseRunnerResult, error) {
+	r.mu.Lock()
+	for id := range r.running {
+		r.overlaps[phaseID] = append(r.overlaps[phaseID], id)
+	}
+	r.running[phaseID] = true
+	r.mu.Unlock()
+
+	if phaseID == "b" {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	r.mu.Lock()
+	delete(r.running, phaseID)
+	r.mu.Unlock()
+	return &PhaseRunnerResult{}, nil
+}
+
+func (r *orderRunner) GenerateCheckpoint(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestWorkerGroupDeterministic(t *testing.T) {
+	t.Parallel()
+
+	n := &Nebula{
+		Dir:      t.TempDir(),
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases: []PhaseSpec{
+			{ID: "a", Title: "A", Body: "a"},
+			{ID: "b", Title: "B", Body: "b"},
+			{ID: "c", Title: "C", Body: "c"},
+			{ID: "d", Title: "D", Body: "d", DependsOn: []string{"a"}},
+		},
+	}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{}}
+	for _, p := range n.Phases {
+		state.Phases[p.ID] = &PhaseState{BeadID: "bead-" + p.ID, Status: PhaseStatusCreated}
+	}
+	runner := &orderRunner{running: map[string]bool{}, overlaps: map[string][]string{}}
+	wg := NewWorkerGroup(n, state, WithRunner(runner), WithMaxWorkers(2), WithDeterministic(true))
+
+	results, err := wg.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := runner.overlaps["d"]; len(got) > 0 {
+		t.Errorf("d started while %v were still running, want after its batch", got)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.PhaseID)
+	}
+	if len(ids) != 4 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" || ids[3] != "d" {
+		t.Errorf("result order = %v, want [a b c d]", ids)
+	}
+}
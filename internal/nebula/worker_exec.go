@@ -2,14 +2,9 @@ package nebula
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
-	"strings"
-
-	"github.com/papapumpkin/quasar/internal/beads"
-	"github.com/papapumpkin/quasar/internal/dag"
-	"github.com/papapumpkin/quasar/internal/fabric"
 )
 
 // executePhase runs a single phase and records the result.
@@ -28,6 +23,10 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 		return
 	}
 
+	if _, err := wg.reserveBudget(phaseID, false); err != nil {
+		wg.recordResult(phaseID, ps, nil, err, done, failed, inFlight, nil)
+		return
+	}
 	wg.progress.RecordPhaseStart(phaseID, waveNumber)
 
 	wg.mu.Lock()
@@ -35,13 +34,50 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 	wg.progress.SaveState()
 	wg.progress.ReportProgress()
 	wg.mu.Unlock()
+	wg.audit(AuditRecord{Event: AuditPhaseStart, Phase: phaseID})
+
+	if phase.IsManual() {
+		wg.runManualPhase(ctx, phase, ps, done, failed, inFlight)
+		return
+	}
 
-	exec := ResolveExecution(wg.GlobalCycles, wg.GlobalBudget, wg.GlobalModel, &wg.Nebula.Manifest.Execution, phase, wg.routingCtx)
-	prompt := buildPhasePrompt(phase, &wg.Nebula.Manifest.Context)
-	phaseResult, err := wg.Runner.RunExistingPhase(ctx, phaseID, ps.BeadID, phase.Title, prompt, exec)
+	exec := wg.resolvePhaseExecution(phase)
+	prompt := buildPhasePrompt(phase, &wg.Nebula.Manifest.Context, ResolveParams(phase, wg.Params))
+	cacheKey, hit := wg.cachedPhase(ctx, phase, prompt, exec)
+	if hit != nil {
+		wg.recordCacheHit(ctx, phase, ps, hit, done, failed, inFlight)
+		return
+	}
+	if wg.Nebula.Dir != "" {
+		prompt += scratchpadInstructions(wg.Nebula.Dir, phaseID)
+	}
+	if wg.OutputDir != "" {
+		exec.OutputDir = filepath.Join(wg.OutputDir, phaseID)
+	}
+	retry, err := wg.beginAttempt(phaseID, ps, &exec)
+	if err != nil {
+		wg.recordResult(phaseID, ps, nil, err, done, failed, inFlight, nil)
+		return
+	}
+	phaseResult, err := wg.runWithTimeout(ctx, phase, ps.BeadID, prompt, exec)
+	err = wg.endAttempt(phaseID, ps, exec, retry, phaseResult, err)
+	if err != nil && ctx.Err() != nil {
+		wg.recordInterrupted(phaseID, ps, phaseResult, inFlight)
+		return
+	}
 
 	if phaseResult != nil {
 		wg.progress.RecordPhaseComplete(phaseID, *phaseResult)
+		wg.checkCostSpike(ctx, phaseID, phaseResult.TotalCostUSD)
+	}
+	if errors.Is(err, ErrPhaseBudgetExceeded) && !errors.Is(err, ErrRetryBudgetExhausted) && wg.retryWithMoreBudget(ctx, phase, ps, exec, phaseResult) {
+		return
+	}
+	if errors.Is(err, ErrPhaseTimeout) && wg.handleTimeout(phase, ps, exec, phaseResult, done, failed, inFlight) {
+		return
+	}
+	if err == nil && phaseResult != nil && phaseResult.ForceApproved {
+		wg.audit(AuditRecord{Event: AuditGateDecision, Phase: phaseID, Action: AuditActionOverrideReviewer, Actor: AuditActorHuman})
 	}
 
 	// Handle auto-decomposition when the loop signals a struggle.
@@ -51,7 +87,7 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 			if decompErr != nil {
 				fmt.Fprintf(wg.logger(), "decomposition failed for %s: %v\n", phaseID, decompErr)
 				// Fall through to record the phase as failed.
-				wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("decomposition failed: %w", decompErr), done, failed, inFlight)
+				wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("decomposition failed: %w", decompErr), done, failed, inFlight, nil)
 				return
 			}
 			// Mark original phase as decomposed and enqueue sub-phases.
@@ -59,6 +95,7 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 			wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusDecomposed)
 			done[phaseID] = true
 			delete(inFlight, phaseID)
+			wg.chargeRun(phaseID, ps, phaseResult)
 			wg.results = append(wg.results, WorkerResult{PhaseID: phaseID, BeadID: ps.BeadID})
 			wg.progress.SaveState()
 			wg.progress.ReportProgress()
@@ -74,7 +111,7 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 		// The loop exited early due to a struggle signal, but decomposition
 		// is not enabled for this phase. Mark as failed — the phase did not
 		// complete its review cycle.
-		wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("phase %q exited due to struggle but auto-decomposition is disabled", phaseID), done, failed, inFlight)
+		wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("phase %q exited due to struggle but auto-decomposition is disabled", phaseID), done, failed, inFlight, nil)
 		return
 	}
 
@@ -82,6 +119,12 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 		if commitErr := wg.Committer.CommitPhase(ctx, wg.Nebula.Manifest.Nebula.Name, phaseID, phase.Title); commitErr != nil {
 			fmt.Fprintf(wg.logger(), "warning: failed to commit phase %q: %v\n", phaseID, commitErr)
 		}
+		wg.squashPhaseCommits(ctx, phase, phaseResult)
+	}
+
+	var checks []CheckResult
+	if err == nil && phaseResult != nil {
+		checks, err = wg.runPhaseChecks(ctx, phase, exec)
 	}
 
 	var cp *Checkpoint
@@ -92,17 +135,24 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 			fmt.Fprintf(wg.logger(), "warning: failed to build checkpoint for %q: %v\n", phaseID, cpErr)
 		}
 	}
+	if cp == nil && len(checks) > 0 {
+		cp = &Checkpoint{PhaseID: phaseID, NebulaName: wg.Nebula.Manifest.Nebula.Name, Status: PhaseStatusDone}
+	}
+	if cp != nil {
+		cp.Checks = checks
+	}
 
 	if err == nil {
-		action, gateErr := wg.Gater.PhaseGate(ctx, phase, cp)
+		action, actor, gateErr := wg.phaseGate(ctx, phase, cp, phaseResult)
 		if gateErr != nil {
 			fmt.Fprintf(wg.logger(), "warning: gate failed for phase %q: %v\n", phaseID, gateErr)
 		}
+		wg.audit(AuditRecord{Event: AuditGateDecision, Phase: phaseID, Action: string(action), Actor: actor})
 		switch action {
 		case GateActionAccept:
 			// Fall through to recordResult.
 		case GateActionReject:
-			wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("phase %q rejected at gate", phaseID), done, failed, inFlight)
+			wg.recordResult(phaseID, ps, phaseResult, fmt.Errorf("phase %q rejected at gate", phaseID), done, failed, inFlight, nil)
 			wg.mu.Lock()
 			wg.gateSignals = append(wg.gateSignals, gateSignal{phaseID: phaseID, action: GateActionReject})
 			wg.mu.Unlock()
@@ -110,13 +160,14 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 		case GateActionRetry:
 			wg.mu.Lock()
 			delete(inFlight, phaseID)
+			wg.chargeRun(phaseID, ps, phaseResult)
 			wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusInProgress)
 			wg.progress.SaveState()
 			wg.gateSignals = append(wg.gateSignals, gateSignal{phaseID: phaseID, action: GateActionRetry})
 			wg.mu.Unlock()
 			return
 		case GateActionSkip:
-			wg.recordResult(phaseID, ps, phaseResult, nil, done, failed, inFlight)
+			wg.recordResult(phaseID, ps, phaseResult, nil, done, failed, inFlight, nil)
 			wg.mu.Lock()
 			wg.gateSignals = append(wg.gateSignals, gateSignal{phaseID: phaseID, action: GateActionSkip})
 			wg.mu.Unlock()
@@ -124,7 +175,14 @@ func (wg *WorkerGroup) executePhase(ctx context.Context, phaseID string, waveNum
 		}
 	}
 
-	wg.recordResult(phaseID, ps, phaseResult, err, done, failed, inFlight)
+	var artifacts []string
+	if err == nil {
+		artifacts = wg.collectArtifacts(phase)
+		wg.storeCachedPhase(phaseID, cacheKey, phaseResult)
+	} else {
+		wg.preserveFailedWork(ctx, phaseID, ps, phaseResult, err)
+	}
+	wg.recordResult(phaseID, ps, phaseResult, err, done, failed, inFlight, artifacts)
 
 	// Publish entanglements and update fabric state on successful completion.
 	if err == nil {
@@ -140,14 +198,28 @@ func (wg *WorkerGroup) recordResult(
 	phaseResult *PhaseRunnerResult,
 	err error,
 	done, failed, inFlight map[string]bool,
+	artifacts []string,
 ) {
 	wg.mu.Lock()
 	defer wg.mu.Unlock()
 
 	delete(inFlight, phaseID)
-	wr := WorkerResult{PhaseID: phaseID, BeadID: ps.BeadID, Err: err}
+	wg.releaseBudget(phaseID)
+	wr := WorkerResult{PhaseID: phaseID, BeadID: ps.BeadID, Err: err, Artifacts: artifacts, RetrySpentUSD: ps.RetrySpentUSD, Cached: ps.DoneReason == DoneReasonCached, TimeoutAction: wg.timeoutActions[phaseID]}
+	if err != nil {
+		wr.Preserved = ps.Preserved
+		if phaseResult != nil {
+			wr.Findings = lastCycleFindings(phaseResult.AllFindings)
+		}
+	} else {
+		ps.Preserved = ""
+	}
 	if phaseResult != nil {
 		wg.State.TotalCostUSD += phaseResult.TotalCostUSD
+		ps.CostUSD += phaseResult.TotalCostUSD
+		if phaseResult.TotalCostUSD > 0 {
+			wg.audit(AuditRecord{Event: AuditCost, Phase: phaseID, CostUSD: phaseResult.TotalCostUSD, TotalCostUSD: wg.State.TotalCostUSD})
+		}
 	}
 	if err == nil && phaseResult != nil && phaseResult.Report != nil {
 		wr.Report = phaseResult.Report
@@ -159,9 +231,15 @@ func (wg *WorkerGroup) recordResult(
 		failed[phaseID] = true
 		done[phaseID] = true
 		wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusFailed)
+		wg.audit(AuditRecord{Event: AuditPhaseFail, Phase: phaseID, Detail: err.Error()})
+		if wg.abortErr == nil && wg.Nebula.Manifest.Execution.OnFailure == FailurePolicyAbort {
+			wg.abortErr = fmt.Errorf("%w: phase %q: %w", ErrAbortedOnFailure, phaseID, err)
+			wg.abortPhase = phaseID
+		}
 	} else {
 		done[phaseID] = true
 		wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusDone)
+		wg.audit(AuditRecord{Event: AuditPhaseComplete, Phase: phaseID})
 	}
 	wg.progress.SaveState()
 	wg.progress.ReportProgress()
@@ -178,312 +256,10 @@ func (wg *WorkerGroup) recordFailure(phaseID string) {
 	wg.tracker.Failed()[phaseID] = true
 	wg.tracker.Done()[phaseID] = true
 	delete(wg.tracker.InFlight(), phaseID)
+	wg.releaseBudget(phaseID)
 	wg.results = append(wg.results, WorkerResult{
 		PhaseID: phaseID,
 		Err:     fmt.Errorf("no bead ID for phase %q", phaseID),
 	})
 	wg.mu.Unlock()
 }
-
-// checkInterventions drains the intervention channel and returns the most
-// significant pending intervention (stop > retry > pause > none).
-func (wg *WorkerGroup) checkInterventions() InterventionKind {
-	if wg.Watcher == nil {
-		return ""
-	}
-	var latest InterventionKind
-	for {
-		select {
-		case kind := <-wg.Watcher.Interventions:
-			if kind == InterventionStop {
-				return InterventionStop
-			}
-			if kind == InterventionRetry {
-				wg.handleRetry()
-				continue
-			}
-			if kind == InterventionPause {
-				latest = InterventionPause
-			}
-		default:
-			return latest
-		}
-	}
-}
-
-// handlePause blocks until the PAUSE file is removed from the nebula directory.
-func (wg *WorkerGroup) handlePause() {
-	pausePath := filepath.Join(wg.Nebula.Dir, "PAUSE")
-	fmt.Fprintf(wg.logger(), "\n── Nebula paused ──────────────────────────────────\n")
-	fmt.Fprintf(wg.logger(), "   Remove the PAUSE file to continue:\n")
-	fmt.Fprintf(wg.logger(), "   rm %s\n", pausePath)
-	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
-
-	if _, err := os.Stat(pausePath); os.IsNotExist(err) {
-		return
-	}
-
-	for kind := range wg.Watcher.Interventions {
-		if kind == InterventionResume {
-			return
-		}
-		if kind == InterventionStop {
-			wg.Watcher.SendIntervention(InterventionStop)
-			return
-		}
-	}
-}
-
-// handleStop saves state, cleans up the STOP file, and prints a message.
-func (wg *WorkerGroup) handleStop() {
-	wg.mu.Lock()
-	wg.progress.SaveState()
-	wg.mu.Unlock()
-
-	stopPath := filepath.Join(wg.Nebula.Dir, "STOP")
-	if err := os.Remove(stopPath); err != nil {
-		fmt.Fprintf(wg.logger(), "warning: failed to remove STOP file: %v\n", err)
-	}
-
-	fmt.Fprintf(wg.logger(), "\n── Nebula stopped by user ─────────────────────────\n")
-	fmt.Fprintf(wg.logger(), "   State saved. Resume with: quasar nebula apply\n")
-	fmt.Fprintf(wg.logger(), "───────────────────────────────────────────────────\n\n")
-}
-
-// handleRetry reads the RETRY file, resets the phase, and removes the file.
-func (wg *WorkerGroup) handleRetry() {
-	retryPath := filepath.Join(wg.Nebula.Dir, "RETRY")
-	content, err := os.ReadFile(retryPath)
-	if err != nil {
-		fmt.Fprintf(wg.logger(), "warning: failed to read RETRY file: %v\n", err)
-		return
-	}
-
-	phaseID := strings.TrimSpace(string(content))
-	if phaseID == "" {
-		fmt.Fprintf(wg.logger(), "warning: RETRY file is empty\n")
-		_ = os.Remove(retryPath)
-		return
-	}
-
-	if err := os.Remove(retryPath); err != nil {
-		fmt.Fprintf(wg.logger(), "warning: failed to remove RETRY file: %v\n", err)
-	}
-
-	done := wg.tracker.Done()
-	failed := wg.tracker.Failed()
-	inFlight := wg.tracker.InFlight()
-
-	wg.mu.Lock()
-	defer wg.mu.Unlock()
-
-	if !failed[phaseID] {
-		fmt.Fprintf(wg.logger(), "warning: phase %q is not failed, ignoring retry\n", phaseID)
-		return
-	}
-
-	delete(failed, phaseID)
-	delete(done, phaseID)
-	delete(inFlight, phaseID)
-
-	ps := wg.State.Phases[phaseID]
-	if ps != nil {
-		wg.State.SetPhaseState(phaseID, ps.BeadID, PhaseStatusInProgress)
-		wg.progress.SaveState()
-	}
-
-	fmt.Fprintf(wg.logger(), "\n── Retrying phase %q ──────────────────────────────\n\n", phaseID)
-}
-
-// processGateSignals handles pending gate signals after a batch completes.
-// Returns true if the dispatch loop should stop, along with any error.
-// Must NOT be called with wg.mu held.
-func (wg *WorkerGroup) processGateSignals() (stop bool, err error) {
-	wg.mu.Lock()
-	signals := wg.drainGateSignals()
-	wg.mu.Unlock()
-
-	for _, sig := range signals {
-		switch sig.action {
-		case GateActionReject:
-			wg.mu.Lock()
-			wg.tracker.MarkRemainingSkipped(wg.Nebula.Phases, wg.State)
-			wg.progress.SaveState()
-			wg.mu.Unlock()
-			return true, fmt.Errorf("phase %q rejected at gate", sig.phaseID)
-
-		case GateActionSkip:
-			wg.mu.Lock()
-			wg.tracker.MarkRemainingSkipped(wg.Nebula.Phases, wg.State)
-			wg.progress.SaveState()
-			wg.mu.Unlock()
-			return true, nil
-
-		case GateActionRetry:
-			// Phase already removed from inFlight; re-eligible next iteration.
-		}
-	}
-	return false, nil
-}
-
-// shouldDecompose checks whether a phase is eligible for auto-decomposition.
-// Decomposition is disabled for phases that were themselves produced by
-// decomposition (to prevent infinite recursion), and when the manifest or
-// per-phase override disables auto_decompose.
-func (wg *WorkerGroup) shouldDecompose(phase *PhaseSpec) bool {
-	if phase.Decomposed {
-		return false
-	}
-	if wg.Invoker == nil {
-		return false
-	}
-	// Per-phase override takes precedence over the manifest default.
-	if phase.AutoDecompose != nil {
-		return *phase.AutoDecompose
-	}
-	return wg.Nebula.Manifest.Execution.AutoDecompose
-}
-
-// decomposePhase invokes the architect to decompose a struggling phase and
-// applies the resulting sub-phases to the DAG. It returns the IDs of the
-// newly created sub-phases. Must NOT be called with wg.mu held.
-func (wg *WorkerGroup) decomposePhase(ctx context.Context, phaseID string, result *PhaseRunnerResult) ([]string, error) {
-	wg.mu.Lock()
-	phasesByID := wg.tracker.PhasesByIDMap()
-	phase := phasesByID[phaseID]
-	nebSnap := wg.Nebula.Snapshot()
-	wg.mu.Unlock()
-
-	if phase == nil {
-		return nil, fmt.Errorf("phase %q not found in tracker", phaseID)
-	}
-
-	req := ArchitectRequest{
-		Mode:           ArchitectModeDecompose,
-		UserPrompt:     phase.Body,
-		Nebula:         nebSnap,
-		PhaseID:        phaseID,
-		StruggleReason: result.StruggleReason,
-		CyclesUsed:     result.CyclesUsed,
-		AllFindings:    result.AllFindings,
-		CostSoFar:      result.TotalCostUSD,
-	}
-
-	decomp, err := RunDecompose(ctx, wg.Invoker, req)
-	if err != nil {
-		return nil, fmt.Errorf("running decompose for %s: %w", phaseID, err)
-	}
-
-	// Build the DecomposeOp from the architect result.
-	op := DecomposeOp{
-		OriginalPhaseID: phaseID,
-		SubPhases:       make([]SubPhaseEntry, len(decomp.SubPhases)),
-	}
-	for i, sp := range decomp.SubPhases {
-		sp.PhaseSpec.Decomposed = true
-		op.SubPhases[i] = SubPhaseEntry{
-			Spec:     sp.PhaseSpec,
-			Body:     sp.Body,
-			Filename: sp.Filename,
-		}
-	}
-
-	// Apply decomposition under lock.
-	wg.mu.Lock()
-
-	// Build live graph if hot-reload state is available, otherwise build from phases.
-	var liveGraph *dag.DAG
-	var livePhasesMap map[string]*PhaseSpec
-	if wg.hotReload != nil && wg.hotReload.liveGraph != nil {
-		liveGraph = wg.hotReload.liveGraph
-		livePhasesMap = wg.hotReload.livePhasesByID
-	}
-	if liveGraph == nil {
-		// Fallback: build from phases.
-		g, _ := phasesToDAG(wg.Nebula.Phases)
-		liveGraph = g
-		livePhasesMap = PhasesByID(wg.Nebula.Phases)
-	}
-
-	subIDs, err := ApplyDecompositionToNebula(wg.Nebula, liveGraph, op, livePhasesMap)
-	if err != nil {
-		wg.mu.Unlock()
-		return nil, fmt.Errorf("applying decomposition for %s: %w", phaseID, err)
-	}
-	wg.mu.Unlock()
-
-	// Set fabric state for the original phase (no lock needed for fabric RPCs).
-	if wg.Fabric != nil {
-		if stateErr := wg.Fabric.SetPhaseState(ctx, phaseID, fabric.StateDecomposed); stateErr != nil {
-			fmt.Fprintf(wg.logger(), "warning: failed to set fabric state for decomposed phase %s: %v\n", phaseID, stateErr)
-		}
-	}
-
-	// Create beads for sub-phases outside the lock to avoid panics from
-	// a deferred Unlock when the RPC is in an unlocked state.
-	type beadResult struct {
-		specID string
-		beadID string
-		body   string
-		ok     bool
-	}
-	var beadResults []beadResult
-	for _, sp := range op.SubPhases {
-		br := beadResult{specID: sp.Spec.ID}
-		if wg.BeadsClient != nil {
-			id, createErr := wg.BeadsClient.Create(ctx, sp.Spec.Title, beads.CreateOpts{
-				Description: sp.Body,
-				Type:        sp.Spec.Type,
-				Labels:      sp.Spec.Labels,
-				Assignee:    sp.Spec.Assignee,
-				Priority:    priorityStr(sp.Spec.Priority),
-			})
-			if createErr != nil {
-				fmt.Fprintf(wg.logger(), "warning: failed to create bead for sub-phase %q: %v\n", sp.Spec.ID, createErr)
-				continue
-			}
-			br.beadID = id
-		}
-		br.ok = true
-		beadResults = append(beadResults, br)
-	}
-
-	// Apply bead results and fabric state under lock.
-	wg.mu.Lock()
-	for _, br := range beadResults {
-		if !br.ok {
-			continue
-		}
-		wg.State.SetPhaseState(br.specID, br.beadID, PhaseStatusPending)
-
-		// Set fabric state for sub-phase.
-		if wg.Fabric != nil {
-			if stateErr := wg.Fabric.SetPhaseState(ctx, br.specID, fabric.StateQueued); stateErr != nil {
-				fmt.Fprintf(wg.logger(), "warning: failed to set fabric state for sub-phase %s: %v\n", br.specID, stateErr)
-			}
-		}
-	}
-
-	wg.progress.SaveState()
-	wg.progress.ReportProgress()
-	wg.mu.Unlock()
-
-	// Notify TUI of hot-added sub-phases (callbacks must not hold the lock).
-	if wg.OnHotAdd != nil {
-		for _, sp := range op.SubPhases {
-			wg.OnHotAdd(sp.Spec.ID, sp.Spec.Title, sp.Spec.DependsOn)
-		}
-	}
-
-	// Post a hail if configured.
-	if wg.OnHail != nil {
-		wg.OnHail(phaseID, fabric.Discovery{
-			Kind:   "decomposition",
-			Detail: fmt.Sprintf("Phase %q decomposed into %d sub-phases: %s (reason: %s)", phaseID, len(subIDs), strings.Join(subIDs, ", "), result.StruggleReason),
-		})
-	}
-
-	fmt.Fprintf(wg.logger(), "phase %q decomposed into %d sub-phases: %s\n", phaseID, len(subIDs), strings.Join(subIDs, ", "))
-
-	return subIDs, nil
-}
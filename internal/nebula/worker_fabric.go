@@ -172,6 +172,18 @@ func (wg *WorkerGroup) fabricPhaseComplete(ctx context.Context, phaseID string,
 	}
 }
 
+// recordConflict counts a file-level conflict reported by the publisher and
+// forwards it to OnConflict.
+func (wg *WorkerGroup) recordConflict(c fabric.FileConflict) {
+	if wg.Metrics != nil {
+		wg.Metrics.RecordConflict(c.Phase)
+	}
+	wg.events.publish(StreamEvent{Kind: StreamEventConflict, Phase: c.Phase, Conflict: &c})
+	if wg.OnConflict != nil {
+		wg.OnConflict(c)
+	}
+}
+
 // reevaluateBlocked re-polls all blocked phases via the Tycho scheduler.
 // Must NOT be called with wg.mu held.
 func (wg *WorkerGroup) reevaluateBlocked(ctx context.Context) {
@@ -510,6 +510,34 @@ func TestFabricPhaseComplete(t *testing.T) {
 	})
 }
 
+func TestWorkerGroupRecordConflict(t *testing.T) {
+	t.Parallel()
+
+	m := NewMetrics("test")
+	m.RecordPhaseStart("b", 1)
+	var got []fabric.FileConflict
+	wg := &WorkerGroup{
+		Metrics:    m,
+		OnConflict: func(c fabric.FileConflict) { got = append(got, c) },
+	}
+
+	c := fabric.FileConflict{File: "main.go", Phase: "b", Owner: "a"}
+	wg.recordConflict(c)
+
+	if len(got) != 1 || got[0] != c {
+		t.Errorf("OnConflict got %+v, want [%+v]", got, c)
+	}
+	if m.TotalConflicts != 1 {
+		t.Errorf("TotalConflicts = %d, want 1", m.TotalConflicts)
+	}
+	if !m.Phases[0].Conflict {
+		t.Error("expected phase b to be marked as conflicted")
+	}
+
+	// Nil collaborators are tolerated.
+	(&WorkerGroup{}).recordConflict(c)
+}
+
 // --- reevaluateBlocked tests ---
 
 func TestReevaluateBlocked(t *testing.T) {
@@ -3,10 +3,12 @@ package nebula
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/beads"
 	"github.com/papapumpkin/quasar/internal/fabric"
+	"github.com/papapumpkin/quasar/internal/tycho"
 )
 
 // PhaseRunnerResult holds the outcome of a single phase execution.
@@ -19,6 +21,8 @@ type PhaseRunnerResult struct {
 	Decompose      bool               // true if the loop exited due to a struggle signal
 	StruggleReason string             // human-readable reason from StruggleSignal.Reason
 	AllFindings    []DecomposeFinding // accumulated findings at time of decomposition
+	// ForceApproved is set when a human approved the phase over the reviewer.
+	ForceApproved bool
 }
 
 // PhaseRunner is the interface for executing a phase (satisfied by loop.Loop).
@@ -83,6 +87,125 @@ func WithPrompter(p GatePrompter) Option {
 	return func(wg *WorkerGroup) { wg.Prompter = p }
 }
 
+// WithBudgetPrompter enables the interactive "more budget and retry" prompt
+// for phases that exhaust their per-phase budget cap.
+func WithBudgetPrompter(p BudgetPrompter) Option {
+	return func(wg *WorkerGroup) { wg.Budget = p }
+}
+
+// WithBudgetStep sets the extra budget (USD) offered when a phase exhausts
+// its cap. Values <= 0 use DefaultBudgetStepUSD.
+func WithBudgetStep(usd float64) Option {
+	return func(wg *WorkerGroup) { wg.BudgetStep = usd }
+}
+
+// WithNotifier sends gate, escalation hail, and completion notifications.
+// Delivery failures are logged and never stop the run.
+func WithNotifier(n Notifier) Option {
+	return func(wg *WorkerGroup) { wg.Notifier = n }
+}
+
+// WithEventSocket streams the run's state over a unix socket at path so a
+// TUI can attach to a headless run (see EventSocketPath).
+func WithEventSocket(path string) Option {
+	return func(wg *WorkerGroup) { wg.EventSocket = path }
+}
+
+// WithOutputDir persists each phase's agent output under dir, one
+// subdirectory per phase. See OutputLogDir.
+func WithOutputDir(dir string) Option {
+	return func(wg *WorkerGroup) { wg.OutputDir = dir }
+}
+
+// WithWorkDir sets the directory that phase artifact globs resolve against.
+func WithWorkDir(dir string) Option {
+	return func(wg *WorkerGroup) { wg.WorkDir = dir }
+}
+
+// WithCheckRunner sets how phase checks are run, in place of shell commands.
+func WithCheckRunner(r CheckRunner) Option {
+	return func(wg *WorkerGroup) { wg.CheckRunner = r }
+}
+
+// WithTimeoutAction sets what a timed-out phase does when the phase itself
+// sets no timeout_action, overriding the manifest's execution.timeout_action.
+func WithTimeoutAction(a TimeoutAction) Option {
+	return func(wg *WorkerGroup) { wg.TimeoutAction = a }
+}
+
+// WithParams sets the launch params substituted into phase prompts.
+func WithParams(params map[string]string) Option {
+	return func(wg *WorkerGroup) { wg.Params = params }
+}
+
+// WithPausePoll sets how often a paused run checks that the PAUSE file
+// still exists.
+func WithPausePoll(d time.Duration) Option {
+	return func(wg *WorkerGroup) { wg.PausePoll = d }
+}
+
+// WithEditDebounce sets the window in which repeated edits to one phase
+// file in watch mode collapse into a single refactor. 0 disables coalescing.
+func WithEditDebounce(d time.Duration) Option {
+	return func(wg *WorkerGroup) { wg.EditDebounce = d }
+}
+
+// WithDeterministic makes dispatch order reproducible across runs of the
+// same nebula: phases run in batches and results are sorted by phase ID.
+func WithDeterministic(on bool) Option {
+	return func(wg *WorkerGroup) { wg.Deterministic = on }
+}
+
+// WithDispatchJitter makes each dispatched phase wait a random delay of up
+// to maxDelay before it starts. 0 disables the jitter.
+func WithDispatchJitter(maxDelay time.Duration) Option {
+	return func(wg *WorkerGroup) { wg.DispatchJitter = maxDelay }
+}
+
+// WithMaxRuntime stops the run from starting new phases once it has run
+// for d, letting running phases finish. 0 disables the limit.
+func WithMaxRuntime(d time.Duration) Option {
+	return func(wg *WorkerGroup) { wg.MaxRuntime = d }
+}
+
+// WithEscalateLowConfidence puts approvals the reviewer marked CONFIDENCE:
+// low to a human, even in trust or watch gate mode.
+func WithEscalateLowConfidence(on bool) Option {
+	return func(wg *WorkerGroup) { wg.EscalateLowConfidence = on }
+}
+
+// WithPhaseCache enables the phase cache: a phase whose prompt, execution
+// settings, and dependency outputs match its last successful run is marked
+// done without running. The cache lives in the nebula directory.
+func WithPhaseCache(on bool) Option {
+	return func(wg *WorkerGroup) { wg.PhaseCache = on }
+}
+
+// WithSquashPhaseCommits squashes each completed phase's cycle commits and
+// phase commit into one commit summarizing the cycles and the review.
+// It has no effect when more than one worker runs.
+func WithSquashPhaseCommits(on bool) Option {
+	return func(wg *WorkerGroup) { wg.SquashPhaseCommits = on }
+}
+
+// WithPreserveFailedWorktrees keeps the uncommitted work of each failed
+// phase on a quasar/failed/<phase> branch so it can be inspected later.
+func WithPreserveFailedWorktrees(on bool) Option {
+	return func(wg *WorkerGroup) { wg.PreserveFailedWorktrees = on }
+}
+
+// WithStaleRemediator sets what tycho does about fabric claims and blocked
+// phases that stay stale, overriding the manifest's stale_action.
+func WithStaleRemediator(r tycho.Remediator) Option {
+	return func(wg *WorkerGroup) { wg.StaleRemediator = r }
+}
+
+// WithStateBackups keeps the last n state files (nebula.state.toml.1 through
+// .n) each time the state is saved, pruning older ones.
+func WithStateBackups(n int) Option {
+	return func(wg *WorkerGroup) { wg.StateBackups = n }
+}
+
 // WithDashboard enables dashboard output coordination in watch mode.
 func WithDashboard(d *Dashboard) Option {
 	return func(wg *WorkerGroup) { wg.Dashboard = d }
@@ -118,6 +241,18 @@ func WithOnRefactor(f func(phaseID string, pending bool)) Option {
 	return func(wg *WorkerGroup) { wg.OnRefactor = f }
 }
 
+// WithAuditLog appends a timestamped JSON line to w for every significant
+// event of a run: phase starts and outcomes, gate decisions and who made
+// them, interventions, hot-adds, and cost changes. Writes are serialized.
+func WithAuditLog(w io.Writer) Option {
+	return func(wg *WorkerGroup) { wg.AuditLog = w }
+}
+
+// WithOnSkip sets the callback for phases skipped when a run stops early.
+func WithOnSkip(f func(phaseID, reason string)) Option {
+	return func(wg *WorkerGroup) { wg.OnSkip = f }
+}
+
 // WithOnHotAdd sets a callback invoked after a phase is dynamically inserted.
 func WithOnHotAdd(f HotAddFunc) Option {
 	return func(wg *WorkerGroup) { wg.OnHotAdd = f }
@@ -0,0 +1,48 @@
+package nebula
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandleRetryMultiplePhases(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	n := &Nebula{
+		Dir:      dir,
+		Manifest: Manifest{Nebula: Info{Name: "test"}},
+		Phases:   []PhaseSpec{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+	}
+	state := &State{Version: 1, Phases: map[string]*PhaseState{
+		"a": {BeadID: "bead-a", Status: PhaseStatusFailed},
+		"b": {BeadID: "bead-b", Status: PhaseStatusFailed},
+		"c": {BeadID: "bead-c", Status: PhaseStatusDone},
+	}}
+	wg := NewWorkerGroup(n, state, WithLogger(io.Discard))
+	wg.tracker = NewPhaseTracker(n.Phases, state)
+	wg.progress = NewProgressReporter(n, state, nil, nil, io.Discard)
+
+	if err := os.WriteFile(filepath.Join(dir, "RETRY"), []byte("a\nb\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wg.handleRetry()
+
+	failed := wg.tracker.Failed()
+	for _, id := range []string{"a", "b"} {
+		if failed[id] {
+			t.Errorf("phase %s still tracked as failed", id)
+		}
+		if got := state.Phases[id].Status; got != PhaseStatusInProgress {
+			t.Errorf("phase %s status = %s, want in_progress", id, got)
+		}
+	}
+	if got := state.Phases["c"].Status; got != PhaseStatusDone {
+		t.Errorf("done phase c was reset to %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "RETRY")); !os.IsNotExist(err) {
+		t.Error("RETRY file was not removed")
+	}
+}
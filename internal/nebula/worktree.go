@@ -0,0 +1,55 @@
+package nebula
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Status returns the porcelain status lines for the whole repository,
+// excluding the given paths.
+func (g *gitCommitter) Status(ctx context.Context, exclude ...string) ([]string, error) {
+	args := []string{"-C", g.dir, "status", "--porcelain", "--untracked-files=all", "--", ":/"}
+	for _, p := range exclude {
+		args = append(args, ":(exclude)"+p)
+	}
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	raw := strings.TrimRight(string(out), "\n")
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	return strings.Split(raw, "\n"), nil
+}
+
+// maxDirtyFilesShown caps how many paths CheckCleanWorkTree lists.
+const maxDirtyFilesShown = 5
+
+// CheckCleanWorkTree returns an error wrapping ErrDirtyWorkTree when the
+// repository has uncommitted changes outside exclude, which the first phase
+// commit would otherwise sweep up. A nil committer (not a git repository)
+// always passes.
+func CheckCleanWorkTree(ctx context.Context, c GitCommitter, exclude ...string) error {
+	if c == nil {
+		return nil
+	}
+	changes, err := c.Status(ctx, exclude...)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	shown := make([]string, 0, maxDirtyFilesShown)
+	for _, line := range changes {
+		if len(shown) == maxDirtyFilesShown {
+			shown = append(shown, fmt.Sprintf("and %d more", len(changes)-maxDirtyFilesShown))
+			break
+		}
+		shown = append(shown, strings.TrimSpace(line))
+	}
+	return fmt.Errorf("%w: %d uncommitted change(s): %s", ErrDirtyWorkTree, len(changes), strings.Join(shown, ", "))
+}
@@ -98,6 +98,7 @@ func WriteNebula(result *GenerateResult, outputDir string, opts WriteOptions) er
 // marshalManifest serializes a Manifest to TOML bytes suitable for writing
 // as nebula.toml.
 func marshalManifest(m Manifest) ([]byte, error) {
+	m.Version = CurrentManifestVersion
 	data, err := toml.Marshal(m)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling manifest to TOML: %w", err)
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// errStreamLost is reported when an attached event stream ends before the
+// nebula run finished.
+var errStreamLost = errors.New("connection to nebula lost")
+
+// SnapshotPhases converts the phases of an event-stream snapshot into the
+// PhaseInfo slice used to initialize the nebula view.
+func SnapshotPhases(snap *nebula.StreamSnapshot) []PhaseInfo {
+	phases := make([]PhaseInfo, len(snap.Phases))
+	for i, p := range snap.Phases {
+		phases[i] = PhaseInfo{
+			ID:         p.ID,
+			Title:      p.Title,
+			DependsOn:  p.DependsOn,
+			SourceFile: p.SourceFile,
+			Status:     PhaseStatusFromString(string(p.Status)),
+			SkipReason: p.SkipReason,
+		}
+	}
+	return phases
+}
+
+// FollowEvents decodes newline-delimited nebula.StreamEvents from r and
+// forwards them to p until the run finishes or the stream ends. A stream
+// that ends without a done event is reported as a failed run.
+func FollowEvents(r io.Reader, p *tea.Program) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var ev nebula.StreamEvent
+		if err := dec.Decode(&ev); err != nil {
+			p.Send(MsgNebulaDone{Err: errStreamLost})
+			return
+		}
+		for _, msg := range streamMsgs(ev) {
+			p.Send(msg)
+		}
+		if ev.Kind == nebula.StreamEventDone {
+			return
+		}
+	}
+}
+
+// streamMsgs translates one stream event into the TUI messages an
+// in-process run would have sent.
+func streamMsgs(ev nebula.StreamEvent) []tea.Msg {
+	switch ev.Kind {
+	case nebula.StreamEventSnapshot:
+		if ev.Snapshot == nil {
+			return nil
+		}
+		return []tea.Msg{
+			MsgNebulaInit{Name: ev.Snapshot.Name, Icon: ev.Snapshot.Icon, Color: ev.Snapshot.Color, Phases: SnapshotPhases(ev.Snapshot)},
+			progressMsg(ev.Snapshot.Progress),
+		}
+	case nebula.StreamEventProgress:
+		if ev.Progress == nil {
+			return nil
+		}
+		return []tea.Msg{progressMsg(*ev.Progress)}
+	case nebula.StreamEventPhase:
+		switch ev.Status {
+		case nebula.PhaseStatusInProgress:
+			return []tea.Msg{MsgPhaseTaskStarted{PhaseID: ev.Phase}}
+		case nebula.PhaseStatusDone:
+			return []tea.Msg{MsgPhaseTaskComplete{PhaseID: ev.Phase}}
+		case nebula.PhaseStatusFailed:
+			return []tea.Msg{MsgPhaseError{PhaseID: ev.Phase, Msg: "failed"}}
+		default:
+			return []tea.Msg{MsgPhaseStatus{PhaseID: ev.Phase, Status: PhaseStatusFromString(string(ev.Status)), Reason: ev.Reason}}
+		}
+	case nebula.StreamEventHail:
+		if ev.Hail == nil {
+			return nil
+		}
+		return []tea.Msg{MsgHail{PhaseID: ev.Phase, Discovery: *ev.Hail}}
+	case nebula.StreamEventConflict:
+		if ev.Conflict == nil {
+			return nil
+		}
+		return []tea.Msg{MsgConflict{PhaseID: ev.Conflict.Phase, OtherPhaseID: ev.Conflict.Owner, File: ev.Conflict.File}}
+	case nebula.StreamEventDone:
+		done := MsgNebulaDone{Results: make([]nebula.WorkerResult, len(ev.Results))}
+		for i, r := range ev.Results {
+			done.Results[i].PhaseID = r.PhaseID
+			done.Results[i].Artifacts = r.Artifacts
+			if r.Error != "" {
+				done.Results[i].Err = errors.New(r.Error)
+			}
+		}
+		if ev.Error != "" {
+			done.Err = fmt.Errorf("nebula run: %s", ev.Error)
+		}
+		return []tea.Msg{done}
+	default:
+		return nil
+	}
+}
+
+// progressMsg converts stream progress counts into a MsgNebulaProgress.
+func progressMsg(p nebula.StreamProgress) MsgNebulaProgress {
+	return MsgNebulaProgress{
+		Completed:    p.Completed,
+		Total:        p.Total,
+		OpenBeads:    p.OpenBeads,
+		ClosedBeads:  p.ClosedBeads,
+		TotalCostUSD: p.TotalCostUSD,
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestStreamMsgs(t *testing.T) {
+	t.Parallel()
+
+	snap := &nebula.StreamSnapshot{
+		Name: "demo",
+		Phases: []nebula.StreamPhase{
+			{ID: "a", Title: "A", Status: nebula.PhaseStatusDone},
+			{ID: "b", Title: "B", DependsOn: []string{"a"}, Status: nebula.PhaseStatusInProgress},
+		},
+		Progress: nebula.StreamProgress{Completed: 1, Total: 2, TotalCostUSD: 0.25},
+	}
+
+	tests := []struct {
+		name string
+		ev   nebula.StreamEvent
+		want []tea.Msg
+	}{
+		{
+			name: "snapshot initializes the view",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventSnapshot, Snapshot: snap},
+			want: []tea.Msg{
+				MsgNebulaInit{Name: "demo", Phases: []PhaseInfo{
+					{ID: "a", Title: "A", Status: PhaseDone},
+					{ID: "b", Title: "B", DependsOn: []string{"a"}, Status: PhaseWorking},
+				}},
+				MsgNebulaProgress{Completed: 1, Total: 2, TotalCostUSD: 0.25},
+			},
+		},
+		{
+			name: "phase started",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventPhase, Phase: "a", Status: nebula.PhaseStatusInProgress},
+			want: []tea.Msg{MsgPhaseTaskStarted{PhaseID: "a"}},
+		},
+		{
+			name: "phase done",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventPhase, Phase: "a", Status: nebula.PhaseStatusDone},
+			want: []tea.Msg{MsgPhaseTaskComplete{PhaseID: "a"}},
+		},
+		{
+			name: "phase failed",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventPhase, Phase: "a", Status: nebula.PhaseStatusFailed},
+			want: []tea.Msg{MsgPhaseError{PhaseID: "a", Msg: "failed"}},
+		},
+		{
+			name: "phase skipped",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventPhase, Phase: "a", Status: nebula.PhaseStatusSkipped, Reason: "dependency failed"},
+			want: []tea.Msg{MsgPhaseStatus{PhaseID: "a", Status: PhaseSkipped, Reason: "dependency failed"}},
+		},
+		{
+			name: "hail",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventHail, Phase: "a", Hail: &fabric.Discovery{Detail: "help"}},
+			want: []tea.Msg{MsgHail{PhaseID: "a", Discovery: fabric.Discovery{Detail: "help"}}},
+		},
+		{
+			name: "conflict",
+			ev:   nebula.StreamEvent{Kind: nebula.StreamEventConflict, Conflict: &fabric.FileConflict{File: "x.go", Phase: "b", Owner: "a"}},
+			want: []tea.Msg{MsgConflict{PhaseID: "b", OtherPhaseID: "a", File: "x.go"}},
+		},
+		{
+			name: "unknown kind is ignored",
+			ev:   nebula.StreamEvent{Kind: "bogus"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := streamMsgs(tt.ev); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("streamMsgs = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamMsgsDone(t *testing.T) {
+	t.Parallel()
+
+	msgs := streamMsgs(nebula.StreamEvent{
+		Kind:    nebula.StreamEventDone,
+		Results: []nebula.StreamResult{{PhaseID: "a", Artifacts: []string{"cover.out"}}, {PhaseID: "b", Error: "boom"}},
+		Error:   "interrupted",
+	})
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+	done, ok := msgs[0].(MsgNebulaDone)
+	if !ok {
+		t.Fatalf("got %T, want MsgNebulaDone", msgs[0])
+	}
+	if len(done.Results) != 2 || done.Results[0].Err != nil || done.Results[1].Err == nil || done.Results[1].Err.Error() != "boom" {
+		t.Errorf("results = %+v, want a ok and b failed with boom", done.Results)
+	}
+	if len(done.Results[0].Artifacts) != 1 || done.Results[0].Artifacts[0] != "cover.out" {
+		t.Errorf("phase a artifacts = %v, want [cover.out]", done.Results[0].Artifacts)
+	}
+	if done.Err == nil {
+		t.Error("expected the run error to be carried over")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
 )
 
 // Bead status icons.
@@ -26,6 +28,9 @@ type BeadView struct {
 	Root    BeadInfo
 	HasData bool
 	Width   int
+	// Attempts is the phase's run history; listed under the tree once the
+	// phase has been retried.
+	Attempts []nebula.AttemptRecord
 }
 
 // NewBeadView creates an empty bead view.
@@ -64,6 +69,8 @@ func (v BeadView) View() string {
 	if total == 0 {
 		b.WriteString("  ")
 		b.WriteString(styleDetailDim.Render("(no child issues)"))
+		b.WriteString("\n")
+		v.writeAttempts(&b)
 		return b.String()
 	}
 	b.WriteString("  ")
@@ -113,9 +120,48 @@ func (v BeadView) View() string {
 		b.WriteString("\n")
 	}
 
+	v.writeAttempts(&b)
 	return b.String()
 }
 
+// writeAttempts lists each run of a retried phase with its outcome and
+// cost, so a flaky phase shows what all its attempts cost together.
+func (v BeadView) writeAttempts(b *strings.Builder) {
+	if len(v.Attempts) < 2 {
+		return
+	}
+	var total float64
+	for _, a := range v.Attempts {
+		total += a.CostUSD
+	}
+	b.WriteString("\n  ")
+	b.WriteString(styleBeadTitle.Render(fmt.Sprintf("Attempts (%d)", len(v.Attempts))))
+	b.WriteString("  ")
+	b.WriteString(styleDetailDim.Render(fmt.Sprintf("$%.2f total", total)))
+	b.WriteString("\n")
+	for i, a := range v.Attempts {
+		connector := treeConnectorMid
+		if i == len(v.Attempts)-1 {
+			connector = treeConnectorLast
+		}
+		outcome := styleRowFailed.Render(a.Outcome)
+		if a.Outcome == nebula.AttemptOutcomeDone {
+			outcome = styleBeadClosed.Render(a.Outcome)
+		}
+		line := fmt.Sprintf("#%d %s  %d cycle%s  $%.2f", i+1, a.At.Local().Format("15:04"), a.Cycles, pluralS(a.Cycles), a.CostUSD)
+		if a.Model != "" {
+			line += "  " + a.Model
+		}
+		b.WriteString("  ")
+		b.WriteString(connector)
+		b.WriteString(" ")
+		b.WriteString(line)
+		b.WriteString("  ")
+		b.WriteString(outcome)
+		b.WriteString("\n")
+	}
+}
+
 // sortChildrenByCycle returns a copy of children sorted by ascending Cycle number,
 // preserving original order within the same cycle. Children with Cycle <= 0 are
 // treated as cycle 1.
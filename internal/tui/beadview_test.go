@@ -3,8 +3,11 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
 )
 
 func TestBeadViewEmptyState(t *testing.T) {
@@ -351,3 +354,24 @@ func TestMsgPhaseBeadUpdatePopulatesModel(t *testing.T) {
 		t.Errorf("PhaseBeads[\"setup\"].ID = %q, want %q", root.ID, "bead-2")
 	}
 }
+
+func TestBeadViewAttempts(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+	first := nebula.AttemptRecord{Cycles: 3, CostUSD: 1.25, Model: "sonnet", Outcome: nebula.AttemptOutcomeFailed, At: at}
+	second := nebula.AttemptRecord{Cycles: 1, CostUSD: 0.5, Model: "opus", Outcome: nebula.AttemptOutcomeDone, At: at}
+
+	bv := NewBeadView()
+	bv.SetRoot(BeadInfo{ID: "quasar-a1b", Title: "Add JWT auth", Status: "open"})
+	bv.Attempts = []nebula.AttemptRecord{first}
+	if view := bv.View(); strings.Contains(view, "Attempts") {
+		t.Errorf("a single attempt should not be listed, got: %q", view)
+	}
+
+	bv.Attempts = append(bv.Attempts, second)
+	view := bv.View()
+	for _, want := range []string{"Attempts (2)", "$1.75 total", "#1 15:04  3 cycles  $1.25  sonnet", "failed", "#2 15:04  1 cycle  $0.50  opus", "done"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q, got: %q", want, view)
+		}
+	}
+}
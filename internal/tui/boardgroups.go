@@ -0,0 +1,34 @@
+package tui
+
+// SelectedGroup returns the import group of the card under the cursor, or ""
+// when the selected phase is not part of a group.
+func (bv BoardView) SelectedGroup() string {
+	p := bv.SelectedPhase()
+	if p == nil {
+		return ""
+	}
+	if bv.Collapsed[p.ID] {
+		return p.ID
+	}
+	return importGroupOf(p.ID)
+}
+
+// ToggleGroup collapses or expands the import group of the selected card,
+// keeping the cursor on the group.
+func (bv *BoardView) ToggleGroup() {
+	g := bv.SelectedGroup()
+	if g == "" {
+		return
+	}
+	if bv.Collapsed == nil {
+		bv.Collapsed = make(map[string]bool)
+	}
+	bv.Collapsed[g] = !bv.Collapsed[g]
+	cards, buckets := bv.partition()
+	for i, p := range flatOrder(cards, buckets, bv.visibleColumns()) {
+		if inGroup(p.ID, g) {
+			bv.Cursor = i
+			break
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -49,6 +50,9 @@ type BoardView struct {
 	Cursor int
 	Width  int
 	Height int
+
+	HideCompleted bool            // when set, the Done column and its phases are not shown
+	Collapsed     map[string]bool // import groups shown as a single card
 }
 
 // NewBoardView creates an empty board view.
@@ -56,11 +60,12 @@ func NewBoardView() BoardView {
 	return BoardView{}
 }
 
-// SelectedPhase returns the phase entry at the cursor position.
+// SelectedPhase returns the phase entry at the cursor position. A collapsed
+// import group is returned as an entry whose ID is the group name.
 func (bv BoardView) SelectedPhase() *PhaseEntry {
-	buckets := bv.partition()
+	cards, buckets := bv.partition()
 	cols := bv.visibleColumns()
-	flat := bv.flatOrder(buckets, cols)
+	flat := flatOrder(cards, buckets, cols)
 	if bv.Cursor < 0 || bv.Cursor >= len(flat) {
 		return nil
 	}
@@ -78,9 +83,9 @@ func (bv *BoardView) MoveUp() {
 // MoveDown moves the cursor to the next phase in the flat column-first order,
 // which may cross column boundaries. Use MoveLeft/MoveRight to jump between columns.
 func (bv *BoardView) MoveDown() {
-	buckets := bv.partition()
+	cards, buckets := bv.partition()
 	cols := bv.visibleColumns()
-	flat := bv.flatOrder(buckets, cols)
+	flat := flatOrder(cards, buckets, cols)
 	max := len(flat) - 1
 	if max < 0 {
 		max = 0
@@ -92,9 +97,9 @@ func (bv *BoardView) MoveDown() {
 
 // MoveLeft moves the cursor to the previous column (first item in it).
 func (bv *BoardView) MoveLeft() {
-	buckets := bv.partition()
+	cards, buckets := bv.partition()
 	cols := bv.visibleColumns()
-	flat := bv.flatOrder(buckets, cols)
+	flat := flatOrder(cards, buckets, cols)
 	if len(flat) == 0 {
 		return
 	}
@@ -123,9 +128,9 @@ func (bv *BoardView) MoveLeft() {
 
 // MoveRight moves the cursor to the next column (first item in it).
 func (bv *BoardView) MoveRight() {
-	buckets := bv.partition()
+	cards, buckets := bv.partition()
 	cols := bv.visibleColumns()
-	flat := bv.flatOrder(buckets, cols)
+	flat := flatOrder(cards, buckets, cols)
 	if len(flat) == 0 {
 		return
 	}
@@ -150,19 +155,24 @@ func (bv *BoardView) MoveRight() {
 	bv.Cursor = bv.flatIndexOfColumn(buckets, cols, colIdx)
 }
 
-// partition distributes phases into column buckets based on status.
+// partition folds collapsed import groups into single cards and distributes
+// the cards into column buckets, by index into cards, based on status.
 // It is width-aware: at medium terminal widths where the Blocked column
 // is not visible, its entries are remapped into Queued so that no phases
 // are silently dropped from the board.
-func (bv BoardView) partition() [colCount][]int {
+func (bv BoardView) partition() ([]PhaseEntry, [colCount][]int) {
 	var buckets [colCount][]int
+	cards := foldGroups(bv.Phases, bv.Collapsed)
 	visible := bv.visibleColumns()
 	visibleSet := make(map[BoardColumn]bool, len(visible))
 	for _, c := range visible {
 		visibleSet[c] = true
 	}
-	for i, p := range bv.Phases {
+	for i, p := range cards {
 		col := statusToColumn(p)
+		if col == ColDone && bv.HideCompleted {
+			continue
+		}
 		// At medium width, the Blocked column is not visible.
 		// Remap its entries into Queued so phases are never lost.
 		if !visibleSet[col] {
@@ -170,7 +180,7 @@ func (bv BoardView) partition() [colCount][]int {
 		}
 		buckets[col] = append(buckets[col], i)
 	}
-	return buckets
+	return cards, buckets
 }
 
 // statusToColumn maps a PhaseEntry to its board column.
@@ -196,12 +206,27 @@ func statusToColumn(p PhaseEntry) BoardColumn {
 // On wide terminals (>= 140): all 6 columns.
 // On medium terminals (100-139): merge Blocked→Queued.
 // Below 100: should fall back to table view (caller decides).
+// With HideCompleted, the Done column is left out.
 func (bv BoardView) visibleColumns() []BoardColumn {
-	if bv.Width >= boardWidthFull {
-		return []BoardColumn{ColQueued, ColRunning, ColReview, ColBlocked, ColDone, ColFailed}
+	cols := []BoardColumn{ColQueued, ColRunning, ColReview, ColBlocked, ColDone, ColFailed}
+	if bv.Width < boardWidthFull {
+		// Medium: omit Blocked (its entries stay in Queued).
+		cols = slices.DeleteFunc(cols, func(c BoardColumn) bool { return c == ColBlocked })
 	}
-	// Medium: omit Blocked (its entries stay in Queued).
-	return []BoardColumn{ColQueued, ColRunning, ColReview, ColDone, ColFailed}
+	if bv.HideCompleted {
+		cols = slices.DeleteFunc(cols, func(c BoardColumn) bool { return c == ColDone })
+	}
+	return cols
+}
+
+// Len returns how many cards the board shows.
+func (bv BoardView) Len() int {
+	_, buckets := bv.partition()
+	n := 0
+	for _, col := range bv.visibleColumns() {
+		n += len(buckets[col])
+	}
+	return n
 }
 
 // ShouldFallback returns true when the terminal is too narrow for the board view
@@ -210,13 +235,13 @@ func (bv BoardView) ShouldFallback() bool {
 	return bv.Width > 0 && bv.Width < boardWidthMedium
 }
 
-// flatOrder returns pointers to phases in column-first order matching visible columns.
+// flatOrder returns pointers to cards in column-first order matching visible columns.
 // It accepts pre-computed buckets and columns to avoid redundant partition() calls.
-func (bv BoardView) flatOrder(buckets [colCount][]int, cols []BoardColumn) []*PhaseEntry {
+func flatOrder(cards []PhaseEntry, buckets [colCount][]int, cols []BoardColumn) []*PhaseEntry {
 	var flat []*PhaseEntry
 	for _, col := range cols {
 		for _, idx := range buckets[col] {
-			flat = append(flat, &bv.Phases[idx])
+			flat = append(flat, &cards[idx])
 		}
 	}
 	return flat
@@ -253,9 +278,9 @@ func (bv BoardView) View() string {
 		return ""
 	}
 
-	buckets := bv.partition()
+	cards, buckets := bv.partition()
 	cols := bv.visibleColumns()
-	flat := bv.flatOrder(buckets, cols)
+	flat := flatOrder(cards, buckets, cols)
 
 	colWidth := bv.columnWidth(len(cols))
 
@@ -292,7 +317,7 @@ func (bv BoardView) View() string {
 			sb.WriteString("\n")
 		}
 		for _, phaseIdx := range entries {
-			p := bv.Phases[phaseIdx]
+			p := cards[phaseIdx]
 			selected := flatIdx == bv.Cursor && flatIdx < len(flat)
 
 			sb.WriteString(bv.renderBoardEntry(p, selected, colWidth))
@@ -305,7 +330,12 @@ func (bv BoardView) View() string {
 		rendered = append(rendered, colStyle.Render(sb.String()))
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	if bv.HideCompleted {
+		label := fmt.Sprintf("%d completed hidden", len(cards)-len(flat))
+		board = "  " + styleDetailDim.Render(label) + "\n" + board
+	}
+	return board
 }
 
 // columnWidth computes the width for each column given the number of visible columns.
@@ -19,7 +19,7 @@ func TestBoardViewPartition_CorrectColumns(t *testing.T) {
 		{ID: "blocked", Status: PhaseWaiting, BlockedBy: "running"},
 	}
 
-	buckets := bv.partition()
+	_, buckets := bv.partition()
 
 	tests := []struct {
 		col    BoardColumn
@@ -66,7 +66,7 @@ func TestBoardViewPartition_MediumWidthRemapping(t *testing.T) {
 		{ID: "running", Status: PhaseWorking},
 	}
 
-	buckets := bv.partition()
+	_, buckets := bv.partition()
 
 	// At medium width, blocked phases should be remapped into Queued.
 	if len(buckets[ColQueued]) != 2 {
@@ -465,3 +465,75 @@ func TestStatusToColumn(t *testing.T) {
 		})
 	}
 }
+
+func TestBoardViewHideCompleted(t *testing.T) {
+	t.Parallel()
+	bv := NewBoardView()
+	bv.Width = 150
+	bv.HideCompleted = true
+	bv.Phases = []PhaseEntry{
+		{ID: "running", Status: PhaseWorking},
+		{ID: "done", Status: PhaseDone},
+		{ID: "skipped", Status: PhaseSkipped},
+	}
+
+	for _, col := range bv.visibleColumns() {
+		if col == ColDone {
+			t.Error("Done column visible with HideCompleted")
+		}
+	}
+	if bv.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", bv.Len())
+	}
+	if got := bv.SelectedPhase(); got == nil || got.ID != "running" {
+		t.Errorf("SelectedPhase() = %v, want running", got)
+	}
+	if view := bv.View(); !strings.Contains(view, "2 completed hidden") {
+		t.Errorf("view missing hidden count:\n%s", view)
+	}
+}
+
+func TestBoardViewToggleGroup(t *testing.T) {
+	t.Parallel()
+	bv := NewBoardView()
+	bv.Width = 150
+	bv.Phases = []PhaseEntry{
+		{ID: "setup", Status: PhaseDone},
+		{ID: "backend/migrate", Status: PhaseDone},
+		{ID: "backend/seed", Status: PhaseWorking},
+		{ID: "deploy", Status: PhaseWaiting},
+	}
+	bv.Cursor = 1 // backend/seed, the only running card
+	if g := bv.SelectedGroup(); g != "backend" {
+		t.Fatalf("SelectedGroup = %q, want backend", g)
+	}
+
+	bv.ToggleGroup()
+	if bv.Len() != 3 {
+		t.Fatalf("Len() = %d after collapsing, want 3", bv.Len())
+	}
+	got := bv.SelectedPhase()
+	if got == nil || got.ID != "backend" || got.Status != PhaseWorking {
+		t.Fatalf("SelectedPhase() = %+v, want the running backend group", got)
+	}
+	if view := bv.View(); !strings.Contains(view, "backend (2 phases)") {
+		t.Errorf("view missing group card:\n%s", view)
+	}
+
+	bv.ToggleGroup()
+	if bv.Len() != 4 || importGroupOf(bv.SelectedPhase().ID) != "backend" {
+		t.Errorf("expanding should restore the phases with the cursor on a member, got %d cards at %q",
+			bv.Len(), bv.SelectedPhase().ID)
+	}
+}
+
+func TestBoardViewToggleGroupIgnoresUngroupedPhase(t *testing.T) {
+	t.Parallel()
+	bv := NewBoardView()
+	bv.Width = 150
+	bv.Phases = []PhaseEntry{{ID: "setup"}, {ID: "backend/migrate"}}
+	bv.ToggleGroup() // cursor on setup
+	if bv.Len() != 2 || len(bv.Collapsed) != 0 {
+		t.Errorf("toggling an ungrouped phase should be a no-op, got %d cards, collapsed %v", bv.Len(), bv.Collapsed)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -102,6 +103,11 @@ func (b *UIBridge) BudgetExceeded(spent, limit float64) {
 	b.program.Send(MsgBudgetExceeded{Spent: spent, Limit: limit})
 }
 
+// BudgetWarning sends MsgBudgetWarning.
+func (b *UIBridge) BudgetWarning(spent, limit float64) {
+	b.program.Send(MsgBudgetWarning{Spent: spent, Limit: limit})
+}
+
 // Error sends MsgError.
 func (b *UIBridge) Error(msg string) {
 	b.program.Send(MsgError{Msg: msg})
@@ -119,7 +125,7 @@ func (b *UIBridge) AgentOutput(role string, cycle int, output string) {
 
 // RefactorApplied is a no-op for the single-task UIBridge; refactor indicators
 // are only meaningful in nebula phase views where PhaseUIBridge is used.
-func (b *UIBridge) RefactorApplied(phaseID string) {}
+func (b *UIBridge) RefactorApplied(phaseID, original, updated string) {}
 
 // FindingLifecycle is a no-op for the single-task UIBridge; finding lifecycle
 // data is displayed through the phase detail view.
@@ -141,6 +147,11 @@ func (b *UIBridge) BeadUpdate(taskBeadID, title, status string, children []ui.Be
 	b.program.Send(MsgBeadUpdate{TaskBeadID: taskBeadID, Root: root})
 }
 
+// BeadsDegraded sends MsgBeadsDegraded.
+func (b *UIBridge) BeadsDegraded(buffered int) {
+	b.program.Send(MsgBeadsDegraded{Buffered: buffered})
+}
+
 // buildBeadInfoTree converts a task bead and its children into a BeadInfo tree.
 // Used by both UIBridge and PhaseUIBridge to avoid duplicated conversion logic.
 func buildBeadInfoTree(taskBeadID, title, status string, children []ui.BeadChild) BeadInfo {
@@ -236,7 +247,8 @@ func captureGitDiffStat(workDir, refRange string) []FileStatEntry {
 
 // parseNumstat parses the output of "git diff --numstat" into FileStatEntry
 // slices. Each line has the format: <additions>\t<deletions>\t<path>.
-// Binary files show "-" for additions/deletions and are recorded as zero.
+// Binary files show "-" for additions/deletions and are recorded as Binary
+// with zero counts.
 func parseNumstat(output string) []FileStatEntry {
 	var entries []FileStatEntry
 	for _, line := range strings.Split(output, "\n") {
@@ -254,6 +266,7 @@ func parseNumstat(output string) []FileStatEntry {
 			Path:      parts[2],
 			Additions: adds,
 			Deletions: dels,
+			Binary:    parts[0] == "-",
 		})
 	}
 	return entries
@@ -352,6 +365,12 @@ func (b *PhaseUIBridge) BudgetExceeded(spent, limit float64) {
 	b.ScratchpadNote(b.phaseID, fmt.Sprintf("budget exceeded ($%.2f / $%.2f)", spent, limit))
 }
 
+// BudgetWarning sends MsgBudgetWarning tagged with this phase's ID.
+func (b *PhaseUIBridge) BudgetWarning(spent, limit float64) {
+	b.program.Send(MsgBudgetWarning{PhaseID: b.phaseID, Spent: spent, Limit: limit})
+	b.ScratchpadNote(b.phaseID, fmt.Sprintf("budget warning ($%.2f / $%.2f)", spent, limit))
+}
+
 // Error sends MsgPhaseError.
 func (b *PhaseUIBridge) Error(msg string) {
 	b.program.Send(MsgPhaseError{PhaseID: b.phaseID, Msg: msg})
@@ -369,9 +388,28 @@ func (b *PhaseUIBridge) AgentOutput(role string, cycle int, output string) {
 }
 
 // RefactorApplied sends MsgPhaseRefactorApplied to notify the TUI that the
-// loop consumed the pending refactor for this phase.
-func (b *PhaseUIBridge) RefactorApplied(phaseID string) {
-	b.program.Send(MsgPhaseRefactorApplied{PhaseID: b.phaseID})
+// loop consumed the pending refactor for this phase, with both descriptions
+// so the TUI can show what changed.
+func (b *PhaseUIBridge) RefactorApplied(phaseID, original, updated string) {
+	b.program.Send(MsgPhaseRefactorApplied{PhaseID: b.phaseID, Original: original, Updated: updated})
+}
+
+// ForceApproveChannel returns the channel the phase's loop watches for a
+// human override of its reviewer, sending its other end to the TUI with
+// MsgPhaseOverrideReady.
+func (b *PhaseUIBridge) ForceApproveChannel() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	b.program.Send(MsgPhaseOverrideReady{PhaseID: b.phaseID, Ch: ch})
+	return ch
+}
+
+// ExtendCyclesChannel returns the channel the phase's loop reads extra
+// review cycles from, sending its other end to the TUI with
+// MsgPhaseCyclesReady.
+func (b *PhaseUIBridge) ExtendCyclesChannel() <-chan int {
+	ch := make(chan int, extendCyclesBuffer)
+	b.program.Send(MsgPhaseCyclesReady{PhaseID: b.phaseID, Ch: ch})
+	return ch
 }
 
 // BeadUpdate sends MsgPhaseBeadUpdate with the bead hierarchy for this phase.
@@ -380,6 +418,11 @@ func (b *PhaseUIBridge) BeadUpdate(taskBeadID, title, status string, children []
 	b.program.Send(MsgPhaseBeadUpdate{PhaseID: b.phaseID, TaskBeadID: taskBeadID, Root: root})
 }
 
+// BeadsDegraded sends MsgBeadsDegraded tagged with this phase's ID.
+func (b *PhaseUIBridge) BeadsDegraded(buffered int) {
+	b.program.Send(MsgBeadsDegraded{PhaseID: b.phaseID, Buffered: buffered})
+}
+
 // FindingLifecycle is a no-op for PhaseUIBridge; finding lifecycle data is
 // surfaced through the phase detail view rather than as a standalone message.
 func (b *PhaseUIBridge) FindingLifecycle(cycle int, summary ui.FindingLifecycleData) {}
@@ -434,3 +477,21 @@ func (b *PhaseUIBridge) ScratchpadNote(phaseID, text string) {
 		Text:      text,
 	})
 }
+
+// Heartbeat returns an activity callback, for agent.WithActivity, that sends
+// MsgPhaseHeartbeat at most once a second.
+func (b *PhaseUIBridge) Heartbeat() func() {
+	var mu sync.Mutex
+	var last time.Time
+	return func() {
+		now := time.Now()
+		mu.Lock()
+		if now.Sub(last) < time.Second {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		b.program.Send(MsgPhaseHeartbeat{PhaseID: b.phaseID, At: now})
+	}
+}
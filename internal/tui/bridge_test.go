@@ -54,6 +54,8 @@ func TestUIBridgeMethodsDoNotPanic(t *testing.T) {
 	b.IssuesFound(2)
 	b.Approved()
 	b.MaxCyclesReached(5)
+	b.BudgetWarning(4.0, 5.0)
+	b.BeadsDegraded(3)
 	b.BudgetExceeded(10.0, 5.0)
 	b.Error("test error")
 	b.Info("test info")
@@ -117,6 +119,64 @@ func TestAppModelLoopLifecycle(t *testing.T) {
 	}
 }
 
+func TestAppModelBudgetWarning(t *testing.T) {
+	m := NewAppModel(ModeNebula)
+	m.Width = 120
+	m.Height = 40
+
+	var tm tea.Model = m
+	tm, cmd := tm.Update(MsgBudgetWarning{PhaseID: "p1", Spent: 8, Limit: 10})
+	am := tm.(AppModel)
+	if !am.StatusBar.BudgetWarned {
+		t.Error("StatusBar.BudgetWarned should be set")
+	}
+	if am.StatusBar.BudgetUSD != 10 {
+		t.Errorf("StatusBar.BudgetUSD = %.2f, want 10", am.StatusBar.BudgetUSD)
+	}
+	if len(am.Toasts) != 1 || !strings.Contains(am.Toasts[0].Message, "[p1] budget warning") {
+		t.Errorf("expected budget warning toast, got %+v", am.Toasts)
+	}
+	if cmd == nil {
+		t.Error("expected toast dismiss command")
+	}
+
+	tm, _ = tm.Update(MsgBudgetExceeded{Spent: 10, Limit: 10})
+	am = tm.(AppModel)
+	if !am.StatusBar.BudgetExceeded {
+		t.Error("StatusBar.BudgetExceeded should be set")
+	}
+}
+
+func TestAppModelBeadsDegraded(t *testing.T) {
+	m := NewAppModel(ModeNebula)
+	m.Width = 120
+	m.Height = 40
+
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgBeadsDegraded{PhaseID: "p1", Buffered: 2})
+	tm, _ = tm.Update(MsgBeadsDegraded{PhaseID: "p1", Buffered: 3})
+	am := tm.(AppModel)
+	if am.StatusBar.BeadsBuffered != 3 {
+		t.Errorf("StatusBar.BeadsBuffered = %d, want 3", am.StatusBar.BeadsBuffered)
+	}
+	if len(am.Toasts) != 1 || !am.Toasts[0].IsError {
+		t.Errorf("expected one error toast on entering degraded mode, got %+v", am.Toasts)
+	}
+	am.StatusBar.Width = 160
+	if !strings.Contains(am.StatusBar.View(), "beads: 3 queued") {
+		t.Error("status bar should show the buffered bead count")
+	}
+
+	tm, _ = tm.Update(MsgBeadsDegraded{Buffered: 0})
+	am = tm.(AppModel)
+	if am.StatusBar.BeadsBuffered != 0 {
+		t.Errorf("StatusBar.BeadsBuffered = %d, want 0 after recovery", am.StatusBar.BeadsBuffered)
+	}
+	if len(am.Toasts) != 2 || am.Toasts[1].IsError {
+		t.Errorf("expected a recovery toast, got %+v", am.Toasts)
+	}
+}
+
 func TestAppModelNebulaProgress(t *testing.T) {
 	m := NewAppModel(ModeNebula)
 	m.Detail = NewDetailPanel(80, 10)
@@ -1158,8 +1218,8 @@ func TestParseNumstat(t *testing.T) {
 		if len(entries) != 1 {
 			t.Fatalf("got %d entries, want 1", len(entries))
 		}
-		if entries[0].Path != "image.png" || entries[0].Additions != 0 || entries[0].Deletions != 0 {
-			t.Errorf("entry[0] = %+v, want {image.png +0 -0}", entries[0])
+		if entries[0].Path != "image.png" || entries[0].Additions != 0 || entries[0].Deletions != 0 || !entries[0].Binary {
+			t.Errorf("entry[0] = %+v, want binary {image.png +0 -0}", entries[0])
 		}
 	})
 
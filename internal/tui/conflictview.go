@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ConflictEntry records a file-level conflict between two parallel phases.
+type ConflictEntry struct {
+	PhaseID      string // phase whose change collided
+	OtherPhaseID string // phase already holding the file
+	File         string
+	Time         time.Time
+}
+
+// ToggleConflicts switches the entanglements tab between the entanglement
+// cards and the conflict list.
+func (ev *EntanglementView) ToggleConflicts() {
+	ev.ShowConflicts = !ev.ShowConflicts
+	ev.refreshContent()
+	if ev.ready {
+		ev.viewport.GotoTop()
+	}
+}
+
+// renderConflicts formats the conflict list in arrival order.
+func (ev EntanglementView) renderConflicts() string {
+	if len(ev.Conflicts) == 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	phaseStyle := lipgloss.NewStyle().Foreground(colorBlueshift).Bold(true)
+	fileStyle := lipgloss.NewStyle().Foreground(colorStarYellow)
+	timeStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	var sb strings.Builder
+	noun := "conflicts"
+	if len(ev.Conflicts) == 1 {
+		noun = "conflict"
+	}
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("  ◆ %d file %s", len(ev.Conflicts), noun)))
+	sb.WriteString("\n")
+
+	for _, c := range ev.Conflicts {
+		other := c.OtherPhaseID
+		if other == "" {
+			other = "?"
+		}
+		line := fmt.Sprintf("    %s ⟷ %s  %s",
+			phaseStyle.Render(c.PhaseID),
+			phaseStyle.Render(other),
+			fileStyle.Render(c.File))
+		if !c.Time.IsZero() {
+			line += "  " + timeStyle.Render(c.Time.Format("15:04:05"))
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
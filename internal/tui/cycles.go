@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cycleExtensionStep is how many review cycles one press of the extend key
+// grants a phase.
+const cycleExtensionStep = 2
+
+// extendCyclesBuffer is how many grants can wait for a phase's loop to read
+// them. The loop reads them all before each cycle.
+const extendCyclesBuffer = 8
+
+// setCycleChannel records the channel phaseID's running loop reads extra
+// review cycles from, replacing any left from an earlier attempt.
+func (m *AppModel) setCycleChannel(phaseID string, ch chan<- int) {
+	if m.cycleChs == nil {
+		m.cycleChs = make(map[string]chan<- int)
+	}
+	m.cycleChs[phaseID] = ch
+}
+
+// cycleTargetPhase returns the phase the extend key applies to: the focused
+// phase in its detail views, or the selected phase in the table or board.
+func (m *AppModel) cycleTargetPhase() string {
+	return m.selectedPhaseID()
+}
+
+// selectedPhaseID returns the phase the user is looking at: the focused
+// phase in its detail views, or the selected phase in the table or board.
+// Unlike the key handlers it does not sync the board, so it is safe to call
+// while handling any message.
+func (m AppModel) selectedPhaseID() string {
+	if m.Mode != ModeNebula {
+		return ""
+	}
+	switch m.Depth {
+	case DepthPhaseLoop, DepthAgentOutput:
+		return m.FocusedPhase
+	case DepthPhases:
+		if m.ActiveTab != TabBoard {
+			return ""
+		}
+		p := m.NebulaView.SelectedPhase()
+		if m.BoardActive {
+			board := m.Board
+			board.Phases = m.NebulaView.Phases
+			p = board.SelectedPhase()
+		}
+		if p != nil {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+// handleExtendCyclesKey raises the review cycle limit of the target phase
+// by cycleExtensionStep. Its loop picks the grant up before its next cycle,
+// or instead of giving up after its last one, and reports the new limit
+// when that cycle starts; the table shows it right away.
+func (m *AppModel) handleExtendCyclesKey() tea.Cmd {
+	phaseID := m.cycleTargetPhase()
+	ch, ok := m.cycleChs[phaseID]
+	if !ok {
+		return nil
+	}
+	select {
+	case ch <- cycleExtensionStep:
+	default:
+		toast, cmd := NewToast("["+phaseID+"] has cycle grants waiting; try again after the next cycle", true)
+		m.Toasts = append(m.Toasts, toast)
+		return cmd
+	}
+	for i := range m.NebulaView.Phases {
+		if p := &m.NebulaView.Phases[i]; p.ID == phaseID && p.MaxCycles > 0 {
+			p.MaxCycles += cycleExtensionStep
+		}
+	}
+	if wc := m.workerCard(phaseID); wc != nil && wc.MaxCycles > 0 {
+		wc.MaxCycles += cycleExtensionStep
+	}
+	m.addMessage("[%s] review cycle limit raised by %d", phaseID, cycleExtensionStep)
+	toast, cmd := NewToast(fmt.Sprintf("[%s] +%d review cycles", phaseID, cycleExtensionStep), false)
+	m.Toasts = append(m.Toasts, toast)
+	return cmd
+}
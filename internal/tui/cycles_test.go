@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExtendCyclesKeySignalsLoop(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, extendCyclesBuffer)
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "auth", Title: "Auth"}}})
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "auth", BeadID: "b-1", Title: "Auth"})
+	tm, _ = tm.Update(MsgPhaseCycleStart{PhaseID: "auth", Cycle: 3, MaxCycles: 3})
+	tm, _ = tm.Update(MsgPhaseCyclesReady{PhaseID: "auth", Ch: ch})
+
+	// From the phase table, the selected phase is extended.
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = tm.(AppModel)
+	if len(ch) != 1 || <-ch != cycleExtensionStep {
+		t.Fatal("extend key did not grant the running loop more cycles")
+	}
+	if got := m.NebulaView.Phases[0].MaxCycles; got != 3+cycleExtensionStep {
+		t.Errorf("displayed max cycles = %d, want %d", got, 3+cycleExtensionStep)
+	}
+	if wc := m.workerCard("auth"); wc == nil || wc.MaxCycles != 3+cycleExtensionStep {
+		t.Errorf("worker card = %+v, want the raised limit", wc)
+	}
+
+	// Once the phase finishes there is no loop left to extend.
+	m.retireWorkerCard("auth", PhaseDone)
+	m.handleExtendCyclesKey()
+	if len(ch) != 0 {
+		t.Error("cycles granted to a phase whose loop has finished")
+	}
+}
+
+func TestExtendCyclesTarget(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.NebulaView.InitPhases([]PhaseInfo{{ID: "a"}, {ID: "b"}})
+	m.NebulaView.Cursor = 1
+	if got := m.cycleTargetPhase(); got != "b" {
+		t.Errorf("table target = %q, want the selected phase", got)
+	}
+	m.ActiveTab = TabGraph
+	if got := m.cycleTargetPhase(); got != "" {
+		t.Errorf("graph tab target = %q, want none", got)
+	}
+	m.Depth = DepthAgentOutput
+	m.FocusedPhase = "a"
+	if got := m.cycleTargetPhase(); got != "a" {
+		t.Errorf("agent output target = %q, want the focused phase", got)
+	}
+}
+
+func TestSelectedPhaseIDLeavesBoardAlone(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.NebulaView.InitPhases([]PhaseInfo{{ID: "a"}, {ID: "b"}})
+	m.BoardActive = true
+	if got := m.selectedPhaseID(); got != "a" {
+		t.Errorf("board selection = %q, want the first card", got)
+	}
+	if m.Board.Phases != nil {
+		t.Errorf("selectedPhaseID synced the board: %v", m.Board.Phases)
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// descDiffMaxLines caps how many changed lines the detail panel shows for a
+// refactored description.
+const descDiffMaxLines = 12
+
+// DescDiffLine is one changed line of a phase description diff.
+type DescDiffLine struct {
+	Added bool   // true for a line in the new description, false for a removed one
+	Text  string // the line, without a trailing newline
+}
+
+// DescriptionDiff is the line-level change between a phase's description
+// before and after a mid-run refactor. Unchanged lines are dropped.
+type DescriptionDiff struct {
+	Lines   []DescDiffLine
+	Added   int
+	Removed int
+}
+
+// diffDescriptions compares two descriptions line by line using their
+// longest common subsequence, keeping only the added and removed lines.
+// Descriptions are short, so the quadratic table is fine.
+func diffDescriptions(original, updated string) DescriptionDiff {
+	a := splitDescLines(original)
+	b := splitDescLines(updated)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var d DescriptionDiff
+	remove := func(s string) {
+		d.Lines = append(d.Lines, DescDiffLine{Text: s})
+		d.Removed++
+	}
+	add := func(s string) {
+		d.Lines = append(d.Lines, DescDiffLine{Added: true, Text: s})
+		d.Added++
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			remove(a[i])
+			i++
+		default:
+			add(b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		remove(a[i])
+	}
+	for ; j < len(b); j++ {
+		add(b[j])
+	}
+	return d
+}
+
+// splitDescLines splits a description into lines, ignoring surrounding
+// blank lines so trailing newlines do not show up as changes.
+func splitDescLines(s string) []string {
+	s = strings.Trim(s, "\n")
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// Summary returns a short "+N −M lines" count of the change.
+func (d DescriptionDiff) Summary() string {
+	return fmt.Sprintf("+%d −%d lines", d.Added, d.Removed)
+}
+
+// Render draws the changed lines in diff colors, truncated to width and
+// capped at descDiffMaxLines with a count of the lines left out.
+func (d DescriptionDiff) Render(width int) string {
+	if len(d.Lines) == 0 {
+		return styleDiffContext.Render("(description unchanged)")
+	}
+	var b strings.Builder
+	for i, l := range d.Lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if i == descDiffMaxLines {
+			b.WriteString(styleDiffContext.Render(fmt.Sprintf("… %d more changed line(s)", len(d.Lines)-descDiffMaxLines)))
+			break
+		}
+		if l.Added {
+			b.WriteString(styleDiffAdd.Render(TruncateWithEllipsis("+ "+l.Text, width)))
+		} else {
+			b.WriteString(styleDiffRemove.Render(TruncateWithEllipsis("- "+l.Text, width)))
+		}
+	}
+	return b.String()
+}
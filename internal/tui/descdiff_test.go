@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDiffDescriptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		original string
+		updated  string
+		want     []DescDiffLine
+	}{
+		{"unchanged", "a\nb\n", "a\nb", nil},
+		{"line added", "a\nc", "a\nb\nc", []DescDiffLine{{Added: true, Text: "b"}}},
+		{"line removed", "a\nb\nc", "a\nc", []DescDiffLine{{Text: "b"}}},
+		{
+			"line changed",
+			"Add login.\nUse sessions.",
+			"Add login.\nUse JWT tokens.",
+			[]DescDiffLine{{Text: "Use sessions."}, {Added: true, Text: "Use JWT tokens."}},
+		},
+		{"from empty", "", "new", []DescDiffLine{{Added: true, Text: "new"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := diffDescriptions(tt.original, tt.updated)
+			if len(d.Lines) != len(tt.want) {
+				t.Fatalf("lines = %+v, want %+v", d.Lines, tt.want)
+			}
+			added, removed := 0, 0
+			for i, l := range d.Lines {
+				if l != tt.want[i] {
+					t.Errorf("line %d = %+v, want %+v", i, l, tt.want[i])
+				}
+				if l.Added {
+					added++
+				} else {
+					removed++
+				}
+			}
+			if d.Added != added || d.Removed != removed {
+				t.Errorf("counts = +%d −%d, want +%d −%d", d.Added, d.Removed, added, removed)
+			}
+		})
+	}
+}
+
+func TestDescriptionDiffRenderCapsLines(t *testing.T) {
+	t.Parallel()
+
+	updated := strings.Repeat("line\n", descDiffMaxLines+3)
+	out := diffDescriptions("", updated).Render(80)
+	if got := strings.Count(out, "+ line"); got != descDiffMaxLines {
+		t.Errorf("rendered %d lines, want %d", got, descDiffMaxLines)
+	}
+	if !strings.Contains(out, "… 3 more changed line(s)") {
+		t.Errorf("missing overflow note:\n%s", out)
+	}
+	if out := (DescriptionDiff{}).Render(80); !strings.Contains(out, "description unchanged") {
+		t.Errorf("empty diff = %q", out)
+	}
+}
+
+func TestRefactorAppliedShowsDescriptionDiff(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.Detail = NewDetailPanel(80, 20)
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "auth", Title: "Auth"}}})
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "auth", BeadID: "b-1", Title: "Auth"})
+	tm, _ = tm.Update(MsgPhaseAgentStart{PhaseID: "auth", Role: "coder"})
+	tm, _ = tm.Update(MsgPhaseRefactorApplied{PhaseID: "auth", Original: "Add login.\nUse sessions.", Updated: "Add login.\nUse JWT tokens."})
+	m = tm.(AppModel)
+
+	if wc := m.workerCard("auth"); wc == nil || wc.Refactor != "refactor applied (+1 −1 lines)" {
+		t.Errorf("worker card = %+v, want the refactor summary", wc)
+	}
+	if len(m.Toasts) == 0 || !strings.Contains(m.Toasts[len(m.Toasts)-1].Message, "+1 −1 lines") {
+		t.Errorf("toasts = %+v, want the refactor summary", m.Toasts)
+	}
+
+	m.FocusedPhase = "auth"
+	m.Depth = DepthPhaseLoop
+	m.updateDetailFromSelection()
+	content := m.Detail.viewport.View()
+	for _, want := range []string{"Description refactor (+1 −1 lines)", "- Use sessions.", "+ Use JWT tokens."} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail missing %q:\n%s", want, content)
+		}
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// maxOutputLines is the maximum number of lines shown before truncation.
-const maxOutputLines = 500
+// Agent output longer than outputHeadLines+outputTailLines is collapsed to its
+// first and last lines until the user expands it.
+const (
+	outputHeadLines = 200
+	outputTailLines = 100
+)
 
 // DetailPanel wraps a viewport for scrollable content display.
 type DetailPanel struct {
@@ -53,6 +58,7 @@ func (d *DetailPanel) SetContent(title, content string) {
 	d.title = title
 	d.emptyHint = ""
 	d.headerBlock = ""
+	d.resetHorizontalScroll()
 	content = d.wrapContent(content)
 	d.totalLines = strings.Count(content, "\n") + 1
 	d.viewport.SetContent(content)
@@ -64,11 +70,27 @@ func (d *DetailPanel) SetContentWithHeader(title, header, body string) {
 	d.title = title
 	d.emptyHint = ""
 	d.headerBlock = header
+	d.resetHorizontalScroll()
+	d.setBody(header, d.wrapContent(body))
+}
 
-	combined := d.wrapContent(body)
+// SetRawContentWithHeader is like SetContentWithHeader but leaves body
+// exactly as given: long lines are not wrapped and scroll sideways instead.
+func (d *DetailPanel) SetRawContentWithHeader(title, header, body string) {
+	d.title = title
+	d.emptyHint = ""
+	d.headerBlock = header
+	d.viewport.SetHorizontalStep(rawHorizontalStep)
+	d.viewport.SetXOffset(0)
+	d.setBody(header, body)
+}
+
+// setBody fills the viewport with the wrapped header, a separator, and body.
+func (d *DetailPanel) setBody(header, body string) {
+	combined := body
 	if header != "" {
 		sep := styleDetailSep.Render(strings.Repeat("─", 40))
-		combined = d.wrapContent(header) + "\n" + sep + "\n" + combined
+		combined = d.wrapContent(header) + "\n" + sep + "\n" + body
 	}
 
 	d.totalLines = strings.Count(combined, "\n") + 1
@@ -76,11 +98,19 @@ func (d *DetailPanel) SetContentWithHeader(title, header, body string) {
 	d.viewport.GotoTop()
 }
 
+// resetHorizontalScroll turns off sideways scrolling, which only raw
+// content uses; wrapped content always fits the width.
+func (d *DetailPanel) resetHorizontalScroll() {
+	d.viewport.SetHorizontalStep(0)
+	d.viewport.SetXOffset(0)
+}
+
 // SetEmpty sets the detail panel to show an empty-state hint.
 func (d *DetailPanel) SetEmpty(hint string) {
 	d.title = ""
 	d.headerBlock = ""
 	d.emptyHint = hint
+	d.resetHorizontalScroll()
 	d.totalLines = 0
 	d.viewport.SetContent("")
 	d.viewport.GotoTop()
@@ -162,12 +192,15 @@ type AgentContext struct {
 
 // PhaseContext holds the contextual information for a selected phase.
 type PhaseContext struct {
-	ID        string
-	Title     string
-	Status    PhaseStatus
-	CostUSD   float64
-	Cycles    int
-	BlockedBy string
+	ID         string
+	Title      string
+	Status     PhaseStatus
+	CostUSD    float64
+	Cycles     int
+	BlockedBy  string
+	SkipReason string
+	Preserved  string
+	Confidence string // reviewer's confidence in its latest verdict
 }
 
 // FormatAgentHeader renders a contextual header for an agent entry.
@@ -240,12 +273,34 @@ func FormatPhaseHeader(ctx PhaseContext) string {
 		b.WriteString(value(fmt.Sprintf("%d", ctx.Cycles)))
 	}
 
+	if ctx.Confidence != "" {
+		b.WriteString("  ")
+		b.WriteString(label("confidence: "))
+		if ctx.Confidence == "low" {
+			b.WriteString(styleRowFailed.Render(ctx.Confidence))
+		} else {
+			b.WriteString(value(ctx.Confidence))
+		}
+	}
+
 	if ctx.BlockedBy != "" {
 		b.WriteString("\n")
 		b.WriteString(label("blocked by: "))
 		b.WriteString(value(ctx.BlockedBy))
 	}
 
+	if ctx.SkipReason != "" {
+		b.WriteString("\n")
+		b.WriteString(label("skipped: "))
+		b.WriteString(value(ctx.SkipReason))
+	}
+
+	if ctx.Preserved != "" && ctx.Status == PhaseFailed {
+		b.WriteString("\n")
+		b.WriteString(label("work kept at: "))
+		b.WriteString(value(ctx.Preserved))
+	}
+
 	return b.String()
 }
 
@@ -300,20 +355,29 @@ func highlightLine(line string) string {
 	}
 }
 
-// TruncateOutput truncates output to maxOutputLines, appending a truncation indicator.
-func TruncateOutput(text string, maxLines int) string {
+// TruncateOutput keeps the first head and last tail lines of text, replacing
+// the middle with a marker naming how many lines are hidden and the expand
+// binding's key.
+func TruncateOutput(text string, head, tail int, expand key.Binding) string {
 	lines := strings.Split(text, "\n")
-	if len(lines) <= maxLines {
+	hidden := len(lines) - head - tail
+	if hidden <= 0 {
 		return text
 	}
-	truncated := strings.Join(lines[:maxLines], "\n")
-	remaining := len(lines) - maxLines
-	indicator := styleDetailDim.Render(fmt.Sprintf("\n(truncated — %d more lines)", remaining))
-	return truncated + indicator
+	marker := styleDetailDim.Render(fmt.Sprintf("[… %d lines hidden, press %s to expand]", hidden, expand.Help().Key))
+	kept := make([]string, 0, head+tail+1)
+	kept = append(kept, lines[:head]...)
+	kept = append(kept, marker)
+	kept = append(kept, lines[len(lines)-tail:]...)
+	return strings.Join(kept, "\n")
 }
 
-// FormatAgentOutput applies truncation and highlighting to agent output.
-func FormatAgentOutput(output string) string {
-	truncated := TruncateOutput(output, maxOutputLines)
-	return HighlightOutput(truncated)
+// FormatAgentOutput applies highlighting to agent output, collapsing the
+// middle of very long output unless expanded is set. The collapsed marker
+// names expand's key.
+func FormatAgentOutput(output string, expanded bool, expand key.Binding) string {
+	if !expanded {
+		output = TruncateOutput(output, outputHeadLines, outputTailLines, expand)
+	}
+	return HighlightOutput(output)
 }
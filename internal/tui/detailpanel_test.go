@@ -89,60 +89,78 @@ func TestHighlightLinePriority(t *testing.T) {
 	}
 }
 
+// numberedLines returns n lines "line 1" … "line n".
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func TestTruncateOutput(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name      string
-		lines     int
-		maxLines  int
-		wantTrunc bool
+		name       string
+		lines      int
+		head, tail int
+		wantHidden int // 0 = no marker
 	}{
-		{"under limit", 5, 10, false},
-		{"at limit", 10, 10, false},
-		{"over limit", 15, 10, true},
-		{"way over limit", 1000, 10, true},
+		{"under limit", 5, 4, 3, 0},
+		{"at limit", 7, 4, 3, 0},
+		{"over limit", 15, 4, 3, 8},
+		{"way over limit", 1000, 4, 3, 993},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			var sb strings.Builder
-			for i := range tt.lines {
-				if i > 0 {
-					sb.WriteString("\n")
-				}
-				fmt.Fprintf(&sb, "line %d", i+1)
+			result := TruncateOutput(numberedLines(tt.lines), tt.head, tt.tail, DefaultKeyMap().Expand)
+			hasMarker := strings.Contains(result, "hidden")
+			if (tt.wantHidden > 0) != hasMarker {
+				t.Fatalf("marker present = %v, want %v:\n%s", hasMarker, tt.wantHidden > 0, result)
 			}
-			result := TruncateOutput(sb.String(), tt.maxLines)
-			hasTruncIndicator := strings.Contains(result, "truncated")
-			if tt.wantTrunc && !hasTruncIndicator {
-				t.Error("expected truncation indicator")
+			if tt.wantHidden == 0 {
+				return
 			}
-			if !tt.wantTrunc && hasTruncIndicator {
-				t.Error("unexpected truncation indicator")
+			want := fmt.Sprintf("[… %d lines hidden, press X to expand]", tt.wantHidden)
+			if !strings.Contains(result, want) {
+				t.Errorf("expected %q in marker, got %q", want, result)
 			}
-			if tt.wantTrunc {
-				remaining := tt.lines - tt.maxLines
-				wantMsg := fmt.Sprintf("%d more lines", remaining)
-				if !strings.Contains(result, wantMsg) {
-					t.Errorf("expected %q in truncation message, got %q", wantMsg, result)
-				}
+			if got := strings.Count(result, "\n") + 1; got != tt.head+tt.tail+1 {
+				t.Errorf("got %d lines, want %d", got, tt.head+tt.tail+1)
 			}
 		})
 	}
 }
 
-func TestTruncateOutputPreservesContent(t *testing.T) {
+func TestTruncateOutputKeepsHeadAndTail(t *testing.T) {
+	t.Parallel()
+	result := TruncateOutput(numberedLines(10), 2, 2, DefaultKeyMap().Expand)
+	for _, want := range []string{"line 1\n", "line 2\n", "line 9\n", "line 10"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in %q", want, result)
+		}
+	}
+	if strings.Contains(result, "line 5") {
+		t.Error("middle lines should be hidden")
+	}
+}
+
+func TestFormatAgentOutputExpanded(t *testing.T) {
 	t.Parallel()
-	input := "line1\nline2\nline3\nline4\nline5"
-	result := TruncateOutput(input, 3)
-	if !strings.Contains(result, "line1") {
-		t.Error("should contain first line")
+	input := numberedLines(outputHeadLines + outputTailLines + 50)
+
+	collapsed := FormatAgentOutput(input, false, DefaultKeyMap().Expand)
+	if !strings.Contains(collapsed, "50 lines hidden") {
+		t.Error("collapsed output should hide the middle")
+	}
+	expanded := FormatAgentOutput(input, true, DefaultKeyMap().Expand)
+	if strings.Contains(expanded, "hidden") {
+		t.Error("expanded output should not contain a marker")
 	}
-	if !strings.Contains(result, "line3") {
-		t.Error("should contain third line")
+	if !strings.Contains(expanded, fmt.Sprintf("line %d\n", outputHeadLines+1)) {
+		t.Error("expanded output should include the middle lines")
 	}
-	// line4 should not appear in the visible portion
-	// (it could be in the truncation indicator message).
 }
 
 func TestFormatAgentHeader(t *testing.T) {
@@ -214,6 +232,14 @@ func TestFormatPhaseHeader(t *testing.T) {
 			},
 			wantSub: []string{"setup", "Setup models", "done", "$1.23", "2"},
 		},
+		{
+			name: "failed phase with kept work",
+			ctx: PhaseContext{
+				ID: "auth", Title: "Auth", Status: PhaseFailed,
+				Preserved: "quasar/failed/auth",
+			},
+			wantSub: []string{"failed", "work kept at: ", "quasar/failed/auth"},
+		},
 		{
 			name: "waiting phase with blocker",
 			ctx: PhaseContext{
@@ -272,7 +298,7 @@ func TestFormatAgentOutput(t *testing.T) {
 	t.Parallel()
 	// FormatAgentOutput combines truncation and highlighting.
 	input := "APPROVED\nISSUE: missing tests\nnormal"
-	result := FormatAgentOutput(input)
+	result := FormatAgentOutput(input, false, DefaultKeyMap().Expand)
 	if !strings.Contains(result, "APPROVED") {
 		t.Error("should contain APPROVED")
 	}
@@ -35,8 +35,9 @@ type DiffHunk struct {
 
 // FileDiff represents the diff for a single file.
 type FileDiff struct {
-	Path  string
-	Hunks []DiffHunk
+	Path   string
+	Hunks  []DiffHunk
+	Binary bool // true for "Binary files ... differ"; there are no hunks
 }
 
 // DiffStat holds the summary statistics for a diff.
@@ -52,6 +53,7 @@ type FileStatEntry struct {
 	Path      string
 	Additions int
 	Deletions int
+	Binary    bool // binary files have no line counts
 }
 
 // ParseUnifiedDiff parses a unified diff string into structured FileDiff slices.
@@ -85,8 +87,13 @@ func ParseUnifiedDiff(raw string) []FileDiff {
 			strings.HasPrefix(line, "new mode") ||
 			strings.HasPrefix(line, "similarity index") ||
 			strings.HasPrefix(line, "rename from") ||
-			strings.HasPrefix(line, "rename to") ||
-			strings.HasPrefix(line, "Binary files") {
+			strings.HasPrefix(line, "rename to") {
+			continue
+		}
+		if strings.HasPrefix(line, "Binary files") {
+			if current != nil {
+				current.Binary = true
+			}
 			continue
 		}
 
@@ -250,11 +257,23 @@ func ComputeDiffStat(files []FileDiff) DiffStat {
 			Path:      f.Path,
 			Additions: adds,
 			Deletions: dels,
+			Binary:    f.Binary,
 		})
 	}
 	return stat
 }
 
+// DiffStatFromEntries totals per-file stats into a DiffStat. Binary files
+// and renames count as one changed file each, as in git diff --stat.
+func DiffStatFromEntries(files []FileStatEntry) DiffStat {
+	stat := DiffStat{FilesChanged: len(files), FileStats: files}
+	for _, f := range files {
+		stat.Insertions += f.Additions
+		stat.Deletions += f.Deletions
+	}
+	return stat
+}
+
 // SideBySidePair represents one row in the side-by-side view.
 type SideBySidePair struct {
 	Left  *DiffLine
@@ -355,18 +374,7 @@ func RenderSingleFileDiff(raw string, path string, width int) string {
 func renderDiffStat(stat DiffStat) string {
 	var b strings.Builder
 
-	// Summary line.
-	summary := fmt.Sprintf("  %d file%s changed",
-		stat.FilesChanged, pluralS(stat.FilesChanged))
-	if stat.Insertions > 0 {
-		summary += ", " + styleDiffStatAdd.Render(fmt.Sprintf("%d insertion%s(+)",
-			stat.Insertions, pluralS(stat.Insertions)))
-	}
-	if stat.Deletions > 0 {
-		summary += ", " + styleDiffStatDel.Render(fmt.Sprintf("%d deletion%s(-)",
-			stat.Deletions, pluralS(stat.Deletions)))
-	}
-	b.WriteString(styleDiffStat.Render(summary))
+	b.WriteString(renderDiffStatSummary(stat))
 
 	// Per-file stats.
 	if len(stat.FileStats) > 0 {
@@ -379,6 +387,10 @@ func renderDiffStat(stat DiffStat) string {
 		}
 		for _, fs := range stat.FileStats {
 			b.WriteString("\n")
+			if fs.Binary {
+				b.WriteString(styleDiffStat.Render(fmt.Sprintf("  %-*s | Bin", maxPath, fs.Path)))
+				continue
+			}
 			total := fs.Additions + fs.Deletions
 			line := fmt.Sprintf("  %-*s | %3d ", maxPath, fs.Path, total)
 			line += styleDiffStatAdd.Render(strings.Repeat("+", fs.Additions))
@@ -390,6 +402,22 @@ func renderDiffStat(stat DiffStat) string {
 	return b.String()
 }
 
+// renderDiffStatSummary renders the git-style "N files changed, X
+// insertions(+), Y deletions(-)" line, omitting zero counts.
+func renderDiffStatSummary(stat DiffStat) string {
+	summary := fmt.Sprintf("  %d file%s changed",
+		stat.FilesChanged, pluralS(stat.FilesChanged))
+	if stat.Insertions > 0 {
+		summary += ", " + styleDiffStatAdd.Render(fmt.Sprintf("%d insertion%s(+)",
+			stat.Insertions, pluralS(stat.Insertions)))
+	}
+	if stat.Deletions > 0 {
+		summary += ", " + styleDiffStatDel.Render(fmt.Sprintf("%d deletion%s(-)",
+			stat.Deletions, pluralS(stat.Deletions)))
+	}
+	return styleDiffStat.Render(summary)
+}
+
 // pluralS returns "s" if n != 1.
 func pluralS(n int) string {
 	if n == 1 {
@@ -220,3 +220,26 @@ func TestParseGitDiffPath(t *testing.T) {
 		t.Errorf("expected internal/foo.go, got %s", path)
 	}
 }
+
+func TestComputeDiffStatBinaryAndRename(t *testing.T) {
+	t.Parallel()
+
+	raw := "diff --git a/logo.png b/logo.png\n" +
+		"index 1111111..2222222 100644\n" +
+		"Binary files a/logo.png and b/logo.png differ\n" +
+		"diff --git a/old.go b/new.go\n" +
+		"similarity index 100%\n" +
+		"rename from old.go\n" +
+		"rename to new.go\n"
+	stat := ComputeDiffStat(ParseUnifiedDiff(raw))
+
+	if stat.FilesChanged != 2 || stat.Insertions != 0 || stat.Deletions != 0 {
+		t.Errorf("stat = %+v, want 2 files and no line changes", stat)
+	}
+	if len(stat.FileStats) != 2 || !stat.FileStats[0].Binary || stat.FileStats[1].Binary {
+		t.Errorf("file stats = %+v, want only logo.png marked binary", stat.FileStats)
+	}
+	if got := renderDiffStat(stat); !strings.Contains(got, "logo.png | Bin") {
+		t.Errorf("renderDiffStat = %q, want a Bin marker for logo.png", got)
+	}
+}
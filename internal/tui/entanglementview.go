@@ -15,9 +15,12 @@ import (
 // EntanglementView renders a scrollable list of entanglement cards grouped by
 // producer phase. It consumes MsgEntanglementUpdate from the fabric bridge and
 // displays each entanglement's ID, producer→consumer parties, status, and
-// interface body as monospace code.
+// interface body as monospace code. With ShowConflicts set it instead lists
+// the file-level conflicts reported between parallel phases.
 type EntanglementView struct {
 	Entanglements []fabric.Entanglement
+	Conflicts     []ConflictEntry
+	ShowConflicts bool
 	Cursor        int
 	Width         int
 	Height        int
@@ -127,6 +130,10 @@ func (ev *EntanglementView) SetSize(width, height int) {
 
 // MoveUp moves the cursor up by one entanglement and scrolls to keep it visible.
 func (ev *EntanglementView) MoveUp() {
+	if ev.ShowConflicts {
+		ev.viewport.ScrollUp(1)
+		return
+	}
 	if ev.Cursor > 0 {
 		ev.Cursor--
 	}
@@ -136,6 +143,10 @@ func (ev *EntanglementView) MoveUp() {
 
 // MoveDown moves the cursor down by one entanglement and scrolls to keep it visible.
 func (ev *EntanglementView) MoveDown() {
+	if ev.ShowConflicts {
+		ev.viewport.ScrollDown(1)
+		return
+	}
 	groups := groupEntanglements(ev.Entanglements)
 	max := flatCount(groups) - 1
 	if max < 0 {
@@ -185,7 +196,13 @@ func (ev *EntanglementView) Update(msg tea.Msg) {
 
 // View renders the entanglement view with grouped, bordered cards inside a viewport.
 func (ev EntanglementView) View() string {
-	if len(ev.Entanglements) == 0 {
+	if ev.ShowConflicts && len(ev.Conflicts) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(colorMuted).
+			PaddingLeft(2).
+			Render("No conflicts")
+	}
+	if !ev.ShowConflicts && len(ev.Entanglements) == 0 {
 		return lipgloss.NewStyle().
 			Foreground(colorMuted).
 			PaddingLeft(2).
@@ -199,7 +216,7 @@ func (ev EntanglementView) View() string {
 
 // scrollToCursor adjusts the viewport offset so the cursor card is visible.
 func (ev *EntanglementView) scrollToCursor() {
-	if !ev.ready || ev.Height <= 0 {
+	if !ev.ready || ev.Height <= 0 || ev.ShowConflicts {
 		return
 	}
 
@@ -245,6 +262,9 @@ func (ev *EntanglementView) refreshContent() {
 
 // renderContent formats all entanglement cards into a single string for the viewport.
 func (ev EntanglementView) renderContent() string {
+	if ev.ShowConflicts {
+		return ev.renderConflicts()
+	}
 	groups := groupEntanglements(ev.Entanglements)
 	if len(groups) == 0 {
 		return ""
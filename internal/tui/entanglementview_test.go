@@ -422,3 +422,36 @@ func TestEntanglementView_EmptySignatureFallback(t *testing.T) {
 		t.Errorf("expected fallback text for empty signature, got: %q", view)
 	}
 }
+
+func TestEntanglementView_ConflictsToggle(t *testing.T) {
+	t.Parallel()
+	ev := NewEntanglementView()
+	ev.Entanglements = []fabric.Entanglement{
+		{ID: 1, Name: "FooService", Producer: "phase-a", Status: fabric.StatusFulfilled},
+	}
+	ev.SetSize(80, 40)
+
+	ev.ToggleConflicts()
+	if view := ev.View(); !strings.Contains(view, "No conflicts") {
+		t.Errorf("expected conflicts placeholder, got: %q", view)
+	}
+
+	ev.Conflicts = []ConflictEntry{
+		{PhaseID: "phase-b", OtherPhaseID: "phase-a", File: "internal/api/server.go"},
+	}
+	ev.refreshContent()
+	view := ev.View()
+	for _, want := range []string{"1 file conflict", "phase-b", "phase-a", "internal/api/server.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in conflict list, got: %q", want, view)
+		}
+	}
+	if strings.Contains(view, "FooService") {
+		t.Error("entanglement cards should be hidden while showing conflicts")
+	}
+
+	ev.ToggleConflicts()
+	if view := ev.View(); !strings.Contains(view, "FooService") {
+		t.Errorf("expected cards after toggling back, got: %q", view)
+	}
+}
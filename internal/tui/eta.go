@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// refreshETA recomputes the status bar's ETA from the phase table. It is a
+// no-op until MsgETAHistory has arrived.
+func (m *AppModel) refreshETA() {
+	if m.etaHistory == nil {
+		return
+	}
+	now := time.Now()
+	phases := make([]nebula.ETAPhase, len(m.NebulaView.Phases))
+	for i, p := range m.NebulaView.Phases {
+		phases[i] = nebula.ETAPhase{ID: p.ID, DependsOn: p.DependsOn, Estimate: m.etaHistory.Estimates[p.ID]}
+		switch {
+		case p.Status == PhaseDone, p.Status == PhaseFailed, p.Status == PhaseSkipped:
+			phases[i].Finished = true
+		case !p.StartedAt.IsZero():
+			phases[i].Elapsed = now.Sub(p.StartedAt)
+		}
+	}
+	eta := nebula.EstimateETA(phases, m.etaHistory.Durations, m.etaHistory.Default, m.etaHistory.MaxWorkers)
+
+	m.StatusBar.ETA = eta.Remaining
+	m.StatusBar.ETANarrow = eta.Narrow()
+	m.StatusBar.ETAAt = now
+	if eta.Known+eta.Hinted+eta.Unknown == 0 {
+		m.StatusBar.ETAAt = time.Time{}
+	}
+}
+
+// renderETA renders the ETA segment text, e.g. "ETA ~12m ±2m", counting
+// down from when the estimate was made. The spread is ±20% when most
+// phases have a recorded duration or an estimate and ±50% otherwise.
+func (s StatusBar) renderETA() string {
+	left := max(s.ETA-time.Since(s.ETAAt), 0)
+	if left < time.Minute {
+		return "ETA <1m"
+	}
+	spread := left / 2
+	if s.ETANarrow {
+		spread = left / 5
+	}
+	return fmt.Sprintf("ETA ~%s ±%s", formatETA(left), formatETA(max(spread, time.Minute)))
+}
+
+// renderDeadline renders the max runtime countdown, e.g. "⌛ 1h05m left",
+// or notes that the limit was reached and running phases are finishing.
+func (s StatusBar) renderDeadline() string {
+	left := time.Until(s.Deadline)
+	if left <= 0 {
+		return "⌛ max runtime reached"
+	}
+	if left < time.Minute {
+		return "⌛ <1m left"
+	}
+	return "⌛ " + formatETA(left) + " left"
+}
+
+// formatETA formats d to the minute as "12m" or "1h05m".
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestETARefresh(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "a", Status: PhaseWaiting},
+		{ID: "b", Status: PhaseWaiting, DependsOn: []string{"a"}},
+	})
+	if !m.StatusBar.ETAAt.IsZero() {
+		t.Fatal("ETA shown before MsgETAHistory arrived")
+	}
+
+	next, _ := m.Update(MsgETAHistory{
+		Durations:  map[string]time.Duration{"a": 20 * time.Minute, "b": 10 * time.Minute},
+		Default:    5 * time.Minute,
+		MaxWorkers: 1,
+	})
+	am := next.(AppModel)
+	if am.StatusBar.ETA != 30*time.Minute || !am.StatusBar.ETANarrow {
+		t.Errorf("ETA = %v narrow=%v, want 30m narrow", am.StatusBar.ETA, am.StatusBar.ETANarrow)
+	}
+	if got := am.StatusBar.renderETA(); got != "ETA ~30m ±6m" {
+		t.Errorf("renderETA() = %q, want ~30m with a narrow spread", got)
+	}
+
+	next, _ = am.Update(MsgPhaseTaskComplete{PhaseID: "a"})
+	am = next.(AppModel)
+	if am.StatusBar.ETA != 10*time.Minute {
+		t.Errorf("ETA after completion = %v, want 10m", am.StatusBar.ETA)
+	}
+
+	next, _ = am.Update(MsgPhaseHotAdded{PhaseID: "c", DependsOn: []string{"b"}})
+	am = next.(AppModel)
+	if am.StatusBar.ETA != 15*time.Minute || am.StatusBar.ETANarrow {
+		t.Errorf("ETA after hot-add = %v narrow=%v, want 15m wide", am.StatusBar.ETA, am.StatusBar.ETANarrow)
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{12 * time.Minute, "12m"},
+		{12*time.Minute + 40*time.Second, "13m"},
+		{65 * time.Minute, "1h05m"},
+	}
+	for _, tt := range tests {
+		if got := formatETA(tt.d); got != tt.want {
+			t.Errorf("formatETA(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderDeadline(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{{ID: "a", Status: PhaseWaiting}})
+	next, _ := m.Update(MsgRuntimeLimit{Deadline: time.Now().Add(90*time.Minute + 40*time.Second)})
+	sb := next.(AppModel).StatusBar
+	if got := sb.renderDeadline(); got != "⌛ 1h31m left" {
+		t.Errorf("renderDeadline() = %q, want 1h31m left", got)
+	}
+
+	sb.Deadline = time.Now().Add(20 * time.Second)
+	if got := sb.renderDeadline(); got != "⌛ <1m left" {
+		t.Errorf("renderDeadline() = %q, want <1m left", got)
+	}
+	sb.Deadline = time.Now().Add(-time.Second)
+	if got := sb.renderDeadline(); got != "⌛ max runtime reached" {
+		t.Errorf("renderDeadline() = %q, want max runtime reached", got)
+	}
+}
@@ -50,6 +50,8 @@ func (v *FileListView) View() string {
 	}
 
 	var b strings.Builder
+	b.WriteString(renderDiffStatSummary(DiffStatFromEntries(v.Files)))
+	b.WriteString("\n\n")
 	for i, f := range v.Files {
 		indicator := "  "
 		pathStyle := lipgloss.NewStyle()
@@ -72,9 +74,12 @@ func (v *FileListView) View() string {
 		}
 		padded := path + strings.Repeat(" ", pad)
 
-		add := styleDiffStatAdd.Render(fmt.Sprintf("+%d", f.Additions))
-		del := styleDiffStatDel.Render(fmt.Sprintf("-%d", f.Deletions))
-		stat := fmt.Sprintf("| %s %s", add, del)
+		stat := "| " + styleDetailDim.Render("binary")
+		if !f.Binary {
+			add := styleDiffStatAdd.Render(fmt.Sprintf("+%d", f.Additions))
+			del := styleDiffStatDel.Render(fmt.Sprintf("-%d", f.Deletions))
+			stat = fmt.Sprintf("| %s %s", add, del)
+		}
 
 		b.WriteString(indicator)
 		b.WriteString(pathStyle.Render(padded))
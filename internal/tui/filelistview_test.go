@@ -173,10 +173,13 @@ func TestFileListView_CursorIndicatorMovesWithSelection(t *testing.T) {
 	}
 	v := NewFileListView(files, 80, "", "", "")
 
+	// The first two lines are the diffstat summary and a blank line.
+	fileLines := func() []string { return strings.Split(v.View(), "\n")[2:] }
+
 	// Initially cursor is on a.go.
-	lines := strings.Split(v.View(), "\n")
+	lines := fileLines()
 	if len(lines) < 2 {
-		t.Fatal("expected at least 2 lines in view")
+		t.Fatal("expected at least 2 file lines in view")
 	}
 	if !strings.Contains(lines[0], "▸") {
 		t.Error("cursor should be on first line initially")
@@ -187,7 +190,7 @@ func TestFileListView_CursorIndicatorMovesWithSelection(t *testing.T) {
 
 	// Move down — cursor should be on b.go.
 	v.MoveDown()
-	lines = strings.Split(v.View(), "\n")
+	lines = fileLines()
 	if strings.Contains(lines[0], "▸") {
 		t.Error("cursor should not be on first line after MoveDown")
 	}
@@ -195,3 +198,58 @@ func TestFileListView_CursorIndicatorMovesWithSelection(t *testing.T) {
 		t.Error("cursor should be on second line after MoveDown")
 	}
 }
+
+func TestFileListView_DiffStatSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []FileStatEntry
+		want  string
+	}{
+		{
+			name: "plural counts",
+			files: []FileStatEntry{
+				{Path: "a.go", Additions: 100, Deletions: 20},
+				{Path: "b.go", Additions: 20, Deletions: 10},
+			},
+			want: "2 files changed, 120 insertions(+), 30 deletions(-)",
+		},
+		{
+			name:  "singular and zero deletions omitted",
+			files: []FileStatEntry{{Path: "a.go", Additions: 1}},
+			want:  "1 file changed, 1 insertion(+)",
+		},
+		{
+			name: "binary files and renames count as files",
+			files: []FileStatEntry{
+				{Path: "logo.png", Binary: true},
+				{Path: "old.go => new.go"},
+				{Path: "c.go", Deletions: 2},
+			},
+			want: "3 files changed, 2 deletions(-)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewFileListView(tt.files, 80, "", "", "")
+			first := strings.Split(v.View(), "\n")[0]
+			if strings.TrimSpace(first) != tt.want {
+				t.Errorf("summary = %q, want %q", strings.TrimSpace(first), tt.want)
+			}
+		})
+	}
+}
+
+func TestFileListView_BinaryFile(t *testing.T) {
+	t.Parallel()
+	v := NewFileListView([]FileStatEntry{{Path: "logo.png", Binary: true}}, 80, "", "", "")
+	got := v.View()
+	if !strings.Contains(got, "logo.png | binary") {
+		t.Errorf("View() = %q, want binary marker for logo.png", got)
+	}
+	if strings.Contains(got, "+0") {
+		t.Errorf("View() = %q, binary file should not show line counts", got)
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// focusChrome is the number of lines focus mode reserves outside the detail
+// viewport: the panel border (2), title (1), scroll indicators (2), and the
+// footer (1).
+const focusChrome = 6
+
+// focusActive reports whether focus mode is in effect. FocusMode only
+// applies at DepthAgentOutput; elsewhere the regular layout is used.
+func (m AppModel) focusActive() bool {
+	return m.FocusMode && m.Depth == DepthAgentOutput
+}
+
+// handleFocusKey toggles focus mode at DepthAgentOutput and resizes the
+// detail panel to match the new layout.
+func (m *AppModel) handleFocusKey() {
+	if m.Depth != DepthAgentOutput {
+		return
+	}
+	m.setFocusMode(!m.FocusMode)
+}
+
+// setFocusMode enables or disables focus mode and resizes the detail panel
+// so the viewport fills (or gives back) the freed rows.
+func (m *AppModel) setFocusMode(on bool) {
+	m.FocusMode = on
+	if m.Width > 0 {
+		m.Detail.SetSize(m.Width-2, m.detailHeight())
+	}
+}
+
+// focusDetailHeight returns the detail viewport height in focus mode: the
+// full terminal height minus the panel frame and footer.
+func (m AppModel) focusDetailHeight() int {
+	h := m.Height - focusChrome
+	if h < 1 {
+		return 1
+	}
+	return h
+}
+
+// focusSections assembles the focus-mode layout above the footer: the detail
+// panel at full height, plus any gate prompt and toasts. The banner, tab bar,
+// status and bottom bars are hidden and collapse thresholds do not apply.
+func (m AppModel) focusSections(contentWidth int) []string {
+	sections := []string{m.Detail.View()}
+	if m.Gate != nil {
+		sections = append(sections, m.Gate.View())
+	}
+	if len(m.Toasts) > 0 {
+		sections = append(sections, RenderToasts(m.Toasts, contentWidth))
+	}
+	return []string{lipgloss.JoinVertical(lipgloss.Left, sections...)}
+}
+
+// focusBinding returns the footer binding for the focus key, labeled for
+// the action it will perform.
+func (m AppModel) focusBinding() key.Binding {
+	b := m.Keys.Focus
+	if m.FocusMode {
+		b.SetHelp("f", "unfocus")
+	}
+	return b
+}
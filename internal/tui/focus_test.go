@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newFocusModel returns a sized nebula model drilled into a phase's agent output.
+func newFocusModel() AppModel {
+	m := newNebulaModelWithPhases("", []PhaseEntry{{ID: "p1", Status: PhaseWorking}})
+	m.Splash = nil
+	m.StatusBar.Name = "focus-nebula"
+	m.StatusBar.Total = 1
+	m.FocusedPhase = "p1"
+	m.Depth = DepthAgentOutput
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(AppModel)
+}
+
+func pressKey(t *testing.T, m AppModel, k tea.KeyMsg) AppModel {
+	t.Helper()
+	updated, _ := m.Update(k)
+	return updated.(AppModel)
+}
+
+var (
+	keyFocus = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestFocusKeyOnlyAtAgentOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		depth ViewDepth
+		want  bool
+	}{
+		{"phases", DepthPhases, false},
+		{"phase loop", DepthPhaseLoop, false},
+		{"agent output", DepthAgentOutput, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newFocusModel()
+			m.Depth = tt.depth
+			m = pressKey(t, m, keyFocus)
+			if m.FocusMode != tt.want {
+				t.Errorf("FocusMode = %v, want %v", m.FocusMode, tt.want)
+			}
+		})
+	}
+}
+
+func TestFocusModeView(t *testing.T) {
+	t.Parallel()
+
+	m := newFocusModel()
+	if !strings.Contains(m.View(), "focus-nebula") {
+		t.Fatal("status bar missing from normal layout")
+	}
+	normalHeight := m.detailHeight()
+
+	m = pressKey(t, m, keyFocus)
+	if strings.Contains(m.View(), "focus-nebula") {
+		t.Error("focus mode should hide the status bar")
+	}
+	if got, want := m.detailHeight(), 40-focusChrome; got != want {
+		t.Errorf("detailHeight() = %d, want %d", got, want)
+	}
+	if m.detailHeight() <= normalHeight {
+		t.Errorf("focus height %d should exceed normal height %d", m.detailHeight(), normalHeight)
+	}
+
+	m = pressKey(t, m, keyFocus)
+	if m.FocusMode {
+		t.Fatal("second f should exit focus mode")
+	}
+	if !strings.Contains(m.View(), "focus-nebula") {
+		t.Error("status bar not restored after exiting focus mode")
+	}
+	if got := m.detailHeight(); got != normalHeight {
+		t.Errorf("detailHeight() = %d after exit, want %d", got, normalHeight)
+	}
+}
+
+func TestFocusModeEscExitsBeforeDrillingUp(t *testing.T) {
+	t.Parallel()
+
+	m := pressKey(t, newFocusModel(), keyFocus)
+
+	m = pressKey(t, m, keyEsc)
+	if m.FocusMode {
+		t.Error("esc should exit focus mode")
+	}
+	if m.Depth != DepthAgentOutput {
+		t.Errorf("Depth = %v, want DepthAgentOutput after first esc", m.Depth)
+	}
+
+	m = pressKey(t, m, keyEsc)
+	if m.Depth != DepthPhaseLoop {
+		t.Errorf("Depth = %v, want DepthPhaseLoop after second esc", m.Depth)
+	}
+}
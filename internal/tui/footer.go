@@ -75,7 +75,7 @@ func HomeFooterBindings(km KeyMap) []key.Binding {
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "filter"),
 	)
-	return []key.Binding{km.Up, km.Down, enter, filter, km.Info, km.Quit}
+	return []key.Binding{km.Up, km.Down, enter, filter, km.Info, km.Mark, km.Quit}
 }
 
 // CockpitFooterBindings returns footer bindings when the board view is active.
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -22,6 +23,8 @@ type GatePrompt struct {
 	Cursor     int
 	ResponseCh chan<- nebula.GateAction
 	IsPlan     bool
+	Budget     *nebula.BudgetRequest // set for a budget-exhaustion prompt
+	Manual     bool                  // a manual phase waiting to be marked complete or failed
 	Width      int
 	Height     int // available terminal height for scroll clamping
 
@@ -31,11 +34,24 @@ type GatePrompt struct {
 	NeedsHumanReview bool
 	Satisfaction     string
 	Risk             string
+	Confidence       string
 	FilesChanged     []nebula.FileChange
+	Checks           []nebula.CheckResult
 	ReviewCycles     int
 	CostUSD          float64
+	Instructions     string // manual phases: what the human is asked to do
+
+	// Changed-file browser: ← from the first button focuses the file list,
+	// Enter opens a file's diff.
+	Diff     string        // the phase's unified diff
+	FileList *FileListView // nil when the checkpoint has no changed files
+	OpenFile string        // path whose diff is shown; "" shows the list
 
 	ScrollOffset int // vertical scroll position within the detail body
+
+	filesFocused bool // navigation keys drive FileList instead of the buttons
+
+	shownAt time.Time // when the prompt was put in front of the user
 }
 
 // NewGatePrompt creates a gate prompt for the given checkpoint.
@@ -47,12 +63,15 @@ func NewGatePrompt(cp *nebula.Checkpoint, responseCh chan<- nebula.GateAction) *
 	}
 
 	var options []GateOption
-	if isPlan {
+	switch {
+	case cp != nil && cp.Manual:
+		options = manualGateOptions()
+	case isPlan:
 		options = []GateOption{
 			{Label: "[a]ccept", Action: nebula.GateActionAccept},
 			{Label: "[s]kip", Action: nebula.GateActionSkip},
 		}
-	} else {
+	default:
 		options = []GateOption{
 			{Label: "[a]ccept", Action: nebula.GateActionAccept},
 			{Label: "[x] reject", Action: nebula.GateActionReject},
@@ -74,14 +93,50 @@ func NewGatePrompt(cp *nebula.Checkpoint, responseCh chan<- nebula.GateAction) *
 		g.NeedsHumanReview = cp.NeedsHumanReview
 		g.Satisfaction = cp.Satisfaction
 		g.Risk = cp.Risk
+		g.Confidence = cp.Confidence
 		g.FilesChanged = cp.FilesChanged
+		g.Checks = cp.Checks
 		g.ReviewCycles = cp.ReviewCycles
 		g.CostUSD = cp.CostUSD
+		g.Manual = cp.Manual
+		g.Instructions = cp.Instructions
+		g.Diff = cp.Diff
+		if files := gateFileEntries(cp.FilesChanged); files != nil {
+			g.FileList = NewFileListView(files, 0, cp.BaseCommitSHA, cp.FinalCommitSHA, "")
+		}
 	}
 
 	return g
 }
 
+// NewBudgetPrompt creates a prompt offering more budget and a retry for a
+// phase that exhausted its budget cap.
+func NewBudgetPrompt(req *nebula.BudgetRequest, responseCh chan<- nebula.GateAction) *GatePrompt {
+	return &GatePrompt{
+		PhaseID: req.PhaseID,
+		Options: []GateOption{
+			{Label: fmt.Sprintf("[r] +$%.2f and retry", req.ExtraUSD), Action: nebula.GateActionRetry},
+			{Label: "[x] fail", Action: nebula.GateActionReject},
+		},
+		ResponseCh: responseCh,
+		Budget:     req,
+		PhaseTitle: req.PhaseTitle,
+		CostUSD:    req.SpentUSD,
+	}
+}
+
+// newGatePromptFor builds the overlay for a gate or budget prompt message.
+func newGatePromptFor(msg MsgGatePrompt) *GatePrompt {
+	var g *GatePrompt
+	if msg.Budget != nil {
+		g = NewBudgetPrompt(msg.Budget, msg.ResponseCh)
+	} else {
+		g = NewGatePrompt(msg.Checkpoint, msg.ResponseCh)
+	}
+	g.shownAt = time.Now()
+	return g
+}
+
 // Resolve sends the selected action and closes the response channel.
 func (g *GatePrompt) Resolve(action nebula.GateAction) {
 	if g.ResponseCh != nil {
@@ -144,6 +199,16 @@ func (g *GatePrompt) detailBody() string {
 	if g.PhaseTitle != "" {
 		title = g.PhaseTitle + " (" + g.PhaseID + ")"
 	}
+	if g.Budget != nil {
+		b.WriteString(styleGateAction.Render(fmt.Sprintf("Budget exhausted: %s", title)))
+		b.WriteString("\n")
+		b.WriteString(styleGateDetail.Render(fmt.Sprintf("spent $%.2f of $%.2f cap", g.Budget.SpentUSD, g.Budget.BudgetUSD)))
+		b.WriteString("\n")
+		return b.String()
+	}
+	if g.Manual {
+		return g.manualBody(title)
+	}
 	b.WriteString(styleGateAction.Render(fmt.Sprintf("Gate: %s", title)))
 	b.WriteString("\n")
 
@@ -170,15 +235,25 @@ func (g *GatePrompt) detailBody() string {
 	if g.Risk != "" {
 		statusParts = append(statusParts, "risk: "+g.Risk)
 	}
+	if g.Confidence != "" {
+		statusParts = append(statusParts, "confidence: "+g.Confidence)
+	}
 	if len(statusParts) > 0 {
 		b.WriteString(styleGateDetail.Render(strings.Join(statusParts, "  ·  ")))
 		b.WriteString("\n")
 	}
 
 	// Files changed.
-	if len(g.FilesChanged) > 0 {
+	if g.filesFocused {
+		b.WriteString("\n")
+		b.WriteString(g.filesSection())
+	} else if len(g.FilesChanged) > 0 {
 		b.WriteString("\n")
-		b.WriteString(styleGateLabel.Render("Files:"))
+		label := styleGateLabel.Render("Files:")
+		if g.hasFiles() {
+			label += styleGateDetail.Render("  ← browse")
+		}
+		b.WriteString(label)
 		b.WriteString("\n")
 		for _, fc := range g.FilesChanged {
 			icon := fileChangeIcon(fc.Operation)
@@ -199,6 +274,24 @@ func (g *GatePrompt) detailBody() string {
 		}
 	}
 
+	// Check results.
+	if len(g.Checks) > 0 {
+		b.WriteString("\n")
+		b.WriteString(styleGateLabel.Render("Checks:"))
+		b.WriteString("\n")
+		for _, c := range g.Checks {
+			icon := styleRowDone.Render(iconDone)
+			if !c.Passed {
+				icon = styleRowFailed.Render(iconFailed)
+			}
+			detail := c.Duration.Round(time.Millisecond).String()
+			if !c.Required {
+				detail += "  optional"
+			}
+			fmt.Fprintf(&b, "  %s %s  %s\n", icon, c.Name, styleGateDetail.Render(detail))
+		}
+	}
+
 	// Reviewer summary.
 	if g.ReviewSummary != "" {
 		b.WriteString("\n")
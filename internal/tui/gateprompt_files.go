@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// gateFileEntries converts a checkpoint's changed files into file list entries.
+func gateFileEntries(changes []nebula.FileChange) []FileStatEntry {
+	if len(changes) == 0 {
+		return nil
+	}
+	files := make([]FileStatEntry, len(changes))
+	for i, fc := range changes {
+		files[i] = FileStatEntry{Path: fc.Path, Additions: fc.LinesAdded, Deletions: fc.LinesRemoved}
+	}
+	return files
+}
+
+// hasFiles reports whether the prompt has changed files to browse.
+func (g *GatePrompt) hasFiles() bool {
+	return g.FileList != nil && len(g.FileList.Files) > 0
+}
+
+// FilesFocused reports whether navigation keys drive the file list rather
+// than the decision buttons.
+func (g *GatePrompt) FilesFocused() bool {
+	return g.filesFocused
+}
+
+// FocusFiles moves focus from the decision buttons to the file list.
+func (g *GatePrompt) FocusFiles() {
+	if g.hasFiles() {
+		g.filesFocused = true
+		g.ScrollOffset = 0
+	}
+}
+
+// FocusOptions returns focus to the decision buttons, closing any open diff.
+func (g *GatePrompt) FocusOptions() {
+	g.filesFocused = false
+	g.OpenFile = ""
+	g.ScrollOffset = 0
+}
+
+// OpenSelectedFile shows the diff of the file under the file list cursor.
+func (g *GatePrompt) OpenSelectedFile() {
+	if !g.hasFiles() {
+		return
+	}
+	g.OpenFile = g.FileList.Files[g.FileList.Cursor].Path
+	g.ScrollOffset = 0
+}
+
+// CloseFile returns from a file's diff to the file list.
+func (g *GatePrompt) CloseFile() {
+	g.OpenFile = ""
+	g.ScrollOffset = 0
+}
+
+// filesSection renders the focused file list, or the open file's diff.
+func (g *GatePrompt) filesSection() string {
+	width := g.Width - 8
+	if width < 20 {
+		width = 20
+	}
+	var b strings.Builder
+	if g.OpenFile != "" {
+		b.WriteString(styleGateLabel.Render(g.OpenFile))
+		b.WriteString("\n")
+		b.WriteString(RenderSingleFileDiff(g.Diff, g.OpenFile, width))
+		b.WriteString("\n")
+		b.WriteString(styleGateDetail.Render("  ↑↓ scroll  esc back to files"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	g.FileList.Width = width
+	b.WriteString(g.FileList.View())
+	b.WriteString("\n")
+	b.WriteString(styleGateDetail.Render("  → back to decision"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// handleGateFileKey handles navigation keys while the gate's file list has
+// focus and reports whether msg was consumed. The decision keys (Accept,
+// Reject, Retry, Skip) are left to handleGateKey so the gate can be
+// resolved from the file view.
+func (m *AppModel) handleGateFileKey(msg tea.KeyMsg) bool {
+	g := m.Gate
+	switch {
+	case key.Matches(msg, m.Keys.Back):
+		if g.OpenFile != "" {
+			g.CloseFile()
+		} else {
+			g.FocusOptions()
+		}
+	case key.Matches(msg, m.Keys.Enter):
+		g.OpenSelectedFile()
+	case key.Matches(msg, m.Keys.FileExit):
+		g.FocusOptions()
+	case key.Matches(msg, m.Keys.FileUp):
+		if g.OpenFile != "" {
+			g.ScrollUp()
+		} else {
+			g.FileList.MoveUp()
+		}
+	case key.Matches(msg, m.Keys.FileDown):
+		if g.OpenFile != "" {
+			g.ScrollDown(g.contentLineCount(), g.viewportHeight())
+		} else {
+			g.FileList.MoveDown()
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -14,8 +14,11 @@ type Gater struct {
 	program *tea.Program
 }
 
-// Verify Gater satisfies nebula.GatePrompter at compile time.
-var _ nebula.GatePrompter = (*Gater)(nil)
+// Verify Gater satisfies nebula.GatePrompter and nebula.BudgetPrompter at compile time.
+var (
+	_ nebula.GatePrompter   = (*Gater)(nil)
+	_ nebula.BudgetPrompter = (*Gater)(nil)
+)
 
 // NewGater creates a GatePrompter that routes gate decisions through the TUI.
 func NewGater(p *tea.Program) *Gater {
@@ -39,3 +42,22 @@ func (g *Gater) Prompt(ctx context.Context, cp *nebula.Checkpoint) (nebula.GateA
 		return action, nil
 	}
 }
+
+// PromptBudget shows the budget-exhaustion prompt for a phase and blocks
+// until the user grants more budget or lets the phase fail. A canceled
+// context fails the phase.
+func (g *Gater) PromptBudget(ctx context.Context, req nebula.BudgetRequest) (nebula.GateAction, error) {
+	responseCh := make(chan nebula.GateAction, 1)
+
+	g.program.Send(MsgGatePrompt{
+		Budget:     &req,
+		ResponseCh: responseCh,
+	})
+
+	select {
+	case <-ctx.Done():
+		return nebula.GateActionReject, ctx.Err()
+	case action := <-responseCh:
+		return action, nil
+	}
+}
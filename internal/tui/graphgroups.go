@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
+
+// nodeOf maps a phase ID to the graph node that displays it: the group node
+// when its import group is collapsed, the phase itself otherwise.
+func (gv *GraphView) nodeOf(phaseID string) string {
+	if g := collapsedGroupOf(phaseID, gv.collapsed); g != "" {
+		return g
+	}
+	return phaseID
+}
+
+// relayout projects phases onto graph nodes, folding collapsed import groups
+// into a single node, and recomputes the waves and cursor order.
+func (gv *GraphView) relayout() {
+	deps := make(map[string][]string, len(gv.phaseOrder))
+	titles := make(map[string]string, len(gv.phaseOrder))
+	members := make(map[string]int)
+	var nodes []string
+
+	for _, id := range gv.phaseOrder {
+		node := gv.nodeOf(id)
+		if _, ok := titles[node]; !ok {
+			nodes = append(nodes, node)
+			titles[node] = gv.phaseTitles[id]
+			deps[node] = nil
+		}
+		if node != id {
+			members[node]++
+		}
+		for _, dep := range gv.phaseDeps[id] {
+			depNode := gv.nodeOf(dep)
+			if depNode == node || containsString(deps[node], depNode) {
+				continue
+			}
+			deps[node] = append(deps[node], depNode)
+		}
+	}
+	for g, n := range members {
+		titles[g] = groupTitle(g, n)
+	}
+
+	d := dag.New()
+	for _, node := range nodes {
+		d.AddNodeIdempotent(node, 0)
+	}
+	for _, node := range nodes {
+		for _, dep := range deps[node] {
+			if err := d.AddEdge(node, dep); err != nil {
+				fmt.Fprintf(os.Stderr, "graphview: AddEdge(%s, %s): %v\n", node, dep, err)
+			}
+		}
+	}
+	waves, err := d.ComputeWaves()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "graphview: ComputeWaves: %v\n", err)
+	}
+
+	// Order nodes by wave for intuitive cursor navigation.
+	ordered := make([]string, 0, len(nodes))
+	for _, w := range waves {
+		ordered = append(ordered, w.NodeIDs...)
+	}
+	if len(ordered) > 0 {
+		nodes = ordered
+	}
+
+	gv.deps = deps
+	gv.titles = titles
+	gv.waves = waves
+	gv.nodeIDs = nodes
+	if gv.cursor >= len(gv.nodeIDs) {
+		gv.cursor = max(len(gv.nodeIDs)-1, 0)
+	}
+	if gv.renderer != nil {
+		if gv.showTracks {
+			gv.renderer.TrackMap = gv.buildTrackMap()
+		}
+		if gv.showCriticalPath {
+			gv.renderer.CriticalPath = gv.computeCriticalPath()
+		}
+	}
+	gv.refreshSearch()
+}
+
+// nodeStatus returns the status of a graph node. A collapsed group reports
+// the status of its members taken together; see groupStatus.
+func (gv *GraphView) nodeStatus(node string) PhaseStatus {
+	if !gv.collapsed[node] {
+		if status, ok := gv.statuses[node]; ok {
+			return status
+		}
+		return PhaseWaiting
+	}
+
+	var members []PhaseStatus
+	for _, id := range gv.phaseOrder {
+		if gv.nodeOf(id) == node {
+			members = append(members, gv.statuses[id])
+		}
+	}
+	return groupStatus(members)
+}
+
+// SelectedGroup returns the import group of the node under the cursor, or
+// "" when the selected phase is not part of a group.
+func (gv *GraphView) SelectedGroup() string {
+	id := gv.selectedNodeID()
+	if gv.collapsed[id] {
+		return id
+	}
+	return importGroupOf(id)
+}
+
+// ToggleGroup collapses or expands the import group of the selected node,
+// keeping the cursor on the group.
+func (gv *GraphView) ToggleGroup() {
+	g := gv.SelectedGroup()
+	if g == "" || gv.renderer == nil {
+		return
+	}
+	gv.collapsed[g] = !gv.collapsed[g]
+	gv.relayout()
+	for i, id := range gv.nodeIDs {
+		if inGroup(id, g) {
+			gv.cursor = i
+			break
+		}
+	}
+	gv.viewport.SetContent(gv.renderDAG())
+}
+
+// containsString reports whether s is in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
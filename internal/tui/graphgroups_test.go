@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func groupedGraphPhases() []PhaseInfo {
+	return []PhaseInfo{
+		{ID: "setup", Title: "Setup"},
+		{ID: "backend/migrate", Title: "Migrate", DependsOn: []string{"setup"}},
+		{ID: "backend/seed", Title: "Seed", DependsOn: []string{"backend/migrate"}},
+		{ID: "deploy", Title: "Deploy", DependsOn: []string{"backend/seed"}},
+	}
+}
+
+func TestImportGroupOf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		id, want string
+	}{
+		{"setup", ""},
+		{"backend/migrate", "backend"},
+		{"svc/db/schema", "svc/db"},
+	}
+	for _, tt := range tests {
+		if got := importGroupOf(tt.id); got != tt.want {
+			t.Errorf("importGroupOf(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestGraphView_ToggleGroup(t *testing.T) {
+	t.Parallel()
+	gv := NewGraphView(groupedGraphPhases(), 100, 40)
+	if len(gv.nodeIDs) != 4 {
+		t.Fatalf("expected 4 nodes before collapsing, got %v", gv.nodeIDs)
+	}
+
+	gv.cursor = 1 // backend/migrate
+	if g := gv.SelectedGroup(); g != "backend" {
+		t.Fatalf("SelectedGroup = %q, want backend", g)
+	}
+	gv.ToggleGroup()
+
+	if want := []string{"setup", "backend", "deploy"}; !reflect.DeepEqual(gv.nodeIDs, want) {
+		t.Fatalf("collapsed nodes = %v, want %v", gv.nodeIDs, want)
+	}
+	if !reflect.DeepEqual(gv.deps["backend"], []string{"setup"}) || !reflect.DeepEqual(gv.deps["deploy"], []string{"backend"}) {
+		t.Errorf("collapsed deps = %v", gv.deps)
+	}
+	if gv.selectedNodeID() != "backend" {
+		t.Errorf("cursor should stay on the group, got %q", gv.selectedNodeID())
+	}
+	if gv.SelectedPhaseID() != "" {
+		t.Errorf("a collapsed group is not a phase, got %q", gv.SelectedPhaseID())
+	}
+	if view := gv.View(); !strings.Contains(view, "backend (2 phases)") {
+		t.Errorf("expected group node in view, got:\n%s", view)
+	}
+
+	gv.ToggleGroup()
+	if len(gv.nodeIDs) != 4 || gv.SelectedPhaseID() != "backend/migrate" {
+		t.Errorf("expanding should restore phases with the cursor on the first member, got %v at %q",
+			gv.nodeIDs, gv.SelectedPhaseID())
+	}
+}
+
+func TestGraphView_CollapsedGroupStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		migrate, seed PhaseStatus
+		want          PhaseStatus
+	}{
+		{"all waiting", PhaseWaiting, PhaseWaiting, PhaseWaiting},
+		{"one running", PhaseDone, PhaseWorking, PhaseWorking},
+		{"failure wins", PhaseWorking, PhaseFailed, PhaseFailed},
+		{"all finished", PhaseDone, PhaseSkipped, PhaseDone},
+		{"partially done", PhaseDone, PhaseWaiting, PhaseWaiting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gv := NewGraphView(groupedGraphPhases(), 100, 40)
+			gv.SetPhaseStatus("backend/migrate", tt.migrate)
+			gv.SetPhaseStatus("backend/seed", tt.seed)
+			gv.cursor = 1
+			gv.ToggleGroup()
+			if got := gv.nodeStatus("backend"); got != tt.want {
+				t.Errorf("nodeStatus = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphView_ToggleGroupIgnoresUngroupedPhase(t *testing.T) {
+	t.Parallel()
+	gv := NewGraphView(groupedGraphPhases(), 100, 40)
+	gv.cursor = 0 // setup
+	gv.ToggleGroup()
+	if len(gv.nodeIDs) != 4 {
+		t.Errorf("toggling an ungrouped phase should be a no-op, got %v", gv.nodeIDs)
+	}
+}
+
+func TestFoldGroupsNested(t *testing.T) {
+	t.Parallel()
+	phases := []PhaseEntry{
+		{ID: "svc/api", Status: PhaseDone},
+		{ID: "svc/db/schema", Status: PhaseWorking},
+		{ID: "svc/db/seed", Status: PhaseWaiting},
+		{ID: "deploy"},
+	}
+	ids := func(entries []PhaseEntry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	inner := foldGroups(phases, map[string]bool{"svc/db": true})
+	if want := []string{"svc/api", "svc/db", "deploy"}; !reflect.DeepEqual(ids(inner), want) {
+		t.Errorf("collapsing svc/db = %v, want %v", ids(inner), want)
+	}
+	if inner[1].Status != PhaseWorking || !strings.Contains(inner[1].Title, "(2 phases)") {
+		t.Errorf("svc/db group = %+v", inner[1])
+	}
+
+	outer := foldGroups(phases, map[string]bool{"svc": true, "svc/db": true})
+	if want := []string{"svc", "deploy"}; !reflect.DeepEqual(ids(outer), want) {
+		t.Errorf("collapsing svc = %v, want %v", ids(outer), want)
+	}
+}
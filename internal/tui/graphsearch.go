@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphSearch is the graph tab's "/" search: the query being typed or
+// held, and the nodes it matches in cursor order.
+type graphSearch struct {
+	input   textinput.Model
+	typing  bool     // the query is still being edited
+	matches []string // matching node IDs, in nodeIDs order
+	current int      // index into matches of the focused match
+}
+
+// StartSearch opens the search prompt, keeping any previous query.
+func (gv *GraphView) StartSearch() {
+	if gv.search == nil {
+		ti := textinput.New()
+		ti.Prompt = "/"
+		ti.Placeholder = "search phases"
+		ti.CharLimit = 64
+		gv.search = &graphSearch{input: ti}
+	}
+	gv.search.typing = true
+	gv.search.input.Focus()
+}
+
+// Searching reports whether the search prompt is taking keystrokes.
+func (gv *GraphView) Searching() bool {
+	return gv.search != nil && gv.search.typing
+}
+
+// SearchActive reports whether a search query is held, so n/N cycle its
+// matches and Esc clears it.
+func (gv *GraphView) SearchActive() bool {
+	return gv.search != nil
+}
+
+// HandleSearchKey edits the query while the prompt is open, focusing the
+// first match as it changes. Enter keeps the query for n/N; Esc, or Enter
+// on an empty query, clears the search.
+func (gv *GraphView) HandleSearchKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		gv.ClearSearch()
+		return nil
+	case "enter":
+		if gv.search.input.Value() == "" {
+			gv.ClearSearch()
+			return nil
+		}
+		gv.search.typing = false
+		gv.search.input.Blur()
+		return nil
+	}
+	var cmd tea.Cmd
+	gv.search.input, cmd = gv.search.input.Update(msg)
+	gv.search.current = 0
+	gv.refreshSearch()
+	gv.focusMatch()
+	return cmd
+}
+
+// NextMatch moves the cursor to the next match, wrapping to the first.
+func (gv *GraphView) NextMatch() {
+	gv.stepMatch(1)
+}
+
+// PrevMatch moves the cursor to the previous match, wrapping to the last.
+func (gv *GraphView) PrevMatch() {
+	gv.stepMatch(-1)
+}
+
+// stepMatch advances the focused match by delta, wrapping around.
+func (gv *GraphView) stepMatch(delta int) {
+	if gv.search == nil || len(gv.search.matches) == 0 {
+		return
+	}
+	n := len(gv.search.matches)
+	gv.search.current = ((gv.search.current+delta)%n + n) % n
+	gv.focusMatch()
+}
+
+// ClearSearch drops the query and its highlights.
+func (gv *GraphView) ClearSearch() {
+	gv.search = nil
+	if gv.renderer != nil {
+		gv.renderer.Highlight = nil
+		gv.viewport.SetContent(gv.renderDAG())
+	}
+}
+
+// refreshSearch recomputes the matches of the query against node IDs and
+// titles, ignoring case. It runs after every edit and relayout, so
+// collapsing a group or hot-adding a phase keeps the matches current.
+func (gv *GraphView) refreshSearch() {
+	if gv.search == nil {
+		return
+	}
+	query := strings.ToLower(gv.search.input.Value())
+	gv.search.matches = gv.search.matches[:0]
+	if query != "" {
+		for _, id := range gv.nodeIDs {
+			if strings.Contains(strings.ToLower(id), query) || strings.Contains(strings.ToLower(gv.titles[id]), query) {
+				gv.search.matches = append(gv.search.matches, id)
+			}
+		}
+	}
+	if gv.search.current >= len(gv.search.matches) {
+		gv.search.current = 0
+	}
+}
+
+// searchHighlight returns the set of matching nodes for the renderer.
+func (gv *GraphView) searchHighlight() map[string]bool {
+	if gv.search == nil || len(gv.search.matches) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(gv.search.matches))
+	for _, id := range gv.search.matches {
+		set[id] = true
+	}
+	return set
+}
+
+// focusMatch re-renders the highlights, puts the cursor on the focused
+// match, and scrolls the viewport so the match sits in the middle of it.
+func (gv *GraphView) focusMatch() {
+	if gv.search == nil || gv.renderer == nil {
+		return
+	}
+	if len(gv.search.matches) == 0 {
+		gv.viewport.SetContent(gv.renderDAG())
+		return
+	}
+	id := gv.search.matches[gv.search.current]
+	for i, nodeID := range gv.nodeIDs {
+		if nodeID == id {
+			gv.cursor = i
+			break
+		}
+	}
+	gv.viewport.SetContent(gv.renderDAG())
+	gv.viewport.SetYOffset(max(gv.renderer.NodeRows[id]-gv.viewport.Height/2, 0))
+}
+
+// searchLine renders the prompt while typing, or the held query with the
+// position of the focused match, and "" when no search is active.
+func (gv GraphView) searchLine() string {
+	if gv.search == nil {
+		return ""
+	}
+	if gv.search.typing {
+		return "  " + gv.search.input.View()
+	}
+	status := "no matches"
+	if n := len(gv.search.matches); n > 0 {
+		status = fmt.Sprintf("%d of %d", gv.search.current+1, n)
+	}
+	return lipgloss.NewStyle().Foreground(colorMutedLight).
+		Render(fmt.Sprintf("  /%s · %s  (n/N next/prev, esc clear)", gv.search.input.Value(), status))
+}
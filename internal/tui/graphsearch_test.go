@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchPhases returns a chain of n phases in which phases 3 and n-2 are
+// titled "Deploy", so the second match lies far below the first.
+func searchPhases(n int) []PhaseInfo {
+	phases := make([]PhaseInfo, n)
+	for i := range phases {
+		phases[i] = PhaseInfo{ID: fmt.Sprintf("p%02d", i), Title: fmt.Sprintf("Build %d", i)}
+		if i > 0 {
+			phases[i].DependsOn = []string{phases[i-1].ID}
+		}
+	}
+	phases[3].Title = "Deploy staging"
+	phases[n-2].Title = "Deploy prod"
+	return phases
+}
+
+func typeKeys(gv *GraphView, s string) {
+	for _, r := range s {
+		gv.HandleSearchKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestGraphSearchFocusesAndCyclesMatches(t *testing.T) {
+	t.Parallel()
+
+	gv := NewGraphView(searchPhases(30), 80, 8)
+	gv.StartSearch()
+	typeKeys(&gv, "DEPLOY")
+	if got := gv.SelectedPhaseID(); got != "p03" {
+		t.Fatalf("selected %q while typing, want the first match p03", got)
+	}
+	gv.HandleSearchKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if gv.Searching() || !gv.SearchActive() {
+		t.Fatal("enter should close the prompt and keep the query")
+	}
+
+	gv.NextMatch()
+	if got := gv.SelectedPhaseID(); got != "p28" {
+		t.Fatalf("selected %q after n, want p28", got)
+	}
+	row := gv.renderer.NodeRows["p28"]
+	if off := gv.viewport.YOffset; row < off || row >= off+gv.viewport.Height {
+		t.Errorf("match on line %d is outside the viewport at offset %d", row, off)
+	}
+	if view := gv.View(); !strings.Contains(view, "2 of 2") {
+		t.Errorf("expected the match position in the view, got:\n%s", view)
+	}
+
+	gv.NextMatch()
+	if got := gv.SelectedPhaseID(); got != "p03" {
+		t.Errorf("n did not wrap to the first match: %q", got)
+	}
+	gv.PrevMatch()
+	if got := gv.SelectedPhaseID(); got != "p28" {
+		t.Errorf("N did not wrap to the last match: %q", got)
+	}
+
+	gv.ClearSearch()
+	if gv.SearchActive() || gv.renderer.Highlight != nil {
+		t.Error("search or highlights kept after clearing")
+	}
+}
+
+func TestGraphSearchNoMatches(t *testing.T) {
+	t.Parallel()
+
+	gv := NewGraphView(searchPhases(12), 80, 8)
+	gv.StartSearch()
+	typeKeys(&gv, "zzz")
+	gv.HandleSearchKey(tea.KeyMsg{Type: tea.KeyEnter})
+	gv.NextMatch()
+	if got := gv.SelectedPhaseID(); got != "p00" {
+		t.Errorf("cursor moved to %q with no matches", got)
+	}
+	if view := gv.View(); !strings.Contains(view, "no matches") {
+		t.Errorf("expected a no-matches note, got:\n%s", view)
+	}
+
+	// Enter on an empty query closes the search outright.
+	gv.ClearSearch()
+	gv.StartSearch()
+	gv.HandleSearchKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if gv.SearchActive() {
+		t.Error("empty query kept")
+	}
+}
+
+func TestGraphSearchKeys(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: searchPhases(30)})
+	m = tm.(AppModel)
+	m.ActiveTab = TabGraph
+
+	tm = m
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("q")}, // typed into the query, not quitting
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("prod")},
+		{Type: tea.KeyEnter},
+	} {
+		tm, _ = tm.Update(k)
+	}
+	m = tm.(AppModel)
+	if m.ShowQuitConfirm {
+		t.Fatal("q in the search prompt asked to quit")
+	}
+	if got := m.Graph.SelectedPhaseID(); got != "p28" {
+		t.Fatalf("selected %q, want p28", got)
+	}
+
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(AppModel)
+	if m.Graph.SearchActive() {
+		t.Error("esc did not clear the search")
+	}
+}
@@ -1,8 +1,6 @@
 package tui
 
 import (
-	"fmt"
-	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -17,81 +15,58 @@ import (
 // It re-renders on phase status changes and supports vertical scrolling
 // for large graphs. Nodes are selectable for drill-down into phase loops.
 type GraphView struct {
-	renderer *ui.DAGRenderer
-	waves    []dag.Wave
-	deps     map[string][]string // phaseID → dependency IDs
-	titles   map[string]string   // phaseID → display title
-	statuses map[string]PhaseStatus
-	nodeIDs  []string // ordered list of all node IDs for cursor navigation
-	cursor   int      // index into nodeIDs
-	viewport viewport.Model
-	width    int
-	height   int
-	ready    bool // whether the viewport has been initialized with dimensions
+	renderer    *ui.DAGRenderer
+	waves       []dag.Wave
+	phaseDeps   map[string][]string // phaseID → dependency IDs
+	phaseTitles map[string]string   // phaseID → display title
+	phaseOrder  []string            // phase IDs in insertion order
+	collapsed   map[string]bool     // import groups rendered as a single node
+	deps        map[string][]string // node ID → dependency node IDs, after collapsing
+	titles      map[string]string   // node ID → display title, after collapsing
+	statuses    map[string]PhaseStatus
+	nodeIDs     []string // ordered list of all node IDs for cursor navigation
+	cursor      int      // index into nodeIDs
+	viewport    viewport.Model
+	width       int
+	height      int
+	ready       bool // whether the viewport has been initialized with dimensions
 
 	// Toggle state.
 	showTracks       bool
 	showCriticalPath bool
+	showImpact       bool
+
+	// search is the active "/" search, or nil.
+	search *graphSearch
 }
 
 // NewGraphView creates a GraphView from phase info.
 func NewGraphView(phases []PhaseInfo, width, height int) GraphView {
-	deps := make(map[string][]string, len(phases))
-	titles := make(map[string]string, len(phases))
-	statuses := make(map[string]PhaseStatus, len(phases))
-	var nodeIDs []string
-
-	for _, p := range phases {
-		deps[p.ID] = p.DependsOn
-		titles[p.ID] = p.Title
-		if p.Status != 0 {
-			statuses[p.ID] = p.Status
-		} else {
-			statuses[p.ID] = PhaseWaiting
-		}
-		nodeIDs = append(nodeIDs, p.ID)
-	}
-
-	// Build DAG to compute waves.
-	d := dag.New()
-	for _, p := range phases {
-		d.AddNodeIdempotent(p.ID, 0)
-	}
-	for _, p := range phases {
-		for _, dep := range p.DependsOn {
-			if err := d.AddEdge(p.ID, dep); err != nil {
-				fmt.Fprintf(os.Stderr, "graphview: AddEdge(%s, %s): %v\n", p.ID, dep, err)
-			}
-		}
-	}
-	waves, err := d.ComputeWaves()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "graphview: ComputeWaves: %v\n", err)
-	}
-
-	// Reorder nodeIDs to follow wave order for intuitive cursor navigation.
-	ordered := make([]string, 0, len(nodeIDs))
-	for _, w := range waves {
-		ordered = append(ordered, w.NodeIDs...)
-	}
-	if len(ordered) > 0 {
-		nodeIDs = ordered
-	}
-
 	gv := GraphView{
 		renderer: &ui.DAGRenderer{
 			Width:    width,
 			UseColor: true,
 		},
-		waves:    waves,
-		deps:     deps,
-		titles:   titles,
-		statuses: statuses,
-		nodeIDs:  nodeIDs,
-		width:    width,
-		height:   height,
+		phaseDeps:   make(map[string][]string, len(phases)),
+		phaseTitles: make(map[string]string, len(phases)),
+		collapsed:   make(map[string]bool),
+		statuses:    make(map[string]PhaseStatus, len(phases)),
+		width:       width,
+		height:      height,
 	}
 
+	for _, p := range phases {
+		gv.phaseDeps[p.ID] = p.DependsOn
+		gv.phaseTitles[p.ID] = p.Title
+		if p.Status != 0 {
+			gv.statuses[p.ID] = p.Status
+		} else {
+			gv.statuses[p.ID] = PhaseWaiting
+		}
+		gv.phaseOrder = append(gv.phaseOrder, p.ID)
+	}
+	gv.relayout()
+
 	gv.initViewport()
 	return gv
 }
@@ -129,41 +104,16 @@ func (gv *GraphView) SetPhaseStatus(phaseID string, status PhaseStatus) {
 func (gv *GraphView) AppendPhase(p PhaseInfo) {
 	if gv.statuses == nil {
 		gv.statuses = make(map[string]PhaseStatus)
-		gv.deps = make(map[string][]string)
-		gv.titles = make(map[string]string)
+		gv.phaseDeps = make(map[string][]string)
+		gv.phaseTitles = make(map[string]string)
+		gv.collapsed = make(map[string]bool)
 	}
 
-	gv.deps[p.ID] = p.DependsOn
-	gv.titles[p.ID] = p.Title
+	gv.phaseDeps[p.ID] = p.DependsOn
+	gv.phaseTitles[p.ID] = p.Title
 	gv.statuses[p.ID] = PhaseWaiting
-	gv.nodeIDs = append(gv.nodeIDs, p.ID)
-
-	// Rebuild the DAG to recompute waves with the new phase.
-	d := dag.New()
-	for id := range gv.statuses {
-		d.AddNodeIdempotent(id, 0)
-	}
-	for id, depList := range gv.deps {
-		for _, dep := range depList {
-			if err := d.AddEdge(id, dep); err != nil {
-				fmt.Fprintf(os.Stderr, "graphview: AppendPhase AddEdge(%s, %s): %v\n", id, dep, err)
-			}
-		}
-	}
-	waves, err := d.ComputeWaves()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "graphview: AppendPhase ComputeWaves: %v\n", err)
-	}
-	gv.waves = waves
-
-	// Reorder nodeIDs to follow wave order.
-	ordered := make([]string, 0, len(gv.nodeIDs))
-	for _, w := range waves {
-		ordered = append(ordered, w.NodeIDs...)
-	}
-	if len(ordered) > 0 {
-		gv.nodeIDs = ordered
-	}
+	gv.phaseOrder = append(gv.phaseOrder, p.ID)
+	gv.relayout()
 
 	// Re-render the viewport.
 	if gv.renderer != nil {
@@ -172,8 +122,18 @@ func (gv *GraphView) AppendPhase(p PhaseInfo) {
 }
 
 // SelectedPhaseID returns the phase ID at the current cursor position,
-// or empty string if no phases exist.
+// or empty string if no phases exist or a collapsed group is selected.
 func (gv *GraphView) SelectedPhaseID() string {
+	id := gv.selectedNodeID()
+	if gv.collapsed[id] {
+		return ""
+	}
+	return id
+}
+
+// selectedNodeID returns the node ID at the cursor, which may be a
+// collapsed group.
+func (gv *GraphView) selectedNodeID() string {
 	if len(gv.nodeIDs) == 0 {
 		return ""
 	}
@@ -224,6 +184,7 @@ func (gv *GraphView) ToggleCriticalPath() {
 	}
 	gv.showCriticalPath = !gv.showCriticalPath
 	if gv.showCriticalPath {
+		gv.showImpact = false
 		gv.renderer.CriticalPath = gv.computeCriticalPath()
 	} else {
 		gv.renderer.CriticalPath = nil
@@ -241,19 +202,25 @@ func (gv GraphView) View() string {
 	if gv.renderer == nil || len(gv.waves) == 0 {
 		return emptyGraphPlaceholder(gv.width)
 	}
+	if line := gv.searchLine(); line != "" {
+		vp := gv.viewport
+		vp.Height = max(vp.Height-1, 1)
+		return vp.View() + "\n" + line
+	}
 	return gv.viewport.View()
 }
 
 // renderDAG produces the DAG string with cursor highlighting applied.
 func (gv *GraphView) renderDAG() string {
-	selectedID := gv.SelectedPhaseID()
+	selectedID := gv.selectedNodeID()
+	if gv.showImpact {
+		gv.renderer.CriticalPath = gv.impactHighlight()
+	}
+	gv.renderer.Highlight = gv.searchHighlight()
 
 	// Configure the status function to map PhaseStatus to DAGRenderer states.
 	gv.renderer.StatusFunc = func(id string) ui.NodeStatus {
-		status, ok := gv.statuses[id]
-		if !ok {
-			status = PhaseWaiting
-		}
+		status := gv.nodeStatus(id)
 		return ui.NodeStatus{
 			State: phaseStatusToDAGState(status),
 		}
@@ -272,7 +239,7 @@ func (gv *GraphView) renderDAG() string {
 		if title == "" {
 			title = selectedID
 		}
-		status := gv.statuses[selectedID]
+		status := gv.nodeStatus(selectedID)
 		indicator := lipgloss.NewStyle().
 			Foreground(colorNebula).
 			Bold(true).
@@ -283,6 +250,9 @@ func (gv *GraphView) renderDAG() string {
 		sb.WriteString(indicator + stateLabel)
 		sb.WriteByte('\n')
 	}
+	if gv.showImpact {
+		sb.WriteString(gv.impactLines())
+	}
 
 	// Legend line.
 	sb.WriteByte('\n')
@@ -297,6 +267,9 @@ func (gv *GraphView) renderDAG() string {
 	if gv.showCriticalPath {
 		toggles = append(toggles, "critical path: on")
 	}
+	if gv.showImpact {
+		toggles = append(toggles, "impact: on")
+	}
 	if len(toggles) > 0 {
 		sb.WriteByte('\n')
 		sb.WriteString(lipgloss.NewStyle().
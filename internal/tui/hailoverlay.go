@@ -118,12 +118,9 @@ func (h *HailOverlay) HandleInput() string {
 	return val
 }
 
-// View renders the hail overlay box content (without centering — the caller
-// handles centering and dimming).
-func (h HailOverlay) View(width, _ int) string {
-	var b strings.Builder
-
-	// Constrain overlay width to terminal width with padding.
+// hailOverlayWidth constrains the hail overlay to the terminal width with
+// padding, but never below 30 columns.
+func hailOverlayWidth(width int) int {
 	overlayWidth := 60
 	if width > 0 && width < overlayWidth+4 {
 		overlayWidth = width - 4
@@ -131,6 +128,15 @@ func (h HailOverlay) View(width, _ int) string {
 	if overlayWidth < 30 {
 		overlayWidth = 30
 	}
+	return overlayWidth
+}
+
+// View renders the hail overlay box content (without centering — the caller
+// handles centering and dimming).
+func (h HailOverlay) View(width, _ int) string {
+	var b strings.Builder
+
+	overlayWidth := hailOverlayWidth(width)
 
 	// Header — critical hails get a more urgent indicator.
 	var header string
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heartbeatStaleAfter is how long a worker card's phase may go without
+// activity before its "last activity" line turns amber.
+const heartbeatStaleAfter = time.Minute
+
+// notePhaseActivity records activity for phaseID's worker card and re-arms
+// its silence warning.
+func (m *AppModel) notePhaseActivity(phaseID string, at time.Time) {
+	if wc := m.WorkerCards[phaseID]; wc != nil && at.After(wc.LastActivity) {
+		wc.LastActivity = at
+		wc.SilentFor = 0
+		wc.silenceWarned = false
+	}
+}
+
+// checkHeartbeats runs on every tick. It ages each active card's last
+// activity and, once a phase has been silent for SilenceLimit, warns about
+// it with a toast — once per silence.
+func (m *AppModel) checkHeartbeats(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, wc := range sortedWorkerCards(m.WorkerCards) {
+		if wc.LastActivity.IsZero() {
+			continue
+		}
+		wc.SilentFor = now.Sub(wc.LastActivity)
+		if m.SilenceLimit <= 0 || wc.SilentFor < m.SilenceLimit || wc.silenceWarned {
+			continue
+		}
+		wc.silenceWarned = true
+		silent := wc.SilentFor.Round(time.Second)
+		m.addMessage("[%s] no activity for %s", wc.PhaseID, silent)
+		toast, cmd := NewToast(fmt.Sprintf("[%s] no activity for %s — it may be stuck", wc.PhaseID, silent), true)
+		m.Toasts = append(m.Toasts, toast)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// heartbeatLine renders how long ago the card's phase last showed activity,
+// in amber once it passes heartbeatStaleAfter. It is empty before the first
+// tick and once the phase has finished.
+func (wc *WorkerCard) heartbeatLine() string {
+	if wc.LastActivity.IsZero() || wc.FinalStatus == PhaseDone || wc.FinalStatus == PhaseFailed {
+		return ""
+	}
+	label := fmt.Sprintf("last activity %s ago", wc.SilentFor.Round(time.Second))
+	color := colorMuted
+	if wc.SilentFor >= heartbeatStaleAfter {
+		color = colorAccent
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(label)
+}
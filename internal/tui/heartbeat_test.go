@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPhaseHeartbeatSilenceWarning(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases(t.TempDir(), []PhaseEntry{{ID: "a", Status: PhaseWaiting}})
+	m.Splash = nil
+	update := func(msg any) {
+		t.Helper()
+		result, _ := m.Update(msg)
+		got := result.(AppModel)
+		m = &got
+	}
+	update(MsgSilenceLimit{Limit: 5 * time.Minute})
+	update(MsgPhaseTaskStarted{PhaseID: "a"})
+	start := time.Now().Add(time.Second)
+	update(MsgPhaseHeartbeat{PhaseID: "a", At: start})
+
+	update(MsgTick{Time: start.Add(90 * time.Second)})
+	wc := m.WorkerCards["a"]
+	if wc.SilentFor != 90*time.Second {
+		t.Fatalf("SilentFor = %s, want 1m30s", wc.SilentFor)
+	}
+	if view := wc.View(40); !strings.Contains(view, "last activity 1m30s ago") {
+		t.Errorf("card does not show the heartbeat:\n%s", view)
+	}
+	if len(m.Toasts) != 0 {
+		t.Fatalf("warned before the silence limit: %+v", m.Toasts)
+	}
+
+	update(MsgTick{Time: start.Add(6 * time.Minute)})
+	update(MsgTick{Time: start.Add(7 * time.Minute)})
+	if len(m.Toasts) != 1 || !m.Toasts[0].IsError || !strings.Contains(m.Toasts[0].Message, "[a] no activity for 6m0s") {
+		t.Fatalf("want one silence warning, got %+v", m.Toasts)
+	}
+
+	// Activity re-arms the warning.
+	update(MsgPhaseHeartbeat{PhaseID: "a", At: start.Add(8 * time.Minute)})
+	if wc.SilentFor != 0 {
+		t.Errorf("SilentFor = %s after a heartbeat, want 0", wc.SilentFor)
+	}
+	update(MsgTick{Time: start.Add(14 * time.Minute)})
+	if len(m.Toasts) != 2 {
+		t.Errorf("want a second warning after renewed silence, got %d toasts", len(m.Toasts))
+	}
+}
+
+func TestPhaseHeartbeatDisabled(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases(t.TempDir(), []PhaseEntry{{ID: "a", Status: PhaseWaiting}})
+	m.Splash = nil
+	result, _ := m.Update(MsgPhaseTaskStarted{PhaseID: "a"})
+	got := result.(AppModel)
+	result, _ = got.Update(MsgTick{Time: time.Now().Add(time.Hour)})
+	got = result.(AppModel)
+	if len(got.Toasts) != 0 {
+		t.Errorf("warned with no silence limit: %+v", got.Toasts)
+	}
+}
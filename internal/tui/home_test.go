@@ -293,15 +293,17 @@ func TestHomeKey_InfoToggle(t *testing.T) {
 		}
 	})
 
-	t.Run("? also toggles detail panel", func(t *testing.T) {
+	t.Run("? opens the keybinding cheat sheet instead", func(t *testing.T) {
 		t.Parallel()
 		m := newHomeModel(choices)
 
-		// Toggle off with ?.
 		result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
 		rm := result.(AppModel)
-		if rm.ShowPlan {
-			t.Error("expected ShowPlan false after ? toggle")
+		if !rm.ShowPlan {
+			t.Error("expected ? to leave the detail panel alone")
+		}
+		if rm.KeyHelp == nil {
+			t.Error("expected ? to open the keybinding cheat sheet")
 		}
 	})
 }
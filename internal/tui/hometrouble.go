@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// homeTroubleLabel summarizes a nebula's failed and stuck phases, e.g.
+// "2 phases failed, 1 stuck", or returns "" when there are none.
+func homeTroubleLabel(nc NebulaChoice) string {
+	var parts []string
+	if nc.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d phase%s failed", nc.Failed, pluralS(nc.Failed)))
+	}
+	if nc.Stuck > 0 {
+		label := fmt.Sprintf("%d stuck", nc.Stuck)
+		if nc.Failed == 0 {
+			label = fmt.Sprintf("%d phase%s stuck", nc.Stuck, pluralS(nc.Stuck))
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, ", ")
+}
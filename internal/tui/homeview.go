@@ -15,6 +15,7 @@ const (
 	HomeFilterReady                        // show ready and in-progress
 	HomeFilterInProgress                   // show in-progress only
 	HomeFilterDone                         // show done only
+	HomeFilterFailures                     // show nebulas with failed or stuck phases
 	homeFilterCount                        // sentinel for cycling
 )
 
@@ -27,6 +28,8 @@ func (f HomeFilter) String() string {
 		return "in progress"
 	case HomeFilterDone:
 		return "done"
+	case HomeFilterFailures:
+		return "failures"
 	default:
 		return "all"
 	}
@@ -46,6 +49,8 @@ func (f HomeFilter) Matches(nc NebulaChoice) bool {
 		return nc.Status == "in_progress"
 	case HomeFilterDone:
 		return nc.Status == "done"
+	case HomeFilterFailures:
+		return nc.Failed > 0 || nc.Stuck > 0
 	default:
 		return true
 	}
@@ -71,8 +76,9 @@ type HomeView struct {
 	Cursor  int
 	Offset  int // first visible item index for viewport scrolling
 	Width   int
-	Height  int        // available lines for the list (0 = no constraint)
-	Filter  HomeFilter // active filter
+	Height  int             // available lines for the list (0 = no constraint)
+	Filter  HomeFilter      // active filter
+	Marked  map[string]bool // nebula paths marked to run together
 }
 
 // View renders the home landing page with a scrollable list of nebulas.
@@ -114,6 +120,7 @@ func (hv HomeView) View() string {
 		Width:   hv.Width,
 		Height:  listHeight,
 		Filter:  hv.Filter,
+		Marked:  hv.Marked,
 	}
 	offset := listView.ensureCursorVisible()
 	b.WriteString(listView.renderWindow(offset))
@@ -233,9 +240,10 @@ func (hv HomeView) renderWindow(offset int) string {
 	return b.String()
 }
 
-// renderFilterBar renders the filter chips (all / active / in progress / done).
+// renderFilterBar renders the filter chips (all / active / in progress /
+// done / failures).
 func (hv HomeView) renderFilterBar() string {
-	filters := []HomeFilter{HomeFilterAll, HomeFilterReady, HomeFilterInProgress, HomeFilterDone}
+	filters := []HomeFilter{HomeFilterAll, HomeFilterReady, HomeFilterInProgress, HomeFilterDone, HomeFilterFailures}
 	var parts []string
 	for _, f := range filters {
 		label := f.String()
@@ -269,6 +277,15 @@ func (hv HomeView) renderNebulaRow(i int, nc NebulaChoice) string {
 		indicator = styleSelectionIndicator.Render(selectionIndicator) + " "
 	}
 
+	// Mark column — only shown once something is marked.
+	if len(hv.Marked) > 0 {
+		if hv.Marked[nc.Path] {
+			indicator += styleRowDone.Render("●") + " "
+		} else {
+			indicator += "○ "
+		}
+	}
+
 	// Status icon and label with color coding.
 	statusIcon, statusStyle := homeStatusIconAndStyle(nc.Status)
 
@@ -280,14 +297,14 @@ func (hv HomeView) renderNebulaRow(i int, nc NebulaChoice) string {
 			nameWidth = 8
 		}
 	}
-	name := TruncateWithEllipsis(nc.Name, nameWidth)
-	paddedName := fmt.Sprintf("%-*s", nameWidth, name)
+	name := TruncateWithEllipsis(withIcon(nc.Icon, nc.Name), nameWidth)
+	paddedName := name + strings.Repeat(" ", max(0, nameWidth-lipgloss.Width(name)))
 
 	var styledName string
 	if selected {
-		styledName = styleRowSelected.Render(paddedName)
+		styledName = identityStyle(styleRowSelected, nc.Color).Render(paddedName)
 	} else {
-		styledName = stylePhaseID.Render(paddedName)
+		styledName = identityStyle(stylePhaseID, nc.Color).Render(paddedName)
 	}
 
 	// Phase count and status detail.
@@ -297,6 +314,9 @@ func (hv HomeView) renderNebulaRow(i int, nc NebulaChoice) string {
 	}
 	statusLabel := homeStatusLabel(nc)
 	detail := phaseLabel + "  " + statusStyle.Render(statusIcon+" "+statusLabel)
+	if trouble := homeTroubleLabel(nc); trouble != "" {
+		detail += "  " + lipgloss.NewStyle().Foreground(colorDanger).Render(trouble)
+	}
 
 	styledDetail := "  " + stylePhaseDetail.Render(detail)
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestHomeView_View_Empty(t *testing.T) {
@@ -43,6 +45,35 @@ func TestHomeView_View_SingleNebula(t *testing.T) {
 	}
 }
 
+func TestHomeView_View_Identity(t *testing.T) {
+	t.Parallel()
+
+	hv := HomeView{
+		Nebulae: []NebulaChoice{
+			{Name: "alpha", Icon: "🚀", Color: "208", Status: "ready", Phases: 1},
+			{Name: "beta", Status: "ready", Phases: 1},
+		},
+		Width: 80,
+	}
+	out := hv.View()
+	if !strings.Contains(out, "🚀 alpha") {
+		t.Errorf("expected icon before the name, got:\n%s", out)
+	}
+	// The icon is two cells wide, so padding must keep the detail columns aligned.
+	var alpha, beta string
+	for _, l := range strings.Split(out, "\n") {
+		switch {
+		case strings.Contains(l, "alpha"):
+			alpha = l
+		case strings.Contains(l, "beta"):
+			beta = l
+		}
+	}
+	if lipgloss.Width(alpha) != lipgloss.Width(beta) {
+		t.Errorf("row widths differ: %d vs %d", lipgloss.Width(alpha), lipgloss.Width(beta))
+	}
+}
+
 func TestHomeView_View_MultipleNebulae(t *testing.T) {
 	t.Parallel()
 
@@ -246,8 +277,8 @@ func TestHomeFooterBindings(t *testing.T) {
 	km := DefaultKeyMap()
 	bindings := HomeFooterBindings(km)
 
-	if len(bindings) != 6 {
-		t.Fatalf("expected 6 home footer bindings, got %d", len(bindings))
+	if len(bindings) != 7 {
+		t.Fatalf("expected 7 home footer bindings, got %d", len(bindings))
 	}
 
 	// Verify the enter binding says "run".
@@ -267,6 +298,11 @@ func TestHomeFooterBindings(t *testing.T) {
 	if infoHelp.Desc != "info" {
 		t.Errorf("expected info binding desc 'info', got %q", infoHelp.Desc)
 	}
+
+	// Verify the mark binding says "mark".
+	if markHelp := bindings[5].Help(); markHelp.Desc != "mark" {
+		t.Errorf("expected mark binding desc 'mark', got %q", markHelp.Desc)
+	}
 }
 
 func TestHomeStatusIconAndStyle(t *testing.T) {
@@ -466,6 +502,10 @@ func TestHomeFilter_Cycle(t *testing.T) {
 		t.Errorf("expected HomeFilterDone, got %d", f)
 	}
 	f = f.Next()
+	if f != HomeFilterFailures {
+		t.Errorf("expected HomeFilterFailures, got %d", f)
+	}
+	f = f.Next()
 	if f != HomeFilterAll {
 		t.Errorf("expected HomeFilterAll after full cycle, got %d", f)
 	}
@@ -476,8 +516,8 @@ func TestHomeFilter_FilterNebulae(t *testing.T) {
 
 	all := []NebulaChoice{
 		{Name: "a", Status: "ready"},
-		{Name: "b", Status: "in_progress"},
-		{Name: "c", Status: "done"},
+		{Name: "b", Status: "in_progress", Stuck: 1},
+		{Name: "c", Status: "done", Failed: 2},
 		{Name: "d", Status: "ready"},
 	}
 
@@ -489,6 +529,7 @@ func TestHomeFilter_FilterNebulae(t *testing.T) {
 		{HomeFilterReady, 3},      // ready + in_progress
 		{HomeFilterInProgress, 1}, // in_progress only
 		{HomeFilterDone, 1},       // done only
+		{HomeFilterFailures, 2},   // any failed or stuck phase
 	}
 
 	for _, tc := range tests {
@@ -559,3 +600,40 @@ func TestHomeStatusLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestHomeTroubleLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		nc     NebulaChoice
+		expect string
+	}{
+		{NebulaChoice{}, ""},
+		{NebulaChoice{Failed: 2}, "2 phases failed"},
+		{NebulaChoice{Failed: 1, Stuck: 1}, "1 phase failed, 1 stuck"},
+		{NebulaChoice{Stuck: 3}, "3 phases stuck"},
+	}
+
+	for _, tc := range tests {
+		if got := homeTroubleLabel(tc.nc); got != tc.expect {
+			t.Errorf("homeTroubleLabel(%+v) = %q, want %q", tc.nc, got, tc.expect)
+		}
+	}
+}
+
+func TestHomeView_RowShowsFailures(t *testing.T) {
+	t.Parallel()
+
+	hv := HomeView{
+		Nebulae: []NebulaChoice{{Name: "broken", Status: "done", Phases: 3, Done: 1, Failed: 2}},
+		Width:   100,
+		Filter:  HomeFilterFailures,
+	}
+	out := hv.View()
+
+	for _, want := range []string{"failures", "2 phases failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
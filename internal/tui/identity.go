@@ -0,0 +1,20 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// withIcon prefixes name with a nebula's icon, if it has one.
+func withIcon(icon, name string) string {
+	if icon == "" {
+		return name
+	}
+	return icon + " " + name
+}
+
+// identityStyle tints base with a nebula's color, a value from
+// nebula.Info.ColorCode. An empty color leaves base unchanged.
+func identityStyle(base lipgloss.Style, color string) lipgloss.Style {
+	if color == "" {
+		return base
+	}
+	return base.Foreground(lipgloss.Color(color))
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IdlePauser pauses a run whose gate prompt has gone unanswered.
+// Implemented by nebula.WorkerGroup, which writes the PAUSE file and sends
+// an idle notification.
+type IdlePauser interface {
+	IdlePause(phaseID string, idle time.Duration) error
+}
+
+// checkIdle runs on every tick. With a gate on screen and no keypress for
+// IdleTimeout it pauses the run and hides the gate until someone is back.
+// While idle-paused it watches for the PAUSE file being removed outside the
+// TUI, which resumes the run just like a keypress does.
+func (m *AppModel) checkIdle(now time.Time) tea.Cmd {
+	if m.idleGate != nil {
+		if _, err := os.Stat(filepath.Join(m.NebulaDir, "PAUSE")); os.IsNotExist(err) {
+			m.restoreIdleGate()
+		}
+		return nil
+	}
+	if m.IdleTimeout <= 0 || m.IdlePauser == nil || m.Gate == nil || m.Paused || m.Stopping || m.NebulaDir == "" {
+		return nil
+	}
+
+	since := m.Gate.shownAt
+	if m.lastKeyAt.After(since) {
+		since = m.lastKeyAt
+	}
+	idle := now.Sub(since)
+	if idle < m.IdleTimeout {
+		return nil
+	}
+	if err := m.IdlePauser.IdlePause(m.Gate.PhaseID, idle); err != nil {
+		m.addMessage("idle pause failed: %s", err)
+		// Wait another full timeout before trying again.
+		m.lastKeyAt = now
+		return nil
+	}
+
+	m.idleGate, m.Gate = m.Gate, nil
+	m.Paused = true
+	m.addMessage("[%s] gate idle for %s — run paused", m.idleGate.PhaseID, idle.Round(time.Second))
+	toast, cmd := NewToast(fmt.Sprintf("[%s] gate idle — paused, press any key to resume", m.idleGate.PhaseID), false)
+	m.Toasts = append(m.Toasts, toast)
+	return cmd
+}
+
+// resumeFromIdle removes the PAUSE file written by an idle pause and
+// re-shows the hidden gate.
+func (m *AppModel) resumeFromIdle() {
+	err := os.Remove(filepath.Join(m.NebulaDir, "PAUSE"))
+	if err != nil && !os.IsNotExist(err) {
+		m.addMessage("failed to remove PAUSE file: %s", err)
+		return
+	}
+	m.restoreIdleGate()
+}
+
+// restoreIdleGate puts the gate hidden by an idle pause back on screen,
+// restarting its idle clock.
+func (m *AppModel) restoreIdleGate() {
+	m.Gate, m.idleGate = m.idleGate, nil
+	m.Gate.shownAt = time.Now()
+	m.Gate.Width = m.contentWidth()
+	m.Gate.Height = m.Height
+	m.Paused = false
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// fakeIdlePauser writes the PAUSE file like the WorkerGroup does and records
+// the phases it was asked to pause on.
+type fakeIdlePauser struct {
+	dir    string
+	paused []string
+}
+
+func (f *fakeIdlePauser) IdlePause(phaseID string, _ time.Duration) error {
+	f.paused = append(f.paused, phaseID)
+	return os.WriteFile(filepath.Join(f.dir, "PAUSE"), []byte("idle\n"), 0644)
+}
+
+// idleModel returns a nebula-mode model with a pending gate on phase "a"
+// and idle pausing enabled after a minute.
+func idleModel(t *testing.T) (AppModel, *fakeIdlePauser, chan nebula.GateAction) {
+	t.Helper()
+	dir := t.TempDir()
+	pauser := &fakeIdlePauser{dir: dir}
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	m.NebulaDir = dir
+	m.Width, m.Height = 120, 40
+	updated, _ := m.Update(MsgIdlePauserReady{Pauser: pauser, Timeout: time.Minute})
+	m = updated.(AppModel)
+
+	resp := make(chan nebula.GateAction, 1)
+	updated, _ = m.Update(MsgGatePrompt{Checkpoint: &nebula.Checkpoint{PhaseID: "a"}, ResponseCh: resp})
+	return updated.(AppModel), pauser, resp
+}
+
+func tick(m AppModel, at time.Time) AppModel {
+	updated, _ := m.Update(MsgTick{Time: at})
+	return updated.(AppModel)
+}
+
+func TestIdlePausesUnansweredGate(t *testing.T) {
+	t.Parallel()
+
+	m, pauser, _ := idleModel(t)
+
+	m = tick(m, time.Now().Add(30*time.Second))
+	if m.Gate == nil || m.Paused || len(pauser.paused) != 0 {
+		t.Fatal("run paused before the idle timeout")
+	}
+
+	m = tick(m, time.Now().Add(2*time.Minute))
+	if m.Gate != nil || m.idleGate == nil {
+		t.Error("gate still shown after idle pause")
+	}
+	if !m.Paused {
+		t.Error("model not marked paused")
+	}
+	if len(pauser.paused) != 1 || pauser.paused[0] != "a" {
+		t.Errorf("IdlePause calls = %v, want [a]", pauser.paused)
+	}
+
+	// Further ticks while paused do not pause again.
+	m = tick(m, time.Now().Add(5*time.Minute))
+	if len(pauser.paused) != 1 {
+		t.Errorf("IdlePause called %d times, want 1", len(pauser.paused))
+	}
+}
+
+func TestIdleKeypressDefersPause(t *testing.T) {
+	t.Parallel()
+
+	m, pauser, _ := idleModel(t)
+	m.lastKeyAt = time.Now().Add(90 * time.Second)
+
+	m = tick(m, time.Now().Add(2*time.Minute))
+	if m.Gate == nil || len(pauser.paused) != 0 {
+		t.Error("run paused within a minute of the last keypress")
+	}
+}
+
+func TestIdleResume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		resume func(t *testing.T, m AppModel) AppModel
+	}{
+		{
+			name: "keypress",
+			resume: func(t *testing.T, m AppModel) AppModel {
+				updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+				m = updated.(AppModel)
+				if _, err := os.Stat(filepath.Join(m.NebulaDir, "PAUSE")); !os.IsNotExist(err) {
+					t.Errorf("PAUSE file still present after keypress (stat err %v)", err)
+				}
+				return m
+			},
+		},
+		{
+			name: "PAUSE removed",
+			resume: func(t *testing.T, m AppModel) AppModel {
+				if err := os.Remove(filepath.Join(m.NebulaDir, "PAUSE")); err != nil {
+					t.Fatal(err)
+				}
+				return tick(m, time.Now())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, _, resp := idleModel(t)
+			m = tick(m, time.Now().Add(2*time.Minute))
+			if m.idleGate == nil {
+				t.Fatal("precondition: run not idle-paused")
+			}
+
+			m = tt.resume(t, m)
+			if m.Gate == nil || m.Gate.PhaseID != "a" {
+				t.Fatal("gate not re-shown after resume")
+			}
+			if m.idleGate != nil || m.Paused {
+				t.Error("model still idle-paused after resume")
+			}
+			select {
+			case action := <-resp:
+				t.Errorf("resume answered the gate with %q", action)
+			default:
+			}
+
+			// The idle clock restarts with the re-shown gate.
+			m = tick(m, time.Now().Add(30*time.Second))
+			if m.Gate == nil {
+				t.Error("re-shown gate paused again before a fresh timeout")
+			}
+		})
+	}
+}
+
+func TestIdlePausedQueuesNewGates(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := idleModel(t)
+	m = tick(m, time.Now().Add(2*time.Minute))
+
+	updated, _ := m.Update(MsgGatePrompt{Checkpoint: &nebula.Checkpoint{PhaseID: "b"}, ResponseCh: make(chan nebula.GateAction, 1)})
+	m = updated.(AppModel)
+	if m.Gate != nil {
+		t.Error("new gate shown while the run is idle-paused")
+	}
+	if len(m.PendingGates) != 1 {
+		t.Errorf("pending gates = %d, want 1", len(m.PendingGates))
+	}
+}
+
+func TestIdleDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	m.NebulaDir = t.TempDir()
+	updated, _ := m.Update(MsgGatePrompt{Checkpoint: &nebula.Checkpoint{PhaseID: "a"}, ResponseCh: make(chan nebula.GateAction, 1)})
+	m = updated.(AppModel)
+
+	m = tick(m, time.Now().Add(24*time.Hour))
+	if m.Gate == nil || m.Paused {
+		t.Error("gate paused with no idle timeout configured")
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
+
+// phaseImpact returns the phases id transitively depends on (upstream) and
+// the phases that transitively depend on it (downstream), given each
+// phase's direct dependencies. Both lists are sorted. An edge the DAG
+// refuses, such as one closing a cycle left by a hot-added phase, is left
+// out of the lists and reported in err, so callers can say the impact is
+// incomplete.
+func phaseImpact(deps map[string][]string, id string) (upstream, downstream []string, err error) {
+	phases := make([]string, 0, len(deps))
+	for p := range deps {
+		phases = append(phases, p)
+	}
+	sort.Strings(phases)
+
+	d := dag.New()
+	for _, p := range phases {
+		d.AddNodeIdempotent(p, 0)
+		for _, dep := range deps[p] {
+			d.AddNodeIdempotent(dep, 0)
+		}
+	}
+	var errs []error
+	for _, p := range phases {
+		for _, dep := range deps[p] {
+			if err := d.AddEdge(p, dep); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return d.Ancestors(id), d.Descendants(id), errors.Join(errs...)
+}
+
+// impactSection renders the transitive dependencies and dependents of a
+// phase for the detail panel, noting when err left them incomplete.
+func impactSection(upstream, downstream []string, err error) string {
+	list := func(ids []string) string {
+		if len(ids) == 0 {
+			return "none"
+		}
+		return fmt.Sprintf("%s (%d)", strings.Join(ids, ", "), len(ids))
+	}
+	s := "Impact\n" +
+		"  depends on: " + list(upstream) + "\n" +
+		"  blocks:     " + list(downstream)
+	if err != nil {
+		s += "\n  incomplete: " + err.Error()
+	}
+	return s
+}
+
+// ToggleImpact toggles highlighting of the selected phase together with
+// everything it transitively depends on and everything that depends on it.
+// It takes the place of the critical path highlight while on.
+func (gv *GraphView) ToggleImpact() {
+	if gv.renderer == nil {
+		return
+	}
+	gv.showImpact = !gv.showImpact
+	if gv.showImpact {
+		gv.showCriticalPath = false
+	}
+	gv.renderer.CriticalPath = nil
+	gv.viewport.SetContent(gv.renderDAG())
+}
+
+// impactHighlight returns the node set to highlight for the selected
+// phase, or nil when a collapsed group or nothing is selected.
+func (gv *GraphView) impactHighlight() map[string]bool {
+	id := gv.SelectedPhaseID()
+	if id == "" {
+		return nil
+	}
+	// impactLines reports a refused edge below the graph; the highlight
+	// shows what could be traced.
+	up, down, _ := phaseImpact(gv.phaseDeps, id)
+	set := map[string]bool{id: true}
+	for _, p := range append(up, down...) {
+		set[p] = true
+	}
+	return set
+}
+
+// impactLines renders the selected phase's upstream and downstream phases
+// below the graph.
+func (gv *GraphView) impactLines() string {
+	id := gv.SelectedPhaseID()
+	if id == "" {
+		return ""
+	}
+	up, down, err := phaseImpact(gv.phaseDeps, id)
+	style := lipgloss.NewStyle().Foreground(colorMutedLight)
+	line := func(label string, ids []string) string {
+		if len(ids) == 0 {
+			return style.Render("  " + label + " none")
+		}
+		return style.Render(fmt.Sprintf("  %s %s (%d)", label, strings.Join(ids, ", "), len(ids)))
+	}
+	lines := line("↑ depends on:", up) + "\n" + line("↓ blocks:", down) + "\n"
+	if err != nil {
+		lines += lipgloss.NewStyle().Foreground(colorDanger).Render("  impact incomplete: "+err.Error()) + "\n"
+	}
+	return lines
+}
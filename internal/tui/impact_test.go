@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/dag"
+)
+
+func TestPhaseImpact(t *testing.T) {
+	t.Parallel()
+
+	// a ← b ← d, a ← c ← d, d ← e; f is unrelated.
+	deps := map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"a"},
+		"d": {"b", "c"},
+		"e": {"d"},
+		"f": nil,
+	}
+	tests := []struct {
+		id       string
+		up, down []string
+	}{
+		{"a", nil, []string{"b", "c", "d", "e"}},
+		{"b", []string{"a"}, []string{"d", "e"}},
+		{"d", []string{"a", "b", "c"}, []string{"e"}},
+		{"e", []string{"a", "b", "c", "d"}, nil},
+		{"f", nil, nil},
+		{"missing", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			t.Parallel()
+			up, down, err := phaseImpact(deps, tt.id)
+			if err != nil {
+				t.Fatalf("phaseImpact: %v", err)
+			}
+			if len(up)+len(tt.up) > 0 && !slices.Equal(up, tt.up) {
+				t.Errorf("upstream = %v, want %v", up, tt.up)
+			}
+			if len(down)+len(tt.down) > 0 && !slices.Equal(down, tt.down) {
+				t.Errorf("downstream = %v, want %v", down, tt.down)
+			}
+		})
+	}
+}
+
+func TestGraphViewToggleImpact(t *testing.T) {
+	t.Parallel()
+
+	gv := NewGraphView([]PhaseInfo{
+		{ID: "p1", Title: "Phase 1"},
+		{ID: "p2", Title: "Phase 2", DependsOn: []string{"p1"}},
+		{ID: "p3", Title: "Phase 3"},
+	}, 80, 24)
+	gv.ToggleCriticalPath()
+	gv.ToggleImpact()
+	if gv.showCriticalPath {
+		t.Error("impact view should turn the critical path highlight off")
+	}
+
+	content := gv.renderDAG()
+	if !strings.Contains(content, "impact: on") {
+		t.Errorf("toggle indicator missing:\n%s", content)
+	}
+	sel := gv.SelectedPhaseID()
+	hl := gv.renderer.CriticalPath
+	if !hl[sel] {
+		t.Errorf("selected phase %s not highlighted: %v", sel, hl)
+	}
+	if sel == "p1" && (!hl["p2"] || hl["p3"]) {
+		t.Errorf("highlight for p1 = %v, want p1 and p2 only", hl)
+	}
+	if !strings.Contains(content, "blocks:") || !strings.Contains(content, "depends on:") {
+		t.Errorf("impact lines missing:\n%s", content)
+	}
+
+	gv.ToggleImpact()
+	if gv.renderer.CriticalPath != nil || strings.Contains(gv.renderDAG(), "impact: on") {
+		t.Error("toggling again should clear the impact highlight")
+	}
+}
+
+func TestImpactSection(t *testing.T) {
+	t.Parallel()
+
+	got := impactSection([]string{"a", "b"}, nil, nil)
+	for _, want := range []string{"depends on: a, b (2)", "blocks:     none"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("impactSection missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "incomplete") {
+		t.Errorf("impactSection without an error marked incomplete:\n%s", got)
+	}
+}
+
+func TestPhaseImpactReportsRefusedEdge(t *testing.T) {
+	t.Parallel()
+
+	// b and c depend on each other; sorted order adds b → c first, so
+	// c → b is refused.
+	deps := map[string][]string{"a": nil, "b": {"a", "c"}, "c": {"b"}}
+	up, _, err := phaseImpact(deps, "b")
+	if !errors.Is(err, dag.ErrCycle) {
+		t.Fatalf("err = %v, want dag.ErrCycle", err)
+	}
+	if !slices.Equal(up, []string{"a", "c"}) {
+		t.Errorf("upstream = %v, want the edges that were added", up)
+	}
+	if got := impactSection(up, nil, err); !strings.Contains(got, "incomplete:") {
+		t.Errorf("impactSection does not flag the refused edge:\n%s", got)
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// importGroupOf returns the import group a namespaced phase ID was expanded
+// into: its full import prefix ("backend" for "backend/migrate", "svc/db" for
+// "svc/db/schema"), or "" for a phase outside any group.
+func importGroupOf(phaseID string) string {
+	i := strings.LastIndex(phaseID, "/")
+	if i < 0 {
+		return ""
+	}
+	return phaseID[:i]
+}
+
+// inGroup reports whether phaseID is group itself or lies under it, at any
+// depth of nesting.
+func inGroup(phaseID, group string) bool {
+	return phaseID == group || strings.HasPrefix(phaseID, group+"/")
+}
+
+// collapsedGroupOf returns the outermost collapsed import group containing
+// phaseID, or "" when none of its enclosing groups is collapsed.
+func collapsedGroupOf(phaseID string, collapsed map[string]bool) string {
+	for i, r := range phaseID {
+		if r == '/' && collapsed[phaseID[:i]] {
+			return phaseID[:i]
+		}
+	}
+	return ""
+}
+
+// groupTitle is the title shown for a collapsed import group of n phases.
+func groupTitle(group string, n int) string {
+	return fmt.Sprintf("▸ %s (%d phases)", group, n)
+}
+
+// groupStatus returns the status of a collapsed import group from its
+// members' statuses: the most urgent of failed, then gated, then running;
+// it is done only when every member is done or skipped.
+func groupStatus(members []PhaseStatus) PhaseStatus {
+	var failed, gate, working, pending bool
+	for _, status := range members {
+		switch status {
+		case PhaseFailed:
+			failed = true
+		case PhaseGate:
+			gate = true
+		case PhaseWorking:
+			working = true
+		case PhaseDone, PhaseSkipped:
+		default:
+			pending = true
+		}
+	}
+	switch {
+	case failed:
+		return PhaseFailed
+	case gate:
+		return PhaseGate
+	case working:
+		return PhaseWorking
+	case pending:
+		return PhaseWaiting
+	default:
+		return PhaseDone
+	}
+}
+
+// foldGroups returns phases with the members of each collapsed import group
+// replaced by a single entry for the group, placed where its first member
+// was. The group entry's ID is the group name. Without collapsed groups,
+// phases is returned as is.
+func foldGroups(phases []PhaseEntry, collapsed map[string]bool) []PhaseEntry {
+	if len(collapsed) == 0 {
+		return phases
+	}
+	folded := make([]PhaseEntry, 0, len(phases))
+	at := make(map[string]int)
+	members := make(map[string][]PhaseStatus)
+	for _, p := range phases {
+		g := collapsedGroupOf(p.ID, collapsed)
+		if g == "" {
+			folded = append(folded, p)
+			continue
+		}
+		if _, ok := at[g]; !ok {
+			at[g] = len(folded)
+			folded = append(folded, PhaseEntry{ID: g})
+		}
+		members[g] = append(members[g], p.Status)
+	}
+	for g, i := range at {
+		folded[i].Title = groupTitle(g, len(members[g]))
+		folded[i].Status = groupStatus(members[g])
+	}
+	return folded
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// KeyHelpSection is one group of the keybinding cheat sheet.
+type KeyHelpSection struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// KeyHelpSections groups every keybinding by the context it applies in. The
+// groups are assembled from the footer binding sets, so the cheat sheet
+// lists exactly what the footers advertise, plus the KeyMap bindings that
+// only appear conditionally.
+func KeyHelpSections(km KeyMap) []KeyHelpSection {
+	hailList := km.HailList
+	hailList.SetEnabled(true)
+
+	sections := []KeyHelpSection{
+		{Title: "Global", Bindings: []key.Binding{km.Help, km.Palette, km.Quit}},
+		{Title: "Home", Bindings: HomeFooterBindings(km)},
+		{Title: "Plan preview", Bindings: PlanFooterBindings(km)},
+		{Title: "Nebula table", Bindings: append(NebulaFooterBindings(km), km.Retry, km.Edit, km.Minimap, km.ExtendCycles, km.HideCompleted)},
+		{Title: "Board", Bindings: append(CockpitFooterBindings(km), km.Retry, km.Edit, km.Pin, km.Minimap, km.ExtendCycles, km.HideCompleted)},
+		{Title: "Phase detail", Bindings: append(NebulaDetailFooterBindings(km), km.Override, km.ExtendCycles)},
+		{Title: "Agent output", Bindings: append(LoopFooterBindings(km),
+			km.Diff, km.Focus, km.Expand, km.Raw, km.Override, km.ExtendCycles, km.PageUp, km.PageDown, km.Home, km.End)},
+		{Title: "Diff files", Bindings: DiffFileListFooterBindings(km)},
+		{Title: "Gate", Bindings: GateFooterBindings(km)},
+		{Title: "Hails", Bindings: append([]key.Binding{hailList}, HailListFooterBindings(km)...)},
+	}
+	for i := range sections {
+		sections[i].Bindings = uniqueBindings(sections[i].Bindings)
+	}
+	return sections
+}
+
+// uniqueBindings drops disabled bindings and repeats of a key already listed.
+func uniqueBindings(bindings []key.Binding) []key.Binding {
+	seen := make(map[string]bool, len(bindings))
+	out := bindings[:0:0]
+	for _, b := range bindings {
+		k := b.Help().Key
+		if !b.Enabled() || k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, b)
+	}
+	return out
+}
+
+// KeyHelpOverlay is a scrollable cheat sheet of every keybinding, grouped
+// by context.
+type KeyHelpOverlay struct {
+	Sections []KeyHelpSection
+	Offset   int // first visible line
+}
+
+// NewKeyHelpOverlay creates a cheat sheet for km.
+func NewKeyHelpOverlay(km KeyMap) *KeyHelpOverlay {
+	return &KeyHelpOverlay{Sections: KeyHelpSections(km)}
+}
+
+// lines renders the cheat sheet body, one entry per line.
+func (k *KeyHelpOverlay) lines() []string {
+	keyWidth := 0
+	for _, s := range k.Sections {
+		for _, b := range s.Bindings {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+	}
+	var lines []string
+	for i, s := range k.Sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, styleGateLabel.Render(s.Title))
+		for _, b := range s.Bindings {
+			h := b.Help()
+			pad := strings.Repeat(" ", keyWidth-lipgloss.Width(h.Key))
+			lines = append(lines, "  "+styleFooterKey.Render(h.Key)+pad+"  "+styleFooterDesc.Render(h.Desc))
+		}
+	}
+	return lines
+}
+
+// visibleLines returns how many body lines fit in a terminal of the given
+// height once the border, padding, title, and hint are accounted for.
+func visibleLines(height int) int {
+	return max(height-10, 5)
+}
+
+// ScrollUp moves the view up one line.
+func (k *KeyHelpOverlay) ScrollUp() {
+	if k.Offset > 0 {
+		k.Offset--
+	}
+}
+
+// ScrollDown moves the view down one line, stopping at the last page.
+func (k *KeyHelpOverlay) ScrollDown(height int) {
+	if k.Offset < len(k.lines())-visibleLines(height) {
+		k.Offset++
+	}
+}
+
+// View renders the cheat sheet as an overlay box sized to the terminal.
+func (k *KeyHelpOverlay) View(_, height int) string {
+	lines := k.lines()
+	visible := visibleLines(height)
+	start := min(k.Offset, max(len(lines)-visible, 0))
+	end := min(start+visible, len(lines))
+
+	var b strings.Builder
+	b.WriteString(styleOverlayTitle.Render("Keybindings"))
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n\n")
+	hint := "esc close"
+	if len(lines) > visible {
+		hint = fmt.Sprintf("↑/↓ scroll (%d–%d of %d) · esc close", start+1, end, len(lines))
+	}
+	b.WriteString(styleOverlayHint.Render(hint))
+	return styleKeyHelpOverlay.Render(b.String())
+}
+
+// KeyHelpFooterBindings returns footer bindings while the cheat sheet is open.
+func KeyHelpFooterBindings(km KeyMap) []key.Binding {
+	esc := km.Back
+	esc.SetHelp("esc", "close")
+	return []key.Binding{km.Up, km.Down, esc}
+}
+
+// handleKeyHelpKey routes key events while the cheat sheet is open. Esc or
+// ? closes it; up and down scroll.
+func (m AppModel) handleKeyHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.Keys.Back), key.Matches(msg, m.Keys.Help):
+		m.KeyHelp = nil
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case key.Matches(msg, m.Keys.Up):
+		m.KeyHelp.ScrollUp()
+	case key.Matches(msg, m.Keys.Down):
+		m.KeyHelp.ScrollDown(m.Height)
+	}
+	return m, nil
+}
+
+// styleKeyHelpOverlay frames the keybinding cheat sheet.
+var styleKeyHelpOverlay = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(colorBlueshift).
+	Padding(1, 2)
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyHelpSections(t *testing.T) {
+	t.Parallel()
+
+	sections := KeyHelpSections(DefaultKeyMap())
+	keys := make(map[string][]string, len(sections))
+	for _, s := range sections {
+		for _, b := range s.Bindings {
+			keys[s.Title] = append(keys[s.Title], b.Help().Key)
+		}
+	}
+
+	tests := []struct {
+		section string
+		key     string
+	}{
+		{"Global", "?"},
+		{"Home", "tab"},
+		{"Nebula table", "v"},
+		{"Board", "P"},
+		{"Agent output", "f"},
+		{"Gate", "a"},
+		{"Hails", "H"}, // listed even though disabled until a hail arrives
+	}
+	for _, tt := range tests {
+		found := false
+		for _, k := range keys[tt.section] {
+			found = found || k == tt.key
+		}
+		if !found {
+			t.Errorf("section %q keys = %v, want %q", tt.section, keys[tt.section], tt.key)
+		}
+	}
+	for title, ks := range keys {
+		seen := make(map[string]bool)
+		for _, k := range ks {
+			if seen[k] {
+				t.Errorf("section %q lists %q twice", title, k)
+			}
+			seen[k] = true
+		}
+	}
+}
+
+func TestKeyHelpOverlay(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{{ID: "p1", Title: "Phase 1"}})
+	m.Splash = nil
+	m.Width, m.Height = 100, 20
+
+	result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	got := result.(AppModel)
+	if got.KeyHelp == nil {
+		t.Fatal("? did not open the cheat sheet")
+	}
+	if view := got.View(); !strings.Contains(view, "Keybindings") || !strings.Contains(view, "Global") {
+		t.Errorf("cheat sheet not rendered:\n%s", view)
+	}
+
+	result, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	got = result.(AppModel)
+	if got.KeyHelp.Offset != 1 {
+		t.Errorf("Offset after down = %d, want 1", got.KeyHelp.Offset)
+	}
+	if got.NebulaView.Cursor != 0 {
+		t.Error("down moved the phase cursor while the cheat sheet was open")
+	}
+
+	result, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if result.(AppModel).KeyHelp != nil {
+		t.Error("esc did not close the cheat sheet")
+	}
+}
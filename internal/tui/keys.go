@@ -31,6 +31,60 @@ type KeyMap struct {
 
 	// Hail list — opens the pending hails overlay.
 	HailList key.Binding
+
+	// Edit — opens the focused phase's file in $EDITOR.
+	Edit key.Binding
+
+	// Focus — maximizes the agent output panel, hiding all other chrome.
+	Focus key.Binding
+
+	// Expand — toggles between collapsed and full rendering of long agent output.
+	Expand key.Binding
+
+	// Raw — toggles verbatim, unwrapped agent output for faithful copying.
+	Raw key.Binding
+
+	// Pin — keeps the selected phase's worker card on the board after it finishes.
+	Pin key.Binding
+
+	// Help — opens the keybinding cheat sheet.
+	Help key.Binding
+
+	// Mark — toggles a home-view nebula in the set to run concurrently.
+	Mark key.Binding
+
+	// Minimap — toggles a compact DAG beside the phase table or board.
+	Minimap key.Binding
+
+	// Palette — opens the command palette.
+	Palette key.Binding
+
+	// Override — force-approves the focused phase over its reviewer.
+	Override key.Binding
+
+	// ExtendCycles — grants the focused phase's loop more review cycles.
+	ExtendCycles key.Binding
+
+	// HideCompleted — hides done and skipped phases from the table and board.
+	HideCompleted key.Binding
+
+	// StepPrev and StepNext — move between the resume wizard's steps.
+	StepPrev key.Binding
+	StepNext key.Binding
+
+	// AcceptAll — approves the resume wizard's remaining steps.
+	AcceptAll key.Binding
+
+	// FileUp and FileDown — move through the gate's file list, or scroll the
+	// open file's diff. k is left out since it is the gate's Skip.
+	FileUp   key.Binding
+	FileDown key.Binding
+
+	// FileExit — returns from the gate's file list to the decision buttons.
+	FileExit key.Binding
+
+	// SaveSummary — writes the run summary from the completion overlay.
+	SaveSummary key.Binding
 }
 
 // DefaultKeyMap returns the default keybinding configuration.
@@ -81,8 +135,8 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("k", "skip"),
 		),
 		Info: key.NewBinding(
-			key.WithKeys("i", "?"),
-			key.WithHelp("?/i", "info"),
+			key.WithKeys("i"),
+			key.WithHelp("i", "info"),
 		),
 		Diff: key.NewBinding(
 			key.WithKeys("d"),
@@ -122,6 +176,82 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("H", "hails"),
 			key.WithDisabled(),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit"),
+		),
+		Focus: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "focus"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "expand"),
+		),
+		Raw: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "raw"),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "pin"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "keys"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		Minimap: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "minimap"),
+		),
+		Palette: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "commands"),
+		),
+		Override: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "override reviewer"),
+		),
+		ExtendCycles: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "+2 review cycles"),
+		),
+		HideCompleted: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "hide done"),
+		),
+		StepPrev: key.NewBinding(
+			key.WithKeys("left", "h", "shift+tab"),
+			key.WithHelp("←", "back"),
+		),
+		StepNext: key.NewBinding(
+			key.WithKeys("right", "l", "tab"),
+			key.WithHelp("→", "next"),
+		),
+		AcceptAll: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "apply the rest"),
+		),
+		FileUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "up"),
+		),
+		FileDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓", "down"),
+		),
+		FileExit: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→", "back to decision"),
+		),
+		SaveSummary: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save summary"),
+		),
 	}
 }
 
@@ -38,6 +38,8 @@ type LoopView struct {
 	Spinner  spinner.Model
 	Width    int
 	Approved bool
+	// Confidence is the reviewer's confidence in its latest verdict.
+	Confidence string
 }
 
 // NewLoopView creates an empty loop view.
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// manualGateOptions are the choices offered for a manual phase: no agent
+// ran, so there is nothing to retry.
+func manualGateOptions() []GateOption {
+	return []GateOption{
+		{Label: "[a] mark complete", Action: nebula.GateActionAccept},
+		{Label: "[x] mark failed", Action: nebula.GateActionReject},
+		{Label: "[s]kip", Action: nebula.GateActionSkip},
+	}
+}
+
+// manualBody renders the gate overlay for a manual phase: what the human
+// is asked to do, taken from the phase body.
+func (g *GatePrompt) manualBody(title string) string {
+	var b strings.Builder
+	b.WriteString(styleGateAction.Render(fmt.Sprintf("Manual step: %s", title)))
+	b.WriteString("\n")
+	b.WriteString(styleGateDetail.Render("No agent runs this phase. Mark it complete once it is done."))
+	b.WriteString("\n")
+	if instr := strings.TrimSpace(g.Instructions); instr != "" {
+		maxWidth := g.Width - 8
+		if maxWidth < 20 {
+			maxWidth = 20
+		}
+		b.WriteString("\n")
+		b.WriteString(styleGateLabel.Render("Instructions:"))
+		b.WriteString("\n")
+		for _, line := range strings.Split(instr, "\n") {
+			b.WriteString("  " + wrapText(line, maxWidth) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// manualPhaseDetail builds the row detail for a manual phase, marking it
+// as a human step in place of the cost and cycle columns.
+func manualPhaseDetail(p PhaseEntry) string {
+	switch p.Status {
+	case PhaseDone:
+		if elapsed := formatDuration(p.StartedAt, p.CompletedAt); elapsed != "" {
+			return "manual  completed  " + elapsed
+		}
+		return "manual  completed"
+	case PhaseGate, PhaseWorking:
+		return "manual  awaiting you"
+	case PhaseFailed:
+		return "manual  marked failed"
+	case PhaseSkipped:
+		if p.SkipReason != "" {
+			return "manual  skipped: " + p.SkipReason
+		}
+		return "manual  skipped"
+	default:
+		if p.BlockedBy != "" {
+			return "manual  blocked: " + p.BlockedBy
+		}
+		return "manual"
+	}
+}
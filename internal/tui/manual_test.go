@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestNewGatePromptManualPhase(t *testing.T) {
+	t.Parallel()
+
+	g := NewGatePrompt(&nebula.Checkpoint{
+		PhaseID:      "signoff",
+		PhaseTitle:   "Legal sign-off",
+		Manual:       true,
+		Instructions: "Get legal to approve the terms.",
+	}, nil)
+	g.Width = 80
+	if len(g.Options) != 3 || g.Options[0].Label != "[a] mark complete" || g.Options[1].Action != nebula.GateActionReject {
+		t.Errorf("options = %+v, want mark complete / mark failed / skip", g.Options)
+	}
+	for _, o := range g.Options {
+		if o.Action == nebula.GateActionRetry {
+			t.Error("manual phases should not offer retry")
+		}
+	}
+	view := g.View()
+	for _, want := range []string{"Manual step: Legal sign-off (signoff)", "Get legal to approve the terms."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestNebulaViewManualPhaseRow(t *testing.T) {
+	t.Parallel()
+
+	nv := NewNebulaView()
+	nv.Width = 100
+	nv.InitPhases([]PhaseInfo{
+		{ID: "signoff", Title: "Legal sign-off", Manual: true},
+		{ID: "ship", Title: "Ship", DependsOn: []string{"signoff"}},
+	})
+	tests := []struct {
+		status PhaseStatus
+		want   string
+	}{
+		{PhaseWaiting, "manual"},
+		{PhaseGate, "manual  awaiting you"},
+		{PhaseDone, "manual  completed"},
+		{PhaseFailed, "manual  marked failed"},
+	}
+	for _, tt := range tests {
+		nv.SetPhaseStatus("signoff", tt.status)
+		if got := nv.phaseDetail(nv.Phases[0]); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("detail for status %d = %q, want prefix %q", tt.status, got, tt.want)
+		}
+	}
+	if got := nv.phaseDetail(nv.Phases[1]); strings.Contains(got, "manual") {
+		t.Errorf("agent phase detail = %q, should not be marked manual", got)
+	}
+}
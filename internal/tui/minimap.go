@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Minimap size limits.
+const (
+	// minimapMaxWaves is the number of wave columns drawn before the map is
+	// cut off with an ellipsis.
+	minimapMaxWaves = 20
+	// minimapMaxRows is the number of nodes drawn per wave; a larger wave
+	// ends in a "+" marker.
+	minimapMaxRows = 6
+	// minimapMinMainWidth is the narrowest the table or board may become to
+	// make room for the minimap. Narrower terminals hide the minimap.
+	minimapMinMainWidth = CompactWidth
+)
+
+// Minimap renders the graph's wave layout at one cell per node: each column
+// is a dependency wave and each dot a node colored by its status. The node
+// holding phaseID is drawn as a highlighted diamond. It returns "" when the
+// graph has no nodes.
+func (gv *GraphView) Minimap(phaseID string) string {
+	if len(gv.waves) == 0 {
+		return ""
+	}
+	selected := gv.nodeOf(phaseID)
+
+	waves := gv.waves
+	cut := len(waves) > minimapMaxWaves
+	if cut {
+		waves = waves[:minimapMaxWaves]
+	}
+	rows := 0
+	for _, w := range waves {
+		rows = max(rows, min(len(w.NodeIDs), minimapMaxRows))
+	}
+
+	lines := make([]string, rows)
+	for i, w := range waves {
+		for r := range rows {
+			cell := " "
+			switch {
+			case r >= len(w.NodeIDs):
+			case r == minimapMaxRows-1 && len(w.NodeIDs) > minimapMaxRows:
+				cell = lipgloss.NewStyle().Foreground(colorMuted).Render("+")
+			default:
+				cell = gv.minimapCell(w.NodeIDs[r], selected)
+			}
+			if i > 0 {
+				lines[r] += " "
+			}
+			lines[r] += cell
+		}
+	}
+	if cut {
+		lines[0] += lipgloss.NewStyle().Foreground(colorMuted).Render(" …")
+	}
+	return styleDetailBorder.Render(strings.Join(lines, "\n"))
+}
+
+// minimapCell renders one node of the minimap.
+func (gv *GraphView) minimapCell(node, selected string) string {
+	if node == selected {
+		return lipgloss.NewStyle().Foreground(colorNebula).Bold(true).Render("◆")
+	}
+	return lipgloss.NewStyle().Foreground(phaseStatusColor(gv.nodeStatus(node))).Render("●")
+}
+
+// boardMinimap returns the minimap shown beside the phase table or board,
+// highlighting the phase under the cursor, or "" when the minimap is off or
+// width leaves too little room for the view beside it.
+func (m AppModel) boardMinimap(width int) string {
+	if !m.ShowMinimap {
+		return ""
+	}
+	var p *PhaseEntry
+	if m.BoardActive {
+		m.Board.Phases = m.NebulaView.Phases
+		p = m.Board.SelectedPhase()
+	} else {
+		p = m.NebulaView.SelectedPhase()
+	}
+	var phaseID string
+	if p != nil {
+		phaseID = p.ID
+	}
+	mm := m.Graph.Minimap(phaseID)
+	if mm == "" || width-lipgloss.Width(mm)-1 < minimapMinMainWidth {
+		return ""
+	}
+	return mm
+}
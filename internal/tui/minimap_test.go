@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGraphViewMinimap(t *testing.T) {
+	t.Parallel()
+
+	gv := NewGraphView([]PhaseInfo{
+		{ID: "a"},
+		{ID: "b", DependsOn: []string{"a"}},
+		{ID: "c", DependsOn: []string{"a"}},
+		{ID: "d", DependsOn: []string{"b", "c"}},
+	}, 80, 24)
+
+	mm := gv.Minimap("c")
+	if got := strings.Count(mm, "◆"); got != 1 {
+		t.Errorf("minimap has %d highlighted nodes, want 1:\n%s", got, mm)
+	}
+	if got := strings.Count(mm, "●"); got != 3 {
+		t.Errorf("minimap has %d plain nodes, want 3:\n%s", got, mm)
+	}
+	// Three waves with at most two nodes each, inside a border.
+	if h := lipgloss.Height(mm); h != 4 {
+		t.Errorf("minimap height = %d, want 4:\n%s", h, mm)
+	}
+
+	empty := NewGraphView(nil, 80, 24)
+	if mm := empty.Minimap(""); mm != "" {
+		t.Errorf("empty graph minimap = %q, want empty", mm)
+	}
+}
+
+func TestGraphViewMinimap_LargeWave(t *testing.T) {
+	t.Parallel()
+
+	var phases []PhaseInfo
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		phases = append(phases, PhaseInfo{ID: id})
+	}
+	gv := NewGraphView(phases, 80, 24)
+	mm := gv.Minimap("")
+	if !strings.Contains(mm, "+") {
+		t.Errorf("oversized wave lacks a + marker:\n%s", mm)
+	}
+	if h := lipgloss.Height(mm); h != minimapMaxRows+2 {
+		t.Errorf("minimap height = %d, want %d", h, minimapMaxRows+2)
+	}
+}
+
+func TestMinimapToggle(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}})
+	m.DisableSplash()
+	m.Graph = NewGraphView([]PhaseInfo{{ID: "a"}, {ID: "b", DependsOn: []string{"a"}}}, 80, 24)
+	m.ActiveTab = TabBoard
+	m.Width = 120
+	m.Height = 40
+
+	next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	*m = next.(AppModel)
+	if !m.ShowMinimap {
+		t.Fatal("M did not turn the minimap on")
+	}
+	if mm := m.boardMinimap(120); !strings.Contains(mm, "◆") {
+		t.Errorf("minimap missing the cursor's phase:\n%s", mm)
+	}
+	if !strings.Contains(m.renderMainView(), "◆") {
+		t.Error("table view does not show the minimap")
+	}
+	if mm := m.boardMinimap(minimapMinMainWidth); mm != "" {
+		t.Errorf("minimap shown on a narrow terminal:\n%s", mm)
+	}
+}
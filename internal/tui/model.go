@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -59,7 +61,8 @@ type AppModel struct {
 	StartTime    time.Time
 	Done         bool
 	DoneErr      error
-	Messages     []string // recent info/error messages
+	DoneResults  []nebula.WorkerResult // phase results from MsgNebulaDone
+	Messages     []string              // recent info/error messages
 
 	// Nebula navigation state.
 	Depth        ViewDepth            // current navigation depth
@@ -73,18 +76,46 @@ type AppModel struct {
 	DiffFileList *FileListView // navigable file list when diff view is active
 	DiffFileOpen bool          // whether user has opened a single file's diff (Enter on file list)
 	ShowBeads    bool          // whether the bead tracker is toggled on
+	FocusMode    bool          // whether the detail panel is maximized at DepthAgentOutput
+	ExpandOutput bool          // whether long agent output is rendered in full
+	RawOutput    bool          // whether agent output and diffs are shown verbatim
 
 	// Bead hierarchy state.
 	LoopBeads  *BeadInfo            // bead hierarchy for loop mode
 	PhaseBeads map[string]*BeadInfo // phaseID → latest bead hierarchy
+	// PhaseAttempts holds each phase's finished runs, shown with its beads.
+	PhaseAttempts map[string][]nebula.AttemptRecord
+
+	// RefactorDiffs holds the description change of each phase's latest
+	// applied refactor, shown in the phase summary.
+	RefactorDiffs map[string]DescriptionDiff
 
 	// Execution control state (nebula mode).
 	Paused    bool   // whether execution is paused
 	Stopping  bool   // whether a stop has been requested
+	Draining  bool   // whether a drain has been requested
 	NebulaDir string // path to nebula directory for intervention files
 
+	// Refactorer queues a refactor after an in-TUI phase edit when no file
+	// watcher is active. Nil until MsgRefactorerReady arrives.
+	Refactorer PhaseRefactorer
+
+	// Idle pausing — see idle.go. IdlePauser is nil until
+	// MsgIdlePauserReady arrives.
+	IdlePauser  IdlePauser
+	IdleTimeout time.Duration // 0 disables idle pausing
+	lastKeyAt   time.Time     // time of the most recent keypress
+	idleGate    *GatePrompt   // gate hidden while idle-paused; nil otherwise
+
+	// SilenceLimit is how long an active phase may go without activity
+	// before a warning toast — see heartbeat.go. 0 disables the warning.
+	SilenceLimit time.Duration
+
+	etaHistory *MsgETAHistory // ETA inputs; nil until MsgETAHistory arrives, which hides the ETA
+
 	// Graph view state — live DAG visualization tab.
-	Graph GraphView // DAG graph renderer
+	Graph       GraphView // DAG graph renderer
+	ShowMinimap bool      // draw a compact graph beside the phase table or board
 
 	// Board view state — columnar board as alternative to the NebulaView table.
 	Board        BoardView // columnar board renderer
@@ -93,8 +124,19 @@ type AppModel struct {
 
 	// Worker card state — live detail cards for active quasars.
 	WorkerCards   map[string]*WorkerCard // phaseID → live worker card
+	PinnedCards   map[string]*WorkerCard // phaseID → card pinned to stay after the phase ends
 	nextQuasarNum int                    // counter for assigning quasar IDs (q-1, q-2, ...)
 
+	// Reviewer overrides — see override.go. overrideChs holds the channel
+	// of each phase whose loop is running; overrideArmed is the phase the
+	// override key was pressed once for, awaiting confirmation.
+	overrideChs   map[string]chan<- struct{}
+	overrideArmed string
+
+	// Cycle extensions — see cycles.go. cycleChs holds the channel each
+	// running phase loop reads extra review cycles from.
+	cycleChs map[string]chan<- int
+
 	// Fabric bridge state — stored for later rendering by cockpit components.
 	Entanglements    []fabric.Entanglement // latest entanglement snapshot
 	EntanglementView EntanglementView      // persistent entanglement viewer with cursor state
@@ -106,16 +148,20 @@ type AppModel struct {
 	// Hail tracking — pending hails from agents that need human attention.
 	PendingHails []ui.HailInfo    // unresolved hails tracked via MsgHailReceived/MsgHailResolved
 	HailList     *HailListOverlay // non-nil when the hail list overlay is active
+	KeyHelp      *KeyHelpOverlay  // non-nil when the keybinding cheat sheet is open
+	Palette      *CommandPalette  // non-nil when the command palette is open
 
 	// Home mode state (landing page).
-	HomeCursor      int            // cursor position in the home nebula list
-	HomeOffset      int            // viewport scroll offset in the home nebula list
-	HomeNebulae     []NebulaChoice // discovered nebulas for the home view
-	HomeFilter      HomeFilter     // active filter for the home nebula list
-	HomeDir         string         // the .nebulas/ parent directory
-	SelectedNebula  string         // set when user selects a nebula from home; read after Run() returns
-	ShowPlanPreview bool           // true when the plan preview is visible (between home and apply)
-	PlanPreview     *PlanView      // plan preview state (non-nil when active)
+	HomeCursor      int             // cursor position in the home nebula list
+	HomeOffset      int             // viewport scroll offset in the home nebula list
+	HomeNebulae     []NebulaChoice  // discovered nebulas for the home view
+	HomeFilter      HomeFilter      // active filter for the home nebula list
+	HomeDir         string          // the .nebulas/ parent directory
+	SelectedNebula  string          // set when user selects a nebula from home; read after Run() returns
+	HomeMarked      map[string]bool // nebula paths marked with space to run concurrently
+	SelectedNebulae []string        // set when user runs the marked nebulas; read after Run() returns
+	ShowPlanPreview bool            // true when the plan preview is visible (between home and apply)
+	PlanPreview     *PlanView       // plan preview state (non-nil when active)
 
 	// Nebula picker state (post-completion).
 	AvailableNebulae []NebulaChoice // populated on MsgNebulaDone via discovery
@@ -140,20 +186,22 @@ type AppModel struct {
 func NewAppModel(mode Mode) AppModel {
 	splash := NewSplash(DefaultSplashConfig())
 	m := AppModel{
-		Mode:        mode,
-		LoopView:    NewLoopView(),
-		NebulaView:  NewNebulaView(),
-		Board:       NewBoardView(),
-		BoardActive: false, // set to true on first WindowSizeMsg if terminal is wide enough
-		Keys:        DefaultKeyMap(),
-		StartTime:   time.Now(),
-		PhaseLoops:  make(map[string]*LoopView),
-		PhaseBeads:  make(map[string]*BeadInfo),
-		Thresholds:  DefaultResourceThresholds(),
-		Splash:      &splash,
+		Mode:          mode,
+		LoopView:      NewLoopView(),
+		NebulaView:    NewNebulaView(),
+		Board:         NewBoardView(),
+		BoardActive:   false, // set to true on first WindowSizeMsg if terminal is wide enough
+		Keys:          DefaultKeyMap(),
+		StartTime:     time.Now(),
+		PhaseLoops:    make(map[string]*LoopView),
+		PhaseBeads:    make(map[string]*BeadInfo),
+		PhaseAttempts: make(map[string][]nebula.AttemptRecord),
+		Thresholds:    DefaultResourceThresholds(),
+		Splash:        &splash,
 	}
 	m.StatusBar.StartTime = m.StartTime
 	m.StatusBar.Thresholds = m.Thresholds
+	m.StatusBar.ASCII = !unicodeLocale(os.Getenv)
 	// In home mode, show the detail panel by default.
 	if mode == ModeHome {
 		m.ShowPlan = true
@@ -229,16 +277,18 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.BoardActive = false
 		}
 
-		// Update gate overlay dimensions if it's currently visible.
-		if m.Gate != nil {
-			m.Gate.Width = m.contentWidth()
-			m.Gate.Height = m.Height
-		}
+		m.resizeOverlays()
 
 		// Clamp cursors so they remain valid after a resize that may shrink lists.
 		clampCursors(&m)
 
 	case tea.KeyMsg:
+		m.lastKeyAt = time.Now()
+		if m.idleGate != nil {
+			// Any key wakes an idle-paused run and brings the gate back.
+			m.resumeFromIdle()
+			return m, nil
+		}
 		return m.handleKey(msg)
 
 	case tea.MouseMsg:
@@ -260,8 +310,14 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case MsgTick:
 		if !m.Done {
+			m.StatusBar.CostHistory.Record(msg.Time, m.StatusBar.CostUSD)
 			cmds = append(cmds, tickCmd())
 		}
+		cmds = append(cmds, m.checkIdle(msg.Time), m.checkHeartbeats(msg.Time))
+
+	case MsgRateLimit:
+		m.StatusBar.RateLimit = msg.Utilization
+		m.StatusBar.Throttled = msg.Throttled
 
 	case MsgResourceUpdate:
 		m.Resources = msg.Snapshot
@@ -297,6 +353,38 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.addMessage("Max cycles reached (%d)", msg.Max)
 	case MsgBudgetExceeded:
 		m.addMessage("Budget exceeded ($%.2f / $%.2f)", msg.Spent, msg.Limit)
+		m.StatusBar.BudgetUSD = msg.Limit
+		m.StatusBar.BudgetExceeded = true
+	case MsgBeadsDegraded:
+		prev := m.StatusBar.BeadsBuffered
+		m.StatusBar.BeadsBuffered = msg.Buffered
+		var note string
+		isErr := false
+		switch {
+		case prev == 0 && msg.Buffered > 0:
+			note, isErr = "beads unavailable — buffering operations locally", true
+		case prev > 0 && msg.Buffered == 0:
+			note = "beads recovered — buffered operations replayed"
+		}
+		if note != "" {
+			m.addMessage("%s", note)
+			toast, cmd := NewToast(note, isErr)
+			m.Toasts = append(m.Toasts, toast)
+			cmds = append(cmds, cmd)
+		}
+	case MsgBudgetWarning:
+		text := fmt.Sprintf("budget warning ($%.2f / $%.2f)", msg.Spent, msg.Limit)
+		if msg.PhaseID != "" {
+			text = fmt.Sprintf("[%s] %s", msg.PhaseID, text)
+		}
+		m.addMessage("%s", text)
+		m.StatusBar.BudgetWarned = true
+		if m.StatusBar.BudgetUSD == 0 {
+			m.StatusBar.BudgetUSD = msg.Limit
+		}
+		toast, cmd := NewToast("⚠ "+text, true)
+		m.Toasts = append(m.Toasts, toast)
+		cmds = append(cmds, cmd)
 	case MsgAgentOutput:
 		m.LoopView.SetAgentOutput(msg.Role, msg.Cycle, msg.Output)
 		m.updateDetailFromSelection()
@@ -315,8 +403,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// --- Nebula initialization ---
 	case MsgNebulaInit:
 		m.StatusBar.Name = msg.Name
+		m.StatusBar.Icon = msg.Icon
+		m.StatusBar.Color = msg.Color
 		m.StatusBar.Total = len(msg.Phases)
 		m.NebulaView.InitPhases(msg.Phases)
+		m.seedAttempts(msg.Phases)
 		m.Graph = NewGraphView(msg.Phases, m.contentWidth(), m.detailHeight())
 
 	// --- Nebula progress ---
@@ -336,12 +427,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgPhaseTaskComplete:
 		m.NebulaView.SetPhaseStatus(msg.PhaseID, PhaseDone)
 		m.Graph.SetPhaseStatus(msg.PhaseID, PhaseDone)
+		m.refreshETA()
 		if lv := m.PhaseLoops[msg.PhaseID]; lv != nil {
 			lv.Approved = true
 		}
 		m.NebulaView.SetPhaseCost(msg.PhaseID, msg.TotalCost)
 		// Remove worker card when phase completes.
-		delete(m.WorkerCards, msg.PhaseID)
+		m.retireWorkerCard(msg.PhaseID, PhaseDone)
 	case MsgPhaseCycleStart:
 		lv := m.ensurePhaseLoop(msg.PhaseID)
 		lv.StartCycle(msg.Cycle)
@@ -349,7 +441,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Clear refactored indicator from previous cycle.
 		m.NebulaView.SetPhaseRefactored(msg.PhaseID, false)
 		// Update worker card cycle info.
-		if wc := m.WorkerCards[msg.PhaseID]; wc != nil {
+		if wc := m.workerCard(msg.PhaseID); wc != nil {
 			wc.Cycle = msg.Cycle
 			wc.MaxCycles = msg.MaxCycles
 		}
@@ -357,7 +449,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		lv := m.ensurePhaseLoop(msg.PhaseID)
 		lv.StartAgent(msg.Role)
 		// Update worker card agent role and activity.
-		if wc := m.WorkerCards[msg.PhaseID]; wc != nil {
+		if wc := m.workerCard(msg.PhaseID); wc != nil {
 			wc.AgentRole = msg.Role
 			wc.Activity = activityFromRole(msg.Role)
 		}
@@ -370,7 +462,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateDetailFromSelection()
 		}
 		// Update worker card token count.
-		if wc := m.WorkerCards[msg.PhaseID]; wc != nil {
+		if wc := m.workerCard(msg.PhaseID); wc != nil {
 			wc.TokensUsed += msg.Tokens
 		}
 	case MsgPhaseAgentOutput:
@@ -388,7 +480,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateDetailFromSelection()
 		}
 		// Update worker card claims from diff file list.
-		if wc := m.WorkerCards[msg.PhaseID]; wc != nil && len(msg.Files) > 0 {
+		if wc := m.workerCard(msg.PhaseID); wc != nil && len(msg.Files) > 0 {
 			claims := make([]string, len(msg.Files))
 			for i, f := range msg.Files {
 				claims[i] = f.Path
@@ -398,6 +490,9 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgPhaseCycleSummary:
 		if lv := m.PhaseLoops[msg.PhaseID]; lv != nil {
 			lv.Approved = msg.Data.Approved
+			if msg.Data.Confidence != "" {
+				lv.Confidence = msg.Data.Confidence
+			}
 		}
 		m.NebulaView.SetPhaseCost(msg.PhaseID, msg.Data.TotalCostUSD)
 		if m.FocusedPhase == msg.PhaseID {
@@ -419,26 +514,78 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Clear refactored indicator on completion.
 		m.NebulaView.SetPhaseRefactored(msg.PhaseID, false)
 		// Remove worker card on approval.
-		delete(m.WorkerCards, msg.PhaseID)
+		m.retireWorkerCard(msg.PhaseID, PhaseDone)
 	case MsgPhaseRefactorPending:
 		m.addMessage("[%s] refactor pending — will apply after current cycle", msg.PhaseID)
 		toast, cmd := NewToast(fmt.Sprintf("[%s] refactor pending", msg.PhaseID), false)
 		m.Toasts = append(m.Toasts, toast)
 		cmds = append(cmds, cmd)
+	case MsgPhaseEdited:
+		if cmd := m.handlePhaseEdited(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case MsgRefactorerReady:
+		m.Refactorer = msg.Refactorer
+	case MsgRuntimeLimit:
+		m.StatusBar.Deadline = msg.Deadline
+
+	case MsgETAHistory:
+		m.etaHistory = &msg
+		m.refreshETA()
+	case MsgIdlePauserReady:
+		m.IdlePauser = msg.Pauser
+		m.IdleTimeout = msg.Timeout
+	case MsgSilenceLimit:
+		m.SilenceLimit = msg.Limit
+	case MsgPhaseHeartbeat:
+		m.notePhaseActivity(msg.PhaseID, msg.At)
 	case MsgPhaseRefactorApplied:
 		m.NebulaView.SetPhaseRefactored(msg.PhaseID, true)
-		toast, cmd := NewToast(fmt.Sprintf("[%s] refactor applied", msg.PhaseID), false)
+		diff := diffDescriptions(msg.Original, msg.Updated)
+		if m.RefactorDiffs == nil {
+			m.RefactorDiffs = make(map[string]DescriptionDiff)
+		}
+		m.RefactorDiffs[msg.PhaseID] = diff
+		if wc := m.workerCard(msg.PhaseID); wc != nil {
+			wc.Refactor = "refactor applied (" + diff.Summary() + ")"
+		}
+		toast, cmd := NewToast(fmt.Sprintf("[%s] refactor applied (%s)", msg.PhaseID, diff.Summary()), false)
 		m.Toasts = append(m.Toasts, toast)
 		cmds = append(cmds, cmd)
+		m.updateDetailFromSelection()
+	case MsgPhaseOverrideReady:
+		m.setOverride(msg.PhaseID, msg.Ch)
+	case MsgPhaseCyclesReady:
+		m.setCycleChannel(msg.PhaseID, msg.Ch)
+
 	case MsgPhaseError:
 		m.NebulaView.SetPhaseStatus(msg.PhaseID, PhaseFailed)
 		m.Graph.SetPhaseStatus(msg.PhaseID, PhaseFailed)
 		// Remove worker card on failure.
-		delete(m.WorkerCards, msg.PhaseID)
+		m.retireWorkerCard(msg.PhaseID, PhaseFailed)
+		m.refreshETA()
 		m.addMessage("[%s] %s", msg.PhaseID, msg.Msg)
 		toast, cmd := NewToast(fmt.Sprintf("[%s] %s", msg.PhaseID, msg.Msg), true)
 		m.Toasts = append(m.Toasts, toast)
 		cmds = append(cmds, cmd)
+	case MsgPhaseStatus:
+		m.NebulaView.SetPhaseStatus(msg.PhaseID, msg.Status)
+		m.Graph.SetPhaseStatus(msg.PhaseID, msg.Status)
+		if msg.Reason != "" {
+			m.NebulaView.SetSkipReason(msg.PhaseID, msg.Reason)
+		}
+		m.refreshETA()
+	case MsgPhasePreserved:
+		if p := m.findPhase(msg.PhaseID); p != nil {
+			p.Preserved = msg.Location
+		}
+		m.addMessage("[%s] work kept at %s", msg.PhaseID, msg.Location)
+		m.updateDetailFromSelection()
+	case MsgPhaseAttempt:
+		m.PhaseAttempts[msg.PhaseID] = append(m.PhaseAttempts[msg.PhaseID], msg.Attempt)
+		if m.ShowBeads {
+			m.updateBeadDetail()
+		}
 	case MsgPhaseInfo:
 		// Informational — don't change phase status.
 
@@ -452,6 +599,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.NebulaView.AppendPhase(pi)
 		m.Graph.AppendPhase(pi)
 		m.StatusBar.Total = len(m.NebulaView.Phases)
+		m.refreshETA()
 		toast, cmd := NewToast(fmt.Sprintf("+ %s added to nebula", msg.PhaseID), false)
 		m.Toasts = append(m.Toasts, toast)
 		cmds = append(cmds, cmd)
@@ -463,6 +611,15 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Toasts = append(m.Toasts, toast)
 		cmds = append(cmds, cmd)
 
+	case MsgPhaseReady:
+		// Only the phase being watched gets a toast; every other phase
+		// becoming ready would be noise in a wide nebula.
+		if msg.PhaseID == m.selectedPhaseID() {
+			toast, cmd := NewToast(fmt.Sprintf("[%s] dependencies met, ready to run", msg.PhaseID), false)
+			m.Toasts = append(m.Toasts, toast)
+			cmds = append(cmds, cmd)
+		}
+
 	// --- Bead hierarchy ---
 	case MsgBeadUpdate:
 		root := msg.Root
@@ -484,9 +641,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.NebulaView.SetPhaseStatus(msg.Checkpoint.PhaseID, PhaseGate)
 			m.Graph.SetPhaseStatus(msg.Checkpoint.PhaseID, PhaseGate)
 		}
-		if m.Gate == nil {
+		if msg.Budget != nil {
+			m.NebulaView.SetPhaseStatus(msg.Budget.PhaseID, PhaseGate)
+			m.Graph.SetPhaseStatus(msg.Budget.PhaseID, PhaseGate)
+		}
+		if m.Gate == nil && m.idleGate == nil {
 			// No active gate — show immediately.
-			m.Gate = NewGatePrompt(msg.Checkpoint, msg.ResponseCh)
+			m.Gate = newGatePromptFor(msg)
 			m.Gate.Width = m.contentWidth()
 			m.Gate.Height = m.Height
 		} else {
@@ -504,8 +665,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgNebulaDone:
 		m.Done = true
 		m.DoneErr = msg.Err
+		m.DoneResults = msg.Results
 		m.StatusBar.FinalElapsed = time.Since(m.StartTime).Truncate(time.Second)
+		if msg.Canceled {
+			// Interrupted phases are waiting to run again, as the saved state has them.
+			for i := range m.NebulaView.Phases {
+				if p := &m.NebulaView.Phases[i]; p.Status == PhaseWorking {
+					p.Status = PhaseWaiting
+				}
+			}
+		}
 		m.Overlay = NewCompletionFromNebulaDone(msg, time.Since(m.StartTime), m.StatusBar.CostUSD, len(m.NebulaView.Phases))
+		m.Overlay.NebulaName = m.StatusBar.Name
+		m.Overlay.Phases = slices.Clone(m.NebulaView.Phases)
+		if m.NebulaDir != "" {
+			// A subdirectory, since .md files beside nebula.toml are phases.
+			m.Overlay.SummaryDir = filepath.Join(m.NebulaDir, "summaries")
+		}
 		// Discover sibling nebulae in background.
 		if m.NebulaDir != "" {
 			nebulaDir := m.NebulaDir
@@ -555,6 +731,20 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.EntanglementView.Entanglements = msg.Entanglements
 		m.EntanglementView.ClampCursor()
 
+	case MsgConflict:
+		m.EntanglementView.Conflicts = append(m.EntanglementView.Conflicts, ConflictEntry{
+			PhaseID:      msg.PhaseID,
+			OtherPhaseID: msg.OtherPhaseID,
+			File:         msg.File,
+			Time:         time.Now(),
+		})
+		m.EntanglementView.refreshContent()
+		m.StatusBar.Conflicts++
+		m.addMessage("[%s] conflict with %s on %s", msg.PhaseID, msg.OtherPhaseID, msg.File)
+		toast, cmd := NewToast(fmt.Sprintf("conflict: %s ⟷ %s on %s", msg.PhaseID, msg.OtherPhaseID, msg.File), true)
+		m.Toasts = append(m.Toasts, toast)
+		cmds = append(cmds, cmd)
+
 	case MsgDiscoveryPosted:
 		m.Discoveries = append(m.Discoveries, msg.Discovery)
 		toast, cmd := NewToast(fmt.Sprintf("discovery: %s", msg.Discovery.Kind), false)
@@ -565,6 +755,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Show the hail overlay when the board view is active; otherwise fallback to a toast.
 		if m.Mode == ModeNebula && m.BoardActive && m.ActiveTab == TabBoard && m.Depth == DepthPhases {
 			m.Hail = NewHailOverlay(msg, msg.ResponseCh)
+			m.resizeOverlays()
 			cmds = append(cmds, m.Hail.Input.Focus())
 		} else {
 			toast, cmd := NewToast(fmt.Sprintf("⚠ hail from %s: %s", msg.PhaseID, msg.Discovery.Detail), true)
@@ -587,7 +778,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgStaleWarning:
 		m.StaleItems = msg.Items
 		if len(msg.Items) > 0 {
-			toast, cmd := NewToast(fmt.Sprintf("stale: %d items need attention", len(msg.Items)), true)
+			toast, cmd := NewToast(staleWarningText(msg.Items), true)
 			m.Toasts = append(m.Toasts, toast)
 			cmds = append(cmds, cmd)
 		}
@@ -604,6 +795,8 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgPlanAction:
 		switch msg.Action {
 		case PlanActionApply:
+			// The run records the plan once its apply succeeds, so the
+			// next preview shows what changed since this apply.
 			m.SelectedNebula = msg.NebulaDir
 			m.NextNebula = msg.NebulaDir
 			return m, tea.Quit
@@ -656,10 +849,16 @@ func (m *AppModel) ensureWorkerCard(phaseID string) *WorkerCard {
 	if wc, ok := m.WorkerCards[phaseID]; ok {
 		return wc
 	}
+	if wc, ok := m.PinnedCards[phaseID]; ok {
+		// A pinned phase was restarted (e.g. retried); its card goes live again.
+		wc.FinalStatus = PhaseWaiting
+		return wc
+	}
 	m.nextQuasarNum++
 	wc := &WorkerCard{
-		PhaseID:  phaseID,
-		QuasarID: fmt.Sprintf("q-%d", m.nextQuasarNum),
+		PhaseID:      phaseID,
+		QuasarID:     fmt.Sprintf("q-%d", m.nextQuasarNum),
+		LastActivity: time.Now(),
 	}
 	m.WorkerCards[phaseID] = wc
 	return wc
@@ -681,7 +880,7 @@ func clampCursors(m *AppModel) {
 		m.HomeOffset = 0
 	}
 
-	// Clamp NebulaView cursor.
+	// Clamp NebulaView cursor, then move it off any phase the view hides.
 	if max := len(m.NebulaView.Phases) - 1; max >= 0 {
 		if m.NebulaView.Cursor > max {
 			m.NebulaView.Cursor = max
@@ -689,6 +888,7 @@ func clampCursors(m *AppModel) {
 	} else {
 		m.NebulaView.Cursor = 0
 	}
+	m.NebulaView.SettleCursor()
 
 	// Clamp LoopView cursor.
 	if max := m.LoopView.TotalEntries() - 1; max >= 0 {
@@ -699,8 +899,8 @@ func clampCursors(m *AppModel) {
 		m.LoopView.Cursor = 0
 	}
 
-	// Clamp BoardView cursor.
-	if max := len(m.Board.Phases) - 1; max >= 0 {
+	// Clamp BoardView cursor against the phases the board shows.
+	if max := m.Board.Len() - 1; max >= 0 {
 		if m.Board.Cursor > max {
 			m.Board.Cursor = max
 		}
@@ -749,6 +949,14 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.KeyHelp != nil {
+		return m.handleKeyHelpKey(msg)
+	}
+
+	if m.Palette != nil {
+		return m.handlePaletteKey(msg)
+	}
+
 	// Completion overlay — q quits, Esc returns to home, arrow keys for picker.
 	if m.Overlay != nil {
 		switch {
@@ -773,6 +981,8 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.NextNebula = m.AvailableNebulae[m.PickerCursor].Path
 				return m, tea.Quit
 			}
+		case key.Matches(msg, m.Keys.SaveSummary):
+			return m, m.saveCompletionSummary()
 		}
 		return m, nil
 	}
@@ -792,9 +1002,24 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleHailListKey(msg)
 	}
 
+	// The graph search prompt takes every key while it is open.
+	if m.Mode == ModeNebula && m.Depth == DepthPhases && m.ActiveTab == TabGraph && m.Graph.Searching() {
+		return m, m.Graph.HandleSearchKey(msg)
+	}
+
+	if key.Matches(msg, m.Keys.Help) {
+		m.KeyHelp = NewKeyHelpOverlay(m.Keys)
+		return m, nil
+	}
+
+	if key.Matches(msg, m.Keys.Palette) {
+		m.openPalette()
+		return m, textinput.Blink
+	}
+
 	// When viewing a single file's diff, route scroll keys to the detail panel.
 	// Esc returns to the file list.
-	if m.ShowDiff && m.DiffFileList != nil && m.DiffFileOpen {
+	if m.diffListActive() && m.DiffFileOpen {
 		switch {
 		case key.Matches(msg, m.Keys.Up):
 			m.Detail.Update(msg)
@@ -824,7 +1049,7 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// When the diff file list is active (but not viewing a single file),
 	// ↑/↓ navigate the file list instead of scrolling the detail panel.
-	if m.ShowDiff && m.DiffFileList != nil && !m.DiffFileOpen {
+	if m.diffListActive() && !m.DiffFileOpen {
 		switch {
 		case key.Matches(msg, m.Keys.Up):
 			m.DiffFileList.MoveUp()
@@ -860,6 +1085,9 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.Keys.End):
 			m.Detail.Update(msg)
 			return m, nil
+		case m.isRawScrollKey(msg):
+			m.Detail.Update(msg)
+			return m, nil
 		}
 	} else if m.showDetailPanel() {
 		// At other depths with detail panel visible (e.g. beads/plan),
@@ -882,12 +1110,19 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// When the diff file list is active, Enter shows the selected file's
 	// diff inline instead of drilling down into the loop view.
-	if m.ShowDiff && m.DiffFileList != nil && key.Matches(msg, m.Keys.OpenDiff) {
+	if m.diffListActive() && key.Matches(msg, m.Keys.OpenDiff) {
 		return m.showFileDiff()
 	}
 
 	// Tab navigation and board toggle — only active in nebula mode at DepthPhases.
 	if m.Mode == ModeNebula && m.Depth == DepthPhases {
+		if key.Matches(msg, m.Keys.HideCompleted) {
+			// Hide or show completed phases on both the table and the board.
+			m.NebulaView.ToggleHideCompleted()
+			m.Board.HideCompleted = m.NebulaView.HideCompleted
+			clampCursors(&m)
+			return m, nil
+		}
 		switch msg.String() {
 		case "v":
 			// Toggle between columnar board and table view.
@@ -921,10 +1156,17 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.Board.MoveRight()
 				return m, nil
 			}
+		case "z":
+			// Collapse or expand the selected card's import group.
+			if m.BoardActive && m.ActiveTab == TabBoard {
+				m.Board.Phases = m.NebulaView.Phases
+				m.Board.ToggleGroup()
+				return m, nil
+			}
 		}
 	}
 
-	// Graph tab key handling — toggle tracks, critical path, and scroll viewport.
+	// Graph tab key handling — toggle tracks, critical path, impact, search, and scroll viewport.
 	if m.Mode == ModeNebula && m.Depth == DepthPhases && m.ActiveTab == TabGraph {
 		switch msg.String() {
 		case "t":
@@ -933,6 +1175,28 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "c":
 			m.Graph.ToggleCriticalPath()
 			return m, nil
+		case "z":
+			m.Graph.ToggleGroup()
+			return m, nil
+		case "I":
+			m.Graph.ToggleImpact()
+			return m, nil
+		case "/":
+			m.Graph.StartSearch()
+			return m, textinput.Blink
+		}
+		if m.Graph.SearchActive() {
+			switch msg.String() {
+			case "n":
+				m.Graph.NextMatch()
+				return m, nil
+			case "N":
+				m.Graph.PrevMatch()
+				return m, nil
+			case "esc":
+				m.Graph.ClearSearch()
+				return m, nil
+			}
 		}
 		// Route scroll keys to the graph viewport.
 		switch {
@@ -972,6 +1236,10 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Entanglement viewport scrolling — when the entanglements tab is active,
 	// route page up/down, home/end, and g/G to the viewport.
 	if m.Mode == ModeNebula && m.Depth == DepthPhases && m.ActiveTab == TabEntanglements {
+		if msg.String() == "c" {
+			m.EntanglementView.ToggleConflicts()
+			return m, nil
+		}
 		switch {
 		case key.Matches(msg, m.Keys.PageUp),
 			key.Matches(msg, m.Keys.PageDown),
@@ -1000,6 +1268,18 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Home mode: space marks a nebula to run alongside others.
+	if m.Mode == ModeHome && key.Matches(msg, m.Keys.Mark) {
+		m.toggleHomeMark()
+		return m, nil
+	}
+
+	// Home mode: Enter runs the marked nebulas together, if any.
+	if m.Mode == ModeHome && key.Matches(msg, m.Keys.Enter) && len(m.HomeMarked) > 0 {
+		m.SelectedNebulae = m.markedNebulae()
+		return m, tea.Quit
+	}
+
 	// Home mode: Enter selects a nebula and launches plan preview.
 	if m.Mode == ModeHome && key.Matches(msg, m.Keys.Enter) {
 		filtered := m.filteredHomeNebulae()
@@ -1036,9 +1316,35 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.Keys.Stop):
 		m.handleStopKey()
 
+	case key.Matches(msg, m.Keys.Raw):
+		m.handleRawKey()
+
 	case key.Matches(msg, m.Keys.Retry):
 		m.handleRetryKey()
 
+	case key.Matches(msg, m.Keys.Edit):
+		cmd := m.handleEditKey()
+		return m, cmd
+
+	case key.Matches(msg, m.Keys.Focus):
+		m.handleFocusKey()
+
+	case key.Matches(msg, m.Keys.Override):
+		cmd := m.handleOverrideKey()
+		return m, cmd
+
+	case key.Matches(msg, m.Keys.ExtendCycles):
+		cmd := m.handleExtendCyclesKey()
+		return m, cmd
+
+	case key.Matches(msg, m.Keys.Pin):
+		m.handlePinKey()
+
+	case key.Matches(msg, m.Keys.Minimap):
+		if m.Mode == ModeNebula {
+			m.ShowMinimap = !m.ShowMinimap
+		}
+
 	case key.Matches(msg, m.Keys.Up):
 		m.moveUp()
 
@@ -1057,6 +1363,9 @@ func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.Keys.Diff):
 		m.handleDiffKey()
 
+	case key.Matches(msg, m.Keys.Expand):
+		m.handleExpandKey()
+
 	case key.Matches(msg, m.Keys.HailList):
 		cmd := m.openHailList()
 		return m, cmd
@@ -1095,6 +1404,14 @@ func (m AppModel) handlePlanKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.PlanPreview.MoveRight()
 		return m, nil
 
+	case msg.String() == "]":
+		m.PlanPreview.NextChange()
+		return m, nil
+
+	case msg.String() == "[":
+		m.PlanPreview.PrevChange()
+		return m, nil
+
 	case key.Matches(msg, m.Keys.Up):
 		m.PlanPreview.ScrollUp()
 		return m, nil
@@ -1152,7 +1469,16 @@ func computePlan(nebulaDir, nebulaName string) tea.Msg {
 // handlePauseKey toggles pause state by writing/removing the PAUSE intervention file.
 // Only active in nebula mode at the phase table level.
 func (m *AppModel) handlePauseKey() {
-	if m.Mode != ModeNebula || m.Depth != DepthPhases || m.NebulaDir == "" {
+	if m.Depth != DepthPhases {
+		return
+	}
+	m.togglePause()
+}
+
+// togglePause writes the PAUSE intervention file, or removes it when the
+// run is already paused. Only active in nebula mode.
+func (m *AppModel) togglePause() {
+	if m.Mode != ModeNebula || m.NebulaDir == "" {
 		return
 	}
 	if m.Stopping {
@@ -1180,7 +1506,15 @@ func (m *AppModel) handlePauseKey() {
 // handleStopKey writes the STOP intervention file.
 // Only active in nebula mode at the phase table level.
 func (m *AppModel) handleStopKey() {
-	if m.Mode != ModeNebula || m.Depth != DepthPhases || m.NebulaDir == "" {
+	if m.Depth != DepthPhases {
+		return
+	}
+	m.requestStop()
+}
+
+// requestStop writes the STOP intervention file. Only active in nebula mode.
+func (m *AppModel) requestStop() {
+	if m.Mode != ModeNebula || m.NebulaDir == "" {
 		return
 	}
 	if m.Stopping {
@@ -1195,6 +1529,21 @@ func (m *AppModel) handleStopKey() {
 	m.Stopping = true
 }
 
+// requestDrain writes the DRAIN intervention file: the run finishes the
+// current wave, then stops with the remaining phases pending. Only active
+// in nebula mode.
+func (m *AppModel) requestDrain() {
+	if m.Mode != ModeNebula || m.NebulaDir == "" || m.Stopping || m.Draining {
+		return
+	}
+	drainPath := filepath.Join(m.NebulaDir, "DRAIN")
+	if err := os.WriteFile(drainPath, []byte("drained by TUI\n"), 0644); err != nil {
+		m.addMessage("failed to write DRAIN file: %s", err)
+		return
+	}
+	m.Draining = true
+}
+
 // handleRetryKey retries a failed phase by writing a RETRY intervention file
 // and resetting the TUI's visual state. The WorkerGroup picks up the RETRY
 // file and re-dispatches the phase.
@@ -1226,20 +1575,27 @@ func (m *AppModel) handleRetryKey() {
 		return // no failed phase selected
 	}
 
-	// Write a RETRY intervention file containing the phase ID.
-	// The WorkerGroup monitors for this file and re-dispatches the phase.
+	m.retryPhases(phaseID)
+}
+
+// retryPhases writes a RETRY intervention file listing the phase IDs, one
+// per line, and resets their visual state. The WorkerGroup monitors for
+// this file and re-dispatches the phases.
+func (m *AppModel) retryPhases(phaseIDs ...string) {
 	retryPath := filepath.Join(m.NebulaDir, "RETRY")
-	if err := os.WriteFile(retryPath, []byte(phaseID+"\n"), 0644); err != nil {
+	if err := os.WriteFile(retryPath, []byte(strings.Join(phaseIDs, "\n")+"\n"), 0644); err != nil {
 		m.addMessage("failed to write RETRY file: %s", err)
 		return
 	}
 
-	// Reset the TUI's visual state so it starts fresh.
-	m.NebulaView.SetPhaseStatus(phaseID, PhaseWaiting)
-	m.Graph.SetPhaseStatus(phaseID, PhaseWaiting)
-	// Clear the per-phase loop view so it starts fresh.
-	delete(m.PhaseLoops, phaseID)
-	m.addMessage("retrying phase %s", phaseID)
+	for _, phaseID := range phaseIDs {
+		// Reset the TUI's visual state so it starts fresh.
+		m.NebulaView.SetPhaseStatus(phaseID, PhaseWaiting)
+		m.Graph.SetPhaseStatus(phaseID, PhaseWaiting)
+		// Clear the per-phase loop view so it starts fresh.
+		delete(m.PhaseLoops, phaseID)
+		m.addMessage("retrying phase %s", phaseID)
+	}
 }
 
 // handleInfoKey toggles the detail/plan viewer in the detail panel.
@@ -1294,17 +1650,23 @@ func (m *AppModel) handleDiffKey() {
 	m.updateDetailFromSelection()
 }
 
-// buildDiffFileList constructs a FileListView from the currently selected agent's diff metadata.
-func (m *AppModel) buildDiffFileList() *FileListView {
-	var agent *AgentEntry
+// selectedAgent returns the agent entry under the cursor of the loop view
+// being shown, or nil when there is none.
+func (m *AppModel) selectedAgent() *AgentEntry {
 	switch m.Mode {
 	case ModeLoop:
-		agent = m.LoopView.SelectedAgent()
+		return m.LoopView.SelectedAgent()
 	case ModeNebula:
 		if lv := m.PhaseLoops[m.FocusedPhase]; lv != nil {
-			agent = lv.SelectedAgent()
+			return lv.SelectedAgent()
 		}
 	}
+	return nil
+}
+
+// buildDiffFileList constructs a FileListView from the currently selected agent's diff metadata.
+func (m *AppModel) buildDiffFileList() *FileListView {
+	agent := m.selectedAgent()
 	if agent == nil || len(agent.DiffFiles) == 0 {
 		return nil
 	}
@@ -1313,15 +1675,7 @@ func (m *AppModel) buildDiffFileList() *FileListView {
 
 // hasSelectedAgentDiff reports whether the currently selected agent has raw diff text.
 func (m *AppModel) hasSelectedAgentDiff() bool {
-	var agent *AgentEntry
-	switch m.Mode {
-	case ModeLoop:
-		agent = m.LoopView.SelectedAgent()
-	case ModeNebula:
-		if lv := m.PhaseLoops[m.FocusedPhase]; lv != nil {
-			agent = lv.SelectedAgent()
-		}
-	}
+	agent := m.selectedAgent()
 	return agent != nil && agent.Diff != ""
 }
 
@@ -1406,10 +1760,20 @@ func (m *AppModel) updateBeadDetail() {
 		bv := NewBeadView()
 		bv.SetRoot(*root)
 		bv.Width = m.contentWidth() - 2
+		bv.Attempts = m.PhaseAttempts[phaseID]
 		m.Detail.SetContent("Beads: "+root.Title, bv.View())
 	}
 }
 
+// seedAttempts loads each phase's run history from saved state.
+func (m *AppModel) seedAttempts(phases []PhaseInfo) {
+	for _, p := range phases {
+		if len(p.Attempts) > 0 {
+			m.PhaseAttempts[p.ID] = p.Attempts
+		}
+	}
+}
+
 // updatePlanDetail populates the detail panel with the selected phase's plan body.
 func (m *AppModel) updatePlanDetail() {
 	var phase *PhaseEntry
@@ -1423,11 +1787,12 @@ func (m *AppModel) updatePlanDetail() {
 		m.Detail.SetEmpty("No phase selected")
 		return
 	}
-	if phase.PlanBody == "" {
-		m.Detail.SetContent("📋 Plan: "+phase.ID, "(no plan body available)")
-		return
+	body := phase.PlanBody
+	if body == "" {
+		body = "(no plan body available)"
 	}
-	m.Detail.SetContent("📋 Plan: "+phase.ID, phase.PlanBody)
+	up, down, err := phaseImpact(m.Graph.phaseDeps, phase.ID)
+	m.Detail.SetContent("📋 Plan: "+phase.ID, body+"\n\n"+impactSection(up, down, err))
 }
 
 // drillDown navigates deeper into the hierarchy.
@@ -1459,9 +1824,19 @@ func (m *AppModel) drillDown() {
 			// Use the active tab's cursor to determine which phase.
 			var phaseID string
 			if m.ActiveTab == TabGraph {
+				// Enter on a collapsed import group expands it.
+				if g := m.Graph.SelectedGroup(); g != "" && m.Graph.SelectedPhaseID() == "" {
+					m.Graph.ToggleGroup()
+					return
+				}
 				phaseID = m.Graph.SelectedPhaseID()
 			} else if m.BoardActive && m.ActiveTab == TabBoard {
 				m.Board.Phases = m.NebulaView.Phases
+				// Enter on a collapsed import group expands it.
+				if g := m.Board.SelectedGroup(); g != "" && m.Board.Collapsed[g] {
+					m.Board.ToggleGroup()
+					return
+				}
 				if p := m.Board.SelectedPhase(); p != nil {
 					phaseID = p.ID
 				}
@@ -1511,6 +1886,11 @@ func (m *AppModel) drillUp() {
 		m.ShowBeads = false
 		return
 	}
+	// Focus mode is exited before leaving the agent output.
+	if m.focusActive() {
+		m.setFocusMode(false)
+		return
+	}
 
 	switch m.Mode {
 	case ModeLoop:
@@ -1530,7 +1910,17 @@ func (m *AppModel) drillUp() {
 // handleGateKey processes keys while a gate prompt is active.
 // Esc dismisses the gate by sending GateActionSkip (least destructive default).
 func (m AppModel) handleGateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Gate.FilesFocused() && m.handleGateFileKey(msg) {
+		return m, nil
+	}
 	switch {
+	case m.Gate.Budget != nil && key.Matches(msg, m.Keys.Back):
+		// A dismissed budget prompt lets the phase fail.
+		m.resolveGate(nebula.GateActionReject)
+	case m.Gate.Budget != nil && (key.Matches(msg, m.Keys.Accept) || key.Matches(msg, m.Keys.Skip)):
+		// Not offered for budget prompts.
+	case m.Gate.Manual && key.Matches(msg, m.Keys.Retry):
+		// Not offered for manual phases.
 	case key.Matches(msg, m.Keys.Back):
 		m.resolveGate(nebula.GateActionSkip)
 	case key.Matches(msg, m.Keys.Accept):
@@ -1544,7 +1934,11 @@ func (m AppModel) handleGateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.Keys.Enter):
 		m.resolveGate(m.Gate.SelectedAction())
 	case msg.String() == "left", msg.String() == "h":
-		m.Gate.MoveLeft()
+		if m.Gate.Cursor == 0 && m.Gate.hasFiles() {
+			m.Gate.FocusFiles()
+		} else {
+			m.Gate.MoveLeft()
+		}
 	case msg.String() == "right", msg.String() == "l":
 		m.Gate.MoveRight()
 	case msg.String() == "up", msg.String() == "k":
@@ -1577,6 +1971,7 @@ func (m *AppModel) resolveGate(action nebula.GateAction) {
 			m.Graph.SetPhaseStatus(phaseID, PhaseWorking)
 		case nebula.GateActionSkip:
 			m.NebulaView.SetPhaseStatus(phaseID, PhaseSkipped)
+			m.NebulaView.SetSkipReason(phaseID, "skipped at gate")
 			m.Graph.SetPhaseStatus(phaseID, PhaseSkipped)
 		}
 
@@ -1584,7 +1979,7 @@ func (m *AppModel) resolveGate(action nebula.GateAction) {
 		if len(m.PendingGates) > 0 {
 			next := m.PendingGates[0]
 			m.PendingGates = m.PendingGates[1:]
-			m.Gate = NewGatePrompt(next.Checkpoint, next.ResponseCh)
+			m.Gate = newGatePromptFor(next)
 			m.Gate.Width = m.contentWidth()
 			m.Gate.Height = m.Height
 		}
@@ -1692,6 +2087,7 @@ func (m *AppModel) openHailList() tea.Cmd {
 		return cmd
 	}
 	m.HailList = NewHailListOverlay(m.PendingHails)
+	m.resizeOverlays()
 	m.HailList.Width = m.Width
 	return nil
 }
@@ -1699,7 +2095,7 @@ func (m *AppModel) openHailList() tea.Cmd {
 // moveUp delegates to the active view based on depth.
 // When the diff file list is active, navigation targets it instead of the main list.
 func (m *AppModel) moveUp() {
-	if m.ShowDiff && m.DiffFileList != nil {
+	if m.diffListActive() {
 		m.DiffFileList.MoveUp()
 		m.updateDetailFromSelection()
 		return
@@ -1736,7 +2132,7 @@ func (m *AppModel) moveUp() {
 // moveDown delegates to the active view based on depth.
 // When the diff file list is active, navigation targets it instead of the main list.
 func (m *AppModel) moveDown() {
-	if m.ShowDiff && m.DiffFileList != nil {
+	if m.diffListActive() {
 		m.DiffFileList.MoveDown()
 		m.updateDetailFromSelection()
 		return
@@ -1808,6 +2204,10 @@ func (m *AppModel) updateDetailFromSelection() {
 			IssueCount: agent.IssueCount,
 			Done:       agent.Done,
 		})
+		if m.RawOutput {
+			m.setRawAgentDetail(agent.Role, header, agent)
+			return
+		}
 		if m.ShowDiff && agent.Diff != "" {
 			var body string
 			if m.DiffFileList != nil {
@@ -1825,7 +2225,7 @@ func (m *AppModel) updateDetailFromSelection() {
 			)
 			return
 		}
-		body := FormatAgentOutput(agent.Output)
+		body := FormatAgentOutput(agent.Output, m.ExpandOutput, m.Keys.Expand)
 		m.Detail.SetContentWithHeader(agent.Role+" output", header, body)
 
 	case ModeNebula:
@@ -1856,8 +2256,12 @@ func (m *AppModel) updateHomeDetail() {
 	}
 	b.WriteString("\nStatus: ")
 	b.WriteString(nc.Status)
+	if trouble := homeTroubleLabel(nc); trouble != "" {
+		b.WriteString("\nAttention: ")
+		b.WriteString(trouble)
+	}
 
-	m.Detail.SetContent(nc.Name, b.String())
+	m.Detail.SetContent(withIcon(nc.Icon, nc.Name), b.String())
 }
 
 // updateNebulaDetail updates the detail panel for nebula mode based on depth.
@@ -1866,13 +2270,20 @@ func (m *AppModel) updateNebulaDetail() {
 	var phaseHeader string
 	if m.FocusedPhase != "" {
 		if p := m.findPhase(m.FocusedPhase); p != nil {
+			var confidence string
+			if lv := m.PhaseLoops[p.ID]; lv != nil {
+				confidence = lv.Confidence
+			}
 			phaseHeader = FormatPhaseHeader(PhaseContext{
-				ID:        p.ID,
-				Title:     p.Title,
-				Status:    p.Status,
-				CostUSD:   p.CostUSD,
-				Cycles:    p.Cycles,
-				BlockedBy: p.BlockedBy,
+				ID:         p.ID,
+				Title:      p.Title,
+				Status:     p.Status,
+				CostUSD:    p.CostUSD,
+				Cycles:     p.Cycles,
+				BlockedBy:  p.BlockedBy,
+				SkipReason: p.SkipReason,
+				Preserved:  p.Preserved,
+				Confidence: confidence,
 			})
 		}
 	}
@@ -1881,7 +2292,11 @@ func (m *AppModel) updateNebulaDetail() {
 	case DepthPhaseLoop:
 		// Show phase summary card in the detail panel.
 		if phaseHeader != "" {
-			m.Detail.SetContentWithHeader(m.FocusedPhase+" summary", phaseHeader, "(select an agent row and press enter to view output)")
+			body := "(select an agent row and press enter to view output)"
+			if diff, ok := m.RefactorDiffs[m.FocusedPhase]; ok {
+				body = "Description refactor (" + diff.Summary() + "):\n" + diff.Render(m.Detail.viewport.Width) + "\n\n" + body
+			}
+			m.Detail.SetContentWithHeader(m.FocusedPhase+" summary", phaseHeader, body)
 		} else {
 			m.Detail.SetEmpty("(select an agent row to view output)")
 		}
@@ -1912,6 +2327,10 @@ func (m *AppModel) updateNebulaDetail() {
 			header = phaseHeader + "\n" + agentHeader
 		}
 
+		if m.RawOutput {
+			m.setRawAgentDetail(fmt.Sprintf("%s → %s", m.FocusedPhase, agent.Role), header, agent)
+			return
+		}
 		if m.ShowDiff && agent.Diff != "" {
 			title := fmt.Sprintf("%s → %s diff", m.FocusedPhase, agent.Role)
 			var body string
@@ -1928,7 +2347,7 @@ func (m *AppModel) updateNebulaDetail() {
 			m.Detail.SetContentWithHeader(title, header, "(output will appear when agent completes)")
 			return
 		}
-		body := FormatAgentOutput(agent.Output)
+		body := FormatAgentOutput(agent.Output, m.ExpandOutput, m.Keys.Expand)
 		m.Detail.SetContentWithHeader(title, header, body)
 
 	default:
@@ -1957,6 +2376,9 @@ func (m *AppModel) addMessage(format string, args ...any) {
 
 // detailHeight computes available height for the detail panel.
 func (m AppModel) detailHeight() int {
+	if m.focusActive() {
+		return m.focusDetailHeight()
+	}
 	used := 3
 	mainH := m.Height - used
 	if mainH < 4 {
@@ -2062,11 +2484,75 @@ func (m AppModel) View() string {
 	// Content uses full terminal width (side panel mode removed).
 	contentWidth := m.Width
 
+	var sections []string
+	if m.focusActive() {
+		sections = m.focusSections(contentWidth)
+	} else {
+		sections = m.layoutSections(contentWidth)
+	}
+
+	// Footer — always full terminal width.
+	footer := m.buildFooter()
+	sections = append(sections, footer.View())
+
+	base := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	// Hail overlay — rendered over a dimmed background when a human decision is pending.
+	if m.Hail != nil {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayContent := m.Hail.View(m.Width, m.Height)
+		overlayBox := centerOverlay(overlayContent, m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	// Hail list overlay — rendered over a dimmed background for browsing pending hails.
+	if m.HailList != nil {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayContent := m.HailList.View(m.Width, m.Height)
+		overlayBox := centerOverlay(overlayContent, m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	// Keybinding cheat sheet — rendered over a dimmed background.
+	if m.KeyHelp != nil {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayBox := centerOverlay(m.KeyHelp.View(m.Width, m.Height), m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	// Command palette — rendered over a dimmed background.
+	if m.Palette != nil {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayBox := centerOverlay(m.Palette.View(m.Width, m.Height), m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	// Quit confirmation overlay — rendered over a dimmed background.
+	if m.ShowQuitConfirm {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayBox := RenderQuitConfirm(m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	// Completion overlay — rendered over a dimmed background.
+	if m.Overlay != nil {
+		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
+		overlayBox := m.Overlay.View(m.Width, m.Height)
+		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
+	}
+
+	return base
+}
+
+// layoutSections assembles the standard layout above the footer: status bar,
+// tab bar, banner, main view, detail panel, gate, toasts, and bottom bar.
+func (m AppModel) layoutSections(contentWidth int) []string {
 	var sections []string
 
 	// Status bar — always full terminal width; sync execution control state.
 	m.StatusBar.Paused = m.Paused
 	m.StatusBar.Stopping = m.Stopping
+	m.StatusBar.Draining = m.Draining
 	if m.Mode == ModeHome {
 		m.StatusBar.HomeMode = true
 		m.StatusBar.HomeNebulaCount = len(m.filteredHomeNebulae())
@@ -2133,43 +2619,7 @@ func (m AppModel) View() string {
 		sections = append(sections, bottomBar)
 	}
 
-	// Footer — always full terminal width.
-	footer := m.buildFooter()
-	sections = append(sections, footer.View())
-
-	base := lipgloss.JoinVertical(lipgloss.Left, sections...)
-
-	// Hail overlay — rendered over a dimmed background when a human decision is pending.
-	if m.Hail != nil {
-		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
-		overlayContent := m.Hail.View(m.Width, m.Height)
-		overlayBox := centerOverlay(overlayContent, m.Width, m.Height)
-		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
-	}
-
-	// Hail list overlay — rendered over a dimmed background for browsing pending hails.
-	if m.HailList != nil {
-		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
-		overlayContent := m.HailList.View(m.Width, m.Height)
-		overlayBox := centerOverlay(overlayContent, m.Width, m.Height)
-		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
-	}
-
-	// Quit confirmation overlay — rendered over a dimmed background.
-	if m.ShowQuitConfirm {
-		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
-		overlayBox := RenderQuitConfirm(m.Width, m.Height)
-		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
-	}
-
-	// Completion overlay — rendered over a dimmed background.
-	if m.Overlay != nil {
-		dimmed := styleOverlayDimmed.Width(m.Width).Height(m.Height).Render(base)
-		overlayBox := m.Overlay.View(m.Width, m.Height)
-		return compositeOverlay(dimmed, overlayBox, m.Width, m.Height)
-	}
-
-	return base
+	return sections
 }
 
 // renderTooSmall renders a centered "Terminal too small" message.
@@ -2240,6 +2690,7 @@ func (m AppModel) renderMainView() string {
 			Width:   w,
 			Height:  m.homeMainHeight(),
 			Filter:  m.HomeFilter,
+			Marked:  m.HomeMarked,
 		}
 		return hv.View()
 
@@ -2252,21 +2703,31 @@ func (m AppModel) renderMainView() string {
 		case DepthPhases:
 			switch m.ActiveTab {
 			case TabBoard:
+				// The minimap, when shown, takes the top-right corner.
+				minimap := m.boardMinimap(w)
+				mainW := w
+				if minimap != "" {
+					mainW = w - lipgloss.Width(minimap) - 1
+				}
 				var boardStr string
 				if m.BoardActive {
 					// Columnar board view — sync phases and render.
 					m.Board.Phases = m.NebulaView.Phases
-					m.Board.Width = w
+					m.Board.Width = mainW
 					boardStr = m.Board.View()
 				} else {
 					// Table view fallback.
-					m.NebulaView.Width = w
+					m.NebulaView.Width = mainW
 					boardStr = m.NebulaView.View()
 				}
-				// Append worker cards beneath the board/table for active phases.
-				active := ActiveWorkerCards(m.WorkerCards)
-				if len(active) > 0 {
-					cardsStr := RenderWorkerCards(active, w)
+				if minimap != "" {
+					boardStr = lipgloss.JoinHorizontal(lipgloss.Top, boardStr, " ", minimap)
+				}
+				// Append worker cards beneath the board/table for active
+				// and pinned phases.
+				cards := m.boardWorkerCards()
+				if len(cards) > 0 {
+					cardsStr := RenderWorkerCards(cards, w)
 					boardStr = lipgloss.JoinVertical(lipgloss.Left, boardStr, cardsStr)
 				}
 				return boardStr
@@ -2299,7 +2760,7 @@ func (m AppModel) buildFooter() Footer {
 	f := Footer{Width: m.Width}
 
 	// When the diff file list is active, show dedicated diff-mode bindings.
-	if m.ShowDiff && m.DiffFileList != nil {
+	if m.diffListActive() {
 		f.Bindings = DiffFileListFooterBindings(m.Keys)
 		return f
 	}
@@ -2309,6 +2770,16 @@ func (m AppModel) buildFooter() Footer {
 		return f
 	}
 
+	if m.KeyHelp != nil {
+		f.Bindings = KeyHelpFooterBindings(m.Keys)
+		return f
+	}
+
+	if m.Palette != nil {
+		f.Bindings = PaletteFooterBindings(m.Keys)
+		return f
+	}
+
 	if m.Gate != nil {
 		f.Bindings = GateFooterBindings(m.Keys)
 	} else if m.Mode == ModeHome {
@@ -2327,21 +2798,36 @@ func (m AppModel) buildFooter() Footer {
 				} else {
 					diffBind.SetHelp("d", "diff")
 				}
-				f.Bindings = append(f.Bindings, diffBind)
+				f.Bindings = append(f.Bindings, diffBind, m.focusBinding(), m.expandBinding(), m.rawBinding())
 			}
 			if m.selectedPhaseFailed() {
 				f.Bindings = append(f.Bindings, m.Keys.Retry)
 			}
+			if m.editTargetPhase() != nil {
+				f.Bindings = append(f.Bindings, m.Keys.Edit)
+			}
 		} else if m.BoardActive {
 			f.Bindings = CockpitFooterBindings(m.Keys)
 			if m.selectedPhaseFailed() {
 				f.Bindings = append(f.Bindings, m.Keys.Retry)
 			}
+			if m.editTargetPhase() != nil {
+				f.Bindings = append(f.Bindings, m.Keys.Edit)
+			}
+			if pin, ok := m.pinBinding(); ok {
+				f.Bindings = append(f.Bindings, pin)
+			}
 		} else {
 			f.Bindings = NebulaFooterBindings(m.Keys)
 			if m.selectedPhaseFailed() {
 				f.Bindings = append(f.Bindings, m.Keys.Retry)
 			}
+			if m.editTargetPhase() != nil {
+				f.Bindings = append(f.Bindings, m.Keys.Edit)
+			}
+			if pin, ok := m.pinBinding(); ok {
+				f.Bindings = append(f.Bindings, pin)
+			}
 		}
 	} else {
 		f.Bindings = LoopFooterBindings(m.Keys)
@@ -2352,7 +2838,7 @@ func (m AppModel) buildFooter() Footer {
 			} else {
 				diffBind.SetHelp("d", "diff")
 			}
-			f.Bindings = append(f.Bindings, diffBind)
+			f.Bindings = append(f.Bindings, diffBind, m.focusBinding(), m.expandBinding(), m.rawBinding())
 		}
 	}
 
@@ -2360,6 +2846,9 @@ func (m AppModel) buildFooter() Footer {
 	if m.Keys.HailList.Enabled() {
 		f.Bindings = append(f.Bindings, m.Keys.HailList)
 	}
+	if m.Gate == nil {
+		f.Bindings = append(f.Bindings, m.Keys.Help)
+	}
 
 	return f
 }
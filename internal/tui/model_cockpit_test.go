@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -256,6 +257,41 @@ func TestMsgEntanglementUpdateFeedsViewer(t *testing.T) {
 	}
 }
 
+func TestMsgConflictCountsAndLists(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "p1", Title: "Phase 1"},
+		{ID: "p2", Title: "Phase 2"},
+	})
+
+	result, _ := m.Update(MsgConflict{PhaseID: "p2", OtherPhaseID: "p1", File: "api.go"})
+	result, _ = result.(AppModel).Update(MsgConflict{PhaseID: "p2", OtherPhaseID: "p1", File: "db.go"})
+	updated := result.(AppModel)
+
+	if updated.StatusBar.Conflicts != 2 {
+		t.Errorf("StatusBar.Conflicts = %d, want 2", updated.StatusBar.Conflicts)
+	}
+	if got := updated.EntanglementView.Conflicts; len(got) != 2 || got[1].File != "db.go" || got[1].OtherPhaseID != "p1" {
+		t.Errorf("unexpected conflict entries: %+v", got)
+	}
+	if len(updated.Toasts) != 2 || !updated.Toasts[0].IsError {
+		t.Errorf("expected two error toasts, got %+v", updated.Toasts)
+	}
+	updated.StatusBar.Width = 160
+	if !strings.Contains(updated.StatusBar.View(), "2 conflicts") {
+		t.Error("status bar should show the conflict count")
+	}
+
+	// c on the entanglements tab switches to the conflict list.
+	updated.DisableSplash()
+	updated.ActiveTab = TabEntanglements
+	result, _ = updated.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if !result.(AppModel).EntanglementView.ShowConflicts {
+		t.Error("expected c to show the conflict list on the entanglements tab")
+	}
+}
+
 func TestMsgScratchpadEntryFeedsScratchpad(t *testing.T) {
 	t.Parallel()
 
@@ -319,6 +355,19 @@ func TestMsgStaleWarningShowsToast(t *testing.T) {
 	}
 }
 
+func TestStaleWarningTextNamesRemediation(t *testing.T) {
+	t.Parallel()
+
+	got := staleWarningText([]tycho.StaleItem{
+		{Kind: "task", ID: "p1"},
+		{Kind: "claim", ID: "api.go", Remediation: "escalated to a hail"},
+	})
+	want := "stale: 2 items need attention; claim api.go: escalated to a hail"
+	if got != want {
+		t.Errorf("staleWarningText = %q, want %q", got, want)
+	}
+}
+
 func TestMsgHailTriggersOverlayWhenBoardActive(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package tui
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -190,6 +191,45 @@ func TestHandleStopKey(t *testing.T) {
 	})
 }
 
+// --- requestDrain tests ---
+
+func TestRequestDrain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes DRAIN file and sets Draining flag", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		m := newNebulaModel(dir)
+
+		m.requestDrain()
+
+		if !m.Draining {
+			t.Error("expected Draining to be true after requestDrain")
+		}
+		if _, err := os.Stat(filepath.Join(dir, "DRAIN")); err != nil {
+			t.Fatalf("expected DRAIN file to exist: %v", err)
+		}
+		m.StatusBar.Draining = m.Draining
+		if !strings.Contains(m.StatusBar.renderStateIndicator(), "DRAINING") {
+			t.Error("expected DRAINING in the status bar")
+		}
+	})
+
+	t.Run("no-op when already stopping", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		m := newNebulaModel(dir)
+		m.Stopping = true
+
+		m.requestDrain()
+
+		if m.Draining {
+			t.Error("expected Draining to remain false while stopping")
+		}
+		assertNoFile(t, filepath.Join(dir, "DRAIN"))
+	})
+}
+
 // --- handleRetryKey tests ---
 
 func TestHandleRetryKey(t *testing.T) {
@@ -830,6 +870,63 @@ func TestHandleGateKeyEscDismissesGate(t *testing.T) {
 	})
 }
 
+func TestBudgetPromptKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		key        tea.KeyMsg
+		wantAction nebula.GateAction
+		wantStatus PhaseStatus
+		resolved   bool
+	}{
+		{"r grants budget and retries", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}, nebula.GateActionRetry, PhaseWorking, true},
+		{"x fails the phase", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, nebula.GateActionReject, PhaseFailed, true},
+		{"Esc fails the phase", tea.KeyMsg{Type: tea.KeyEscape}, nebula.GateActionReject, PhaseFailed, true},
+		{"a is ignored", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, "", PhaseGate, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := *newNebulaModelWithPhases("", []PhaseEntry{
+				{ID: "phase-1", Title: "Phase 1", Status: PhaseWorking},
+			})
+			m.Splash = nil
+
+			ch := make(chan nebula.GateAction, 1)
+			req := &nebula.BudgetRequest{PhaseID: "phase-1", SpentUSD: 2, BudgetUSD: 2, ExtraUSD: 1}
+			result, _ := m.Update(MsgGatePrompt{Budget: req, ResponseCh: ch})
+			m = result.(AppModel)
+			if m.Gate == nil || m.Gate.Budget == nil {
+				t.Fatal("expected a budget prompt to be shown")
+			}
+			if !strings.Contains(m.Gate.View(), "+$1.00 and retry") {
+				t.Errorf("budget prompt view missing retry option:\n%s", m.Gate.View())
+			}
+
+			result, _ = m.handleKey(tt.key)
+			m = result.(AppModel)
+
+			if got := m.Gate == nil; got != tt.resolved {
+				t.Errorf("gate resolved = %v, want %v", got, tt.resolved)
+			}
+			select {
+			case action := <-ch:
+				if action != tt.wantAction {
+					t.Errorf("action = %q, want %q", action, tt.wantAction)
+				}
+			default:
+				if tt.resolved {
+					t.Error("expected a response on the channel")
+				}
+			}
+			if got := m.NebulaView.Phases[0].Status; got != tt.wantStatus {
+				t.Errorf("phase status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
 // --- Completion overlay Esc tests ---
 
 func TestCompletionOverlayEscReturnsToHome(t *testing.T) {
@@ -1121,3 +1218,109 @@ func TestQuitConfirmOtherKeysIgnored(t *testing.T) {
 		t.Error("expected no command for unrelated key in confirmation overlay")
 	}
 }
+
+func TestGateFileBrowser(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "phase-1", Title: "Phase 1", Status: PhaseGate},
+	})
+	m.Splash = nil
+	ch := make(chan nebula.GateAction, 1)
+	m.Gate = NewGatePrompt(&nebula.Checkpoint{
+		PhaseID: "phase-1",
+		Diff: "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-old\n+new\n" +
+			"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1 +1 @@\n-before\n+after\n",
+		FilesChanged: []nebula.FileChange{
+			{Path: "a.go", Operation: "modified", LinesAdded: 1, LinesRemoved: 1},
+			{Path: "b.go", Operation: "modified", LinesAdded: 1, LinesRemoved: 1},
+		},
+	}, ch)
+	m.Gate.Width = 100
+	m.Gate.Height = 40
+
+	press := func(k tea.KeyMsg) {
+		t.Helper()
+		result, _ := m.handleKey(k)
+		updated := result.(AppModel)
+		m = &updated
+		if m.Gate == nil {
+			t.Fatalf("gate resolved by %q", k.String())
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	if !m.Gate.FilesFocused() {
+		t.Fatal("left from the first button should focus the file list")
+	}
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Gate.OpenFile != "b.go" {
+		t.Fatalf("OpenFile = %q, want b.go", m.Gate.OpenFile)
+	}
+	if view := m.Gate.View(); !strings.Contains(view, "after") || strings.Contains(view, "new") {
+		t.Errorf("gate should show only b.go's diff:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEscape})
+	if m.Gate.OpenFile != "" || !m.Gate.FilesFocused() {
+		t.Error("esc should close the diff and stay in the file list")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if m.Gate.FilesFocused() {
+		t.Error("right should return focus to the decision buttons")
+	}
+
+	// Decision keys still resolve the gate from the file list.
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if result.(AppModel).Gate != nil {
+		t.Fatal("accept should resolve the gate from the file list")
+	}
+	if action := <-ch; action != nebula.GateActionAccept {
+		t.Errorf("action = %q, want accept", action)
+	}
+}
+
+func TestGateFileBrowserVimKeys(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "phase-1", Title: "Phase 1", Status: PhaseGate},
+	})
+	m.Splash = nil
+	ch := make(chan nebula.GateAction, 1)
+	m.Gate = NewGatePrompt(&nebula.Checkpoint{
+		PhaseID: "phase-1",
+		FilesChanged: []nebula.FileChange{
+			{Path: "a.go", Operation: "modified", LinesAdded: 1, LinesRemoved: 1},
+			{Path: "b.go", Operation: "modified", LinesAdded: 1, LinesRemoved: 1},
+		},
+	}, ch)
+	press := func(s string) {
+		t.Helper()
+		result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		updated := result.(AppModel)
+		m = &updated
+	}
+
+	press("h")
+	press("j")
+	if !m.Gate.FilesFocused() || m.Gate.FileList.Cursor != 1 {
+		t.Fatalf("j should move down the file list: focused=%v cursor=%d", m.Gate.FilesFocused(), m.Gate.FileList.Cursor)
+	}
+	press("l")
+	if m.Gate.FilesFocused() {
+		t.Fatal("l should return focus to the decision buttons")
+	}
+
+	// k is the gate's Skip, not up, even in the file list.
+	press("h")
+	press("k")
+	if m.Gate != nil {
+		t.Fatal("k should resolve the gate from the file list")
+	}
+	if action := <-ch; action != nebula.GateActionSkip {
+		t.Errorf("action = %q, want skip", action)
+	}
+}
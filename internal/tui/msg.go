@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/papapumpkin/quasar/internal/fabric"
@@ -67,6 +68,14 @@ type MsgBudgetExceeded struct {
 	Limit float64
 }
 
+// MsgBudgetWarning is sent once when spending crosses the soft budget
+// threshold. PhaseID is empty in loop mode.
+type MsgBudgetWarning struct {
+	PhaseID string
+	Spent   float64
+	Limit   float64
+}
+
 // MsgError is sent for error messages.
 type MsgError struct {
 	Msg string
@@ -178,6 +187,28 @@ type MsgPhaseError struct {
 	Msg     string
 }
 
+// MsgPhaseStatus sets a phase's status directly, for status changes that
+// have no dedicated message (e.g. gate or skipped) such as those replayed
+// by an attached event stream.
+type MsgPhaseStatus struct {
+	PhaseID string
+	Status  PhaseStatus
+	Reason  string // why the phase was skipped; only set with PhaseSkipped
+}
+
+// MsgPhasePreserved is sent when the work a failed phase left in the
+// working tree was kept for inspection.
+type MsgPhasePreserved struct {
+	PhaseID  string
+	Location string // branch, or "snapshot <id>" outside git
+}
+
+// MsgPhaseAttempt is sent when a run of a phase finishes, successful or not.
+type MsgPhaseAttempt struct {
+	PhaseID string
+	Attempt nebula.AttemptRecord
+}
+
 // MsgPhaseInfo is sent for informational messages within a phase.
 type MsgPhaseInfo struct {
 	PhaseID string
@@ -188,16 +219,22 @@ type MsgPhaseInfo struct {
 
 // PhaseInfo carries phase metadata for populating the NebulaView at startup.
 type PhaseInfo struct {
-	ID        string
-	Title     string
-	DependsOn []string
-	PlanBody  string      // markdown content from the phase file
-	Status    PhaseStatus // initial status from saved state (default PhaseWaiting)
+	ID         string
+	Title      string
+	DependsOn  []string
+	PlanBody   string                 // markdown content from the phase file
+	SourceFile string                 // path to the phase's markdown file (empty = not editable)
+	Status     PhaseStatus            // initial status from saved state (default PhaseWaiting)
+	SkipReason string                 // why a skipped phase never ran, from saved state
+	Manual     bool                   // completed by a human rather than an agent
+	Attempts   []nebula.AttemptRecord // runs so far, from saved state
 }
 
 // MsgNebulaInit is sent at TUI startup to populate the phase table.
 type MsgNebulaInit struct {
 	Name   string
+	Icon   string // nebula icon; "" for none
+	Color  string // lipgloss color from nebula.Info.ColorCode; "" for none
 	Phases []PhaseInfo
 }
 
@@ -211,8 +248,10 @@ type MsgNebulaProgress struct {
 }
 
 // MsgGatePrompt is sent when a gate decision is needed from the user.
+// Budget is set instead of Checkpoint when a phase exhausted its budget cap.
 type MsgGatePrompt struct {
 	Checkpoint *nebula.Checkpoint
+	Budget     *nebula.BudgetRequest
 	ResponseCh chan<- nebula.GateAction
 }
 
@@ -231,9 +270,77 @@ type MsgPhaseRefactorPending struct {
 }
 
 // MsgPhaseRefactorApplied signals that the pending refactor was picked up by
-// the loop and the new description is now in use.
+// the loop and the new description is now in use. Original and Updated are
+// the descriptions before and after the refactor.
 type MsgPhaseRefactorApplied struct {
+	PhaseID  string
+	Original string
+	Updated  string
+}
+
+// MsgPhaseOverrideReady hands the TUI the channel a phase's running loop
+// watches for a human override of its reviewer.
+type MsgPhaseOverrideReady struct {
+	PhaseID string
+	Ch      chan<- struct{}
+}
+
+// MsgPhaseCyclesReady hands the TUI the channel a phase's running loop
+// reads extra review cycles from.
+type MsgPhaseCyclesReady struct {
+	PhaseID string
+	Ch      chan<- int
+}
+
+// MsgPhaseEdited is sent when the external editor opened on a phase file
+// (via the edit key) exits. Err is non-nil if the editor failed to run.
+type MsgPhaseEdited struct {
+	PhaseID string
+	Err     error
+}
+
+// MsgRefactorerReady attaches the PhaseRefactorer used to queue a refactor
+// after an in-TUI edit. Sent once the WorkerGroup exists.
+type MsgRefactorerReady struct {
+	Refactorer PhaseRefactorer
+}
+
+// MsgIdlePauserReady enables idle pausing: once a gate prompt has gone
+// Timeout without a keypress, the TUI asks Pauser to pause the run.
+// Sent once the WorkerGroup exists; a zero Timeout leaves it disabled.
+type MsgIdlePauserReady struct {
+	Pauser  IdlePauser
+	Timeout time.Duration
+}
+
+// MsgSilenceLimit sets how long an active phase may go without activity
+// before the TUI warns that it may be stuck. A zero Limit disables the warning.
+type MsgSilenceLimit struct {
+	Limit time.Duration
+}
+
+// MsgPhaseHeartbeat reports that a phase's agent showed signs of life at At,
+// such as CLI output, between the lifecycle messages.
+type MsgPhaseHeartbeat struct {
 	PhaseID string
+	At      time.Time
+}
+
+// MsgETAHistory seeds the status bar's ETA: each phase's duration in the
+// nebula's last recorded run, the estimated_minutes hints of the phases
+// that declare one, the duration assumed for phases with neither, and the
+// worker limit.
+type MsgETAHistory struct {
+	Durations  map[string]time.Duration
+	Estimates  map[string]time.Duration
+	Default    time.Duration
+	MaxWorkers int
+}
+
+// MsgRuntimeLimit tells the status bar when the run's max runtime ends,
+// so it can count down the time left to start phases.
+type MsgRuntimeLimit struct {
+	Deadline time.Time
 }
 
 // MsgPhaseHotAdded signals that a new phase was dynamically inserted into
@@ -250,6 +357,18 @@ type MsgPhaseScanning struct {
 	PhaseID string
 }
 
+// MsgPhaseReady is sent when a phase's dependencies are met and it becomes
+// eligible to run.
+type MsgPhaseReady struct {
+	PhaseID string
+}
+
+// MsgRateLimit reports the shared agent rate limiter's state for the status bar.
+type MsgRateLimit struct {
+	Utilization float64 // fraction of the limit in use, 0–1
+	Throttled   bool    // an invocation is waiting for capacity
+}
+
 // Internal TUI messages.
 
 // MsgTick drives the elapsed-time timer.
@@ -266,6 +385,9 @@ type MsgLoopDone struct {
 type MsgNebulaDone struct {
 	Results []nebula.WorkerResult
 	Err     error
+	// Canceled is set when the run's context was canceled (e.g. Ctrl-C
+	// outside the TUI) before it finished, rather than the run ending.
+	Canceled bool
 }
 
 // MsgGitPostCompletion delivers the results of the post-nebula git workflow
@@ -315,6 +437,14 @@ type MsgPhaseBeadUpdate struct {
 	Root       BeadInfo
 }
 
+// MsgBeadsDegraded reports the number of bead operations buffered locally
+// while the beads backend is unavailable. Buffered == 0 means it recovered.
+// PhaseID is empty in loop mode.
+type MsgBeadsDegraded struct {
+	PhaseID  string
+	Buffered int
+}
+
 // MsgSplashDone signals that the splash screen timer has elapsed.
 type MsgSplashDone struct{}
 
@@ -325,6 +455,14 @@ type MsgEntanglementUpdate struct {
 	Entanglements []fabric.Entanglement
 }
 
+// MsgConflict reports that PhaseID modified File while OtherPhaseID held a
+// claim on it.
+type MsgConflict struct {
+	PhaseID      string
+	OtherPhaseID string
+	File         string
+}
+
 // MsgDiscoveryPosted surfaces a new discovery in the cockpit.
 type MsgDiscoveryPosted struct {
 	Discovery fabric.Discovery
@@ -369,6 +507,18 @@ type MsgStaleWarning struct {
 	Items []tycho.StaleItem
 }
 
+// staleWarningText summarizes stale items for a toast, naming the action
+// taken on each remediated one.
+func staleWarningText(items []tycho.StaleItem) string {
+	text := fmt.Sprintf("stale: %d items need attention", len(items))
+	for _, it := range items {
+		if it.Remediation != "" {
+			text += fmt.Sprintf("; %s %s: %s", it.Kind, it.ID, it.Remediation)
+		}
+	}
+	return text
+}
+
 // PlanAction represents the user's chosen action from the plan preview.
 type PlanAction int
 
@@ -0,0 +1,398 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MsgScoped addresses Msg to one nebula of a MultiModel.
+type MsgScoped struct {
+	NebulaID string
+	Msg      tea.Msg
+}
+
+// forwardModel wraps every message it receives in a MsgScoped and sends it
+// on to the program hosting the MultiModel.
+type forwardModel struct {
+	target *Program
+	id     string
+}
+
+func (f forwardModel) Init() tea.Cmd { return nil }
+
+func (f forwardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	f.target.Send(MsgScoped{NebulaID: f.id, Msg: msg})
+	return f, nil
+}
+
+func (f forwardModel) View() string { return "" }
+
+// NewNebulaForwarder returns a headless program that forwards everything
+// sent to it into target as MsgScoped for nebula id. Bridges and gaters
+// built against the forwarder therefore drive one section of a MultiModel
+// unchanged. The caller runs it in its own goroutine and quits it when the
+// nebula finishes.
+func NewNebulaForwarder(target *Program, id string) *Program {
+	return tea.NewProgram(forwardModel{target: target, id: id},
+		tea.WithoutRenderer(),
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithoutSignalHandler(),
+	)
+}
+
+// MultiModel shows several independently running nebulas, each as a
+// collapsible section with its own phase table, above an aggregate status
+// bar. Enter zooms into a section, which then behaves like a single-nebula
+// TUI until esc returns to the overview.
+type MultiModel struct {
+	Order       []string            // nebula IDs in display order
+	Sections    map[string]AppModel // per-nebula models
+	Collapsed   map[string]bool     // sections showing only their header
+	Cursor      int                 // selected section in the overview
+	Zoomed      string              // nebula ID shown full screen; "" for the overview
+	ConfirmQuit bool                // quit pressed while nebulas are still running
+	Keys        KeyMap
+	Width       int
+	Height      int
+}
+
+// NewMultiModel creates an empty multi-nebula overview.
+func NewMultiModel() MultiModel {
+	return MultiModel{
+		Sections:  make(map[string]AppModel),
+		Collapsed: make(map[string]bool),
+		Keys:      DefaultKeyMap(),
+	}
+}
+
+// Add appends a nebula section. Sections are added before the program runs.
+func (m *MultiModel) Add(id string, model AppModel) {
+	model.DisableSplash()
+	m.Order = append(m.Order, id)
+	m.Sections[id] = model
+}
+
+// NewMultiProgram creates a TUI program hosting m.
+func NewMultiProgram(m MultiModel) *Program {
+	return tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+}
+
+// Init starts every section.
+func (m MultiModel) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.Order))
+	for _, id := range m.Order {
+		cmds = append(cmds, scopeCmd(id, m.Sections[id].Init()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update routes scoped messages to their section, keys to the zoomed
+// section or the overview, and everything else to the zoomed section.
+func (m MultiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case MsgScoped:
+		if _, ok := msg.Msg.(tea.QuitMsg); ok {
+			return m.handleSectionQuit(msg.NebulaID)
+		}
+		return m.updateSection(msg.NebulaID, msg.Msg)
+
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		cmds := make([]tea.Cmd, 0, len(m.Order))
+		for _, id := range m.Order {
+			var cmd tea.Cmd
+			m, cmd = m.updateSection(id, msg)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		if m.Zoomed == "" {
+			return m.handleOverviewKey(msg)
+		}
+		if key.Matches(msg, m.Keys.Back) && sectionAtRest(m.Sections[m.Zoomed]) {
+			m.Zoomed = ""
+			return m, nil
+		}
+		return m.updateSection(m.Zoomed, msg)
+	}
+
+	// Unscoped results, such as an editor exiting, belong to the section
+	// the user is looking at.
+	if m.Zoomed != "" {
+		return m.updateSection(m.Zoomed, msg)
+	}
+	return m, nil
+}
+
+// updateSection delivers msg to section id and scopes the returned command.
+func (m MultiModel) updateSection(id string, msg tea.Msg) (MultiModel, tea.Cmd) {
+	section, ok := m.Sections[id]
+	if !ok {
+		return m, nil
+	}
+	next, cmd := section.Update(msg)
+	if am, ok := next.(AppModel); ok {
+		m.Sections[id] = am
+	}
+	return m, scopeCmd(id, cmd)
+}
+
+// scopeCmd wraps cmd so the message it produces comes back as a MsgScoped
+// for nebula id. Batches are unwrapped so each command keeps its scope.
+// Bubble Tea's own control messages (exec, sequence, screen) pass through
+// unscoped, since the program must handle them itself.
+func scopeCmd(id string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		switch msg := msg.(type) {
+		case nil:
+			return nil
+		case tea.BatchMsg:
+			cmds := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				cmds[i] = scopeCmd(id, c)
+			}
+			return cmds
+		case tea.QuitMsg:
+			return MsgScoped{NebulaID: id, Msg: msg}
+		}
+		if t := reflect.TypeOf(msg); t.PkgPath() == teaPkgPath {
+			return msg
+		}
+		return MsgScoped{NebulaID: id, Msg: msg}
+	}
+}
+
+// teaPkgPath is the import path of Bubble Tea, whose messages scopeCmd
+// leaves unscoped.
+const teaPkgPath = "github.com/charmbracelet/bubbletea"
+
+// handleSectionQuit handles a section asking to quit. A finished nebula
+// only returns to the overview while others are still running; otherwise
+// the whole program quits.
+func (m MultiModel) handleSectionQuit(id string) (tea.Model, tea.Cmd) {
+	if m.Sections[id].Done && m.running() > 0 {
+		m.Zoomed = ""
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+// sectionAtRest reports whether esc in section s has nothing of its own to
+// close, so it can return to the overview instead.
+func sectionAtRest(s AppModel) bool {
+	return s.Depth == DepthPhases && s.Gate == nil && s.KeyHelp == nil &&
+		s.HailList == nil && s.Hail == nil && !s.ShowQuitConfirm
+}
+
+// handleOverviewKey handles keys while the overview is shown.
+func (m MultiModel) handleOverviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.ConfirmQuit {
+		m.ConfirmQuit = false
+		if key.Matches(msg, m.Keys.Quit) {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, m.Keys.Quit):
+		if msg.String() == "ctrl+c" || m.running() == 0 {
+			return m, tea.Quit
+		}
+		m.ConfirmQuit = true
+	case key.Matches(msg, m.Keys.Up):
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case key.Matches(msg, m.Keys.Down):
+		if m.Cursor < len(m.Order)-1 {
+			m.Cursor++
+		}
+	case key.Matches(msg, m.Keys.Mark):
+		if id := m.selected(); id != "" {
+			m.Collapsed[id] = !m.Collapsed[id]
+		}
+	case key.Matches(msg, m.Keys.Enter):
+		m.Zoomed = m.selected()
+	}
+	return m, nil
+}
+
+// selected returns the nebula ID under the cursor.
+func (m MultiModel) selected() string {
+	if m.Cursor < 0 || m.Cursor >= len(m.Order) {
+		return ""
+	}
+	return m.Order[m.Cursor]
+}
+
+// running counts the nebulas that have not finished.
+func (m MultiModel) running() int {
+	n := 0
+	for _, id := range m.Order {
+		if !m.Sections[id].Done {
+			n++
+		}
+	}
+	return n
+}
+
+// View renders the zoomed section, or the overview.
+func (m MultiModel) View() string {
+	if m.Zoomed != "" {
+		return m.Sections[m.Zoomed].View()
+	}
+	if m.Width == 0 {
+		return "initializing..."
+	}
+
+	var lines []string
+	cursorLine := 0
+	for i, id := range m.Order {
+		if i == m.Cursor {
+			cursorLine = len(lines)
+		}
+		lines = append(lines, m.sectionHeader(i, id))
+		if m.Collapsed[id] {
+			continue
+		}
+		nv := m.Sections[id].NebulaView
+		nv.Width = m.Width - 4
+		for _, l := range strings.Split(strings.TrimRight(nv.View(), "\n"), "\n") {
+			lines = append(lines, "    "+l)
+		}
+	}
+
+	// Keep the selected section's header in view.
+	bodyHeight := max(m.Height-2, 1)
+	start := 0
+	if len(lines) > bodyHeight && cursorLine+bodyHeight > len(lines) {
+		start = len(lines) - bodyHeight
+	} else if len(lines) > bodyHeight {
+		start = cursorLine
+	}
+	end := min(start+bodyHeight, len(lines))
+	body := strings.Join(lines[start:end], "\n")
+	if pad := bodyHeight - (end - start); pad > 0 {
+		body += strings.Repeat("\n", pad)
+	}
+	return body + "\n" + m.aggregateBar() + "\n" + m.footer()
+}
+
+// sectionHeader renders one section's summary line.
+func (m MultiModel) sectionHeader(i int, id string) string {
+	s := m.Sections[id]
+	indicator := "  "
+	if i == m.Cursor {
+		indicator = styleSelectionIndicator.Render(selectionIndicator) + " "
+	}
+	fold := "▾"
+	if m.Collapsed[id] {
+		fold = "▸"
+	}
+	name := s.StatusBar.Name
+	if name == "" {
+		name = id
+	}
+	name = withIcon(s.StatusBar.Icon, name)
+	nameStyle := stylePhaseID
+	if i == m.Cursor {
+		nameStyle = styleRowSelected
+	}
+	nameStyle = identityStyle(nameStyle, s.StatusBar.Color)
+	detail := fmt.Sprintf("%d/%d phases  $%.2f", s.StatusBar.Completed, s.StatusBar.Total, s.StatusBar.CostUSD)
+
+	var state string
+	switch {
+	case s.Gate != nil:
+		state = styleRowGate.Render(iconGate + " gate")
+	case s.Done && (s.DoneErr != nil || sectionFailed(s)):
+		state = styleRowFailed.Render(iconFailed + " failed")
+	case s.Done:
+		state = styleRowDone.Render(iconDone + " done")
+	default:
+		state = styleRowWorking.Render(iconWorking + " running")
+	}
+	return indicator + fold + " " + nameStyle.Render(name) + "  " + stylePhaseDetail.Render(detail) + "  " + state
+}
+
+// sectionFailed reports whether any of a finished nebula's phases failed.
+func sectionFailed(s AppModel) bool {
+	for _, r := range s.DoneResults {
+		if r.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregateBar summarizes every section in one status line.
+func (m MultiModel) aggregateBar() string {
+	var completed, total int
+	var cost float64
+	for _, id := range m.Order {
+		sb := m.Sections[id].StatusBar
+		completed += sb.Completed
+		total += sb.Total
+		cost += sb.CostUSD
+	}
+	running := m.running()
+	text := fmt.Sprintf("%d running · %d done · phases %d/%d · $%.2f",
+		running, len(m.Order)-running, completed, total, cost)
+	if m.ConfirmQuit {
+		text = fmt.Sprintf("%d nebulas still running — press q again to quit", running)
+	}
+	return styleStatusBar.Width(m.Width).Render(" " + text)
+}
+
+// footer renders the overview's keybinding hints.
+func (m MultiModel) footer() string {
+	return Footer{Width: m.Width, Bindings: MultiFooterBindings(m.Keys)}.View()
+}
+
+// MultiFooterBindings returns footer bindings for the multi-nebula overview.
+func MultiFooterBindings(km KeyMap) []key.Binding {
+	collapse := km.Mark
+	collapse.SetHelp("space", "collapse")
+	zoom := km.Enter
+	zoom.SetHelp("enter", "zoom")
+	return []key.Binding{km.Up, km.Down, collapse, zoom, km.Quit}
+}
+
+// toggleHomeMark marks or unmarks the nebula under the home cursor.
+func (m *AppModel) toggleHomeMark() {
+	filtered := m.filteredHomeNebulae()
+	if m.HomeCursor < 0 || m.HomeCursor >= len(filtered) {
+		return
+	}
+	path := filtered[m.HomeCursor].Path
+	if m.HomeMarked[path] {
+		delete(m.HomeMarked, path)
+		return
+	}
+	if m.HomeMarked == nil {
+		m.HomeMarked = make(map[string]bool)
+	}
+	m.HomeMarked[path] = true
+}
+
+// markedNebulae returns the paths of the marked nebulas in list order.
+func (m AppModel) markedNebulae() []string {
+	var paths []string
+	for _, nc := range m.HomeNebulae {
+		if m.HomeMarked[nc.Path] {
+			paths = append(paths, nc.Path)
+		}
+	}
+	return paths
+}
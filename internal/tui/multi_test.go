@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func newTestMultiModel() MultiModel {
+	m := NewMultiModel()
+	m.Add("a", NewNebulaModel(nebula.Info{Name: "alpha"}, []PhaseInfo{{ID: "a1", Title: "A one"}}, "", true))
+	m.Add("b", NewNebulaModel(nebula.Info{Name: "beta"}, []PhaseInfo{{ID: "b1", Title: "B one"}}, "", true))
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	return next.(MultiModel)
+}
+
+func TestMultiModel_ScopedRouting(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMultiModel()
+	next, _ := m.Update(MsgScoped{NebulaID: "b", Msg: MsgPhaseStatus{PhaseID: "b1", Status: PhaseFailed}})
+	m = next.(MultiModel)
+
+	if got := m.Sections["b"].NebulaView.Phases[0].Status; got != PhaseFailed {
+		t.Errorf("section b phase status = %v, want failed", got)
+	}
+	if got := m.Sections["a"].NebulaView.Phases[0].Status; got != PhaseWaiting {
+		t.Errorf("section a phase status = %v, want untouched", got)
+	}
+	if m.Sections["a"].Width != 100 {
+		t.Errorf("section a width = %d, want the window size forwarded", m.Sections["a"].Width)
+	}
+}
+
+func TestScopeCmd(t *testing.T) {
+	t.Parallel()
+
+	msg := scopeCmd("a", func() tea.Msg { return MsgError{Msg: "x"} })()
+	scoped, ok := msg.(MsgScoped)
+	if !ok || scoped.NebulaID != "a" {
+		t.Fatalf("scopeCmd result = %#v, want MsgScoped for a", msg)
+	}
+
+	batch := scopeCmd("a", tea.Batch(
+		func() tea.Msg { return MsgError{Msg: "x"} },
+		func() tea.Msg { return MsgError{Msg: "y"} },
+	))()
+	cmds, ok := batch.(tea.BatchMsg)
+	if !ok || len(cmds) != 2 {
+		t.Fatalf("scoped batch = %#v, want a two-command BatchMsg", batch)
+	}
+	if _, ok := cmds[0]().(MsgScoped); !ok {
+		t.Error("batched command result is not scoped")
+	}
+
+	if _, ok := scopeCmd("a", tea.ClearScreen)().(MsgScoped); ok {
+		t.Error("Bubble Tea control message was scoped")
+	}
+}
+
+func TestMultiModel_OverviewKeys(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMultiModel()
+	press := func(k tea.KeyMsg) tea.Cmd {
+		next, cmd := m.Update(k)
+		m = next.(MultiModel)
+		return cmd
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if !m.Collapsed["b"] {
+		t.Fatal("space did not collapse the selected section")
+	}
+	view := m.View()
+	if !strings.Contains(view, "alpha") || !strings.Contains(view, "a1") {
+		t.Errorf("overview missing expanded section a:\n%s", view)
+	}
+	if strings.Contains(view, "b1") {
+		t.Errorf("overview shows collapsed section b's phases:\n%s", view)
+	}
+	if !strings.Contains(view, "2 running") {
+		t.Errorf("overview missing aggregate bar:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Zoomed != "b" {
+		t.Fatalf("Zoomed = %q, want b", m.Zoomed)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Zoomed != "" {
+		t.Errorf("esc at rest did not return to the overview")
+	}
+
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd != nil || !m.ConfirmQuit {
+		t.Error("q with running nebulas should ask for confirmation")
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd == nil {
+		t.Error("second q should quit")
+	}
+}
+
+func TestMultiModel_FinishedSectionQuit(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMultiModel()
+	next, _ := m.Update(MsgScoped{NebulaID: "a", Msg: MsgNebulaDone{}})
+	m = next.(MultiModel)
+	m.Zoomed = "a"
+
+	next, cmd := m.Update(MsgScoped{NebulaID: "a", Msg: tea.QuitMsg{}})
+	m = next.(MultiModel)
+	if cmd != nil || m.Zoomed != "" {
+		t.Error("quitting a finished nebula while another runs should return to the overview")
+	}
+}
+
+func TestHomeMarking(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeHome)
+	m.DisableSplash()
+	m.HomeNebulae = []NebulaChoice{
+		{Name: "one", Path: "/n/one", Status: "ready"},
+		{Name: "two", Path: "/n/two", Status: "ready"},
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	m.HomeCursor = 1
+	next, _ := m.handleKey(space)
+	m = next.(AppModel)
+	m.HomeCursor = 0
+	next, _ = m.handleKey(space)
+	m = next.(AppModel)
+
+	next, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(AppModel)
+	if cmd == nil {
+		t.Fatal("enter with marked nebulas should quit the home screen")
+	}
+	if got := strings.Join(m.SelectedNebulae, ","); got != "/n/one,/n/two" {
+		t.Errorf("SelectedNebulae = %q, want list order", got)
+	}
+	if m.ShowPlanPreview {
+		t.Error("enter with marks opened the plan preview")
+	}
+}
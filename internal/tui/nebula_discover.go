@@ -12,10 +12,14 @@ import (
 type NebulaChoice struct {
 	Name        string // from nebula.toml [nebula] name
 	Description string // from nebula.toml [nebula] description
+	Icon        string // from nebula.toml [nebula] icon; "" for none
+	Color       string // lipgloss color from nebula.toml [nebula] color; "" for none
 	Path        string // directory path
 	Status      string // "ready", "in_progress", "done", "partial"
 	Phases      int    // total phase count
 	Done        int    // completed phases
+	Failed      int    // phases whose last run failed
+	Stuck       int    // phases the state file still records as in progress
 }
 
 // DiscoverNebulae scans the parent of currentDir for sibling nebula directories.
@@ -58,6 +62,8 @@ func DiscoverNebulae(currentDir string) ([]NebulaChoice, error) {
 		choice := NebulaChoice{
 			Name:        n.Manifest.Nebula.Name,
 			Description: n.Manifest.Nebula.Description,
+			Icon:        n.Manifest.Nebula.Icon,
+			Color:       n.Manifest.Nebula.ColorCode(),
 			Path:        dirPath,
 			Phases:      len(n.Phases),
 		}
@@ -73,6 +79,7 @@ func DiscoverNebulae(currentDir string) ([]NebulaChoice, error) {
 			choice.Status = "ready"
 		} else {
 			choice.Status, choice.Done = classifyNebulaStatus(n, state)
+			choice.Failed, choice.Stuck = countTroubledPhases(state)
 		}
 
 		choices = append(choices, choice)
@@ -106,6 +113,8 @@ func DiscoverAllNebulae(nebulaeDir string) ([]NebulaChoice, error) {
 		choice := NebulaChoice{
 			Name:        n.Manifest.Nebula.Name,
 			Description: n.Manifest.Nebula.Description,
+			Icon:        n.Manifest.Nebula.Icon,
+			Color:       n.Manifest.Nebula.ColorCode(),
 			Path:        dirPath,
 			Phases:      len(n.Phases),
 		}
@@ -119,6 +128,7 @@ func DiscoverAllNebulae(nebulaeDir string) ([]NebulaChoice, error) {
 			choice.Status = "ready"
 		} else {
 			choice.Status, choice.Done = classifyNebulaStatus(n, state)
+			choice.Failed, choice.Stuck = countTroubledPhases(state)
 		}
 
 		choices = append(choices, choice)
@@ -155,3 +165,18 @@ func classifyNebulaStatus(n *nebula.Nebula, state *nebula.State) (status string,
 		return "ready", 0
 	}
 }
+
+// countTroubledPhases counts the phases in state that failed and those
+// still recorded as in progress. Outside a run, an in-progress phase is one
+// an interrupted run never finished.
+func countTroubledPhases(state *nebula.State) (failed, stuck int) {
+	for _, ps := range state.Phases {
+		switch ps.Status {
+		case nebula.PhaseStatusFailed:
+			failed++
+		case nebula.PhaseStatusInProgress:
+			stuck++
+		}
+	}
+	return failed, stuck
+}
@@ -357,6 +357,43 @@ updated_at = 2024-01-01T00:00:00Z
 	}
 }
 
+func TestDiscoverAllNebulae_CountsTroubledPhases(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "broken")
+	createTestNebula(t, dir, "Broken", 3)
+	writeTestState(t, dir, `version = 1
+nebula_name = "Broken"
+[phases.phase-1]
+bead_id = "beads-a"
+status = "failed"
+created_at = 2024-01-01T00:00:00Z
+updated_at = 2024-01-01T00:00:00Z
+[phases.phase-2]
+bead_id = "beads-b"
+status = "in_progress"
+created_at = 2024-01-01T00:00:00Z
+updated_at = 2024-01-01T00:00:00Z
+[phases.phase-3]
+bead_id = "beads-c"
+status = "done"
+created_at = 2024-01-01T00:00:00Z
+updated_at = 2024-01-01T00:00:00Z
+`)
+
+	choices, err := DiscoverAllNebulae(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d", len(choices))
+	}
+	if choices[0].Failed != 1 || choices[0].Stuck != 1 {
+		t.Errorf("expected 1 failed and 1 stuck, got %d and %d", choices[0].Failed, choices[0].Stuck)
+	}
+}
+
 func TestDiscoverAllNebulae_FallbackName(t *testing.T) {
 	t.Parallel()
 
@@ -405,6 +442,29 @@ func TestDiscoverNebulae_PopulatesDescription(t *testing.T) {
 	}
 }
 
+func TestDiscoverAllNebulae_PopulatesIdentity(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "rocket")
+	createTestNebula(t, dir, "Rocket", 1)
+	manifest := "[nebula]\nname = \"Rocket\"\nicon = \"🚀\"\ncolor = \"Orange\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "nebula.toml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	choices, err := DiscoverAllNebulae(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d", len(choices))
+	}
+	if choices[0].Icon != "🚀" || choices[0].Color != "208" {
+		t.Errorf("icon, color = %q, %q; want 🚀, 208", choices[0].Icon, choices[0].Color)
+	}
+}
+
 // createTestNebula creates a minimal nebula directory with a manifest and phase files.
 func createTestNebula(t *testing.T, dir, name string, phaseCount int) {
 	t.Helper()
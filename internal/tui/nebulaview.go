@@ -21,25 +21,6 @@ const (
 	PhaseSkipped
 )
 
-// PhaseStatusFromString maps a nebula state status string to a TUI PhaseStatus.
-// This is used when initializing the TUI from saved state.
-func PhaseStatusFromString(s string) PhaseStatus {
-	switch s {
-	case "done":
-		return PhaseDone
-	case "failed":
-		return PhaseFailed
-	case "in_progress":
-		return PhaseWorking
-	case "skipped":
-		return PhaseSkipped
-	case "gate":
-		return PhaseGate
-	default:
-		return PhaseWaiting
-	}
-}
-
 // PhaseEntry represents one phase in the nebula view.
 type PhaseEntry struct {
 	ID          string
@@ -54,7 +35,11 @@ type PhaseEntry struct {
 	StartedAt   time.Time
 	CompletedAt time.Time // set when phase reaches a terminal state
 	PlanBody    string    // markdown content from the phase file
+	SourceFile  string    // path to the phase's markdown file (empty = not editable)
 	Refactored  bool      // true when a mid-run refactor was applied this cycle
+	SkipReason  string    // why the phase was skipped; empty unless Status is PhaseSkipped
+	Manual      bool      // completed by a human rather than an agent
+	Preserved   string    // where a failed phase's leftover work was kept; empty if it was not
 }
 
 // NebulaView renders the phase table for multi-task orchestration.
@@ -63,6 +48,9 @@ type NebulaView struct {
 	Cursor  int
 	Spinner spinner.Model
 	Width   int
+	Filter  *PhaseStatus // when set, only phases with this status are listed
+
+	HideCompleted bool // when set, done and skipped phases are not listed
 }
 
 // NewNebulaView creates an empty nebula view.
@@ -81,21 +69,23 @@ func (nv NebulaView) SelectedPhase() *PhaseEntry {
 	return &nv.Phases[nv.Cursor]
 }
 
-// MoveUp moves the cursor up.
+// MoveUp moves the cursor up to the previous listed phase.
 func (nv *NebulaView) MoveUp() {
-	if nv.Cursor > 0 {
-		nv.Cursor--
+	for i := nv.Cursor - 1; i >= 0; i-- {
+		if nv.listed(i) {
+			nv.Cursor = i
+			return
+		}
 	}
 }
 
-// MoveDown moves the cursor down.
+// MoveDown moves the cursor down to the next listed phase.
 func (nv *NebulaView) MoveDown() {
-	max := len(nv.Phases) - 1
-	if max < 0 {
-		max = 0
-	}
-	if nv.Cursor < max {
-		nv.Cursor++
+	for i := nv.Cursor + 1; i < len(nv.Phases); i++ {
+		if nv.listed(i) {
+			nv.Cursor = i
+			return
+		}
 	}
 }
 
@@ -117,12 +107,15 @@ func (nv *NebulaView) InitPhases(phases []PhaseInfo) {
 			status = PhaseWaiting
 		}
 		nv.Phases[i] = PhaseEntry{
-			ID:        p.ID,
-			Title:     p.Title,
-			Status:    status,
-			BlockedBy: blocked,
-			DependsOn: p.DependsOn,
-			PlanBody:  p.PlanBody,
+			ID:         p.ID,
+			Title:      p.Title,
+			Status:     status,
+			BlockedBy:  blocked,
+			DependsOn:  p.DependsOn,
+			PlanBody:   p.PlanBody,
+			SourceFile: p.SourceFile,
+			SkipReason: p.SkipReason,
+			Manual:     p.Manual,
 		}
 	}
 	// Recalculate blocked-by so phases with completed deps show correctly.
@@ -145,6 +138,7 @@ func (nv *NebulaView) AppendPhase(info PhaseInfo) {
 		BlockedBy: blocked,
 		DependsOn: info.DependsOn,
 		PlanBody:  info.PlanBody,
+		Manual:    info.Manual,
 	})
 }
 
@@ -162,6 +156,9 @@ func (nv *NebulaView) SetPhaseStatus(phaseID string, status PhaseStatus) {
 				nv.Phases[i].CompletedAt = time.Now()
 			}
 			nv.Phases[i].Status = status
+			if status != PhaseSkipped {
+				nv.Phases[i].SkipReason = ""
+			}
 			break
 		}
 	}
@@ -170,6 +167,8 @@ func (nv *NebulaView) SetPhaseStatus(phaseID string, status PhaseStatus) {
 	if status == PhaseDone || status == PhaseFailed || status == PhaseSkipped {
 		nv.refreshBlockedBy()
 	}
+	// The phase may have just left the listed set.
+	nv.SettleCursor()
 }
 
 // refreshBlockedBy recalculates BlockedBy for all phases based on
@@ -200,6 +199,16 @@ func (nv *NebulaView) refreshBlockedBy() {
 	}
 }
 
+// SetSkipReason records why a skipped phase never ran.
+func (nv *NebulaView) SetSkipReason(phaseID, reason string) {
+	for i := range nv.Phases {
+		if nv.Phases[i].ID == phaseID {
+			nv.Phases[i].SkipReason = reason
+			return
+		}
+	}
+}
+
 // SetPhaseCost updates the cost of a phase by ID.
 func (nv *NebulaView) SetPhaseCost(phaseID string, cost float64) {
 	for i := range nv.Phases {
@@ -234,8 +243,15 @@ func (nv *NebulaView) SetPhaseRefactored(phaseID string, refactored bool) {
 // View renders the phase table with wave separators and aligned columns.
 func (nv NebulaView) View() string {
 	var b strings.Builder
+	if nv.Filter != nil || nv.HideCompleted {
+		b.WriteString(nv.renderFilterHeader())
+		b.WriteString("\n")
+	}
 	lastWave := -1
 	for i, p := range nv.Phases {
+		if !nv.listed(i) {
+			continue
+		}
 		// Wave separator when wave changes.
 		if p.Wave > 0 && p.Wave != lastWave {
 			if i > 0 {
@@ -316,12 +332,18 @@ func (nv NebulaView) phaseIconAndStyle(p PhaseEntry) (string, lipgloss.Style) {
 	case PhaseSkipped:
 		return styleRowWaiting.Render(iconSkipped), styleRowWaiting
 	default:
+		if p.Manual {
+			return styleRowWaiting.Render(iconManual), styleRowWaiting
+		}
 		return styleRowWaiting.Render(iconWaiting), styleRowWaiting
 	}
 }
 
 // phaseDetail builds the detail text for a phase row.
 func (nv NebulaView) phaseDetail(p PhaseEntry) string {
+	if p.Manual {
+		return manualPhaseDetail(p)
+	}
 	switch p.Status {
 	case PhaseDone:
 		elapsed := formatDuration(p.StartedAt, p.CompletedAt)
@@ -349,6 +371,11 @@ func (nv NebulaView) phaseDetail(p PhaseEntry) string {
 		}
 		parts = append(parts, nv.Spinner.View())
 		return strings.Join(parts, "  ")
+	case PhaseSkipped:
+		if p.SkipReason != "" {
+			return "skipped: " + p.SkipReason
+		}
+		return ""
 	default:
 		if p.BlockedBy != "" {
 			return fmt.Sprintf("blocked: %s", p.BlockedBy)
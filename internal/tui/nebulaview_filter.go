@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PhaseStatusFromString maps a nebula state status string to a TUI PhaseStatus.
+// This is used when initializing the TUI from saved state.
+func PhaseStatusFromString(s string) PhaseStatus {
+	switch s {
+	case "done":
+		return PhaseDone
+	case "failed":
+		return PhaseFailed
+	case "in_progress":
+		return PhaseWorking
+	case "skipped":
+		return PhaseSkipped
+	case "gate":
+		return PhaseGate
+	default:
+		return PhaseWaiting
+	}
+}
+
+// phaseStatusNames maps each phase status to the name the command palette
+// uses for it.
+var phaseStatusNames = map[PhaseStatus]string{
+	PhaseWaiting: "waiting",
+	PhaseWorking: "working",
+	PhaseDone:    "done",
+	PhaseFailed:  "failed",
+	PhaseGate:    "gate",
+	PhaseSkipped: "skipped",
+}
+
+// PhaseStatusNames returns the palette names of every phase status, in
+// display order.
+func PhaseStatusNames() []string {
+	names := make([]string, 0, len(phaseStatusNames))
+	for s := PhaseWaiting; s <= PhaseSkipped; s++ {
+		names = append(names, phaseStatusNames[s])
+	}
+	return names
+}
+
+// ParsePhaseStatus returns the status with the given palette name.
+func ParsePhaseStatus(name string) (PhaseStatus, bool) {
+	for s, n := range phaseStatusNames {
+		if n == name {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// SetFilter lists only phases with the given status, or every phase when
+// status is nil, and moves the cursor onto a listed phase.
+func (nv *NebulaView) SetFilter(status *PhaseStatus) {
+	nv.Filter = status
+	nv.SettleCursor()
+}
+
+// ToggleHideCompleted hides done and skipped phases, or lists them again,
+// and moves the cursor onto a listed phase.
+func (nv *NebulaView) ToggleHideCompleted() {
+	nv.HideCompleted = !nv.HideCompleted
+	nv.SettleCursor()
+}
+
+// SettleCursor moves the cursor onto the nearest listed phase at or after
+// it, or before it when none follows. It is left alone when no phase is
+// listed.
+func (nv *NebulaView) SettleCursor() {
+	if nv.listed(nv.Cursor) {
+		return
+	}
+	for i := max(nv.Cursor, 0); i < len(nv.Phases); i++ {
+		if nv.listed(i) {
+			nv.Cursor = i
+			return
+		}
+	}
+	for i := min(nv.Cursor, len(nv.Phases)) - 1; i >= 0; i-- {
+		if nv.listed(i) {
+			nv.Cursor = i
+			return
+		}
+	}
+}
+
+// listed reports whether the phase at index i passes the filter and is not
+// hidden as completed.
+func (nv NebulaView) listed(i int) bool {
+	if i < 0 || i >= len(nv.Phases) {
+		return false
+	}
+	status := nv.Phases[i].Status
+	if nv.HideCompleted && isCompleted(status) {
+		return false
+	}
+	return nv.Filter == nil || status == *nv.Filter
+}
+
+// isCompleted reports whether status is one HideCompleted takes off the
+// phase table and board.
+func isCompleted(status PhaseStatus) bool {
+	return status == PhaseDone || status == PhaseSkipped
+}
+
+// renderFilterHeader renders the line naming the active filter and how many
+// phases pass it, and how many completed phases are hidden.
+func (nv NebulaView) renderFilterHeader() string {
+	n, hidden := 0, 0
+	for i, p := range nv.Phases {
+		if nv.listed(i) {
+			n++
+		}
+		if nv.HideCompleted && isCompleted(p.Status) {
+			hidden++
+		}
+	}
+	var parts []string
+	if nv.Filter != nil {
+		parts = append(parts, fmt.Sprintf("filter: %s · %d of %d phases", phaseStatusNames[*nv.Filter], n, len(nv.Phases)))
+	}
+	if nv.HideCompleted {
+		parts = append(parts, fmt.Sprintf("%d completed hidden", hidden))
+	}
+	return "  " + styleDetailDim.Render(strings.Join(parts, " · "))
+}
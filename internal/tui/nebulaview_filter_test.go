@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNebulaViewHideCompleted(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		cursor     int
+		wantCursor int
+		wantListed []string
+	}{
+		{"cursor on listed phase stays", 1, 1, []string{"b", "d"}},
+		{"cursor on done phase moves forward", 2, 3, []string{"b", "d"}},
+		{"cursor on last hidden phase moves back", 4, 3, []string{"b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			nv := NewNebulaView()
+			nv.Phases = []PhaseEntry{
+				{ID: "a", Status: PhaseDone},
+				{ID: "b", Status: PhaseWorking},
+				{ID: "c", Status: PhaseSkipped},
+				{ID: "d", Status: PhaseFailed},
+				{ID: "e", Status: PhaseDone},
+			}
+			nv.Cursor = tt.cursor
+
+			nv.ToggleHideCompleted()
+
+			if nv.Cursor != tt.wantCursor {
+				t.Errorf("Cursor = %d, want %d", nv.Cursor, tt.wantCursor)
+			}
+			var listed []string
+			for i, p := range nv.Phases {
+				if nv.listed(i) {
+					listed = append(listed, p.ID)
+				}
+			}
+			if strings.Join(listed, ",") != strings.Join(tt.wantListed, ",") {
+				t.Errorf("listed = %v, want %v", listed, tt.wantListed)
+			}
+			view := nv.View()
+			if !strings.Contains(view, "3 completed hidden") {
+				t.Errorf("view missing hidden count:\n%s", view)
+			}
+
+			nv.ToggleHideCompleted()
+			if strings.Contains(nv.View(), "completed hidden") {
+				t.Error("hidden count still shown after toggling back")
+			}
+		})
+	}
+}
+
+func TestNebulaViewHideCompletedWithFilter(t *testing.T) {
+	t.Parallel()
+	nv := NewNebulaView()
+	nv.Phases = []PhaseEntry{
+		{ID: "a", Status: PhaseDone},
+		{ID: "b", Status: PhaseFailed},
+		{ID: "c", Status: PhaseFailed},
+	}
+	failed := PhaseFailed
+	nv.SetFilter(&failed)
+	nv.ToggleHideCompleted()
+
+	view := nv.View()
+	for _, want := range []string{"filter: failed · 2 of 3 phases", "1 completed hidden"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestHideCompletedKeyPersistsAcrossResize(t *testing.T) {
+	t.Parallel()
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}}})
+	tm, _ = tm.Update(MsgPhaseTaskComplete{PhaseID: "a"})
+
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	got := tm.(AppModel)
+
+	if !got.NebulaView.HideCompleted || !got.Board.HideCompleted {
+		t.Fatalf("HideCompleted = %v/%v after resize, want true on table and board",
+			got.NebulaView.HideCompleted, got.Board.HideCompleted)
+	}
+	if got.NebulaView.Cursor != 1 {
+		t.Errorf("NebulaView.Cursor = %d, want 1", got.NebulaView.Cursor)
+	}
+}
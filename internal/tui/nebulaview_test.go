@@ -136,6 +136,25 @@ func TestNebulaViewView_BlockedDetail(t *testing.T) {
 	}
 }
 
+func TestNebulaViewView_SkipReason(t *testing.T) {
+	t.Parallel()
+	nv := NewNebulaView()
+	nv.InitPhases([]PhaseInfo{
+		{ID: "auth", Status: PhaseFailed},
+		{ID: "tests", Status: PhaseSkipped, DependsOn: []string{"auth"}, SkipReason: `dependency "auth" failed`},
+	})
+	nv.Width = 80
+
+	if view := nv.View(); !strings.Contains(view, `skipped: dependency "auth" failed`) {
+		t.Errorf("expected skip reason in view, got:\n%s", view)
+	}
+
+	nv.SetPhaseStatus("tests", PhaseWorking)
+	if nv.Phases[1].SkipReason != "" {
+		t.Errorf("SkipReason = %q after retry, want it cleared", nv.Phases[1].SkipReason)
+	}
+}
+
 func TestNebulaViewView_SelectionIndicator(t *testing.T) {
 	t.Parallel()
 	nv := NewNebulaView()
@@ -0,0 +1,24 @@
+package tui
+
+import "github.com/charmbracelet/bubbles/key"
+
+// handleExpandKey toggles full rendering of long agent output at
+// DepthAgentOutput. The full text is always kept on the AgentEntry, so
+// expanding only re-renders the detail panel.
+func (m *AppModel) handleExpandKey() {
+	if m.Depth != DepthAgentOutput || m.ShowDiff {
+		return
+	}
+	m.ExpandOutput = !m.ExpandOutput
+	m.updateDetailFromSelection()
+}
+
+// expandBinding returns the expand key binding with help text reflecting
+// the current state.
+func (m AppModel) expandBinding() key.Binding {
+	b := m.Keys.Expand
+	if m.ExpandOutput {
+		b.SetHelp("X", "collapse")
+	}
+	return b
+}
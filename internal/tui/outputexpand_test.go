@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExpandKeyTogglesFullOutput(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeLoop)
+	m.Splash = nil
+	var tm tea.Model = m
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	tm, _ = tm.Update(MsgCycleStart{Cycle: 1, MaxCycles: 3})
+	tm, _ = tm.Update(MsgAgentStart{Role: "coder"})
+	tm, _ = tm.Update(MsgAgentOutput{Role: "coder", Cycle: 1, Output: numberedLines(outputHeadLines + outputTailLines + 500)})
+	tm, _ = tm.Update(MsgAgentDone{Role: "coder"})
+	m = tm.(AppModel)
+	m.LoopView.Cursor = 1 // the coder entry under the cycle header
+	m.Depth = DepthAgentOutput
+	m.updateDetailFromSelection()
+	collapsed := m.Detail.totalLines
+
+	keyExpand := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")}
+	m = pressKey(t, m, keyExpand)
+	if !m.ExpandOutput {
+		t.Fatal("expected ExpandOutput after pressing X")
+	}
+	if expanded := m.Detail.totalLines; expanded != collapsed+499 {
+		t.Errorf("expanded output has %d lines, collapsed %d; want 499 more (500 hidden lines minus the marker)", expanded, collapsed)
+	}
+	if got := m.expandBinding().Help().Desc; got != "collapse" {
+		t.Errorf("footer help = %q, want collapse", got)
+	}
+
+	m = pressKey(t, m, keyExpand)
+	if m.ExpandOutput || m.Detail.totalLines != collapsed {
+		t.Errorf("second X should collapse: ExpandOutput=%v lines=%d want %d", m.ExpandOutput, m.Detail.totalLines, collapsed)
+	}
+
+	// x keeps its reject meaning here; it does not expand output.
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.ExpandOutput {
+		t.Error("x should not expand output")
+	}
+
+	m.Depth = DepthPhases
+	m = pressKey(t, m, keyExpand)
+	if m.ExpandOutput {
+		t.Error("X should do nothing outside agent output")
+	}
+}
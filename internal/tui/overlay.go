@@ -24,8 +24,16 @@ type CompletionOverlay struct {
 	DoneCount    int
 	FailedCount  int
 	SkippedCount int
+	// Artifacts collected from successful phases, as "phaseID: path".
+	Artifacts []string
 	// Post-completion git workflow status (push/checkout results).
 	GitResult *nebula.PostCompletionResult
+	// Run details kept for the markdown summary (see SaveSummary).
+	NebulaName string
+	Phases     []PhaseEntry
+	Results    []nebula.WorkerResult
+	SummaryDir string // directory the summary is saved to; "" = saving unavailable
+	SavedPath  string // where the summary was last saved; "" = not saved
 	// Nebula picker state.
 	NebulaChoices []NebulaChoice
 	PickerCursor  int
@@ -43,6 +51,8 @@ const (
 	CompletionBudgetExceeded
 	// CompletionError indicates the task ended with an error.
 	CompletionError
+	// CompletionCanceled indicates the run was canceled before it finished.
+	CompletionCanceled
 )
 
 // View renders the completion overlay as a centered box.
@@ -75,12 +85,27 @@ func (o *CompletionOverlay) View(width, height int) string {
 		b.WriteString("\n")
 	}
 
+	if failures := o.renderFailures(); failures != "" {
+		b.WriteString(failures)
+		b.WriteString("\n")
+	}
+
+	if len(o.Artifacts) > 0 {
+		b.WriteString(o.renderArtifacts())
+		b.WriteString("\n")
+	}
+
 	// Git post-completion status.
 	if o.GitResult != nil {
 		b.WriteString(o.renderGitStatus())
 		b.WriteString("\n")
 	}
 
+	if o.SavedPath != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSuccess).Render("✓ Summary saved to " + o.SavedPath))
+		b.WriteString("\n")
+	}
+
 	// Nebula picker (if available).
 	if len(o.NebulaChoices) > 0 {
 		b.WriteString("\n")
@@ -92,11 +117,14 @@ func (o *CompletionOverlay) View(width, height int) string {
 
 	// Exit hint.
 	b.WriteString("\n")
+	hint := "esc:home  q:quit"
 	if len(o.NebulaChoices) > 0 {
-		b.WriteString(styleOverlayHint.Render("esc:home  enter:launch  q:quit"))
-	} else {
-		b.WriteString(styleOverlayHint.Render("esc:home  q:quit"))
+		hint = "esc:home  enter:launch  q:quit"
 	}
+	if o.SummaryDir != "" {
+		hint = "s:save summary  " + hint
+	}
+	b.WriteString(styleOverlayHint.Render(hint))
 
 	// Render the box.
 	boxContent := style.Render(b.String())
@@ -116,6 +144,8 @@ func (o *CompletionOverlay) styling() (icon string, title string, style lipgloss
 		return "✗", "Budget exceeded", styleOverlayError
 	case CompletionError:
 		return "✗", "Error", styleOverlayError
+	case CompletionCanceled:
+		return "⊘", "Canceled", styleOverlayWarning
 	default:
 		return "·", "Done", styleOverlaySuccess
 	}
@@ -151,6 +181,22 @@ func (o *CompletionOverlay) renderStats() string {
 	return styleDetailDim.Render(strings.Join(parts, "  "))
 }
 
+// maxOverlayArtifacts caps the artifact lines shown in the completion overlay.
+const maxOverlayArtifacts = 6
+
+// renderArtifacts lists the collected artifacts, eliding past maxOverlayArtifacts.
+func (o *CompletionOverlay) renderArtifacts() string {
+	lines := []string{styleOverlayHint.Render("Artifacts:")}
+	for i, a := range o.Artifacts {
+		if i == maxOverlayArtifacts {
+			lines = append(lines, styleDetailDim.Render(fmt.Sprintf("  … and %d more", len(o.Artifacts)-i)))
+			break
+		}
+		lines = append(lines, "  "+a)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderGitStatus renders the post-completion git push/checkout results.
 func (o *CompletionOverlay) renderGitStatus() string {
 	r := o.GitResult
@@ -218,9 +264,18 @@ func NewCompletionFromNebulaDone(msg MsgNebulaDone, duration time.Duration, cost
 	}
 
 	// Count results by outcome.
+	o.Results = msg.Results
 	o.DoneCount, o.FailedCount, o.SkippedCount = buildNebulaResultCounts(msg.Results, totalPhases)
+	for _, r := range msg.Results {
+		for _, a := range r.Artifacts {
+			o.Artifacts = append(o.Artifacts, r.PhaseID+": "+a)
+		}
+	}
 
-	if msg.Err != nil {
+	if msg.Canceled {
+		o.Kind = CompletionCanceled
+		o.Message = "Interrupted phases are left to run again on the next apply."
+	} else if msg.Err != nil {
 		o.Kind = CompletionError
 		o.Message = msg.Err.Error()
 	} else if o.FailedCount > 0 {
@@ -268,30 +323,16 @@ func (o *CompletionOverlay) renderNebulaPicker() string {
 	return b.String()
 }
 
-// centerOverlay places content in the center of the given dimensions.
-func centerOverlay(content string, width, height int) string {
+// centerOverlay centers content horizontally within width. Vertical
+// placement is left to compositeOverlay, which centers the overlay over the
+// background; padding vertically here as well would push it below center.
+func centerOverlay(content string, width, _ int) string {
 	contentWidth := lipgloss.Width(content)
-	contentHeight := lipgloss.Height(content)
-
-	if width <= 0 || height <= 0 {
+	if width <= 0 || contentWidth >= width {
 		return content
 	}
-
-	// Horizontal centering.
-	leftPad := 0
-	if contentWidth < width {
-		leftPad = (width - contentWidth) / 2
-	}
-
-	// Vertical centering.
-	topPad := 0
-	if contentHeight < height {
-		topPad = (height - contentHeight) / 2
-	}
-
 	return lipgloss.NewStyle().
-		PaddingLeft(leftPad).
-		PaddingTop(topPad).
+		PaddingLeft((width - contentWidth) / 2).
 		Render(content)
 }
 
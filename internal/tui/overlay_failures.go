@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// Limits on the failure section of the completion overlay; the saved
+// summary and the terminal output carry the rest.
+const (
+	maxOverlayFailures  = 4  // failed phases listed
+	maxOverlayFindings  = 2  // reviewer findings listed per failed phase
+	overlayFailureWidth = 72 // width long lines are truncated to
+)
+
+// renderFailures lists each failed phase with its error, the reviewer's
+// last findings, and how many dependents it held back, followed by the
+// phases to retry. It returns "" when no phase failed.
+func (o *CompletionOverlay) renderFailures() string {
+	var failed []nebula.WorkerResult
+	for _, r := range o.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+
+	danger := lipgloss.NewStyle().Foreground(colorDanger)
+	lines := []string{styleOverlayHint.Render("Failures:")}
+	for i, r := range failed {
+		if i == maxOverlayFailures {
+			lines = append(lines, styleDetailDim.Render(fmt.Sprintf("  … and %d more", len(failed)-i)))
+			break
+		}
+		lines = append(lines, danger.Render(truncateToWidth(fmt.Sprintf("  %s %s: %s", iconFailed, r.PhaseID, oneLine(r.Err.Error())), overlayFailureWidth)))
+		for j, f := range r.Findings {
+			if j == maxOverlayFindings {
+				lines = append(lines, styleDetailDim.Render(fmt.Sprintf("      … %d more findings", len(r.Findings)-j)))
+				break
+			}
+			lines = append(lines, styleDetailDim.Render(truncateToWidth(fmt.Sprintf("      [%s] %s", f.Severity, oneLine(f.Description)), overlayFailureWidth)))
+		}
+		if held := heldBack(r); held != "" {
+			lines = append(lines, styleDetailDim.Render("      "+held))
+		}
+	}
+	if retry := nebula.RetryCandidates(o.Results); len(retry) > 0 {
+		lines = append(lines, truncateToWidth("Next: fix and re-run nebula apply to retry "+strings.Join(retry, ", "), overlayFailureWidth))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// heldBack describes the dependents a failed phase kept from running, e.g.
+// "blocked 2 dependents, skipped 1".
+func heldBack(r nebula.WorkerResult) string {
+	var parts []string
+	if n := len(r.Blocked); n > 0 {
+		parts = append(parts, fmt.Sprintf("blocked %d dependent%s", n, pluralS(n)))
+	}
+	if n := len(r.Skipped); n > 0 {
+		parts = append(parts, fmt.Sprintf("skipped %d dependent%s", n, pluralS(n)))
+	}
+	return strings.Join(parts, ", ")
+}
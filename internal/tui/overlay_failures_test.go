@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestCompletionOverlayFailures(t *testing.T) {
+	t.Parallel()
+
+	o := NewCompletionFromNebulaDone(MsgNebulaDone{Results: []nebula.WorkerResult{
+		{PhaseID: "api", Err: errors.New("max cycles reached"),
+			Findings: []nebula.DecomposeFinding{
+				{Severity: "major", Description: "handler leaks connections", Cycle: 5},
+				{Severity: "minor", Description: "missing test", Cycle: 5},
+				{Severity: "minor", Description: "typo", Cycle: 5},
+			},
+			Blocked: []string{"cli", "ui"},
+		},
+		{PhaseID: "docs", Err: fmt.Errorf("%w: spent", nebula.ErrRetryBudgetExhausted), Skipped: []string{"site"}},
+		{PhaseID: "seed"},
+	}}, 0, 0, 6)
+
+	view := o.View(120, 60)
+	for _, want := range []string{
+		"Failures:",
+		"api: max cycles reached",
+		"[major] handler leaks connections",
+		"… 1 more findings",
+		"blocked 2 dependents",
+		"docs: retry budget exhausted",
+		"skipped 1 dependent",
+		"retry api",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "retry api, docs") {
+		t.Errorf("suggested retrying a phase with no retry budget left:\n%s", view)
+	}
+
+	if md := o.SummaryMarkdown(time.Now()); !strings.Contains(md, "- `api`: max cycles reached (blocked 2 dependents)") {
+		t.Errorf("summary does not count the held-back dependents:\n%s", md)
+	}
+}
+
+func TestCompletionOverlayNoFailures(t *testing.T) {
+	t.Parallel()
+
+	o := NewCompletionFromNebulaDone(MsgNebulaDone{Results: []nebula.WorkerResult{{PhaseID: "a"}}}, 0, 0, 1)
+	if got := o.renderFailures(); got != "" {
+		t.Errorf("renderFailures = %q for a clean run", got)
+	}
+}
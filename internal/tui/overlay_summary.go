@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// SummaryMarkdown renders the completed run as a markdown report: outcome,
+// totals, a table of phases, failures, artifacts, and git results.
+func (o *CompletionOverlay) SummaryMarkdown(finished time.Time) string {
+	_, title, _ := o.styling()
+	results := make(map[string]nebula.WorkerResult, len(o.Results))
+	for _, r := range o.Results {
+		results[r.PhaseID] = r
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Nebula run: %s\n\n", o.NebulaName)
+	fmt.Fprintf(&b, "- Outcome: %s\n", title)
+	fmt.Fprintf(&b, "- Finished: %s\n", finished.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "- Elapsed: %s\n", o.Duration.Truncate(time.Second))
+	fmt.Fprintf(&b, "- Total cost: $%.2f\n", o.CostUSD)
+	fmt.Fprintf(&b, "- Phases: %d done, %d failed, %d skipped\n", o.DoneCount, o.FailedCount, o.SkippedCount)
+
+	if len(o.Phases) > 0 {
+		b.WriteString("\n## Phases\n\n")
+		b.WriteString("| Phase | Status | Cost | Cycles | Reviewer satisfaction |\n")
+		b.WriteString("|-------|--------|------|--------|-----------------------|\n")
+		for _, p := range o.Phases {
+			r := results[p.ID]
+			status := phaseStatusNames[p.Status]
+			if r.Cached {
+				status += " (cached)"
+			}
+			if r.TimeoutAction != "" {
+				status += " (timed out: " + string(r.TimeoutAction) + ")"
+			}
+			cycles := fmt.Sprintf("%d", p.Cycles)
+			if p.MaxCycles > 0 {
+				cycles = fmt.Sprintf("%d/%d", p.Cycles, p.MaxCycles)
+			}
+			satisfaction := "-"
+			if r.Report != nil && r.Report.Satisfaction != "" {
+				satisfaction = r.Report.Satisfaction
+			}
+			fmt.Fprintf(&b, "| %s | %s | $%.2f | %s | %s |\n", p.ID, status, p.CostUSD, cycles, satisfaction)
+		}
+	}
+
+	var failures []string
+	if o.Message != "" {
+		failures = append(failures, "- Run: "+oneLine(o.Message))
+	}
+	for _, r := range o.Results {
+		if r.Err != nil {
+			line := fmt.Sprintf("- `%s`: %s", r.PhaseID, oneLine(r.Err.Error()))
+			if held := heldBack(r); held != "" {
+				line += " (" + held + ")"
+			}
+			failures = append(failures, line)
+		}
+	}
+	for _, p := range o.Phases {
+		if p.Status == PhaseSkipped && p.SkipReason != "" {
+			failures = append(failures, fmt.Sprintf("- `%s` skipped: %s", p.ID, oneLine(p.SkipReason)))
+		}
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n## Failures\n\n")
+		b.WriteString(strings.Join(failures, "\n"))
+		b.WriteString("\n")
+	}
+
+	if len(o.Artifacts) > 0 {
+		b.WriteString("\n## Artifacts\n\n")
+		for _, a := range o.Artifacts {
+			fmt.Fprintf(&b, "- %s\n", a)
+		}
+	}
+
+	if r := o.GitResult; r != nil {
+		b.WriteString("\n## Git\n\n")
+		if r.CommitErr != nil {
+			fmt.Fprintf(&b, "- Commit failed: %s\n", oneLine(r.CommitErr.Error()))
+		}
+		if r.PushErr != nil {
+			fmt.Fprintf(&b, "- Push failed: %s\n", oneLine(r.PushErr.Error()))
+		} else {
+			fmt.Fprintf(&b, "- Pushed to origin/%s\n", r.PushBranch)
+		}
+		if r.CheckoutErr != nil {
+			fmt.Fprintf(&b, "- Checkout failed: %s\n", oneLine(r.CheckoutErr.Error()))
+		}
+	}
+	return b.String()
+}
+
+// SaveSummary writes SummaryMarkdown to SummaryDir as
+// summary-<nebula>-<timestamp>.md and returns the file's path.
+func (o *CompletionOverlay) SaveSummary(finished time.Time) (string, error) {
+	if o.SummaryDir == "" {
+		return "", errors.New("no directory to save the run summary to")
+	}
+	if err := os.MkdirAll(o.SummaryDir, 0755); err != nil {
+		return "", fmt.Errorf("creating summary directory: %w", err)
+	}
+	name := fmt.Sprintf("summary-%s-%s.md", fileSafe(o.NebulaName), finished.Format("20060102-150405"))
+	path := filepath.Join(o.SummaryDir, name)
+	if err := os.WriteFile(path, []byte(o.SummaryMarkdown(finished)), 0644); err != nil {
+		return "", fmt.Errorf("writing run summary: %w", err)
+	}
+	o.SavedPath = path
+	return path, nil
+}
+
+// oneLine collapses s onto a single line for a markdown list item.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// fileSafe replaces characters that are awkward in file names with '-'.
+func fileSafe(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(s))
+	if s == "" {
+		return "nebula"
+	}
+	return s
+}
+
+// saveCompletionSummary writes the completion overlay's run summary and
+// reports the outcome in a toast.
+func (m *AppModel) saveCompletionSummary() tea.Cmd {
+	if m.Overlay.SummaryDir == "" {
+		return nil
+	}
+	path, err := m.Overlay.SaveSummary(time.Now())
+	msg, isErr := "summary saved to "+path, false
+	if err != nil {
+		msg, isErr = err.Error(), true
+	}
+	toast, cmd := NewToast(msg, isErr)
+	m.Toasts = append(m.Toasts, toast)
+	return cmd
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/agent"
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestCompletionSummaryMarkdown(t *testing.T) {
+	t.Parallel()
+
+	o := NewCompletionFromNebulaDone(MsgNebulaDone{Results: []nebula.WorkerResult{
+		{PhaseID: "setup", Report: &agent.ReviewReport{Satisfaction: "high"}},
+		{PhaseID: "api", Err: errors.New("tests failed:\nTestLogin")},
+	}}, 95*time.Second, 3.5, 3)
+	o.NebulaName = "auth rework"
+	o.Phases = []PhaseEntry{
+		{ID: "setup", Status: PhaseDone, CostUSD: 1.25, Cycles: 1, MaxCycles: 3},
+		{ID: "api", Status: PhaseFailed, CostUSD: 2.25, Cycles: 3, MaxCycles: 3},
+		{ID: "docs", Status: PhaseSkipped, SkipReason: "dependency api failed"},
+	}
+
+	md := o.SummaryMarkdown(time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC))
+	for _, want := range []string{
+		"# Nebula run: auth rework",
+		"- Elapsed: 1m35s",
+		"- Total cost: $3.50",
+		"- Phases: 1 done, 1 failed, 1 skipped",
+		"| setup | done | $1.25 | 1/3 | high |",
+		"| api | failed | $2.25 | 3/3 | - |",
+		"- `api`: tests failed: TestLogin",
+		"- `docs` skipped: dependency api failed",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("summary missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestCompletionOverlaySaveSummaryKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := newNebulaModelWithPhases(dir, []PhaseEntry{{ID: "a", Status: PhaseDone}})
+	m.Splash = nil
+	m.StatusBar.Name = "my/nebula"
+	result, _ := m.Update(MsgNebulaDone{Results: []nebula.WorkerResult{{PhaseID: "a"}}})
+	got := result.(AppModel)
+
+	result, cmd := got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	got = result.(AppModel)
+	if cmd == nil || len(got.Toasts) != 1 || got.Toasts[0].IsError {
+		t.Fatalf("s did not show a confirmation toast: %+v", got.Toasts)
+	}
+	if got.Overlay == nil || got.Overlay.SavedPath == "" {
+		t.Fatal("overlay does not record the saved summary")
+	}
+	if filepath.Dir(got.Overlay.SavedPath) != filepath.Join(dir, "summaries") {
+		t.Errorf("summary saved to %s, want the nebula's summaries directory", got.Overlay.SavedPath)
+	}
+	name := filepath.Base(got.Overlay.SavedPath)
+	if !strings.HasPrefix(name, "summary-my-nebula-") || !strings.HasSuffix(name, ".md") {
+		t.Errorf("summary file name = %q", name)
+	}
+	data, err := os.ReadFile(got.Overlay.SavedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "| a | done |") {
+		t.Errorf("saved summary:\n%s", data)
+	}
+}
@@ -253,6 +253,23 @@ func TestNewCompletionFromNebulaDone(t *testing.T) {
 		}
 	})
 
+	t.Run("canceled run", func(t *testing.T) {
+		t.Parallel()
+		msg := MsgNebulaDone{
+			Err:      nebula.ErrCanceled,
+			Canceled: true,
+			Results:  []nebula.WorkerResult{{PhaseID: "a"}},
+		}
+		o := NewCompletionFromNebulaDone(msg, 10*time.Second, 2.0, 2)
+
+		if o.Kind != CompletionCanceled {
+			t.Errorf("expected CompletionCanceled, got %d", o.Kind)
+		}
+		if view := o.View(80, 30); !strings.Contains(view, "Canceled") || strings.Contains(view, "complete") {
+			t.Errorf("expected a Canceled title, got:\n%s", view)
+		}
+	})
+
 	t.Run("error when top-level error present", func(t *testing.T) {
 		t.Parallel()
 		msg := MsgNebulaDone{
@@ -291,6 +308,35 @@ func TestNewCompletionFromNebulaDone(t *testing.T) {
 			t.Errorf("expected SkippedCount=3, got %d", o.SkippedCount)
 		}
 	})
+
+	t.Run("lists collected artifacts", func(t *testing.T) {
+		t.Parallel()
+		msg := MsgNebulaDone{
+			Results: []nebula.WorkerResult{
+				{PhaseID: "a", Artifacts: []string{"artifacts/a/cover.out"}},
+				{PhaseID: "b"},
+			},
+		}
+		o := NewCompletionFromNebulaDone(msg, 10*time.Second, 2.0, 2)
+
+		if len(o.Artifacts) != 1 || o.Artifacts[0] != "a: artifacts/a/cover.out" {
+			t.Errorf("Artifacts = %v, want [a: artifacts/a/cover.out]", o.Artifacts)
+		}
+		if view := o.View(120, 40); !strings.Contains(view, "a: artifacts/a/cover.out") {
+			t.Error("overlay view does not list the artifact")
+		}
+	})
+
+	t.Run("elides long artifact lists", func(t *testing.T) {
+		t.Parallel()
+		o := &CompletionOverlay{Kind: CompletionSuccess}
+		for i := 0; i < maxOverlayArtifacts+3; i++ {
+			o.Artifacts = append(o.Artifacts, fmt.Sprintf("a: f%d", i))
+		}
+		if view := o.View(120, 40); !strings.Contains(view, "and 3 more") {
+			t.Error("overlay view does not elide extra artifacts")
+		}
+	})
 }
 
 // --- Nebula picker tests ---
@@ -1115,3 +1161,22 @@ func TestOverlayQuitKeyMatchesKeyMap(t *testing.T) {
 		t.Error("expected q to match Quit key binding")
 	}
 }
+
+func TestNebulaDoneCanceledResetsWorkingPhases(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "a"}, {ID: "b"}}})
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "a", BeadID: "b-1", Title: "A"})
+	tm, _ = tm.Update(MsgNebulaDone{Err: nebula.ErrCanceled, Canceled: true})
+	m = tm.(AppModel)
+
+	if got := m.NebulaView.Phases[0].Status; got != PhaseWaiting {
+		t.Errorf("interrupted phase status = %d, want waiting", got)
+	}
+	if m.Overlay == nil || m.Overlay.Kind != CompletionCanceled {
+		t.Errorf("overlay = %+v, want a canceled completion", m.Overlay)
+	}
+}
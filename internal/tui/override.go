@@ -0,0 +1,50 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// setOverride records the channel phaseID's running loop watches for a
+// reviewer override, replacing any left from an earlier attempt.
+func (m *AppModel) setOverride(phaseID string, ch chan<- struct{}) {
+	if m.overrideChs == nil {
+		m.overrideChs = make(map[string]chan<- struct{})
+	}
+	m.overrideChs[phaseID] = ch
+}
+
+// dropOverride forgets phaseID's override channel once its loop is done.
+func (m *AppModel) dropOverride(phaseID string) {
+	delete(m.overrideChs, phaseID)
+	if m.overrideArmed == phaseID {
+		m.overrideArmed = ""
+	}
+}
+
+// handleOverrideKey force-approves the focused phase over its reviewer. The
+// first press only arms the override; pressing the key again for the same
+// phase signals its loop, which approves and closes the phase's bead once
+// the agent now running finishes.
+func (m *AppModel) handleOverrideKey() tea.Cmd {
+	if m.Mode != ModeNebula || (m.Depth != DepthPhaseLoop && m.Depth != DepthAgentOutput) {
+		return nil
+	}
+	phaseID := m.FocusedPhase
+	ch, ok := m.overrideChs[phaseID]
+	if !ok {
+		return nil
+	}
+	if m.overrideArmed != phaseID {
+		m.overrideArmed = phaseID
+		toast, cmd := NewToast("press O again to approve ["+phaseID+"] over its reviewer", false)
+		m.Toasts = append(m.Toasts, toast)
+		return cmd
+	}
+	select {
+	case ch <- struct{}{}:
+	default: // already signaled
+	}
+	m.dropOverride(phaseID)
+	m.addMessage("[%s] reviewer overridden — approving after the current agent finishes", phaseID)
+	toast, cmd := NewToast("["+phaseID+"] reviewer overridden", false)
+	m.Toasts = append(m.Toasts, toast)
+	return cmd
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOverrideKeySignalsLoopOnSecondPress(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan struct{}, 1)
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	m.Detail = NewDetailPanel(80, 20)
+	var tm tea.Model = m
+	tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "auth", Title: "Auth"}}})
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "auth", BeadID: "b-1", Title: "Auth"})
+	tm, _ = tm.Update(MsgPhaseOverrideReady{PhaseID: "auth", Ch: ch})
+	m = tm.(AppModel)
+	m.FocusedPhase = "auth"
+	m.Depth = DepthPhaseLoop
+
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")}
+	tm, _ = m.Update(press)
+	if len(ch) != 0 {
+		t.Fatal("first press signaled the loop; it should only arm the override")
+	}
+	tm, _ = tm.Update(press)
+	m = tm.(AppModel)
+	if len(ch) != 1 {
+		t.Fatal("second press did not signal the loop")
+	}
+	if _, ok := m.overrideChs["auth"]; ok {
+		t.Error("override channel kept after signaling")
+	}
+}
+
+func TestOverrideKeyIgnoredWithoutRunningLoop(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan struct{}, 1)
+	m := NewAppModel(ModeNebula)
+	m.setOverride("auth", ch)
+	m.retireWorkerCard("auth", PhaseDone)
+	m.FocusedPhase = "auth"
+	m.Depth = DepthPhaseLoop
+	m.handleOverrideKey()
+	m.handleOverrideKey()
+	if len(ch) != 0 {
+		t.Error("override sent to a phase whose loop has finished")
+	}
+
+	// The key does nothing outside a phase's detail views.
+	m.setOverride("auth", ch)
+	m.Depth = DepthPhases
+	m.handleOverrideKey()
+	m.handleOverrideKey()
+	if len(ch) != 0 {
+		t.Error("override sent from the phase table")
+	}
+}
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteMaxMatches caps how many completions the palette lists at once.
+const paletteMaxMatches = 8
+
+// PaletteMatch is one completion the palette offers: a command, with its
+// argument filled in for commands that take one.
+type PaletteMatch struct {
+	Command PaletteCommand
+	Arg     string
+	Text    string // the full command line, e.g. "goto phase setup"
+	score   int
+}
+
+// CommandPalette is the ":" overlay for running commands by name with
+// fuzzy completion.
+type CommandPalette struct {
+	Input   textinput.Model
+	Matches []PaletteMatch
+	Cursor  int
+	Err     string // why the last Enter ran nothing
+
+	candidates []PaletteMatch
+}
+
+// NewCommandPalette creates a palette offering the given commands. args
+// supplies the argument completions of commands that take one.
+func NewCommandPalette(commands []PaletteCommand, args func(PaletteCommand) []string) *CommandPalette {
+	ti := textinput.New()
+	ti.Prompt = ": "
+	ti.Placeholder = "type a command"
+	ti.CharLimit = 128
+	ti.Focus()
+
+	p := &CommandPalette{Input: ti}
+	for _, c := range commands {
+		if c.Arg == "" {
+			p.candidates = append(p.candidates, PaletteMatch{Command: c, Text: c.Name})
+			continue
+		}
+		for _, a := range args(c) {
+			p.candidates = append(p.candidates, PaletteMatch{Command: c, Arg: a, Text: c.Name + " " + a})
+		}
+	}
+	p.refilter()
+	return p
+}
+
+// refilter recomputes the completions for the current input, best match
+// first. Ties keep registry order.
+func (p *CommandPalette) refilter() {
+	query := p.Input.Value()
+	p.Matches = p.Matches[:0]
+	for _, c := range p.candidates {
+		if score, ok := fuzzyScore(query, c.Text); ok {
+			c.score = score
+			p.Matches = append(p.Matches, c)
+		}
+	}
+	slices.SortStableFunc(p.Matches, func(a, b PaletteMatch) int { return b.score - a.score })
+	p.Cursor = 0
+	p.Err = ""
+}
+
+// Selected returns the highlighted completion, or nil when nothing matches.
+func (p *CommandPalette) Selected() *PaletteMatch {
+	if p.Cursor < 0 || p.Cursor >= len(p.Matches) {
+		return nil
+	}
+	return &p.Matches[p.Cursor]
+}
+
+// MoveUp highlights the previous completion.
+func (p *CommandPalette) MoveUp() {
+	if p.Cursor > 0 {
+		p.Cursor--
+	}
+}
+
+// MoveDown highlights the next completion.
+func (p *CommandPalette) MoveDown() {
+	if p.Cursor < min(len(p.Matches), paletteMaxMatches)-1 {
+		p.Cursor++
+	}
+}
+
+// Complete replaces the input with the highlighted completion.
+func (p *CommandPalette) Complete() {
+	if sel := p.Selected(); sel != nil {
+		p.Input.SetValue(sel.Text)
+		p.Input.CursorEnd()
+		p.refilter()
+	}
+}
+
+// fuzzyScore reports whether every non-space character of query appears in
+// text in order, ignoring case, and scores the match: characters that
+// continue a run or start a word score extra, so "rtal" ranks "retry all"
+// above a command that merely contains those letters.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+	score, qi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == prev+1 {
+			score += 2
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		prev = ti
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// View renders the palette as an overlay box.
+func (p *CommandPalette) View(width, _ int) string {
+	w := min(max(width/2, 40), width-4)
+
+	var b strings.Builder
+	b.WriteString(styleOverlayTitle.Render("Command palette"))
+	b.WriteString("\n\n")
+	b.WriteString(p.Input.View())
+	b.WriteString("\n\n")
+	if len(p.Matches) == 0 {
+		b.WriteString(styleOverlayHint.Render("  no matching commands"))
+		b.WriteString("\n")
+	}
+	for i, m := range p.Matches[:min(len(p.Matches), paletteMaxMatches)] {
+		cursor, style := "  ", styleRowNormal
+		if i == p.Cursor {
+			cursor, style = "▸ ", styleRowSelected
+		}
+		line := style.Render(cursor+m.Text) + "  " + styleDetailDim.Render(m.Command.Desc)
+		b.WriteString(lipgloss.NewStyle().MaxWidth(w).Render(line))
+		b.WriteString("\n")
+	}
+	if p.Err != "" {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(colorDanger).Render(p.Err))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(styleOverlayHint.Render("↑/↓ select · tab complete · enter run · esc close"))
+	return styleKeyHelpOverlay.Width(w).Render(b.String())
+}
+
+// PaletteFooterBindings returns footer bindings while the palette is open.
+func PaletteFooterBindings(km KeyMap) []key.Binding {
+	esc := km.Back
+	esc.SetHelp("esc", "close")
+	run := km.Enter
+	run.SetHelp("enter", "run")
+	return []key.Binding{run, esc}
+}
+
+// openPalette shows the command palette.
+func (m *AppModel) openPalette() {
+	m.Palette = NewCommandPalette(PaletteCommands(), m.paletteArgs)
+}
+
+// handlePaletteKey routes key events while the command palette is open. Esc
+// closes it, Enter runs the highlighted command, Tab completes it, and the
+// arrow keys move the highlight; everything else edits the input.
+func (m AppModel) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.Palette = nil
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "up":
+		m.Palette.MoveUp()
+		return m, nil
+	case "down":
+		m.Palette.MoveDown()
+		return m, nil
+	case "tab":
+		m.Palette.Complete()
+		return m, nil
+	case "enter":
+		sel := m.Palette.Selected()
+		if sel == nil {
+			m.Palette.Err = "unknown command: " + m.Palette.Input.Value()
+			return m, nil
+		}
+		m.Palette = nil
+		return m, sel.Command.Run(&m, sel.Arg)
+	}
+	var cmd tea.Cmd
+	m.Palette.Input, cmd = m.Palette.Input.Update(msg)
+	m.Palette.refilter()
+	return m, cmd
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PaletteCommand is a named action the command palette can run.
+type PaletteCommand struct {
+	Name string // what the user types, e.g. "retry all"
+	Arg  string // kind of argument the command takes ("status", "phase"); empty for none
+	Desc string
+	Run  func(m *AppModel, arg string) tea.Cmd
+}
+
+// PaletteCommands returns the commands the palette offers, in the order it
+// lists them before anything is typed.
+func PaletteCommands() []PaletteCommand {
+	return []PaletteCommand{
+		{Name: "goto phase", Arg: "phase", Desc: "open a phase's cycles", Run: paletteGotoPhase},
+		{Name: "filter", Arg: "status", Desc: "list only phases with a status", Run: paletteFilter},
+		{Name: "retry all", Desc: "retry every failed phase", Run: paletteRetryAll},
+		{Name: "pause", Desc: "pause or resume starting new phases", Run: palettePause},
+		{Name: "stop", Desc: "stop once running phases finish", Run: paletteStop},
+		{Name: "drain", Desc: "finish the current wave, then stop leaving the rest pending", Run: paletteDrain},
+		{Name: "export diff", Desc: "write the selected agent's diff to a file", Run: paletteExportDiff},
+		{Name: "keys", Desc: "show the keybinding cheat sheet", Run: paletteKeys},
+	}
+}
+
+// paletteArgs returns the argument completions for c.
+func (m *AppModel) paletteArgs(c PaletteCommand) []string {
+	switch c.Arg {
+	case "status":
+		return append([]string{"all"}, PhaseStatusNames()...)
+	case "phase":
+		ids := make([]string, len(m.NebulaView.Phases))
+		for i, p := range m.NebulaView.Phases {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+	return nil
+}
+
+// requireNebula reports whether a nebula is running, noting in the message
+// log that name needs one when it is not.
+func (m *AppModel) requireNebula(name string) bool {
+	if m.Mode == ModeNebula && m.NebulaDir != "" {
+		return true
+	}
+	m.addMessage("%s: only available while a nebula is running", name)
+	return false
+}
+
+// paletteGotoPhase opens the phase's cycle view, clearing a filter that
+// hides it.
+func paletteGotoPhase(m *AppModel, id string) tea.Cmd {
+	if m.Mode != ModeNebula {
+		m.addMessage("goto phase: only available in nebula mode")
+		return nil
+	}
+	for i, p := range m.NebulaView.Phases {
+		if p.ID != id {
+			continue
+		}
+		if !m.NebulaView.listed(i) {
+			m.NebulaView.SetFilter(nil)
+		}
+		m.NebulaView.Cursor = i
+		m.ShowPlan = false
+		m.ShowDiff = false
+		m.DiffFileList = nil
+		m.DiffFileOpen = false
+		m.ShowBeads = false
+		m.FocusedPhase = id
+		m.Depth = DepthPhaseLoop
+		m.updateDetailFromSelection()
+		return nil
+	}
+	m.addMessage("goto phase: no phase %q", id)
+	return nil
+}
+
+// paletteFilter limits the phase table to one status, or lifts the limit
+// for "all".
+func paletteFilter(m *AppModel, arg string) tea.Cmd {
+	if arg == "all" {
+		m.NebulaView.SetFilter(nil)
+		return nil
+	}
+	status, ok := ParsePhaseStatus(arg)
+	if !ok {
+		m.addMessage("filter: unknown status %q", arg)
+		return nil
+	}
+	m.NebulaView.SetFilter(&status)
+	return nil
+}
+
+// paletteRetryAll retries every failed phase at once.
+func paletteRetryAll(m *AppModel, _ string) tea.Cmd {
+	if !m.requireNebula("retry all") {
+		return nil
+	}
+	var failed []string
+	for _, p := range m.NebulaView.Phases {
+		if p.Status == PhaseFailed {
+			failed = append(failed, p.ID)
+		}
+	}
+	if len(failed) == 0 {
+		m.addMessage("retry all: no failed phases")
+		return nil
+	}
+	m.retryPhases(failed...)
+	return nil
+}
+
+// palettePause toggles the PAUSE intervention file from any view.
+func palettePause(m *AppModel, _ string) tea.Cmd {
+	if m.requireNebula("pause") {
+		m.togglePause()
+	}
+	return nil
+}
+
+// paletteStop writes the STOP intervention file from any view.
+func paletteStop(m *AppModel, _ string) tea.Cmd {
+	if m.requireNebula("stop") {
+		m.requestStop()
+	}
+	return nil
+}
+
+// paletteDrain writes the DRAIN intervention file from any view.
+func paletteDrain(m *AppModel, _ string) tea.Cmd {
+	if m.requireNebula("drain") {
+		m.requestDrain()
+	}
+	return nil
+}
+
+// paletteExportDiff writes the selected agent's diff to <phase>.diff in the
+// nebula directory, or quasar.diff in the working directory in loop mode.
+func paletteExportDiff(m *AppModel, _ string) tea.Cmd {
+	agent := m.selectedAgent()
+	if agent == nil || agent.Diff == "" {
+		m.addMessage("export diff: select an agent with a diff first")
+		return nil
+	}
+	path := "quasar.diff"
+	if m.Mode == ModeNebula {
+		path = filepath.Join(m.NebulaDir, m.FocusedPhase+".diff")
+	}
+	if err := os.WriteFile(path, []byte(agent.Diff), 0644); err != nil {
+		m.addMessage("export diff: %s", err)
+		return nil
+	}
+	m.addMessage("diff exported to %s", path)
+	return nil
+}
+
+// paletteKeys opens the keybinding cheat sheet.
+func paletteKeys(m *AppModel, _ string) tea.Cmd {
+	m.KeyHelp = NewKeyHelpOverlay(m.Keys)
+	return nil
+}
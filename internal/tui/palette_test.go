@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"", "pause", true},
+		{"retry", "retry all", true},
+		{"rtal", "retry all", true},
+		{"RETRY ALL", "retry all", true},
+		{"gp set", "goto phase setup", true},
+		{"fx", "filter failed", false},
+		{"lla", "retry all", false}, // out of order
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) match = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	prefix, _ := fuzzyScore("st", "stop")
+	scattered, _ := fuzzyScore("st", "filter skipped")
+	if prefix <= scattered {
+		t.Errorf("prefix score %d <= scattered score %d", prefix, scattered)
+	}
+}
+
+// typePalette opens the palette on m, types text, and presses enter.
+func typePalette(t *testing.T, m AppModel, text string) AppModel {
+	t.Helper()
+	m.Splash = nil
+	result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	m = result.(AppModel)
+	if m.Palette == nil {
+		t.Fatal(": did not open the palette")
+	}
+	result, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	m = result.(AppModel)
+	result, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	return result.(AppModel)
+}
+
+func TestPaletteCommands(t *testing.T) {
+	t.Parallel()
+
+	phases := func() []PhaseEntry {
+		return []PhaseEntry{
+			{ID: "setup", Status: PhaseDone},
+			{ID: "api", Status: PhaseFailed},
+			{ID: "ui", Status: PhaseFailed},
+			{ID: "docs", Status: PhaseWaiting},
+		}
+	}
+
+	t.Run("retry all", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		m := typePalette(t, *newNebulaModelWithPhases(dir, phases()), "retry all")
+		if m.Palette != nil {
+			t.Error("palette still open after running a command")
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "RETRY"))
+		if err != nil {
+			t.Fatalf("RETRY file: %v", err)
+		}
+		if got := strings.Fields(string(data)); len(got) != 2 || got[0] != "api" || got[1] != "ui" {
+			t.Errorf("RETRY lists %v, want [api ui]", got)
+		}
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		m := *newNebulaModelWithPhases(dir, phases())
+		m.Depth = DepthPhaseLoop
+		m = typePalette(t, m, "pause")
+		if !m.Paused {
+			t.Error("pause did not pause from the phase view")
+		}
+		if _, err := os.Stat(filepath.Join(dir, "PAUSE")); err != nil {
+			t.Errorf("PAUSE file: %v", err)
+		}
+	})
+
+	t.Run("filter failed", func(t *testing.T) {
+		t.Parallel()
+		m := typePalette(t, *newNebulaModelWithPhases("", phases()), "filter failed")
+		if p := m.NebulaView.SelectedPhase(); p == nil || p.ID != "api" {
+			t.Fatalf("selected %v, want api", p)
+		}
+		m.NebulaView.MoveDown()
+		m.NebulaView.MoveDown()
+		if p := m.NebulaView.SelectedPhase(); p.ID != "ui" {
+			t.Errorf("cursor moved to %s, want to stay on ui", p.ID)
+		}
+		view := m.NebulaView.View()
+		if strings.Contains(view, "setup") || !strings.Contains(view, "2 of 4 phases") {
+			t.Errorf("filtered view:\n%s", view)
+		}
+
+		m = typePalette(t, m, "filter all")
+		if m.NebulaView.Filter != nil {
+			t.Error("filter all left a filter set")
+		}
+	})
+
+	t.Run("goto phase", func(t *testing.T) {
+		t.Parallel()
+		m := *newNebulaModelWithPhases("", phases())
+		failed := PhaseFailed
+		m.NebulaView.SetFilter(&failed)
+		m = typePalette(t, m, "goto phase docs")
+		if m.FocusedPhase != "docs" || m.Depth != DepthPhaseLoop {
+			t.Errorf("focused %q at depth %v, want docs at DepthPhaseLoop", m.FocusedPhase, m.Depth)
+		}
+		if m.NebulaView.Filter != nil {
+			t.Error("goto kept a filter that hides the phase")
+		}
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		t.Parallel()
+		m := typePalette(t, *newNebulaModelWithPhases("", phases()), "zzz")
+		if m.Palette == nil || m.Palette.Err == "" {
+			t.Fatal("unknown command did not report an error in the palette")
+		}
+		result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+		if result.(AppModel).Palette != nil {
+			t.Error("esc did not close the palette")
+		}
+	})
+}
+
+func TestPaletteExportDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := *newNebulaModelWithPhases(dir, []PhaseEntry{{ID: "api", Status: PhaseDone}})
+	lv := NewLoopView()
+	lv.Cycles = []CycleEntry{{Number: 1, Agents: []AgentEntry{{Role: "coder", Done: true, Diff: "diff --git a/x b/x\n"}}}}
+	lv.Cursor = 1 // the coder entry under the cycle header
+	m.PhaseLoops["api"] = &lv
+	m.FocusedPhase = "api"
+	m.Depth = DepthAgentOutput
+
+	m = typePalette(t, m, "export diff")
+	data, err := os.ReadFile(filepath.Join(dir, "api.diff"))
+	if err != nil {
+		t.Fatalf("exported diff: %v", err)
+	}
+	if string(data) != "diff --git a/x b/x\n" {
+		t.Errorf("exported %q", data)
+	}
+}
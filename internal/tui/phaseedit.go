@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultEditor is used when $EDITOR is unset.
+const defaultEditor = "vi"
+
+// PhaseRefactorer queues a refactor for a phase whose source file changed.
+// Implemented by nebula.WorkerGroup; a no-op there when a file watcher is
+// already observing the nebula directory.
+type PhaseRefactorer interface {
+	RefactorPhase(phaseID string) error
+}
+
+// editTargetPhase returns the phase the edit key applies to: the selected
+// row at the phase table, or the focused phase when drilled in. Returns nil
+// outside nebula mode or when the phase has no source file (e.g. hot-added).
+func (m *AppModel) editTargetPhase() *PhaseEntry {
+	if m.Mode != ModeNebula {
+		return nil
+	}
+	var p *PhaseEntry
+	switch m.Depth {
+	case DepthPhases:
+		p = m.NebulaView.SelectedPhase()
+	case DepthPhaseLoop:
+		p = m.findPhase(m.FocusedPhase)
+	}
+	if p == nil || p.SourceFile == "" {
+		return nil
+	}
+	return p
+}
+
+// handleEditKey suspends the TUI and opens the target phase's file in
+// $EDITOR. Completed phases are refused with a toast, since a refactor can
+// no longer reach their loop.
+func (m *AppModel) handleEditKey() tea.Cmd {
+	p := m.editTargetPhase()
+	if p == nil {
+		return nil
+	}
+	if p.Status == PhaseDone || p.Status == PhaseSkipped {
+		toast, cmd := NewToast(fmt.Sprintf("[%s] already completed — edits won't apply", p.ID), true)
+		m.Toasts = append(m.Toasts, toast)
+		return cmd
+	}
+
+	phaseID := p.ID
+	return tea.ExecProcess(editorCommand(p.SourceFile), func(err error) tea.Msg {
+		return MsgPhaseEdited{PhaseID: phaseID, Err: err}
+	})
+}
+
+// handlePhaseEdited reacts to the editor exiting. When a Refactorer is
+// attached it is asked to queue the refactor directly; otherwise the file
+// watcher is relied on to notice the write. Success is reported later via
+// MsgPhaseRefactorPending.
+func (m *AppModel) handlePhaseEdited(msg MsgPhaseEdited) tea.Cmd {
+	err := msg.Err
+	if err == nil && m.Refactorer != nil {
+		err = m.Refactorer.RefactorPhase(msg.PhaseID)
+	}
+	if err == nil {
+		return nil
+	}
+	m.addMessage("[%s] edit failed: %s", msg.PhaseID, err)
+	toast, cmd := NewToast(fmt.Sprintf("[%s] edit failed: %s", msg.PhaseID, err), true)
+	m.Toasts = append(m.Toasts, toast)
+	return cmd
+}
+
+// editorCommand builds the command that opens path in the user's $EDITOR.
+// The variable may carry arguments (e.g. "code --wait").
+func editorCommand(path string) *exec.Cmd {
+	args := strings.Fields(os.Getenv("EDITOR"))
+	if len(args) == 0 {
+		args = []string{defaultEditor}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
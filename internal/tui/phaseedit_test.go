@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mockRefactorer records RefactorPhase calls and returns a fixed error.
+type mockRefactorer struct {
+	calls []string
+	err   error
+}
+
+func (r *mockRefactorer) RefactorPhase(phaseID string) error {
+	r.calls = append(r.calls, phaseID)
+	return r.err
+}
+
+func TestHandleEditKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		phase     PhaseEntry
+		wantCmd   bool
+		wantToast string
+	}{
+		{"working phase opens editor", PhaseEntry{ID: "p1", Status: PhaseWorking, SourceFile: "/tmp/p1.md"}, true, ""},
+		{"waiting phase opens editor", PhaseEntry{ID: "p1", Status: PhaseWaiting, SourceFile: "/tmp/p1.md"}, true, ""},
+		{"done phase refused", PhaseEntry{ID: "p1", Status: PhaseDone, SourceFile: "/tmp/p1.md"}, true, "edits won't apply"},
+		{"skipped phase refused", PhaseEntry{ID: "p1", Status: PhaseSkipped, SourceFile: "/tmp/p1.md"}, true, "edits won't apply"},
+		{"no source file ignored", PhaseEntry{ID: "p1", Status: PhaseWorking}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newNebulaModelWithPhases("", []PhaseEntry{tt.phase})
+			cmd := m.handleEditKey()
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("handleEditKey() cmd = %v, want non-nil %v", cmd != nil, tt.wantCmd)
+			}
+			if tt.wantToast == "" {
+				if len(m.Toasts) != 0 {
+					t.Errorf("unexpected toasts: %+v", m.Toasts)
+				}
+				return
+			}
+			if len(m.Toasts) != 1 || !strings.Contains(m.Toasts[0].Message, tt.wantToast) {
+				t.Errorf("toasts = %+v, want one containing %q", m.Toasts, tt.wantToast)
+			}
+		})
+	}
+}
+
+func TestEditKeyUsesFocusedPhase(t *testing.T) {
+	t.Parallel()
+
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "p1", SourceFile: "/tmp/p1.md"},
+		{ID: "p2", SourceFile: "/tmp/p2.md"},
+	})
+	m.Depth = DepthPhaseLoop
+	m.FocusedPhase = "p2"
+	if p := m.editTargetPhase(); p == nil || p.ID != "p2" {
+		t.Errorf("editTargetPhase() = %+v, want p2", p)
+	}
+
+	m.Mode = ModeLoop
+	if p := m.editTargetPhase(); p != nil {
+		t.Errorf("editTargetPhase() in loop mode = %+v, want nil", p)
+	}
+}
+
+func TestMsgPhaseEdited(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		refactorer *mockRefactorer
+		editErr    error
+		wantCalls  int
+		wantToast  bool
+	}{
+		{"refactor queued", &mockRefactorer{}, nil, 1, false},
+		{"no refactorer relies on watcher", nil, nil, 0, false},
+		{"editor failed", &mockRefactorer{}, errors.New("exit status 1"), 0, true},
+		{"refactor failed", &mockRefactorer{err: errors.New("bad toml")}, nil, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newNebulaModelWithPhases("", []PhaseEntry{{ID: "p1"}})
+			if tt.refactorer != nil {
+				m.Refactorer = tt.refactorer
+			}
+
+			var tm tea.Model = *m
+			tm, _ = tm.Update(MsgPhaseEdited{PhaseID: "p1", Err: tt.editErr})
+			am := tm.(AppModel)
+
+			if tt.refactorer != nil && len(tt.refactorer.calls) != tt.wantCalls {
+				t.Errorf("RefactorPhase calls = %d, want %d", len(tt.refactorer.calls), tt.wantCalls)
+			}
+			if got := len(am.Toasts) > 0; got != tt.wantToast {
+				t.Errorf("toast shown = %v, want %v", got, tt.wantToast)
+			}
+		})
+	}
+}
+
+func TestMsgRefactorerReady(t *testing.T) {
+	t.Parallel()
+
+	r := &mockRefactorer{}
+	m := newNebulaModel("")
+	var tm tea.Model = *m
+	tm, _ = tm.Update(MsgRefactorerReady{Refactorer: r})
+	if am := tm.(AppModel); am.Refactorer != r {
+		t.Error("Refactorer should be attached")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		editor   string
+		wantArgs []string
+	}{
+		{"default", "", []string{defaultEditor, "/tmp/p.md"}},
+		{"plain", "nano", []string{"nano", "/tmp/p.md"}},
+		{"with args", "code --wait", []string{"code", "--wait", "/tmp/p.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			cmd := editorCommand("/tmp/p.md")
+			if strings.Join(cmd.Args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("Args = %v, want %v", cmd.Args, tt.wantArgs)
+			}
+		})
+	}
+}
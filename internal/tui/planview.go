@@ -25,6 +25,8 @@ type PlanView struct {
 	NebulaDir string
 	viewport  viewport.Model
 	selected  PlanAction // currently highlighted action button
+	change    int        // index of the highlighted entry in Changes
+	changeRow int        // viewport line of the first Changes entry
 	width     int
 	height    int
 	ready     bool // whether viewport dimensions have been set
@@ -44,10 +46,50 @@ func (pv *PlanView) SetPlan(plan *nebula.ExecutionPlan, changes []nebula.PlanCha
 	pv.Plan = plan
 	pv.Changes = changes
 	pv.NebulaDir = nebulaDir
+	pv.change = 0
 	pv.loading = false
 	pv.refresh()
 }
 
+// NextChange highlights the next entry in the changes panel and scrolls it
+// into view.
+func (pv *PlanView) NextChange() {
+	if pv.change < len(pv.Changes)-1 {
+		pv.change++
+		pv.refresh()
+		pv.revealChange()
+	}
+}
+
+// PrevChange highlights the previous entry in the changes panel and scrolls
+// it into view.
+func (pv *PlanView) PrevChange() {
+	if pv.change > 0 {
+		pv.change--
+		pv.refresh()
+		pv.revealChange()
+	}
+}
+
+// SelectedChange returns the highlighted change, or nil if there are none.
+func (pv *PlanView) SelectedChange() *nebula.PlanChange {
+	if pv.change < 0 || pv.change >= len(pv.Changes) {
+		return nil
+	}
+	return &pv.Changes[pv.change]
+}
+
+// revealChange scrolls the viewport so the highlighted change is visible.
+func (pv *PlanView) revealChange() {
+	row := pv.changeRow + pv.change
+	switch {
+	case row < pv.viewport.YOffset:
+		pv.viewport.SetYOffset(row)
+	case row >= pv.viewport.YOffset+pv.viewport.Height:
+		pv.viewport.SetYOffset(row - pv.viewport.Height + 1)
+	}
+}
+
 // SetSize updates the viewport dimensions and re-renders.
 func (pv *PlanView) SetSize(width, height int) {
 	pv.width = width
@@ -143,6 +185,15 @@ func (pv *PlanView) refresh() {
 	b.WriteString(strings.Repeat("═", w))
 	b.WriteString("\n\n")
 
+	// Changes since the last apply come first, so a re-apply is reviewed
+	// before anything else.
+	if len(pv.Changes) > 0 {
+		// The entries start after the section header and summary lines.
+		pv.changeRow = strings.Count(b.String(), "\n") + 2
+		b.WriteString(pv.renderDiffSection())
+		b.WriteString("\n")
+	}
+
 	// DAG graph section.
 	b.WriteString(pv.renderGraphSection(w))
 	b.WriteString("\n")
@@ -159,12 +210,6 @@ func (pv *PlanView) refresh() {
 	b.WriteString(pv.renderStatsSection())
 	b.WriteString("\n")
 
-	// Diff section (if there are changes from a previous plan).
-	if len(pv.Changes) > 0 {
-		b.WriteString(pv.renderDiffSection())
-		b.WriteString("\n")
-	}
-
 	pv.viewport.SetContent(b.String())
 }
 
@@ -374,24 +419,45 @@ func (pv *PlanView) renderStatsSection() string {
 	return stylePlanSectionHeader.Render("Stats") + "\n  " + line + "\n"
 }
 
-// renderDiffSection renders changes since the last plan.
+// renderDiffSection renders the changes since the last applied plan: a
+// count per kind, then one line per change with the highlighted one marked.
 func (pv *PlanView) renderDiffSection() string {
 	var b strings.Builder
-	b.WriteString(stylePlanSectionHeader.Render("Changes since last plan"))
+	b.WriteString(stylePlanSectionHeader.Render("Changes since last apply"))
 	b.WriteString("\n")
 
+	counts := make(map[string]int)
 	for _, c := range pv.Changes {
-		b.WriteString("  ")
+		counts[c.Kind]++
+	}
+	b.WriteString("  ")
+	b.WriteString(stylePlanDiffAdd.Render(fmt.Sprintf("%d added", counts["added"])))
+	b.WriteString(styleDetailDim.Render(" · "))
+	b.WriteString(stylePlanDiffRemove.Render(fmt.Sprintf("%d removed", counts["removed"])))
+	b.WriteString(styleDetailDim.Render(" · "))
+	b.WriteString(stylePlanDiffChange.Render(fmt.Sprintf("%d modified", counts["changed"])))
+	b.WriteString(styleDetailDim.Render("   [/] to step through"))
+	b.WriteString("\n")
+
+	for i, c := range pv.Changes {
+		cursor := "  "
+		if i == pv.change {
+			cursor = styleSelectionIndicator.Render("▸ ")
+		}
+		b.WriteString(cursor)
+		style, sign := lipgloss.NewStyle(), " "
 		switch c.Kind {
 		case "added":
-			b.WriteString(stylePlanDiffAdd.Render("+ " + c.Subject + ": " + c.Detail))
+			style, sign = stylePlanDiffAdd, "+"
 		case "removed":
-			b.WriteString(stylePlanDiffRemove.Render("- " + c.Subject + ": " + c.Detail))
+			style, sign = stylePlanDiffRemove, "-"
 		case "changed":
-			b.WriteString(stylePlanDiffChange.Render("~ " + c.Subject + ": " + c.Detail))
-		default:
-			b.WriteString("  " + c.Subject + ": " + c.Detail)
+			style, sign = stylePlanDiffChange, "~"
+		}
+		if i == pv.change {
+			style = style.Bold(true)
 		}
+		b.WriteString(style.Render(sign + " " + c.Subject + ": " + c.Detail))
 		b.WriteString("\n")
 	}
 
@@ -456,6 +522,7 @@ func PlanFooterBindings(km KeyMap) []key.Binding {
 		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
 		key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "scroll up")),
 		key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "scroll down")),
+		key.NewBinding(key.WithKeys("[", "]"), key.WithHelp("[/]", "changes")),
 		km.Back,
 		km.Quit,
 	}
@@ -126,9 +126,66 @@ func TestPlanView_ViewWithDiff(t *testing.T) {
 	pv.SetPlan(testPlan(), changes, "/tmp/test")
 
 	view := pv.View()
-	if !strings.Contains(view, "Changes since last plan") {
+	if !strings.Contains(view, "Changes since last apply") {
 		t.Error("view should contain diff section when changes exist")
 	}
+	if !strings.Contains(view, "1 added · 0 removed · 1 modified") {
+		t.Errorf("view should count changes by kind:\n%s", view)
+	}
+	if strings.Index(view, "Changes since last apply") > strings.Index(view, "Execution Graph") {
+		t.Error("changes panel should come before the graph")
+	}
+}
+
+func TestPlanView_ChangeNavigation(t *testing.T) {
+	t.Parallel()
+
+	pv := NewPlanView()
+	pv.SetSize(100, 6)
+	changes := []nebula.PlanChange{
+		{Kind: "added", Subject: "phase-d", Detail: "phase added to plan"},
+		{Kind: "changed", Subject: "phase-a", Detail: "phase modified: body edited"},
+		{Kind: "removed", Subject: "phase-x", Detail: "phase removed from plan"},
+	}
+	pv.SetPlan(testPlan(), changes, "/tmp/test")
+
+	if c := pv.SelectedChange(); c == nil || c.Subject != "phase-d" {
+		t.Fatalf("initial change = %+v, want phase-d", c)
+	}
+	pv.NextChange()
+	pv.NextChange()
+	pv.NextChange() // stops at the last change
+	if c := pv.SelectedChange(); c.Subject != "phase-x" {
+		t.Errorf("after next x3, change = %s, want phase-x", c.Subject)
+	}
+	if !strings.Contains(pv.View(), "▸ - phase-x") {
+		t.Errorf("highlighted change should be marked and scrolled into view:\n%s", pv.View())
+	}
+	pv.PrevChange()
+	if c := pv.SelectedChange(); c.Subject != "phase-a" {
+		t.Errorf("after prev, change = %s, want phase-a", c.Subject)
+	}
+
+	var empty PlanView
+	empty.NextChange()
+	if empty.SelectedChange() != nil {
+		t.Error("a plan without changes has no selected change")
+	}
+}
+
+func TestMsgPlanAction_ApplyLeavesSavingToTheRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := newHomeModel([]NebulaChoice{{Name: "Alpha", Path: dir, Status: "ready", Phases: 2}})
+	plan := testPlan()
+	m.Update(MsgPlanAction{Action: PlanActionApply, Plan: plan, NebulaDir: dir})
+
+	// Nothing has been applied yet; a saved plan would hide these changes
+	// from the next preview if the apply then failed.
+	if prev := LoadPreviousPlan(dir, plan.Name); prev != nil {
+		t.Errorf("plan saved before the apply ran: %+v", prev)
+	}
 }
 
 func TestPlanView_ActionCycling(t *testing.T) {
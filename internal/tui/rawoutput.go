@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rawHorizontalStep is how many columns ←/→ scroll unwrapped raw output.
+const rawHorizontalStep = 8
+
+// handleRawKey toggles verbatim rendering at DepthAgentOutput. In raw mode
+// the selected agent's output, or its diff when the diff view is on, is
+// shown exactly as received: no highlighting, collapsing, or wrapping, so
+// copied text matches what the agent produced. The setting is kept for the
+// rest of the session.
+func (m *AppModel) handleRawKey() {
+	if m.Depth != DepthAgentOutput {
+		return
+	}
+	m.RawOutput = !m.RawOutput
+	m.DiffFileOpen = false
+	m.updateDetailFromSelection()
+}
+
+// rawBinding returns the raw key binding with help text reflecting the
+// current state.
+func (m AppModel) rawBinding() key.Binding {
+	b := m.Keys.Raw
+	if m.RawOutput {
+		b.SetHelp("R", "formatted")
+	}
+	return b
+}
+
+// diffListActive reports whether the diff file list is shown and owns
+// navigation. Raw mode replaces the list with the verbatim diff.
+func (m AppModel) diffListActive() bool {
+	return m.ShowDiff && m.DiffFileList != nil && !m.RawOutput
+}
+
+// isRawScrollKey reports whether msg scrolls raw output sideways.
+func (m AppModel) isRawScrollKey(msg tea.KeyMsg) bool {
+	return m.RawOutput && (msg.Type == tea.KeyLeft || msg.Type == tea.KeyRight)
+}
+
+// setRawAgentDetail shows agent's diff or output verbatim in the detail
+// panel. label names the agent in the title, e.g. "coder" or "phase → coder".
+func (m *AppModel) setRawAgentDetail(label, header string, agent *AgentEntry) {
+	if m.ShowDiff && agent.Diff != "" {
+		m.Detail.SetRawContentWithHeader(fmt.Sprintf("%s diff (raw)", label), header, agent.Diff)
+		return
+	}
+	title := fmt.Sprintf("%s output (raw)", label)
+	if agent.Output == "" {
+		m.Detail.SetContentWithHeader(title, header, "(output will appear when agent completes)")
+		return
+	}
+	m.Detail.SetRawContentWithHeader(title, header, agent.Output)
+}
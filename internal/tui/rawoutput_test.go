@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRawKeyShowsOutputVerbatim(t *testing.T) {
+	t.Parallel()
+
+	long := "error: " + strings.Repeat("x", 300)
+	output := "## Summary\n" + long + "\n\ttabbed line"
+
+	m := NewAppModel(ModeLoop)
+	m.Splash = nil
+	var tm tea.Model = m
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	tm, _ = tm.Update(MsgCycleStart{Cycle: 1, MaxCycles: 3})
+	tm, _ = tm.Update(MsgAgentStart{Role: "coder"})
+	tm, _ = tm.Update(MsgAgentOutput{Role: "coder", Cycle: 1, Output: output})
+	tm, _ = tm.Update(MsgAgentDone{Role: "coder"})
+	tm, _ = tm.Update(MsgAgentDiff{Role: "coder", Cycle: 1, Diff: "diff --git a/x b/x\n+added", Files: []FileStatEntry{{Path: "x", Additions: 1}}})
+	m = tm.(AppModel)
+	m.LoopView.Cursor = 1
+	m.Depth = DepthAgentOutput
+	m.updateDetailFromSelection()
+
+	keyRaw := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")}
+	m = pressKey(t, m, keyRaw)
+	if !m.RawOutput {
+		t.Fatal("expected RawOutput after pressing R")
+	}
+	if m.Detail.title != "coder output (raw)" {
+		t.Errorf("title = %q, want coder output (raw)", m.Detail.title)
+	}
+	content := m.Detail.viewport.View()
+	if !strings.Contains(content, "## Summary") {
+		t.Errorf("raw view should keep markdown verbatim:\n%s", content)
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "xxxx") {
+			t.Errorf("long line was wrapped in raw mode:\n%s", content)
+			break
+		}
+	}
+	// r keeps its retry meaning here; it does not leave raw mode.
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if !m.RawOutput {
+		t.Error("r should not toggle raw output")
+	}
+	if got := m.rawBinding().Help().Desc; got != "formatted" {
+		t.Errorf("footer help = %q, want formatted", got)
+	}
+
+	// The diff view is raw too, replacing the file list.
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.Detail.title != "coder diff (raw)" || m.diffListActive() {
+		t.Errorf("title = %q, diffListActive = %v; want the raw diff", m.Detail.title, m.diffListActive())
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+
+	// The setting survives leaving and re-entering agent output.
+	m.Depth = DepthAgentOutput - 1
+	m = pressKey(t, m, keyRaw)
+	if !m.RawOutput {
+		t.Error("R outside agent output should not change the raw setting")
+	}
+	m.Depth = DepthAgentOutput
+	m.updateDetailFromSelection()
+	if m.Detail.title != "coder output (raw)" {
+		t.Errorf("title after re-entering = %q, want raw", m.Detail.title)
+	}
+
+	m = pressKey(t, m, keyRaw)
+	if m.RawOutput || m.Detail.title != "coder output" {
+		t.Errorf("second R should restore formatting: RawOutput=%v title=%q", m.RawOutput, m.Detail.title)
+	}
+}
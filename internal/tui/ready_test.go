@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPhaseReadyToastsOnlySelectedPhase(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		phaseID   string
+		wantToast bool
+	}{
+		{"selected phase", "a", true},
+		{"other phase", "b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := NewAppModel(ModeNebula)
+			m.DisableSplash()
+			var tm tea.Model = m
+			tm, _ = tm.Update(MsgNebulaInit{Name: "test", Phases: []PhaseInfo{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}}})
+			before := len(tm.(AppModel).Toasts)
+
+			tm, _ = tm.Update(MsgPhaseReady{PhaseID: tt.phaseID})
+
+			if got := len(tm.(AppModel).Toasts) > before; got != tt.wantToast {
+				t.Errorf("toast shown = %v, want %v", got, tt.wantToast)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package tui
+
+// hailInputPadding is the horizontal space the hail overlay's border and
+// padding take from its text input.
+const hailInputPadding = 6
+
+// resizeOverlays propagates the current terminal size to every active
+// overlay. Overlays are re-centered from m.Width and m.Height on each render,
+// so only their own dimensions need updating here.
+func (m *AppModel) resizeOverlays() {
+	if m.Gate != nil {
+		m.Gate.Width = m.contentWidth()
+		m.Gate.Height = m.Height
+	}
+	if m.Hail != nil {
+		m.Hail.Width = m.Width
+		m.Hail.Input.Width = hailOverlayWidth(m.Width) - hailInputPadding
+	}
+	if m.HailList != nil {
+		m.HailList.Width = m.Width
+	}
+	if m.PlanPreview != nil && m.ShowPlanPreview {
+		m.PlanPreview.SetSize(m.contentWidth(), m.homeMainHeight())
+	}
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// resizeCases are terminal sizes to resize to while an overlay is open,
+// including ones smaller than the overlays' minimum width.
+var resizeCases = []struct {
+	name          string
+	width, height int
+}{
+	{"large", 160, 50},
+	{"small", 70, 20},
+	{"tiny", 20, 8},
+}
+
+func TestResizeWithOverlays(t *testing.T) {
+	t.Parallel()
+
+	overlays := []struct {
+		name  string
+		open  func(m *AppModel)
+		check func(t *testing.T, m AppModel)
+	}{
+		{
+			name: "gate",
+			open: func(m *AppModel) {
+				m.Gate = NewGatePrompt(&nebula.Checkpoint{PhaseID: "a"}, make(chan nebula.GateAction, 1))
+			},
+			check: func(t *testing.T, m AppModel) {
+				if m.Gate.Width != m.contentWidth() || m.Gate.Height != m.Height {
+					t.Errorf("gate size = %dx%d, want %dx%d", m.Gate.Width, m.Gate.Height, m.contentWidth(), m.Height)
+				}
+			},
+		},
+		{
+			name: "hail",
+			open: func(m *AppModel) {
+				m.Hail = NewHailOverlay(MsgHail{PhaseID: "a", Discovery: fabric.Discovery{Kind: "blocker", Detail: "need input"}}, nil)
+			},
+			check: func(t *testing.T, m AppModel) {
+				if m.Hail.Width != m.Width {
+					t.Errorf("hail width = %d, want %d", m.Hail.Width, m.Width)
+				}
+				if want := hailOverlayWidth(m.Width) - hailInputPadding; m.Hail.Input.Width != want {
+					t.Errorf("hail input width = %d, want %d", m.Hail.Input.Width, want)
+				}
+			},
+		},
+		{
+			name: "hail list",
+			open: func(m *AppModel) { m.HailList = NewHailListOverlay(makeTestHails(3)) },
+			check: func(t *testing.T, m AppModel) {
+				if m.HailList.Width != m.Width {
+					t.Errorf("hail list width = %d, want %d", m.HailList.Width, m.Width)
+				}
+			},
+		},
+		{
+			name: "completion",
+			open: func(m *AppModel) {
+				m.Overlay = NewCompletionFromNebulaDone(MsgNebulaDone{}, time.Minute, 1.5, 2)
+			},
+			check: func(*testing.T, AppModel) {},
+		},
+		{
+			name: "plan preview",
+			open: func(m *AppModel) {
+				pv := NewPlanView()
+				m.PlanPreview = &pv
+				m.ShowPlanPreview = true
+			},
+			check: func(t *testing.T, m AppModel) {
+				if m.PlanPreview.width != m.contentWidth() || m.PlanPreview.height != m.homeMainHeight() {
+					t.Errorf("plan preview size = %dx%d, want %dx%d",
+						m.PlanPreview.width, m.PlanPreview.height, m.contentWidth(), m.homeMainHeight())
+				}
+			},
+		},
+	}
+
+	for _, ov := range overlays {
+		for _, rc := range resizeCases {
+			t.Run(ov.name+"/"+rc.name, func(t *testing.T) {
+				t.Parallel()
+				m := NewAppModel(ModeNebula)
+				m.DisableSplash()
+				updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+				m = updated.(AppModel)
+				ov.open(&m)
+
+				updated, _ = m.Update(tea.WindowSizeMsg{Width: rc.width, Height: rc.height})
+				m = updated.(AppModel)
+				if m.Width != rc.width || m.Height != rc.height {
+					t.Fatalf("model size = %dx%d, want %dx%d", m.Width, m.Height, rc.width, rc.height)
+				}
+				ov.check(t, m)
+				_ = m.View() // must not panic at any size
+			})
+		}
+	}
+}
+
+func TestOverlayCenteredVertically(t *testing.T) {
+	t.Parallel()
+
+	m := NewAppModel(ModeNebula)
+	m.DisableSplash()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(AppModel)
+	m.HailList = NewHailListOverlay(makeTestHails(2))
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 50})
+	m = updated.(AppModel)
+
+	box := m.HailList.View(m.Width, m.Height)
+	boxTop := strings.Split(box, "\n")[0]
+	wantRow := (m.Height - lipgloss.Height(box)) / 2
+
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != m.Height {
+		t.Fatalf("view has %d lines, want %d", len(lines), m.Height)
+	}
+	if !strings.Contains(lines[wantRow], strings.TrimSpace(boxTop)) {
+		t.Errorf("overlay top border not at row %d; got %q", wantRow, lines[wantRow])
+	}
+}
@@ -0,0 +1,281 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// ErrResumeCanceled is returned by RunResumeWizard when the user leaves the
+// wizard without applying anything.
+var ErrResumeCanceled = errors.New("resume wizard canceled")
+
+// ResumeWizard walks the changes an apply plan would make to a nebula that
+// was edited since it last ran, one action at a time. Each step explains
+// what the action does and lets the user apply or skip it, except for the
+// creates and renames a phase needs to run; a final summary confirms the
+// approved actions before Apply runs them.
+type ResumeWizard struct {
+	Plan     *nebula.Plan
+	Steps    []nebula.Action // the plan's actions that change something
+	Approved []bool          // per step; every step starts approved
+	Cursor   int             // current step; len(Steps) is the summary
+	// Confirmed is set when the summary is accepted, Canceled when the
+	// wizard is left without applying.
+	Confirmed bool
+	Canceled  bool
+	Width     int
+	Keys      KeyMap
+
+	beads  map[string]string // phase ID → bead ID in the saved state
+	titles map[string]string // phase ID → phase title
+}
+
+// NewResumeWizard creates a wizard over plan's actions. Skip actions change
+// nothing, so they are not offered as steps.
+func NewResumeWizard(plan *nebula.Plan, n *nebula.Nebula, state *nebula.State) ResumeWizard {
+	w := ResumeWizard{
+		Plan:   plan,
+		Width:  80,
+		Keys:   DefaultKeyMap(),
+		beads:  make(map[string]string),
+		titles: make(map[string]string),
+	}
+	for _, a := range plan.Actions {
+		if a.Type != nebula.ActionSkip {
+			w.Steps = append(w.Steps, a)
+			w.Approved = append(w.Approved, true)
+		}
+	}
+	for id, ps := range state.Phases {
+		w.beads[id] = ps.BeadID
+	}
+	for _, p := range n.Phases {
+		w.titles[p.ID] = p.Title
+	}
+	return w
+}
+
+// Init implements tea.Model.
+func (w ResumeWizard) Init() tea.Cmd { return nil }
+
+// Update implements tea.Model.
+func (w ResumeWizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.Width = msg.Width
+	case tea.KeyMsg:
+		return w.handleKey(msg)
+	}
+	return w, nil
+}
+
+// handleKey applies a key press to the current step or the summary. The
+// decision keys are the gate's: Accept applies a step and Skip skips it.
+func (w ResumeWizard) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	summary := w.Cursor == len(w.Steps)
+	switch {
+	case key.Matches(msg, w.Keys.Back), key.Matches(msg, w.Keys.Quit):
+		w.Canceled = true
+		return w, tea.Quit
+	case key.Matches(msg, w.Keys.StepPrev):
+		w.Cursor = max(w.Cursor-1, 0)
+	case key.Matches(msg, w.Keys.StepNext):
+		w.Cursor = min(w.Cursor+1, len(w.Steps))
+	case key.Matches(msg, w.Keys.Enter), key.Matches(msg, w.Keys.Accept):
+		if summary {
+			w.Confirmed = true
+			return w, tea.Quit
+		}
+		w.Approved[w.Cursor] = true
+		w.Cursor++
+	case key.Matches(msg, w.Keys.Skip):
+		if !summary && !requiredAction(w.Steps[w.Cursor].Type) {
+			w.Approved[w.Cursor] = false
+			w.Cursor++
+		}
+	case key.Matches(msg, w.Keys.AcceptAll):
+		for i := w.Cursor; i < len(w.Steps); i++ {
+			w.Approved[i] = true
+		}
+		w.Cursor = len(w.Steps)
+	}
+	return w, nil
+}
+
+// requiredAction reports whether an action of type t cannot be skipped:
+// creates and renames give a phase its bead, and a phase without one fails
+// as soon as the run reaches it, blocking its dependents.
+func requiredAction(t nebula.ActionType) bool {
+	return t == nebula.ActionCreate || t == nebula.ActionRename
+}
+
+// ApprovedPlan returns the plan with the skipped steps left out.
+func (w ResumeWizard) ApprovedPlan() *nebula.Plan {
+	out := &nebula.Plan{NebulaName: w.Plan.NebulaName}
+	step := 0
+	for _, a := range w.Plan.Actions {
+		if a.Type != nebula.ActionSkip {
+			step++
+			if !w.Approved[step-1] {
+				continue
+			}
+		}
+		out.Actions = append(out.Actions, a)
+	}
+	return out
+}
+
+// View implements tea.Model.
+func (w ResumeWizard) View() string {
+	var b strings.Builder
+	title := fmt.Sprintf("Resume %s", w.Plan.NebulaName)
+	if w.Cursor < len(w.Steps) {
+		title += fmt.Sprintf(" — change %d of %d", w.Cursor+1, len(w.Steps))
+	} else {
+		title += " — review"
+	}
+	b.WriteString(styleOverlayTitle.Foreground(colorNebula).Render(title))
+	b.WriteString("\n\n")
+
+	if w.Cursor < len(w.Steps) {
+		b.WriteString(w.stepView(w.Cursor))
+		b.WriteString("\n\n")
+		b.WriteString(w.progressRow())
+		b.WriteString("\n\n")
+		b.WriteString(styleOverlayHint.Render(w.stepHint(w.Steps[w.Cursor])))
+		return b.String()
+	}
+
+	approved := 0
+	for i, a := range w.Steps {
+		mark := lipgloss.NewStyle().Foreground(colorSuccess).Render(iconDone)
+		if !w.Approved[i] {
+			mark = styleDetailDim.Render(iconSkipped)
+		} else {
+			approved++
+		}
+		fmt.Fprintf(&b, "  %s %s %-8s %s\n", mark, actionSymbol(a.Type), a.Type, a.PhaseID)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Apply %d of %d changes?\n\n", approved, len(w.Steps))
+	b.WriteString(styleOverlayHint.Render(fmt.Sprintf("%s:apply  %s:back  %s:cancel without changing anything",
+		w.Keys.Enter.Help().Key, w.Keys.StepPrev.Help().Key, w.Keys.Back.Help().Key)))
+	return b.String()
+}
+
+// stepHint lists the keys that act on step a, leaving out Skip when the
+// step is required.
+func (w ResumeWizard) stepHint(a nebula.Action) string {
+	hints := []string{w.Keys.Accept.Help().Key + "/" + w.Keys.Enter.Help().Key + ":apply"}
+	if !requiredAction(a.Type) {
+		hints = append(hints, w.Keys.Skip.Help().Key+":skip")
+	}
+	hints = append(hints,
+		w.Keys.StepPrev.Help().Key+"/"+w.Keys.StepNext.Help().Key+":back/next",
+		w.Keys.AcceptAll.Help().Key+":"+w.Keys.AcceptAll.Help().Desc,
+		w.Keys.Back.Help().Key+":cancel")
+	return strings.Join(hints, "  ")
+}
+
+// stepView renders step i: the action, the planner's reason, and what
+// applying or skipping it means.
+func (w ResumeWizard) stepView(i int) string {
+	a := w.Steps[i]
+	head := fmt.Sprintf("%s %s  %s", actionSymbol(a.Type), a.Type, a.PhaseID)
+	if t := w.titles[a.PhaseID]; t != "" {
+		head += fmt.Sprintf(" %q", t)
+	}
+	decision := lipgloss.NewStyle().Foreground(colorSuccess).Render("will apply")
+	if requiredAction(a.Type) {
+		decision += styleDetailDim.Render(" (required)")
+	} else if !w.Approved[i] {
+		decision = lipgloss.NewStyle().Foreground(colorAccent).Render("will skip")
+	}
+	text := lipgloss.NewStyle().Width(max(w.Width-4, 20)).Render(w.explain(a))
+	return strings.Join([]string{
+		lipgloss.NewStyle().Bold(true).Render(head),
+		styleDetailDim.Render(a.Reason),
+		"",
+		text,
+		"",
+		"Currently: " + decision,
+	}, "\n")
+}
+
+// explain describes what applying action a does, and what skipping it leaves.
+func (w ResumeWizard) explain(a nebula.Action) string {
+	switch a.Type {
+	case nebula.ActionCreate:
+		return "Creates a bead for this phase so it can run. It cannot be skipped: without a bead the phase would fail and block its dependents."
+	case nebula.ActionRetry:
+		return fmt.Sprintf("The phase failed on its last run (bead %s). Creates a fresh bead so the next run tries it again. Skipped, it stays failed.", w.beads[a.PhaseID])
+	case nebula.ActionUpdate:
+		return fmt.Sprintf("Keeps bead %s and syncs its assignee from the phase file; the phase's status and progress are unchanged. Skipped, the bead keeps its old assignee.", w.beads[a.PhaseID])
+	case nebula.ActionClose:
+		return fmt.Sprintf("The phase is no longer in the nebula. Closes bead %s and marks the phase done in the saved state. Skipped, the bead stays open.", w.beads[a.PhaseID])
+	case nebula.ActionRename:
+		return fmt.Sprintf("Moves bead %s and the saved progress of %q to %q, so the renamed phase is not started over. It cannot be skipped: without a bead %q would fail and block its dependents.", w.beads[a.From], a.From, a.PhaseID, a.PhaseID)
+	}
+	return a.Reason
+}
+
+// progressRow shows one mark per step: applied, skipped, or the current one.
+func (w ResumeWizard) progressRow() string {
+	marks := make([]string, len(w.Steps))
+	for i := range w.Steps {
+		switch {
+		case i == w.Cursor:
+			marks[i] = lipgloss.NewStyle().Foreground(colorPrimary).Render("●")
+		case !w.Approved[i]:
+			marks[i] = styleDetailDim.Render(iconSkipped)
+		case i < w.Cursor:
+			marks[i] = lipgloss.NewStyle().Foreground(colorSuccess).Render(iconDone)
+		default:
+			marks[i] = styleDetailDim.Render("·")
+		}
+	}
+	return strings.Join(marks, " ")
+}
+
+// actionSymbol returns the mark `nebula plan` prints for an action type.
+func actionSymbol(t nebula.ActionType) string {
+	switch t {
+	case nebula.ActionCreate:
+		return "+"
+	case nebula.ActionUpdate:
+		return "~"
+	case nebula.ActionClose:
+		return "×"
+	case nebula.ActionRetry:
+		return "↻"
+	case nebula.ActionRename:
+		return "→"
+	}
+	return "-"
+}
+
+// RunResumeWizard shows the wizard full screen and returns the plan with
+// the actions the user skipped left out, or ErrResumeCanceled. A plan with
+// nothing to change is returned as is.
+func RunResumeWizard(plan *nebula.Plan, n *nebula.Nebula, state *nebula.State) (*nebula.Plan, error) {
+	w := NewResumeWizard(plan, n, state)
+	if len(w.Steps) == 0 {
+		return plan, nil
+	}
+	final, err := tea.NewProgram(w, tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("TUI error: %w", err)
+	}
+	w = final.(ResumeWizard)
+	if !w.Confirmed {
+		return nil, ErrResumeCanceled
+	}
+	return w.ApprovedPlan(), nil
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func wizardFixture() (*nebula.Plan, *nebula.Nebula, *nebula.State) {
+	plan := &nebula.Plan{NebulaName: "auth", Actions: []nebula.Action{
+		{PhaseID: "api", Type: nebula.ActionCreate, Reason: `create bead for "API"`},
+		{PhaseID: "done", Type: nebula.ActionSkip, Reason: "already completed"},
+		{PhaseID: "ui", Type: nebula.ActionRetry, Reason: "retrying failed phase (previous bead: b-2)"},
+		{PhaseID: "old", Type: nebula.ActionClose, Reason: "removed from spec"},
+	}}
+	n := &nebula.Nebula{Phases: []nebula.PhaseSpec{{ID: "api", Title: "API"}, {ID: "done"}, {ID: "ui", Title: "UI"}}}
+	state := &nebula.State{Phases: map[string]*nebula.PhaseState{
+		"done": {BeadID: "b-1", Status: nebula.PhaseStatusDone},
+		"ui":   {BeadID: "b-2", Status: nebula.PhaseStatusFailed},
+		"old":  {BeadID: "b-3", Status: nebula.PhaseStatusCreated},
+	}}
+	return plan, n, state
+}
+
+func pressWizard(w ResumeWizard, keys ...string) ResumeWizard {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m, _ := w.Update(msg)
+		w = m.(ResumeWizard)
+	}
+	return w
+}
+
+func TestResumeWizardApprovesAndSkips(t *testing.T) {
+	t.Parallel()
+
+	w := NewResumeWizard(wizardFixture())
+	if len(w.Steps) != 3 {
+		t.Fatalf("steps = %+v, want the three actions that change something", w.Steps)
+	}
+	if view := w.View(); !strings.Contains(view, "change 1 of 3") || !strings.Contains(view, "Creates a bead") {
+		t.Errorf("first step view:\n%s", view)
+	}
+
+	w = pressWizard(w, "a", "k")
+	if view := w.View(); !strings.Contains(view, "Closes bead b-3") {
+		t.Errorf("close step does not name the bead:\n%s", view)
+	}
+	// Going back and changing an answer.
+	w = pressWizard(w, "left")
+	if view := w.View(); !strings.Contains(view, "bead b-2") || !strings.Contains(view, "will skip") {
+		t.Errorf("retry step after skipping it:\n%s", view)
+	}
+	w = pressWizard(w, "right", "a")
+	if w.Cursor != len(w.Steps) || !strings.Contains(w.View(), "Apply 2 of 3 changes?") {
+		t.Fatalf("summary:\n%s", w.View())
+	}
+
+	w = pressWizard(w, "enter")
+	if !w.Confirmed || w.Canceled {
+		t.Fatal("enter on the summary did not confirm")
+	}
+	var got []string
+	for _, a := range w.ApprovedPlan().Actions {
+		got = append(got, a.PhaseID)
+	}
+	if strings.Join(got, ",") != "api,done,old" {
+		t.Errorf("approved actions = %v, want api, the no-op skip, and old", got)
+	}
+}
+
+func TestResumeWizardApproveRestAndCancel(t *testing.T) {
+	t.Parallel()
+
+	w := pressWizard(NewResumeWizard(wizardFixture()), "a", "k", "A")
+	if w.Cursor != len(w.Steps) || !w.Approved[0] || w.Approved[1] || !w.Approved[2] {
+		t.Errorf("A should approve the remaining steps and jump to the summary: %+v", w.Approved)
+	}
+
+	w = pressWizard(w, "esc")
+	if !w.Canceled || w.Confirmed {
+		t.Error("esc did not cancel the wizard")
+	}
+}
+
+func TestResumeWizardCreateIsRequired(t *testing.T) {
+	t.Parallel()
+
+	w := NewResumeWizard(wizardFixture())
+	if view := w.View(); strings.Contains(view, "k:skip") || !strings.Contains(view, "(required)") {
+		t.Errorf("create step should offer no skip:\n%s", view)
+	}
+	// Skip leaves a create approved, and keys that mean something else in
+	// the TUI (Stop's s, the old n) do nothing.
+	w = pressWizard(w, "k", "s", "n")
+	if w.Cursor != 0 || !w.Approved[0] {
+		t.Errorf("cursor = %d, approved = %v; want the create still current and approved", w.Cursor, w.Approved)
+	}
+}
+
+func TestRunResumeWizardNothingToReview(t *testing.T) {
+	t.Parallel()
+
+	plan := &nebula.Plan{Actions: []nebula.Action{{PhaseID: "a", Type: nebula.ActionSkip}}}
+	got, err := RunResumeWizard(plan, &nebula.Nebula{}, &nebula.State{})
+	if err != nil || got != plan {
+		t.Errorf("RunResumeWizard = %v, %v; want the plan back unchanged", got, err)
+	}
+}
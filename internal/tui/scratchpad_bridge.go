@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// ScratchpadBridge tails a nebula's scratchpad file and sends each note an
+// agent leaves with `quasar nebula note` to the TUI as a MsgScratchpadEntry.
+// It reads from the start of the file, so notes from earlier runs of the
+// nebula reappear on resume.
+type ScratchpadBridge struct {
+	program  *tea.Program
+	path     string
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScratchpadBridge creates a bridge that tails the scratchpad of the
+// nebula in dir and sends its notes to the TUI program.
+func NewScratchpadBridge(p *tea.Program, dir string) *ScratchpadBridge {
+	return &ScratchpadBridge{
+		program: p,
+		path:    nebula.ScratchpadPath(dir),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start begins tailing the scratchpad in a background goroutine, creating
+// the file if no agent has written to it yet.
+func (sb *ScratchpadBridge) Start() error {
+	f, err := os.OpenFile(sb.path, os.O_RDONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("scratchpad bridge: open %s: %w", sb.path, err)
+	}
+	go sb.tail(f)
+	return nil
+}
+
+// Stop signals the tailing goroutine to exit. It is safe to call multiple times.
+func (sb *ScratchpadBridge) Stop() {
+	sb.stopOnce.Do(func() { close(sb.done) })
+}
+
+// tail sends each note in f, and each note appended later, to the program.
+func (sb *ScratchpadBridge) tail(f *os.File) {
+	defer f.Close()
+	tailLines(f, sb.done, func(line []byte) {
+		var note nebula.ScratchpadNote
+		if err := json.Unmarshal(line, &note); err != nil {
+			return // skip malformed lines
+		}
+		sb.program.Send(noteEntry(note))
+	})
+}
+
+// noteEntry converts an agent's note to a scratchpad entry, prefixing the
+// text with the agent's role when it gave one.
+func noteEntry(note nebula.ScratchpadNote) MsgScratchpadEntry {
+	text := note.Text
+	if note.Role != "" {
+		text = note.Role + ": " + text
+	}
+	return MsgScratchpadEntry{Timestamp: note.Time.Local(), PhaseID: note.PhaseID, Text: text}
+}
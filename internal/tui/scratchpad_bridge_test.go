@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+func TestNoteEntry(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		note nebula.ScratchpadNote
+		want string
+	}{
+		{nebula.ScratchpadNote{Time: at, PhaseID: "api", Role: "coder", Text: "trying a router table"}, "coder: trying a router table"},
+		{nebula.ScratchpadNote{Time: at, PhaseID: "api", Text: "no role given"}, "no role given"},
+	}
+	for _, tt := range tests {
+		got := noteEntry(tt.note)
+		if got.Text != tt.want || got.PhaseID != "api" || !got.Timestamp.Equal(at) {
+			t.Errorf("noteEntry(%+v) = %+v, want text %q", tt.note, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Cost is sampled at most once per costSampleInterval into a ring of
+// costSampleCount samples, so the sparkline covers the last six minutes.
+const (
+	costSampleInterval = 15 * time.Second
+	costSampleCount    = 25
+)
+
+// sparkBlocks are the sparkline levels, lowest first.
+const sparkBlocks = "▁▂▃▄▅▆▇█"
+
+// costSample is the run's total cost at one point in time.
+type costSample struct {
+	at   time.Time
+	cost float64
+}
+
+// CostHistory is a fixed-size ring buffer of cost samples. It is a value
+// type so the status bar can be copied freely.
+type CostHistory struct {
+	samples [costSampleCount]costSample
+	next    int // index the next sample is written to
+	n       int // number of samples held
+}
+
+// Record adds a sample of the total cost, unless the last one was taken
+// less than costSampleInterval ago.
+func (h *CostHistory) Record(now time.Time, cost float64) {
+	if h.n > 0 && now.Sub(h.at(h.n-1).at) < costSampleInterval {
+		return
+	}
+	h.samples[h.next] = costSample{at: now, cost: cost}
+	h.next = (h.next + 1) % costSampleCount
+	h.n = min(h.n+1, costSampleCount)
+}
+
+// at returns the i-th held sample, oldest first.
+func (h *CostHistory) at(i int) costSample {
+	start := (h.next - h.n + costSampleCount) % costSampleCount
+	return h.samples[(start+i)%costSampleCount]
+}
+
+// Rate returns the average spend in USD per minute across the held
+// samples, and false until there are two samples to compare.
+func (h CostHistory) Rate() (float64, bool) {
+	if h.n < 2 {
+		return 0, false
+	}
+	first, last := h.at(0), h.at(h.n-1)
+	mins := last.at.Sub(first.at).Minutes()
+	if mins <= 0 {
+		return 0, false
+	}
+	return max(last.cost-first.cost, 0) / mins, true
+}
+
+// Sparkline renders the spend between consecutive samples as block
+// characters scaled to the largest step in the window, so a spike stands
+// out as a tall bar. It returns "" until there are two samples.
+func (h CostHistory) Sparkline() string {
+	if h.n < 2 {
+		return ""
+	}
+	steps := make([]float64, h.n-1)
+	peak := 0.0
+	for i := range steps {
+		steps[i] = max(h.at(i+1).cost-h.at(i).cost, 0)
+		peak = max(peak, steps[i])
+	}
+	levels := []rune(sparkBlocks)
+	var b strings.Builder
+	for _, s := range steps {
+		lvl := 0
+		if peak > 0 {
+			lvl = int(s / peak * float64(len(levels)-1))
+		}
+		b.WriteRune(levels[lvl])
+	}
+	return b.String()
+}
+
+// renderBurnRate renders the bottom bar's spend-velocity segment, e.g.
+// "rate $0.12/min ▁▂▅█▃", dropping the sparkline when ascii is set. It
+// returns "" until the rate is known.
+func (s StatusBar) renderBurnRate(label, value lipgloss.Style) string {
+	rate, ok := s.CostHistory.Rate()
+	if !ok {
+		return ""
+	}
+	out := label.Render("rate ") + value.Render(fmt.Sprintf("$%.2f/min", rate))
+	if !s.ASCII {
+		out += " " + lipgloss.NewStyle().Foreground(colorAccent).Render(s.CostHistory.Sparkline())
+	}
+	return out
+}
+
+// unicodeLocale reports whether the locale named by the environment uses
+// UTF-8, checking LC_ALL, LC_CTYPE, and LANG in order of precedence.
+func unicodeLocale(getenv func(string) string) bool {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := getenv(key); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
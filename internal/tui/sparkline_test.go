@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCostHistoryRecord(t *testing.T) {
+	t.Parallel()
+
+	var h CostHistory
+	start := time.Unix(0, 0)
+	h.Record(start, 0)
+	h.Record(start.Add(time.Second), 5) // too soon, dropped
+	if h.n != 1 {
+		t.Fatalf("held %d samples, want 1", h.n)
+	}
+	if _, ok := h.Rate(); ok {
+		t.Error("rate known with a single sample")
+	}
+
+	// Overfill the ring; only the newest costSampleCount samples remain.
+	for i := 1; i <= costSampleCount+5; i++ {
+		h.Record(start.Add(time.Duration(i)*costSampleInterval), float64(i))
+	}
+	if h.n != costSampleCount {
+		t.Fatalf("held %d samples, want %d", h.n, costSampleCount)
+	}
+	if got := h.at(0).cost; got != 6 {
+		t.Errorf("oldest sample cost = %v, want 6", got)
+	}
+	// $1 per 15s step is $4 a minute.
+	if rate, ok := h.Rate(); !ok || rate != 4 {
+		t.Errorf("rate = %v, %v; want 4, true", rate, ok)
+	}
+}
+
+func TestCostHistorySparkline(t *testing.T) {
+	t.Parallel()
+
+	var h CostHistory
+	start := time.Unix(0, 0)
+	for i, cost := range []float64{0, 0.1, 0.2, 1.0, 1.0} {
+		h.Record(start.Add(time.Duration(i)*costSampleInterval), cost)
+	}
+	// Steps of 0.1, 0.1, 0.8, 0: the spike is the full block.
+	if got := h.Sparkline(); got != "▁▁█▁" {
+		t.Errorf("sparkline = %q, want %q", got, "▁▁█▁")
+	}
+	if got := (CostHistory{}).Sparkline(); got != "" {
+		t.Errorf("empty sparkline = %q", got)
+	}
+}
+
+func TestBottomBarBurnRate(t *testing.T) {
+	t.Parallel()
+
+	var h CostHistory
+	start := time.Unix(0, 0)
+	h.Record(start, 0)
+	h.Record(start.Add(time.Minute), 0.5)
+
+	sb := StatusBar{CostUSD: 0.5, Width: 120, CostHistory: h}
+	if view := sb.BottomBar(); !strings.Contains(view, "rate $0.50/min") || !strings.Contains(view, "█") {
+		t.Errorf("expected rate and sparkline, got: %s", view)
+	}
+	sb.ASCII = true
+	if view := sb.BottomBar(); !strings.Contains(view, "rate $0.50/min") || strings.Contains(view, "█") {
+		t.Errorf("expected the numeric rate only, got: %s", view)
+	}
+	if view := (StatusBar{Width: 120}).BottomBar(); strings.Contains(view, "rate") {
+		t.Errorf("expected no rate before two samples, got: %s", view)
+	}
+}
+
+func TestUnicodeLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"unset", nil, false},
+		{"utf-8 lang", map[string]string{"LANG": "en_US.UTF-8"}, true},
+		{"utf8 lang", map[string]string{"LANG": "C.utf8"}, true},
+		{"posix lang", map[string]string{"LANG": "C"}, false},
+		{"lc_all wins", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{"lc_ctype before lang", map[string]string{"LC_CTYPE": "en_US.UTF-8", "LANG": "C"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := unicodeLocale(func(k string) string { return tt.env[k] }); got != tt.want {
+				t.Errorf("unicodeLocale = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,23 +10,30 @@ import (
 
 // StatusBar renders the persistent top bar with task name, progress, budget, elapsed.
 type StatusBar struct {
-	Name         string
-	BeadID       string
-	Cycle        int
-	MaxCycles    int
-	Completed    int
-	Total        int
-	InProgress   int // phases currently being worked on
-	TotalTokens  int // aggregate token usage across all agents
-	CostUSD      float64
-	BudgetUSD    float64
-	StartTime    time.Time
-	FinalElapsed time.Duration
-	Width        int
-	Paused       bool
-	Stopping     bool
-	Resources    ResourceSnapshot
-	Thresholds   ResourceThresholds
+	Name        string
+	Icon        string // nebula icon shown before Name; "" for none
+	Color       string // nebula color tinting Name; "" for the default
+	BeadID      string
+	Cycle       int
+	MaxCycles   int
+	Completed   int
+	Total       int
+	InProgress  int // phases currently being worked on
+	TotalTokens int // aggregate token usage across all agents
+	CostUSD     float64
+	BudgetUSD   float64
+	// BudgetWarned is set once the loop reports the soft budget threshold;
+	// BudgetExceeded once the hard limit is hit. They drive the cost color.
+	BudgetWarned   bool
+	BudgetExceeded bool
+	StartTime      time.Time
+	FinalElapsed   time.Duration
+	Width          int
+	Paused         bool
+	Stopping       bool
+	Draining       bool
+	Resources      ResourceSnapshot
+	Thresholds     ResourceThresholds
 
 	// Hail counters for the status badge.
 	HailCount         int // total unresolved hails
@@ -35,6 +42,33 @@ type StatusBar struct {
 	// Gate queue counter for the status badge.
 	GateQueueCount int // number of gate prompts waiting behind the active one
 
+	// BeadsBuffered counts bead operations queued locally while the beads
+	// backend is unavailable. Non-zero shows a degraded badge.
+	BeadsBuffered int
+
+	// Conflicts counts file-level conflicts between parallel phases.
+	Conflicts int
+
+	// RateLimit is the shared agent rate limiter's utilization (0–1) and
+	// Throttled whether an invocation is waiting on it. Zero hides the segment.
+	RateLimit float64
+	Throttled bool
+
+	// ETA is the estimated time left as of ETAAt; a zero ETAAt hides it.
+	// ETANarrow marks an estimate backed mostly by recorded phase durations.
+	ETA       time.Duration
+	ETAAt     time.Time
+	ETANarrow bool
+
+	// Deadline is when the run's max runtime stops new phases from
+	// starting; zero hides the countdown.
+	Deadline time.Time
+
+	// CostHistory samples the run's cost for the bottom bar's burn rate and
+	// sparkline. ASCII drops the sparkline on terminals without Unicode.
+	CostHistory CostHistory
+	ASCII       bool
+
 	// Home mode fields.
 	HomeMode        bool // true when displaying the home landing page
 	HomeNebulaCount int  // number of discovered nebulas
@@ -122,8 +156,7 @@ func (s StatusBar) buildRightSegments(compact bool) []statusSegment {
 	// Cost segment (priority 2).
 	// When a budget is set, color-code the cost based on consumption ratio.
 	if s.BudgetUSD > 0 && !compact {
-		ratio := s.CostUSD / s.BudgetUSD
-		costColor := budgetColor(ratio)
+		costColor := s.costColor()
 		costStyle := lipgloss.NewStyle().Background(colorSurface).Foreground(costColor)
 		budgetBar := renderBudgetBar(s.CostUSD, s.BudgetUSD, 10)
 		costText := costStyle.Render(fmt.Sprintf("$%.2f", s.CostUSD)) + barBg.Render(" ") +
@@ -131,8 +164,12 @@ func (s StatusBar) buildRightSegments(compact bool) []statusSegment {
 			costStyle.Render(fmt.Sprintf("$%.2f", s.BudgetUSD))
 		segments = append(segments, statusSegment{text: costText, priority: 2})
 	} else {
+		costStyle := styleStatusCost
+		if s.BudgetWarned || s.BudgetExceeded {
+			costStyle = costStyle.Foreground(s.costColor())
+		}
 		segments = append(segments, statusSegment{
-			text:     styleStatusCost.Render(fmt.Sprintf("$%.2f", s.CostUSD)),
+			text:     costStyle.Render(fmt.Sprintf("$%.2f", s.CostUSD)),
 			priority: 2,
 		})
 	}
@@ -154,6 +191,33 @@ func (s StatusBar) buildRightSegments(compact bool) []statusSegment {
 		segments = append(segments, statusSegment{text: barBg.Render("  ") + gateBadge, priority: 3})
 	}
 
+	// Beads degraded badge (priority 3 — tracking data is at risk until replayed).
+	if s.BeadsBuffered > 0 {
+		beadsStyle := lipgloss.NewStyle().Background(colorSurface).Foreground(colorBudgetWarn)
+		beadsBadge := beadsStyle.Render(fmt.Sprintf("⚠ beads: %d queued", s.BeadsBuffered))
+		segments = append(segments, statusSegment{text: barBg.Render("  ") + beadsBadge, priority: 3})
+	}
+
+	// Conflict badge (priority 3 — parallel phases are stepping on each other).
+	if s.Conflicts > 0 {
+		conflictStyle := lipgloss.NewStyle().Background(colorSurface).Foreground(colorDanger)
+		label := "conflicts"
+		if s.Conflicts == 1 {
+			label = "conflict"
+		}
+		conflictBadge := conflictStyle.Render(fmt.Sprintf("⚔ %d %s", s.Conflicts, label))
+		segments = append(segments, statusSegment{text: barBg.Render("  ") + conflictBadge, priority: 3})
+	}
+
+	// Rate limit segment (priority 3 while throttled, otherwise dropped first).
+	if s.Throttled {
+		rateStyle := lipgloss.NewStyle().Background(colorSurface).Foreground(colorBudgetWarn)
+		segments = append(segments, statusSegment{text: barBg.Render("  ") + rateStyle.Render("⏱ throttled"), priority: 3})
+	} else if s.RateLimit > 0 && !compact {
+		rateText := styleStatusElapsed.Render(fmt.Sprintf("  ⏱ %d%%", int(s.RateLimit*100+0.5)))
+		segments = append(segments, statusSegment{text: rateText, priority: 0})
+	}
+
 	// Resource indicator segment (priority 0 — dropped before elapsed).
 	resText := s.renderResourceSegment(compact)
 	if resText != "" {
@@ -174,6 +238,22 @@ func (s StatusBar) buildRightSegments(compact bool) []statusSegment {
 		})
 	}
 
+	// ETA segment (priority 1 — shown until the run finishes).
+	if !s.ETAAt.IsZero() && s.FinalElapsed == 0 {
+		segments = append(segments, statusSegment{
+			text:     styleStatusElapsed.Render("  " + s.renderETA()),
+			priority: 1,
+		})
+	}
+
+	// Max runtime countdown (priority 2 — outlasts the ETA it bounds).
+	if !s.Deadline.IsZero() && s.FinalElapsed == 0 {
+		segments = append(segments, statusSegment{
+			text:     styleStatusElapsed.Render("  " + s.renderDeadline()),
+			priority: 2,
+		})
+	}
+
 	return segments
 }
 
@@ -278,10 +358,11 @@ func (s StatusBar) buildNameSegment(compact bool, maxWidth int) string {
 
 	if s.Total > 0 {
 		// Nebula mode: name + progress bar + counts.
-		name := s.Name
+		name := withIcon(s.Icon, s.Name)
+		nameStyle := identityStyle(styleStatusName, s.Color)
 		if compact {
 			name = TruncateWithEllipsis(name, min(12, maxWidth))
-			return styleStatusName.Render(name)
+			return nameStyle.Render(name)
 		}
 
 		// Full mode: "name  ━━━━░░░░ 2/5 · 2 active"
@@ -309,7 +390,7 @@ func (s StatusBar) buildNameSegment(compact bool, maxWidth int) string {
 			availableForName = 4
 		}
 		name = TruncateWithEllipsis(name, availableForName)
-		return styleStatusName.Render(name) + fullSuffix
+		return nameStyle.Render(name) + fullSuffix
 	}
 
 	if s.BeadID != "" {
@@ -337,12 +418,15 @@ func (s StatusBar) buildNameSegment(compact bool, maxWidth int) string {
 	return ""
 }
 
-// renderStateIndicator returns the styled STOPPING/PAUSED indicator, or empty string.
+// renderStateIndicator returns the styled STOPPING/DRAINING/PAUSED indicator, or empty string.
 func (s StatusBar) renderStateIndicator() string {
 	barBg := lipgloss.NewStyle().Background(colorSurface)
 	if s.Stopping {
 		return barBg.Render("  ") + styleStatusStopping.Render("STOPPING")
 	}
+	if s.Draining {
+		return barBg.Render("  ") + styleStatusStopping.Render("DRAINING")
+	}
 	if s.Paused {
 		return barBg.Render("  ") + styleStatusPaused.Render("PAUSED")
 	}
@@ -488,6 +572,22 @@ func resourceLevelStyle(level ResourceLevel) lipgloss.Style {
 	}
 }
 
+// costColor returns the cost segment color. Loop-reported budget signals take
+// precedence: red once the limit is hit, amber after the soft warning.
+// Otherwise the color follows the consumption ratio.
+func (s StatusBar) costColor() lipgloss.Color {
+	switch {
+	case s.BudgetExceeded:
+		return colorDanger
+	case s.BudgetWarned:
+		return colorBudgetWarn
+	case s.BudgetUSD > 0:
+		return budgetColor(s.CostUSD / s.BudgetUSD)
+	default:
+		return colorMutedLight
+	}
+}
+
 // budgetColor returns a color based on budget consumption ratio.
 // Under 30%: muted (calm), 30-50%: amber, 50-80%: orange warning, over 80%: red danger.
 func budgetColor(ratio float64) lipgloss.Color {
@@ -504,8 +604,8 @@ func budgetColor(ratio float64) lipgloss.Color {
 }
 
 // BottomBar renders the cockpit-style aggregate stats line pinned below the main
-// content area: tokens, cost, elapsed, and a block-character progress bar.
-// Format: " tokens 284.3k | cost $1.42 | elapsed 4m 32s | progress █████░░░ 5/8"
+// content area: tokens, cost, burn rate, elapsed, and a block-character progress bar.
+// Format: " tokens 284.3k | cost $1.42 | rate $0.12/min ▁▃█▂ | elapsed 4m 32s | progress █████░░░ 5/8"
 func (s StatusBar) BottomBar() string {
 	if s.Width <= 0 {
 		return ""
@@ -522,6 +622,11 @@ func (s StatusBar) BottomBar() string {
 	// Cost segment.
 	parts = append(parts, label.Render("cost ")+value.Render(fmt.Sprintf("$%.2f", s.CostUSD)))
 
+	// Burn rate segment, once there are two cost samples.
+	if rate := s.renderBurnRate(label, value); rate != "" {
+		parts = append(parts, rate)
+	}
+
 	// Elapsed segment.
 	var elapsed time.Duration
 	if s.FinalElapsed > 0 {
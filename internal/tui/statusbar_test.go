@@ -12,6 +12,14 @@ import (
 func TestStatusBarView(t *testing.T) {
 	t.Parallel()
 
+	t.Run("nebula icon precedes the name", func(t *testing.T) {
+		t.Parallel()
+		sb := StatusBar{Name: "rocket", Icon: "🚀", Color: "208", Total: 3, Width: 100}
+		if view := sb.View(); !strings.Contains(view, "🚀 rocket") {
+			t.Errorf("expected icon and name in view, got: %s", view)
+		}
+	})
+
 	t.Run("FinalElapsed freezes timer", func(t *testing.T) {
 		t.Parallel()
 		sb := StatusBar{
@@ -196,6 +204,17 @@ func TestStatusBarView(t *testing.T) {
 			t.Errorf("expected budget $10.00 in view, got: %s", view)
 		}
 	})
+	t.Run("rate limit utilization and throttling", func(t *testing.T) {
+		t.Parallel()
+		sb := StatusBar{RateLimit: 0.45, Width: 120}
+		if view := sb.View(); !strings.Contains(view, "⏱ 45%") {
+			t.Errorf("expected rate limit utilization in view, got: %s", view)
+		}
+		sb.Throttled = true
+		if view := sb.View(); !strings.Contains(view, "⏱ throttled") {
+			t.Errorf("expected throttled badge in view, got: %s", view)
+		}
+	})
 }
 
 func TestDropSegments(t *testing.T) {
@@ -37,6 +37,7 @@ const (
 	iconWaiting = "·"
 	iconGate    = "⊘"
 	iconSkipped = "–"
+	iconManual  = "◇"
 )
 
 // Status bar styles — visually dominant with solid background.
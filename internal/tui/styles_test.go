@@ -500,6 +500,28 @@ func TestBudgetColor(t *testing.T) {
 	}
 }
 
+func TestStatusBarCostColor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		bar  StatusBar
+		want lipgloss.Color
+	}{
+		{"no budget", StatusBar{CostUSD: 5}, colorMutedLight},
+		{"ratio based", StatusBar{CostUSD: 4, BudgetUSD: 10}, colorAccent},
+		{"warned overrides ratio", StatusBar{CostUSD: 9, BudgetUSD: 10, BudgetWarned: true}, colorBudgetWarn},
+		{"exceeded overrides warned", StatusBar{CostUSD: 10, BudgetUSD: 10, BudgetWarned: true, BudgetExceeded: true}, colorDanger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.bar.costColor(); got != tt.want {
+				t.Errorf("costColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatElapsed(t *testing.T) {
 	t.Parallel()
 	t.Run("zero time", func(t *testing.T) {
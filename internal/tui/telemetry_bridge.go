@@ -60,42 +60,47 @@ func (tb *TelemetryBridge) Stop() {
 	tb.stopOnce.Do(func() { close(tb.done) })
 }
 
-// tail reads lines from the file, polling for new content.
+// tail converts the telemetry events appended to f into scratchpad entries.
 func (tb *TelemetryBridge) tail(f *os.File) {
 	defer f.Close()
+	tailLines(f, tb.done, func(line []byte) {
+		var evt telemetry.Event
+		if err := json.Unmarshal(line, &evt); err != nil {
+			return // skip malformed lines
+		}
+		if text := eventToScratchpad(evt); text != "" {
+			tb.program.Send(MsgScratchpadEntry{
+				Timestamp: evt.Timestamp,
+				PhaseID:   evt.TaskID,
+				Text:      text,
+			})
+		}
+	})
+}
 
+// tailLines passes each non-empty line read from f to handle, polling for
+// new content until done is closed.
+func tailLines(f *os.File, done <-chan struct{}, handle func(line []byte)) {
 	scanner := bufio.NewScanner(f)
 	const pollInterval = 250 * time.Millisecond
 
 	for {
 		for scanner.Scan() {
-			line := scanner.Bytes()
-			if len(line) == 0 {
-				continue
-			}
-			var evt telemetry.Event
-			if err := json.Unmarshal(line, &evt); err != nil {
-				continue // skip malformed lines
-			}
-			if text := eventToScratchpad(evt); text != "" {
-				tb.program.Send(MsgScratchpadEntry{
-					Timestamp: evt.Timestamp,
-					PhaseID:   evt.TaskID,
-					Text:      text,
-				})
+			if line := scanner.Bytes(); len(line) > 0 {
+				handle(line)
 			}
 		}
 
 		// Check for shutdown.
 		select {
-		case <-tb.done:
+		case <-done:
 			return
 		default:
 		}
 
 		// Poll for new data.
 		select {
-		case <-tb.done:
+		case <-done:
 			return
 		case <-time.After(pollInterval):
 		}
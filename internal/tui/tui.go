@@ -5,6 +5,8 @@ import (
 	"io"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
 )
 
 // Program is an alias for tea.Program, exposed so callers don't need
@@ -42,15 +44,25 @@ func NewProgramRaw(mode Mode) *Program {
 // nebulaDir is the path to the nebula directory, used for writing intervention
 // files (PAUSE/STOP) from TUI keyboard shortcuts.
 // If noSplash is true, the binary-star splash animation is skipped.
-func NewNebulaProgram(name string, phases []PhaseInfo, nebulaDir string, noSplash bool) *Program {
+func NewNebulaProgram(info nebula.Info, phases []PhaseInfo, nebulaDir string, noSplash bool) *Program {
+	model := NewNebulaModel(info, phases, nebulaDir, noSplash)
+	return tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+}
+
+// NewNebulaModel creates the nebula-mode model behind NewNebulaProgram, for
+// callers that compose it into another model (see MultiModel).
+func NewNebulaModel(info nebula.Info, phases []PhaseInfo, nebulaDir string, noSplash bool) AppModel {
 	model := NewAppModel(ModeNebula)
 	model.Detail = NewDetailPanel(80, 10)
 	if noSplash {
 		model.DisableSplash()
 	}
-	model.StatusBar.Name = name
+	model.StatusBar.Name = info.Name
+	model.StatusBar.Icon = info.Icon
+	model.StatusBar.Color = info.ColorCode()
 	model.StatusBar.Total = len(phases)
 	model.NebulaView.InitPhases(phases)
+	model.seedAttempts(phases)
 	model.Graph = NewGraphView(phases, 80, 20)
 	// Seed the completed count from phases that are already done in saved state.
 	for _, p := range phases {
@@ -59,7 +71,7 @@ func NewNebulaProgram(name string, phases []PhaseInfo, nebulaDir string, noSplas
 		}
 	}
 	model.NebulaDir = nebulaDir
-	return tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	return model
 }
 
 // NewHomeProgram creates a home-mode TUI with the nebula list pre-populated.
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -19,6 +20,14 @@ type WorkerCard struct {
 	Claims     []string // file paths currently touched by this quasar
 	Activity   string   // human-readable activity: "coding...", "reviewing..."
 	AgentRole  string   // "coder" or "reviewer"
+	Refactor   string   // latest applied refactor, e.g. "refactor applied (+2 −1 lines)"
+
+	LastActivity  time.Time     // when the phase last showed signs of life
+	SilentFor     time.Duration // time since LastActivity as of the last tick
+	silenceWarned bool          // a silence toast was shown since LastActivity
+
+	Pinned      bool        // kept on the board after the phase finishes
+	FinalStatus PhaseStatus // PhaseDone or PhaseFailed once a pinned card's phase ends
 }
 
 // workerCardMinWidth is the minimum width for a single worker card.
@@ -151,17 +160,45 @@ func (wc *WorkerCard) View(width int) string {
 		activity = activityFromRole(wc.AgentRole)
 	}
 	b.WriteString(actStyle.Render(activity))
+	if wc.Refactor != "" {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(colorAccent).Render(TruncateWithEllipsis("⟳ "+wc.Refactor, innerWidth)))
+	}
+	if hb := wc.heartbeatLine(); hb != "" {
+		b.WriteString("\n")
+		b.WriteString(hb)
+	}
+
+	borderColor := colorMuted
+	if wc.Pinned {
+		borderColor = colorNebulaDeep
+		b.WriteString("\n")
+		b.WriteString(wc.pinBadge())
+	}
 
 	// Wrap in a rounded border box.
 	cardStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(colorMuted).
+		BorderForeground(borderColor).
 		Width(width-2). // account for border width
 		Padding(0, 1)
 
 	return cardStyle.Render(b.String())
 }
 
+// pinBadge renders the status line of a pinned card: the phase's final
+// outcome once it has finished, or a plain pin marker while it still runs.
+func (wc *WorkerCard) pinBadge() string {
+	switch wc.FinalStatus {
+	case PhaseDone:
+		return lipgloss.NewStyle().Foreground(colorSuccess).Bold(true).Render(iconDone + " done")
+	case PhaseFailed:
+		return lipgloss.NewStyle().Foreground(colorDanger).Bold(true).Render(iconFailed + " failed")
+	default:
+		return lipgloss.NewStyle().Foreground(colorNebula).Render("pinned")
+	}
+}
+
 // activityFromRole returns a default activity string based on the agent role.
 func activityFromRole(role string) string {
 	switch role {
@@ -177,18 +214,22 @@ func activityFromRole(role string) string {
 // ActiveWorkerCards extracts worker cards for all phases currently in the Running state.
 // The cards are ordered by quasar ID for stable rendering.
 func ActiveWorkerCards(cards map[string]*WorkerCard) []*WorkerCard {
+	return sortedWorkerCards(cards)
+}
+
+// sortedWorkerCards returns the cards in a map ordered by quasar ID for
+// stable rendering.
+func sortedWorkerCards(cards map[string]*WorkerCard) []*WorkerCard {
 	if len(cards) == 0 {
 		return nil
 	}
 
-	var active []*WorkerCard
+	var out []*WorkerCard
 	for _, card := range cards {
-		active = append(active, card)
+		out = append(out, card)
 	}
-
-	// Sort by QuasarID for stable ordering.
-	sortWorkerCards(active)
-	return active
+	sortWorkerCards(out)
+	return out
 }
 
 // quasarNum extracts the numeric suffix from a quasar ID (e.g. "q-12" → 12).
@@ -0,0 +1,95 @@
+package tui
+
+import "github.com/charmbracelet/bubbles/key"
+
+// workerCard returns the card tracking phaseID, whether it is still active
+// or has been pinned, or nil if the phase has no card.
+func (m *AppModel) workerCard(phaseID string) *WorkerCard {
+	if wc := m.WorkerCards[phaseID]; wc != nil {
+		return wc
+	}
+	return m.PinnedCards[phaseID]
+}
+
+// retireWorkerCard drops phaseID's active card when the phase finishes. A
+// pinned card stays on the board and records the phase's final status.
+func (m *AppModel) retireWorkerCard(phaseID string, final PhaseStatus) {
+	delete(m.WorkerCards, phaseID)
+	m.dropOverride(phaseID)
+	delete(m.cycleChs, phaseID)
+	if wc := m.PinnedCards[phaseID]; wc != nil {
+		wc.FinalStatus = final
+	}
+}
+
+// boardWorkerCards returns the cards shown beneath the board: active cards
+// first, then pinned ones, each ordered by quasar ID.
+func (m *AppModel) boardWorkerCards() []*WorkerCard {
+	return append(ActiveWorkerCards(m.WorkerCards), sortedWorkerCards(m.PinnedCards)...)
+}
+
+// pinTargetPhase returns the ID of the phase the pin key applies to: the
+// selected phase on the board tab, provided it has a card to pin or unpin.
+func (m *AppModel) pinTargetPhase() string {
+	if m.Mode != ModeNebula || m.Depth != DepthPhases || m.ActiveTab != TabBoard {
+		return ""
+	}
+	var p *PhaseEntry
+	if m.BoardActive {
+		m.Board.Phases = m.NebulaView.Phases
+		p = m.Board.SelectedPhase()
+	} else {
+		p = m.NebulaView.SelectedPhase()
+	}
+	if p == nil || m.workerCard(p.ID) == nil {
+		return ""
+	}
+	return p.ID
+}
+
+// handlePinKey toggles the pin on the selected phase's worker card. Pinning
+// moves the card from the active set to the pinned set, where phase
+// completion and failure no longer remove it; unpinning discards it, or
+// returns it to the active set if the phase is still running.
+func (m *AppModel) handlePinKey() {
+	phaseID := m.pinTargetPhase()
+	if phaseID == "" {
+		return
+	}
+
+	if wc := m.PinnedCards[phaseID]; wc != nil {
+		delete(m.PinnedCards, phaseID)
+		wc.Pinned = false
+		if p := m.findPhase(phaseID); p != nil && p.Status == PhaseWorking {
+			if m.WorkerCards == nil {
+				m.WorkerCards = make(map[string]*WorkerCard)
+			}
+			m.WorkerCards[phaseID] = wc
+		}
+		m.addMessage("[%s] worker card unpinned", phaseID)
+		return
+	}
+
+	wc := m.WorkerCards[phaseID]
+	delete(m.WorkerCards, phaseID)
+	if m.PinnedCards == nil {
+		m.PinnedCards = make(map[string]*WorkerCard)
+	}
+	wc.Pinned = true
+	m.PinnedCards[phaseID] = wc
+	m.addMessage("[%s] worker card pinned", phaseID)
+}
+
+// pinBinding returns the pin key with help text matching the selected
+// card's state, and false when there is no card to pin.
+func (m *AppModel) pinBinding() (key.Binding, bool) {
+	phaseID := m.pinTargetPhase()
+	if phaseID == "" {
+		return key.Binding{}, false
+	}
+	b := m.Keys.Pin
+	if m.PinnedCards[phaseID] != nil {
+		b.SetHelp("P", "unpin")
+	}
+	return b, true
+}
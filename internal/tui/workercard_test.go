@@ -3,6 +3,8 @@ package tui
 import (
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestWorkerCardView_BasicContent(t *testing.T) {
@@ -370,3 +372,77 @@ func TestWorkerCardLifecycle(t *testing.T) {
 		t.Fatalf("expected 0 worker cards after delete, got %d", len(m.WorkerCards))
 	}
 }
+
+func TestWorkerCardView_PinnedBadge(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		final PhaseStatus
+		want  string
+	}{
+		{"running", PhaseWaiting, "pinned"},
+		{"done", PhaseDone, iconDone + " done"},
+		{"failed", PhaseFailed, iconFailed + " failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wc := &WorkerCard{PhaseID: "p", QuasarID: "q-1", Pinned: true, FinalStatus: tt.final}
+			if out := wc.View(40); !strings.Contains(out, tt.want) {
+				t.Errorf("pinned card should show %q:\n%s", tt.want, out)
+			}
+		})
+	}
+
+	unpinned := (&WorkerCard{PhaseID: "p", QuasarID: "q-1"}).View(40)
+	if strings.Contains(unpinned, "pinned") {
+		t.Errorf("unpinned card should not show a pin badge:\n%s", unpinned)
+	}
+}
+
+func TestWorkerCardPin(t *testing.T) {
+	t.Parallel()
+	m := newNebulaModelWithPhases("", []PhaseEntry{
+		{ID: "p1", Title: "Phase 1"},
+		{ID: "p2", Title: "Phase 2"},
+	})
+	m.Width = 120
+	m.Height = 40
+	m.Splash = nil
+	pin := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}}
+
+	var tm tea.Model = *m
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "p1", Title: "Phase 1"})
+	tm, _ = tm.Update(MsgPhaseTaskStarted{PhaseID: "p2", Title: "Phase 2"})
+	tm, _ = tm.Update(pin) // cursor is on p1
+	got := tm.(AppModel)
+	if _, ok := got.WorkerCards["p1"]; ok {
+		t.Fatal("pinned card should leave the active set")
+	}
+	if wc := got.PinnedCards["p1"]; wc == nil || !wc.Pinned {
+		t.Fatalf("p1 card not pinned: %+v", got.PinnedCards)
+	}
+
+	// Updates still reach the pinned card while the phase runs.
+	tm, _ = tm.Update(MsgPhaseCycleStart{PhaseID: "p1", Cycle: 2, MaxCycles: 5})
+	tm, _ = tm.Update(MsgPhaseApproved{PhaseID: "p1"})
+	tm, _ = tm.Update(MsgPhaseError{PhaseID: "p2", Msg: "boom"})
+	got = tm.(AppModel)
+	wc := got.PinnedCards["p1"]
+	if wc == nil || wc.Cycle != 2 || wc.FinalStatus != PhaseDone {
+		t.Fatalf("pinned card after approval = %+v, want cycle 2 and PhaseDone", wc)
+	}
+	if len(got.WorkerCards) != 0 {
+		t.Errorf("unpinned cards should be removed on completion, got %d", len(got.WorkerCards))
+	}
+	if cards := got.boardWorkerCards(); len(cards) != 1 || cards[0].PhaseID != "p1" {
+		t.Errorf("board cards = %v, want only the pinned p1 card", cards)
+	}
+
+	tm, _ = tm.Update(pin)
+	got = tm.(AppModel)
+	if len(got.PinnedCards) != 0 || len(got.WorkerCards) != 0 {
+		t.Errorf("unpinning a finished card should discard it: active %d, pinned %d",
+			len(got.WorkerCards), len(got.PinnedCards))
+	}
+}
@@ -0,0 +1,191 @@
+package tycho
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+// Default ages after which StaleCheck callers consider claims and blocked
+// tasks stale.
+const (
+	DefaultStaleClaim = 10 * time.Minute
+	DefaultStaleTask  = 30 * time.Minute
+)
+
+// StaleItem describes a claim or task that appears stuck.
+type StaleItem struct {
+	Kind        string        // "claim" or "task"
+	ID          string        // filepath or task_id
+	Phase       string        // task the item belongs to: the claim's owner, or the task itself
+	Age         time.Duration // time since creation/last transition
+	Details     string        // human-readable context
+	Remediation string        // what the Remediator did about the item; empty if nothing
+}
+
+// key identifies the item across scans.
+func (it StaleItem) key() string {
+	return it.Kind + ":" + it.ID
+}
+
+// Remediator acts on an item that has been stale for longer than the
+// scheduler's RemediateAfter, for example by escalating it to a hail.
+type Remediator interface {
+	// Remediate acts on item and returns a short description of the
+	// action, such as "escalated to a hail". An empty description means
+	// nothing was done.
+	Remediate(ctx context.Context, item StaleItem) (string, error)
+}
+
+// RemediatorFunc adapts a function to the Remediator interface.
+type RemediatorFunc func(ctx context.Context, item StaleItem) (string, error)
+
+// Remediate calls f.
+func (f RemediatorFunc) Remediate(ctx context.Context, item StaleItem) (string, error) {
+	return f(ctx, item)
+}
+
+// NoopRemediator takes no action. It is what a nil Scheduler.Remediator
+// amounts to.
+type NoopRemediator struct{}
+
+// Remediate does nothing.
+func (NoopRemediator) Remediate(context.Context, StaleItem) (string, error) {
+	return "", nil
+}
+
+// HailRemediator escalates a stale item to a hail on the item's phase: it
+// posts a discovery to the fabric and surfaces it through OnHail.
+type HailRemediator struct {
+	Fabric fabric.Fabric
+	OnHail func(phaseID string, discovery fabric.Discovery) // may be nil
+}
+
+// Remediate posts the hail for item.
+func (h HailRemediator) Remediate(ctx context.Context, item StaleItem) (string, error) {
+	disc := fabric.Discovery{
+		SourceTask: item.Phase,
+		Kind:       fabric.DiscoveryStaleWork,
+		Detail:     fmt.Sprintf("stale %s %q for %s: %s", item.Kind, item.ID, item.Age.Round(time.Second), item.Details),
+	}
+	if _, err := h.Fabric.PostDiscovery(ctx, disc); err != nil {
+		return "", fmt.Errorf("posting hail: %w", err)
+	}
+	if h.OnHail != nil {
+		h.OnHail(item.Phase, disc)
+	}
+	return "escalated to a hail", nil
+}
+
+// PingRemediator pings the worker of a stale item's phase: it emits a note
+// pulse to the phase, which its agents see in the fabric context of their
+// next prompt.
+type PingRemediator struct {
+	Fabric fabric.Fabric
+}
+
+// Remediate emits the ping for item.
+func (p PingRemediator) Remediate(ctx context.Context, item StaleItem) (string, error) {
+	pulse := fabric.Pulse{
+		TaskID:  item.Phase,
+		Kind:    fabric.PulseNote,
+		Content: fmt.Sprintf("Stale %s %q for %s (%s). Finish or release it.", item.Kind, item.ID, item.Age.Round(time.Second), item.Details),
+	}
+	if err := p.Fabric.EmitPulse(ctx, pulse); err != nil {
+		return "", fmt.Errorf("pinging worker: %w", err)
+	}
+	return "pinged the worker of " + item.Phase, nil
+}
+
+// StaleCheck identifies tasks and claims that appear stuck.
+// Claims older than staleClaim with no corresponding running task, and tasks
+// with no state transition within staleTask, are flagged. Items stale for at
+// least RemediateAfter are handed to the Remediator once each; every item
+// carries the action taken on it, in this scan or an earlier one. StaleCheck
+// is not safe for concurrent use.
+func (s *Scheduler) StaleCheck(ctx context.Context, staleClaim, staleTask time.Duration) ([]StaleItem, error) {
+	var items []StaleItem
+
+	// Check file claims for staleness.
+	claims, err := s.Fabric.AllClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching claims: %w", err)
+	}
+
+	states, err := s.Fabric.AllPhaseStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching phase states: %w", err)
+	}
+
+	now := time.Now()
+	for _, c := range claims {
+		age := now.Sub(c.ClaimedAt)
+		if age < staleClaim {
+			continue
+		}
+		// A claim is stale if the owning task is not running.
+		ownerState := states[c.OwnerTask]
+		if ownerState == fabric.StateRunning {
+			continue
+		}
+		items = append(items, StaleItem{
+			Kind:    "claim",
+			ID:      c.Filepath,
+			Phase:   c.OwnerTask,
+			Age:     age,
+			Details: fmt.Sprintf("owner %q in state %q", c.OwnerTask, ownerState),
+		})
+	}
+
+	// Check blocked tasks for staleness.
+	if s.Blocked != nil {
+		for _, bp := range s.Blocked.All() {
+			age := now.Sub(bp.BlockedAt)
+			if age >= staleTask {
+				items = append(items, StaleItem{
+					Kind:    "task",
+					ID:      bp.PhaseID,
+					Phase:   bp.PhaseID,
+					Age:     age,
+					Details: fmt.Sprintf("blocked: %s (retries: %d)", bp.LastResult.Reason, bp.RetryCount),
+				})
+			}
+		}
+	}
+
+	s.remediate(ctx, items)
+	return items, nil
+}
+
+// remediate runs the Remediator on items that have passed RemediateAfter
+// and have not been acted on yet, and records each item's action on it.
+func (s *Scheduler) remediate(ctx context.Context, items []StaleItem) {
+	if s.Remediator == nil {
+		return
+	}
+	if s.remediated == nil {
+		s.remediated = make(map[string]string)
+	}
+	for i := range items {
+		it := &items[i]
+		if action, done := s.remediated[it.key()]; done {
+			it.Remediation = action
+			continue
+		}
+		if it.Age < s.RemediateAfter {
+			continue
+		}
+		action, err := s.Remediator.Remediate(ctx, *it)
+		if err != nil {
+			fmt.Fprintf(s.logger(), "warning: remediating stale %s %q: %v\n", it.Kind, it.ID, err)
+			continue
+		}
+		s.remediated[it.key()] = action
+		it.Remediation = action
+		if action != "" {
+			fmt.Fprintf(s.logger(), "  Stale %s %q: %s\n", it.Kind, it.ID, action)
+		}
+	}
+}
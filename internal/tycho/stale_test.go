@@ -0,0 +1,109 @@
+package tycho
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/fabric"
+)
+
+func TestStaleCheckRemediation(t *testing.T) {
+	t.Parallel()
+
+	// claimAt adds a claim on file by owner, age old, with owner blocked.
+	claimAt := func(mf *mockFabric, file, owner string, age time.Duration) {
+		mf.mu.Lock()
+		defer mf.mu.Unlock()
+		mf.claims[file] = fabric.Claim{Filepath: file, OwnerTask: owner, ClaimedAt: time.Now().Add(-age)}
+		mf.states[owner] = fabric.StateBlocked
+	}
+
+	t.Run("acts once per item past the threshold", func(t *testing.T) {
+		t.Parallel()
+		s, mf, _, _ := newTestScheduler()
+		claimAt(mf, "old.go", "a", time.Hour)
+		claimAt(mf, "new.go", "b", 10*time.Minute)
+		var calls []string
+		s.Remediator = RemediatorFunc(func(_ context.Context, it StaleItem) (string, error) {
+			calls = append(calls, it.ID)
+			return "pinged " + it.Phase, nil
+		})
+		s.RemediateAfter = 30 * time.Minute
+
+		for range 2 {
+			items, err := s.StaleCheck(context.Background(), 5*time.Minute, time.Hour)
+			if err != nil {
+				t.Fatalf("StaleCheck: %v", err)
+			}
+			if len(items) != 2 {
+				t.Fatalf("got %d stale items, want 2", len(items))
+			}
+			for _, it := range items {
+				want := ""
+				if it.ID == "old.go" {
+					want = "pinged a"
+				}
+				if it.Remediation != want {
+					t.Errorf("%s remediation = %q, want %q", it.ID, it.Remediation, want)
+				}
+			}
+		}
+		if len(calls) != 1 || calls[0] != "old.go" {
+			t.Errorf("remediator calls = %v, want [old.go] once", calls)
+		}
+	})
+
+	t.Run("hail remediator posts and surfaces a hail", func(t *testing.T) {
+		t.Parallel()
+		s, mf, _, logBuf := newTestScheduler()
+		claimAt(mf, "old.go", "a", time.Hour)
+		var hailed string
+		s.Remediator = HailRemediator{Fabric: mf, OnHail: func(phaseID string, _ fabric.Discovery) { hailed = phaseID }}
+
+		items, err := s.StaleCheck(context.Background(), 5*time.Minute, time.Hour)
+		if err != nil {
+			t.Fatalf("StaleCheck: %v", err)
+		}
+		if len(items) != 1 || items[0].Remediation != "escalated to a hail" {
+			t.Fatalf("items = %+v", items)
+		}
+		if hailed != "a" || len(mf.discoveries) != 1 || !strings.Contains(mf.discoveries[0].Detail, `stale claim "old.go"`) {
+			t.Errorf("hailed %q, discoveries %+v", hailed, mf.discoveries)
+		} else if mf.discoveries[0].Kind != fabric.DiscoveryStaleWork {
+			t.Errorf("discovery kind = %q, want %q", mf.discoveries[0].Kind, fabric.DiscoveryStaleWork)
+		}
+		if !strings.Contains(logBuf.String(), `Stale claim "old.go": escalated to a hail`) {
+			t.Errorf("log = %q", logBuf.String())
+		}
+	})
+
+	t.Run("ping remediator emits a pulse to the phase", func(t *testing.T) {
+		t.Parallel()
+		s, mf, _, _ := newTestScheduler()
+		claimAt(mf, "old.go", "a", time.Hour)
+		s.Remediator = PingRemediator{Fabric: mf}
+
+		items, err := s.StaleCheck(context.Background(), 5*time.Minute, time.Hour)
+		if err != nil {
+			t.Fatalf("StaleCheck: %v", err)
+		}
+		if len(items) != 1 || items[0].Remediation != "pinged the worker of a" {
+			t.Fatalf("items = %+v", items)
+		}
+		if len(mf.pulses) != 1 || mf.pulses[0].TaskID != "a" || mf.pulses[0].Kind != fabric.PulseNote || !strings.Contains(mf.pulses[0].Content, `claim "old.go"`) {
+			t.Errorf("pulses = %+v", mf.pulses)
+		}
+	})
+
+	t.Run("no remediator leaves items untouched", func(t *testing.T) {
+		t.Parallel()
+		s, mf, _, _ := newTestScheduler()
+		claimAt(mf, "old.go", "a", time.Hour)
+		items, err := s.StaleCheck(context.Background(), 5*time.Minute, time.Hour)
+		if err != nil || len(items) != 1 || items[0].Remediation != "" || items[0].Phase != "a" {
+			t.Errorf("StaleCheck = %+v, %v", items, err)
+		}
+	})
+}
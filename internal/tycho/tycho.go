@@ -35,14 +35,6 @@ type EligibleResolver interface {
 	AnyInFlight() bool
 }
 
-// StaleItem describes a claim or task that appears stuck.
-type StaleItem struct {
-	Kind    string        // "claim" or "task"
-	ID      string        // filepath or task_id
-	Age     time.Duration // time since creation/last transition
-	Details string        // human-readable context
-}
-
 // Scheduler observes fabric state and resolves the DAG to determine
 // which tasks are eligible for execution. It encapsulates DAG resolution,
 // scanning gate logic, blocked-task re-polling, stale detection, and hail
@@ -72,6 +64,12 @@ type Scheduler struct {
 	// OnHail is called when a blocked task requires human intervention.
 	// If nil, escalations are logged but not surfaced.
 	OnHail func(phaseID string, discovery fabric.Discovery)
+
+	// Remediator acts on items StaleCheck keeps finding once they have
+	// been stale for RemediateAfter. Nil takes no action.
+	Remediator     Remediator
+	RemediateAfter time.Duration
+	remediated     map[string]string // stale item key → action already taken
 }
 
 // Eligible returns task IDs that have all DAG dependencies satisfied and
@@ -221,60 +219,6 @@ func (s *Scheduler) BlockedCount() int {
 	return s.Blocked.Len()
 }
 
-// StaleCheck identifies tasks and claims that appear stuck.
-// Claims older than staleClaim with no corresponding running task, and tasks
-// with no state transition within staleTask, are flagged.
-func (s *Scheduler) StaleCheck(ctx context.Context, staleClaim, staleTask time.Duration) ([]StaleItem, error) {
-	var items []StaleItem
-
-	// Check file claims for staleness.
-	claims, err := s.Fabric.AllClaims(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("fetching claims: %w", err)
-	}
-
-	states, err := s.Fabric.AllPhaseStates(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("fetching phase states: %w", err)
-	}
-
-	now := time.Now()
-	for _, c := range claims {
-		age := now.Sub(c.ClaimedAt)
-		if age < staleClaim {
-			continue
-		}
-		// A claim is stale if the owning task is not running.
-		ownerState := states[c.OwnerTask]
-		if ownerState == fabric.StateRunning {
-			continue
-		}
-		items = append(items, StaleItem{
-			Kind:    "claim",
-			ID:      c.Filepath,
-			Age:     age,
-			Details: fmt.Sprintf("owner %q in state %q", c.OwnerTask, ownerState),
-		})
-	}
-
-	// Check blocked tasks for staleness.
-	if s.Blocked != nil {
-		for _, bp := range s.Blocked.All() {
-			age := now.Sub(bp.BlockedAt)
-			if age >= staleTask {
-				items = append(items, StaleItem{
-					Kind:    "task",
-					ID:      bp.PhaseID,
-					Age:     age,
-					Details: fmt.Sprintf("blocked: %s (retries: %d)", bp.LastResult.Reason, bp.RetryCount),
-				})
-			}
-		}
-	}
-
-	return items, nil
-}
-
 // EscalateAllBlocked escalates every remaining blocked phase. This is
 // called when nothing is in-flight and all ready phases are blocked — a
 // dead end that requires human intervention.
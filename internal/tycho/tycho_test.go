@@ -22,6 +22,7 @@ type mockFabric struct {
 	setCalls       []string                // phase IDs passed to SetPhaseState
 	releasedClaims []string                // phase IDs passed to ReleaseClaims
 	discoveries    []fabric.Discovery
+	pulses         []fabric.Pulse
 }
 
 func newMockFabric() *mockFabric {
@@ -177,7 +178,13 @@ func (m *mockFabric) UnresolvedDiscoveries(_ context.Context) ([]fabric.Discover
 	return nil, nil
 }
 
-func (m *mockFabric) EmitPulse(_ context.Context, _ fabric.Pulse) error             { return nil }
+func (m *mockFabric) EmitPulse(_ context.Context, p fabric.Pulse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pulses = append(m.pulses, p)
+	return nil
+}
+
 func (m *mockFabric) PulsesFor(_ context.Context, _ string) ([]fabric.Pulse, error) { return nil, nil }
 func (m *mockFabric) AllPulses(_ context.Context) ([]fabric.Pulse, error)           { return nil, nil }
 func (m *mockFabric) PurgeAll(_ context.Context) error                              { return nil }
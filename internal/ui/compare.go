@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// ModelComparisonRow is one model's outcome in a `run --compare` experiment.
+type ModelComparisonRow struct {
+	Model        string
+	Approved     bool   // the reviewer approved the final cycle
+	Outcome      string // "approved", or why the run stopped
+	Cycles       int
+	CostUSD      float64
+	Satisfaction string // reviewer satisfaction from the final report; "" when none
+	FilesChanged int
+	LinesAdded   int
+	LinesRemoved int
+	Branch       string // branch holding the run's commits
+}
+
+// ModelComparison renders a side-by-side comparison of model runs to stderr.
+func (p *Printer) ModelComparison(rows []ModelComparisonRow) {
+	p.WriteModelComparison(os.Stderr, rows)
+}
+
+// WriteModelComparison renders the ModelComparison table to w. The cheapest
+// approved run is starred.
+func (p *Printer) WriteModelComparison(w io.Writer, rows []ModelComparisonRow) {
+	best := -1
+	for i, r := range rows {
+		if r.Approved && (best < 0 || r.CostUSD < rows[best].CostUSD) {
+			best = i
+		}
+	}
+
+	fmt.Fprintf(w, "\n"+bold+cyan+"── model comparison ──"+reset+"\n")
+	fmt.Fprintf(w, "  "+dim+"  %-24s %-18s %6s %8s  %-12s %s"+reset+"\n", "model", "outcome", "cycles", "cost", "satisfaction", "diff")
+	for i, r := range rows {
+		mark := "  "
+		if i == best {
+			mark = yellow + "★ " + reset
+		}
+		outcomeColor := red
+		if r.Approved {
+			outcomeColor = green
+		}
+		satisfaction := r.Satisfaction
+		if satisfaction == "" {
+			satisfaction = "-"
+		}
+		diff := fmt.Sprintf("%d files "+green+"+%d"+reset+" "+red+"-%d"+reset, r.FilesChanged, r.LinesAdded, r.LinesRemoved)
+		if r.FilesChanged == 0 {
+			diff = dim + "no changes" + reset
+		}
+		fmt.Fprintf(w, "  %s%-24s "+outcomeColor+"%-18s"+reset+" %6d %8s  %-12s %s\n",
+			mark, r.Model, r.Outcome, r.Cycles, fmt.Sprintf("$%.2f", r.CostUSD), satisfaction, diff)
+	}
+	fmt.Fprintln(w)
+	for _, r := range rows {
+		if r.Branch != "" {
+			fmt.Fprintf(w, "  "+dim+"%s:"+reset+" git diff HEAD...%s\n", r.Model, r.Branch)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteModelComparison(t *testing.T) {
+	t.Parallel()
+
+	rows := []ModelComparisonRow{
+		{Model: "opus", Approved: true, Outcome: "approved", Cycles: 1, CostUSD: 2.40, Satisfaction: "high", FilesChanged: 3, LinesAdded: 40, LinesRemoved: 2, Branch: "quasar/compare/x/opus"},
+		{Model: "sonnet", Approved: true, Outcome: "approved", Cycles: 2, CostUSD: 0.80, Satisfaction: "medium", FilesChanged: 2, LinesAdded: 31, Branch: "quasar/compare/x/sonnet"},
+		{Model: "haiku", Outcome: "max cycles", Cycles: 3, CostUSD: 0.30},
+	}
+	var buf bytes.Buffer
+	New().WriteModelComparison(&buf, rows)
+	out := buf.String()
+
+	lines := strings.Split(out, "\n")
+	for _, l := range lines {
+		if strings.Contains(l, "★") && !strings.Contains(l, "sonnet") {
+			t.Errorf("starred %q, want the cheapest approved run (sonnet)", l)
+		}
+	}
+	for _, want := range []string{"$2.40", "max cycles", "no changes", "git diff HEAD...quasar/compare/x/sonnet"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "haiku:") {
+		t.Error("a run without a branch should not list a diff command")
+	}
+}
@@ -40,6 +40,14 @@ type DAGRenderer struct {
 
 	// TrackMap maps node ID to track ID for visual grouping.
 	TrackMap map[string]int
+
+	// Highlight is the set of node IDs drawn in reverse video, such as
+	// search matches. It has no effect without UseColor.
+	Highlight map[string]bool
+
+	// NodeRows is set by Render to the output line, counting from 0, on
+	// which each node is drawn, so callers can scroll a node into view.
+	NodeRows map[string]int
 }
 
 // compactThreshold is the number of total nodes above which the renderer
@@ -61,6 +69,7 @@ func (r *DAGRenderer) Render(waves []dag.Wave, deps map[string][]string, titles
 	if totalNodes == 0 {
 		return ""
 	}
+	r.NodeRows = make(map[string]int, totalNodes)
 
 	width := r.Width
 	if width <= 0 {
@@ -107,6 +116,10 @@ func (r *DAGRenderer) renderFull(waves []dag.Wave, deps map[string][]string, tit
 		}
 
 		// Draw the node boxes.
+		top := strings.Count(sb.String(), "\n")
+		for _, id := range w.NodeIDs {
+			r.NodeRows[id] = top
+		}
 		r.drawRow(&sb, row, width)
 	}
 
@@ -211,6 +224,9 @@ func (r *DAGRenderer) colorize(text, id, state string) string {
 	if r.CriticalPath[id] {
 		prefix = ansi.Bold + prefix
 	}
+	if r.Highlight[id] {
+		prefix = ansi.Reverse + prefix
+	}
 
 	return prefix + text + ansi.Reset
 }
@@ -516,6 +532,7 @@ func (r *DAGRenderer) renderCompact(waves []dag.Wave, deps map[string][]string,
 				status = r.StatusFunc(id)
 			}
 
+			r.NodeRows[id] = strings.Count(sb.String(), "\n")
 			nodeStr := r.compactNode(title, id, status.State)
 			sb.WriteString(nodeStr)
 
@@ -572,6 +589,9 @@ func (r *DAGRenderer) compactNode(title, id, state string) string {
 	if r.CriticalPath[id] {
 		prefix = ansi.Bold + prefix
 	}
+	if r.Highlight[id] {
+		prefix = ansi.Reverse + prefix
+	}
 	return prefix + text + ansi.Reset
 }
 
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -280,6 +281,52 @@ func TestRender_CriticalPathCompact(t *testing.T) {
 	}
 }
 
+func TestRender_Highlight(t *testing.T) {
+	t.Parallel()
+
+	waves, deps, titles := buildTestDAG(t, []dagSpec{
+		{id: "a", title: "Start"},
+		{id: "b", title: "End", deps: []string{"a"}},
+	})
+
+	r := &DAGRenderer{Width: 80, UseColor: true, Highlight: map[string]bool{"b": true}}
+	out := r.Render(waves, deps, titles)
+	if strings.Count(out, "\033[7m") != 3 {
+		t.Errorf("expected the 3 lines of one box in reverse video:\n%s", out)
+	}
+}
+
+func TestRender_NodeRows(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{3, 12} {
+		var specs []dagSpec
+		for i := 1; i <= n; i++ {
+			s := dagSpec{id: fmt.Sprintf("p%d", i), title: fmt.Sprintf("Phase %d", i)}
+			if i > 1 {
+				s.deps = []string{fmt.Sprintf("p%d", i-1)}
+			}
+			specs = append(specs, s)
+		}
+		waves, deps, titles := buildTestDAG(t, specs)
+
+		r := &DAGRenderer{Width: 80}
+		lines := strings.Split(r.Render(waves, deps, titles), "\n")
+		if len(r.NodeRows) != n {
+			t.Fatalf("%d nodes: NodeRows has %d entries", n, len(r.NodeRows))
+		}
+		for _, s := range specs {
+			row := r.NodeRows[s.id]
+			if n <= compactThreshold {
+				row++ // the title sits below the box's top border
+			}
+			if row >= len(lines) || !strings.Contains(lines[row], s.title+" ") && !strings.Contains(lines[row], s.title+"]") {
+				t.Errorf("%d nodes: %s at row %d, line is %q", n, s.id, row, lines[min(row, len(lines)-1)])
+			}
+		}
+	}
+}
+
 func TestRender_TrackBorders(t *testing.T) {
 	t.Parallel()
 
@@ -2,10 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"maps"
 	"os"
-	"sort"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/papapumpkin/quasar/internal/agent"
 	"github.com/papapumpkin/quasar/internal/nebula"
@@ -45,6 +45,8 @@ func (p *Printer) NebulaPlan(plan *nebula.Plan) {
 			symbol, color = "×", red
 		case nebula.ActionRetry:
 			symbol, color = "↻", yellow
+		case nebula.ActionRename:
+			symbol, color = "→", yellow
 		}
 		fmt.Fprintf(os.Stderr, "  "+color+symbol+" %-20s"+reset+" %s\n", a.PhaseID, a.Reason)
 	}
@@ -53,7 +55,7 @@ func (p *Printer) NebulaPlan(plan *nebula.Plan) {
 
 // NebulaApplyDone prints a summary of completed apply actions.
 func (p *Printer) NebulaApplyDone(plan *nebula.Plan) {
-	var created, updated, closed, skipped, retried int
+	var created, updated, closed, skipped, retried, renamed int
 	for _, a := range plan.Actions {
 		switch a.Type {
 		case nebula.ActionCreate:
@@ -66,10 +68,12 @@ func (p *Printer) NebulaApplyDone(plan *nebula.Plan) {
 			skipped++
 		case nebula.ActionRetry:
 			retried++
+		case nebula.ActionRename:
+			renamed++
 		}
 	}
-	fmt.Fprintf(os.Stderr, green+bold+"✓ apply complete"+reset+" — created: %d, updated: %d, retried: %d, closed: %d, skipped: %d\n",
-		created, updated, retried, closed, skipped)
+	fmt.Fprintf(os.Stderr, green+bold+"✓ apply complete"+reset+" — created: %d, updated: %d, renamed: %d, retried: %d, closed: %d, skipped: %d\n",
+		created, updated, renamed, retried, closed, skipped)
 }
 
 // NebulaWorkerResults prints the outcome of each worker task execution.
@@ -78,12 +82,72 @@ func (p *Printer) NebulaWorkerResults(results []nebula.WorkerResult) {
 	for _, r := range results {
 		if r.Err != nil {
 			fmt.Fprintf(os.Stderr, "  "+red+"✗ %s"+reset+" — %v\n", r.PhaseID, r.Err)
+			if r.RetrySpentUSD > 0 {
+				fmt.Fprintf(os.Stderr, dim+"    retries spent: $%.2f"+reset+"\n", r.RetrySpentUSD)
+			}
+			if r.Preserved != "" {
+				fmt.Fprintf(os.Stderr, dim+"    work kept at: %s"+reset+"\n", r.Preserved)
+			}
+			if r.TimeoutAction != "" {
+				fmt.Fprintf(os.Stderr, dim+"    timed out (timeout_action: %s)"+reset+"\n", r.TimeoutAction)
+			}
 		} else {
-			fmt.Fprintf(os.Stderr, "  "+green+"✓ %s"+reset+" (bead %s)\n", r.PhaseID, r.BeadID)
+			note := ""
+			if r.Cached {
+				note = ", cached"
+			}
+			if r.TimeoutAction == nebula.TimeoutActionSkip {
+				note = ", timed out and skipped"
+			}
+			fmt.Fprintf(os.Stderr, "  "+green+"✓ %s"+reset+" (bead %s%s)\n", r.PhaseID, r.BeadID, note)
 			if r.Report != nil {
 				p.ReviewReport(r.PhaseID, r.Report)
 			}
+			for _, a := range r.Artifacts {
+				fmt.Fprintf(os.Stderr, dim+"    artifact: %s"+reset+"\n", a)
+			}
+		}
+	}
+	nebulaFailures(results)
+}
+
+// nebulaFailures prints every failed phase in one place: its error, the
+// reviewer's last findings, the dependents it held back, and which phases
+// to retry. It prints nothing when no phase failed.
+func nebulaFailures(results []nebula.WorkerResult) {
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n"+bold+red+"failures (%d):"+reset+"\n", failed)
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  "+red+"✗ %s"+reset+" — %v\n", r.PhaseID, r.Err)
+		if len(r.Findings) > 0 {
+			fmt.Fprintf(os.Stderr, "    reviewer's last findings (cycle %d):\n", r.Findings[0].Cycle)
+			for _, f := range r.Findings {
+				desc, _, _ := strings.Cut(f.Description, "\n")
+				fmt.Fprintf(os.Stderr, "      [%s] %s\n", f.Severity, desc)
+			}
 		}
+		if n := len(r.Blocked); n > 0 {
+			fmt.Fprintf(os.Stderr, yellow+"    blocked %d dependent%s: %s"+reset+"\n", n, pluralS(n), strings.Join(r.Blocked, ", "))
+		}
+		if n := len(r.Skipped); n > 0 {
+			fmt.Fprintf(os.Stderr, yellow+"    skipped %d dependent%s: %s"+reset+"\n", n, pluralS(n), strings.Join(r.Skipped, ", "))
+		}
+	}
+	if retry := nebula.RetryCandidates(results); len(retry) > 0 {
+		fmt.Fprintf(os.Stderr, bold+"  next:"+reset+" fix and retry %s — `nebula apply` runs failed phases again\n", strings.Join(retry, ", "))
+	} else {
+		fmt.Fprintln(os.Stderr, bold+"  next:"+reset+" every failed phase has spent its retry budget; raise retry_budget_usd to run them again")
 	}
 }
 
@@ -92,6 +156,13 @@ func (p *Printer) ReviewReport(phaseID string, report *agent.ReviewReport) {
 	fmt.Fprintf(os.Stderr, dim+"  report for %s:"+reset+"\n", phaseID)
 	fmt.Fprintf(os.Stderr, "    satisfaction:  %s\n", report.Satisfaction)
 	fmt.Fprintf(os.Stderr, "    risk:          %s\n", report.Risk)
+	if report.Confidence != "" {
+		confidence := report.Confidence
+		if confidence == "low" {
+			confidence = yellow + confidence + reset
+		}
+		fmt.Fprintf(os.Stderr, "    confidence:    %s\n", confidence)
+	}
 	humanReview := "no"
 	if report.NeedsHumanReview {
 		humanReview = yellow + "yes" + reset
@@ -101,7 +172,8 @@ func (p *Printer) ReviewReport(phaseID string, report *agent.ReviewReport) {
 }
 
 // NebulaShow prints a detailed overview of a nebula and its phase states.
-func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
+// Each phase's params are shown as resolved with the launch params.
+func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State, params map[string]string) {
 	fmt.Fprintf(os.Stderr, bold+cyan+"nebula: %s"+reset+"\n", n.Manifest.Nebula.Name)
 	if n.Manifest.Nebula.Description != "" {
 		fmt.Fprintf(os.Stderr, dim+"%s"+reset+"\n", n.Manifest.Nebula.Description)
@@ -110,7 +182,7 @@ func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
 
 	// Display execution config if any fields are set.
 	exec := n.Manifest.Execution
-	if exec.MaxWorkers > 0 || exec.MaxReviewCycles > 0 || exec.MaxBudgetUSD > 0 || exec.Model != "" {
+	if exec.MaxWorkers > 0 || exec.MaxReviewCycles > 0 || exec.MaxBudgetUSD > 0 || exec.RetryBudgetUSD > 0 || exec.Model != "" || exec.OnFailure != "" {
 		fmt.Fprintf(os.Stderr, bold+"execution:"+reset+"\n")
 		if exec.MaxWorkers > 0 {
 			fmt.Fprintf(os.Stderr, "  max workers:       %d\n", exec.MaxWorkers)
@@ -121,9 +193,15 @@ func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
 		if exec.MaxBudgetUSD > 0 {
 			fmt.Fprintf(os.Stderr, "  max budget:        $%.2f\n", exec.MaxBudgetUSD)
 		}
+		if exec.RetryBudgetUSD > 0 {
+			fmt.Fprintf(os.Stderr, "  retry budget:      $%.2f\n", exec.RetryBudgetUSD)
+		}
 		if exec.Model != "" {
 			fmt.Fprintf(os.Stderr, "  model:             %s\n", exec.Model)
 		}
+		if exec.OnFailure != "" {
+			fmt.Fprintf(os.Stderr, "  on failure:        %s\n", exec.OnFailure)
+		}
 		fmt.Fprintln(os.Stderr)
 	}
 
@@ -171,6 +249,9 @@ func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
 		beadID := ""
 		if hasState {
 			status = string(ts.Status)
+			if ts.DoneReason != "" {
+				status += " (" + ts.DoneReason + ")"
+			}
 			beadID = ts.BeadID
 		}
 
@@ -185,6 +266,9 @@ func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
 		}
 
 		fmt.Fprintf(os.Stderr, "  %-20s %-12s %s%s%s\n", t.ID, status, t.Title, deps, beadStr)
+		if line := formatPhaseParams(&t, params); line != "" {
+			fmt.Fprintf(os.Stderr, "    "+dim+"params: %s"+reset+"\n", line)
+		}
 		if hasState && ts.Report != nil {
 			fmt.Fprintf(os.Stderr, "    "+dim+"satisfaction:%s risk:%s human-review:%v"+reset+"\n",
 				ts.Report.Satisfaction, ts.Report.Risk, ts.Report.NeedsHumanReview)
@@ -192,6 +276,23 @@ func (p *Printer) NebulaShow(n *nebula.Nebula, state *nebula.State) {
 	}
 }
 
+// formatPhaseParams lists a phase's resolved params as "key=value" pairs in
+// name order, marking each required or referenced param with no value as
+// "key=<missing>". It returns "" for a phase without params.
+func formatPhaseParams(p *nebula.PhaseSpec, launch map[string]string) string {
+	resolved := nebula.ResolveParams(p, launch)
+	var pairs []string
+	for _, k := range slices.Sorted(maps.Keys(resolved)) {
+		pairs = append(pairs, k+"="+resolved[k])
+	}
+	for _, k := range nebula.MissingParams(p, resolved) {
+		if _, ok := resolved[k]; !ok {
+			pairs = append(pairs, k+"=<missing>")
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
 // NebulaProgressBarLine formats a progress line string (without ANSI escape prefix).
 // Format matches the spec: [nebula] 3/7 phases complete | $2.34 spent
 // This is exported for testing.
@@ -212,156 +313,3 @@ func (p *Printer) NebulaProgressBar(completed, total, openBeads, closedBeads int
 func (p *Printer) NebulaProgressBarDone() {
 	fmt.Fprintln(os.Stderr)
 }
-
-// NebulaStatus renders a metrics summary for a nebula run to stderr.
-// It gracefully handles nil metrics by falling back to state-only information.
-func (p *Printer) NebulaStatus(n *nebula.Nebula, state *nebula.State, m *nebula.Metrics, history []nebula.HistorySummary) {
-	name := n.Manifest.Nebula.Name
-
-	if m != nil && !m.CompletedAt.IsZero() {
-		fmt.Fprintf(os.Stderr, bold+cyan+"nebula %q"+reset+" — last run %s\n\n", name, m.CompletedAt.Format(time.RFC3339))
-	} else if m != nil && !m.StartedAt.IsZero() {
-		fmt.Fprintf(os.Stderr, bold+cyan+"nebula %q"+reset+" — started %s (in progress)\n\n", name, m.StartedAt.Format(time.RFC3339))
-	} else {
-		fmt.Fprintf(os.Stderr, bold+cyan+"nebula %q"+reset+" — no metrics recorded\n\n", name)
-	}
-
-	// Phase counts from state.
-	completed, failed := 0, 0
-	for _, ps := range state.Phases {
-		switch ps.Status {
-		case nebula.PhaseStatusDone:
-			completed++
-		case nebula.PhaseStatusFailed:
-			failed++
-		}
-	}
-
-	restarts := 0
-	if m != nil {
-		restarts = m.TotalRestarts
-	}
-	fmt.Fprintf(os.Stderr, "  Phases:  %d completed, %d failed, %d restarts\n", completed, failed, restarts)
-
-	// Waves.
-	if m != nil && len(m.Waves) > 0 {
-		avgParallelism := nebulaAvgParallelism(m.Waves)
-		fmt.Fprintf(os.Stderr, "  Waves:   %d (avg effective parallelism: %.1f)\n", len(m.Waves), avgParallelism)
-	} else {
-		fmt.Fprintf(os.Stderr, "  Waves:   0\n")
-	}
-
-	// Cost.
-	totalCost := state.TotalCostUSD
-	if m != nil && m.TotalCostUSD > 0 {
-		totalCost = m.TotalCostUSD
-	}
-	totalPhases := len(n.Phases)
-	avgCost := 0.0
-	if totalPhases > 0 {
-		avgCost = totalCost / float64(totalPhases)
-	}
-	fmt.Fprintf(os.Stderr, "  Cost:    $%.2f (avg $%.2f/phase)\n", totalCost, avgCost)
-
-	// Duration.
-	if m != nil && !m.StartedAt.IsZero() && !m.CompletedAt.IsZero() {
-		dur := m.CompletedAt.Sub(m.StartedAt)
-		fmt.Fprintf(os.Stderr, "  Duration: %s (wall-clock)\n", formatDuration(dur))
-	}
-
-	// Conflicts.
-	if m != nil {
-		fmt.Fprintf(os.Stderr, "  Conflicts: %d\n", m.TotalConflicts)
-	}
-
-	// Wave breakdown.
-	if m != nil && len(m.Waves) > 0 {
-		fmt.Fprintf(os.Stderr, "\n  Wave breakdown:\n")
-		for _, w := range m.Waves {
-			note := ""
-			if w.EffectiveParallelism < w.PhaseCount {
-				note = " (scope serialization)"
-			}
-			fmt.Fprintf(os.Stderr, "    Wave %d: %d phases, parallelism %d/%d%s, %s\n",
-				w.WaveNumber, w.PhaseCount, w.EffectiveParallelism, w.PhaseCount, note,
-				formatDuration(w.TotalDuration))
-		}
-	}
-
-	// Slowest phases.
-	if m != nil && len(m.Phases) > 0 {
-		sorted := make([]nebula.PhaseMetrics, len(m.Phases))
-		copy(sorted, m.Phases)
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].Duration > sorted[j].Duration
-		})
-		limit := 5
-		if len(sorted) < limit {
-			limit = len(sorted)
-		}
-		fmt.Fprintf(os.Stderr, "\n  Slowest phases:\n")
-		for _, pm := range sorted[:limit] {
-			sat := pm.Satisfaction
-			if sat == "" {
-				sat = "-"
-			}
-			fmt.Fprintf(os.Stderr, "    %-24s %s  $%.2f  %d cycles  satisfaction: %s\n",
-				pm.PhaseID, formatDuration(pm.Duration), pm.CostUSD, pm.CyclesUsed, sat)
-		}
-	}
-
-	// History — entries are oldest-first, so take from the end for most recent.
-	if len(history) > 0 {
-		limit := 3
-		if len(history) < limit {
-			limit = len(history)
-		}
-		recent := history[len(history)-limit:]
-		fmt.Fprintf(os.Stderr, "\n  History (last %d run%s):\n", limit, pluralS(limit))
-		for _, h := range recent {
-			fmt.Fprintf(os.Stderr, "    %s  %d phases  $%.2f  %s  %d conflict%s\n",
-				h.StartedAt.Format("2006-01-02 15:04"),
-				h.TotalPhases, h.TotalCostUSD,
-				formatDuration(h.Duration),
-				h.TotalConflicts, pluralS(h.TotalConflicts))
-		}
-	}
-
-	fmt.Fprintln(os.Stderr)
-}
-
-// nebulaAvgParallelism computes the average effective parallelism across waves.
-func nebulaAvgParallelism(waves []nebula.WaveMetrics) float64 {
-	if len(waves) == 0 {
-		return 0
-	}
-	total := 0
-	for _, w := range waves {
-		total += w.EffectiveParallelism
-	}
-	return float64(total) / float64(len(waves))
-}
-
-// formatDuration formats a duration as a human-readable string like "4m32s".
-func formatDuration(d time.Duration) string {
-	d = d.Round(time.Second)
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	totalSeconds := int(d.Seconds())
-	h := totalSeconds / 3600
-	m := (totalSeconds % 3600) / 60
-	s := totalSeconds % 60
-	if h > 0 {
-		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
-	}
-	return fmt.Sprintf("%dm%02ds", m, s)
-}
-
-// pluralS returns "s" if n != 1, for simple English pluralization.
-func pluralS(n int) string {
-	if n == 1 {
-		return ""
-	}
-	return "s"
-}
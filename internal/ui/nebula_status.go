@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// NebulaStatus renders a metrics summary for a nebula run to stderr.
+// It gracefully handles nil metrics by falling back to state-only information.
+func (p *Printer) NebulaStatus(n *nebula.Nebula, state *nebula.State, m *nebula.Metrics, history []nebula.HistorySummary) {
+	p.WriteNebulaStatus(os.Stderr, n, state, m, history)
+}
+
+// WriteNebulaStatus renders the NebulaStatus summary to w, so callers such
+// as status --watch can measure and redraw it.
+func (p *Printer) WriteNebulaStatus(w io.Writer, n *nebula.Nebula, state *nebula.State, m *nebula.Metrics, history []nebula.HistorySummary) {
+	name := n.Manifest.Nebula.Name
+
+	if m != nil && !m.CompletedAt.IsZero() {
+		fmt.Fprintf(w, bold+cyan+"nebula %q"+reset+" — last run %s\n\n", name, m.CompletedAt.Format(time.RFC3339))
+	} else if m != nil && !m.StartedAt.IsZero() {
+		fmt.Fprintf(w, bold+cyan+"nebula %q"+reset+" — started %s (in progress)\n\n", name, m.StartedAt.Format(time.RFC3339))
+	} else {
+		fmt.Fprintf(w, bold+cyan+"nebula %q"+reset+" — no metrics recorded\n\n", name)
+	}
+
+	// Phase counts from state.
+	completed, failed, retried := 0, 0, 0
+	retrySpent := 0.0
+	for _, ps := range state.Phases {
+		if ps.RetrySpentUSD > 0 {
+			retried++
+			retrySpent += ps.RetrySpentUSD
+		}
+		switch ps.Status {
+		case nebula.PhaseStatusDone:
+			completed++
+		case nebula.PhaseStatusFailed:
+			failed++
+		}
+	}
+
+	restarts := 0
+	if m != nil {
+		restarts = m.TotalRestarts
+	}
+	fmt.Fprintf(w, "  Phases:  %d completed, %d failed, %d restarts\n", completed, failed, restarts)
+
+	// Waves.
+	if m != nil && len(m.Waves) > 0 {
+		avgParallelism := nebulaAvgParallelism(m.Waves)
+		fmt.Fprintf(w, "  Waves:   %d (avg effective parallelism: %.1f)\n", len(m.Waves), avgParallelism)
+	} else {
+		fmt.Fprintf(w, "  Waves:   0\n")
+	}
+
+	// Cost.
+	totalCost := state.TotalCostUSD
+	if m != nil && m.TotalCostUSD > 0 {
+		totalCost = m.TotalCostUSD
+	}
+	totalPhases := len(n.Phases)
+	avgCost := 0.0
+	if totalPhases > 0 {
+		avgCost = totalCost / float64(totalPhases)
+	}
+	fmt.Fprintf(w, "  Cost:    $%.2f (avg $%.2f/phase)\n", totalCost, avgCost)
+	if retried > 0 {
+		fmt.Fprintf(w, "  Retries: $%.2f across %d phases\n", retrySpent, retried)
+	}
+
+	// Duration.
+	if m != nil && !m.StartedAt.IsZero() && !m.CompletedAt.IsZero() {
+		dur := m.CompletedAt.Sub(m.StartedAt)
+		fmt.Fprintf(w, "  Duration: %s (wall-clock)\n", formatDuration(dur))
+	}
+
+	// Conflicts.
+	if m != nil {
+		fmt.Fprintf(w, "  Conflicts: %d\n", m.TotalConflicts)
+	}
+
+	// Wave breakdown.
+	if m != nil && len(m.Waves) > 0 {
+		fmt.Fprintf(w, "\n  Wave breakdown:\n")
+		for _, wave := range m.Waves {
+			note := ""
+			if wave.EffectiveParallelism < wave.PhaseCount {
+				note = " (scope serialization)"
+			}
+			fmt.Fprintf(w, "    Wave %d: %d phases, parallelism %d/%d%s, %s\n",
+				wave.WaveNumber, wave.PhaseCount, wave.EffectiveParallelism, wave.PhaseCount, note,
+				formatDuration(wave.TotalDuration))
+		}
+	}
+
+	// Slowest phases.
+	if m != nil && len(m.Phases) > 0 {
+		sorted := make([]nebula.PhaseMetrics, len(m.Phases))
+		copy(sorted, m.Phases)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Duration > sorted[j].Duration
+		})
+		limit := 5
+		if len(sorted) < limit {
+			limit = len(sorted)
+		}
+		fmt.Fprintf(w, "\n  Slowest phases:\n")
+		for _, pm := range sorted[:limit] {
+			sat := pm.Satisfaction
+			if sat == "" {
+				sat = "-"
+			}
+			fmt.Fprintf(w, "    %-24s %s  $%.2f  %d cycles  satisfaction: %s\n",
+				pm.PhaseID, formatDuration(pm.Duration), pm.CostUSD, pm.CyclesUsed, sat)
+		}
+	}
+
+	writeEstimates(w, n, m)
+
+	// History — entries are oldest-first, so take from the end for most recent.
+	if len(history) > 0 {
+		limit := 3
+		if len(history) < limit {
+			limit = len(history)
+		}
+		recent := history[len(history)-limit:]
+		fmt.Fprintf(w, "\n  History (last %d run%s):\n", limit, pluralS(limit))
+		for _, h := range recent {
+			fmt.Fprintf(w, "    %s  %d phases  $%.2f  %s  %d conflict%s\n",
+				h.StartedAt.Format("2006-01-02 15:04"),
+				h.TotalPhases, h.TotalCostUSD,
+				formatDuration(h.Duration),
+				h.TotalConflicts, pluralS(h.TotalConflicts))
+		}
+	}
+
+	// Worker tuning — SuggestMaxWorkers stays silent until enough history exists.
+	if suggested, why := nebula.SuggestMaxWorkers(history, []*nebula.Metrics{m}); why != "" {
+		fmt.Fprintf(w, "\n  Suggested max_workers: %d — %s\n", suggested, why)
+	}
+
+	fmt.Fprintln(w)
+}
+
+// nebulaAvgParallelism computes the average effective parallelism across waves.
+func nebulaAvgParallelism(waves []nebula.WaveMetrics) float64 {
+	if len(waves) == 0 {
+		return 0
+	}
+	total := 0
+	for _, w := range waves {
+		total += w.EffectiveParallelism
+	}
+	return float64(total) / float64(len(waves))
+}
+
+// formatDuration formats a duration as a human-readable string like "4m32s".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	totalSeconds := int(d.Seconds())
+	h := totalSeconds / 3600
+	m := (totalSeconds % 3600) / 60
+	s := totalSeconds % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
+	}
+	return fmt.Sprintf("%dm%02ds", m, s)
+}
+
+// pluralS returns "s" if n != 1, for simple English pluralization.
+func pluralS(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// writeEstimates compares each phase's estimated_minutes with how long it
+// actually took in m, so authors can calibrate their hints. Phases without
+// a hint or a recorded duration are left out.
+func writeEstimates(w io.Writer, n *nebula.Nebula, m *nebula.Metrics) {
+	if m == nil {
+		return
+	}
+	estimates := nebula.PhaseEstimates(n.Phases)
+	header := false
+	for _, pm := range m.Phases {
+		est, ok := estimates[pm.PhaseID]
+		if !ok || pm.Duration <= 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintf(w, "\n  Estimates vs actual:\n")
+			header = true
+		}
+		off := (pm.Duration - est) * 100 / est
+		fmt.Fprintf(w, "    %-24s est %s  actual %s  (%+d%%)\n",
+			pm.PhaseID, formatDuration(est), formatDuration(pm.Duration), int64(off))
+	}
+}
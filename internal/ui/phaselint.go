@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/papapumpkin/quasar/internal/nebula"
+)
+
+// NebulaPhaseLint prints per-phase rubric scores from LintPhases, with
+// suggestions for phases likely to cause extra review cycles.
+func (p *Printer) NebulaPhaseLint(name string, report *nebula.PhaseLintReport) {
+	fmt.Fprintf(os.Stderr, "\n"+bold+cyan+"phase lint: %s"+reset+"\n", name)
+	flagged, cached, skipped := 0, 0, 0
+	for _, r := range report.Results {
+		if r.Cached {
+			cached++
+		}
+		switch {
+		case r.Skipped:
+			skipped++
+			fmt.Fprintf(os.Stderr, "  "+dim+"- %-24s skipped (budget exhausted)"+reset+"\n", r.PhaseID)
+			continue
+		case r.Score == 0:
+			fmt.Fprintf(os.Stderr, "  "+dim+"? %-24s unscored (unreadable response)"+reset+"\n", r.PhaseID)
+			continue
+		case r.Risky():
+			flagged++
+			fmt.Fprintf(os.Stderr, "  "+yellow+"⚠ %-24s %d/5"+reset+"  likely to need extra review cycles\n", r.PhaseID, r.Score)
+		default:
+			fmt.Fprintf(os.Stderr, "  "+green+"✓ %-24s %d/5"+reset+"\n", r.PhaseID, r.Score)
+		}
+		for _, s := range r.Suggestions {
+			fmt.Fprintf(os.Stderr, "      "+dim+"•"+reset+" %s\n", s)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\n  %d of %d phase%s flagged · $%.4f spent · %d cached",
+		flagged, len(report.Results), pluralS(len(report.Results)), report.CostUSD, cached)
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, " · %d skipped", skipped)
+	}
+	fmt.Fprintln(os.Stderr)
+}
@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// quietContextLines is how many recent events Quiet keeps to print when
+	// something goes wrong.
+	quietContextLines = 50
+	// quietProgressInterval is the minimum time between Quiet's progress lines.
+	quietProgressInterval = 30 * time.Second
+)
+
+// Verify that *Quiet satisfies the UI interface at compile time.
+var _ UI = (*Quiet)(nil)
+
+// Quiet is a UI for unattended runs such as CI. It keeps the most recent
+// lifecycle events in memory and prints only an occasional one-line progress
+// report, until Error, BudgetExceeded, or MaxCyclesReached flushes the
+// buffered events to stderr so the failure has its context.
+type Quiet struct {
+	w        io.Writer
+	interval time.Duration
+	now      func() time.Time
+	label    string // names the run in every line; "" for a lone run
+
+	mu           sync.Mutex
+	recent       []string
+	lastProgress time.Time
+	task         string
+	cycle        int
+	maxCycles    int
+	costUSD      float64
+}
+
+// NewQuiet returns a Quiet UI that writes to stderr.
+func NewQuiet() *Quiet {
+	return newQuiet(os.Stderr, quietProgressInterval, time.Now)
+}
+
+// NewQuietLabeled returns a Quiet UI whose lines carry label, so several
+// runs can share stderr, e.g. "quasar[sonnet]: cycle 2/5 · $0.40 spent".
+func NewQuietLabeled(label string) *Quiet {
+	q := NewQuiet()
+	q.label = label
+	return q
+}
+
+// newQuiet returns a Quiet UI writing to w, reporting progress at most once
+// per interval as measured by now.
+func newQuiet(w io.Writer, interval time.Duration, now func() time.Time) *Quiet {
+	return &Quiet{w: w, interval: interval, now: now}
+}
+
+// name is how Quiet's own lines are introduced: "quasar", or
+// "quasar[label]" for a labeled run.
+func (q *Quiet) name() string {
+	if q.label == "" {
+		return "quasar"
+	}
+	return "quasar[" + q.label + "]"
+}
+
+// record buffers an event line, dropping the oldest once the buffer is
+// full, and prints a progress line if one is due. The caller holds q.mu.
+func (q *Quiet) record(format string, args ...any) {
+	now := q.now()
+	q.recent = append(q.recent, now.Format("15:04:05")+" "+fmt.Sprintf(format, args...))
+	if len(q.recent) > quietContextLines {
+		q.recent = q.recent[len(q.recent)-quietContextLines:]
+	}
+	if now.Sub(q.lastProgress) >= q.interval {
+		q.lastProgress = now
+		q.progress()
+	}
+}
+
+// progress prints a one-line summary of where the run is. The caller holds
+// q.mu.
+func (q *Quiet) progress() {
+	var parts []string
+	if q.task != "" {
+		parts = append(parts, q.task)
+	}
+	if q.cycle > 0 {
+		parts = append(parts, fmt.Sprintf("cycle %d/%d", q.cycle, q.maxCycles))
+	}
+	parts = append(parts, fmt.Sprintf("$%.2f spent", q.costUSD))
+	fmt.Fprintln(q.w, q.name()+": "+strings.Join(parts, " · "))
+}
+
+// flush prints the buffered events followed by msg, then empties the
+// buffer. The caller holds q.mu.
+func (q *Quiet) flush(msg string) {
+	if len(q.recent) > 0 {
+		fmt.Fprintln(q.w, "--- recent activity ---")
+		for _, line := range q.recent {
+			fmt.Fprintln(q.w, line)
+		}
+		fmt.Fprintln(q.w, "---")
+	}
+	if q.label != "" {
+		msg = q.name() + ": " + msg
+	}
+	fmt.Fprintln(q.w, msg)
+	q.recent = nil
+}
+
+// TaskStarted records the start of a task.
+func (q *Quiet) TaskStarted(beadID, title string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.task, q.cycle, q.costUSD = beadID, 0, 0
+	q.record("task %s — %s", beadID, title)
+}
+
+// TaskComplete prints a one-line result and discards the buffered events.
+func (q *Quiet) TaskComplete(beadID string, totalCost float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.costUSD = totalCost
+	fmt.Fprintf(q.w, "%s: task %s complete ($%.4f)\n", q.name(), beadID, totalCost)
+	q.recent = nil
+}
+
+// CycleStart records the start of a cycle.
+func (q *Quiet) CycleStart(cycle, maxCycles int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cycle, q.maxCycles = cycle, maxCycles
+	q.record("── cycle %d/%d ──", cycle, maxCycles)
+}
+
+// AgentStart records that an agent began work.
+func (q *Quiet) AgentStart(role string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("▶ %s working...", role)
+}
+
+// AgentDone records an agent's cost and duration.
+func (q *Quiet) AgentDone(role string, costUSD float64, durationMs int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("✓ %s done (%.1fs, $%.4f)", role, float64(durationMs)/1000.0, costUSD)
+}
+
+// CycleSummary records the cost and outcome of a coder or reviewer phase.
+func (q *Quiet) CycleSummary(d CycleSummaryData) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.costUSD = d.TotalCostUSD
+	line := fmt.Sprintf("cycle %d/%d %s: $%.4f, $%.4f total", d.Cycle, d.MaxCycles, d.Phase, d.CostUSD, d.TotalCostUSD)
+	if d.Phase == "review_complete" {
+		if d.Approved {
+			line += ", approved"
+		} else {
+			line += fmt.Sprintf(", %d issue(s) found", d.IssueCount)
+		}
+	}
+	q.record("%s", line)
+}
+
+// IssuesFound records that the reviewer found issues.
+func (q *Quiet) IssuesFound(count int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("⚠ %d issue(s) found — sending back to coder", count)
+}
+
+// Approved records reviewer approval.
+func (q *Quiet) Approved() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("✓ APPROVED — reviewer is satisfied")
+}
+
+// MaxCyclesReached prints the buffered events and the cycle limit error.
+func (q *Quiet) MaxCyclesReached(max int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.flush(fmt.Sprintf("✗ max cycles reached (%d) — stopping", max))
+}
+
+// BudgetExceeded prints the buffered events and the budget error.
+func (q *Quiet) BudgetExceeded(spent, limit float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.flush(fmt.Sprintf("✗ budget exceeded ($%.2f / $%.2f)", spent, limit))
+}
+
+// BudgetWarning records that spending is approaching the budget.
+func (q *Quiet) BudgetWarning(spent, limit float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("⚠ budget warning ($%.2f / $%.2f) — approaching limit", spent, limit)
+}
+
+// Error prints the buffered events and the error.
+func (q *Quiet) Error(msg string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.flush("error: " + msg)
+}
+
+// Info records an informational message.
+func (q *Quiet) Info(msg string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("%s", msg)
+}
+
+// AgentOutput is a no-op; agent output is only displayed in the TUI.
+func (q *Quiet) AgentOutput(role string, cycle int, output string) {}
+
+// BeadUpdate is a no-op; the bead hierarchy is only displayed in the TUI.
+func (q *Quiet) BeadUpdate(taskBeadID, title, status string, children []BeadChild) {}
+
+// BeadsDegraded records how many bead operations are buffered locally.
+func (q *Quiet) BeadsDegraded(buffered int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if buffered == 0 {
+		q.record("✓ beads backend recovered — buffered operations replayed")
+		return
+	}
+	q.record("⚠ beads degraded — %d operation(s) buffered locally", buffered)
+}
+
+// RefactorApplied is a no-op; refactor indicators are only displayed in the TUI.
+func (q *Quiet) RefactorApplied(phaseID, original, updated string) {}
+
+// FindingLifecycle records the verification summary for a cycle.
+func (q *Quiet) FindingLifecycle(cycle int, summary FindingLifecycleData) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("  findings: %s", summary.String())
+}
+
+// HailReceived prints the hail immediately, since the run waits on a human.
+func (q *Quiet) HailReceived(h HailInfo) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	fmt.Fprintf(q.w, "⚠ AGENT NEEDS INPUT [%s] — %s\n", h.Kind, h.Summary)
+	q.record("hail %s [%s] %s", h.ID, h.Kind, h.Summary)
+}
+
+// HailResolved records that a hail was resolved.
+func (q *Quiet) HailResolved(id, resolution string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.record("✓ hail resolved [%s] %s", id, resolution)
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a now func that advances by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	t := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+func TestQuietSilentUntilError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	q := newQuiet(&buf, time.Hour, fakeClock(time.Second))
+	q.TaskStarted("bead-1", "add login")
+	q.CycleStart(1, 3)
+	q.AgentStart("coder")
+	q.AgentDone("coder", 0.12, 1500)
+	q.IssuesFound(2)
+
+	// Only the first event's progress line is printed; the rest are buffered.
+	if got := strings.Count(buf.String(), "\n"); got != 1 {
+		t.Fatalf("quiet printed %d lines before the error, want 1:\n%s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "quasar: bead-1") {
+		t.Errorf("progress line = %q", buf.String())
+	}
+
+	q.Error("coder crashed")
+	out := buf.String()
+	for _, want := range []string{"--- recent activity ---", "cycle 1/3", "coder done (1.5s, $0.1200)", "2 issue(s) found", "error: coder crashed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("flushed output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "2 issue(s) found") > strings.Index(out, "error: coder crashed") {
+		t.Error("error printed before its context")
+	}
+}
+
+func TestQuietFlushTriggers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fail func(*Quiet)
+		want string
+	}{
+		{"max cycles", func(q *Quiet) { q.MaxCyclesReached(3) }, "max cycles reached (3)"},
+		{"budget", func(q *Quiet) { q.BudgetExceeded(5.5, 5) }, "budget exceeded ($5.50 / $5.00)"},
+		{"error", func(q *Quiet) { q.Error("boom") }, "error: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			q := newQuiet(&buf, time.Hour, fakeClock(time.Second))
+			q.Info("first")
+			q.Info("reviewer context")
+			tt.fail(q)
+			if out := buf.String(); !strings.Contains(out, "reviewer context") || !strings.Contains(out, tt.want) {
+				t.Errorf("output:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestQuietPeriodicProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	q := newQuiet(&buf, time.Minute, fakeClock(20*time.Second))
+	q.TaskStarted("bead-1", "task")
+	for i := 1; i <= 6; i++ {
+		q.CycleStart(i, 6)
+	}
+	// Events 20s apart over 140s with a 1m interval: progress at 20s, 80s, 140s.
+	if got := strings.Count(buf.String(), "quasar:"); got != 3 {
+		t.Errorf("progress lines = %d, want 3:\n%s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "cycle 6/6") {
+		t.Errorf("latest progress line missing the cycle:\n%s", buf.String())
+	}
+}
+
+func TestQuietKeepsRecentContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	q := newQuiet(&buf, time.Hour, fakeClock(time.Second))
+	for i := 0; i < quietContextLines+10; i++ {
+		q.Info(fmt.Sprintf("event-%03d", i))
+	}
+	buf.Reset()
+	q.Error("failed")
+	out := buf.String()
+	if strings.Contains(out, "event-009 ") || strings.Contains(out, "event-009\n") {
+		t.Error("flush kept an event older than the buffer")
+	}
+	if !strings.Contains(out, "event-010") || !strings.Contains(out, fmt.Sprintf("event-%03d", quietContextLines+9)) {
+		t.Errorf("flush dropped recent events:\n%s", out)
+	}
+}
+
+func TestQuietTaskCompleteDiscardsContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	q := newQuiet(&buf, time.Hour, fakeClock(time.Second))
+	q.TaskStarted("bead-1", "task")
+	q.Info("noise")
+	q.TaskComplete("bead-1", 1.5)
+	q.Error("later failure")
+	if out := buf.String(); strings.Contains(out, "noise") || !strings.Contains(out, "task bead-1 complete ($1.5000)") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestQuietLabeledPrefixesLines(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	q := newQuiet(&buf, time.Hour, fakeClock(time.Second))
+	q.label = "sonnet"
+	q.TaskStarted("bead-1", "task")
+	q.Info("context line")
+	q.Error("boom")
+	q.TaskComplete("bead-1", 0.5)
+	out := buf.String()
+	for _, want := range []string{"quasar[sonnet]: ", "context line", "boom", "quasar[sonnet]: task bead-1 complete"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
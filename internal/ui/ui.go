@@ -45,11 +45,13 @@ type UI interface {
 	Approved()
 	MaxCyclesReached(max int)
 	BudgetExceeded(spent, limit float64)
+	BudgetWarning(spent, limit float64)
 	Error(msg string)
 	Info(msg string)
 	AgentOutput(role string, cycle int, output string)
 	BeadUpdate(taskBeadID, title, status string, children []BeadChild)
-	RefactorApplied(phaseID string)
+	BeadsDegraded(buffered int)
+	RefactorApplied(phaseID, original, updated string)
 	FindingLifecycle(cycle int, summary FindingLifecycleData)
 	HailReceived(h HailInfo)
 	HailResolved(id, resolution string)
@@ -145,6 +147,11 @@ func (p *Printer) BudgetExceeded(spent, limit float64) {
 	fmt.Fprintf(os.Stderr, red+bold+"✗ budget exceeded"+reset+" ($%.2f / $%.2f)\n", spent, limit)
 }
 
+// BudgetWarning prints a non-fatal warning that spending is approaching the budget.
+func (p *Printer) BudgetWarning(spent, limit float64) {
+	fmt.Fprintf(os.Stderr, yellow+bold+"⚠ budget warning"+reset+" ($%.2f / $%.2f) — approaching limit\n", spent, limit)
+}
+
 // Error prints an error message to stderr.
 func (p *Printer) Error(msg string) {
 	fmt.Fprintf(os.Stderr, red+bold+"error: "+reset+"%s\n", msg)
@@ -163,9 +170,19 @@ func (p *Printer) AgentOutput(role string, cycle int, output string) {}
 // displayed in the TUI bead tracker view.
 func (p *Printer) BeadUpdate(taskBeadID, title, status string, children []BeadChild) {}
 
+// BeadsDegraded reports how many bead operations are buffered locally while
+// the beads backend is unavailable. Zero means the buffer was replayed.
+func (p *Printer) BeadsDegraded(buffered int) {
+	if buffered == 0 {
+		fmt.Fprintln(os.Stderr, green+"✓ beads backend recovered"+reset+" — buffered operations replayed")
+		return
+	}
+	fmt.Fprintf(os.Stderr, yellow+bold+"⚠ beads degraded"+reset+" — %d operation(s) buffered locally\n", buffered)
+}
+
 // RefactorApplied is a no-op for the stderr printer; refactor indicators
 // are only displayed in the TUI phase view.
-func (p *Printer) RefactorApplied(phaseID string) {}
+func (p *Printer) RefactorApplied(phaseID, original, updated string) {}
 
 // FindingLifecycle prints the verification summary for a cycle.
 func (p *Printer) FindingLifecycle(cycle int, summary FindingLifecycleData) {
@@ -268,6 +285,7 @@ type CycleSummaryData struct {
 	DurationMs   int64
 	Approved     bool
 	IssueCount   int
+	Confidence   string // reviewer's confidence after a review step; "" if not given
 }
 
 // CycleSummary prints a structured summary after each coder/reviewer phase.
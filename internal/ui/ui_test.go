@@ -462,6 +462,31 @@ func TestNebulaStatus_HistoryFewerThan3(t *testing.T) {
 	}
 }
 
+func TestNebulaStatus_MaxWorkersSuggestion(t *testing.T) {
+	p := New()
+
+	neb := &nebula.Nebula{Manifest: nebula.Manifest{Nebula: nebula.Info{Name: "tuning"}}}
+	state := &nebula.State{Phases: map[string]*nebula.PhaseState{}}
+	m := &nebula.Metrics{
+		MaxWorkers: 8,
+		Waves:      []nebula.WaveMetrics{{EffectiveParallelism: 3, PhaseCount: 3, TotalDuration: time.Minute}},
+	}
+
+	output := captureStderr(func() {
+		p.NebulaStatus(neb, state, m, make([]nebula.HistorySummary, 3))
+	})
+	if !strings.Contains(output, "Suggested max_workers: 4") {
+		t.Errorf("expected max_workers suggestion in output, got:\n%s", output)
+	}
+
+	output = captureStderr(func() {
+		p.NebulaStatus(neb, state, m, nil)
+	})
+	if strings.Contains(output, "Suggested max_workers") {
+		t.Errorf("expected no suggestion without history, got:\n%s", output)
+	}
+}
+
 // --- Lifecycle method tests ---
 
 func TestBanner(t *testing.T) {
@@ -655,6 +680,40 @@ func TestBudgetExceeded(t *testing.T) {
 	}
 }
 
+func TestBudgetWarning(t *testing.T) {
+	p := New()
+	output := captureStderr(func() {
+		p.BudgetWarning(8.00, 10.00)
+	})
+
+	if !strings.Contains(output, "budget warning") {
+		t.Errorf("expected 'budget warning' in output, got: %q", output)
+	}
+	if !strings.Contains(output, "$8.00") {
+		t.Errorf("expected '$8.00' (spent) in output, got: %q", output)
+	}
+	if !strings.Contains(output, "$10.00") {
+		t.Errorf("expected '$10.00' (limit) in output, got: %q", output)
+	}
+}
+
+func TestBeadsDegraded(t *testing.T) {
+	p := New()
+	output := captureStderr(func() {
+		p.BeadsDegraded(4)
+	})
+	if !strings.Contains(output, "beads degraded") || !strings.Contains(output, "4 operation(s)") {
+		t.Errorf("expected degraded warning with count, got %q", output)
+	}
+
+	output = captureStderr(func() {
+		p.BeadsDegraded(0)
+	})
+	if !strings.Contains(output, "recovered") {
+		t.Errorf("expected recovery message, got %q", output)
+	}
+}
+
 func TestError(t *testing.T) {
 	p := New()
 	output := captureStderr(func() {
@@ -709,7 +768,7 @@ func TestBeadUpdate_NoOp(t *testing.T) {
 func TestRefactorApplied_NoOp(t *testing.T) {
 	p := New()
 	output := captureStderr(func() {
-		p.RefactorApplied("phase-1")
+		p.RefactorApplied("phase-1", "old", "new")
 	})
 
 	if len(output) != 0 {
@@ -905,6 +964,7 @@ func TestNebulaPlan(t *testing.T) {
 				{PhaseID: "phase-c", Type: nebula.ActionSkip, Reason: "already done"},
 				{PhaseID: "phase-d", Type: nebula.ActionClose, Reason: "removed from spec"},
 				{PhaseID: "phase-e", Type: nebula.ActionRetry, Reason: "previously failed"},
+				{PhaseID: "phase-f", Type: nebula.ActionRename, Reason: "renamed from phase-old (keeps bead b-1)", From: "phase-old"},
 			},
 		}
 
@@ -919,6 +979,7 @@ func TestNebulaPlan(t *testing.T) {
 			"phase-c", "already done",
 			"phase-d", "removed from spec",
 			"phase-e", "previously failed",
+			"phase-f", "renamed from phase-old",
 		}
 		for _, want := range checks {
 			if !strings.Contains(output, want) {
@@ -958,6 +1019,7 @@ func TestNebulaApplyDone(t *testing.T) {
 			{PhaseID: "p4", Type: nebula.ActionSkip},
 			{PhaseID: "p5", Type: nebula.ActionClose},
 			{PhaseID: "p6", Type: nebula.ActionRetry},
+			{PhaseID: "p7", Type: nebula.ActionRename, From: "p0"},
 		},
 	}
 
@@ -969,6 +1031,7 @@ func TestNebulaApplyDone(t *testing.T) {
 		"apply complete",
 		"created: 2",
 		"updated: 1",
+		"renamed: 1",
 		"retried: 1",
 		"closed: 1",
 		"skipped: 1",
@@ -1013,6 +1076,41 @@ func TestNebulaWorkerResults(t *testing.T) {
 				t.Errorf("expected output to contain %q, got:\n%s", want, output)
 			}
 		}
+		if strings.Contains(output, "blocked") {
+			t.Errorf("no dependents were held back, got:\n%s", output)
+		}
+	})
+
+	t.Run("failure report", func(t *testing.T) {
+		results := []nebula.WorkerResult{
+			{PhaseID: "api", Err: fmt.Errorf("max cycles reached"),
+				Findings: []nebula.DecomposeFinding{{Severity: "major", Description: "handler leaks connections\nsee pool.go", Cycle: 5}},
+				Blocked:  []string{"cli", "ui"},
+			},
+			{PhaseID: "docs", Err: fmt.Errorf("%w: spent", nebula.ErrRetryBudgetExhausted), Skipped: []string{"site"}},
+			{PhaseID: "seed", BeadID: "bead-004"},
+		}
+
+		output := captureStderr(func() {
+			p.NebulaWorkerResults(results)
+		})
+
+		checks := []string{
+			"failures (2):",
+			"reviewer's last findings (cycle 5):",
+			"[major] handler leaks connections",
+			"blocked 2 dependents: cli, ui",
+			"skipped 1 dependent: site",
+			"retry api —",
+		}
+		for _, want := range checks {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "see pool.go") || strings.Contains(output, "retry api, docs") {
+			t.Errorf("expected one-line findings and no retry of docs, got:\n%s", output)
+		}
 	})
 }
 
@@ -1091,7 +1189,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		checks := []string{
@@ -1108,6 +1206,27 @@ func TestNebulaShow(t *testing.T) {
 		}
 	})
 
+	t.Run("with params", func(t *testing.T) {
+		neb := &nebula.Nebula{
+			Manifest: nebula.Manifest{Nebula: nebula.Info{Name: "params-test"}},
+			Phases: []nebula.PhaseSpec{
+				{ID: "p1", Title: "Release", Params: map[string]string{"target": "staging"}, Body: "Ship ${params.version}."},
+				{ID: "p2", Title: "Notify"},
+			},
+		}
+		state := &nebula.State{Phases: map[string]*nebula.PhaseState{}}
+
+		output := captureStderr(func() {
+			p.NebulaShow(neb, state, map[string]string{"target": "prod"})
+		})
+		if !strings.Contains(output, "params: target=prod version=<missing>") {
+			t.Errorf("expected resolved params for p1, got:\n%s", output)
+		}
+		if strings.Count(output, "params:") != 1 {
+			t.Errorf("expected no params line for p2, got:\n%s", output)
+		}
+	})
+
 	t.Run("with execution config", func(t *testing.T) {
 		neb := &nebula.Nebula{
 			Manifest: nebula.Manifest{
@@ -1126,7 +1245,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		checks := []string{
@@ -1161,7 +1280,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		checks := []string{
@@ -1197,7 +1316,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		checks := []string{
@@ -1234,7 +1353,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		checks := []string{
@@ -1261,7 +1380,7 @@ func TestNebulaShow(t *testing.T) {
 		}
 
 		output := captureStderr(func() {
-			p.NebulaShow(neb, state)
+			p.NebulaShow(neb, state, nil)
 		})
 
 		if !strings.Contains(output, "pending") {
@@ -1397,3 +1516,66 @@ func TestNebulaStatus_WaveScopeSerialization(t *testing.T) {
 		t.Errorf("expected '(scope serialization)' note in output, got:\n%s", output)
 	}
 }
+
+func TestNebulaStatus_EstimatesVsActual(t *testing.T) {
+	p := New()
+
+	neb := &nebula.Nebula{
+		Manifest: nebula.Manifest{Nebula: nebula.Info{Name: "estimates"}},
+		Phases:   []nebula.PhaseSpec{{ID: "hinted", EstimatedMinutes: 10}, {ID: "unhinted"}},
+	}
+	state := &nebula.State{Phases: map[string]*nebula.PhaseState{}}
+	m := &nebula.Metrics{
+		Phases: []nebula.PhaseMetrics{
+			{PhaseID: "hinted", Duration: 15 * time.Minute},
+			{PhaseID: "unhinted", Duration: 3 * time.Minute},
+		},
+	}
+
+	output := captureStderr(func() {
+		p.NebulaStatus(neb, state, m, nil)
+	})
+
+	if !strings.Contains(output, "Estimates vs actual") {
+		t.Fatalf("expected estimates section in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "est 10m00s  actual 15m00s  (+50%)") {
+		t.Errorf("expected hinted phase comparison in output, got:\n%s", output)
+	}
+	if strings.Count(output, "unhinted") != 1 {
+		t.Errorf("unhinted phase should only appear under slowest phases, got:\n%s", output)
+	}
+}
+
+func TestNebulaPhaseLint(t *testing.T) {
+	p := New()
+
+	report := &nebula.PhaseLintReport{
+		Results: []nebula.PhaseLintResult{
+			{PhaseID: "clear", Score: 5, Cached: true},
+			{PhaseID: "vague", Score: 2, Suggestions: []string{"name the target file"}},
+			{PhaseID: "late", Skipped: true},
+		},
+		CostUSD: 0.0123,
+	}
+
+	output := captureStderr(func() {
+		p.NebulaPhaseLint("lint-test", report)
+	})
+
+	for _, want := range []string{
+		"phase lint: lint-test",
+		"5/5",
+		"likely to need extra review cycles",
+		"name the target file",
+		"skipped (budget exhausted)",
+		"1 of 3 phases flagged",
+		"$0.0123 spent",
+		"1 cached",
+		"1 skipped",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}